@@ -0,0 +1,88 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/checkmarble/marble-backend/dto"
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/pure_utils"
+	"github.com/checkmarble/marble-backend/usecases"
+	"github.com/checkmarble/marble-backend/utils"
+	"github.com/gin-gonic/gin"
+)
+
+func handlePostCaseBulkAction(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		organizationId, err := utils.OrganizationIdFromRequest(c.Request)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		var data dto.CreateCaseBulkActionBody
+		if err := c.ShouldBindJSON(&data); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+		var filters *dto.CaseFilters
+		if data.Filters != nil {
+			filters = utils.Ptr(dto.AdaptCaseFiltersFromView(*data.Filters))
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewCaseBulkActionUsecase()
+		bulkAction, err := usecase.CreateCaseBulkAction(ctx, organizationId,
+			models.CaseBulkActionType(data.Action), dto.AdaptCaseBulkActionParams(data.Params),
+			data.CaseIds, filters)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusAccepted, gin.H{"bulk_action": dto.AdaptCaseBulkActionDto(bulkAction)})
+	}
+}
+
+func handleListCaseBulkActions(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		organizationId, err := utils.OrganizationIdFromRequest(c.Request)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewCaseBulkActionUsecase()
+		bulkActions, err := usecase.ListCaseBulkActions(ctx, organizationId)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"bulk_actions": pure_utils.Map(bulkActions, dto.AdaptCaseBulkActionDto)})
+	}
+}
+
+type CaseBulkActionUriInput struct {
+	BulkActionId string `uri:"bulk_action_id" binding:"required,uuid"`
+}
+
+func handleGetCaseBulkAction(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		organizationId, err := utils.OrganizationIdFromRequest(c.Request)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		var input CaseBulkActionUriInput
+		if err := c.ShouldBindUri(&input); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewCaseBulkActionUsecase()
+		bulkAction, err := usecase.GetCaseBulkAction(ctx, organizationId, input.BulkActionId)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"bulk_action": dto.AdaptCaseBulkActionWithItemsDto(bulkAction)})
+	}
+}
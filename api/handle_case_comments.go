@@ -0,0 +1,121 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/checkmarble/marble-backend/dto"
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/pure_utils"
+	"github.com/checkmarble/marble-backend/usecases"
+	"github.com/checkmarble/marble-backend/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type CaseCommentUriInput struct {
+	CaseId    string `uri:"case_id" binding:"required,uuid"`
+	CommentId string `uri:"comment_id" binding:"required,uuid"`
+}
+
+func handleListCaseComments(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		var caseInput CaseInput
+		if err := c.ShouldBindUri(&caseInput); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewCaseUseCase()
+		comments, err := usecase.ListCaseComments(ctx, caseInput.Id)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"comments": pure_utils.Map(comments, dto.AdaptCaseCommentDto),
+		})
+	}
+}
+
+func handlePatchCaseComment(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		creds, found := utils.CredentialsFromCtx(ctx)
+		if !found {
+			presentError(ctx, c, fmt.Errorf("no credentials in context"))
+			return
+		}
+
+		var input CaseCommentUriInput
+		if err := c.ShouldBindUri(&input); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+		var data dto.UpdateCaseCommentBody
+		if err := c.ShouldBindJSON(&data); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewCaseUseCase()
+		comment, err := usecase.UpdateCaseComment(ctx, string(creds.ActorIdentity.UserId),
+			models.UpdateCaseCommentAttributes{
+				CaseId:           input.CaseId,
+				CommentId:        input.CommentId,
+				Body:             data.Comment,
+				MentionedUserIds: data.MentionedUserIds,
+			})
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"comment": dto.AdaptCaseCommentDto(comment)})
+	}
+}
+
+func handleDeleteCaseComment(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		creds, found := utils.CredentialsFromCtx(ctx)
+		if !found {
+			presentError(ctx, c, fmt.Errorf("no credentials in context"))
+			return
+		}
+
+		var input CaseCommentUriInput
+		if err := c.ShouldBindUri(&input); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewCaseUseCase()
+		err := usecase.DeleteCaseComment(ctx, string(creds.ActorIdentity.UserId), input.CaseId, input.CommentId)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
+
+func handleListCaseCommentVersions(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		var input CaseCommentUriInput
+		if err := c.ShouldBindUri(&input); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewCaseUseCase()
+		versions, err := usecase.ListCaseCommentVersions(ctx, input.CaseId, input.CommentId)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"versions": pure_utils.Map(versions, dto.AdaptCaseCommentVersionDto),
+		})
+	}
+}
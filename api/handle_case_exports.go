@@ -0,0 +1,74 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/checkmarble/marble-backend/dto"
+	"github.com/checkmarble/marble-backend/pure_utils"
+	"github.com/checkmarble/marble-backend/usecases"
+	"github.com/gin-gonic/gin"
+)
+
+type CaseExportUriInput struct {
+	CaseId   string `uri:"case_id" binding:"required,uuid"`
+	ExportId string `uri:"export_id" binding:"required,uuid"`
+}
+
+func handlePostCaseExport(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		var caseInput CaseInput
+		if err := c.ShouldBindUri(&caseInput); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewCaseExportUsecase()
+		export, err := usecase.CreateCaseExport(ctx, caseInput.Id)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusAccepted, gin.H{"case_export": dto.AdaptCaseExportDto(export)})
+	}
+}
+
+func handleListCaseExports(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		var caseInput CaseInput
+		if err := c.ShouldBindUri(&caseInput); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewCaseExportUsecase()
+		exports, err := usecase.ListCaseExports(ctx, caseInput.Id)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"case_exports": pure_utils.Map(exports, dto.AdaptCaseExportDto),
+		})
+	}
+}
+
+func handleGetCaseExport(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		var input CaseExportUriInput
+		if err := c.ShouldBindUri(&input); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewCaseExportUsecase()
+		export, err := usecase.GetCaseExport(ctx, input.CaseId, input.ExportId)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"case_export": dto.AdaptCaseExportWithUrlDto(export)})
+	}
+}
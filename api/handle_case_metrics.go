@@ -0,0 +1,70 @@
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/checkmarble/marble-backend/dto"
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/usecases"
+	"github.com/checkmarble/marble-backend/utils"
+	"github.com/gin-gonic/gin"
+)
+
+func handleGetCaseMetrics(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		organizationId, err := utils.OrganizationIdFromRequest(c.Request)
+		if presentError(ctx, c, err) {
+			return
+		}
+		var query dto.CaseMetricsQuery
+		if err := c.ShouldBindQuery(&query); err != nil {
+			c.JSON(http.StatusBadRequest, dto.APIErrorResponse{Message: err.Error()})
+			return
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewCaseMetricsUsecase()
+		metrics, err := usecase.GetCaseMetrics(ctx, dto.AdaptCaseMetricsFilters(organizationId, query))
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"case_metrics": dto.AdaptCaseMetricsDto(metrics)})
+	}
+}
+
+func handleExportCaseMetric(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		organizationId, err := utils.OrganizationIdFromRequest(c.Request)
+		if presentError(ctx, c, err) {
+			return
+		}
+		var query dto.CaseMetricsExportQuery
+		if err := c.ShouldBindQuery(&query); err != nil {
+			c.JSON(http.StatusBadRequest, dto.APIErrorResponse{Message: err.Error()})
+			return
+		}
+		metric, err := models.CaseMetricTypeFrom(query.Metric)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		// the metric is computed before anything is written, so that errors can be presented as json
+		var csv bytes.Buffer
+		usecase := usecasesWithCreds(ctx, uc).NewCaseMetricsUsecase()
+		err = usecase.ExportCaseMetric(ctx, &csv,
+			dto.AdaptCaseMetricsFilters(organizationId, query.CaseMetricsQuery), metric)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.Header("Access-Control-Expose-Headers", "Content-Disposition")
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=case_metrics_%s_%s_%s.csv", metric,
+			query.StartDate.Format(time.DateOnly), query.EndDate.Format(time.DateOnly)))
+		c.Data(http.StatusOK, "text/csv", csv.Bytes())
+	}
+}
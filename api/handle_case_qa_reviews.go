@@ -0,0 +1,98 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/checkmarble/marble-backend/dto"
+	"github.com/checkmarble/marble-backend/pure_utils"
+	"github.com/checkmarble/marble-backend/usecases"
+	"github.com/checkmarble/marble-backend/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type CaseQaReviewUriInput struct {
+	ReviewId string `uri:"review_id" binding:"required,uuid"`
+}
+
+func handleListCaseQaReviews(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		creds, _ := utils.CredentialsFromCtx(ctx)
+		var query dto.CaseQaReviewListQuery
+		if err := c.ShouldBindQuery(&query); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewCaseQaReviewUsecase()
+		reviews, err := usecase.ListCaseQaReviews(ctx,
+			dto.AdaptCaseQaReviewFilters(query, creds.ActorIdentity.UserId))
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"qa_reviews": pure_utils.Map(reviews, dto.AdaptCaseQaReviewDto)})
+	}
+}
+
+func handleGetCaseQaReview(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		var uri CaseQaReviewUriInput
+		if err := c.ShouldBindUri(&uri); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewCaseQaReviewUsecase()
+		review, err := usecase.GetCaseQaReview(ctx, uri.ReviewId)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"qa_review": dto.AdaptCaseQaReviewDto(review)})
+	}
+}
+
+func handleCompleteCaseQaReview(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		var uri CaseQaReviewUriInput
+		if err := c.ShouldBindUri(&uri); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+		var data dto.CompleteCaseQaReviewBody
+		if err := c.ShouldBindJSON(&data); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewCaseQaReviewUsecase()
+		review, err := usecase.CompleteCaseQaReview(ctx, uri.ReviewId, dto.AdaptCaseQaReviewCompletion(data))
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"qa_review": dto.AdaptCaseQaReviewDto(review)})
+	}
+}
+
+func handleListCaseQaScores(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		var query dto.CaseQaScoreQuery
+		if err := c.ShouldBindQuery(&query); err != nil {
+			c.JSON(http.StatusBadRequest, dto.APIErrorResponse{Message: err.Error()})
+			return
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewCaseQaReviewUsecase()
+		scores, err := usecase.ListCaseQaScores(ctx, dto.AdaptCaseQaScoreFilters(query))
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"qa_scores": pure_utils.Map(scores, dto.AdaptCaseQaScoreDto)})
+	}
+}
@@ -0,0 +1,95 @@
+package api
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/cockroachdb/errors"
+	"github.com/gin-gonic/gin"
+
+	"github.com/checkmarble/marble-backend/dto"
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/pure_utils"
+	"github.com/checkmarble/marble-backend/usecases"
+)
+
+func handleListCaseRoutingRules(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		scenarioId := c.Param("scenario_id")
+
+		usecase := usecasesWithCreds(ctx, uc).NewScenarioUsecase()
+		rules, err := usecase.ListCaseRoutingRules(ctx, scenarioId)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		rulesDto, err := pure_utils.MapErr(rules, dto.AdaptCaseRoutingRuleDto)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"case_routing_rules": rulesDto})
+	}
+}
+
+func handleUpdateCaseRoutingRules(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		scenarioId := c.Param("scenario_id")
+
+		var data dto.UpdateCaseRoutingRulesBody
+		if err := c.ShouldBindJSON(&data); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+		rules, err := pure_utils.MapErr(data.Rules, dto.AdaptCaseRoutingRuleInput)
+		if err != nil {
+			presentError(ctx, c, errors.Wrap(models.BadParameterError, err.Error()))
+			return
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewScenarioUsecase()
+		updatedRules, err := usecase.UpdateCaseRoutingRules(ctx, scenarioId, rules)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		rulesDto, err := pure_utils.MapErr(updatedRules, dto.AdaptCaseRoutingRuleDto)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"case_routing_rules": rulesDto})
+	}
+}
+
+func handleValidateCaseRoutingRuleAst(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		var input PostScenarioAstValidationInputBody
+		err := c.ShouldBindJSON(&input)
+		if err != nil && err != io.EOF { //nolint:errorlint
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		scenarioId := c.Param("scenario_id")
+
+		astNode, err := dto.AdaptASTNode(input.Node)
+		if err != nil {
+			presentError(ctx, c, errors.Wrap(models.BadParameterError, err.Error()))
+			return
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewScenarioUsecase()
+		astValidation, err := usecase.ValidateCaseRoutingRuleAst(ctx, scenarioId, &astNode)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"ast_validation": dto.AdaptAstValidationDto(astValidation),
+		})
+	}
+}
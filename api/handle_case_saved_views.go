@@ -0,0 +1,118 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/checkmarble/marble-backend/dto"
+	"github.com/checkmarble/marble-backend/pure_utils"
+	"github.com/checkmarble/marble-backend/usecases"
+	"github.com/checkmarble/marble-backend/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type CaseSavedViewUriInput struct {
+	ViewId string `uri:"view_id" binding:"required,uuid"`
+}
+
+func handleListCaseSavedViews(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		creds, found := utils.CredentialsFromCtx(ctx)
+		if !found {
+			presentError(ctx, c, fmt.Errorf("no credentials in context"))
+			return
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewCaseUseCase()
+		views, err := usecase.ListCaseSavedViews(ctx, creds.OrganizationId, string(creds.ActorIdentity.UserId))
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"views": pure_utils.Map(views, dto.AdaptCaseSavedViewDto)})
+	}
+}
+
+func handlePostCaseSavedView(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		creds, found := utils.CredentialsFromCtx(ctx)
+		if !found {
+			presentError(ctx, c, fmt.Errorf("no credentials in context"))
+			return
+		}
+
+		var data dto.CreateCaseSavedViewBody
+		if err := c.ShouldBindJSON(&data); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewCaseUseCase()
+		view, err := usecase.CreateCaseSavedView(ctx, dto.AdaptCaseSavedViewInput(
+			creds.OrganizationId, string(creds.ActorIdentity.UserId), data))
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusCreated, gin.H{"view": dto.AdaptCaseSavedViewDto(view)})
+	}
+}
+
+func handlePatchCaseSavedView(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		creds, found := utils.CredentialsFromCtx(ctx)
+		if !found {
+			presentError(ctx, c, fmt.Errorf("no credentials in context"))
+			return
+		}
+
+		var input CaseSavedViewUriInput
+		if err := c.ShouldBindUri(&input); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+		var data dto.UpdateCaseSavedViewBody
+		if err := c.ShouldBindJSON(&data); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewCaseUseCase()
+		view, err := usecase.UpdateCaseSavedView(ctx, creds.OrganizationId,
+			string(creds.ActorIdentity.UserId), input.ViewId, dto.AdaptUpdateCaseSavedViewInput(data))
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"view": dto.AdaptCaseSavedViewDto(view)})
+	}
+}
+
+func handleDeleteCaseSavedView(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		creds, found := utils.CredentialsFromCtx(ctx)
+		if !found {
+			presentError(ctx, c, fmt.Errorf("no credentials in context"))
+			return
+		}
+
+		var input CaseSavedViewUriInput
+		if err := c.ShouldBindUri(&input); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewCaseUseCase()
+		err := usecase.DeleteCaseSavedView(ctx, creds.OrganizationId,
+			string(creds.ActorIdentity.UserId), input.ViewId)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
@@ -153,6 +153,7 @@ func handlePatchCase(uc usecases.Usecases) func(c *gin.Context) {
 			Status:  models.CaseStatus(data.Status),
 			Outcome: models.CaseOutcome(data.Outcome),
 			InboxId: data.InboxId,
+			Comment: data.Comment,
 		})
 
 		if presentError(ctx, c, err) {
@@ -339,8 +340,10 @@ func handlePostCaseComment(uc usecases.Usecases) func(c *gin.Context) {
 
 		usecase := usecasesWithCreds(ctx, uc).NewCaseUseCase()
 		inboxCase, err := usecase.CreateCaseComment(ctx, userId, models.CreateCaseCommentAttributes{
-			Id:      caseInput.Id,
-			Comment: data.Comment,
+			Id:               caseInput.Id,
+			Comment:          data.Comment,
+			ParentId:         data.ParentId,
+			MentionedUserIds: data.MentionedUserIds,
 		})
 
 		if presentError(ctx, c, err) {
@@ -597,6 +600,72 @@ func handleEscalateCase(uc usecases.Usecases) func(c *gin.Context) {
 	}
 }
 
+func handleMergeCase(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		creds, found := utils.CredentialsFromCtx(ctx)
+		if !found {
+			presentError(ctx, c, fmt.Errorf("no credentials in context"))
+			return
+		}
+		userId := string(creds.ActorIdentity.UserId)
+
+		var caseInput CaseInput
+		if err := c.ShouldBindUri(&caseInput); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		var data dto.MergeCaseBody
+		if err := c.ShouldBindJSON(&data); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewCaseUseCase()
+		targetCase, err := usecase.MergeCases(ctx, userId, caseInput.Id, data.TargetCaseId)
+		if presentError(ctx, c, err) {
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"case": dto.AdaptCaseWithDecisionsDto(targetCase)})
+	}
+}
+
+func handleSplitCase(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		creds, found := utils.CredentialsFromCtx(ctx)
+		if !found {
+			presentError(ctx, c, fmt.Errorf("no credentials in context"))
+			return
+		}
+		userId := string(creds.ActorIdentity.UserId)
+
+		var caseInput CaseInput
+		if err := c.ShouldBindUri(&caseInput); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		var data dto.SplitCaseBody
+		if err := c.ShouldBindJSON(&data); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewCaseUseCase()
+		newCase, err := usecase.SplitCase(ctx, userId, models.SplitCaseInput{
+			CaseId:      caseInput.Id,
+			DecisionIds: data.DecisionIds,
+			Name:        data.Name,
+		})
+		if presentError(ctx, c, err) {
+			return
+		}
+		c.JSON(http.StatusCreated, gin.H{"case": dto.AdaptCaseWithDecisionsDto(newCase)})
+	}
+}
+
 func handleGetNextCase(uc usecases.Usecases) func(c *gin.Context) {
 	return func(c *gin.Context) {
 		ctx := c.Request.Context()
@@ -0,0 +1,76 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/checkmarble/marble-backend/dto"
+	"github.com/checkmarble/marble-backend/usecases"
+	"github.com/checkmarble/marble-backend/utils"
+	"github.com/gin-gonic/gin"
+)
+
+func handleReadChangeFeed(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		organizationId, err := utils.OrganizationIdFromRequest(c.Request)
+		if presentError(ctx, c, err) {
+			return
+		}
+		var query dto.ChangeFeedQuery
+		if err := c.ShouldBindQuery(&query); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewChangeFeedUsecase()
+		page, err := usecase.ReadChangeFeed(ctx, organizationId, query.Cursor, query.Limit,
+			time.Duration(query.WaitSeconds)*time.Second)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusOK, dto.AdaptChangeFeedPageDto(page))
+	}
+}
+
+func handleGetChangeFeedConfig(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		organizationId, err := utils.OrganizationIdFromRequest(c.Request)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewChangeFeedUsecase()
+		config, err := usecase.GetChangeFeedConfig(ctx, organizationId)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"change_feed_config": dto.AdaptChangeFeedConfigDto(config)})
+	}
+}
+
+func handleUpdateChangeFeedConfig(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		organizationId, err := utils.OrganizationIdFromRequest(c.Request)
+		if presentError(ctx, c, err) {
+			return
+		}
+		var data dto.UpdateChangeFeedConfigBody
+		if err := c.ShouldBindJSON(&data); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewChangeFeedUsecase()
+		config, err := usecase.UpdateChangeFeedConfig(ctx, dto.AdaptUpdateChangeFeedConfigInput(organizationId, data))
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"change_feed_config": dto.AdaptChangeFeedConfigDto(config)})
+	}
+}
@@ -2,6 +2,7 @@ package api
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/checkmarble/marble-backend/dto"
 	"github.com/checkmarble/marble-backend/models"
@@ -23,8 +24,18 @@ func handleGetIngestedObject(uc usecases.Usecases) func(c *gin.Context) {
 		objectType := c.Param("object_type")
 		objectId := c.Param("object_id")
 
+		var params dto.ClientDataReadParams
+		if err := c.ShouldBindQuery(&params); err != nil {
+			presentError(ctx, c, errors.Wrap(models.BadParameterError, err.Error()))
+			return
+		}
+		var asOf *time.Time
+		if !params.AsOf.IsZero() {
+			asOf = &params.AsOf
+		}
+
 		usecase := usecasesWithCreds(ctx, uc).NewIngestedDataReaderUsecase()
-		objects, err := usecase.GetIngestedObject(ctx, organizationID, nil, objectType, objectId, "object_id")
+		objects, err := usecase.GetIngestedObject(ctx, organizationID, nil, objectType, objectId, "object_id", asOf)
 		if presentError(ctx, c, err) {
 			return
 		}
@@ -38,6 +49,32 @@ func handleGetIngestedObject(uc usecases.Usecases) func(c *gin.Context) {
 	}
 }
 
+func handleGetIngestedObjectHistory(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		organizationID, err := utils.OrganizationIdFromRequest(c.Request)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		objectType := c.Param("object_type")
+		objectId := c.Param("object_id")
+
+		usecase := usecasesWithCreds(ctx, uc).NewIngestedDataReaderUsecase()
+		versions, err := usecase.GetIngestedObjectHistory(ctx, organizationID, objectType, objectId)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		if len(versions) == 0 {
+			c.JSON(http.StatusNotFound, nil)
+			return
+		}
+
+		c.JSON(http.StatusOK, pure_utils.Map(versions, dto.AdaptClientObjectVersionDto))
+	}
+}
+
 func handleReadClientDataAsList(uc usecases.Usecases) func(c *gin.Context) {
 	return func(c *gin.Context) {
 		ctx := c.Request.Context()
@@ -71,8 +71,14 @@ func handleUpdateDataModelTable(uc usecases.Usecases) func(c *gin.Context) {
 		}
 		tableID := c.Param("tableID")
 
+		var decisionTriggerMode *models.DecisionTriggerMode
+		if input.DecisionTriggerMode != nil {
+			mode := models.DecisionTriggerMode(*input.DecisionTriggerMode)
+			decisionTriggerMode = &mode
+		}
+
 		usecase := usecasesWithCreds(ctx, uc).NewDataModelUseCase()
-		err := usecase.UpdateDataModelTable(ctx, tableID, input.Description)
+		err := usecase.UpdateDataModelTable(ctx, tableID, input.Description, decisionTriggerMode)
 		if presentError(ctx, c, err) {
 			return
 		}
@@ -98,6 +104,7 @@ func handleCreateField(uc usecases.Usecases) func(c *gin.Context) {
 			Nullable:    input.Nullable,
 			IsEnum:      input.IsEnum,
 			IsUnique:    input.IsUnique,
+			Constraints: dto.AdaptFieldConstraintsInput(input.Constraints),
 		}
 
 		usecase := usecasesWithCreds(ctx, uc).NewDataModelUseCase()
@@ -122,11 +129,15 @@ func handleUpdateDataModelField(uc usecases.Usecases) func(c *gin.Context) {
 		fieldID := c.Param("fieldID")
 
 		usecase := usecasesWithCreds(ctx, uc).NewDataModelUseCase()
-		err := usecase.UpdateDataModelField(ctx, fieldID, models.UpdateFieldInput{
+		updateInput := models.UpdateFieldInput{
 			Description: input.Description,
 			IsEnum:      input.IsEnum,
 			IsUnique:    input.IsUnique,
-		})
+		}
+		if input.Constraints != nil {
+			updateInput.Constraints = utils.Ptr(dto.AdaptFieldConstraintsInput(*input.Constraints))
+		}
+		err := usecase.UpdateDataModelField(ctx, fieldID, updateInput)
 		if presentError(ctx, c, err) {
 			return
 		}
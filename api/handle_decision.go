@@ -12,6 +12,9 @@ import (
 	"github.com/checkmarble/marble-backend/utils"
 )
 
+// idempotencyKeyHeader lets clients safely retry decision creation requests, see models.DecisionIdempotencyKey
+const idempotencyKeyHeader = "Idempotency-Key"
+
 var decisionPaginationDefaults = models.PaginationDefaults{
 	Limit:  25,
 	SortBy: models.DecisionSortingCreatedAt,
@@ -131,6 +134,8 @@ func handlePostDecision(uc usecases.Usecases, marbleAppUrl *url.URL) func(c *gin
 				PayloadRaw:         requestData.TriggerObject,
 				ScenarioId:         requestData.ScenarioId,
 				TriggerObjectTable: requestData.ObjectType,
+				ReadAsOf:           requestData.AsOf,
+				IdempotencyKey:     c.GetHeader(idempotencyKeyHeader),
 			},
 			models.CreateDecisionParams{
 				WithScenarioPermissionCheck: true,
@@ -175,6 +180,8 @@ func handlePostAllDecisions(uc usecases.Usecases, marbleAppUrl *url.URL) func(c
 				OrganizationId:     organizationId,
 				PayloadRaw:         requestData.TriggerObject,
 				TriggerObjectTable: requestData.ObjectType,
+				ReadAsOf:           requestData.AsOf,
+				IdempotencyKey:     c.GetHeader(idempotencyKeyHeader),
 			},
 		)
 		if presentIngestionValidationError(c, err) || presentError(ctx, c, err) {
@@ -0,0 +1,79 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/checkmarble/marble-backend/dto"
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/pure_utils"
+	"github.com/checkmarble/marble-backend/usecases"
+	"github.com/checkmarble/marble-backend/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type DecisionExportUriInput struct {
+	ExportId string `uri:"export_id" binding:"required,uuid"`
+}
+
+func handlePostDecisionExport(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		organizationId, err := utils.OrganizationIdFromRequest(c.Request)
+		if presentError(ctx, c, err) {
+			return
+		}
+		var data dto.CreateDecisionExportBody
+		if err := c.ShouldBindJSON(&data); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewDecisionExportUsecase()
+		export, err := usecase.CreateDecisionExport(ctx, organizationId,
+			models.DecisionExportFormatFrom(data.Format), data.Filters.ToDecisionFilters())
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusAccepted, gin.H{"decision_export": dto.AdaptDecisionExportDto(export)})
+	}
+}
+
+func handleListDecisionExports(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		organizationId, err := utils.OrganizationIdFromRequest(c.Request)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewDecisionExportUsecase()
+		exports, err := usecase.ListDecisionExports(ctx, organizationId)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"decision_exports": pure_utils.Map(exports, dto.AdaptDecisionExportDto),
+		})
+	}
+}
+
+func handleGetDecisionExport(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		var input DecisionExportUriInput
+		if err := c.ShouldBindUri(&input); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewDecisionExportUsecase()
+		export, err := usecase.GetDecisionExport(ctx, input.ExportId)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"decision_export": dto.AdaptDecisionExportWithFilesDto(export)})
+	}
+}
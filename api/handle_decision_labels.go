@@ -0,0 +1,123 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/checkmarble/marble-backend/dto"
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/pure_utils"
+	"github.com/checkmarble/marble-backend/usecases"
+	"github.com/checkmarble/marble-backend/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type DecisionLabelUriInput struct {
+	LabelId string `uri:"label_id" binding:"required,uuid"`
+}
+
+func handlePostDecisionLabel(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		organizationId, err := utils.OrganizationIdFromRequest(c.Request)
+		if presentError(ctx, c, err) {
+			return
+		}
+		var data dto.CreateDecisionLabelBody
+		if err := c.ShouldBindJSON(&data); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewDecisionLabelUsecase()
+		labels, err := usecase.CreateDecisionLabels(ctx, organizationId,
+			[]models.CreateDecisionLabelInput{dto.AdaptCreateDecisionLabelInput(data)})
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusCreated, gin.H{"label": dto.AdaptDecisionLabelDto(labels[0])})
+	}
+}
+
+func handlePostDecisionLabelsBatch(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		organizationId, err := utils.OrganizationIdFromRequest(c.Request)
+		if presentError(ctx, c, err) {
+			return
+		}
+		var data dto.CreateDecisionLabelsBody
+		if err := c.ShouldBindJSON(&data); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewDecisionLabelUsecase()
+		labels, err := usecase.CreateDecisionLabels(ctx, organizationId,
+			pure_utils.Map(data.Labels, dto.AdaptCreateDecisionLabelInput))
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusCreated, gin.H{"labels": pure_utils.Map(labels, dto.AdaptDecisionLabelDto)})
+	}
+}
+
+func handleDeleteDecisionLabel(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		var uri DecisionLabelUriInput
+		if err := c.ShouldBindUri(&uri); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewDecisionLabelUsecase()
+		if presentError(ctx, c, usecase.DeleteDecisionLabel(ctx, uri.LabelId)) {
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
+
+func handleExportLabelledDecisions(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		organizationId, err := utils.OrganizationIdFromRequest(c.Request)
+		if presentError(ctx, c, err) {
+			return
+		}
+		var query dto.DecisionLabelExportQuery
+		if err := c.ShouldBindQuery(&query); err != nil {
+			c.JSON(http.StatusBadRequest, dto.APIErrorResponse{Message: err.Error()})
+			return
+		}
+
+		c.Header("Access-Control-Expose-Headers", "Content-Disposition")
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=labelled_decisions_%s_%s.csv",
+			query.StartDate.Format(time.DateOnly), query.EndDate.Format(time.DateOnly)))
+		c.Header("Content-Type", "text/csv")
+
+		usecase := usecasesWithCreds(ctx, uc).NewDecisionLabelUsecase()
+		err = usecase.ExportLabelledDecisions(ctx, c.Writer, models.DecisionLabelExportFilters{
+			OrganizationId:    organizationId,
+			ScenarioId:        query.ScenarioId,
+			StartDate:         query.StartDate,
+			EndDate:           query.EndDate,
+			IncludeUnlabelled: query.IncludeUnlabelled,
+		})
+		if err != nil && !c.Writer.Written() {
+			// nothing was streamed yet, the error can still be presented as json
+			c.Header("Content-Disposition", "")
+			c.Header("Content-Type", "")
+			presentError(ctx, c, err)
+			return
+		} else if err != nil {
+			utils.LogAndReportSentryError(ctx, err)
+			return
+		}
+	}
+}
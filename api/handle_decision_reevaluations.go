@@ -0,0 +1,102 @@
+package api
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/checkmarble/marble-backend/dto"
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/pure_utils"
+	"github.com/checkmarble/marble-backend/usecases"
+	"github.com/checkmarble/marble-backend/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type DecisionReevaluationBatchUriInput struct {
+	BatchId string `uri:"batch_id" binding:"required,uuid"`
+}
+
+func handleReevaluateDecision(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		decisionId := c.Param("decision_id")
+
+		// the body is optional, the live iteration of the scenario is used by default
+		var data dto.ReevaluateDecisionBody
+		if err := c.ShouldBindJSON(&data); err != nil && err != io.EOF { //nolint:errorlint
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewDecisionUsecase()
+		reevaluation, err := usecase.ReevaluateDecision(ctx, models.ReevaluateDecisionInput{
+			DecisionId:          decisionId,
+			ScenarioIterationId: data.ScenarioIterationId,
+		})
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"reevaluation": dto.AdaptDecisionReevaluationDto(reevaluation)})
+	}
+}
+
+func handleListDecisionReevaluations(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		decisionId := c.Param("decision_id")
+
+		usecase := usecasesWithCreds(ctx, uc).NewDecisionUsecase()
+		reevaluations, err := usecase.ListDecisionReevaluations(ctx, decisionId)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"reevaluations": pure_utils.Map(reevaluations, dto.AdaptDecisionReevaluationDto),
+		})
+	}
+}
+
+func handlePostDecisionReevaluationBatch(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		organizationId, err := utils.OrganizationIdFromRequest(c.Request)
+		if presentError(ctx, c, err) {
+			return
+		}
+		var data dto.CreateDecisionReevaluationBatchBody
+		if err := c.ShouldBindJSON(&data); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewDecisionUsecase()
+		batch, err := usecase.CreateDecisionReevaluationBatch(ctx, organizationId,
+			data.ScenarioIterationId, data.Filters.ToDecisionFilters())
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusAccepted, gin.H{"batch": dto.AdaptDecisionReevaluationBatchDto(batch)})
+	}
+}
+
+func handleGetDecisionReevaluationBatch(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		var uri DecisionReevaluationBatchUriInput
+		if err := c.ShouldBindUri(&uri); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewDecisionUsecase()
+		batch, err := usecase.GetDecisionReevaluationBatch(ctx, uri.BatchId)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"batch": dto.AdaptDecisionReevaluationBatchDto(batch)})
+	}
+}
@@ -0,0 +1,62 @@
+package api
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/checkmarble/marble-backend/dto"
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/usecases"
+	"github.com/checkmarble/marble-backend/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type DecisionRequestUriInput struct {
+	RequestId string `uri:"request_id" binding:"required,uuid"`
+}
+
+func handlePostDecisionRequest(uc usecases.Usecases, marbleAppUrl *url.URL) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		organizationId, err := utils.OrganizationIdFromRequest(c.Request)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		var requestData dto.CreateDecisionRequestBody
+		if err := c.ShouldBindJSON(&requestData); err != nil {
+			c.JSON(http.StatusBadRequest, dto.APIErrorResponse{
+				Message: err.Error(),
+			})
+			return
+		}
+
+		decisionUsecase := usecasesWithCreds(ctx, uc).NewDecisionUsecase()
+		request, err := decisionUsecase.CreateDecisionRequest(ctx, requestData.ToInput(organizationId))
+		if presentIngestionValidationError(c, err) || presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusAccepted, gin.H{"decision_request": dto.AdaptDecisionRequestDto(
+			models.DecisionRequestWithDecision{DecisionRequest: request}, marbleAppUrl)})
+	}
+}
+
+func handleGetDecisionRequest(uc usecases.Usecases, marbleAppUrl *url.URL) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		var uri DecisionRequestUriInput
+		if err := c.ShouldBindUri(&uri); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		decisionUsecase := usecasesWithCreds(ctx, uc).NewDecisionUsecase()
+		request, err := decisionUsecase.GetDecisionRequest(ctx, uri.RequestId)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"decision_request": dto.AdaptDecisionRequestDto(request, marbleAppUrl)})
+	}
+}
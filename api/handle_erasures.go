@@ -0,0 +1,84 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/checkmarble/marble-backend/dto"
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/pure_utils"
+	"github.com/checkmarble/marble-backend/usecases"
+	"github.com/checkmarble/marble-backend/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type ErasureCertificateUriInput struct {
+	CertificateId string `uri:"certificate_id" binding:"required,uuid"`
+}
+
+func handlePostErasure(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		organizationId, err := utils.OrganizationIdFromRequest(c.Request)
+		if presentError(ctx, c, err) {
+			return
+		}
+		var data dto.CreateErasureBody
+		if err := c.ShouldBindJSON(&data); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewErasureUsecase()
+		certificate, err := usecase.Erase(ctx, models.ErasureRequest{
+			OrganizationId: organizationId,
+			ObjectType:     data.ObjectType,
+			ObjectId:       data.ObjectId,
+			PivotValue:     data.PivotValue,
+			Mode:           models.ErasureModeFromString(data.Mode),
+		})
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusCreated, gin.H{"erasure_certificate": dto.AdaptErasureCertificateDto(certificate)})
+	}
+}
+
+func handleListErasureCertificates(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		organizationId, err := utils.OrganizationIdFromRequest(c.Request)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewErasureUsecase()
+		certificates, err := usecase.ListErasureCertificates(ctx, organizationId)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"erasure_certificates": pure_utils.Map(certificates, dto.AdaptErasureCertificateDto),
+		})
+	}
+}
+
+func handleGetErasureCertificate(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		var input ErasureCertificateUriInput
+		if err := c.ShouldBindUri(&input); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewErasureUsecase()
+		certificate, err := usecase.GetErasureCertificate(ctx, input.CertificateId)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"erasure_certificate": dto.AdaptErasureCertificateDto(certificate)})
+	}
+}
@@ -0,0 +1,70 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/checkmarble/marble-backend/dto"
+	"github.com/checkmarble/marble-backend/usecases"
+	"github.com/gin-gonic/gin"
+)
+
+func handleGetInboxCaseWorkflow(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		var uri GetInboxIdUriInput
+		if err := c.ShouldBindUri(&uri); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewInboxUsecase()
+		workflow, err := usecase.GetInboxCaseWorkflow(ctx, uri.InboxId)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"case_workflow": dto.AdaptInboxCaseWorkflowDto(uri.InboxId, workflow)})
+	}
+}
+
+func handleUpsertInboxCaseWorkflow(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		var uri GetInboxIdUriInput
+		if err := c.ShouldBindUri(&uri); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+		var data dto.InboxCaseWorkflowBody
+		if err := c.ShouldBindJSON(&data); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewInboxUsecase()
+		workflow, err := usecase.UpsertInboxCaseWorkflow(ctx, uri.InboxId, dto.AdaptInboxCaseWorkflowInput(data))
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"case_workflow": dto.AdaptInboxCaseWorkflowDto(uri.InboxId, &workflow)})
+	}
+}
+
+func handleDeleteInboxCaseWorkflow(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		var uri GetInboxIdUriInput
+		if err := c.ShouldBindUri(&uri); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewInboxUsecase()
+		if err := usecase.DeleteInboxCaseWorkflow(ctx, uri.InboxId); presentError(ctx, c, err) {
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
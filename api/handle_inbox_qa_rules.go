@@ -0,0 +1,101 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/checkmarble/marble-backend/dto"
+	"github.com/checkmarble/marble-backend/pure_utils"
+	"github.com/checkmarble/marble-backend/usecases"
+	"github.com/gin-gonic/gin"
+)
+
+type InboxQaRuleUriInput struct {
+	InboxId string `uri:"inbox_id" binding:"required,uuid"`
+	RuleId  string `uri:"rule_id" binding:"required,uuid"`
+}
+
+func handleListInboxQaRules(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		var uri GetInboxIdUriInput
+		if err := c.ShouldBindUri(&uri); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewInboxUsecase()
+		rules, err := usecase.ListInboxQaRules(ctx, uri.InboxId)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"qa_rules": pure_utils.Map(rules, dto.AdaptInboxQaRuleDto)})
+	}
+}
+
+func handleCreateInboxQaRule(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		var uri GetInboxIdUriInput
+		if err := c.ShouldBindUri(&uri); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+		var data dto.InboxQaRuleBody
+		if err := c.ShouldBindJSON(&data); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewInboxUsecase()
+		rule, err := usecase.CreateInboxQaRule(ctx, uri.InboxId, dto.AdaptInboxQaRuleInput(data))
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusCreated, gin.H{"qa_rule": dto.AdaptInboxQaRuleDto(rule)})
+	}
+}
+
+func handleUpdateInboxQaRule(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		var uri InboxQaRuleUriInput
+		if err := c.ShouldBindUri(&uri); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+		var data dto.InboxQaRuleBody
+		if err := c.ShouldBindJSON(&data); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewInboxUsecase()
+		rule, err := usecase.UpdateInboxQaRule(ctx, uri.InboxId, uri.RuleId,
+			dto.AdaptInboxQaRuleInput(data))
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"qa_rule": dto.AdaptInboxQaRuleDto(rule)})
+	}
+}
+
+func handleDeleteInboxQaRule(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		var uri InboxQaRuleUriInput
+		if err := c.ShouldBindUri(&uri); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewInboxUsecase()
+		if err := usecase.DeleteInboxQaRule(ctx, uri.InboxId, uri.RuleId); presentError(ctx, c, err) {
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,101 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/checkmarble/marble-backend/dto"
+	"github.com/checkmarble/marble-backend/pure_utils"
+	"github.com/checkmarble/marble-backend/usecases"
+	"github.com/gin-gonic/gin"
+)
+
+type InboxSlaPolicyUriInput struct {
+	InboxId  string `uri:"inbox_id" binding:"required,uuid"`
+	PolicyId string `uri:"policy_id" binding:"required,uuid"`
+}
+
+func handleListInboxSlaPolicies(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		var uri GetInboxIdUriInput
+		if err := c.ShouldBindUri(&uri); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewInboxUsecase()
+		policies, err := usecase.ListInboxSlaPolicies(ctx, uri.InboxId)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"sla_policies": pure_utils.Map(policies, dto.AdaptInboxSlaPolicyDto)})
+	}
+}
+
+func handleCreateInboxSlaPolicy(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		var uri GetInboxIdUriInput
+		if err := c.ShouldBindUri(&uri); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+		var data dto.InboxSlaPolicyBody
+		if err := c.ShouldBindJSON(&data); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewInboxUsecase()
+		policy, err := usecase.CreateInboxSlaPolicy(ctx, uri.InboxId, dto.AdaptInboxSlaPolicyInput(data))
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusCreated, gin.H{"sla_policy": dto.AdaptInboxSlaPolicyDto(policy)})
+	}
+}
+
+func handleUpdateInboxSlaPolicy(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		var uri InboxSlaPolicyUriInput
+		if err := c.ShouldBindUri(&uri); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+		var data dto.InboxSlaPolicyBody
+		if err := c.ShouldBindJSON(&data); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewInboxUsecase()
+		policy, err := usecase.UpdateInboxSlaPolicy(ctx, uri.InboxId, uri.PolicyId,
+			dto.AdaptInboxSlaPolicyInput(data))
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"sla_policy": dto.AdaptInboxSlaPolicyDto(policy)})
+	}
+}
+
+func handleDeleteInboxSlaPolicy(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		var uri InboxSlaPolicyUriInput
+		if err := c.ShouldBindUri(&uri); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewInboxUsecase()
+		if err := usecase.DeleteInboxSlaPolicy(ctx, uri.InboxId, uri.PolicyId); presentError(ctx, c, err) {
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
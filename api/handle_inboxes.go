@@ -153,16 +153,26 @@ func handlePatchInbox(uc usecases.Usecases) func(c *gin.Context) {
 		}
 
 		var data struct {
-			Name              string  `json:"name" binding:"required"`
-			EscalationInboxId *string `json:"escalation_inbox_id" binding:"omitempty,uuid"`
+			Name               string  `json:"name" binding:"required"`
+			EscalationInboxId  *string `json:"escalation_inbox_id" binding:"omitempty,uuid"`
+			AssignmentStrategy *string `json:"assignment_strategy"`
 		}
 		if err := c.ShouldBind(&data); err != nil {
 			c.Status(http.StatusBadRequest)
 			return
 		}
 
+		input := models.UpdateInboxInput{
+			Id:                getInboxInput.InboxId,
+			Name:              data.Name,
+			EscalationInboxId: data.EscalationInboxId,
+		}
+		if data.AssignmentStrategy != nil {
+			input.AssignmentStrategy = utils.Ptr(models.InboxAssignmentStrategy(*data.AssignmentStrategy))
+		}
+
 		usecase := usecasesWithCreds(ctx, uc).NewInboxUsecase()
-		inbox, err := usecase.UpdateInbox(ctx, getInboxInput.InboxId, data.Name, data.EscalationInboxId)
+		inbox, err := usecase.UpdateInbox(ctx, input)
 		if presentError(ctx, c, err) {
 			return
 		}
@@ -293,15 +303,25 @@ func handlePatchInboxUser(uc usecases.Usecases) func(c *gin.Context) {
 		}
 
 		var data struct {
-			Role string `json:"role" binding:"required"`
+			Role        *string   `json:"role"`
+			OutOfOffice *bool     `json:"out_of_office"`
+			SkillTagIds *[]string `json:"skill_tag_ids" binding:"omitempty,dive,uuid"`
 		}
 		if err := c.ShouldBind(&data); err != nil {
 			c.Status(http.StatusBadRequest)
 			return
 		}
 
+		input := models.UpdateInboxUserInput{
+			OutOfOffice: data.OutOfOffice,
+			SkillTagIds: data.SkillTagIds,
+		}
+		if data.Role != nil {
+			input.Role = utils.Ptr(models.InboxUserRole(*data.Role))
+		}
+
 		usecase := usecasesWithCreds(ctx, uc).NewInboxUsecase()
-		inboxUser, err := usecase.UpdateInboxUser(ctx, getInboxUserInput.Id, models.InboxUserRole(data.Role))
+		inboxUser, err := usecase.UpdateInboxUser(ctx, getInboxUserInput.Id, input)
 		if presentError(ctx, c, err) {
 			return
 		}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 
 	"github.com/cockroachdb/errors"
 	"github.com/gin-gonic/gin"
@@ -17,7 +18,7 @@ import (
 	"github.com/checkmarble/marble-backend/utils"
 )
 
-func handleIngestion(uc usecases.Usecases) func(c *gin.Context) {
+func handleIngestion(uc usecases.Usecases, marbleAppUrl *url.URL) func(c *gin.Context) {
 	return func(c *gin.Context) {
 		ctx := c.Request.Context()
 		organizationId, err := utils.OrganizationIdFromRequest(c.Request)
@@ -33,7 +34,7 @@ func handleIngestion(uc usecases.Usecases) func(c *gin.Context) {
 		}
 
 		usecase := usecasesWithCreds(ctx, uc).NewIngestionUseCase()
-		nb, err := usecase.IngestObject(ctx, organizationId, objectType, objectBody)
+		nb, decisions, err := usecase.IngestObject(ctx, organizationId, objectType, objectBody)
 		var validationError models.IngestionValidationErrors
 		if errors.As(err, &validationError) {
 			_, objectErr := validationError.GetSomeItem()
@@ -46,12 +47,23 @@ func handleIngestion(uc usecases.Usecases) func(c *gin.Context) {
 		} else if presentError(ctx, c, err) {
 			return
 		}
-		if nb == 0 {
-			c.Status(http.StatusOK)
-			return
-		}
-		c.Status(http.StatusCreated)
+		presentIngestionResult(c, nb, decisions, marbleAppUrl)
+	}
+}
+
+// presentIngestionResult also returns the decisions created on the ingested objects, for tables configured to
+// create decisions synchronously on ingestion. The objects on which decisions could not be created are listed
+// separately, since they were ingested anyway.
+func presentIngestionResult(c *gin.Context, nb int, decisions *models.IngestionDecisions, marbleAppUrl *url.URL) {
+	status := http.StatusCreated
+	if nb == 0 {
+		status = http.StatusOK
+	}
+	if decisions != nil {
+		c.JSON(status, dto.AdaptIngestionDecisionsDto(*decisions, marbleAppUrl))
+		return
 	}
+	c.Status(status)
 }
 
 func presentIngestionValidationError(c *gin.Context, err error) bool {
@@ -68,7 +80,7 @@ func presentIngestionValidationError(c *gin.Context, err error) bool {
 	return false
 }
 
-func handleIngestionPartialUpsert(uc usecases.Usecases) func(c *gin.Context) {
+func handleIngestionPartialUpsert(uc usecases.Usecases, marbleAppUrl *url.URL) func(c *gin.Context) {
 	return func(c *gin.Context) {
 		ctx := c.Request.Context()
 		organizationId, err := utils.OrganizationIdFromRequest(c.Request)
@@ -84,15 +96,11 @@ func handleIngestionPartialUpsert(uc usecases.Usecases) func(c *gin.Context) {
 		}
 
 		usecase := usecasesWithCreds(ctx, uc).NewIngestionUseCase()
-		nb, err := usecase.IngestObject(ctx, organizationId, objectType, objectBody, payload_parser.WithAllowPatch())
+		nb, decisions, err := usecase.IngestObject(ctx, organizationId, objectType, objectBody, payload_parser.WithAllowPatch())
 		if presentIngestionValidationError(c, err) || presentError(ctx, c, err) {
 			return
 		}
-		if nb == 0 {
-			c.Status(http.StatusOK)
-			return
-		}
-		c.Status(http.StatusCreated)
+		presentIngestionResult(c, nb, decisions, marbleAppUrl)
 	}
 }
 
@@ -109,7 +117,7 @@ func presentIngestionValidationErrorMultiple(c *gin.Context, err error) bool {
 	return false
 }
 
-func handleIngestionMultiple(uc usecases.Usecases) func(c *gin.Context) {
+func handleIngestionMultiple(uc usecases.Usecases, marbleAppUrl *url.URL) func(c *gin.Context) {
 	return func(c *gin.Context) {
 		ctx := c.Request.Context()
 		organizationId, err := utils.OrganizationIdFromRequest(c.Request)
@@ -125,19 +133,15 @@ func handleIngestionMultiple(uc usecases.Usecases) func(c *gin.Context) {
 		}
 
 		usecase := usecasesWithCreds(ctx, uc).NewIngestionUseCase()
-		nb, err := usecase.IngestObjects(ctx, organizationId, objectType, objectBody)
+		nb, decisions, err := usecase.IngestObjects(ctx, organizationId, objectType, objectBody)
 		if presentIngestionValidationErrorMultiple(c, err) || presentError(ctx, c, err) {
 			return
 		}
-		if nb == 0 {
-			c.Status(http.StatusOK)
-			return
-		}
-		c.Status(http.StatusCreated)
+		presentIngestionResult(c, nb, decisions, marbleAppUrl)
 	}
 }
 
-func handleIngestionMultiplePartialUpsert(uc usecases.Usecases) func(c *gin.Context) {
+func handleIngestionMultiplePartialUpsert(uc usecases.Usecases, marbleAppUrl *url.URL) func(c *gin.Context) {
 	return func(c *gin.Context) {
 		ctx := c.Request.Context()
 		organizationId, err := utils.OrganizationIdFromRequest(c.Request)
@@ -153,15 +157,11 @@ func handleIngestionMultiplePartialUpsert(uc usecases.Usecases) func(c *gin.Cont
 		}
 
 		usecase := usecasesWithCreds(ctx, uc).NewIngestionUseCase()
-		nb, err := usecase.IngestObjects(ctx, organizationId, objectType, objectBody, payload_parser.WithAllowPatch())
+		nb, decisions, err := usecase.IngestObjects(ctx, organizationId, objectType, objectBody, payload_parser.WithAllowPatch())
 		if presentIngestionValidationErrorMultiple(c, err) || presentError(ctx, c, err) {
 			return
 		}
-		if nb == 0 {
-			c.Status(http.StatusOK)
-			return
-		}
-		c.Status(http.StatusCreated)
+		presentIngestionResult(c, nb, decisions, marbleAppUrl)
 	}
 }
 
@@ -0,0 +1,114 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/checkmarble/marble-backend/dto"
+	"github.com/checkmarble/marble-backend/pure_utils"
+	"github.com/checkmarble/marble-backend/usecases"
+	"github.com/gin-gonic/gin"
+)
+
+type NotificationUriInput struct {
+	NotificationId string `uri:"notification_id" binding:"required,uuid"`
+}
+
+func handleListNotifications(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		var query dto.ListNotificationsQuery
+		if err := c.ShouldBindQuery(&query); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewNotificationUsecase()
+		notifications, err := usecase.ListNotifications(ctx, dto.AdaptNotificationFilters(query))
+		if presentError(ctx, c, err) {
+			return
+		}
+		unreadCount, err := usecase.CountUnreadNotifications(ctx)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"notifications": pure_utils.Map(notifications, dto.AdaptNotificationDto),
+			"unread_count":  unreadCount,
+		})
+	}
+}
+
+func handleMarkNotificationRead(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		var input NotificationUriInput
+		if err := c.ShouldBindUri(&input); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewNotificationUsecase()
+		notification, err := usecase.MarkNotificationRead(ctx, input.NotificationId)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"notification": dto.AdaptNotificationDto(notification)})
+	}
+}
+
+func handleMarkAllNotificationsRead(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		usecase := usecasesWithCreds(ctx, uc).NewNotificationUsecase()
+		nbRead, err := usecase.MarkAllNotificationsRead(ctx)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"nb_read": nbRead})
+	}
+}
+
+func handleGetNotificationPreferences(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		usecase := usecasesWithCreds(ctx, uc).NewNotificationUsecase()
+		preferences, err := usecase.GetNotificationPreferences(ctx)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"preferences": pure_utils.Map(preferences, dto.AdaptNotificationPreferenceDto),
+		})
+	}
+}
+
+func handleUpdateNotificationPreferences(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		var body dto.UpdateNotificationPreferencesBody
+		if err := c.ShouldBindJSON(&body); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+		input, err := dto.AdaptNotificationPreferencesInput(body)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewNotificationUsecase()
+		preferences, err := usecase.UpdateNotificationPreferences(ctx, input)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"preferences": pure_utils.Map(preferences, dto.AdaptNotificationPreferenceDto),
+		})
+	}
+}
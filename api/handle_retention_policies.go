@@ -0,0 +1,138 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/checkmarble/marble-backend/dto"
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/pure_utils"
+	"github.com/checkmarble/marble-backend/usecases"
+	"github.com/checkmarble/marble-backend/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type RetentionPolicyUriInput struct {
+	PolicyId string `uri:"policy_id" binding:"required,uuid"`
+}
+
+func handleListRetentionPolicies(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		organizationId, err := utils.OrganizationIdFromRequest(c.Request)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewRetentionPolicyUsecase()
+		policies, err := usecase.ListRetentionPolicies(ctx, organizationId)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"retention_policies": pure_utils.Map(policies, dto.AdaptRetentionPolicyDto)})
+	}
+}
+
+func handlePostRetentionPolicy(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		organizationId, err := utils.OrganizationIdFromRequest(c.Request)
+		if presentError(ctx, c, err) {
+			return
+		}
+		var data dto.CreateRetentionPolicyBody
+		if err := c.ShouldBindJSON(&data); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewRetentionPolicyUsecase()
+		policy, err := usecase.CreateRetentionPolicy(ctx, models.CreateRetentionPolicyInput{
+			OrganizationId:    organizationId,
+			TableName:         data.TableName,
+			Action:            models.RetentionPolicyActionFromString(data.Action),
+			RetentionDays:     data.RetentionDays,
+			KeepLatestVersion: data.KeepLatestVersion,
+			Enabled:           data.Enabled == nil || *data.Enabled,
+		})
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusCreated, gin.H{"retention_policy": dto.AdaptRetentionPolicyDto(policy)})
+	}
+}
+
+func handlePatchRetentionPolicy(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		var policyInput RetentionPolicyUriInput
+		if err := c.ShouldBindUri(&policyInput); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		var data dto.UpdateRetentionPolicyBody
+		if err := c.ShouldBindJSON(&data); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		input := models.UpdateRetentionPolicyInput{
+			Id:                policyInput.PolicyId,
+			RetentionDays:     data.RetentionDays,
+			KeepLatestVersion: data.KeepLatestVersion,
+			Enabled:           data.Enabled,
+		}
+		if data.Action != nil {
+			action := models.RetentionPolicyActionFromString(*data.Action)
+			input.Action = &action
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewRetentionPolicyUsecase()
+		policy, err := usecase.UpdateRetentionPolicy(ctx, input)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"retention_policy": dto.AdaptRetentionPolicyDto(policy)})
+	}
+}
+
+func handleDeleteRetentionPolicy(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		var policyInput RetentionPolicyUriInput
+		if err := c.ShouldBindUri(&policyInput); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewRetentionPolicyUsecase()
+		err := usecase.DeleteRetentionPolicy(ctx, policyInput.PolicyId)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
+
+func handleDryRunRetentionPolicy(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		var policyInput RetentionPolicyUriInput
+		if err := c.ShouldBindUri(&policyInput); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewRetentionPolicyUsecase()
+		report, err := usecase.DryRunRetentionPolicy(ctx, policyInput.PolicyId)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusOK, dto.AdaptRetentionReportDto(report))
+	}
+}
@@ -2,7 +2,9 @@ package api
 
 import (
 	"net/http"
+	"time"
 
+	"github.com/cockroachdb/errors"
 	"github.com/gin-gonic/gin"
 
 	"github.com/checkmarble/marble-backend/dto"
@@ -64,10 +66,21 @@ func handleCreateScheduledExecution(uc usecases.Usecases) func(c *gin.Context) {
 
 		iterationID := c.Param("iteration_id")
 
+		var params dto.CreateScheduledExecutionParams
+		if err := c.ShouldBindQuery(&params); err != nil {
+			presentError(ctx, c, errors.Wrap(models.BadParameterError, err.Error()))
+			return
+		}
+		var asOf *time.Time
+		if !params.AsOf.IsZero() {
+			asOf = &params.AsOf
+		}
+
 		usecase := usecasesWithCreds(ctx, uc).NewScheduledExecutionUsecase()
 		err = usecase.CreateScheduledExecution(ctx, models.CreateScheduledExecutionInput{
 			OrganizationId:      organizationId,
 			ScenarioIterationId: iterationID,
+			ReadAsOf:            asOf,
 		})
 
 		if presentError(ctx, c, err) {
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"fmt"
 	"net/http"
 
 	"github.com/checkmarble/marble-backend/dto"
@@ -167,3 +168,84 @@ func handleDeleteSuspiciousActivityReport(uc usecases.Usecases) gin.HandlerFunc
 		c.Status(http.StatusNoContent)
 	}
 }
+
+func handleGetSuspiciousActivityReportContent(uc usecases.Usecases) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		creds, _ := utils.CredentialsFromCtx(ctx)
+
+		caseId := c.Param("case_id")
+		reportId := c.Param("reportId")
+
+		uc := usecasesWithCreds(ctx, uc)
+		sarUsecase := uc.NewSuspiciousActivityReportUsecase()
+
+		content, prefilled, err := sarUsecase.GetReportContent(ctx, creds.OrganizationId, caseId, reportId)
+		if err != nil {
+			presentError(ctx, c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, dto.AdaptSuspiciousActivityReportContentDto(content, prefilled))
+	}
+}
+
+func handleUpdateSuspiciousActivityReportContent(uc usecases.Usecases) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		creds, _ := utils.CredentialsFromCtx(ctx)
+
+		caseId := c.Param("case_id")
+		reportId := c.Param("reportId")
+
+		var content models.SarContent
+		if err := c.ShouldBindJSON(&content); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		uc := usecasesWithCreds(ctx, uc)
+		sarUsecase := uc.NewSuspiciousActivityReportUsecase()
+
+		sar, err := sarUsecase.UpdateReportContent(ctx, creds.OrganizationId, caseId, reportId, content)
+		if err != nil {
+			presentError(ctx, c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, dto.AdaptSuspiciousActivityReportContentDto(*sar.Content, false))
+	}
+}
+
+func handleExportSuspiciousActivityReport(uc usecases.Usecases) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		creds, _ := utils.CredentialsFromCtx(ctx)
+
+		caseId := c.Param("case_id")
+		reportId := c.Param("reportId")
+
+		var params dto.SuspiciousActivityReportExportParams
+		if err := c.ShouldBindQuery(&params); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+		format := models.SarExportGoAml
+		if params.Format != "" {
+			format = models.SarExportFormat(params.Format)
+		}
+
+		uc := usecasesWithCreds(ctx, uc)
+		sarUsecase := uc.NewSuspiciousActivityReportUsecase()
+
+		export, err := sarUsecase.ExportReport(ctx, creds.OrganizationId, caseId, reportId, format)
+		if err != nil {
+			presentError(ctx, c, err)
+			return
+		}
+
+		c.Header("Access-Control-Expose-Headers", "Content-Disposition")
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", export.FileName))
+		c.Data(http.StatusOK, export.ContentType, export.Data)
+	}
+}
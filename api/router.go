@@ -58,7 +58,7 @@ func corsOption(ctx context.Context, conf Configuration) cors.Config {
 			http.MethodOptions, http.MethodHead, http.MethodGet,
 			http.MethodPost, http.MethodDelete, http.MethodPatch,
 		},
-		AllowHeaders:     []string{"Authorization", "Content-Type", "X-Api-Key", "baggage", "sentry-trace"},
+		AllowHeaders:     []string{"Authorization", "Content-Type", "X-Api-Key", "Idempotency-Key", "baggage", "sentry-trace"},
 		AllowCredentials: false,
 		MaxAge:           12 * time.Hour,
 	}
@@ -61,19 +61,43 @@ func addRoutes(r *gin.Engine, conf Configuration, uc usecases.Usecases, auth uti
 	router.POST("/decisions/all",
 		timeoutMiddleware(3*conf.DecisionTimeout),
 		handlePostAllDecisions(uc, parsedAppUrl))
+	router.POST("/decisions/async", tom, handlePostDecisionRequest(uc, parsedAppUrl))
+	router.GET("/decisions/async/:request_id", tom, handleGetDecisionRequest(uc, parsedAppUrl))
 	router.GET("/decisions/:decision_id", tom, handleGetDecision(uc, parsedAppUrl))
 	router.GET("/decisions/:decision_id/active-snoozes", tom, handleSnoozesOfDecision(uc))
 	router.POST("/decisions/:decision_id/snooze", tom, handleSnoozeDecision(uc))
-
-	router.POST("/ingestion/:object_type", tom, handleIngestion(uc))
-	router.PATCH("/ingestion/:object_type", tom, handleIngestionPartialUpsert(uc))
-	router.POST("/ingestion/:object_type/multiple", tom, handleIngestionMultiple(uc))
+	router.POST("/decisions/:decision_id/reevaluate", timeoutMiddleware(conf.DecisionTimeout),
+		handleReevaluateDecision(uc))
+	router.GET("/decisions/:decision_id/reevaluations", tom, handleListDecisionReevaluations(uc))
+
+	router.POST("/decision-reevaluations", tom, handlePostDecisionReevaluationBatch(uc))
+	router.GET("/decision-reevaluations/:batch_id", tom, handleGetDecisionReevaluationBatch(uc))
+
+	router.POST("/decision-labels", tom, handlePostDecisionLabel(uc))
+	router.POST("/decision-labels/batch", tom, handlePostDecisionLabelsBatch(uc))
+	router.DELETE("/decision-labels/:label_id", tom, handleDeleteDecisionLabel(uc))
+	router.GET("/decision-labels/export", timeoutMiddleware(conf.BatchTimeout),
+		handleExportLabelledDecisions(uc))
+
+	router.POST("/decision-exports", tom, handlePostDecisionExport(uc))
+	router.GET("/decision-exports", tom, handleListDecisionExports(uc))
+	router.GET("/decision-exports/:export_id", tom, handleGetDecisionExport(uc))
+
+	// long polling, see models.ChangeFeedMaxWait
+	router.GET("/change-feed", timeoutMiddleware(conf.BatchTimeout), handleReadChangeFeed(uc))
+	router.GET("/change-feed/config", tom, handleGetChangeFeedConfig(uc))
+	router.PUT("/change-feed/config", tom, handleUpdateChangeFeedConfig(uc))
+
+	router.POST("/ingestion/:object_type", tom, handleIngestion(uc, parsedAppUrl))
+	router.PATCH("/ingestion/:object_type", tom, handleIngestionPartialUpsert(uc, parsedAppUrl))
+	router.POST("/ingestion/:object_type/multiple", tom, handleIngestionMultiple(uc, parsedAppUrl))
 	router.PATCH("/ingestion/:object_type/multiple", tom,
-		handleIngestionMultiplePartialUpsert(uc))
+		handleIngestionMultiplePartialUpsert(uc, parsedAppUrl))
 	router.POST("/ingestion/:object_type/batch", timeoutMiddleware(conf.BatchTimeout), handlePostCsvIngestion(uc))
 	router.GET("/ingestion/:object_type/upload-logs", tom, handleListUploadLogs(uc))
 
 	router.GET("/client_data/:object_type/:object_id", tom, handleGetIngestedObject(uc))
+	router.GET("/client_data/:object_type/:object_id/history", tom, handleGetIngestedObjectHistory(uc))
 	router.GET("/client_data/:object_type/:object_id/annotations", tom, handleListEntityAnnotations(uc))
 	router.POST("/client_data/:object_type/annotations", tom,
 		handleListEntityAnnotationsForObjects(uc))
@@ -89,6 +113,10 @@ func addRoutes(r *gin.Engine, conf Configuration, uc usecases.Usecases, auth uti
 	router.GET("/scenarios/:scenario_id", tom, getScenario(uc))
 	router.PATCH("/scenarios/:scenario_id", tom, updateScenario(uc))
 	router.POST("/scenarios/:scenario_id/validate-ast", tom, validateScenarioAst(uc))
+	router.GET("/scenarios/:scenario_id/case_routing_rules", tom, handleListCaseRoutingRules(uc))
+	router.PUT("/scenarios/:scenario_id/case_routing_rules", tom, handleUpdateCaseRoutingRules(uc))
+	router.POST("/scenarios/:scenario_id/case_routing_rules/validate-ast", tom,
+		handleValidateCaseRoutingRuleAst(uc))
 
 	router.GET("/scenario-iterations", tom, handleListScenarioIterations(uc))
 	router.POST("/scenario-iterations", tom, handleCreateScenarioIteration(uc))
@@ -146,6 +174,8 @@ func addRoutes(r *gin.Engine, conf Configuration, uc usecases.Usecases, auth uti
 	router.GET("/scheduled-executions/:execution_id", tom, handleGetScheduledExecution(uc))
 
 	router.GET("/analytics", tom, handleListAnalytics(uc))
+	router.GET("/case_metrics", tom, handleGetCaseMetrics(uc))
+	router.GET("/case_metrics/export", tom, handleExportCaseMetric(uc))
 
 	router.GET("/apikeys", tom, handleListApiKeys(uc))
 	router.POST("/apikeys", tom, handlePostApiKey(uc))
@@ -186,6 +216,13 @@ func addRoutes(r *gin.Engine, conf Configuration, uc usecases.Usecases, auth uti
 
 	router.GET("/cases", tom, handleListCases(uc))
 	router.POST("/cases", tom, handlePostCase(uc))
+	router.GET("/cases/bulk_actions", tom, handleListCaseBulkActions(uc))
+	router.POST("/cases/bulk_actions", tom, handlePostCaseBulkAction(uc))
+	router.GET("/cases/bulk_actions/:bulk_action_id", tom, handleGetCaseBulkAction(uc))
+	router.GET("/cases/views", tom, handleListCaseSavedViews(uc))
+	router.POST("/cases/views", tom, handlePostCaseSavedView(uc))
+	router.PATCH("/cases/views/:view_id", tom, handlePatchCaseSavedView(uc))
+	router.DELETE("/cases/views/:view_id", tom, handleDeleteCaseSavedView(uc))
 	router.GET("/cases/:case_id", tom, handleGetCase(uc))
 	router.GET("/cases/:case_id/next", tom, handleGetNextCase(uc))
 	router.POST("/cases/:case_id/snooze", tom, handleSnoozeCase(uc))
@@ -193,7 +230,11 @@ func addRoutes(r *gin.Engine, conf Configuration, uc usecases.Usecases, auth uti
 	router.PATCH("/cases/:case_id", tom, handlePatchCase(uc))
 	router.GET("/cases/:case_id/decisions", tom, handleListCaseDecisions(uc, parsedAppUrl))
 	router.POST("/cases/:case_id/decisions", tom, handlePostCaseDecisions(uc))
+	router.GET("/cases/:case_id/comments", tom, handleListCaseComments(uc))
 	router.POST("/cases/:case_id/comments", tom, handlePostCaseComment(uc))
+	router.PATCH("/cases/:case_id/comments/:comment_id", tom, handlePatchCaseComment(uc))
+	router.DELETE("/cases/:case_id/comments/:comment_id", tom, handleDeleteCaseComment(uc))
+	router.GET("/cases/:case_id/comments/:comment_id/versions", tom, handleListCaseCommentVersions(uc))
 	router.POST("/cases/:case_id/case_tags", tom, handlePostCaseTags(uc))
 	router.POST("/cases/:case_id/assignee", tom, handleAssignCase(uc))
 	router.DELETE("/cases/:case_id/assignee", tom, handleUnassignCase(uc))
@@ -210,7 +251,24 @@ func addRoutes(r *gin.Engine, conf Configuration, uc usecases.Usecases, auth uti
 		handleDownloadFileToSuspiciousActivityReport(uc))
 	router.DELETE("/cases/:case_id/sar/:reportId", tom,
 		handleDeleteSuspiciousActivityReport(uc))
+	router.GET("/cases/:case_id/sar/:reportId/content", tom,
+		handleGetSuspiciousActivityReportContent(uc))
+	router.PUT("/cases/:case_id/sar/:reportId/content", tom,
+		handleUpdateSuspiciousActivityReportContent(uc))
+	router.GET("/cases/:case_id/sar/:reportId/export", tom,
+		handleExportSuspiciousActivityReport(uc))
 	router.POST("/cases/:case_id/escalate", tom, handleEscalateCase(uc))
+	router.POST("/cases/:case_id/merge", tom, handleMergeCase(uc))
+	router.POST("/cases/:case_id/split", tom, handleSplitCase(uc))
+	router.POST("/cases/:case_id/exports", tom, handlePostCaseExport(uc))
+	router.GET("/cases/:case_id/exports", tom, handleListCaseExports(uc))
+	router.GET("/cases/:case_id/exports/:export_id", tom, handleGetCaseExport(uc))
+
+	router.GET("/notifications", tom, handleListNotifications(uc))
+	router.POST("/notifications/read_all", tom, handleMarkAllNotificationsRead(uc))
+	router.POST("/notifications/:notification_id/read", tom, handleMarkNotificationRead(uc))
+	router.GET("/notifications/preferences", tom, handleGetNotificationPreferences(uc))
+	router.PUT("/notifications/preferences", tom, handleUpdateNotificationPreferences(uc))
 
 	router.GET("/inboxes/:inbox_id", tom, handleGetInboxById(uc))
 	router.GET("/inboxes/:inbox_id/metadata", tom, handleGetInboxMetadataById(uc))
@@ -225,6 +283,22 @@ func addRoutes(r *gin.Engine, conf Configuration, uc usecases.Usecases, auth uti
 	router.DELETE("/inbox_users/:inbox_user_id", tom, handleDeleteInboxUser(uc))
 	router.GET("/inboxes/:inbox_id/users", tom, handleListInboxUsers(uc))
 	router.POST("/inboxes/:inbox_id/users", tom, handlePostInboxUser(uc))
+	router.GET("/inboxes/:inbox_id/sla_policies", tom, handleListInboxSlaPolicies(uc))
+	router.POST("/inboxes/:inbox_id/sla_policies", tom, handleCreateInboxSlaPolicy(uc))
+	router.PATCH("/inboxes/:inbox_id/sla_policies/:policy_id", tom, handleUpdateInboxSlaPolicy(uc))
+	router.DELETE("/inboxes/:inbox_id/sla_policies/:policy_id", tom, handleDeleteInboxSlaPolicy(uc))
+	router.GET("/inboxes/:inbox_id/case_workflow", tom, handleGetInboxCaseWorkflow(uc))
+	router.PUT("/inboxes/:inbox_id/case_workflow", tom, handleUpsertInboxCaseWorkflow(uc))
+	router.DELETE("/inboxes/:inbox_id/case_workflow", tom, handleDeleteInboxCaseWorkflow(uc))
+	router.GET("/inboxes/:inbox_id/qa_rules", tom, handleListInboxQaRules(uc))
+	router.POST("/inboxes/:inbox_id/qa_rules", tom, handleCreateInboxQaRule(uc))
+	router.PATCH("/inboxes/:inbox_id/qa_rules/:rule_id", tom, handleUpdateInboxQaRule(uc))
+	router.DELETE("/inboxes/:inbox_id/qa_rules/:rule_id", tom, handleDeleteInboxQaRule(uc))
+
+	router.GET("/qa/reviews", tom, handleListCaseQaReviews(uc))
+	router.GET("/qa/reviews/:review_id", tom, handleGetCaseQaReview(uc))
+	router.POST("/qa/reviews/:review_id/complete", tom, handleCompleteCaseQaReview(uc))
+	router.GET("/qa/scores", tom, handleListCaseQaScores(uc))
 
 	router.GET("/tags", tom, handleListTags(uc))
 	router.POST("/tags", tom, handlePostTag(uc))
@@ -232,6 +306,16 @@ func addRoutes(r *gin.Engine, conf Configuration, uc usecases.Usecases, auth uti
 	router.PATCH("/tags/:tag_id", tom, handlePatchTag(uc))
 	router.DELETE("/tags/:tag_id", tom, handleDeleteTag(uc))
 
+	router.POST("/erasures", tom, handlePostErasure(uc))
+	router.GET("/erasures", tom, handleListErasureCertificates(uc))
+	router.GET("/erasures/:certificate_id", tom, handleGetErasureCertificate(uc))
+
+	router.GET("/retention-policies", tom, handleListRetentionPolicies(uc))
+	router.POST("/retention-policies", tom, handlePostRetentionPolicy(uc))
+	router.PATCH("/retention-policies/:policy_id", tom, handlePatchRetentionPolicy(uc))
+	router.DELETE("/retention-policies/:policy_id", tom, handleDeleteRetentionPolicy(uc))
+	router.GET("/retention-policies/:policy_id/dry-run", tom, handleDryRunRetentionPolicy(uc))
+
 	router.GET("/data-model", tom, handleGetDataModel(uc))
 	router.POST("/data-model/tables", tom, handleCreateTable(uc))
 	router.PATCH("/data-model/tables/:tableID", tom, handleUpdateDataModelTable(uc))
@@ -97,23 +97,33 @@ func RunServer(config CompiledConfig) error {
 		caseManagerBucket                string
 		ingestionBucketUrl               string
 		offloadingBucketUrl              string
+		decisionExportBucketUrl          string
+		retentionArchiveBucketUrl        string
+		idempotencyKeyTtlHours           int
+		decisionRequestDeadlineSeconds   int
 		jwtSigningKey                    string
 		jwtSigningKeyFile                string
 		loggingFormat                    string
 		sentryDsn                        string
 		transferCheckEnrichmentBucketUrl string
 		firebaseEmulatorHost             string
+		clamAvAddress                    string
 	}{
 		batchIngestionMaxSize:            utils.GetEnv("BATCH_INGESTION_MAX_SIZE", 0),
 		caseManagerBucket:                utils.GetEnv("CASE_MANAGER_BUCKET_URL", ""),
 		ingestionBucketUrl:               utils.GetEnv("INGESTION_BUCKET_URL", ""),
 		offloadingBucketUrl:              utils.GetEnv("OFFLOADING_BUCKET_URL", ""),
+		decisionExportBucketUrl:          utils.GetEnv("DECISION_EXPORT_BUCKET_URL", ""),
+		retentionArchiveBucketUrl:        utils.GetEnv("RETENTION_ARCHIVE_BUCKET_URL", ""),
+		idempotencyKeyTtlHours:           utils.GetEnv("IDEMPOTENCY_KEY_TTL_HOURS", 24),
+		decisionRequestDeadlineSeconds:   utils.GetEnv("DECISION_REQUEST_DEADLINE_SECONDS", 300),
 		jwtSigningKey:                    utils.GetEnv("AUTHENTICATION_JWT_SIGNING_KEY", ""),
 		jwtSigningKeyFile:                utils.GetEnv("AUTHENTICATION_JWT_SIGNING_KEY_FILE", ""),
 		loggingFormat:                    utils.GetEnv("LOGGING_FORMAT", "text"),
 		sentryDsn:                        utils.GetEnv("SENTRY_DSN", ""),
 		transferCheckEnrichmentBucketUrl: utils.GetEnv("TRANSFER_CHECK_ENRICHMENT_BUCKET_URL", ""), // required for transfercheck
 		firebaseEmulatorHost:             utils.GetEnv("FIREBASE_AUTH_EMULATOR_HOST", ""),
+		clamAvAddress:                    utils.GetEnv("CLAMAV_ADDRESS", ""),
 	}
 
 	logger := utils.NewLogger(serverConfig.loggingFormat)
@@ -166,6 +176,7 @@ func RunServer(config CompiledConfig) error {
 		repositories.WithClientDbConfig(clientDbConfig),
 		repositories.WithTracerProvider(telemetryRessources.TracerProvider),
 		repositories.WithRiverClient(riverClient),
+		repositories.WithClamAvAddress(serverConfig.clamAvAddress),
 	)
 
 	uc := usecases.NewUsecases(repositories,
@@ -173,6 +184,10 @@ func RunServer(config CompiledConfig) error {
 		usecases.WithBatchIngestionMaxSize(serverConfig.batchIngestionMaxSize),
 		usecases.WithIngestionBucketUrl(serverConfig.ingestionBucketUrl),
 		usecases.WithOffloadingBucketUrl(serverConfig.offloadingBucketUrl),
+		usecases.WithDecisionExportBucketUrl(serverConfig.decisionExportBucketUrl),
+		usecases.WithRetention(infra.RetentionConfig{ArchiveBucketUrl: serverConfig.retentionArchiveBucketUrl}),
+		usecases.WithIdempotencyKeyTtl(time.Duration(serverConfig.idempotencyKeyTtlHours)*time.Hour),
+		usecases.WithDecisionRequestDeadline(time.Duration(serverConfig.decisionRequestDeadlineSeconds)*time.Second),
 		usecases.WithCaseManagerBucketUrl(serverConfig.caseManagerBucket),
 		usecases.WithLicense(license),
 		usecases.WithConvoyServer(convoyConfiguration.APIUrl),
@@ -68,17 +68,23 @@ func RunTaskQueue(apiVersion string) error {
 		env                         string
 		failedWebhooksRetryPageSize int
 		ingestionBucketUrl          string
+		decisionExportBucketUrl     string
+		caseManagerBucketUrl        string
 		loggingFormat               string
 		sentryDsn                   string
 		cloudRunProbePort           string
+		marbleAppUrl                string
 	}{
 		appName:                     "marble-backend",
 		env:                         utils.GetEnv("ENV", "development"),
 		failedWebhooksRetryPageSize: utils.GetEnv("FAILED_WEBHOOKS_RETRY_PAGE_SIZE", 1000),
 		ingestionBucketUrl:          utils.GetRequiredEnv[string]("INGESTION_BUCKET_URL"),
+		decisionExportBucketUrl:     utils.GetEnv("DECISION_EXPORT_BUCKET_URL", ""),
+		caseManagerBucketUrl:        utils.GetEnv("CASE_MANAGER_BUCKET_URL", ""),
 		loggingFormat:               utils.GetEnv("LOGGING_FORMAT", "text"),
 		sentryDsn:                   utils.GetEnv("SENTRY_DSN", ""),
 		cloudRunProbePort:           utils.GetEnv("CLOUD_RUN_PROBE_PORT", ""),
+		marbleAppUrl:                utils.GetEnv("MARBLE_APP_URL", ""),
 	}
 
 	logger := utils.NewLogger(workerConfig.loggingFormat)
@@ -97,6 +103,23 @@ func RunTaskQueue(apiVersion string) error {
 
 	offloadingConfig.ValidateAndFix(ctx)
 
+	retentionConfig := infra.RetentionConfig{
+		Enabled:          utils.GetEnv("RETENTION_ENABLED", false),
+		ArchiveBucketUrl: utils.GetEnv("RETENTION_ARCHIVE_BUCKET_URL", ""),
+		JobInterval:      utils.GetEnvDuration("RETENTION_JOB_INTERVAL", 24*time.Hour),
+		BatchSize:        utils.GetEnv("RETENTION_BATCH_SIZE", 1000),
+	}
+
+	retentionConfig.ValidateAndFix(ctx)
+
+	smtpConfig := infra.SmtpConfig{
+		Host:     utils.GetEnv("SMTP_HOST", ""),
+		Port:     utils.GetEnv("SMTP_PORT", 587),
+		Username: utils.GetEnv("SMTP_USERNAME", ""),
+		Password: utils.GetEnv("SMTP_PASSWORD", ""),
+		From:     utils.GetEnv("SMTP_FROM", ""),
+	}
+
 	infra.SetupSentry(workerConfig.sentryDsn, workerConfig.env, apiVersion)
 	defer sentry.Flush(3 * time.Second)
 
@@ -143,12 +166,13 @@ func RunTaskQueue(apiVersion string) error {
 		repositories.WithClientDbConfig(clientDbConfig),
 		repositories.WithTracerProvider(telemetryRessources.TracerProvider),
 		repositories.WithOpenSanctions(openSanctionsConfig),
+		repositories.WithSmtp(smtpConfig),
 	)
 
 	// Start the task queue workers
 	workers := river.NewWorkers()
 	queues, orgPeriodics, err := usecases.QueuesFromOrgs(ctx,
-		repositories.OrganizationRepository, repositories.ExecutorGetter, offloadingConfig)
+		repositories.OrganizationRepository, repositories.ExecutorGetter, offloadingConfig, retentionConfig)
 	if err != nil {
 		utils.LogAndReportSentryError(ctx, err)
 		return err
@@ -185,7 +209,11 @@ func RunTaskQueue(apiVersion string) error {
 
 	uc := usecases.NewUsecases(repositories,
 		usecases.WithIngestionBucketUrl(workerConfig.ingestionBucketUrl),
+		usecases.WithDecisionExportBucketUrl(workerConfig.decisionExportBucketUrl),
+		usecases.WithCaseManagerBucketUrl(workerConfig.caseManagerBucketUrl),
+		usecases.WithMarbleAppUrl(workerConfig.marbleAppUrl),
 		usecases.WithOffloading(offloadingConfig),
+		usecases.WithRetention(retentionConfig),
 		usecases.WithFailedWebhooksRetryPageSize(workerConfig.failedWebhooksRetryPageSize),
 		usecases.WithLicense(license),
 		usecases.WithConvoyServer(convoyConfiguration.APIUrl),
@@ -198,11 +226,26 @@ func RunTaskQueue(apiVersion string) error {
 	river.AddWorker(workers, adminUc.NewIndexCreationStatusWorker())
 	river.AddWorker(workers, adminUc.NewIndexCleanupWorker())
 	river.AddWorker(workers, adminUc.NewTestRunSummaryWorker())
+	river.AddWorker(workers, adminUc.NewIdempotencyKeyCleanupWorker())
+	river.AddWorker(workers, adminUc.NewDecisionExportWorker())
+	river.AddWorker(workers, adminUc.NewCaseExportWorker())
+	river.AddWorker(workers, adminUc.NewCaseBulkActionWorker())
+	river.AddWorker(workers, adminUc.NewDecisionRequestWorker())
+	river.AddWorker(workers, adminUc.NewDecisionReevaluationBatchWorker())
+	river.AddWorker(workers, adminUc.NewChangeFeedWorker())
+	river.AddWorker(workers, adminUc.NewCaseSlaWorker())
+	river.AddWorker(workers, adminUc.NewNotificationWorker())
+	river.AddWorker(workers, adminUc.NewNotificationEmailWorker())
 	river.AddWorker(workers, adminUc.NewMatchEnrichmentWorker())
+	river.AddWorker(workers, adminUc.NewIngestedObjectsDecisionWorker())
+	river.AddWorker(workers, adminUc.NewBlobDeletionWorker())
 
 	if offloadingConfig.Enabled {
 		river.AddWorker(workers, adminUc.NewOffloadingWorker())
 	}
+	if retentionConfig.Enabled {
+		river.AddWorker(workers, adminUc.NewRetentionWorker())
+	}
 
 	if err := riverClient.Start(ctx); err != nil {
 		utils.LogAndReportSentryError(ctx, err)
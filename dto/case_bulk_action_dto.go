@@ -0,0 +1,120 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/pure_utils"
+)
+
+type APICaseBulkActionParams struct {
+	Outcome       string        `json:"outcome"`
+	AssigneeId    models.UserId `json:"assignee_id"`
+	InboxId       string        `json:"inbox_id" binding:"omitempty,uuid"`
+	TagIds        []string      `json:"tag_ids" binding:"dive,uuid"`
+	SnoozeUntil   *time.Time    `json:"snooze_until"`
+	ReviewStatus  string        `json:"review_status"`
+	ReviewComment string        `json:"review_comment"`
+}
+
+// CreateCaseBulkActionBody selects the cases either by their ids, or by the same filters as the case list
+type CreateCaseBulkActionBody struct {
+	Action  string                  `json:"action" binding:"required"`
+	Params  APICaseBulkActionParams `json:"params"`
+	CaseIds []string                `json:"case_ids" binding:"dive,uuid"`
+	Filters *APICaseViewFilters     `json:"filters"`
+}
+
+func AdaptCaseBulkActionParams(p APICaseBulkActionParams) models.CaseBulkActionParams {
+	return models.CaseBulkActionParams{
+		Outcome:       models.CaseOutcome(p.Outcome),
+		AssigneeId:    p.AssigneeId,
+		InboxId:       p.InboxId,
+		TagIds:        p.TagIds,
+		SnoozeUntil:   p.SnoozeUntil,
+		ReviewStatus:  p.ReviewStatus,
+		ReviewComment: p.ReviewComment,
+	}
+}
+
+// AdaptCaseFiltersFromView converts JSON case filters to the filters of the case list
+func AdaptCaseFiltersFromView(f APICaseViewFilters) CaseFilters {
+	return CaseFilters{
+		InboxIds:        f.InboxIds,
+		Statuses:        f.Statuses,
+		Name:            f.Name,
+		IncludeSnoozed:  f.IncludeSnoozed,
+		ExcludeAssigned: f.ExcludeAssigned,
+		AssigneeId:      f.AssigneeId,
+		Query:           f.Query,
+		TagIds:          f.TagIds,
+		Outcomes:        f.Outcomes,
+		ScenarioIds:     f.ScenarioIds,
+		RuleIds:         f.RuleIds,
+		MinScore:        f.MinScore,
+		MaxScore:        f.MaxScore,
+		SlaStatus:       f.SlaStatus,
+	}
+}
+
+type APICaseBulkAction struct {
+	Id          string                  `json:"id"`
+	UserId      models.UserId           `json:"user_id"`
+	Action      string                  `json:"action"`
+	Params      APICaseBulkActionParams `json:"params"`
+	Status      string                  `json:"status"`
+	NbCases     int                     `json:"nb_cases"`
+	NbSucceeded int                     `json:"nb_succeeded"`
+	NbFailed    int                     `json:"nb_failed"`
+	CreatedAt   time.Time               `json:"created_at"`
+	UpdatedAt   time.Time               `json:"updated_at"`
+}
+
+func AdaptCaseBulkActionDto(b models.CaseBulkAction) APICaseBulkAction {
+	return APICaseBulkAction{
+		Id:     b.Id,
+		UserId: b.UserId,
+		Action: string(b.Action),
+		Params: APICaseBulkActionParams{
+			Outcome:       string(b.Params.Outcome),
+			AssigneeId:    b.Params.AssigneeId,
+			InboxId:       b.Params.InboxId,
+			TagIds:        emptyIfNil(b.Params.TagIds),
+			SnoozeUntil:   b.Params.SnoozeUntil,
+			ReviewStatus:  b.Params.ReviewStatus,
+			ReviewComment: b.Params.ReviewComment,
+		},
+		Status:      string(b.Status),
+		NbCases:     b.NbCases,
+		NbSucceeded: b.NbSucceeded,
+		NbFailed:    b.NbFailed,
+		CreatedAt:   b.CreatedAt,
+		UpdatedAt:   b.UpdatedAt,
+	}
+}
+
+type APICaseBulkActionItem struct {
+	CaseId    string    `json:"case_id"`
+	Status    string    `json:"status"`
+	Error     *string   `json:"error"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type APICaseBulkActionWithItems struct {
+	APICaseBulkAction
+	Items []APICaseBulkActionItem `json:"items"`
+}
+
+func AdaptCaseBulkActionWithItemsDto(b models.CaseBulkActionWithItems) APICaseBulkActionWithItems {
+	return APICaseBulkActionWithItems{
+		APICaseBulkAction: AdaptCaseBulkActionDto(b.CaseBulkAction),
+		Items: pure_utils.Map(b.Items, func(item models.CaseBulkActionItem) APICaseBulkActionItem {
+			return APICaseBulkActionItem{
+				CaseId:    item.CaseId,
+				Status:    string(item.Status),
+				Error:     item.Error,
+				UpdatedAt: item.UpdatedAt,
+			}
+		}),
+	}
+}
@@ -0,0 +1,68 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/checkmarble/marble-backend/models"
+)
+
+type APICaseComment struct {
+	Id               string     `json:"id"`
+	CaseId           string     `json:"case_id"`
+	ParentId         *string    `json:"parent_id"`
+	AuthorId         *string    `json:"author_id"`
+	Comment          string     `json:"comment"`
+	MentionedUserIds []string   `json:"mentioned_user_ids"`
+	CreatedAt        time.Time  `json:"created_at"`
+	EditedAt         *time.Time `json:"edited_at"`
+	Deleted          bool       `json:"deleted"`
+}
+
+// AdaptCaseCommentDto hides the content of deleted comments, which are only returned to display their replies
+func AdaptCaseCommentDto(c models.CaseComment) APICaseComment {
+	comment := APICaseComment{
+		Id:               c.Id,
+		CaseId:           c.CaseId,
+		ParentId:         c.ParentId,
+		AuthorId:         c.AuthorId,
+		Comment:          c.Body,
+		MentionedUserIds: c.MentionedUserIds,
+		CreatedAt:        c.CreatedAt,
+		EditedAt:         c.EditedAt,
+		Deleted:          c.DeletedAt != nil,
+	}
+	if comment.MentionedUserIds == nil || comment.Deleted {
+		comment.MentionedUserIds = []string{}
+	}
+	if comment.Deleted {
+		comment.Comment = ""
+	}
+	return comment
+}
+
+type UpdateCaseCommentBody struct {
+	Comment          string   `json:"comment" binding:"required"`
+	MentionedUserIds []string `json:"mentioned_user_ids"`
+}
+
+type APICaseCommentVersion struct {
+	Id               string    `json:"id"`
+	Comment          string    `json:"comment"`
+	MentionedUserIds []string  `json:"mentioned_user_ids"`
+	EditedBy         *string   `json:"edited_by"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+func AdaptCaseCommentVersionDto(v models.CaseCommentVersion) APICaseCommentVersion {
+	version := APICaseCommentVersion{
+		Id:               v.Id,
+		Comment:          v.Body,
+		MentionedUserIds: v.MentionedUserIds,
+		EditedBy:         v.EditedBy,
+		CreatedAt:        v.CreatedAt,
+	}
+	if version.MentionedUserIds == nil {
+		version.MentionedUserIds = []string{}
+	}
+	return version
+}
@@ -0,0 +1,32 @@
+package dto
+
+import (
+	"testing"
+	"time"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdaptCaseCommentDto(t *testing.T) {
+	comment := models.CaseComment{
+		Id:               "comment-id",
+		CaseId:           "case-id",
+		AuthorId:         utils.Ptr("user-id"),
+		Body:             "please check @jane",
+		MentionedUserIds: []string{"jane-id"},
+		CreatedAt:        time.Now(),
+	}
+
+	out := AdaptCaseCommentDto(comment)
+	assert.Equal(t, "please check @jane", out.Comment)
+	assert.Equal(t, []string{"jane-id"}, out.MentionedUserIds)
+	assert.False(t, out.Deleted)
+
+	comment.DeletedAt = utils.Ptr(time.Now())
+	out = AdaptCaseCommentDto(comment)
+	assert.Empty(t, out.Comment)
+	assert.Empty(t, out.MentionedUserIds)
+	assert.True(t, out.Deleted)
+}
@@ -9,20 +9,26 @@ import (
 )
 
 type APICase struct {
-	Id             string               `json:"id"`
-	Contributors   []APICaseContributor `json:"contributors"`
-	CreatedAt      time.Time            `json:"created_at"`
-	DecisionsCount int                  `json:"decisions_count"`
-	Events         []APICaseEvent       `json:"events"`
-	InboxId        string               `json:"inbox_id"`
-	Name           string               `json:"name"`
-	Status         string               `json:"status"`
-	Outcome        string               `json:"outcome"`
-	Tags           []APICaseTag         `json:"tags"`
-	Files          []APICaseFile        `json:"files"`
-	SnoozedUntil   *time.Time           `json:"snoozed_until,omitempty"`
-	AssignedTo     *string              `json:"assigned_to,omitempty"`
-	Boost          string               `json:"boost,omitempty"`
+	Id               string               `json:"id"`
+	Contributors     []APICaseContributor `json:"contributors"`
+	CreatedAt        time.Time            `json:"created_at"`
+	DecisionsCount   int                  `json:"decisions_count"`
+	Events           []APICaseEvent       `json:"events"`
+	InboxId          string               `json:"inbox_id"`
+	Name             string               `json:"name"`
+	Status           string               `json:"status"`
+	Outcome          string               `json:"outcome"`
+	Tags             []APICaseTag         `json:"tags"`
+	Files            []APICaseFile        `json:"files"`
+	SnoozedUntil     *time.Time           `json:"snoozed_until,omitempty"`
+	AssignedTo       *string              `json:"assigned_to,omitempty"`
+	Boost            string               `json:"boost,omitempty"`
+	SlaPolicyId      *string              `json:"sla_policy_id,omitempty"`
+	FirstActionDueAt *time.Time           `json:"first_action_due_at,omitempty"`
+	CloseDueAt       *time.Time           `json:"close_due_at,omitempty"`
+	DueAt            *time.Time           `json:"due_at,omitempty"`
+	SlaBreachedAt    *time.Time           `json:"sla_breached_at,omitempty"`
+	MergedIntoId     *string              `json:"merged_into_id,omitempty"`
 }
 
 type APICaseWithDecisions struct {
@@ -32,18 +38,24 @@ type APICaseWithDecisions struct {
 
 func AdaptCaseDto(c models.Case) APICase {
 	dto := APICase{
-		Id:             c.Id,
-		Contributors:   pure_utils.Map(c.Contributors, NewAPICaseContributor),
-		CreatedAt:      c.CreatedAt,
-		DecisionsCount: c.DecisionsCount,
-		Events:         pure_utils.Map(c.Events, NewAPICaseEvent),
-		InboxId:        c.InboxId,
-		Name:           c.Name,
-		Status:         c.Status.EnrichedStatus(c.SnoozedUntil, c.Boost),
-		Outcome:        string(c.Outcome),
-		Tags:           pure_utils.Map(c.Tags, NewAPICaseTag),
-		Files:          pure_utils.Map(c.Files, NewAPICaseFile),
-		Boost:          c.Boost.String(),
+		Id:               c.Id,
+		Contributors:     pure_utils.Map(c.Contributors, NewAPICaseContributor),
+		CreatedAt:        c.CreatedAt,
+		DecisionsCount:   c.DecisionsCount,
+		Events:           pure_utils.Map(c.Events, NewAPICaseEvent),
+		InboxId:          c.InboxId,
+		Name:             c.Name,
+		Status:           c.Status.EnrichedStatus(c.SnoozedUntil, c.Boost),
+		Outcome:          string(c.Outcome),
+		Tags:             pure_utils.Map(c.Tags, NewAPICaseTag),
+		Files:            pure_utils.Map(c.Files, NewAPICaseFile),
+		Boost:            c.Boost.String(),
+		SlaPolicyId:      c.SlaPolicyId,
+		FirstActionDueAt: c.FirstActionDueAt,
+		CloseDueAt:       c.CloseDueAt,
+		DueAt:            c.DueAt,
+		SlaBreachedAt:    c.SlaBreachedAt,
+		MergedIntoId:     c.MergedIntoId,
 	}
 
 	if c.SnoozedUntil != nil && c.SnoozedUntil.After(time.Now()) {
@@ -92,14 +104,27 @@ type UpdateCaseBody struct {
 	Name    string `json:"name"`
 	Status  string `json:"status"`
 	Outcome string `json:"outcome"`
+	// Comment is saved with the status update, some transitions of a case workflow require it
+	Comment string `json:"comment"`
 }
 
 type AddDecisionToCaseBody struct {
 	DecisionIds []string `json:"decision_ids" binding:"required"`
 }
 
+type MergeCaseBody struct {
+	TargetCaseId string `json:"target_case_id" binding:"required,uuid"`
+}
+
+type SplitCaseBody struct {
+	DecisionIds []string `json:"decision_ids" binding:"required,min=1,dive,uuid"`
+	Name        string   `json:"name"`
+}
+
 type CreateCaseCommentBody struct {
-	Comment string `json:"comment" binding:"required"`
+	Comment          string   `json:"comment" binding:"required"`
+	ParentId         *string  `json:"parent_id" binding:"omitempty,uuid"`
+	MentionedUserIds []string `json:"mentioned_user_ids"`
 }
 
 type CaseFilters struct {
@@ -111,6 +136,16 @@ type CaseFilters struct {
 	IncludeSnoozed  bool          `form:"include_snoozed"`
 	ExcludeAssigned bool          `form:"exclude_assigned"`
 	AssigneeId      models.UserId `form:"assignee_id"`
+	SlaBreached     *bool         `form:"sla_breached"`
+	DueBefore       time.Time     `form:"due_before"`
+	Query           string        `form:"q"`
+	TagIds          []string      `form:"tag_id[]" binding:"dive,uuid"`
+	Outcomes        []string      `form:"outcome[]"`
+	ScenarioIds     []string      `form:"scenario_id[]" binding:"dive,uuid"`
+	RuleIds         []string      `form:"rule_id[]" binding:"dive,uuid"`
+	MinScore        *int          `form:"min_score"`
+	MaxScore        *int          `form:"max_score"`
+	SlaStatus       string        `form:"sla_status"`
 }
 
 type ReviewCaseDecisionsBody struct {
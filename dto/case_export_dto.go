@@ -0,0 +1,44 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/checkmarble/marble-backend/models"
+)
+
+type APICaseExport struct {
+	Id                string    `json:"id"`
+	CaseId            string    `json:"case_id"`
+	Status            string    `json:"status"`
+	NbFiles           int       `json:"nb_files"`
+	SizeBytes         int64     `json:"size_bytes"`
+	RequestedByUserId *string   `json:"requested_by_user_id"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+	FileUrl           *string   `json:"file_url,omitempty"`
+}
+
+func AdaptCaseExportDto(e models.CaseExport) APICaseExport {
+	var userId *string
+	if e.RequestedByUserId != nil {
+		id := string(*e.RequestedByUserId)
+		userId = &id
+	}
+
+	return APICaseExport{
+		Id:                e.Id,
+		CaseId:            e.CaseId,
+		Status:            string(e.Status),
+		NbFiles:           e.NbFiles,
+		SizeBytes:         e.SizeBytes,
+		RequestedByUserId: userId,
+		CreatedAt:         e.CreatedAt,
+		UpdatedAt:         e.UpdatedAt,
+	}
+}
+
+func AdaptCaseExportWithUrlDto(e models.CaseExportWithUrl) APICaseExport {
+	export := AdaptCaseExportDto(e.CaseExport)
+	export.FileUrl = e.FileUrl
+	return export
+}
@@ -7,17 +7,19 @@ import (
 )
 
 type APICaseFile struct {
-	Id        string    `json:"id"`
-	CaseId    string    `json:"case_id"`
-	CreatedAt time.Time `json:"created_at"`
-	FileName  string    `json:"file_name"`
+	Id         string    `json:"id"`
+	CaseId     string    `json:"case_id"`
+	CreatedAt  time.Time `json:"created_at"`
+	FileName   string    `json:"file_name"`
+	ScanStatus string    `json:"scan_status"`
 }
 
 func NewAPICaseFile(caseFile models.CaseFile) APICaseFile {
 	return APICaseFile{
-		Id:        caseFile.Id,
-		CaseId:    caseFile.CaseId,
-		CreatedAt: caseFile.CreatedAt,
-		FileName:  caseFile.FileName,
+		Id:         caseFile.Id,
+		CaseId:     caseFile.CaseId,
+		CreatedAt:  caseFile.CreatedAt,
+		FileName:   caseFile.FileName,
+		ScanStatus: string(caseFile.ScanStatus),
 	}
 }
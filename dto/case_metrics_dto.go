@@ -0,0 +1,100 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/pure_utils"
+)
+
+type CaseMetricsQuery struct {
+	StartDate time.Time `form:"start_date" binding:"required"`
+	EndDate   time.Time `form:"end_date" binding:"required"`
+	InboxIds  []string  `form:"inbox_id[]" binding:"dive,uuid"`
+	Timezone  string    `form:"timezone"`
+}
+
+type CaseMetricsExportQuery struct {
+	CaseMetricsQuery
+	Metric string `form:"metric" binding:"required"`
+}
+
+func AdaptCaseMetricsFilters(organizationId string, query CaseMetricsQuery) models.CaseMetricsFilters {
+	return models.CaseMetricsFilters{
+		OrganizationId: organizationId,
+		InboxIds:       query.InboxIds,
+		StartDate:      query.StartDate,
+		EndDate:        query.EndDate,
+		Timezone:       query.Timezone,
+	}
+}
+
+type APICaseVolumeMetric struct {
+	Day      string `json:"day"`
+	NbOpened int    `json:"nb_opened"`
+	NbClosed int    `json:"nb_closed"`
+}
+
+type APICaseTimeToCloseMetric struct {
+	Id                       string  `json:"id"`
+	NbClosed                 int     `json:"nb_closed"`
+	MedianTimeToCloseSeconds float64 `json:"median_time_to_close_seconds"`
+}
+
+type APICaseBacklogAgeMetric struct {
+	Age     string `json:"age"`
+	NbCases int    `json:"nb_cases"`
+}
+
+type APICaseOutcomeMetric struct {
+	Outcome string `json:"outcome"`
+	NbCases int    `json:"nb_cases"`
+}
+
+type APICaseEscalationMetric struct {
+	InboxId        string  `json:"inbox_id"`
+	NbClosed       int     `json:"nb_closed"`
+	NbEscalated    int     `json:"nb_escalated"`
+	EscalationRate float64 `json:"escalation_rate"`
+}
+
+type APICaseMetrics struct {
+	Volume               []APICaseVolumeMetric      `json:"volume"`
+	TimeToCloseByInbox   []APICaseTimeToCloseMetric `json:"time_to_close_by_inbox"`
+	TimeToCloseByAnalyst []APICaseTimeToCloseMetric `json:"time_to_close_by_analyst"`
+	BacklogAge           []APICaseBacklogAgeMetric  `json:"backlog_age"`
+	Outcomes             []APICaseOutcomeMetric     `json:"outcomes"`
+	Escalations          []APICaseEscalationMetric  `json:"escalations"`
+}
+
+func AdaptCaseMetricsDto(m models.CaseMetrics) APICaseMetrics {
+	adaptTimeToClose := func(t models.CaseTimeToCloseMetric) APICaseTimeToCloseMetric {
+		return APICaseTimeToCloseMetric{
+			Id:                       t.GroupId,
+			NbClosed:                 t.NbClosed,
+			MedianTimeToCloseSeconds: t.MedianTimeToClose.Seconds(),
+		}
+	}
+
+	return APICaseMetrics{
+		Volume: pure_utils.Map(m.Volume, func(v models.CaseVolumeMetric) APICaseVolumeMetric {
+			return APICaseVolumeMetric{Day: v.Day.Format(time.DateOnly), NbOpened: v.NbOpened, NbClosed: v.NbClosed}
+		}),
+		TimeToCloseByInbox:   pure_utils.Map(m.TimeToCloseByInbox, adaptTimeToClose),
+		TimeToCloseByAnalyst: pure_utils.Map(m.TimeToCloseByAnalyst, adaptTimeToClose),
+		BacklogAge: pure_utils.Map(m.BacklogAge, func(b models.CaseBacklogAgeMetric) APICaseBacklogAgeMetric {
+			return APICaseBacklogAgeMetric{Age: b.Bucket, NbCases: b.NbCases}
+		}),
+		Outcomes: pure_utils.Map(m.Outcomes, func(o models.CaseOutcomeMetric) APICaseOutcomeMetric {
+			return APICaseOutcomeMetric{Outcome: string(o.Outcome), NbCases: o.NbCases}
+		}),
+		Escalations: pure_utils.Map(m.Escalations, func(e models.CaseEscalationMetric) APICaseEscalationMetric {
+			return APICaseEscalationMetric{
+				InboxId:        e.InboxId,
+				NbClosed:       e.NbClosed,
+				NbEscalated:    e.NbEscalated,
+				EscalationRate: e.EscalationRate(),
+			}
+		}),
+	}
+}
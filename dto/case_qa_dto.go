@@ -0,0 +1,184 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/utils"
+)
+
+type InboxQaRuleBody struct {
+	Name        string   `json:"name" binding:"required"`
+	Priority    int      `json:"priority"`
+	CaseOutcome *string  `json:"case_outcome"`
+	TagId       *string  `json:"tag_id" binding:"omitempty,uuid"`
+	SampleRate  float64  `json:"sample_rate"`
+	ReviewerIds []string `json:"reviewer_ids" binding:"dive,uuid"`
+}
+
+func AdaptInboxQaRuleInput(body InboxQaRuleBody) models.InboxQaRuleInput {
+	input := models.InboxQaRuleInput{
+		Name:        body.Name,
+		Priority:    body.Priority,
+		TagId:       body.TagId,
+		SampleRate:  body.SampleRate,
+		ReviewerIds: body.ReviewerIds,
+	}
+	if body.CaseOutcome != nil {
+		input.CaseOutcome = utils.Ptr(models.CaseOutcome(*body.CaseOutcome))
+	}
+	return input
+}
+
+type APIInboxQaRule struct {
+	Id          string    `json:"id"`
+	InboxId     string    `json:"inbox_id"`
+	Name        string    `json:"name"`
+	Priority    int       `json:"priority"`
+	CaseOutcome *string   `json:"case_outcome"`
+	TagId       *string   `json:"tag_id"`
+	SampleRate  float64   `json:"sample_rate"`
+	ReviewerIds []string  `json:"reviewer_ids"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+func AdaptInboxQaRuleDto(r models.InboxQaRule) APIInboxQaRule {
+	out := APIInboxQaRule{
+		Id:          r.Id,
+		InboxId:     r.InboxId,
+		Name:        r.Name,
+		Priority:    r.Priority,
+		TagId:       r.TagId,
+		SampleRate:  r.SampleRate,
+		ReviewerIds: r.ReviewerIds,
+		CreatedAt:   r.CreatedAt,
+		UpdatedAt:   r.UpdatedAt,
+	}
+	if out.ReviewerIds == nil {
+		out.ReviewerIds = []string{}
+	}
+	if r.CaseOutcome != nil {
+		out.CaseOutcome = utils.Ptr(string(*r.CaseOutcome))
+	}
+	return out
+}
+
+type CaseQaReviewListQuery struct {
+	// Mine lists the reviews assigned to the user, the other reviews can only be listed by the admins of their inbox
+	Mine       bool   `form:"mine"`
+	ReviewerId string `form:"reviewer_id" binding:"omitempty,uuid"`
+	InboxId    string `form:"inbox_id" binding:"omitempty,uuid"`
+	CaseId     string `form:"case_id" binding:"omitempty,uuid"`
+	Status     string `form:"status" binding:"omitempty,oneof=pending completed"`
+}
+
+func AdaptCaseQaReviewFilters(query CaseQaReviewListQuery, userId models.UserId) models.CaseQaReviewFilters {
+	filters := models.CaseQaReviewFilters{}
+	if query.Mine {
+		filters.ReviewerId = utils.Ptr(string(userId))
+	} else if query.ReviewerId != "" {
+		filters.ReviewerId = &query.ReviewerId
+	}
+	if query.InboxId != "" {
+		filters.InboxId = &query.InboxId
+	}
+	if query.CaseId != "" {
+		filters.CaseId = &query.CaseId
+	}
+	if query.Status != "" {
+		filters.Status = utils.Ptr(models.CaseQaReviewStatus(query.Status))
+	}
+	return filters
+}
+
+type CompleteCaseQaReviewBody struct {
+	Verdict         string  `json:"verdict" binding:"required"`
+	ReviewerOutcome *string `json:"reviewer_outcome"`
+	Comment         string  `json:"comment"`
+}
+
+func AdaptCaseQaReviewCompletion(body CompleteCaseQaReviewBody) models.CaseQaReviewCompletion {
+	input := models.CaseQaReviewCompletion{
+		Verdict: models.CaseQaVerdict(body.Verdict),
+		Comment: body.Comment,
+	}
+	if body.ReviewerOutcome != nil {
+		input.ReviewerOutcome = utils.Ptr(models.CaseOutcome(*body.ReviewerOutcome))
+	}
+	return input
+}
+
+type APICaseQaReview struct {
+	Id              string     `json:"id"`
+	InboxId         string     `json:"inbox_id"`
+	CaseId          string     `json:"case_id"`
+	RuleId          *string    `json:"rule_id"`
+	AnalystId       *string    `json:"analyst_id"`
+	ReviewerId      string     `json:"reviewer_id"`
+	CaseOutcome     string     `json:"case_outcome"`
+	Status          string     `json:"status"`
+	Verdict         *string    `json:"verdict"`
+	ReviewerOutcome *string    `json:"reviewer_outcome"`
+	Comment         string     `json:"comment"`
+	CreatedAt       time.Time  `json:"created_at"`
+	CompletedAt     *time.Time `json:"completed_at"`
+}
+
+func AdaptCaseQaReviewDto(r models.CaseQaReview) APICaseQaReview {
+	out := APICaseQaReview{
+		Id:          r.Id,
+		InboxId:     r.InboxId,
+		CaseId:      r.CaseId,
+		RuleId:      r.RuleId,
+		AnalystId:   r.AnalystId,
+		ReviewerId:  r.ReviewerId,
+		CaseOutcome: string(r.CaseOutcome),
+		Status:      string(r.Status),
+		Comment:     r.Comment,
+		CreatedAt:   r.CreatedAt,
+		CompletedAt: r.CompletedAt,
+	}
+	if r.Verdict != nil {
+		out.Verdict = utils.Ptr(string(*r.Verdict))
+	}
+	if r.ReviewerOutcome != nil {
+		out.ReviewerOutcome = utils.Ptr(string(*r.ReviewerOutcome))
+	}
+	return out
+}
+
+type CaseQaScoreQuery struct {
+	InboxId   string    `form:"inbox_id" binding:"omitempty,uuid"`
+	StartDate time.Time `form:"start_date" binding:"required"`
+	EndDate   time.Time `form:"end_date" binding:"required"`
+}
+
+func AdaptCaseQaScoreFilters(query CaseQaScoreQuery) models.CaseQaScoreFilters {
+	filters := models.CaseQaScoreFilters{
+		StartDate: query.StartDate,
+		EndDate:   query.EndDate,
+	}
+	if query.InboxId != "" {
+		filters.InboxId = &query.InboxId
+	}
+	return filters
+}
+
+type APICaseQaScore struct {
+	AnalystId       string  `json:"analyst_id"`
+	NbReviews       int     `json:"nb_reviews"`
+	NbAgreements    int     `json:"nb_agreements"`
+	NbDisagreements int     `json:"nb_disagreements"`
+	AgreementRate   float64 `json:"agreement_rate"`
+}
+
+func AdaptCaseQaScoreDto(s models.CaseQaScore) APICaseQaScore {
+	return APICaseQaScore{
+		AnalystId:       s.AnalystId,
+		NbReviews:       s.NbReviews,
+		NbAgreements:    s.NbAgreements,
+		NbDisagreements: s.NbDisagreements,
+		AgreementRate:   s.AgreementRate(),
+	}
+}
@@ -0,0 +1,59 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/checkmarble/marble-backend/models"
+)
+
+type CaseRoutingRuleBody struct {
+	Name    string   `json:"name" binding:"required"`
+	Formula NodeDto  `json:"formula" binding:"required"`
+	InboxId string   `json:"inbox_id" binding:"required,uuid"`
+	TagIds  []string `json:"tag_ids" binding:"omitempty,dive,uuid"`
+}
+
+type UpdateCaseRoutingRulesBody struct {
+	Rules []CaseRoutingRuleBody `json:"rules" binding:"dive"`
+}
+
+func AdaptCaseRoutingRuleInput(body CaseRoutingRuleBody) (models.CaseRoutingRuleInput, error) {
+	formula, err := AdaptASTNode(body.Formula)
+	if err != nil {
+		return models.CaseRoutingRuleInput{}, err
+	}
+
+	return models.CaseRoutingRuleInput{
+		Name:    body.Name,
+		Formula: &formula,
+		InboxId: body.InboxId,
+		TagIds:  body.TagIds,
+	}, nil
+}
+
+type APICaseRoutingRule struct {
+	Id        string    `json:"id"`
+	Name      string    `json:"name"`
+	Formula   *NodeDto  `json:"formula"`
+	InboxId   string    `json:"inbox_id"`
+	TagIds    []string  `json:"tag_ids"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func AdaptCaseRoutingRuleDto(rule models.CaseRoutingRule) (APICaseRoutingRule, error) {
+	out := APICaseRoutingRule{
+		Id:        rule.Id,
+		Name:      rule.Name,
+		InboxId:   rule.InboxId,
+		TagIds:    rule.TagIds,
+		CreatedAt: rule.CreatedAt,
+	}
+	if rule.Formula != nil {
+		formula, err := AdaptNodeDto(*rule.Formula)
+		if err != nil {
+			return APICaseRoutingRule{}, err
+		}
+		out.Formula = &formula
+	}
+	return out, nil
+}
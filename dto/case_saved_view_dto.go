@@ -0,0 +1,152 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/pure_utils"
+)
+
+type APICaseViewFilters struct {
+	Query           string        `json:"q"`
+	Name            string        `json:"name"`
+	Statuses        []string      `json:"statuses"`
+	InboxIds        []string      `json:"inbox_ids" binding:"dive,uuid"`
+	TagIds          []string      `json:"tag_ids" binding:"dive,uuid"`
+	Outcomes        []string      `json:"outcomes"`
+	ScenarioIds     []string      `json:"scenario_ids" binding:"dive,uuid"`
+	RuleIds         []string      `json:"rule_ids" binding:"dive,uuid"`
+	MinScore        *int          `json:"min_score"`
+	MaxScore        *int          `json:"max_score"`
+	SlaStatus       string        `json:"sla_status"`
+	IncludeSnoozed  bool          `json:"include_snoozed"`
+	ExcludeAssigned bool          `json:"exclude_assigned"`
+	AssigneeId      models.UserId `json:"assignee_id"`
+}
+
+func emptyIfNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
+
+func AdaptCaseViewFiltersDto(f models.CaseViewFilters) APICaseViewFilters {
+	return APICaseViewFilters{
+		Query:           f.Query,
+		Name:            f.Name,
+		Statuses:        pure_utils.Map(f.Statuses, func(s models.CaseStatus) string { return string(s) }),
+		InboxIds:        emptyIfNil(f.InboxIds),
+		TagIds:          emptyIfNil(f.TagIds),
+		Outcomes:        pure_utils.Map(f.Outcomes, func(o models.CaseOutcome) string { return string(o) }),
+		ScenarioIds:     emptyIfNil(f.ScenarioIds),
+		RuleIds:         emptyIfNil(f.RuleIds),
+		MinScore:        f.MinScore,
+		MaxScore:        f.MaxScore,
+		SlaStatus:       string(f.SlaStatus),
+		IncludeSnoozed:  f.IncludeSnoozed,
+		ExcludeAssigned: f.ExcludeAssigned,
+		AssigneeId:      f.AssigneeId,
+	}
+}
+
+func AdaptCaseViewFilters(f APICaseViewFilters) models.CaseViewFilters {
+	return models.CaseViewFilters{
+		Query:           f.Query,
+		Name:            f.Name,
+		Statuses:        pure_utils.Map(f.Statuses, func(s string) models.CaseStatus { return models.CaseStatus(s) }),
+		InboxIds:        f.InboxIds,
+		TagIds:          f.TagIds,
+		Outcomes:        pure_utils.Map(f.Outcomes, func(o string) models.CaseOutcome { return models.CaseOutcome(o) }),
+		ScenarioIds:     f.ScenarioIds,
+		RuleIds:         f.RuleIds,
+		MinScore:        f.MinScore,
+		MaxScore:        f.MaxScore,
+		SlaStatus:       models.CaseSlaStatus(f.SlaStatus),
+		IncludeSnoozed:  f.IncludeSnoozed,
+		ExcludeAssigned: f.ExcludeAssigned,
+		AssigneeId:      f.AssigneeId,
+	}
+}
+
+type APICaseSavedView struct {
+	Id        string             `json:"id"`
+	OwnerId   string             `json:"owner_id"`
+	InboxId   *string            `json:"inbox_id"`
+	Name      string             `json:"name"`
+	Filters   APICaseViewFilters `json:"filters"`
+	Sorting   string             `json:"sorting"`
+	Order     string             `json:"order"`
+	CreatedAt time.Time          `json:"created_at"`
+	UpdatedAt time.Time          `json:"updated_at"`
+}
+
+func AdaptCaseSavedViewDto(v models.CaseSavedView) APICaseSavedView {
+	return APICaseSavedView{
+		Id:        v.Id,
+		OwnerId:   v.OwnerId,
+		InboxId:   v.InboxId,
+		Name:      v.Name,
+		Filters:   AdaptCaseViewFiltersDto(v.Filters),
+		Sorting:   v.Sorting.String(),
+		Order:     v.Order.String(),
+		CreatedAt: v.CreatedAt,
+		UpdatedAt: v.UpdatedAt,
+	}
+}
+
+type CreateCaseSavedViewBody struct {
+	Name    string             `json:"name" binding:"required"`
+	InboxId *string            `json:"inbox_id" binding:"omitempty,uuid"`
+	Filters APICaseViewFilters `json:"filters"`
+	Sorting string             `json:"sorting"`
+	Order   string             `json:"order"`
+}
+
+func AdaptCaseSavedViewInput(organizationId, ownerId string, body CreateCaseSavedViewBody) models.CaseSavedViewInput {
+	input := models.CaseSavedViewInput{
+		OrganizationId: organizationId,
+		OwnerId:        ownerId,
+		InboxId:        body.InboxId,
+		Name:           body.Name,
+		Filters:        AdaptCaseViewFilters(body.Filters),
+		Sorting:        models.CasesSortingCreatedAt,
+		Order:          models.SortingOrderDesc,
+	}
+	if body.Sorting != "" {
+		input.Sorting = models.SortingFieldFrom(body.Sorting)
+	}
+	if body.Order != "" {
+		input.Order = models.SortingOrderFrom(body.Order)
+	}
+	return input
+}
+
+type UpdateCaseSavedViewBody struct {
+	Name    *string             `json:"name"`
+	Filters *APICaseViewFilters `json:"filters"`
+	Sorting *string             `json:"sorting"`
+	Order   *string             `json:"order"`
+	// InboxId shares the view with an inbox, or makes it private again when empty
+	InboxId *string `json:"inbox_id" binding:"omitempty,uuid|eq="`
+}
+
+func AdaptUpdateCaseSavedViewInput(body UpdateCaseSavedViewBody) models.UpdateCaseSavedViewInput {
+	input := models.UpdateCaseSavedViewInput{
+		Name:    body.Name,
+		InboxId: body.InboxId,
+	}
+	if body.Filters != nil {
+		filters := AdaptCaseViewFilters(*body.Filters)
+		input.Filters = &filters
+	}
+	if body.Sorting != nil {
+		sorting := models.SortingFieldFrom(*body.Sorting)
+		input.Sorting = &sorting
+	}
+	if body.Order != nil {
+		order := models.SortingOrderFrom(*body.Order)
+		input.Order = &order
+	}
+	return input
+}
@@ -0,0 +1,96 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/pure_utils"
+)
+
+type ChangeFeedQuery struct {
+	Cursor      string `form:"cursor"`
+	Limit       int    `form:"limit" binding:"omitempty,min=1,max=1000"`
+	WaitSeconds int    `form:"wait_seconds" binding:"omitempty,min=0,max=30"`
+}
+
+type APIChangeEvent struct {
+	Cursor     string          `json:"cursor"`
+	EntityType string          `json:"entity_type"`
+	EntityId   string          `json:"entity_id"`
+	Operation  string          `json:"operation"`
+	Data       json.RawMessage `json:"data"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+func AdaptChangeEventDto(e models.ChangeEvent) APIChangeEvent {
+	return APIChangeEvent{
+		Cursor:     e.Cursor.String(),
+		EntityType: string(e.EntityType),
+		EntityId:   e.EntityId,
+		Operation:  string(e.Operation),
+		Data:       e.Data,
+		CreatedAt:  e.CreatedAt,
+	}
+}
+
+type APIChangeFeedPage struct {
+	Events     []APIChangeEvent `json:"events"`
+	NextCursor string           `json:"next_cursor"`
+	HasMore    bool             `json:"has_more"`
+}
+
+func AdaptChangeFeedPageDto(page models.ChangeFeedPage) APIChangeFeedPage {
+	return APIChangeFeedPage{
+		Events:     pure_utils.Map(page.Events, AdaptChangeEventDto),
+		NextCursor: page.NextCursor.String(),
+		HasMore:    page.HasMore,
+	}
+}
+
+type UpdateChangeFeedConfigBody struct {
+	Enabled     bool    `json:"enabled"`
+	SinkType    *string `json:"sink_type"`
+	SinkAddress string  `json:"sink_address"`
+	SinkTopic   string  `json:"sink_topic"`
+}
+
+func AdaptUpdateChangeFeedConfigInput(orgId string, body UpdateChangeFeedConfigBody) models.UpdateChangeFeedConfigInput {
+	var sinkType *models.ChangeFeedSinkType
+	if body.SinkType != nil {
+		t := models.ChangeFeedSinkType(*body.SinkType)
+		sinkType = &t
+	}
+	return models.UpdateChangeFeedConfigInput{
+		OrgId:       orgId,
+		Enabled:     body.Enabled,
+		SinkType:    sinkType,
+		SinkAddress: body.SinkAddress,
+		SinkTopic:   body.SinkTopic,
+	}
+}
+
+type APIChangeFeedConfig struct {
+	Enabled     bool      `json:"enabled"`
+	SinkType    *string   `json:"sink_type"`
+	SinkAddress string    `json:"sink_address"`
+	SinkTopic   string    `json:"sink_topic"`
+	SinkCursor  string    `json:"sink_cursor"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+func AdaptChangeFeedConfigDto(c models.ChangeFeedConfig) APIChangeFeedConfig {
+	var sinkType *string
+	if c.SinkType != nil {
+		t := string(*c.SinkType)
+		sinkType = &t
+	}
+	return APIChangeFeedConfig{
+		Enabled:     c.Enabled,
+		SinkType:    sinkType,
+		SinkAddress: c.SinkAddress,
+		SinkTopic:   c.SinkTopic,
+		SinkCursor:  c.SinkCursor.String(),
+		UpdatedAt:   c.UpdatedAt,
+	}
+}
@@ -85,6 +85,40 @@ type ClientObjectMetadata struct {
 	ObjectType string    `json:"object_type"`
 }
 
+type ClientDataReadParams struct {
+	AsOf time.Time `form:"as_of"`
+}
+
+type ClientObjectVersion struct {
+	ObjectType string                    `json:"object_type"`
+	ValidFrom  time.Time                 `json:"valid_from"`
+	ValidUntil *time.Time                `json:"valid_until"`
+	Data       map[string]any            `json:"data"`
+	Changes    []ClientObjectFieldChange `json:"changes"`
+}
+
+type ClientObjectFieldChange struct {
+	FieldName     string `json:"field_name"`
+	PreviousValue any    `json:"previous_value"`
+	NewValue      any    `json:"new_value"`
+}
+
+func AdaptClientObjectVersionDto(v models.ClientObjectVersion) ClientObjectVersion {
+	return ClientObjectVersion{
+		ObjectType: v.ObjectType,
+		ValidFrom:  v.ValidFrom,
+		ValidUntil: v.ValidUntil,
+		Data:       v.Data,
+		Changes: pure_utils.Map(v.Changes, func(c models.ClientObjectFieldChange) ClientObjectFieldChange {
+			return ClientObjectFieldChange{
+				FieldName:     c.FieldName,
+				PreviousValue: c.PreviousValue,
+				NewValue:      c.NewValue,
+			}
+		}),
+	}
+}
+
 type ClientDataListResponse struct {
 	Data       []ClientObjectDetail     `json:"data"`
 	Pagination ClientDataListPagination `json:"pagination"`
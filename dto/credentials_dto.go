@@ -10,6 +10,7 @@ type Identity struct {
 	Email      string `json:"email,omitempty"`
 	FirstName  string `json:"first_name,omitempty"`
 	LastName   string `json:"last_name,omitempty"`
+	ApiKeyId   string `json:"api_key_id,omitempty"`
 	ApiKeyName string `json:"api_key_name,omitempty"`
 }
 
@@ -36,6 +37,7 @@ func AdaptCredentialDto(creds models.Credentials) (Credentials, error) {
 			Email:      creds.ActorIdentity.Email,
 			FirstName:  creds.ActorIdentity.FirstName,
 			LastName:   creds.ActorIdentity.LastName,
+			ApiKeyId:   creds.ActorIdentity.ApiKeyId,
 			ApiKeyName: creds.ActorIdentity.ApiKeyName,
 		},
 		OrganizationId: creds.OrganizationId,
@@ -52,6 +54,7 @@ func AdaptCredential(dto Credentials) models.Credentials {
 			Email:      dto.ActorIdentity.Email,
 			FirstName:  dto.ActorIdentity.FirstName,
 			LastName:   dto.ActorIdentity.LastName,
+			ApiKeyId:   dto.ActorIdentity.ApiKeyId,
 			ApiKeyName: dto.ActorIdentity.ApiKeyName,
 		},
 		OrganizationId: dto.OrganizationId,
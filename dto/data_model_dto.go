@@ -18,15 +18,29 @@ type LinkToSingle struct {
 }
 
 type Field struct {
-	ID                string `json:"id"`
-	DataType          string `json:"data_type"`
-	Description       string `json:"description"`
-	IsEnum            bool   `json:"is_enum"`
-	Name              string `json:"name"`
-	Nullable          bool   `json:"nullable"`
-	TableId           string `json:"table_id"`
-	Values            []any  `json:"values,omitempty"`
-	UnicityConstraint string `json:"unicity_constraint"`
+	ID                string           `json:"id"`
+	DataType          string           `json:"data_type"`
+	Description       string           `json:"description"`
+	IsEnum            bool             `json:"is_enum"`
+	Name              string           `json:"name"`
+	Nullable          bool             `json:"nullable"`
+	TableId           string           `json:"table_id"`
+	Values            []any            `json:"values,omitempty"`
+	UnicityConstraint string           `json:"unicity_constraint"`
+	Constraints       FieldConstraints `json:"constraints"`
+}
+
+type FieldConstraints struct {
+	Min           *float64 `json:"min,omitempty"`
+	Max           *float64 `json:"max,omitempty"`
+	MaxLength     *int     `json:"max_length,omitempty"`
+	Regex         *string  `json:"regex,omitempty"`
+	AllowedValues []any    `json:"allowed_values,omitempty"`
+	NotInFuture   bool     `json:"not_in_future,omitempty"`
+}
+
+func AdaptFieldConstraintsInput(c FieldConstraints) models.FieldConstraints {
+	return models.FieldConstraints(c)
 }
 
 type NavigationOption struct {
@@ -44,12 +58,13 @@ type NavigationOption struct {
 }
 
 type Table struct {
-	ID                string                  `json:"id"`
-	Name              string                  `json:"name"`
-	Description       string                  `json:"description"`
-	Fields            map[string]Field        `json:"fields"`
-	LinksToSingle     map[string]LinkToSingle `json:"links_to_single,omitempty"`
-	NavigationOptions []NavigationOption      `json:"navigation_options,omitempty"`
+	ID                  string                  `json:"id"`
+	Name                string                  `json:"name"`
+	Description         string                  `json:"description"`
+	DecisionTriggerMode string                  `json:"decision_trigger_mode"`
+	Fields              map[string]Field        `json:"fields"`
+	LinksToSingle       map[string]LinkToSingle `json:"links_to_single,omitempty"`
+	NavigationOptions   []NavigationOption      `json:"navigation_options,omitempty"`
 }
 
 type DataModel struct {
@@ -58,12 +73,13 @@ type DataModel struct {
 
 func AdaptTableDto(table models.Table) Table {
 	return Table{
-		Name:              table.Name,
-		ID:                table.ID,
-		Fields:            pure_utils.MapValues(table.Fields, adaptDataModelField),
-		LinksToSingle:     pure_utils.MapValues(table.LinksToSingle, adaptDataModelLink),
-		NavigationOptions: pure_utils.Map(table.NavigationOptions, adaptDataModelNavigationOption),
-		Description:       table.Description,
+		Name:                table.Name,
+		ID:                  table.ID,
+		Fields:              pure_utils.MapValues(table.Fields, adaptDataModelField),
+		LinksToSingle:       pure_utils.MapValues(table.LinksToSingle, adaptDataModelLink),
+		NavigationOptions:   pure_utils.Map(table.NavigationOptions, adaptDataModelNavigationOption),
+		Description:         table.Description,
+		DecisionTriggerMode: string(table.DecisionTriggerMode),
 	}
 }
 
@@ -78,6 +94,7 @@ func adaptDataModelField(field models.Field) Field {
 		TableId:           field.TableId,
 		Values:            field.Values,
 		UnicityConstraint: field.UnicityConstraint.String(),
+		Constraints:       FieldConstraints(field.Constraints),
 	}
 }
 
@@ -123,7 +140,8 @@ type CreateTableInput struct {
 }
 
 type UpdateTableInput struct {
-	Description string `json:"description"`
+	Description         string  `json:"description"`
+	DecisionTriggerMode *string `json:"decision_trigger_mode"`
 }
 
 type CreateLinkInput struct {
@@ -138,15 +156,18 @@ type UpdateFieldInput struct {
 	Description *string `json:"description"`
 	IsEnum      *bool   `json:"is_enum"`
 	IsUnique    *bool   `json:"is_unique"`
+	// Constraints replaces all the constraints of the field if set
+	Constraints *FieldConstraints `json:"constraints"`
 }
 
 type CreateFieldInput struct {
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	Type        string `json:"type"`
-	Nullable    bool   `json:"nullable"`
-	IsEnum      bool   `json:"is_enum"`
-	IsUnique    bool   `json:"is_unique"`
+	Name        string           `json:"name"`
+	Description string           `json:"description"`
+	Type        string           `json:"type"`
+	Nullable    bool             `json:"nullable"`
+	IsEnum      bool             `json:"is_enum"`
+	IsUnique    bool             `json:"is_unique"`
+	Constraints FieldConstraints `json:"constraints"`
 }
 
 type DataModelObject struct {
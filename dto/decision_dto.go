@@ -8,6 +8,7 @@ import (
 
 	"github.com/checkmarble/marble-backend/models"
 	"github.com/checkmarble/marble-backend/models/ast"
+	"github.com/checkmarble/marble-backend/pure_utils"
 	"github.com/guregu/null/v5"
 )
 
@@ -24,6 +25,8 @@ type DecisionFilters struct {
 	StartDate             time.Time `form:"start_date"`
 	TriggerObjects        []string  `form:"trigger_object[]"`
 	TriggerObjectId       *string   `form:"trigger_object_id"`
+	HasLabel              *bool     `form:"has_label"`
+	Labels                []string  `form:"label[]"`
 }
 
 type DecisionListPageWithIndexesDto struct {
@@ -68,12 +71,14 @@ func AdaptDecisionListPageDto(decisionsPage models.DecisionListPage, marbleAppUr
 type CreateDecisionBody struct {
 	TriggerObject json.RawMessage `json:"trigger_object" binding:"required"`
 	ObjectType    string          `json:"object_type" binding:"required"`
+	AsOf          *time.Time      `json:"as_of"`
 }
 
 type CreateDecisionWithScenarioBody struct {
 	ScenarioId    string          `json:"scenario_id" binding:"required"`
 	TriggerObject json.RawMessage `json:"trigger_object" binding:"required"`
 	ObjectType    string          `json:"object_type" binding:"required"`
+	AsOf          *time.Time      `json:"as_of"`
 }
 
 type CreateDecisionInput struct {
@@ -138,6 +143,9 @@ type DecisionWithRules struct {
 	Decision
 	Rules         []DecisionRule         `json:"rules"`
 	SanctionCheck *DecisionSanctionCheck `json:"sanction_check,omitempty"`
+	Labels        []DecisionLabel        `json:"labels"`
+	// Reevaluations are only returned with the decisions of a case
+	Reevaluations []APIDecisionReevaluation `json:"reevaluations,omitempty"`
 }
 
 func NewDecisionDto(decision models.Decision, marbleAppUrl *url.URL) Decision {
@@ -196,6 +204,10 @@ func NewDecisionWithRuleDto(decision models.DecisionWithRuleExecutions, marbleAp
 	decisionDto := DecisionWithRules{
 		Decision: NewDecisionDto(decision.Decision, marbleAppUrl),
 		Rules:    make([]DecisionRule, len(decision.RuleExecutions)),
+		Labels:   pure_utils.Map(decision.Labels, AdaptDecisionLabelDto),
+	}
+	if len(decision.Reevaluations) > 0 {
+		decisionDto.Reevaluations = pure_utils.Map(decision.Reevaluations, AdaptDecisionReevaluationDto)
 	}
 
 	for i, ruleExecution := range decision.RuleExecutions {
@@ -269,6 +281,30 @@ func AdaptDecisionsWithMetadataDto(
 	}
 }
 
+// IngestionDecisionsDto holds the decisions created synchronously on ingested objects, and the objects on which they
+// could not be created
+type IngestionDecisionsDto struct {
+	DecisionsWithMetadata
+	DecisionErrors []IngestionDecisionErrorDto `json:"decision_errors,omitempty"`
+}
+
+type IngestionDecisionErrorDto struct {
+	ObjectId string `json:"object_id"`
+	Message  string `json:"message"`
+}
+
+func AdaptIngestionDecisionsDto(decisions models.IngestionDecisions, marbleAppUrl *url.URL) IngestionDecisionsDto {
+	return IngestionDecisionsDto{
+		DecisionsWithMetadata: AdaptDecisionsWithMetadataDto(decisions.Decisions, marbleAppUrl, decisions.NbSkipped, false),
+		DecisionErrors: pure_utils.Map(decisions.Errors, func(e models.IngestionDecisionError) IngestionDecisionErrorDto {
+			return IngestionDecisionErrorDto{
+				ObjectId: e.ObjectId,
+				Message:  "the object was ingested, but the decisions on it could not be created",
+			}
+		}),
+	}
+}
+
 func AdaptDecisionsMetadata(
 	decisions []models.DecisionWithRuleExecutions,
 	nbSkipped int,
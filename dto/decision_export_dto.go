@@ -0,0 +1,74 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/checkmarble/marble-backend/models"
+)
+
+// DecisionFiltersBody is the json equivalent of the DecisionFilters query parameters of the decision list
+// endpoints
+type DecisionFiltersBody struct {
+	CaseIds               []string  `json:"case_ids"`
+	CaseInboxIds          []string  `json:"case_inbox_ids"`
+	EndDate               time.Time `json:"end_date"`
+	HasCase               *bool     `json:"has_case"`
+	Outcomes              []string  `json:"outcomes"`
+	PivotValue            *string   `json:"pivot_value"`
+	ReviewStatuses        []string  `json:"review_statuses"`
+	ScenarioIds           []string  `json:"scenario_ids"`
+	ScheduledExecutionIds []string  `json:"scheduled_execution_ids"`
+	StartDate             time.Time `json:"start_date"`
+	TriggerObjects        []string  `json:"trigger_objects"`
+	TriggerObjectId       *string   `json:"trigger_object_id"`
+	HasLabel              *bool     `json:"has_label"`
+	Labels                []string  `json:"labels"`
+}
+
+func (f DecisionFiltersBody) ToDecisionFilters() DecisionFilters {
+	return DecisionFilters(f)
+}
+
+type CreateDecisionExportBody struct {
+	Format  string              `json:"format" binding:"required,oneof=csv parquet"`
+	Filters DecisionFiltersBody `json:"filters"`
+}
+
+type APIDecisionExport struct {
+	Id                string    `json:"id"`
+	Format            string    `json:"format"`
+	Status            string    `json:"status"`
+	NbFiles           int       `json:"nb_files"`
+	NbDecisions       int       `json:"nb_decisions"`
+	RequestedByUserId *string   `json:"requested_by_user_id"`
+	RequestedByApiKey string    `json:"requested_by_api_key,omitempty"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+	FileUrls          []string  `json:"file_urls,omitempty"`
+}
+
+func AdaptDecisionExportDto(e models.DecisionExport) APIDecisionExport {
+	var userId *string
+	if e.RequestedByUserId != nil {
+		id := string(*e.RequestedByUserId)
+		userId = &id
+	}
+
+	return APIDecisionExport{
+		Id:                e.Id,
+		Format:            e.Format.String(),
+		Status:            string(e.Status),
+		NbFiles:           e.NbFiles,
+		NbDecisions:       e.NbDecisions,
+		RequestedByUserId: userId,
+		RequestedByApiKey: e.RequestedByApiKey,
+		CreatedAt:         e.CreatedAt,
+		UpdatedAt:         e.UpdatedAt,
+	}
+}
+
+func AdaptDecisionExportWithFilesDto(e models.DecisionExportWithFiles) APIDecisionExport {
+	export := AdaptDecisionExportDto(e.DecisionExport)
+	export.FileUrls = e.FileUrls
+	return export
+}
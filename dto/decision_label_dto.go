@@ -0,0 +1,70 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/checkmarble/marble-backend/models"
+)
+
+type DecisionLabel struct {
+	Id         string    `json:"id"`
+	DecisionId *string   `json:"decision_id"`
+	ObjectType *string   `json:"object_type"`
+	ObjectId   *string   `json:"object_id"`
+	Label      string    `json:"label"`
+	Amount     *float64  `json:"amount"`
+	Currency   *string   `json:"currency"`
+	Source     string    `json:"source"`
+	OccurredAt time.Time `json:"occurred_at"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+func AdaptDecisionLabelDto(label models.DecisionLabel) DecisionLabel {
+	return DecisionLabel{
+		Id:         label.Id,
+		DecisionId: label.DecisionId,
+		ObjectType: label.ObjectType,
+		ObjectId:   label.ObjectId,
+		Label:      label.Label,
+		Amount:     label.Amount,
+		Currency:   label.Currency,
+		Source:     label.Source,
+		OccurredAt: label.OccurredAt,
+		CreatedAt:  label.CreatedAt,
+	}
+}
+
+type CreateDecisionLabelBody struct {
+	DecisionId *string   `json:"decision_id"`
+	ObjectType *string   `json:"object_type"`
+	ObjectId   *string   `json:"object_id"`
+	Label      string    `json:"label" binding:"required"`
+	Amount     *float64  `json:"amount"`
+	Currency   *string   `json:"currency"`
+	Source     string    `json:"source" binding:"required"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+type CreateDecisionLabelsBody struct {
+	Labels []CreateDecisionLabelBody `json:"labels" binding:"required,dive"`
+}
+
+func AdaptCreateDecisionLabelInput(body CreateDecisionLabelBody) models.CreateDecisionLabelInput {
+	return models.CreateDecisionLabelInput{
+		DecisionId: body.DecisionId,
+		ObjectType: body.ObjectType,
+		ObjectId:   body.ObjectId,
+		Label:      body.Label,
+		Amount:     body.Amount,
+		Currency:   body.Currency,
+		Source:     body.Source,
+		OccurredAt: body.OccurredAt,
+	}
+}
+
+type DecisionLabelExportQuery struct {
+	ScenarioId        string    `form:"scenario_id" binding:"required,uuid"`
+	StartDate         time.Time `form:"start_date" binding:"required"`
+	EndDate           time.Time `form:"end_date" binding:"required"`
+	IncludeUnlabelled bool      `form:"include_unlabelled"`
+}
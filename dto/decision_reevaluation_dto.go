@@ -0,0 +1,66 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/checkmarble/marble-backend/models"
+)
+
+type ReevaluateDecisionBody struct {
+	ScenarioIterationId *string `json:"scenario_iteration_id" binding:"omitempty,uuid"`
+}
+
+type CreateDecisionReevaluationBatchBody struct {
+	ScenarioIterationId *string             `json:"scenario_iteration_id" binding:"omitempty,uuid"`
+	Filters             DecisionFiltersBody `json:"filters"`
+}
+
+type APIDecisionReevaluation struct {
+	Id                     string    `json:"id"`
+	DecisionId             string    `json:"decision_id"`
+	ReevaluationDecisionId *string   `json:"reevaluation_decision_id"`
+	ScenarioIterationId    string    `json:"scenario_iteration_id"`
+	Outcome                *string   `json:"outcome"`
+	Score                  *int      `json:"score"`
+	BatchId                *string   `json:"batch_id"`
+	CreatedAt              time.Time `json:"created_at"`
+}
+
+func AdaptDecisionReevaluationDto(r models.DecisionReevaluation) APIDecisionReevaluation {
+	out := APIDecisionReevaluation{
+		Id:                     r.Id,
+		DecisionId:             r.DecisionId,
+		ReevaluationDecisionId: r.ReevaluationDecisionId,
+		ScenarioIterationId:    r.ScenarioIterationId,
+		Score:                  r.Score,
+		BatchId:                r.BatchId,
+		CreatedAt:              r.CreatedAt,
+	}
+	if r.Outcome != nil {
+		outcome := r.Outcome.String()
+		out.Outcome = &outcome
+	}
+	return out
+}
+
+type APIDecisionReevaluationBatch struct {
+	Id                  string    `json:"id"`
+	ScenarioIterationId *string   `json:"scenario_iteration_id"`
+	Status              string    `json:"status"`
+	NbDecisions         int       `json:"nb_decisions"`
+	NbErrors            int       `json:"nb_errors"`
+	CreatedAt           time.Time `json:"created_at"`
+	UpdatedAt           time.Time `json:"updated_at"`
+}
+
+func AdaptDecisionReevaluationBatchDto(b models.DecisionReevaluationBatch) APIDecisionReevaluationBatch {
+	return APIDecisionReevaluationBatch{
+		Id:                  b.Id,
+		ScenarioIterationId: b.ScenarioIterationId,
+		Status:              string(b.Status),
+		NbDecisions:         b.NbDecisions,
+		NbErrors:            b.NbErrors,
+		CreatedAt:           b.CreatedAt,
+		UpdatedAt:           b.UpdatedAt,
+	}
+}
@@ -0,0 +1,68 @@
+package dto
+
+import (
+	"encoding/json"
+	"net/url"
+	"time"
+
+	"github.com/checkmarble/marble-backend/models"
+)
+
+type CreateDecisionRequestBody struct {
+	ScenarioId    string          `json:"scenario_id" binding:"required"`
+	TriggerObject json.RawMessage `json:"trigger_object" binding:"required"`
+	ObjectType    string          `json:"object_type" binding:"required"`
+	AsOf          *time.Time      `json:"as_of"`
+	// DeadlineSeconds is the maximum duration of the evaluation, after which the fallback outcome is recorded
+	DeadlineSeconds int    `json:"deadline_seconds"`
+	FallbackOutcome string `json:"fallback_outcome"`
+}
+
+func (b CreateDecisionRequestBody) ToInput(organizationId string) models.CreateDecisionRequestInput {
+	fallbackOutcome := models.Review
+	if b.FallbackOutcome != "" {
+		fallbackOutcome = models.OutcomeFrom(b.FallbackOutcome)
+	}
+	return models.CreateDecisionRequestInput{
+		OrganizationId:     organizationId,
+		ScenarioId:         b.ScenarioId,
+		TriggerObjectTable: b.ObjectType,
+		PayloadRaw:         b.TriggerObject,
+		ReadAsOf:           b.AsOf,
+		Deadline:           time.Duration(b.DeadlineSeconds) * time.Second,
+		FallbackOutcome:    fallbackOutcome,
+	}
+}
+
+type APIDecisionRequest struct {
+	Id              string             `json:"id"`
+	Status          string             `json:"status"`
+	Outcome         *string            `json:"outcome"`
+	FallbackOutcome string             `json:"fallback_outcome"`
+	Error           string             `json:"error,omitempty"`
+	Deadline        time.Time          `json:"deadline"`
+	CreatedAt       time.Time          `json:"created_at"`
+	UpdatedAt       time.Time          `json:"updated_at"`
+	Decision        *DecisionWithRules `json:"decision,omitempty"`
+}
+
+func AdaptDecisionRequestDto(request models.DecisionRequestWithDecision, marbleAppUrl *url.URL) APIDecisionRequest {
+	out := APIDecisionRequest{
+		Id:              request.Id,
+		Status:          string(request.Status),
+		FallbackOutcome: request.FallbackOutcome.String(),
+		Error:           request.Error,
+		Deadline:        request.Deadline,
+		CreatedAt:       request.CreatedAt,
+		UpdatedAt:       request.UpdatedAt,
+	}
+	if request.Outcome != nil {
+		outcome := request.Outcome.String()
+		out.Outcome = &outcome
+	}
+	if request.Decision != nil {
+		decision := NewDecisionWithRuleDto(*request.Decision, marbleAppUrl, false)
+		out.Decision = &decision
+	}
+	return out
+}
@@ -71,8 +71,9 @@ type EntityAnnotationTagDto struct {
 type EntityAnnotationFileDto struct {
 	Caption string `json:"caption"`
 	Files   []struct {
-		Id       string `json:"id"`
-		Filename string `json:"filename"`
+		Id         string `json:"id"`
+		Filename   string `json:"filename"`
+		ScanStatus string `json:"scan_status"`
 	} `json:"files"`
 }
 
@@ -94,6 +95,9 @@ func AdaptEntityAnnotationPayload(model models.EntityAnnotation) (out any, err e
 		var o EntityAnnotationFileDto
 
 		err = json.Unmarshal(model.Payload, &o)
+		for i := range o.Files {
+			o.Files[i].ScanStatus = string(models.FileScanStatusFrom(o.Files[i].ScanStatus))
+		}
 		out = o
 
 	default:
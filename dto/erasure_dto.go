@@ -0,0 +1,56 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/checkmarble/marble-backend/models"
+)
+
+type CreateErasureBody struct {
+	ObjectType string `json:"object_type" binding:"required"`
+	ObjectId   string `json:"object_id"`
+	PivotValue string `json:"pivot_value"`
+	Mode       string `json:"mode" binding:"required,oneof=pseudonymise delete"`
+}
+
+type APIErasureCounts struct {
+	IngestedRows      int `json:"ingested_rows"`
+	LinkedRows        int `json:"linked_rows"`
+	Decisions         int `json:"decisions"`
+	RuleExecutions    int `json:"rule_executions"`
+	OffloadedRules    int `json:"offloaded_rules"`
+	EntityAnnotations int `json:"entity_annotations"`
+	CaseFiles         int `json:"case_files"`
+	SanctionChecks    int `json:"sanction_checks"`
+	WebhookEvents     int `json:"webhook_events"`
+}
+
+type APIErasureCertificate struct {
+	Id                string           `json:"id"`
+	ObjectType        string           `json:"object_type"`
+	SubjectPseudonym  string           `json:"subject_pseudonym"`
+	Mode              string           `json:"mode"`
+	RequestedByUserId *string          `json:"requested_by_user_id"`
+	RequestedByApiKey string           `json:"requested_by_api_key,omitempty"`
+	Counts            APIErasureCounts `json:"counts"`
+	CreatedAt         time.Time        `json:"created_at"`
+}
+
+func AdaptErasureCertificateDto(c models.ErasureCertificate) APIErasureCertificate {
+	var userId *string
+	if c.RequestedByUserId != nil {
+		id := string(*c.RequestedByUserId)
+		userId = &id
+	}
+
+	return APIErasureCertificate{
+		Id:                c.Id,
+		ObjectType:        c.ObjectType,
+		SubjectPseudonym:  c.SubjectPseudonym,
+		Mode:              c.Mode.String(),
+		RequestedByUserId: userId,
+		RequestedByApiKey: c.RequestedByApiKey,
+		Counts:            APIErasureCounts(c.Counts),
+		CreatedAt:         c.CreatedAt,
+	}
+}
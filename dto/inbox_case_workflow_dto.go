@@ -0,0 +1,102 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/pure_utils"
+)
+
+type APICaseWorkflowStatus struct {
+	Name  string `json:"name" binding:"required"`
+	Label string `json:"label"`
+}
+
+type APICaseStatusTransition struct {
+	From           string   `json:"from" binding:"required"`
+	To             string   `json:"to" binding:"required"`
+	RequireOutcome bool     `json:"require_outcome"`
+	RequireComment bool     `json:"require_comment"`
+	RequireSar     bool     `json:"require_sar"`
+	AllowedRoles   []string `json:"allowed_roles"`
+}
+
+type InboxCaseWorkflowBody struct {
+	Statuses    []APICaseWorkflowStatus   `json:"statuses" binding:"dive"`
+	Transitions []APICaseStatusTransition `json:"transitions" binding:"required,dive"`
+}
+
+func AdaptInboxCaseWorkflowInput(body InboxCaseWorkflowBody) models.InboxCaseWorkflowInput {
+	return models.InboxCaseWorkflowInput{
+		Statuses: pure_utils.Map(body.Statuses, func(s APICaseWorkflowStatus) models.CaseWorkflowStatus {
+			return models.CaseWorkflowStatus{Name: models.CaseStatus(s.Name), Label: s.Label}
+		}),
+		Transitions: pure_utils.Map(body.Transitions, func(t APICaseStatusTransition) models.CaseStatusTransition {
+			return models.CaseStatusTransition{
+				From:           models.CaseStatus(t.From),
+				To:             models.CaseStatus(t.To),
+				RequireOutcome: t.RequireOutcome,
+				RequireComment: t.RequireComment,
+				RequireSar:     t.RequireSar,
+				AllowedRoles: pure_utils.Map(t.AllowedRoles, func(role string) models.InboxUserRole {
+					return models.InboxUserRole(role)
+				}),
+			}
+		}),
+	}
+}
+
+type APIInboxCaseWorkflow struct {
+	InboxId     string                    `json:"inbox_id"`
+	IsDefault   bool                      `json:"is_default"`
+	Statuses    []APICaseWorkflowStatus   `json:"statuses"`
+	Transitions []APICaseStatusTransition `json:"transitions"`
+	UpdatedAt   *time.Time                `json:"updated_at"`
+}
+
+// AdaptInboxCaseWorkflowDto presents the case workflow of an inbox, or the default workflow if the inbox has none
+func AdaptInboxCaseWorkflowDto(inboxId string, w *models.InboxCaseWorkflow) APIInboxCaseWorkflow {
+	if w == nil {
+		return APIInboxCaseWorkflow{
+			InboxId:     inboxId,
+			IsDefault:   true,
+			Statuses:    []APICaseWorkflowStatus{},
+			Transitions: defaultCaseStatusTransitions(),
+		}
+	}
+	return APIInboxCaseWorkflow{
+		InboxId: w.InboxId,
+		Statuses: pure_utils.Map(w.Statuses, func(s models.CaseWorkflowStatus) APICaseWorkflowStatus {
+			return APICaseWorkflowStatus{Name: string(s.Name), Label: s.Label}
+		}),
+		Transitions: pure_utils.Map(w.Transitions, adaptCaseStatusTransitionDto),
+		UpdatedAt:   &w.UpdatedAt,
+	}
+}
+
+func adaptCaseStatusTransitionDto(t models.CaseStatusTransition) APICaseStatusTransition {
+	return APICaseStatusTransition{
+		From:           string(t.From),
+		To:             string(t.To),
+		RequireOutcome: t.RequireOutcome,
+		RequireComment: t.RequireComment,
+		RequireSar:     t.RequireSar,
+		AllowedRoles: pure_utils.Map(t.AllowedRoles, func(role models.InboxUserRole) string {
+			return string(role)
+		}),
+	}
+}
+
+func defaultCaseStatusTransitions() []APICaseStatusTransition {
+	statuses := []models.CaseStatus{models.CasePending, models.CaseInvestigating, models.CaseClosed}
+	transitions := make([]APICaseStatusTransition, 0)
+	for _, from := range statuses {
+		for _, to := range statuses {
+			if from != to && from.CanTransition(to) {
+				transitions = append(transitions, adaptCaseStatusTransitionDto(
+					models.CaseStatusTransition{From: from, To: to, AllowedRoles: []models.InboxUserRole{}}))
+			}
+		}
+	}
+	return transitions
+}
@@ -8,46 +8,54 @@ import (
 )
 
 type InboxDto struct {
-	Id                string         `json:"id"`
-	CreatedAt         time.Time      `json:"created_at"`
-	UpdatedAt         time.Time      `json:"updated_at"`
-	Name              string         `json:"name"`
-	Status            string         `json:"status"`
-	EscalationInboxId *string        `json:"escalation_inbox_id,omitempty"`
-	Users             []InboxUserDto `json:"users"`
-	CasesCount        *int           `json:"cases_count"`
+	Id                 string         `json:"id"`
+	CreatedAt          time.Time      `json:"created_at"`
+	UpdatedAt          time.Time      `json:"updated_at"`
+	Name               string         `json:"name"`
+	Status             string         `json:"status"`
+	EscalationInboxId  *string        `json:"escalation_inbox_id,omitempty"`
+	AssignmentStrategy string         `json:"assignment_strategy"`
+	Users              []InboxUserDto `json:"users"`
+	CasesCount         *int           `json:"cases_count"`
 }
 
 func AdaptInboxDto(i models.Inbox) InboxDto {
 	return InboxDto{
-		Id:                i.Id,
-		CreatedAt:         i.CreatedAt,
-		UpdatedAt:         i.UpdatedAt,
-		Name:              i.Name,
-		Status:            string(i.Status),
-		EscalationInboxId: i.EscalationInboxId,
-		Users:             pure_utils.Map(i.InboxUsers, AdaptInboxUserDto),
-		CasesCount:        i.CasesCount,
+		Id:                 i.Id,
+		CreatedAt:          i.CreatedAt,
+		UpdatedAt:          i.UpdatedAt,
+		Name:               i.Name,
+		Status:             string(i.Status),
+		EscalationInboxId:  i.EscalationInboxId,
+		AssignmentStrategy: string(i.AssignmentStrategy),
+		Users:              pure_utils.Map(i.InboxUsers, AdaptInboxUserDto),
+		CasesCount:         i.CasesCount,
 	}
 }
 
 type InboxUserDto struct {
-	Id        string    `json:"id"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
-	Role      string    `json:"role"`
-	InboxId   string    `json:"inbox_id"`
-	UserId    string    `json:"user_id"`
+	Id             string     `json:"id"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+	Role           string     `json:"role"`
+	InboxId        string     `json:"inbox_id"`
+	UserId         string     `json:"user_id"`
+	OutOfOffice    bool       `json:"out_of_office"`
+	SkillTagIds    []string   `json:"skill_tag_ids"`
+	LastAssignedAt *time.Time `json:"last_assigned_at"`
 }
 
 func AdaptInboxUserDto(i models.InboxUser) InboxUserDto {
 	return InboxUserDto{
-		Id:        i.Id,
-		CreatedAt: i.CreatedAt,
-		UpdatedAt: i.UpdatedAt,
-		Role:      string(i.Role),
-		InboxId:   i.InboxId,
-		UserId:    i.UserId,
+		Id:             i.Id,
+		CreatedAt:      i.CreatedAt,
+		UpdatedAt:      i.UpdatedAt,
+		Role:           string(i.Role),
+		InboxId:        i.InboxId,
+		UserId:         i.UserId,
+		OutOfOffice:    i.OutOfOffice,
+		SkillTagIds:    i.SkillTagIds,
+		LastAssignedAt: i.LastAssignedAt,
 	}
 }
 
@@ -0,0 +1,70 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/checkmarble/marble-backend/models"
+)
+
+type InboxSlaPolicyBody struct {
+	Name               string  `json:"name" binding:"required"`
+	Priority           int     `json:"priority"`
+	DecisionOutcome    *string `json:"decision_outcome"`
+	TagId              *string `json:"tag_id" binding:"omitempty,uuid"`
+	FirstActionMinutes *int    `json:"first_action_minutes"`
+	CloseMinutes       *int    `json:"close_minutes"`
+	BreachAction       string  `json:"breach_action"`
+}
+
+func AdaptInboxSlaPolicyInput(body InboxSlaPolicyBody) models.InboxSlaPolicyInput {
+	input := models.InboxSlaPolicyInput{
+		Name:               body.Name,
+		Priority:           body.Priority,
+		TagId:              body.TagId,
+		FirstActionMinutes: body.FirstActionMinutes,
+		CloseMinutes:       body.CloseMinutes,
+		BreachAction:       models.SlaBreachAction(body.BreachAction),
+	}
+	if body.BreachAction == "" {
+		input.BreachAction = models.SlaBreachFlag
+	}
+	if body.DecisionOutcome != nil {
+		outcome := models.OutcomeFrom(*body.DecisionOutcome)
+		input.DecisionOutcome = &outcome
+	}
+	return input
+}
+
+type APIInboxSlaPolicy struct {
+	Id                 string    `json:"id"`
+	InboxId            string    `json:"inbox_id"`
+	Name               string    `json:"name"`
+	Priority           int       `json:"priority"`
+	DecisionOutcome    *string   `json:"decision_outcome"`
+	TagId              *string   `json:"tag_id"`
+	FirstActionMinutes *int      `json:"first_action_minutes"`
+	CloseMinutes       *int      `json:"close_minutes"`
+	BreachAction       string    `json:"breach_action"`
+	CreatedAt          time.Time `json:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at"`
+}
+
+func AdaptInboxSlaPolicyDto(p models.InboxSlaPolicy) APIInboxSlaPolicy {
+	out := APIInboxSlaPolicy{
+		Id:                 p.Id,
+		InboxId:            p.InboxId,
+		Name:               p.Name,
+		Priority:           p.Priority,
+		TagId:              p.TagId,
+		FirstActionMinutes: p.FirstActionMinutes,
+		CloseMinutes:       p.CloseMinutes,
+		BreachAction:       string(p.BreachAction),
+		CreatedAt:          p.CreatedAt,
+		UpdatedAt:          p.UpdatedAt,
+	}
+	if p.DecisionOutcome != nil {
+		outcome := p.DecisionOutcome.String()
+		out.DecisionOutcome = &outcome
+	}
+	return out
+}
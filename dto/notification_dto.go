@@ -0,0 +1,79 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/checkmarble/marble-backend/models"
+)
+
+type APINotification struct {
+	Id        string     `json:"id"`
+	Type      string     `json:"type"`
+	CaseId    *string    `json:"case_id"`
+	ActorId   *string    `json:"actor_id"`
+	Message   string     `json:"message"`
+	Read      bool       `json:"read"`
+	ReadAt    *time.Time `json:"read_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+func AdaptNotificationDto(n models.Notification) APINotification {
+	return APINotification{
+		Id:        n.Id,
+		Type:      string(n.Type),
+		CaseId:    n.CaseId,
+		ActorId:   n.ActorId,
+		Message:   n.Message,
+		Read:      n.ReadAt != nil,
+		ReadAt:    n.ReadAt,
+		CreatedAt: n.CreatedAt,
+	}
+}
+
+type ListNotificationsQuery struct {
+	UnreadOnly bool       `form:"unread_only"`
+	Before     *time.Time `form:"before"`
+	Limit      int        `form:"limit"`
+}
+
+func AdaptNotificationFilters(query ListNotificationsQuery) models.NotificationFilters {
+	return models.NotificationFilters{
+		UnreadOnly: query.UnreadOnly,
+		Before:     query.Before,
+		Limit:      query.Limit,
+	}
+}
+
+type APINotificationPreference struct {
+	Type  string `json:"type" binding:"required"`
+	InApp bool   `json:"in_app"`
+	Email bool   `json:"email"`
+}
+
+func AdaptNotificationPreferenceDto(p models.NotificationPreference) APINotificationPreference {
+	return APINotificationPreference{
+		Type:  string(p.Type),
+		InApp: p.InApp,
+		Email: p.Email,
+	}
+}
+
+type UpdateNotificationPreferencesBody struct {
+	Preferences []APINotificationPreference `json:"preferences" binding:"required,dive"`
+}
+
+func AdaptNotificationPreferencesInput(body UpdateNotificationPreferencesBody) ([]models.NotificationPreference, error) {
+	preferences := make([]models.NotificationPreference, 0, len(body.Preferences))
+	for _, p := range body.Preferences {
+		t, err := models.NotificationTypeFrom(p.Type)
+		if err != nil {
+			return nil, err
+		}
+		preferences = append(preferences, models.NotificationPreference{
+			Type:  t,
+			InApp: p.InApp,
+			Email: p.Email,
+		})
+	}
+	return preferences, nil
+}
@@ -29,7 +29,11 @@ type Property struct {
 	Description *string             `json:"description,omitempty"`
 	Type        *string             `json:"type,omitempty"`
 	Format      *string             `json:"format,omitempty"`
-	Enum        []string            `json:"enum,omitempty"`
+	Enum        []any               `json:"enum,omitempty"`
+	Minimum     *float64            `json:"minimum,omitempty"`
+	Maximum     *float64            `json:"maximum,omitempty"`
+	MaxLength   *int                `json:"maxLength,omitempty"`
+	Pattern     *string             `json:"pattern,omitempty"`
 	OneOf       []map[string]string `json:"oneOf,omitempty"`
 	AnyOf       []map[string]string `json:"anyOf,omitempty"`
 	Ref         *string             `json:"$ref,omitempty"`
@@ -278,12 +282,12 @@ func decisionSchema(triggerObjects []map[string]string) ComponentsSchema {
 			"outcome": {
 				Description: utils.Ptr("Object type used to take a decision."),
 				Type:        utils.Ptr("string"),
-				Enum:        []string{"approve", "review", "block_and_review", "decline"},
+				Enum:        []any{"approve", "review", "block_and_review", "decline"},
 			},
 			"review_status": {
 				Description: utils.Ptr("Review status of the decision (used for decisions with block_and_review outcome)."),
 				Type:        utils.Ptr("string"),
-				Enum:        []string{"pending", "approve", "decline"},
+				Enum:        []any{"pending", "approve", "decline"},
 			},
 			"scenario": {
 				Ref: utils.Ptr("#/components/schemas/Scenario"),
@@ -340,7 +344,7 @@ func decisionRuleSchema() ComponentsSchema {
 			},
 			"outcome": {
 				Type: utils.Ptr("string"),
-				Enum: []string{"hit", "no_hit", "error", "snoozed"},
+				Enum: []any{"hit", "no_hit", "error", "snoozed"},
 			},
 		},
 	}
@@ -424,9 +428,17 @@ func OpenAPIFromDataModel(dataModel models.DataModel) Reference {
 		properties := make(map[string]Property)
 		for name, field := range table.Fields {
 			description := field.Description
+			if field.Constraints.NotInFuture {
+				description += " Must not be in the future."
+			}
 			properties[name] = Property{
 				Description: &description,
 				Type:        toSwaggerType(field.DataType),
+				Enum:        field.Constraints.AllowedValues,
+				Minimum:     field.Constraints.Min,
+				Maximum:     field.Constraints.Max,
+				MaxLength:   field.Constraints.MaxLength,
+				Pattern:     field.Constraints.Regex,
 			}
 			if !field.Nullable {
 				required = append(required, name)
@@ -0,0 +1,66 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/checkmarble/marble-backend/models"
+)
+
+type APIRetentionPolicy struct {
+	Id                string    `json:"id"`
+	TableName         string    `json:"table_name"`
+	Action            string    `json:"action"`
+	RetentionDays     int       `json:"retention_days"`
+	KeepLatestVersion bool      `json:"keep_latest_version"`
+	Enabled           bool      `json:"enabled"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+func AdaptRetentionPolicyDto(p models.RetentionPolicy) APIRetentionPolicy {
+	return APIRetentionPolicy{
+		Id:                p.Id,
+		TableName:         p.TableName,
+		Action:            p.Action.String(),
+		RetentionDays:     p.RetentionDays,
+		KeepLatestVersion: p.KeepLatestVersion,
+		Enabled:           p.Enabled,
+		CreatedAt:         p.CreatedAt,
+		UpdatedAt:         p.UpdatedAt,
+	}
+}
+
+type CreateRetentionPolicyBody struct {
+	TableName         string `json:"table_name" binding:"required"`
+	Action            string `json:"action" binding:"required,oneof=delete archive"`
+	RetentionDays     int    `json:"retention_days" binding:"required,gt=0"`
+	KeepLatestVersion bool   `json:"keep_latest_version"`
+	Enabled           *bool  `json:"enabled"`
+}
+
+type UpdateRetentionPolicyBody struct {
+	Action            *string `json:"action" binding:"omitempty,oneof=delete archive"`
+	RetentionDays     *int    `json:"retention_days" binding:"omitempty,gt=0"`
+	KeepLatestVersion *bool   `json:"keep_latest_version"`
+	Enabled           *bool   `json:"enabled"`
+}
+
+type APIRetentionReport struct {
+	PolicyId     string    `json:"policy_id"`
+	TableName    string    `json:"table_name"`
+	Action       string    `json:"action"`
+	Cutoff       time.Time `json:"cutoff"`
+	NbRows       int       `json:"nb_rows"`
+	NbRowsOnHold int       `json:"nb_rows_on_hold"`
+}
+
+func AdaptRetentionReportDto(r models.RetentionReport) APIRetentionReport {
+	return APIRetentionReport{
+		PolicyId:     r.PolicyId,
+		TableName:    r.TableName,
+		Action:       r.Action.String(),
+		Cutoff:       r.Cutoff,
+		NbRows:       r.NbRows,
+		NbRowsOnHold: r.NbRowsOnHold,
+	}
+}
@@ -171,15 +171,17 @@ func AdaptSanctionCheckMatchCommentDto(m models.SanctionCheckMatchComment) Sanct
 }
 
 type SanctionCheckFileDto struct {
-	Id        string    `json:"id"`
-	Filename  string    `json:"filename"`
-	CreatedAt time.Time `json:"created_at"`
+	Id         string    `json:"id"`
+	Filename   string    `json:"filename"`
+	ScanStatus string    `json:"scan_status"`
+	CreatedAt  time.Time `json:"created_at"`
 }
 
 func AdaptSanctionCheckFileDto(m models.SanctionCheckFile) SanctionCheckFileDto {
 	return SanctionCheckFileDto{
-		Id:        m.Id,
-		Filename:  m.FileName,
-		CreatedAt: m.CreatedAt,
+		Id:         m.Id,
+		Filename:   m.FileName,
+		ScanStatus: string(m.ScanStatus),
+		CreatedAt:  m.CreatedAt,
 	}
 }
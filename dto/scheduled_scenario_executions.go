@@ -19,6 +19,13 @@ type ScheduledExecutionDto struct {
 	ScenarioName               string     `json:"scenario_name"`
 	ScenarioTriggerObjectType  string     `json:"scenario_trigger_object_type"`
 	Manual                     bool       `json:"manual"`
+	ReadAsOf                   *time.Time `json:"read_as_of"`
+}
+
+// CreateScheduledExecutionParams are the query parameters of a manual execution. AsOf, if set, runs the scenario as a
+// backtest on the data as it was at that time.
+type CreateScheduledExecutionParams struct {
+	AsOf time.Time `form:"as_of"`
 }
 
 func AdaptScheduledExecutionDto(ExecutionBatch models.ScheduledExecution) ScheduledExecutionDto {
@@ -35,5 +42,6 @@ func AdaptScheduledExecutionDto(ExecutionBatch models.ScheduledExecution) Schedu
 		ScenarioName:               ExecutionBatch.Scenario.Name,
 		ScenarioTriggerObjectType:  ExecutionBatch.Scenario.TriggerObjectType,
 		Manual:                     ExecutionBatch.Manual,
+		ReadAsOf:                   ExecutionBatch.ReadAsOf,
 	}
 }
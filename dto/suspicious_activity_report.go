@@ -11,6 +11,8 @@ type SuspiciousActivityReportDto struct {
 	ReportId   string    `json:"id"` //nolint:tagliatelle
 	Status     string    `json:"status"`
 	HasFile    bool      `json:"has_file"`
+	HasContent bool      `json:"has_content"`
+	ScanStatus string    `json:"scan_status"`
 	CreatedBy  string    `json:"created_by"`
 	UploadedBy *string   `json:"uploaded_by,omitempty"`
 	CreatedAt  time.Time `json:"created_at"`
@@ -26,8 +28,29 @@ func AdaptSuspiciousActivityReportDto(model models.SuspiciousActivityReport) Sus
 		ReportId:   model.ReportId,
 		Status:     model.Status.String(),
 		HasFile:    model.UploadedBy != nil,
+		HasContent: model.Content != nil,
+		ScanStatus: string(model.ScanStatus),
 		CreatedBy:  model.CreatedBy,
 		UploadedBy: model.UploadedBy,
 		CreatedAt:  model.CreatedAt,
 	}
 }
+
+type SuspiciousActivityReportContentDto struct {
+	Content models.SarContent `json:"content"`
+	// Prefilled is true when the content was never saved and was generated from the case
+	Prefilled        bool     `json:"prefilled"`
+	ValidationErrors []string `json:"validation_errors"`
+}
+
+type SuspiciousActivityReportExportParams struct {
+	Format string `form:"format" binding:"omitempty,oneof=goaml json"`
+}
+
+func AdaptSuspiciousActivityReportContentDto(content models.SarContent, prefilled bool) SuspiciousActivityReportContentDto {
+	return SuspiciousActivityReportContentDto{
+		Content:          content,
+		Prefilled:        prefilled,
+		ValidationErrors: content.Validate(),
+	}
+}
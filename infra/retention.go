@@ -0,0 +1,34 @@
+package infra
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/checkmarble/marble-backend/utils"
+)
+
+const (
+	RETENTION_MIN_JOB_INTERVAL = time.Hour
+	RETENTION_MAX_BATCH_SIZE   = 10_000
+)
+
+type RetentionConfig struct {
+	Enabled          bool
+	ArchiveBucketUrl string
+	JobInterval      time.Duration
+	BatchSize        int
+}
+
+func (cfg *RetentionConfig) ValidateAndFix(ctx context.Context) {
+	logger := utils.LoggerFromContext(ctx)
+
+	if cfg.JobInterval.Seconds() < RETENTION_MIN_JOB_INTERVAL.Seconds() {
+		logger.Warn(fmt.Sprintf("RETENTION_JOB_INTERVAL should be greater than %[1]s, but is %[2]s, setting to %[1]s", RETENTION_MIN_JOB_INTERVAL, cfg.JobInterval))
+		cfg.JobInterval = RETENTION_MIN_JOB_INTERVAL
+	}
+	if cfg.BatchSize <= 0 || cfg.BatchSize > RETENTION_MAX_BATCH_SIZE {
+		logger.Warn(fmt.Sprintf("RETENTION_BATCH_SIZE should be between 1 and %[1]d, but is %[2]d, setting to %[1]d", RETENTION_MAX_BATCH_SIZE, cfg.BatchSize))
+		cfg.BatchSize = RETENTION_MAX_BATCH_SIZE
+	}
+}
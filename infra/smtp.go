@@ -0,0 +1,14 @@
+package infra
+
+type SmtpConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	// From is the sender address of the emails, for instance "Marble <notifications@example.com>"
+	From string
+}
+
+func (cfg SmtpConfig) IsSet() bool {
+	return cfg.Host != "" && cfg.From != ""
+}
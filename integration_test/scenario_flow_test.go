@@ -442,15 +442,15 @@ func ingestAccounts(
 		"updated_at": "2020-01-01T00:00:00Z"
 	}`)
 
-	_, err := ingestionUsecase.IngestObject(ctx, organizationId, tableName, accountPayloadJson1)
+	_, _, err := ingestionUsecase.IngestObject(ctx, organizationId, tableName, accountPayloadJson1)
 	if err != nil {
 		assert.FailNow(t, "Could not ingest data", err)
 	}
-	_, err = ingestionUsecase.IngestObject(ctx, organizationId, tableName, accountPayloadJson2)
+	_, _, err = ingestionUsecase.IngestObject(ctx, organizationId, tableName, accountPayloadJson2)
 	if err != nil {
 		assert.FailNow(t, "Could not ingest data", err)
 	}
-	_, err = ingestionUsecase.IngestObject(ctx, organizationId, tableName, accountPayloadJson3)
+	_, _, err = ingestionUsecase.IngestObject(ctx, organizationId, tableName, accountPayloadJson3)
 	if err != nil {
 		assert.FailNow(t, "Could not ingest data", err)
 	}
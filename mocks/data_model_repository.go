@@ -33,8 +33,10 @@ func (d *DataModelRepository) CreateDataModelTable(ctx context.Context, exec rep
 	return args.Error(0)
 }
 
-func (d *DataModelRepository) UpdateDataModelTable(ctx context.Context, exec repositories.Executor, tableID, description string) error {
-	args := d.Called(ctx, exec, tableID, description)
+func (d *DataModelRepository) UpdateDataModelTable(ctx context.Context, exec repositories.Executor, tableID, description string,
+	decisionTriggerMode *models.DecisionTriggerMode,
+) error {
+	args := d.Called(ctx, exec, tableID, description, decisionTriggerMode)
 	return args.Error(0)
 }
 
@@ -40,6 +40,16 @@ func (e *EnforceSecurity) ReadDecision(decision models.Decision) error {
 	return args.Error(0)
 }
 
+func (e *EnforceSecurity) ReadDecisionRequest(request models.DecisionRequest) error {
+	args := e.Called(request)
+	return args.Error(0)
+}
+
+func (e *EnforceSecurity) ReadDecisionReevaluationBatch(batch models.DecisionReevaluationBatch) error {
+	args := e.Called(batch)
+	return args.Error(0)
+}
+
 func (e *EnforceSecurity) ReadScheduledExecution(scheduledExecution models.ScheduledExecution) error {
 	args := e.Called(scheduledExecution)
 	return args.Error(0)
@@ -201,3 +211,13 @@ func (e *EnforceSecurity) CanIngest(orgId string) error {
 	args := e.Called(orgId)
 	return args.Error(0)
 }
+
+func (e *EnforceSecurity) EraseData(organizationId string) error {
+	args := e.Called(organizationId)
+	return args.Error(0)
+}
+
+func (e *EnforceSecurity) ReadErasureCertificate(certificate models.ErasureCertificate) error {
+	args := e.Called(certificate)
+	return args.Error(0)
+}
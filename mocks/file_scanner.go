@@ -0,0 +1,18 @@
+package mocks
+
+import (
+	"context"
+	"io"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type FileScanner struct {
+	mock.Mock
+}
+
+func (m *FileScanner) Scan(ctx context.Context, r io.Reader) (models.FileScanResult, error) {
+	args := m.Called(ctx, r)
+	return args.Get(0).(models.FileScanResult), args.Error(1)
+}
@@ -30,8 +30,8 @@ func (r *InboxRepository) CreateInbox(ctx context.Context, exec repositories.Exe
 	return args.Error(0)
 }
 
-func (r *InboxRepository) UpdateInbox(ctx context.Context, exec repositories.Executor, inboxId, name string, escalationInboxId *string) error {
-	args := r.Called(exec, inboxId, name, escalationInboxId)
+func (r *InboxRepository) UpdateInbox(ctx context.Context, exec repositories.Executor, input models.UpdateInboxInput) error {
+	args := r.Called(exec, input)
 	return args.Error(0)
 }
 
@@ -66,8 +66,10 @@ func (repo *InboxRepository) CreateInboxUser(ctx context.Context, exec repositor
 	return args.Error(0)
 }
 
-func (repo *InboxRepository) UpdateInboxUser(ctx context.Context, exec repositories.Executor, inboxUserId string, role models.InboxUserRole) error {
-	args := repo.Called(exec, inboxUserId, role)
+func (repo *InboxRepository) UpdateInboxUser(ctx context.Context, exec repositories.Executor,
+	inboxUserId string, input models.UpdateInboxUserInput,
+) error {
+	args := repo.Called(exec, inboxUserId, input)
 	return args.Error(0)
 }
 
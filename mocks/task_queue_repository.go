@@ -2,6 +2,7 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"github.com/checkmarble/marble-backend/models"
 	"github.com/checkmarble/marble-backend/repositories"
@@ -61,3 +62,86 @@ func (m *TaskQueueRepository) EnqueueMatchEnrichmentTask(
 	args := m.Called(ctx, organizationId, sanctionCheckId)
 	return args.Error(0)
 }
+
+func (m *TaskQueueRepository) EnqueueIngestedObjectsDecisionTask(
+	ctx context.Context,
+	organizationId string,
+	objectType string,
+	objectIds []string,
+) error {
+	args := m.Called(ctx, organizationId, objectType, objectIds)
+	return args.Error(0)
+}
+
+func (m *TaskQueueRepository) EnqueueDecisionExportTask(
+	ctx context.Context,
+	tx repositories.Transaction,
+	organizationId string,
+	exportId string,
+) error {
+	args := m.Called(ctx, tx, organizationId, exportId)
+	return args.Error(0)
+}
+
+func (m *TaskQueueRepository) EnqueueCaseExportTask(
+	ctx context.Context,
+	tx repositories.Transaction,
+	organizationId string,
+	exportId string,
+) error {
+	args := m.Called(ctx, tx, organizationId, exportId)
+	return args.Error(0)
+}
+
+func (m *TaskQueueRepository) EnqueueCaseBulkActionTask(
+	ctx context.Context,
+	tx repositories.Transaction,
+	organizationId string,
+	bulkActionId string,
+) error {
+	args := m.Called(ctx, tx, organizationId, bulkActionId)
+	return args.Error(0)
+}
+
+func (m *TaskQueueRepository) EnqueueDecisionRequestTask(
+	ctx context.Context,
+	tx repositories.Transaction,
+	organizationId string,
+	requestId string,
+	deadline time.Time,
+) error {
+	args := m.Called(ctx, tx, organizationId, requestId, deadline)
+	return args.Error(0)
+}
+
+func (m *TaskQueueRepository) EnqueueDecisionReevaluationBatchTask(
+	ctx context.Context,
+	tx repositories.Transaction,
+	organizationId string,
+	batchId string,
+) error {
+	args := m.Called(ctx, tx, organizationId, batchId)
+	return args.Error(0)
+}
+
+func (m *TaskQueueRepository) EnqueueNotificationEmailTask(
+	ctx context.Context,
+	tx repositories.Transaction,
+	organizationId string,
+	userId string,
+	caseId string,
+	message string,
+) error {
+	args := m.Called(ctx, tx, organizationId, userId, caseId, message)
+	return args.Error(0)
+}
+
+func (m *TaskQueueRepository) EnqueueBlobDeletionTask(
+	ctx context.Context,
+	tx repositories.Transaction,
+	organizationId string,
+	blobs []models.BlobRef,
+) error {
+	args := m.Called(ctx, tx, organizationId, blobs)
+	return args.Error(0)
+}
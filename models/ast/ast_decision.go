@@ -0,0 +1,23 @@
+package ast
+
+// The fields of a decision that can be read with the Decision function. The function is only available in the formulas
+// evaluated after the decision is made, such as the case routing rules of a scenario.
+const (
+	DecisionFieldScore               = "score"
+	DecisionFieldOutcome             = "outcome"
+	DecisionFieldTriggeredRules      = "triggered_rules"
+	DecisionFieldSanctionCheckStatus = "sanction_check_status"
+)
+
+var DecisionFields = []string{
+	DecisionFieldScore,
+	DecisionFieldOutcome,
+	DecisionFieldTriggeredRules,
+	DecisionFieldSanctionCheckStatus,
+}
+
+var FuncDecisionAttributes = FuncAttributes{
+	DebugName:      "FUNC_DECISION",
+	AstName:        "Decision",
+	NamedArguments: []string{"field"},
+}
@@ -50,6 +50,7 @@ const (
 	FUNC_STRING_TEMPLATE
 	FUNC_STRING_CONCAT
 	FUNC_FUZZY_MATCH_FILTER_OPTIONS
+	FUNC_DECISION
 	FUNC_UNDEFINED Function = -1
 	FUNC_UNKNOWN   Function = -2
 )
@@ -238,7 +239,8 @@ var FuncAttributesMap = map[Function]FuncAttributes{
 		DebugName: "FUNC_STRING_CONCAT",
 		AstName:   "StringConcat",
 	},
-	FUNC_FILTER: FuncFilterAttributes,
+	FUNC_FILTER:   FuncFilterAttributes,
+	FUNC_DECISION: FuncDecisionAttributes,
 }
 
 var FuncAstNameMap = pure_utils.MapKeyValue(FuncAttributesMap, func(function Function,
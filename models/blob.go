@@ -8,3 +8,9 @@ type Blob struct {
 	FileName   string
 	ReadCloser io.ReadCloser
 }
+
+// BlobRef identifies a file in a bucket
+type BlobRef struct {
+	BucketUrl string `json:"bucket_url"`
+	Key       string `json:"key"`
+}
@@ -23,6 +23,15 @@ type Case struct {
 	Files          []CaseFile
 	SnoozedUntil   *time.Time
 	Boost          *BoostReason
+	// The SLA fields are computed by a periodic job from the SLA policies of the inbox of the case
+	SlaPolicyId      *string
+	FirstActionDueAt *time.Time
+	CloseDueAt       *time.Time
+	// DueAt is the next deadline of the case, nil if it has none
+	DueAt         *time.Time
+	SlaBreachedAt *time.Time
+	// MergedIntoId is the case that a closed case was merged into
+	MergedIntoId *string
 }
 
 func (c Case) GetMetadata() CaseMetadata {
@@ -39,8 +48,12 @@ func (c Case) IsSnoozed() bool {
 	return c.SnoozedUntil != nil && c.SnoozedUntil.After(time.Now())
 }
 
+// FinalizedCaseStatuses are the statuses of the cases that are no longer worked on. All the other statuses, including
+// the custom statuses of case workflows, are open statuses.
+var FinalizedCaseStatuses = []CaseStatus{CaseClosed}
+
 func (c CaseStatus) IsFinalized() bool {
-	return c == CaseClosed
+	return slices.Contains(FinalizedCaseStatuses, c)
 }
 
 type CaseMetadata struct {
@@ -79,7 +92,7 @@ func (s CaseStatus) CanTransition(newStatus CaseStatus) bool {
 }
 
 func (s CaseStatus) EnrichedStatus(snoozedUntil *time.Time, boost *BoostReason) string {
-	if (s == CaseInvestigating || s == CasePending) && snoozedUntil != nil && snoozedUntil.After(time.Now()) {
+	if !s.IsFinalized() && snoozedUntil != nil && snoozedUntil.After(time.Now()) {
 		return "snoozed"
 	}
 	if s == CaseInvestigating && boost != nil {
@@ -106,6 +119,8 @@ type CreateCaseAttributes struct {
 	Name           string
 	OrganizationId string
 	AssigneeId     *string
+	// TagIds are the initial tags of the case
+	TagIds []string
 }
 
 type UpdateCaseAttributes struct {
@@ -115,11 +130,16 @@ type UpdateCaseAttributes struct {
 	Status  CaseStatus
 	Outcome CaseOutcome
 	Boost   BoostReason
+	// Comment explains a status update, it is saved with the status update event
+	Comment string
 }
 
 type CreateCaseCommentAttributes struct {
 	Id      string
 	Comment string
+	// ParentId is the comment that this comment replies to
+	ParentId         *string
+	MentionedUserIds []string
 }
 
 type CaseFilters struct {
@@ -132,6 +152,22 @@ type CaseFilters struct {
 	IncludeSnoozed  bool
 	ExcludeAssigned bool
 	AssigneeId      UserId
+	SlaBreached     *bool
+	DueBefore       time.Time
+	// Query is searched in the names of the cases, the text of their comments and the pivot values of their decisions
+	Query string
+	// TagIds matches the cases that have at least one of the tags
+	TagIds   []string
+	Outcomes []CaseOutcome
+	// ScenarioIds matches the cases with a decision from one of the scenarios
+	ScenarioIds []string
+	// RuleIds matches the cases with a decision on which one of the rules, or another version of one of the rules, was
+	// triggered
+	RuleIds []string
+	// MinScore and MaxScore filter on the highest score of the decisions of the cases
+	MinScore  *int
+	MaxScore  *int
+	SlaStatus CaseSlaStatus
 }
 
 type CaseListPage struct {
@@ -146,7 +182,21 @@ type CaseWithRank struct {
 	RankNumber int
 }
 
-const CasesSortingCreatedAt = SortingFieldCreatedAt
+const (
+	CasesSortingCreatedAt = SortingFieldCreatedAt
+	CasesSortingDueAt     = SortingFieldDueAt
+)
+
+func ValidateCaseOutcomes(outcomes []string) ([]CaseOutcome, error) {
+	sanitizedOutcomes := make([]CaseOutcome, len(outcomes))
+	for i, outcome := range outcomes {
+		sanitizedOutcomes[i] = CaseOutcome(outcome)
+		if !slices.Contains(ValidCaseOutcomes, sanitizedOutcomes[i]) {
+			return []CaseOutcome{}, fmt.Errorf("invalid outcome: %s %w", outcome, BadParameterError)
+		}
+	}
+	return sanitizedOutcomes, nil
+}
 
 func ValidateCaseStatuses(statuses []string) ([]CaseStatus, error) {
 	sanitizedStatuses := make([]CaseStatus, len(statuses))
@@ -186,6 +236,7 @@ const (
 	BoostReassigned  BoostReason = "reassigned"
 	BoostEscalated   BoostReason = "escalated"
 	BoostNewDecision BoostReason = "new_decision"
+	BoostSlaBreached BoostReason = "sla_breached"
 )
 
 func (br *BoostReason) String() string {
@@ -203,3 +254,10 @@ type CaseDecisionsRequest struct {
 	CursorId string
 	Limit    int
 }
+
+type SplitCaseInput struct {
+	CaseId      string
+	DecisionIds []string
+	// Name is the name of the new case, it defaults to the name of the original case with a "(split)" suffix
+	Name string
+}
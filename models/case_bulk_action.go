@@ -0,0 +1,123 @@
+package models
+
+import (
+	"slices"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+// MaxCaseBulkActionSize is the maximum number of cases that a single bulk action can update
+const MaxCaseBulkActionSize = 500
+
+type CaseBulkActionType string
+
+const (
+	CaseBulkActionClose           CaseBulkActionType = "close"
+	CaseBulkActionAssign          CaseBulkActionType = "assign"
+	CaseBulkActionMoveInbox       CaseBulkActionType = "move_inbox"
+	CaseBulkActionTag             CaseBulkActionType = "tag"
+	CaseBulkActionSnooze          CaseBulkActionType = "snooze"
+	CaseBulkActionReviewDecisions CaseBulkActionType = "review_decisions"
+)
+
+type CaseBulkActionStatus string
+
+const (
+	CaseBulkActionPending   CaseBulkActionStatus = "pending"
+	CaseBulkActionRunning   CaseBulkActionStatus = "running"
+	CaseBulkActionCompleted CaseBulkActionStatus = "completed"
+)
+
+type CaseBulkActionItemStatus string
+
+const (
+	CaseBulkActionItemPending CaseBulkActionItemStatus = "pending"
+	CaseBulkActionItemSuccess CaseBulkActionItemStatus = "success"
+	CaseBulkActionItemFailed  CaseBulkActionItemStatus = "failed"
+)
+
+// CaseBulkActionParams are the parameters of a bulk action, of which only those of its type are used
+type CaseBulkActionParams struct {
+	// close
+	Outcome CaseOutcome `json:"outcome,omitempty"`
+	// assign
+	AssigneeId UserId `json:"assignee_id,omitempty"`
+	// move_inbox
+	InboxId string `json:"inbox_id,omitempty"`
+	// tag, the tags are added to those of the case
+	TagIds []string `json:"tag_ids,omitempty"`
+	// snooze
+	SnoozeUntil *time.Time `json:"snooze_until,omitempty"`
+	// review_decisions, applied to every decision of the case that is pending review
+	ReviewStatus  string `json:"review_status,omitempty"`
+	ReviewComment string `json:"review_comment,omitempty"`
+}
+
+func (p CaseBulkActionParams) Validate(action CaseBulkActionType) error {
+	switch action {
+	case CaseBulkActionClose:
+		if !slices.Contains(ValidCaseOutcomes, p.Outcome) || p.Outcome == CaseOutcomeUnset {
+			return errors.Wrapf(BadParameterError, "invalid outcome '%s' to close cases", p.Outcome)
+		}
+	case CaseBulkActionAssign:
+		if p.AssigneeId == "" {
+			return errors.Wrap(BadParameterError, "assignee_id is required to assign cases")
+		}
+	case CaseBulkActionMoveInbox:
+		if p.InboxId == "" {
+			return errors.Wrap(BadParameterError, "inbox_id is required to move cases")
+		}
+	case CaseBulkActionTag:
+		if len(p.TagIds) == 0 {
+			return errors.Wrap(BadParameterError, "tag_ids is required to tag cases")
+		}
+	case CaseBulkActionSnooze:
+		if p.SnoozeUntil == nil || !p.SnoozeUntil.After(time.Now()) {
+			return errors.Wrap(BadParameterError, "snooze_until must be in the future to snooze cases")
+		}
+	case CaseBulkActionReviewDecisions:
+		if p.ReviewStatus != ReviewStatusApprove && p.ReviewStatus != ReviewStatusDecline {
+			return errors.Wrapf(BadParameterError, "invalid review status '%s'", p.ReviewStatus)
+		}
+	default:
+		return errors.Wrapf(BadParameterError, "invalid bulk action '%s'", action)
+	}
+	return nil
+}
+
+// CaseBulkAction is an action applied in the background to a set of cases, on behalf of the user who requested it. The
+// action is performed case by case, with the permissions of the user, and its result is recorded for every case.
+type CaseBulkAction struct {
+	Id             string
+	OrganizationId string
+	UserId         UserId
+	Action         CaseBulkActionType
+	Params         CaseBulkActionParams
+	Status         CaseBulkActionStatus
+	NbCases        int
+	NbSucceeded    int
+	NbFailed       int
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+type CaseBulkActionItem struct {
+	CaseId    string
+	Status    CaseBulkActionItemStatus
+	Error     *string
+	UpdatedAt time.Time
+}
+
+type CaseBulkActionWithItems struct {
+	CaseBulkAction
+	Items []CaseBulkActionItem
+}
+
+type CaseBulkActionCreate struct {
+	OrganizationId string
+	UserId         UserId
+	Action         CaseBulkActionType
+	Params         CaseBulkActionParams
+	CaseIds        []string
+}
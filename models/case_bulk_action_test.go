@@ -0,0 +1,33 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCaseBulkActionParams_Validate(t *testing.T) {
+	future := time.Now().Add(time.Hour)
+	past := time.Now().Add(-time.Hour)
+
+	assert.NoError(t, CaseBulkActionParams{Outcome: CaseFalsePositive}.Validate(CaseBulkActionClose))
+	assert.ErrorIs(t, CaseBulkActionParams{}.Validate(CaseBulkActionClose), BadParameterError)
+	assert.ErrorIs(t, CaseBulkActionParams{Outcome: CaseOutcomeUnset}.Validate(CaseBulkActionClose), BadParameterError)
+
+	assert.NoError(t, CaseBulkActionParams{AssigneeId: "user"}.Validate(CaseBulkActionAssign))
+	assert.ErrorIs(t, CaseBulkActionParams{}.Validate(CaseBulkActionAssign), BadParameterError)
+
+	assert.NoError(t, CaseBulkActionParams{InboxId: "inbox"}.Validate(CaseBulkActionMoveInbox))
+	assert.NoError(t, CaseBulkActionParams{TagIds: []string{"tag"}}.Validate(CaseBulkActionTag))
+	assert.ErrorIs(t, CaseBulkActionParams{}.Validate(CaseBulkActionTag), BadParameterError)
+
+	assert.NoError(t, CaseBulkActionParams{SnoozeUntil: &future}.Validate(CaseBulkActionSnooze))
+	assert.ErrorIs(t, CaseBulkActionParams{SnoozeUntil: &past}.Validate(CaseBulkActionSnooze), BadParameterError)
+
+	assert.NoError(t, CaseBulkActionParams{ReviewStatus: ReviewStatusApprove}.Validate(CaseBulkActionReviewDecisions))
+	assert.ErrorIs(t, CaseBulkActionParams{ReviewStatus: ReviewStatusPending}.Validate(CaseBulkActionReviewDecisions),
+		BadParameterError)
+
+	assert.ErrorIs(t, CaseBulkActionParams{}.Validate("delete"), BadParameterError)
+}
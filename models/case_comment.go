@@ -0,0 +1,45 @@
+package models
+
+import "time"
+
+// CaseComment is a comment posted on a case, or a reply to a comment. Replies are not nested: a reply to a reply is
+// attached to the comment that started the thread.
+type CaseComment struct {
+	Id             string
+	OrganizationId string
+	CaseId         string
+	// ParentId is the comment that started the thread, nil for the comment itself
+	ParentId         *string
+	AuthorId         *string
+	Body             string
+	MentionedUserIds []string
+	CreatedAt        time.Time
+	EditedAt         *time.Time
+	DeletedAt        *time.Time
+}
+
+// CaseCommentVersion is the content of a comment before an edit
+type CaseCommentVersion struct {
+	Id               string
+	CommentId        string
+	Body             string
+	MentionedUserIds []string
+	EditedBy         *string
+	CreatedAt        time.Time
+}
+
+type CaseCommentCreate struct {
+	OrganizationId   string
+	CaseId           string
+	ParentId         *string
+	AuthorId         string
+	Body             string
+	MentionedUserIds []string
+}
+
+type UpdateCaseCommentAttributes struct {
+	CaseId           string
+	CommentId        string
+	Body             string
+	MentionedUserIds []string
+}
@@ -23,6 +23,9 @@ type CaseEventType string
 
 const (
 	CaseCommentAdded      CaseEventType = "comment_added"
+	CaseCommentUpdated    CaseEventType = "comment_updated"
+	CaseCommentDeleted    CaseEventType = "comment_deleted"
+	CaseCommentMention    CaseEventType = "comment_mention"
 	CaseCreated           CaseEventType = "case_created"
 	CaseFileAdded         CaseEventType = "file_added"
 	CaseInboxChanged      CaseEventType = "inbox_changed"
@@ -36,22 +39,32 @@ const (
 	SarDeleted            CaseEventType = "sar_deleted"
 	SarStatusChanged      CaseEventType = "sar_status_changed"
 	SarFileUploaded       CaseEventType = "sar_file_uploaded"
+	SarContentUpdated     CaseEventType = "sar_content_updated"
 	SanctionCheckReviewed CaseEventType = "sanction_check_reviewed"
 	DecisionAdded         CaseEventType = "decision_added"
 	DecisionReviewed      CaseEventType = "decision_reviewed"
 	CaseSnoozed           CaseEventType = "case_snoozed"
 	CaseUnsnoozed         CaseEventType = "case_unsnoozed"
 	CaseEscalated         CaseEventType = "case_escalated"
+	CaseSlaBreached       CaseEventType = "sla_breached"
+	CaseMerged            CaseEventType = "case_merged"
+	CaseSplit             CaseEventType = "case_split"
+	CaseExported          CaseEventType = "case_exported"
+	CaseQaReviewRequested CaseEventType = "qa_review_requested"
+	CaseQaReviewed        CaseEventType = "qa_reviewed"
 )
 
 type CaseEventResourceType string
 
 const (
-	DecisionResourceType   CaseEventResourceType = "decision"
-	CaseTagResourceType    CaseEventResourceType = "case_tag"
-	CaseFileResourceType   CaseEventResourceType = "case_file"
-	RuleSnoozeResourceType CaseEventResourceType = "rule_snooze"
-	SarResourceType        CaseEventResourceType = "sar"
+	DecisionResourceType     CaseEventResourceType = "decision"
+	CaseTagResourceType      CaseEventResourceType = "case_tag"
+	CaseFileResourceType     CaseEventResourceType = "case_file"
+	RuleSnoozeResourceType   CaseEventResourceType = "rule_snooze"
+	SarResourceType          CaseEventResourceType = "sar"
+	CaseResourceType         CaseEventResourceType = "case"
+	CaseCommentResourceType  CaseEventResourceType = "case_comment"
+	CaseQaReviewResourceType CaseEventResourceType = "case_qa_review"
 )
 
 type CreateCaseEventAttributes struct {
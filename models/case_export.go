@@ -0,0 +1,66 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+type CaseExportStatus string
+
+const (
+	CaseExportPending CaseExportStatus = "pending"
+	CaseExportRunning CaseExportStatus = "running"
+	CaseExportSuccess CaseExportStatus = "success"
+	CaseExportFailed  CaseExportStatus = "failed"
+)
+
+// CaseExport is an asynchronous export of the complete file of a case, for auditors and regulators. It is a single
+// ZIP archive in the case manager bucket, that contains an HTML summary of the case, JSON files with its metadata,
+// timeline, decisions, sanction checks, annotations and suspicious activity reports, the files of the case and of its
+// reports, and a manifest with the SHA-256 hash of every other file of the archive.
+type CaseExport struct {
+	Id             string
+	OrganizationId string
+	CaseId         string
+	Status         CaseExportStatus
+	// NbFiles is the number of files in the archive, excluding the manifest
+	NbFiles           int
+	SizeBytes         int64
+	RequestedByUserId *UserId
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+func (e CaseExport) FileKey() string {
+	return fmt.Sprintf("case_exports/%s/%s/%s.zip", e.OrganizationId, e.CaseId, e.Id)
+}
+
+func (e CaseExport) FileName() string {
+	return fmt.Sprintf("case-%s-%s.zip", e.CaseId, e.CreatedAt.UTC().Format("20060102-150405"))
+}
+
+type CaseExportWithUrl struct {
+	CaseExport
+	// FileUrl is a signed url to download the archive, set once the export has succeeded
+	FileUrl *string
+}
+
+type CaseExportUpdate struct {
+	Status    CaseExportStatus
+	NbFiles   int
+	SizeBytes int64
+}
+
+// CaseExportManifest is the last file of a case export archive
+type CaseExportManifest struct {
+	CaseId      string                   `json:"case_id"`
+	ExportId    string                   `json:"export_id"`
+	GeneratedAt time.Time                `json:"generated_at"`
+	Files       []CaseExportManifestFile `json:"files"`
+}
+
+type CaseExportManifestFile struct {
+	Path      string `json:"path"`
+	SizeBytes int64  `json:"size_bytes"`
+	Sha256    string `json:"sha256"`
+}
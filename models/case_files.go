@@ -12,6 +12,7 @@ type CaseFile struct {
 	BucketName    string
 	FileReference string
 	FileName      string
+	ScanStatus    FileScanStatus
 }
 
 type CreateCaseFilesInput struct {
@@ -25,4 +26,5 @@ type CreateDbCaseFileInput struct {
 	CaseId        string
 	FileName      string
 	FileReference string
+	ScanStatus    FileScanStatus
 }
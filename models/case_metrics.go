@@ -0,0 +1,131 @@
+package models
+
+import (
+	"slices"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+// MaxCaseMetricsPeriod is the longest period over which case metrics can be computed
+const MaxCaseMetricsPeriod = 366 * 24 * time.Hour
+
+type CaseMetricType string
+
+const (
+	CaseMetricVolume               CaseMetricType = "volume"
+	CaseMetricTimeToCloseByInbox   CaseMetricType = "time_to_close_by_inbox"
+	CaseMetricTimeToCloseByAnalyst CaseMetricType = "time_to_close_by_analyst"
+	CaseMetricBacklogAge           CaseMetricType = "backlog_age"
+	CaseMetricOutcomes             CaseMetricType = "outcomes"
+	CaseMetricEscalations          CaseMetricType = "escalations"
+)
+
+var ValidCaseMetricTypes = []CaseMetricType{
+	CaseMetricVolume,
+	CaseMetricTimeToCloseByInbox,
+	CaseMetricTimeToCloseByAnalyst,
+	CaseMetricBacklogAge,
+	CaseMetricOutcomes,
+	CaseMetricEscalations,
+}
+
+func CaseMetricTypeFrom(s string) (CaseMetricType, error) {
+	t := CaseMetricType(s)
+	if !slices.Contains(ValidCaseMetricTypes, t) {
+		return "", errors.Wrapf(BadParameterError, "invalid case metric '%s'", s)
+	}
+	return t, nil
+}
+
+type CaseMetricsFilters struct {
+	OrganizationId string
+	// InboxIds restricts the metrics to the cases of these inboxes, the metrics cover all the inboxes that the user can
+	// read if empty
+	InboxIds  []string
+	StartDate time.Time
+	EndDate   time.Time
+	// Timezone is the IANA time zone in which the cases are counted by day, UTC if empty
+	Timezone string
+}
+
+func (f CaseMetricsFilters) Validate() error {
+	if !f.StartDate.Before(f.EndDate) {
+		return errors.Wrap(BadParameterError, "the start date must be before the end date")
+	}
+	if f.EndDate.Sub(f.StartDate) > MaxCaseMetricsPeriod {
+		return errors.Wrap(BadParameterError, "case metrics cannot be computed over more than a year")
+	}
+	if _, err := time.LoadLocation(f.Timezone); err != nil {
+		return errors.Wrapf(BadParameterError, "invalid timezone '%s'", f.Timezone)
+	}
+	return nil
+}
+
+// CaseVolumeMetric is the number of cases opened and closed on a day. A case that is closed several times is counted
+// every time.
+type CaseVolumeMetric struct {
+	Day      time.Time
+	NbOpened int
+	NbClosed int
+}
+
+// CaseTimeToCloseMetric is the time from the creation of the cases of an inbox, or of the cases closed by an analyst,
+// to their closure in the period
+type CaseTimeToCloseMetric struct {
+	// GroupId is the id of the inbox or of the analyst
+	GroupId           string
+	NbClosed          int
+	MedianTimeToClose time.Duration
+}
+
+type CaseBacklogAgeBucket struct {
+	Label string
+	// MaxAge is the age under which an open case falls in the bucket, the last bucket has no maximum age
+	MaxAge time.Duration
+}
+
+var CaseBacklogAgeBuckets = []CaseBacklogAgeBucket{
+	{Label: "less_than_1_day", MaxAge: 24 * time.Hour},
+	{Label: "1_to_3_days", MaxAge: 3 * 24 * time.Hour},
+	{Label: "3_to_7_days", MaxAge: 7 * 24 * time.Hour},
+	{Label: "7_to_30_days", MaxAge: 30 * 24 * time.Hour},
+	{Label: "more_than_30_days"},
+}
+
+// CaseBacklogAgeMetric is the number of the currently open cases in an age bucket, regardless of the period
+type CaseBacklogAgeMetric struct {
+	Bucket  string
+	NbCases int
+}
+
+// CaseOutcomeMetric is the number of the cases closed in the period that have an outcome
+type CaseOutcomeMetric struct {
+	Outcome CaseOutcome
+	NbCases int
+}
+
+// CaseEscalationMetric compares the cases that an inbox escalated to another inbox in the period, to the cases it
+// closed itself
+type CaseEscalationMetric struct {
+	InboxId     string
+	NbClosed    int
+	NbEscalated int
+}
+
+// EscalationRate is the percentage of the cases handled by the inbox that were escalated rather than closed
+func (m CaseEscalationMetric) EscalationRate() float64 {
+	if m.NbClosed+m.NbEscalated == 0 {
+		return 0
+	}
+	return float64(m.NbEscalated) * 100 / float64(m.NbClosed+m.NbEscalated)
+}
+
+type CaseMetrics struct {
+	Volume               []CaseVolumeMetric
+	TimeToCloseByInbox   []CaseTimeToCloseMetric
+	TimeToCloseByAnalyst []CaseTimeToCloseMetric
+	BacklogAge           []CaseBacklogAgeMetric
+	Outcomes             []CaseOutcomeMetric
+	Escalations          []CaseEscalationMetric
+}
@@ -0,0 +1,44 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCaseMetricsFilters_Validate(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	valid := CaseMetricsFilters{StartDate: start, EndDate: start.AddDate(0, 1, 0)}
+	assert.NoError(t, valid.Validate())
+
+	filters := valid
+	filters.Timezone = "Europe/Paris"
+	assert.NoError(t, filters.Validate())
+
+	filters = valid
+	filters.Timezone = "Mars/Olympus_Mons"
+	assert.ErrorIs(t, filters.Validate(), BadParameterError)
+
+	filters = valid
+	filters.EndDate = start
+	assert.ErrorIs(t, filters.Validate(), BadParameterError)
+
+	filters = valid
+	filters.EndDate = start.AddDate(2, 0, 0)
+	assert.ErrorIs(t, filters.Validate(), BadParameterError)
+}
+
+func TestCaseMetricTypeFrom(t *testing.T) {
+	metric, err := CaseMetricTypeFrom("backlog_age")
+	assert.NoError(t, err)
+	assert.Equal(t, CaseMetricBacklogAge, metric)
+
+	_, err = CaseMetricTypeFrom("unknown")
+	assert.ErrorIs(t, err, BadParameterError)
+}
+
+func TestCaseEscalationMetric_EscalationRate(t *testing.T) {
+	assert.Equal(t, 25.0, CaseEscalationMetric{NbClosed: 3, NbEscalated: 1}.EscalationRate())
+	assert.Equal(t, 0.0, CaseEscalationMetric{}.EscalationRate())
+}
@@ -0,0 +1,182 @@
+package models
+
+import (
+	"slices"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+// InboxQaRule samples a share of the cases of an inbox when they are closed, for a second review of their outcome by a
+// QA reviewer. A rule can be restricted to the cases closed with a given outcome, or to the cases that have a tag. The
+// rules of an inbox are evaluated by ascending priority, and the first one that matches a case applies to it.
+type InboxQaRule struct {
+	Id             string
+	OrganizationId string
+	InboxId        string
+	Name           string
+	Priority       int
+	CaseOutcome    *CaseOutcome
+	TagId          *string
+	// SampleRate is the percentage of the matching cases that are reviewed
+	SampleRate float64
+	// ReviewerIds are the QA users the reviews are assigned to, they must be users of the inbox
+	ReviewerIds []string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type InboxQaRuleInput struct {
+	Name        string
+	Priority    int
+	CaseOutcome *CaseOutcome
+	TagId       *string
+	SampleRate  float64
+	ReviewerIds []string
+}
+
+func (input InboxQaRuleInput) Validate() error {
+	if input.Name == "" {
+		return errors.Wrap(BadParameterError, "the name of the QA rule is required")
+	}
+	if input.SampleRate <= 0 || input.SampleRate > 100 {
+		return errors.Wrap(BadParameterError, "sample_rate must be a percentage greater than 0")
+	}
+	if input.CaseOutcome != nil && (!slices.Contains(ValidCaseOutcomes, *input.CaseOutcome) ||
+		*input.CaseOutcome == CaseOutcomeUnset) {
+		return errors.Wrapf(BadParameterError, "invalid case outcome '%s'", *input.CaseOutcome)
+	}
+	if len(input.ReviewerIds) == 0 {
+		return errors.Wrap(BadParameterError, "a QA rule must have at least one reviewer")
+	}
+	return nil
+}
+
+// Matches tells whether the rule applies to a case closed with the given outcome and tags
+func (r InboxQaRule) Matches(outcome CaseOutcome, tagIds []string) bool {
+	if r.CaseOutcome != nil && *r.CaseOutcome != outcome {
+		return false
+	}
+	if r.TagId != nil && !slices.Contains(tagIds, *r.TagId) {
+		return false
+	}
+	return true
+}
+
+// PickQaReviewer returns the reviewer of the rule with the fewest pending reviews, other than the excluded users, who
+// are the analysts of the case. Ties are broken by the order of the reviewers in the rule.
+func (r InboxQaRule) PickQaReviewer(pendingReviews map[string]int, excluded ...string) (string, bool) {
+	reviewerId, found := "", false
+	for _, id := range r.ReviewerIds {
+		if slices.Contains(excluded, id) {
+			continue
+		}
+		if !found || pendingReviews[id] < pendingReviews[reviewerId] {
+			reviewerId, found = id, true
+		}
+	}
+	return reviewerId, found
+}
+
+type CaseQaReviewStatus string
+
+const (
+	CaseQaReviewPending   CaseQaReviewStatus = "pending"
+	CaseQaReviewCompleted CaseQaReviewStatus = "completed"
+)
+
+type CaseQaVerdict string
+
+const (
+	CaseQaAgree    CaseQaVerdict = "agree"
+	CaseQaDisagree CaseQaVerdict = "disagree"
+)
+
+// CaseQaReview is the second review of the outcome of a closed case, assigned to a QA reviewer
+type CaseQaReview struct {
+	Id             string
+	OrganizationId string
+	InboxId        string
+	CaseId         string
+	RuleId         *string
+	// AnalystId is the user who closed the case, whose outcome is reviewed
+	AnalystId   *string
+	ReviewerId  string
+	CaseOutcome CaseOutcome
+	Status      CaseQaReviewStatus
+	Verdict     *CaseQaVerdict
+	// ReviewerOutcome is the outcome that the reviewer would have chosen, when they disagree
+	ReviewerOutcome *CaseOutcome
+	Comment         string
+	CreatedAt       time.Time
+	CompletedAt     *time.Time
+}
+
+type CaseQaReviewCreate struct {
+	OrganizationId string
+	InboxId        string
+	CaseId         string
+	RuleId         string
+	AnalystId      string
+	ReviewerId     string
+	CaseOutcome    CaseOutcome
+}
+
+type CaseQaReviewCompletion struct {
+	Verdict         CaseQaVerdict
+	ReviewerOutcome *CaseOutcome
+	Comment         string
+}
+
+func (input CaseQaReviewCompletion) Validate(review CaseQaReview) error {
+	switch input.Verdict {
+	case CaseQaAgree:
+		if input.ReviewerOutcome != nil && *input.ReviewerOutcome != review.CaseOutcome {
+			return errors.Wrap(BadParameterError, "the outcome of the reviewer must be the outcome of the case")
+		}
+	case CaseQaDisagree:
+		if input.ReviewerOutcome == nil || !slices.Contains(ValidCaseOutcomes, *input.ReviewerOutcome) ||
+			*input.ReviewerOutcome == CaseOutcomeUnset || *input.ReviewerOutcome == review.CaseOutcome {
+			return errors.Wrap(BadParameterError,
+				"a disagreement requires an outcome different from the outcome of the case")
+		}
+		if input.Comment == "" {
+			return errors.Wrap(BadParameterError, "a disagreement requires a comment")
+		}
+	default:
+		return errors.Wrapf(BadParameterError, "invalid QA verdict '%s'", input.Verdict)
+	}
+	return nil
+}
+
+type CaseQaReviewFilters struct {
+	OrganizationId string
+	ReviewerId     *string
+	InboxId        *string
+	CaseId         *string
+	Status         *CaseQaReviewStatus
+}
+
+type CaseQaScoreFilters struct {
+	OrganizationId string
+	InboxId        *string
+	// the reviews completed between StartDate and EndDate are counted
+	StartDate time.Time
+	EndDate   time.Time
+}
+
+// CaseQaScore is the result of the completed QA reviews of the cases closed by an analyst
+type CaseQaScore struct {
+	AnalystId       string
+	NbReviews       int
+	NbAgreements    int
+	NbDisagreements int
+}
+
+// AgreementRate is the percentage of the reviews that agreed with the outcome of the analyst
+func (s CaseQaScore) AgreementRate() float64 {
+	if s.NbReviews == 0 {
+		return 0
+	}
+	return float64(s.NbAgreements) * 100 / float64(s.NbReviews)
+}
@@ -0,0 +1,85 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInboxQaRuleInput_Validate(t *testing.T) {
+	valid := InboxQaRuleInput{Name: "false positives", SampleRate: 10, ReviewerIds: []string{"senior"}}
+	assert.NoError(t, valid.Validate())
+
+	input := valid
+	input.SampleRate = 0
+	assert.ErrorIs(t, input.Validate(), BadParameterError)
+
+	input = valid
+	input.SampleRate = 101
+	assert.ErrorIs(t, input.Validate(), BadParameterError)
+
+	input = valid
+	input.ReviewerIds = nil
+	assert.ErrorIs(t, input.Validate(), BadParameterError)
+
+	input = valid
+	outcome := CaseOutcome(CaseOutcomeUnset)
+	input.CaseOutcome = &outcome
+	assert.ErrorIs(t, input.Validate(), BadParameterError)
+}
+
+func TestInboxQaRule_Matches(t *testing.T) {
+	outcome := CaseOutcome(CaseFalsePositive)
+	tagId := "tag"
+	rule := InboxQaRule{CaseOutcome: &outcome, TagId: &tagId}
+
+	assert.True(t, rule.Matches(CaseFalsePositive, []string{"other", "tag"}))
+	assert.False(t, rule.Matches(CaseConfirmedRisk, []string{"tag"}))
+	assert.False(t, rule.Matches(CaseFalsePositive, nil))
+	assert.True(t, InboxQaRule{}.Matches(CaseConfirmedRisk, nil))
+}
+
+func TestInboxQaRule_PickQaReviewer(t *testing.T) {
+	rule := InboxQaRule{ReviewerIds: []string{"alice", "bob", "carol"}}
+
+	reviewerId, ok := rule.PickQaReviewer(map[string]int{"alice": 2, "bob": 1, "carol": 1})
+	assert.True(t, ok)
+	assert.Equal(t, "bob", reviewerId)
+
+	reviewerId, ok = rule.PickQaReviewer(map[string]int{"alice": 2, "bob": 1, "carol": 1}, "bob")
+	assert.True(t, ok)
+	assert.Equal(t, "carol", reviewerId)
+
+	_, ok = rule.PickQaReviewer(nil, "alice", "bob", "carol")
+	assert.False(t, ok)
+}
+
+func TestCaseQaReviewCompletion_Validate(t *testing.T) {
+	review := CaseQaReview{CaseOutcome: CaseFalsePositive}
+	confirmed := CaseOutcome(CaseConfirmedRisk)
+	falsePositive := CaseOutcome(CaseFalsePositive)
+
+	assert.NoError(t, CaseQaReviewCompletion{Verdict: CaseQaAgree}.Validate(review))
+	assert.ErrorIs(t, CaseQaReviewCompletion{Verdict: CaseQaAgree, ReviewerOutcome: &confirmed}.Validate(review),
+		BadParameterError)
+
+	assert.NoError(t, CaseQaReviewCompletion{
+		Verdict:         CaseQaDisagree,
+		ReviewerOutcome: &confirmed,
+		Comment:         "the transactions match a known fraud pattern",
+	}.Validate(review))
+	assert.ErrorIs(t, CaseQaReviewCompletion{Verdict: CaseQaDisagree, ReviewerOutcome: &confirmed}.Validate(review),
+		BadParameterError)
+	assert.ErrorIs(t, CaseQaReviewCompletion{
+		Verdict:         CaseQaDisagree,
+		ReviewerOutcome: &falsePositive,
+		Comment:         "same outcome",
+	}.Validate(review), BadParameterError)
+
+	assert.ErrorIs(t, CaseQaReviewCompletion{Verdict: "maybe"}.Validate(review), BadParameterError)
+}
+
+func TestCaseQaScore_AgreementRate(t *testing.T) {
+	assert.Equal(t, 75.0, CaseQaScore{NbReviews: 4, NbAgreements: 3, NbDisagreements: 1}.AgreementRate())
+	assert.Equal(t, 0.0, CaseQaScore{}.AgreementRate())
+}
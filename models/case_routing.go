@@ -0,0 +1,57 @@
+package models
+
+import (
+	"slices"
+	"time"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/checkmarble/marble-backend/models/ast"
+)
+
+// CaseRoutingRule sends the cases created by the decisions of a scenario to an inbox, with initial tags. The formula
+// is a boolean AST expression that can read the trigger object, its linked objects and the fields of the decision.
+// The rules of a scenario are evaluated by ascending priority, and the first one that matches a decision applies. If
+// no rule matches, the case is created in the default inbox of the scenario.
+type CaseRoutingRule struct {
+	Id             string
+	OrganizationId string
+	ScenarioId     string
+	Priority       int
+	Name           string
+	Formula        *ast.Node
+	InboxId        string
+	TagIds         []string
+	CreatedAt      time.Time
+}
+
+type CaseRoutingRuleInput struct {
+	Name    string
+	Formula *ast.Node
+	InboxId string
+	TagIds  []string
+}
+
+func (input CaseRoutingRuleInput) Validate() error {
+	if input.Name == "" {
+		return errors.Wrap(BadParameterError, "the name of the routing rule is required")
+	}
+	if input.Formula == nil {
+		return errors.Wrap(BadParameterError, "the formula of the routing rule is required")
+	}
+	if input.InboxId == "" {
+		return errors.Wrap(BadParameterError, "the inbox of the routing rule is required")
+	}
+	for i, tagId := range input.TagIds {
+		if slices.Contains(input.TagIds[:i], tagId) {
+			return errors.Wrap(BadParameterError, "the tags of the routing rule must be unique")
+		}
+	}
+	return nil
+}
+
+// CaseRoute is where the case of a decision is created
+type CaseRoute struct {
+	InboxId string
+	TagIds  []string
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/checkmarble/marble-backend/models/ast"
+)
+
+func TestCaseRoutingRuleInput_Validate(t *testing.T) {
+	formula := ast.Node{Constant: true}
+
+	valid := CaseRoutingRuleInput{
+		Name:    "high risk",
+		Formula: &formula,
+		InboxId: "0a5ef5bb-7a7e-4e2b-9b5b-6e3fa0c4cdb1",
+		TagIds:  []string{"tag-1", "tag-2"},
+	}
+	assert.NoError(t, valid.Validate())
+
+	noName := valid
+	noName.Name = ""
+	assert.ErrorIs(t, noName.Validate(), BadParameterError)
+
+	noFormula := valid
+	noFormula.Formula = nil
+	assert.ErrorIs(t, noFormula.Validate(), BadParameterError)
+
+	noInbox := valid
+	noInbox.InboxId = ""
+	assert.ErrorIs(t, noInbox.Validate(), BadParameterError)
+
+	duplicateTags := valid
+	duplicateTags.TagIds = []string{"tag-1", "tag-1"}
+	assert.ErrorIs(t, duplicateTags.Validate(), BadParameterError)
+}
@@ -0,0 +1,82 @@
+package models
+
+import (
+	"slices"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+// CaseSavedView is a named set of case filters with a sort order, saved by a user. A view is private to its owner,
+// unless it is shared with the members of an inbox.
+type CaseSavedView struct {
+	Id             string
+	OrganizationId string
+	OwnerId        string
+	// InboxId is the inbox the view is shared with
+	InboxId   *string
+	Name      string
+	Filters   CaseViewFilters
+	Sorting   SortingField
+	Order     SortingOrder
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// CaseViewFilters are the filters of a saved view, stored as JSON. The date range is not part of a view, because it
+// would not be relevant for long.
+type CaseViewFilters struct {
+	Query           string        `json:"q,omitempty"`
+	Name            string        `json:"name,omitempty"`
+	Statuses        []CaseStatus  `json:"statuses,omitempty"`
+	InboxIds        []string      `json:"inbox_ids,omitempty"`
+	TagIds          []string      `json:"tag_ids,omitempty"`
+	Outcomes        []CaseOutcome `json:"outcomes,omitempty"`
+	ScenarioIds     []string      `json:"scenario_ids,omitempty"`
+	RuleIds         []string      `json:"rule_ids,omitempty"`
+	MinScore        *int          `json:"min_score,omitempty"`
+	MaxScore        *int          `json:"max_score,omitempty"`
+	SlaStatus       CaseSlaStatus `json:"sla_status,omitempty"`
+	IncludeSnoozed  bool          `json:"include_snoozed,omitempty"`
+	ExcludeAssigned bool          `json:"exclude_assigned,omitempty"`
+	AssigneeId      UserId        `json:"assignee_id,omitempty"`
+}
+
+func (f CaseViewFilters) Validate() error {
+	for _, status := range f.Statuses {
+		if !caseStatusNameRegex.MatchString(string(status)) || status == CaseUnknownStatus {
+			return errors.Wrapf(BadParameterError, "invalid status: %s", status)
+		}
+	}
+	for _, outcome := range f.Outcomes {
+		if !slices.Contains(ValidCaseOutcomes, outcome) {
+			return errors.Wrapf(BadParameterError, "invalid outcome: %s", outcome)
+		}
+	}
+	if _, err := CaseSlaStatusFrom(string(f.SlaStatus)); err != nil {
+		return err
+	}
+	if f.MinScore != nil && f.MaxScore != nil && *f.MinScore > *f.MaxScore {
+		return errors.Wrap(BadParameterError, "min_score must be lower than max_score")
+	}
+	return nil
+}
+
+type CaseSavedViewInput struct {
+	OrganizationId string
+	OwnerId        string
+	InboxId        *string
+	Name           string
+	Filters        CaseViewFilters
+	Sorting        SortingField
+	Order          SortingOrder
+}
+
+type UpdateCaseSavedViewInput struct {
+	Name    *string
+	Filters *CaseViewFilters
+	Sorting *SortingField
+	Order   *SortingOrder
+	// InboxId shares the view with another inbox when set, and makes it private again when set to an empty string
+	InboxId *string
+}
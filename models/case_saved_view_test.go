@@ -0,0 +1,37 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCaseViewFilters_Validate(t *testing.T) {
+	low, high := 10, 50
+
+	valid := CaseViewFilters{
+		Statuses:  []CaseStatus{CasePending, CaseInvestigating},
+		Outcomes:  []CaseOutcome{CaseFalsePositive},
+		SlaStatus: CaseSlaStatusOverdue,
+		MinScore:  &low,
+		MaxScore:  &high,
+	}
+	assert.NoError(t, valid.Validate())
+	assert.NoError(t, CaseViewFilters{}.Validate())
+
+	badStatus := valid
+	badStatus.Statuses = []CaseStatus{"Closed!"}
+	assert.ErrorIs(t, badStatus.Validate(), BadParameterError)
+
+	badOutcome := valid
+	badOutcome.Outcomes = []CaseOutcome{"maybe"}
+	assert.ErrorIs(t, badOutcome.Validate(), BadParameterError)
+
+	badSla := valid
+	badSla.SlaStatus = "late"
+	assert.ErrorIs(t, badSla.Validate(), BadParameterError)
+
+	badRange := valid
+	badRange.MinScore, badRange.MaxScore = &high, &low
+	assert.ErrorIs(t, badRange.Validate(), BadParameterError)
+}
@@ -0,0 +1,119 @@
+package models
+
+import (
+	"slices"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+type SlaBreachAction string
+
+const (
+	SlaBreachFlag     SlaBreachAction = "flag"
+	SlaBreachBoost    SlaBreachAction = "boost"
+	SlaBreachEscalate SlaBreachAction = "escalate"
+)
+
+var ValidSlaBreachActions = []SlaBreachAction{SlaBreachFlag, SlaBreachBoost, SlaBreachEscalate}
+
+type SlaBreachKind string
+
+const (
+	SlaBreachFirstAction SlaBreachKind = "first_action"
+	SlaBreachClose       SlaBreachKind = "close"
+)
+
+// InboxSlaPolicy sets the deadlines of the open cases of an inbox, counted from the creation of the case. A policy can be
+// restricted to the cases that have a tag, or a decision with a given outcome. The policies of an inbox are evaluated
+// by ascending priority, and the first one that matches a case applies to it.
+type InboxSlaPolicy struct {
+	Id              string
+	OrganizationId  string
+	InboxId         string
+	Name            string
+	Priority        int
+	DecisionOutcome *Outcome
+	TagId           *string
+	// FirstActionMinutes is the time allowed for a case to leave the pending status
+	FirstActionMinutes *int
+	// CloseMinutes is the time allowed for a case to be closed
+	CloseMinutes *int
+	BreachAction SlaBreachAction
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+type InboxSlaPolicyInput struct {
+	Name               string
+	Priority           int
+	DecisionOutcome    *Outcome
+	TagId              *string
+	FirstActionMinutes *int
+	CloseMinutes       *int
+	BreachAction       SlaBreachAction
+}
+
+func (input InboxSlaPolicyInput) Validate() error {
+	if input.Name == "" {
+		return errors.Wrap(BadParameterError, "the name of the SLA policy is required")
+	}
+	if input.FirstActionMinutes == nil && input.CloseMinutes == nil {
+		return errors.Wrap(BadParameterError, "an SLA policy must set at least one deadline")
+	}
+	if input.FirstActionMinutes != nil && *input.FirstActionMinutes <= 0 {
+		return errors.Wrap(BadParameterError, "first_action_minutes must be greater than 0")
+	}
+	if input.CloseMinutes != nil && *input.CloseMinutes <= 0 {
+		return errors.Wrap(BadParameterError, "close_minutes must be greater than 0")
+	}
+	if input.DecisionOutcome != nil && !slices.Contains(ValidOutcomes, *input.DecisionOutcome) {
+		return errors.Wrap(BadParameterError, "invalid decision outcome")
+	}
+	if !slices.Contains(ValidSlaBreachActions, input.BreachAction) {
+		return errors.Wrapf(BadParameterError, "invalid breach action: %s", input.BreachAction)
+	}
+	return nil
+}
+
+// CaseSlaBreach is an open case that missed one of the deadlines set by its SLA policy
+type CaseSlaBreach struct {
+	CaseId         string
+	OrganizationId string
+	InboxId        string
+	PolicyId       string
+	Kind           SlaBreachKind
+	DueAt          time.Time
+	BreachAction   SlaBreachAction
+}
+
+// CaseSlaStatus is where a case stands with regard to the deadlines of its SLA policy, used to filter the cases
+type CaseSlaStatus string
+
+const (
+	// the case has no deadline: it is closed or no SLA policy applies to it
+	CaseSlaStatusNone CaseSlaStatus = "none"
+	// the next deadline of the case is in the future
+	CaseSlaStatusOnTrack CaseSlaStatus = "on_track"
+	// the next deadline of the case has passed, but the breach was not handled by the SLA job yet
+	CaseSlaStatusOverdue  CaseSlaStatus = "overdue"
+	CaseSlaStatusBreached CaseSlaStatus = "breached"
+)
+
+var ValidCaseSlaStatuses = []CaseSlaStatus{
+	CaseSlaStatusNone,
+	CaseSlaStatusOnTrack,
+	CaseSlaStatusOverdue,
+	CaseSlaStatusBreached,
+}
+
+func CaseSlaStatusFrom(s string) (CaseSlaStatus, error) {
+	if s == "" {
+		return "", nil
+	}
+	status := CaseSlaStatus(s)
+	if !slices.Contains(ValidCaseSlaStatuses, status) {
+		return "", errors.Wrapf(BadParameterError, "invalid SLA status: %s", s)
+	}
+	return status, nil
+}
@@ -0,0 +1,43 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInboxSlaPolicyInput_Validate(t *testing.T) {
+	minutes := 60
+	zero := 0
+	unknown := UnknownOutcome
+	review := Review
+
+	valid := InboxSlaPolicyInput{Name: "default", CloseMinutes: &minutes, BreachAction: SlaBreachFlag}
+	assert.NoError(t, valid.Validate())
+
+	withOutcome := valid
+	withOutcome.DecisionOutcome = &review
+	withOutcome.FirstActionMinutes = &minutes
+	withOutcome.BreachAction = SlaBreachEscalate
+	assert.NoError(t, withOutcome.Validate())
+
+	noName := valid
+	noName.Name = ""
+	assert.ErrorIs(t, noName.Validate(), BadParameterError)
+
+	noDeadline := valid
+	noDeadline.CloseMinutes = nil
+	assert.ErrorIs(t, noDeadline.Validate(), BadParameterError)
+
+	zeroDeadline := valid
+	zeroDeadline.FirstActionMinutes = &zero
+	assert.ErrorIs(t, zeroDeadline.Validate(), BadParameterError)
+
+	badOutcome := valid
+	badOutcome.DecisionOutcome = &unknown
+	assert.ErrorIs(t, badOutcome.Validate(), BadParameterError)
+
+	badAction := valid
+	badAction.BreachAction = "close"
+	assert.ErrorIs(t, badAction.Validate(), BadParameterError)
+}
@@ -0,0 +1,122 @@
+package models
+
+import (
+	"regexp"
+	"slices"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+var caseStatusNameRegex = regexp.MustCompile(`^[a-z][a-z0-9_]{0,62}$`)
+
+// reservedCaseStatusNames are the built-in statuses, and the statuses that are derived from them for display
+var reservedCaseStatusNames = []CaseStatus{
+	CasePending, CaseInvestigating, CaseClosed, CaseUnknownStatus, "snoozed", "waiting_for_action",
+}
+
+// InboxCaseWorkflow replaces the default status workflow of the cases of an inbox. It adds custom statuses between
+// pending and closed, and lists every transition that users are allowed to make between statuses, with the fields that
+// the transition requires and the inbox roles that can make it. A custom status is an open status: the case is neither
+// pending nor closed.
+type InboxCaseWorkflow struct {
+	InboxId        string
+	OrganizationId string
+	Statuses       []CaseWorkflowStatus
+	Transitions    []CaseStatusTransition
+	UpdatedAt      time.Time
+}
+
+type CaseWorkflowStatus struct {
+	Name  CaseStatus `json:"name"`
+	Label string     `json:"label"`
+}
+
+type CaseStatusTransition struct {
+	From CaseStatus `json:"from"`
+	To   CaseStatus `json:"to"`
+	// RequireOutcome requires the case to have an outcome after the transition
+	RequireOutcome bool `json:"require_outcome"`
+	// RequireComment requires a comment with the status update
+	RequireComment bool `json:"require_comment"`
+	// RequireSar requires the case to have a suspicious activity report
+	RequireSar bool `json:"require_sar"`
+	// AllowedRoles restricts the transition to the users with one of these roles in the inbox, organization admins can
+	// always make it. Any user with access to the case can make the transition if empty.
+	AllowedRoles []InboxUserRole `json:"allowed_roles"`
+}
+
+type InboxCaseWorkflowInput struct {
+	Statuses    []CaseWorkflowStatus
+	Transitions []CaseStatusTransition
+}
+
+func (input InboxCaseWorkflowInput) Validate() error {
+	statuses := []CaseStatus{CasePending, CaseInvestigating, CaseClosed}
+	for _, status := range input.Statuses {
+		if !caseStatusNameRegex.MatchString(string(status.Name)) {
+			return errors.Wrapf(BadParameterError,
+				"invalid status name '%s', it must be in lowercase snake case", status.Name)
+		}
+		if slices.Contains(reservedCaseStatusNames, status.Name) {
+			return errors.Wrapf(BadParameterError, "the status name '%s' is reserved", status.Name)
+		}
+		if slices.Contains(statuses, status.Name) {
+			return errors.Wrapf(BadParameterError, "the status '%s' is defined twice", status.Name)
+		}
+		statuses = append(statuses, status.Name)
+	}
+
+	if len(input.Transitions) == 0 {
+		return errors.Wrap(BadParameterError, "a case workflow must have at least one transition")
+	}
+	for i, transition := range input.Transitions {
+		if !slices.Contains(statuses, transition.From) || !slices.Contains(statuses, transition.To) {
+			return errors.Wrapf(BadParameterError, "the transition from '%s' to '%s' uses an unknown status",
+				transition.From, transition.To)
+		}
+		if transition.From == transition.To {
+			return errors.Wrapf(BadParameterError, "the transition from '%s' to itself is not needed", transition.From)
+		}
+		for _, other := range input.Transitions[:i] {
+			if other.From == transition.From && other.To == transition.To {
+				return errors.Wrapf(BadParameterError, "the transition from '%s' to '%s' is defined twice",
+					transition.From, transition.To)
+			}
+		}
+		for _, role := range transition.AllowedRoles {
+			if role != InboxUserRoleAdmin && role != InboxUserRoleMember {
+				return errors.Wrapf(BadParameterError, "invalid inbox role '%s'", role)
+			}
+		}
+	}
+
+	// cases must not get stuck in a custom status
+	for _, status := range input.Statuses {
+		if !slices.ContainsFunc(input.Transitions, func(t CaseStatusTransition) bool { return t.To == status.Name }) {
+			return errors.Wrapf(BadParameterError, "the status '%s' cannot be reached", status.Name)
+		}
+		if !slices.ContainsFunc(input.Transitions, func(t CaseStatusTransition) bool { return t.From == status.Name }) {
+			return errors.Wrapf(BadParameterError, "the status '%s' cannot be left", status.Name)
+		}
+	}
+	return nil
+}
+
+// HasStatus tells whether cases of the inbox can have the status
+func (w InboxCaseWorkflow) HasStatus(status CaseStatus) bool {
+	if status == CasePending || status == CaseInvestigating || status == CaseClosed {
+		return true
+	}
+	return slices.ContainsFunc(w.Statuses, func(s CaseWorkflowStatus) bool { return s.Name == status })
+}
+
+// Transition returns the transition between two statuses, if the workflow allows it
+func (w InboxCaseWorkflow) Transition(from, to CaseStatus) (CaseStatusTransition, bool) {
+	for _, t := range w.Transitions {
+		if t.From == from && t.To == to {
+			return t, true
+		}
+	}
+	return CaseStatusTransition{}, false
+}
@@ -0,0 +1,75 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func secondReviewWorkflowInput() InboxCaseWorkflowInput {
+	return InboxCaseWorkflowInput{
+		Statuses: []CaseWorkflowStatus{{Name: "second_review", Label: "Second review"}},
+		Transitions: []CaseStatusTransition{
+			{From: CasePending, To: CaseInvestigating},
+			{From: CaseInvestigating, To: "second_review", RequireComment: true},
+			{From: "second_review", To: CaseInvestigating},
+			{
+				From:           "second_review",
+				To:             CaseClosed,
+				RequireOutcome: true,
+				AllowedRoles:   []InboxUserRole{InboxUserRoleAdmin},
+			},
+		},
+	}
+}
+
+func TestInboxCaseWorkflowInput_Validate(t *testing.T) {
+	assert.NoError(t, secondReviewWorkflowInput().Validate())
+
+	input := secondReviewWorkflowInput()
+	input.Statuses = append(input.Statuses, CaseWorkflowStatus{Name: "Second Review"})
+	assert.ErrorIs(t, input.Validate(), BadParameterError, "invalid name")
+
+	input = secondReviewWorkflowInput()
+	input.Statuses = append(input.Statuses, CaseWorkflowStatus{Name: "snoozed"})
+	assert.ErrorIs(t, input.Validate(), BadParameterError, "reserved name")
+
+	input = secondReviewWorkflowInput()
+	input.Statuses = append(input.Statuses, input.Statuses[0])
+	assert.ErrorIs(t, input.Validate(), BadParameterError, "duplicate status")
+
+	input = secondReviewWorkflowInput()
+	input.Transitions = append(input.Transitions, CaseStatusTransition{From: CaseClosed, To: "reopened"})
+	assert.ErrorIs(t, input.Validate(), BadParameterError, "unknown status")
+
+	input = secondReviewWorkflowInput()
+	input.Transitions = append(input.Transitions, input.Transitions[0])
+	assert.ErrorIs(t, input.Validate(), BadParameterError, "duplicate transition")
+
+	input = secondReviewWorkflowInput()
+	input.Transitions[3].AllowedRoles = []InboxUserRole{"owner"}
+	assert.ErrorIs(t, input.Validate(), BadParameterError, "invalid role")
+
+	input = secondReviewWorkflowInput()
+	input.Transitions = input.Transitions[:2]
+	assert.ErrorIs(t, input.Validate(), BadParameterError, "status that cannot be left")
+
+	assert.ErrorIs(t, InboxCaseWorkflowInput{}.Validate(), BadParameterError, "no transition")
+}
+
+func TestInboxCaseWorkflow_Transition(t *testing.T) {
+	input := secondReviewWorkflowInput()
+	workflow := InboxCaseWorkflow{Statuses: input.Statuses, Transitions: input.Transitions}
+
+	assert.True(t, workflow.HasStatus("second_review"))
+	assert.True(t, workflow.HasStatus(CaseClosed))
+	assert.False(t, workflow.HasStatus("awaiting_customer_info"))
+
+	transition, ok := workflow.Transition("second_review", CaseClosed)
+	assert.True(t, ok)
+	assert.True(t, transition.RequireOutcome)
+	assert.Equal(t, []InboxUserRole{InboxUserRoleAdmin}, transition.AllowedRoles)
+
+	_, ok = workflow.Transition(CaseInvestigating, CaseClosed)
+	assert.False(t, ok)
+}
@@ -0,0 +1,131 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+type ChangeEventEntityType string
+
+const (
+	ChangeEventDecision      ChangeEventEntityType = "decision"
+	ChangeEventCase          ChangeEventEntityType = "case"
+	ChangeEventCaseEvent     ChangeEventEntityType = "case_event"
+	ChangeEventSanctionCheck ChangeEventEntityType = "sanction_check"
+	ChangeEventRuleSnooze    ChangeEventEntityType = "rule_snooze"
+)
+
+type ChangeEventOperation string
+
+const (
+	ChangeEventInsert ChangeEventOperation = "insert"
+	ChangeEventUpdate ChangeEventOperation = "update"
+)
+
+// ChangeEvent is a row inserted or updated in one of the captured tables of an organization. Change events are written
+// by database triggers in the same transaction as the change itself, so that they are committed (or rolled back)
+// atomically with it.
+type ChangeEvent struct {
+	Cursor     ChangeFeedCursor
+	OrgId      string
+	EntityType ChangeEventEntityType
+	EntityId   string
+	Operation  ChangeEventOperation
+	Data       json.RawMessage
+	CreatedAt  time.Time
+}
+
+// ChangeFeedCursor is the position of a change event in the feed of an organization. Events are ordered by the id of
+// the transaction that wrote them, then by their own sequence id, and only events from transactions that can no longer
+// be in progress are exposed, so that a consumer never skips an event that commits after it has read the feed.
+type ChangeFeedCursor struct {
+	TxId int64
+	Id   int64
+}
+
+func (c ChangeFeedCursor) String() string {
+	if c.TxId == 0 && c.Id == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d_%d", c.TxId, c.Id)
+}
+
+func ParseChangeFeedCursor(s string) (ChangeFeedCursor, error) {
+	if s == "" {
+		return ChangeFeedCursor{}, nil
+	}
+	txId, id, ok := strings.Cut(s, "_")
+	if !ok {
+		return ChangeFeedCursor{}, errors.Wrapf(BadParameterError, "invalid change feed cursor %q", s)
+	}
+	cursor := ChangeFeedCursor{}
+	var err error
+	if cursor.TxId, err = strconv.ParseInt(txId, 10, 64); err != nil {
+		return ChangeFeedCursor{}, errors.Wrapf(BadParameterError, "invalid change feed cursor %q", s)
+	}
+	if cursor.Id, err = strconv.ParseInt(id, 10, 64); err != nil {
+		return ChangeFeedCursor{}, errors.Wrapf(BadParameterError, "invalid change feed cursor %q", s)
+	}
+	return cursor, nil
+}
+
+type ChangeFeedPage struct {
+	Events     []ChangeEvent
+	NextCursor ChangeFeedCursor
+	HasMore    bool
+}
+
+const (
+	ChangeFeedDefaultLimit = 100
+	ChangeFeedMaxLimit     = 1000
+	ChangeFeedMaxWait      = 30 * time.Second
+)
+
+type ChangeFeedSinkType string
+
+const (
+	ChangeFeedSinkKafka ChangeFeedSinkType = "kafka"
+	ChangeFeedSinkNats  ChangeFeedSinkType = "nats"
+)
+
+// ChangeFeedConfig enables the capture of changes for an organization, and optionally their push to a message broker.
+// For kafka, the sink address is a comma separated list of brokers and the topic is the kafka topic. For nats, the
+// sink address is the server url and the topic is the subject prefix, suffixed with the entity type of each event.
+type ChangeFeedConfig struct {
+	OrgId       string
+	Enabled     bool
+	SinkType    *ChangeFeedSinkType
+	SinkAddress string
+	SinkTopic   string
+	// SinkCursor is the position of the last event pushed to the sink
+	SinkCursor ChangeFeedCursor
+	UpdatedAt  time.Time
+}
+
+type UpdateChangeFeedConfigInput struct {
+	OrgId       string
+	Enabled     bool
+	SinkType    *ChangeFeedSinkType
+	SinkAddress string
+	SinkTopic   string
+}
+
+func (input UpdateChangeFeedConfigInput) Validate() error {
+	if input.SinkType == nil {
+		return nil
+	}
+	switch *input.SinkType {
+	case ChangeFeedSinkKafka, ChangeFeedSinkNats:
+	default:
+		return errors.Wrapf(BadParameterError, "sink type must be one of kafka, nats")
+	}
+	if input.SinkAddress == "" || input.SinkTopic == "" {
+		return errors.Wrap(BadParameterError, "sink address and topic are required when a sink is configured")
+	}
+	return nil
+}
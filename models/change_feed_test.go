@@ -0,0 +1,39 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChangeFeedCursor(t *testing.T) {
+	cursor, err := ParseChangeFeedCursor("")
+	assert.NoError(t, err)
+	assert.Equal(t, ChangeFeedCursor{}, cursor)
+	assert.Equal(t, "", cursor.String())
+
+	cursor, err = ParseChangeFeedCursor("1234_56")
+	assert.NoError(t, err)
+	assert.Equal(t, ChangeFeedCursor{TxId: 1234, Id: 56}, cursor)
+	assert.Equal(t, "1234_56", cursor.String())
+
+	for _, invalid := range []string{"1234", "a_1", "1_b", "_"} {
+		_, err = ParseChangeFeedCursor(invalid)
+		assert.ErrorIs(t, err, BadParameterError, invalid)
+	}
+}
+
+func TestUpdateChangeFeedConfigInput_Validate(t *testing.T) {
+	assert.NoError(t, UpdateChangeFeedConfigInput{Enabled: true}.Validate())
+
+	kafka := ChangeFeedSinkKafka
+	assert.NoError(t, UpdateChangeFeedConfigInput{
+		SinkType: &kafka, SinkAddress: "broker:9092", SinkTopic: "marble",
+	}.Validate())
+	assert.ErrorIs(t, UpdateChangeFeedConfigInput{SinkType: &kafka}.Validate(), BadParameterError)
+
+	unknown := ChangeFeedSinkType("rabbitmq")
+	assert.ErrorIs(t, UpdateChangeFeedConfigInput{
+		SinkType: &unknown, SinkAddress: "a", SinkTopic: "b",
+	}.Validate(), BadParameterError)
+}
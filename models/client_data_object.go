@@ -1,6 +1,8 @@
 package models
 
 import (
+	"reflect"
+	"slices"
 	"time"
 )
 
@@ -93,3 +95,58 @@ type ExplorationOptions struct {
 	FilterFieldValue  StringOrNumber
 	OrderingFieldName string
 }
+
+// ClientObjectVersion is one of the successive versions of an ingested object, as kept in the client db table with
+// its validity interval. ValidUntil is nil for the version that is currently valid.
+type ClientObjectVersion struct {
+	ObjectType string
+	ValidFrom  time.Time
+	ValidUntil *time.Time
+	Data       map[string]any
+	Changes    []ClientObjectFieldChange
+}
+
+type ClientObjectFieldChange struct {
+	FieldName     string
+	PreviousValue any
+	NewValue      any
+}
+
+// DiffClientObjectData returns the list of fields whose value differs between two versions of an object, sorted by field name.
+// A nil "previous" map means that the object did not exist before, so that all non null fields of the new version are returned.
+func DiffClientObjectData(previous, current map[string]any) []ClientObjectFieldChange {
+	fieldNames := make([]string, 0, len(current))
+	for fieldName := range current {
+		fieldNames = append(fieldNames, fieldName)
+	}
+	for fieldName := range previous {
+		if _, ok := current[fieldName]; !ok {
+			fieldNames = append(fieldNames, fieldName)
+		}
+	}
+	slices.Sort(fieldNames)
+
+	changes := make([]ClientObjectFieldChange, 0)
+	for _, fieldName := range fieldNames {
+		previousValue := previous[fieldName]
+		newValue := current[fieldName]
+		if fieldValuesEqual(previousValue, newValue) {
+			continue
+		}
+		changes = append(changes, ClientObjectFieldChange{
+			FieldName:     fieldName,
+			PreviousValue: previousValue,
+			NewValue:      newValue,
+		})
+	}
+	return changes
+}
+
+func fieldValuesEqual(a, b any) bool {
+	aTime, aIsTime := a.(time.Time)
+	bTime, bIsTime := b.(time.Time)
+	if aIsTime && bIsTime {
+		return aTime.Equal(bTime)
+	}
+	return reflect.DeepEqual(a, b)
+}
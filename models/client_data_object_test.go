@@ -0,0 +1,34 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffClientObjectData(t *testing.T) {
+	t.Run("first version returns all non null fields", func(t *testing.T) {
+		changes := DiffClientObjectData(nil, map[string]any{
+			"object_id": "a",
+			"amount":    10.0,
+			"comment":   nil,
+		})
+		assert.Equal(t, []ClientObjectFieldChange{
+			{FieldName: "amount", NewValue: 10.0},
+			{FieldName: "object_id", NewValue: "a"},
+		}, changes)
+	})
+
+	t.Run("only changed fields are returned", func(t *testing.T) {
+		updatedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		changes := DiffClientObjectData(
+			map[string]any{"object_id": "a", "amount": 10.0, "updated_at": updatedAt, "status": "pending"},
+			map[string]any{"object_id": "a", "amount": 12.5, "updated_at": updatedAt.In(time.Local), "status": nil},
+		)
+		assert.Equal(t, []ClientObjectFieldChange{
+			{FieldName: "amount", PreviousValue: 10.0, NewValue: 12.5},
+			{FieldName: "status", PreviousValue: "pending", NewValue: nil},
+		}, changes)
+	})
+}
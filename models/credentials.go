@@ -5,6 +5,7 @@ type Identity struct {
 	Email      string
 	FirstName  string
 	LastName   string
+	ApiKeyId   string
 	ApiKeyName string
 }
 
@@ -29,9 +30,10 @@ func NewCredentialWithUser(user User) Credentials {
 	}
 }
 
-func NewCredentialWithApiKey(organizationId string, partnerId *string, role Role, apiKeyName string) Credentials {
+func NewCredentialWithApiKey(organizationId string, partnerId *string, role Role, apiKeyId, apiKeyName string) Credentials {
 	return Credentials{
 		ActorIdentity: Identity{
+			ApiKeyId:   apiKeyId,
 			ApiKeyName: apiKeyName,
 		},
 		OrganizationId: organizationId,
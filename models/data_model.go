@@ -101,12 +101,41 @@ func (dm DataModel) AllFieldsAsMap() map[string]Field {
 // ///////////////////////////////
 
 type Table struct {
-	ID                string
-	Name              string
-	Description       string
-	Fields            map[string]Field
-	LinksToSingle     map[string]LinkToSingle
-	NavigationOptions []NavigationOption
+	ID                  string
+	Name                string
+	Description         string
+	DecisionTriggerMode DecisionTriggerMode
+	Fields              map[string]Field
+	LinksToSingle       map[string]LinkToSingle
+	NavigationOptions   []NavigationOption
+}
+
+// DecisionTriggerMode controls whether ingesting an object of a table automatically creates decisions
+// on the live scenarios that have this table as trigger object type.
+type DecisionTriggerMode string
+
+const (
+	DecisionTriggerModeNone  DecisionTriggerMode = "none"
+	DecisionTriggerModeSync  DecisionTriggerMode = "sync"
+	DecisionTriggerModeAsync DecisionTriggerMode = "async"
+)
+
+func DecisionTriggerModeFrom(s string) DecisionTriggerMode {
+	switch s {
+	case "sync":
+		return DecisionTriggerModeSync
+	case "async":
+		return DecisionTriggerModeAsync
+	}
+	return DecisionTriggerModeNone
+}
+
+func (m DecisionTriggerMode) IsValid() bool {
+	switch m {
+	case DecisionTriggerModeNone, DecisionTriggerModeSync, DecisionTriggerModeAsync:
+		return true
+	}
+	return false
 }
 
 func (t Table) Copy() Table {
@@ -134,10 +163,11 @@ func (t Table) GetFieldById(fieldId string) (Field, bool) {
 }
 
 type TableMetadata struct {
-	ID             string
-	Description    string
-	Name           string
-	OrganizationID string
+	ID                  string
+	Description         string
+	Name                string
+	OrganizationID      string
+	DecisionTriggerMode DecisionTriggerMode
 }
 
 func ColumnNames(table Table) []string {
@@ -165,6 +195,7 @@ type Field struct {
 	TableId           string
 	Values            []any
 	UnicityConstraint UnicityConstraint
+	Constraints       FieldConstraints
 }
 
 type FieldMetadata struct {
@@ -175,6 +206,7 @@ type FieldMetadata struct {
 	Name        string
 	Nullable    bool
 	TableId     string
+	Constraints FieldConstraints
 }
 
 type UnicityConstraint int
@@ -217,12 +249,14 @@ type CreateFieldInput struct {
 	Nullable    bool
 	IsEnum      bool
 	IsUnique    bool
+	Constraints FieldConstraints
 }
 
 type UpdateFieldInput struct {
 	Description *string
 	IsEnum      *bool
 	IsUnique    *bool
+	Constraints *FieldConstraints
 }
 
 type EnumValues map[string]map[any]struct{}
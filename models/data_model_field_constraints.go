@@ -0,0 +1,164 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/cockroachdb/errors"
+)
+
+// FieldConstraints are declarative validation rules on the values of a data model field, enforced at ingestion time on
+// top of the field's data type and nullability. The zero value has no constraint.
+type FieldConstraints struct {
+	// Min and Max are inclusive bounds for int and float fields
+	Min *float64
+	Max *float64
+	// MaxLength is the maximum number of characters of a string field
+	MaxLength *int
+	// Regex must match the values of a string field. It is not anchored, use ^ and $ to match the full value.
+	Regex *string
+	// AllowedValues restricts the values of an enum field to a fixed set (strict mode). If empty, any value is accepted
+	// and the enum values are collected from the ingested data.
+	AllowedValues []any
+	// NotInFuture rejects timestamps that are later than the time of ingestion
+	NotInFuture bool
+}
+
+func (c FieldConstraints) IsEmpty() bool {
+	return c.Min == nil && c.Max == nil && c.MaxLength == nil && c.Regex == nil &&
+		len(c.AllowedValues) == 0 && !c.NotInFuture
+}
+
+// Validate checks that the constraints are consistent with the field they are set on
+func (c FieldConstraints) Validate(dataType DataType, isEnum bool) error {
+	if (c.Min != nil || c.Max != nil) && dataType != Int && dataType != Float {
+		return errors.Wrap(BadParameterError, "min and max constraints can only be set on int or float fields")
+	}
+	if c.Min != nil && c.Max != nil && *c.Min > *c.Max {
+		return errors.Wrap(BadParameterError, "min constraint must be lower than or equal to max")
+	}
+	if (c.MaxLength != nil || c.Regex != nil) && dataType != String {
+		return errors.Wrap(BadParameterError, "max_length and regex constraints can only be set on string fields")
+	}
+	if c.MaxLength != nil && *c.MaxLength <= 0 {
+		return errors.Wrap(BadParameterError, "max_length constraint must be greater than 0")
+	}
+	if c.Regex != nil {
+		if _, err := regexp.Compile(*c.Regex); err != nil {
+			return errors.Wrapf(BadParameterError, "invalid regex constraint: %s", err.Error())
+		}
+	}
+	if len(c.AllowedValues) > 0 {
+		if !isEnum {
+			return errors.Wrap(BadParameterError, "allowed_values constraint can only be set on enum fields")
+		}
+		for _, value := range c.AllowedValues {
+			_, isString := value.(string)
+			number, isNumber := constraintNumber(value)
+			if (dataType == String && !isString) || (dataType == Float && !isNumber) ||
+				(dataType == Int && (!isNumber || number != math.Trunc(number))) {
+				return errors.Wrapf(BadParameterError,
+					"allowed value %v does not match the field type %s", value, dataType.String())
+			}
+		}
+	}
+	if c.NotInFuture && dataType != Timestamp {
+		return errors.Wrap(BadParameterError, "not_in_future constraint can only be set on timestamp fields")
+	}
+	return nil
+}
+
+var constraintRegexps sync.Map
+
+func constraintRegexp(pattern string) (*regexp.Regexp, error) {
+	if re, ok := constraintRegexps.Load(pattern); ok {
+		return re.(*regexp.Regexp), nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	constraintRegexps.Store(pattern, re)
+	return re, nil
+}
+
+// Check returns an error describing the first constraint that a parsed value does not respect. Null values and empty
+// strings are not checked, nullability is enforced separately.
+func (c FieldConstraints) Check(value any, now time.Time) error {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case int, int64, float64:
+		number, _ := constraintNumber(v)
+		if err := c.checkNumber(number); err != nil {
+			return err
+		}
+	case string:
+		if v == "" {
+			return nil
+		}
+		if c.MaxLength != nil && utf8.RuneCountInString(v) > *c.MaxLength {
+			return fmt.Errorf("is longer than the maximum length of %d characters", *c.MaxLength)
+		}
+		if c.Regex != nil {
+			re, err := constraintRegexp(*c.Regex)
+			if err != nil {
+				return err
+			}
+			if !re.MatchString(v) {
+				return fmt.Errorf("does not match the pattern %s", *c.Regex)
+			}
+		}
+	case time.Time:
+		if c.NotInFuture && v.After(now) {
+			return errors.New("is in the future")
+		}
+		return nil
+	default:
+		return nil
+	}
+
+	if len(c.AllowedValues) > 0 && !slices.ContainsFunc(c.AllowedValues, func(allowed any) bool {
+		return constraintValuesEqual(allowed, value)
+	}) {
+		return fmt.Errorf("is not one of the allowed values %v", c.AllowedValues)
+	}
+	return nil
+}
+
+// constraintNumber normalises the numeric types of parsed values and of allowed values read from json
+func constraintNumber(value any) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case float64:
+		return v, true
+	default:
+		return 0, false
+	}
+}
+
+func constraintValuesEqual(allowed, value any) bool {
+	if a, ok := constraintNumber(allowed); ok {
+		v, ok := constraintNumber(value)
+		return ok && a == v
+	}
+	return allowed == value
+}
+
+func (c FieldConstraints) checkNumber(v float64) error {
+	if c.Min != nil && v < *c.Min {
+		return fmt.Errorf("is lower than the minimum %v", *c.Min)
+	}
+	if c.Max != nil && v > *c.Max {
+		return fmt.Errorf("is greater than the maximum %v", *c.Max)
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ptr[T any](v T) *T { return &v }
+
+func TestFieldConstraintsValidate(t *testing.T) {
+	assert.NoError(t, FieldConstraints{Min: ptr(0.0), Max: ptr(10.0)}.Validate(Int, false))
+	assert.NoError(t, FieldConstraints{AllowedValues: []any{"EUR"}}.Validate(String, true))
+	assert.NoError(t, FieldConstraints{NotInFuture: true}.Validate(Timestamp, false))
+	assert.NoError(t, FieldConstraints{AllowedValues: []any{1.0, int64(2)}}.Validate(Int, true))
+
+	assert.ErrorIs(t, FieldConstraints{Min: ptr(0.0)}.Validate(String, false), BadParameterError)
+	assert.ErrorIs(t, FieldConstraints{Min: ptr(2.0), Max: ptr(1.0)}.Validate(Float, false), BadParameterError)
+	assert.ErrorIs(t, FieldConstraints{Regex: ptr("(")}.Validate(String, false), BadParameterError)
+	assert.ErrorIs(t, FieldConstraints{MaxLength: ptr(0)}.Validate(String, false), BadParameterError)
+	assert.ErrorIs(t, FieldConstraints{AllowedValues: []any{"EUR"}}.Validate(String, false), BadParameterError)
+	assert.ErrorIs(t, FieldConstraints{AllowedValues: []any{1.0}}.Validate(String, true), BadParameterError)
+	assert.ErrorIs(t, FieldConstraints{AllowedValues: []any{1.5}}.Validate(Int, true), BadParameterError)
+	assert.ErrorIs(t, FieldConstraints{AllowedValues: []any{"1"}}.Validate(Int, true), BadParameterError)
+	assert.ErrorIs(t, FieldConstraints{NotInFuture: true}.Validate(String, false), BadParameterError)
+}
+
+func TestFieldConstraintsCheck(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := FieldConstraints{Min: ptr(1.0), Max: ptr(5.0)}
+	assert.NoError(t, c.Check(int64(5), now))
+	assert.NoError(t, c.Check(nil, now))
+	assert.EqualError(t, c.Check(int64(6), now), "is greater than the maximum 5")
+
+	c = FieldConstraints{MaxLength: ptr(3)}
+	assert.NoError(t, c.Check("abc", now))
+	assert.NoError(t, c.Check("", now))
+	assert.EqualError(t, c.Check("abcd", now), "is longer than the maximum length of 3 characters")
+
+	c = FieldConstraints{NotInFuture: true}
+	assert.NoError(t, c.Check(now, now))
+	assert.EqualError(t, c.Check(now.Add(time.Second), now), "is in the future")
+
+	c = FieldConstraints{AllowedValues: []any{1.0, 2.0}}
+	assert.NoError(t, c.Check(2.0, now))
+	assert.EqualError(t, c.Check(3.0, now), "is not one of the allowed values [1 2]")
+
+	// allowed values of int fields are read from json as floats, ingested values are parsed as int64
+	c = FieldConstraints{AllowedValues: []any{1.0, 2.0}, Max: ptr(10.0)}
+	assert.NoError(t, c.Check(int64(1), now))
+	assert.EqualError(t, c.Check(int64(3), now), "is not one of the allowed values [1 2]")
+	assert.EqualError(t, c.Check(int64(11), now), "is greater than the maximum 10")
+}
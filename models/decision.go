@@ -51,6 +51,9 @@ type DecisionWithRuleExecutions struct {
 	Decision
 	RuleExecutions         []RuleExecution
 	SanctionCheckExecution *SanctionCheckWithMatches
+	Labels                 []DecisionLabel
+	// Reevaluations are only loaded with the decisions of a case
+	Reevaluations []DecisionReevaluation
 }
 
 type DecisionsByVersionByOutcome struct {
@@ -164,6 +167,15 @@ type CreateDecisionInput struct {
 	ClientObject       *ClientObject
 	ScenarioId         string
 	TriggerObjectTable string
+	// ReadAsOf, if set, evaluates the rules against the ingested data as it was at that time
+	ReadAsOf *time.Time
+	// IdempotencyKey, if set, makes retries of the same request return the original decision
+	IdempotencyKey string
+	// DecisionId, if set, is used as the id of the created decision instead of a new one
+	DecisionId string
+	// DecisionRequestId, if set, is the asynchronous decision request that the decision answers. The decision is only
+	// stored while the request is pending.
+	DecisionRequestId string
 }
 
 type CreateDecisionParams struct {
@@ -175,7 +187,24 @@ type CreateDecisionParams struct {
 type CreateAllDecisionsInput struct {
 	OrganizationId     string
 	PayloadRaw         json.RawMessage
+	ClientObject       *ClientObject
 	TriggerObjectTable string
+	ReadAsOf           *time.Time
+	IdempotencyKey     string
+}
+
+// IngestionDecisions holds the decisions created synchronously after the ingestion of objects, on tables
+// configured with DecisionTriggerModeSync
+type IngestionDecisions struct {
+	Decisions []DecisionWithRuleExecutions
+	NbSkipped int
+	// Errors lists the objects on which decisions could not be created. The objects themselves are ingested.
+	Errors []IngestionDecisionError
+}
+
+type IngestionDecisionError struct {
+	ObjectId string
+	Err      error
 }
 
 type DecisionFilters struct {
@@ -191,6 +220,10 @@ type DecisionFilters struct {
 	StartDate             time.Time
 	TriggerObjects        []string
 	TriggerObjectId       *string
+	HasLabel              *bool
+	Labels                []string
+	// ExcludeReevaluations filters out the decisions made by re-evaluating another decision
+	ExcludeReevaluations bool
 }
 
 type DecisionListPageWithIndexes struct {
@@ -0,0 +1,96 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+type DecisionExportFormat int
+
+const (
+	DecisionExportFormatCsv DecisionExportFormat = iota
+	DecisionExportFormatParquet
+	DecisionExportFormatUnknown
+)
+
+func (f DecisionExportFormat) String() string {
+	switch f {
+	case DecisionExportFormatCsv:
+		return "csv"
+	case DecisionExportFormatParquet:
+		return "parquet"
+	}
+	return "unknown"
+}
+
+func DecisionExportFormatFrom(s string) DecisionExportFormat {
+	switch s {
+	case "csv":
+		return DecisionExportFormatCsv
+	case "parquet":
+		return DecisionExportFormatParquet
+	}
+	return DecisionExportFormatUnknown
+}
+
+type DecisionExportStatus string
+
+const (
+	DecisionExportPending DecisionExportStatus = "pending"
+	DecisionExportRunning DecisionExportStatus = "running"
+	DecisionExportSuccess DecisionExportStatus = "success"
+	DecisionExportFailed  DecisionExportStatus = "failed"
+)
+
+// DecisionExport is an asynchronous export of the decisions matching a set of filters to files in blob storage. The
+// decisions are written in chronological order, split in several files of at most DecisionExportFileSize decisions.
+type DecisionExport struct {
+	Id                string
+	OrganizationId    string
+	Format            DecisionExportFormat
+	Filters           DecisionFilters
+	Status            DecisionExportStatus
+	NbFiles           int
+	NbDecisions       int
+	RequestedByUserId *UserId
+	RequestedByApiKey string
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+	// CursorDecisionId is the last exported decision, from which the next file starts
+	CursorDecisionId *string
+}
+
+const DecisionExportFileSize = 20_000
+
+func (e DecisionExport) FileKey(fileNumber int) string {
+	return fmt.Sprintf("decision_exports/%s/%s/part-%05d.%s", e.OrganizationId, e.Id, fileNumber, e.Format.String())
+}
+
+func (e DecisionExport) FileKeys() []string {
+	keys := make([]string, e.NbFiles)
+	for i := range keys {
+		keys[i] = e.FileKey(i + 1)
+	}
+	return keys
+}
+
+type DecisionExportWithFiles struct {
+	DecisionExport
+	// FileUrls are signed urls to download the files of the export, set once it has succeeded
+	FileUrls []string
+}
+
+type DecisionExportCreate struct {
+	OrganizationId    string
+	Format            DecisionExportFormat
+	Filters           DecisionFilters
+	RequestedByUserId *UserId
+	RequestedByApiKey string
+}
+
+type DecisionExportUpdate struct {
+	Status           DecisionExportStatus
+	NbFiles          int
+	NbDecisions      int
+	CursorDecisionId *string
+}
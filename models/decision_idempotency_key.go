@@ -0,0 +1,58 @@
+package models
+
+import (
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	IdempotencyKeyMaxLength   = 255
+	DefaultIdempotencyKeyTtl  = 24 * time.Hour
+	idempotencyKeyActorApiKey = "api_key:"
+	idempotencyKeyActorUser   = "user:"
+)
+
+// DecisionIdempotencyKey records the outcome of a decision creation request sent with an idempotency key, so that
+// retries of the same request within the idempotency window return the original decisions instead of creating new ones.
+type DecisionIdempotencyKey struct {
+	Id             string
+	OrganizationId string
+	// Actor is the API key or user that sent the request, keys are scoped per organization and actor
+	Actor string
+	Key   string
+	// RequestHash identifies the payload of the original request, a request reusing the key with another payload is
+	// rejected
+	RequestHash   []byte
+	TriggerPassed bool
+	DecisionIds   []string
+	NbSkipped     int
+	CreatedAt     time.Time
+	ExpiresAt     time.Time
+}
+
+type DecisionIdempotencyKeyCreate struct {
+	OrganizationId string
+	Actor          string
+	Key            string
+	RequestHash    []byte
+	TriggerPassed  bool
+	DecisionIds    []string
+	NbSkipped      int
+	ExpiresAt      time.Time
+}
+
+// IdempotencyKeyActor returns the scope of the idempotency keys sent with the given credentials, within their
+// organization. Tokens issued before API key ids were added to them do not identify the key, and could collide with the
+// keys of other API keys of the organization: they cannot use idempotency keys until they are re-issued.
+func IdempotencyKeyActor(creds Credentials) (string, error) {
+	switch {
+	case creds.ActorIdentity.ApiKeyId != "":
+		return idempotencyKeyActorApiKey + creds.ActorIdentity.ApiKeyId, nil
+	case creds.ActorIdentity.UserId != "":
+		return idempotencyKeyActorUser + string(creds.ActorIdentity.UserId), nil
+	default:
+		return "", errors.Wrap(UnAuthorizedError,
+			"idempotency keys require a token that identifies its API key, please request a new token")
+	}
+}
@@ -0,0 +1,89 @@
+package models
+
+import (
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+const decisionLabelMaxLength = 100
+
+// DecisionLabel is an external feedback on the outcome of a decision, typically received from a payment processor days
+// after the decision was made (confirmed fraud, chargeback...). A label is either attached to a decision, or to a
+// trigger object, in which case it applies to all the decisions made on that object.
+type DecisionLabel struct {
+	Id             string
+	OrganizationId string
+	DecisionId     *string
+	ObjectType     *string
+	ObjectId       *string
+	// Label is the kind of feedback, for instance a fraud type
+	Label    string
+	Amount   *float64
+	Currency *string
+	// Source is the system the label comes from, for instance the name of the payment processor
+	Source     string
+	OccurredAt time.Time
+	CreatedAt  time.Time
+}
+
+type CreateDecisionLabelInput struct {
+	OrganizationId string
+	DecisionId     *string
+	ObjectType     *string
+	ObjectId       *string
+	Label          string
+	Amount         *float64
+	Currency       *string
+	Source         string
+	OccurredAt     time.Time
+}
+
+func (input CreateDecisionLabelInput) Validate() error {
+	hasDecision := input.DecisionId != nil && *input.DecisionId != ""
+	hasObject := input.ObjectType != nil && *input.ObjectType != "" && input.ObjectId != nil && *input.ObjectId != ""
+	if hasDecision == hasObject {
+		return errors.Wrap(BadParameterError, "a label must be attached either to a decision_id, or to an object_type and object_id")
+	}
+	if input.Label == "" || len(input.Label) > decisionLabelMaxLength {
+		return errors.Wrapf(BadParameterError, "label must be between 1 and %d characters long", decisionLabelMaxLength)
+	}
+	if input.Source == "" || len(input.Source) > decisionLabelMaxLength {
+		return errors.Wrapf(BadParameterError, "source must be between 1 and %d characters long", decisionLabelMaxLength)
+	}
+	if input.Amount != nil && *input.Amount < 0 {
+		return errors.Wrap(BadParameterError, "amount must be positive")
+	}
+	if input.Currency != nil && len(*input.Currency) != 3 {
+		return errors.Wrap(BadParameterError, "currency must be a 3 letter ISO 4217 code")
+	}
+	if input.Amount == nil && input.Currency != nil {
+		return errors.Wrap(BadParameterError, "currency can only be set with an amount")
+	}
+	return nil
+}
+
+// DecisionLabelExportFilters select the decisions exported with their labels, for model training or rule precision
+// analysis
+type DecisionLabelExportFilters struct {
+	OrganizationId    string
+	ScenarioId        string
+	StartDate         time.Time
+	EndDate           time.Time
+	IncludeUnlabelled bool
+}
+
+const DecisionLabelExportMaxRange = 366 * 24 * time.Hour
+
+func (f DecisionLabelExportFilters) Validate() error {
+	if f.ScenarioId == "" {
+		return errors.Wrap(BadParameterError, "scenario_id is required")
+	}
+	if f.StartDate.IsZero() || f.EndDate.IsZero() || !f.StartDate.Before(f.EndDate) {
+		return errors.Wrap(BadParameterError, "start_date and end_date are required, and start_date must be before end_date")
+	}
+	if f.EndDate.Sub(f.StartDate) > DecisionLabelExportMaxRange {
+		return errors.Wrap(BadParameterError, "the exported period must not be longer than a year")
+	}
+	return nil
+}
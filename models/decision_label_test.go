@@ -0,0 +1,44 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateDecisionLabelInputValidate(t *testing.T) {
+	valid := CreateDecisionLabelInput{
+		DecisionId: ptr("decision_id"),
+		Label:      "chargeback",
+		Amount:     ptr(12.5),
+		Currency:   ptr("EUR"),
+		Source:     "processor",
+	}
+	assert.NoError(t, valid.Validate())
+
+	onObject := valid
+	onObject.DecisionId = nil
+	onObject.ObjectType = ptr("transactions")
+	onObject.ObjectId = ptr("tx_1")
+	assert.NoError(t, onObject.Validate())
+
+	both := onObject
+	both.DecisionId = ptr("decision_id")
+	assert.ErrorIs(t, both.Validate(), BadParameterError)
+
+	neither := valid
+	neither.DecisionId = nil
+	assert.ErrorIs(t, neither.Validate(), BadParameterError)
+
+	noLabel := valid
+	noLabel.Label = ""
+	assert.ErrorIs(t, noLabel.Validate(), BadParameterError)
+
+	badCurrency := valid
+	badCurrency.Currency = ptr("EURO")
+	assert.ErrorIs(t, badCurrency.Validate(), BadParameterError)
+
+	negativeAmount := valid
+	negativeAmount.Amount = ptr(-1.0)
+	assert.ErrorIs(t, negativeAmount.Validate(), BadParameterError)
+}
@@ -0,0 +1,79 @@
+package models
+
+import "time"
+
+// DecisionReevaluation links a decision to the decision made by running a scenario iteration again on the same trigger
+// object. The original decision is left unchanged. If the trigger condition of the iteration does not match the trigger
+// object anymore, no decision is made and ReevaluationDecisionId is nil.
+type DecisionReevaluation struct {
+	Id                     string
+	OrganizationId         string
+	DecisionId             string
+	ReevaluationDecisionId *string
+	ScenarioIterationId    string
+	Outcome                *Outcome
+	Score                  *int
+	// BatchId is set if the re-evaluation was made as part of a bulk re-evaluation
+	BatchId   *string
+	CreatedAt time.Time
+}
+
+type DecisionReevaluationCreate struct {
+	OrganizationId         string
+	DecisionId             string
+	ReevaluationDecisionId *string
+	ScenarioIterationId    string
+	Outcome                *Outcome
+	Score                  *int
+	BatchId                *string
+}
+
+type ReevaluateDecisionInput struct {
+	DecisionId string
+	// ScenarioIterationId is the iteration to run, the live iteration of the scenario of the decision if nil
+	ScenarioIterationId *string
+	BatchId             *string
+}
+
+type DecisionReevaluationBatchStatus string
+
+const (
+	DecisionReevaluationBatchPending DecisionReevaluationBatchStatus = "pending"
+	DecisionReevaluationBatchRunning DecisionReevaluationBatchStatus = "running"
+	DecisionReevaluationBatchSuccess DecisionReevaluationBatchStatus = "success"
+	DecisionReevaluationBatchFailed  DecisionReevaluationBatchStatus = "failed"
+)
+
+// DecisionReevaluationBatch is a bulk re-evaluation of the decisions matching a set of filters, run by a background
+// job. Only the decisions made before the batch was requested are re-evaluated, and decisions that are themselves
+// re-evaluations are skipped.
+type DecisionReevaluationBatch struct {
+	Id                  string
+	OrganizationId      string
+	ScenarioIterationId *string
+	Filters             DecisionFilters
+	Status              DecisionReevaluationBatchStatus
+	NbDecisions         int
+	NbErrors            int
+	RequestedByUserId   *UserId
+	RequestedByApiKey   string
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+	// CursorDecisionId is the last processed decision, from which the next run of the job starts
+	CursorDecisionId *string
+}
+
+type DecisionReevaluationBatchCreate struct {
+	OrganizationId      string
+	ScenarioIterationId *string
+	Filters             DecisionFilters
+	RequestedByUserId   *UserId
+	RequestedByApiKey   string
+}
+
+type DecisionReevaluationBatchUpdate struct {
+	Status           DecisionReevaluationBatchStatus
+	NbDecisions      int
+	NbErrors         int
+	CursorDecisionId *string
+}
@@ -0,0 +1,100 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+type DecisionRequestStatus string
+
+const (
+	DecisionRequestPending          DecisionRequestStatus = "pending"
+	DecisionRequestCompleted        DecisionRequestStatus = "completed"
+	DecisionRequestTriggerNotPassed DecisionRequestStatus = "trigger_not_passed"
+	// DecisionRequestFallback means that no decision could be made before the deadline, the fallback outcome of the
+	// request is recorded instead
+	DecisionRequestFallback DecisionRequestStatus = "fallback"
+)
+
+const (
+	DefaultDecisionRequestDeadline = 5 * time.Minute
+	MinDecisionRequestDeadline     = 10 * time.Second
+	MaxDecisionRequestDeadline     = 24 * time.Hour
+)
+
+// DecisionRequest is a request to evaluate a scenario asynchronously. The id of the request is the id of the decision
+// that is created if the scenario is evaluated before the deadline.
+type DecisionRequest struct {
+	Id                 string
+	OrganizationId     string
+	ScenarioId         string
+	TriggerObjectTable string
+	PayloadRaw         json.RawMessage
+	ReadAsOf           *time.Time
+	Status             DecisionRequestStatus
+	FallbackOutcome    Outcome
+	// Outcome is the outcome of the decision, or the fallback outcome, once the request is no longer pending
+	Outcome           *Outcome
+	Error             string
+	Deadline          time.Time
+	RequestedByUserId *UserId
+	RequestedByApiKey string
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+func (r DecisionRequest) DecisionId() *string {
+	if r.Status != DecisionRequestCompleted {
+		return nil
+	}
+	return &r.Id
+}
+
+type CreateDecisionRequestInput struct {
+	OrganizationId     string
+	ScenarioId         string
+	TriggerObjectTable string
+	PayloadRaw         json.RawMessage
+	ReadAsOf           *time.Time
+	// Deadline is the maximum duration of the evaluation, the default deadline is used if it is zero
+	Deadline        time.Duration
+	FallbackOutcome Outcome
+}
+
+func (input CreateDecisionRequestInput) Validate() error {
+	if input.Deadline != 0 && (input.Deadline < MinDecisionRequestDeadline || input.Deadline > MaxDecisionRequestDeadline) {
+		return errors.Wrapf(BadParameterError, "the deadline must be between %s and %s",
+			MinDecisionRequestDeadline, MaxDecisionRequestDeadline)
+	}
+	if input.FallbackOutcome == UnknownOutcome {
+		return errors.Wrap(BadParameterError, "invalid fallback outcome")
+	}
+	return nil
+}
+
+type DecisionRequestCreate struct {
+	Id                 string
+	OrganizationId     string
+	ScenarioId         string
+	TriggerObjectTable string
+	PayloadRaw         json.RawMessage
+	ReadAsOf           *time.Time
+	FallbackOutcome    Outcome
+	Deadline           time.Time
+	RequestedByUserId  *UserId
+	RequestedByApiKey  string
+}
+
+type DecisionRequestResult struct {
+	Status  DecisionRequestStatus
+	Outcome *Outcome
+	Error   string
+}
+
+type DecisionRequestWithDecision struct {
+	DecisionRequest
+	// Decision is set once the request is completed
+	Decision *DecisionWithRuleExecutions
+}
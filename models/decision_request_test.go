@@ -0,0 +1,30 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateDecisionRequestInput_Validate(t *testing.T) {
+	assert.NoError(t, CreateDecisionRequestInput{FallbackOutcome: Review}.Validate())
+	assert.NoError(t, CreateDecisionRequestInput{FallbackOutcome: Decline, Deadline: time.Minute}.Validate())
+
+	assert.ErrorIs(t, CreateDecisionRequestInput{FallbackOutcome: UnknownOutcome}.Validate(), BadParameterError)
+	assert.ErrorIs(t, CreateDecisionRequestInput{FallbackOutcome: Review, Deadline: time.Second}.Validate(),
+		BadParameterError)
+	assert.ErrorIs(t, CreateDecisionRequestInput{FallbackOutcome: Review, Deadline: 48 * time.Hour}.Validate(),
+		BadParameterError)
+}
+
+func TestDecisionRequest_DecisionId(t *testing.T) {
+	request := DecisionRequest{Id: "request_id", Status: DecisionRequestPending}
+	assert.Nil(t, request.DecisionId())
+
+	request.Status = DecisionRequestFallback
+	assert.Nil(t, request.DecisionId())
+
+	request.Status = DecisionRequestCompleted
+	assert.Equal(t, "request_id", *request.DecisionId())
+}
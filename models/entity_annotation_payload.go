@@ -53,9 +53,10 @@ type EntityAnnotationFilePayload struct {
 }
 
 type EntityAnnotationFilePayloadFile struct {
-	Id       string `json:"id"`
-	Key      string `json:"key"`
-	Filename string `json:"filename"`
+	Id         string         `json:"id"`
+	Key        string         `json:"key"`
+	Filename   string         `json:"filename"`
+	ScanStatus FileScanStatus `json:"scan_status,omitempty"`
 }
 
 func (EntityAnnotationFilePayload) entityAnnotationPayload() {}
@@ -0,0 +1,90 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+type ErasureMode int
+
+const (
+	ErasureModePseudonymise ErasureMode = iota
+	ErasureModeDelete
+	ErasureModeUnknown
+)
+
+func (m ErasureMode) String() string {
+	switch m {
+	case ErasureModePseudonymise:
+		return "pseudonymise"
+	case ErasureModeDelete:
+		return "delete"
+	default:
+		return "unknown"
+	}
+}
+
+func ErasureModeFromString(s string) ErasureMode {
+	switch s {
+	case "pseudonymise":
+		return ErasureModePseudonymise
+	case "delete":
+		return ErasureModeDelete
+	default:
+		return ErasureModeUnknown
+	}
+}
+
+// ErasureRequest identifies the data subject to erase, either by the object id of an ingested object or by a pivot
+// value. In both cases, ObjectType is the table holding the subject's ingested data.
+type ErasureRequest struct {
+	OrganizationId string
+	ObjectType     string
+	ObjectId       string
+	PivotValue     string
+	Mode           ErasureMode
+}
+
+// ErasurePseudonym returns the stable pseudonym that replaces an erased identifier. It is salted with the organization
+// id so that the same identifier in two organizations does not yield the same pseudonym.
+func ErasurePseudonym(organizationId, value string) string {
+	sum := sha256.Sum256([]byte(organizationId + ":" + value))
+	return "erased_" + hex.EncodeToString(sum[:])
+}
+
+// ErasableDecision is a decision that references an erased subject, either as its trigger object or its pivot value
+type ErasableDecision struct {
+	Id                string
+	CaseId            *string
+	CreatedAt         time.Time
+	TriggerObjectType string
+	ObjectId          string
+	OnLegalHold       bool
+}
+
+type ErasureCounts struct {
+	IngestedRows      int
+	LinkedRows        int
+	Decisions         int
+	RuleExecutions    int
+	OffloadedRules    int
+	EntityAnnotations int
+	CaseFiles         int
+	SanctionChecks    int
+	WebhookEvents     int
+}
+
+// ErasureCertificate is the record kept after an erasure request was executed. It does not contain the erased
+// identifier itself, only its pseudonym, which can be recomputed to prove that a given subject was erased.
+type ErasureCertificate struct {
+	Id                string
+	OrganizationId    string
+	ObjectType        string
+	SubjectPseudonym  string
+	Mode              ErasureMode
+	RequestedByUserId *UserId
+	RequestedByApiKey string
+	Counts            ErasureCounts
+	CreatedAt         time.Time
+}
@@ -0,0 +1,14 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErasurePseudonym(t *testing.T) {
+	assert.Equal(t,
+		"erased_15b69718dd351828ebabd62cd1e250593a400949351164a15be8ee57e774e646",
+		ErasurePseudonym("org", "object"))
+	assert.NotEqual(t, ErasurePseudonym("org", "object"), ErasurePseudonym("other_org", "object"))
+}
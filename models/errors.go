@@ -61,6 +61,8 @@ var (
 	ErrTestRunAlreadyExist      = errors.Wrap(ConflictError, "there is an already existing testrun for this scenario")
 	ErrNoTestRunFound           = errors.Wrap(NotFoundError, "there is no testrun for this scenario")
 	ErrWrongIterationForTestRun = errors.Wrap(ConflictError, "the current scenario iteration is a live version and cannot be used")
+
+	ErrDecisionRequestCompleted = errors.Wrap(ConflictError, "the decision request already has a result")
 )
 
 // ingestion and decision creating payload related errors
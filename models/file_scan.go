@@ -0,0 +1,34 @@
+package models
+
+// FileScanStatus is the result of the malware scan of an uploaded file. Files uploaded before scanning was enabled, or
+// while no scanner is configured, are not scanned.
+type FileScanStatus string
+
+const (
+	FileScanNotScanned  FileScanStatus = "not_scanned"
+	FileScanClean       FileScanStatus = "clean"
+	FileScanQuarantined FileScanStatus = "quarantined"
+)
+
+func FileScanStatusFrom(s string) FileScanStatus {
+	switch s {
+	case "clean":
+		return FileScanClean
+	case "quarantined":
+		return FileScanQuarantined
+	default:
+		return FileScanNotScanned
+	}
+}
+
+// IsQuarantined is true when the file was found to be infected. A quarantined file is kept in blob storage for
+// investigation, but it cannot be downloaded.
+func (s FileScanStatus) IsQuarantined() bool {
+	return s == FileScanQuarantined
+}
+
+type FileScanResult struct {
+	Status FileScanStatus
+	// Signature is the name of the malware found in a quarantined file
+	Signature string
+}
@@ -1,6 +1,10 @@
 package models
 
-import "time"
+import (
+	"cmp"
+	"slices"
+	"time"
+)
 
 type InboxStatus string
 
@@ -9,16 +13,38 @@ const (
 	InboxStatusInactive InboxStatus = "archived"
 )
 
+// InboxAssignmentStrategy is how the cases created in, or escalated to, an inbox are assigned to its members
+type InboxAssignmentStrategy string
+
+const (
+	InboxAssignmentManual InboxAssignmentStrategy = "manual"
+	// the case is assigned to the member who was assigned a case the longest time ago
+	InboxAssignmentRoundRobin InboxAssignmentStrategy = "round_robin"
+	// the case is assigned to the member with the fewest open cases
+	InboxAssignmentLeastLoaded InboxAssignmentStrategy = "least_loaded"
+	// the case is assigned to the least loaded member skilled for one of the tags of the case, or to the least loaded
+	// member if none is
+	InboxAssignmentSkillBased InboxAssignmentStrategy = "skill_based"
+)
+
+var ValidInboxAssignmentStrategies = []InboxAssignmentStrategy{
+	InboxAssignmentManual,
+	InboxAssignmentRoundRobin,
+	InboxAssignmentLeastLoaded,
+	InboxAssignmentSkillBased,
+}
+
 type Inbox struct {
-	Id                string
-	Name              string
-	OrganizationId    string
-	Status            InboxStatus
-	EscalationInboxId *string
-	CreatedAt         time.Time
-	UpdatedAt         time.Time
-	InboxUsers        []InboxUser
-	CasesCount        *int
+	Id                 string
+	Name               string
+	OrganizationId     string
+	Status             InboxStatus
+	EscalationInboxId  *string
+	AssignmentStrategy InboxAssignmentStrategy
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+	InboxUsers         []InboxUser
+	CasesCount         *int
 }
 
 type InboxMetadata struct {
@@ -45,4 +71,72 @@ type UpdateInboxInput struct {
 	Id                string
 	Name              string
 	EscalationInboxId *string
+	// AssignmentStrategy is left unchanged if nil
+	AssignmentStrategy *InboxAssignmentStrategy
+}
+
+// CaseAssignmentCandidate is an available member of an inbox, who can be assigned a case automatically
+type CaseAssignmentCandidate struct {
+	InboxUserId    string
+	UserId         string
+	SkillTagIds    []string
+	OpenCasesCount int
+	LastAssignedAt *time.Time
+}
+
+// PickAssignee returns the candidate to assign a case to, according to the strategy. It returns false if the strategy is
+// manual or if there is no candidate. The candidates must be read while the members of the inbox are locked, otherwise
+// concurrent assignments pick the same member.
+func (s InboxAssignmentStrategy) PickAssignee(candidates []CaseAssignmentCandidate,
+	caseTagIds []string,
+) (CaseAssignmentCandidate, bool) {
+	if len(candidates) == 0 {
+		return CaseAssignmentCandidate{}, false
+	}
+
+	switch s {
+	case InboxAssignmentRoundRobin:
+		return slices.MinFunc(candidates, compareLastAssigned), true
+	case InboxAssignmentLeastLoaded:
+		return slices.MinFunc(candidates, compareLoad), true
+	case InboxAssignmentSkillBased:
+		skilled := make([]CaseAssignmentCandidate, 0, len(candidates))
+		for _, c := range candidates {
+			if slices.ContainsFunc(c.SkillTagIds, func(tagId string) bool {
+				return slices.Contains(caseTagIds, tagId)
+			}) {
+				skilled = append(skilled, c)
+			}
+		}
+		if len(skilled) == 0 {
+			skilled = candidates
+		}
+		return slices.MinFunc(skilled, compareLoad), true
+	default:
+		return CaseAssignmentCandidate{}, false
+	}
+}
+
+// compareLastAssigned orders the candidates who were never assigned a case first, then by date of last assignment
+func compareLastAssigned(a, b CaseAssignmentCandidate) int {
+	switch {
+	case a.LastAssignedAt == nil && b.LastAssignedAt == nil:
+		return cmp.Compare(a.UserId, b.UserId)
+	case a.LastAssignedAt == nil:
+		return -1
+	case b.LastAssignedAt == nil:
+		return 1
+	case !a.LastAssignedAt.Equal(*b.LastAssignedAt):
+		return a.LastAssignedAt.Compare(*b.LastAssignedAt)
+	default:
+		return cmp.Compare(a.UserId, b.UserId)
+	}
+}
+
+// compareLoad orders the candidates by number of open cases, and rotates between the candidates with the same load
+func compareLoad(a, b CaseAssignmentCandidate) int {
+	if a.OpenCasesCount != b.OpenCasesCount {
+		return cmp.Compare(a.OpenCasesCount, b.OpenCasesCount)
+	}
+	return compareLastAssigned(a, b)
 }
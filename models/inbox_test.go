@@ -0,0 +1,44 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInboxAssignmentStrategy_PickAssignee(t *testing.T) {
+	earlier := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	later := earlier.Add(time.Hour)
+
+	candidates := []CaseAssignmentCandidate{
+		{InboxUserId: "iu1", UserId: "u1", OpenCasesCount: 1, LastAssignedAt: &earlier},
+		{InboxUserId: "iu2", UserId: "u2", OpenCasesCount: 3, SkillTagIds: []string{"fraud"}, LastAssignedAt: &later},
+		{InboxUserId: "iu3", UserId: "u3", OpenCasesCount: 1, SkillTagIds: []string{"aml"}},
+	}
+
+	_, ok := InboxAssignmentManual.PickAssignee(candidates, nil)
+	assert.False(t, ok)
+
+	_, ok = InboxAssignmentRoundRobin.PickAssignee(nil, nil)
+	assert.False(t, ok)
+
+	// never assigned candidates come first
+	picked, ok := InboxAssignmentRoundRobin.PickAssignee(candidates, nil)
+	assert.True(t, ok)
+	assert.Equal(t, "u3", picked.UserId)
+
+	picked, _ = InboxAssignmentRoundRobin.PickAssignee(candidates[:2], nil)
+	assert.Equal(t, "u1", picked.UserId)
+
+	// ties on load are broken by date of last assignment
+	picked, _ = InboxAssignmentLeastLoaded.PickAssignee(candidates, nil)
+	assert.Equal(t, "u3", picked.UserId)
+
+	picked, _ = InboxAssignmentSkillBased.PickAssignee(candidates, []string{"fraud"})
+	assert.Equal(t, "u2", picked.UserId)
+
+	// without a skilled candidate, falls back to the least loaded
+	picked, _ = InboxAssignmentSkillBased.PickAssignee(candidates[:2], []string{"aml"})
+	assert.Equal(t, "u1", picked.UserId)
+}
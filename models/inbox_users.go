@@ -10,6 +10,17 @@ type InboxUser struct {
 	CreatedAt      time.Time
 	UpdatedAt      time.Time
 	Role           InboxUserRole
+	// An inbox user who is out of office is not picked by the automatic assignment of cases
+	OutOfOffice bool
+	// SkillTagIds are the case tags the user is skilled for, used by the skill based assignment strategy
+	SkillTagIds    []string
+	LastAssignedAt *time.Time
+}
+
+type UpdateInboxUserInput struct {
+	Role        *InboxUserRole
+	OutOfOffice *bool
+	SkillTagIds *[]string
 }
 
 type CreateInboxUserInput struct {
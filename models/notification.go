@@ -0,0 +1,144 @@
+package models
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+type NotificationType string
+
+const (
+	NotificationCaseAssigned  NotificationType = "case_assigned"
+	NotificationCaseEscalated NotificationType = "case_escalated"
+	NotificationMention       NotificationType = "comment_mention"
+	NotificationSlaBreached   NotificationType = "sla_breached"
+	NotificationSnoozeExpired NotificationType = "snooze_expired"
+	NotificationQaReview      NotificationType = "qa_review_assigned"
+)
+
+var ValidNotificationTypes = []NotificationType{
+	NotificationCaseAssigned,
+	NotificationCaseEscalated,
+	NotificationMention,
+	NotificationSlaBreached,
+	NotificationSnoozeExpired,
+	NotificationQaReview,
+}
+
+// NotifiableCaseEventTypes are the case events from which users are notified, and the type of the notifications
+var NotifiableCaseEventTypes = map[CaseEventType]NotificationType{
+	CaseAssigned:          NotificationCaseAssigned,
+	CaseEscalated:         NotificationCaseEscalated,
+	CaseCommentMention:    NotificationMention,
+	CaseSlaBreached:       NotificationSlaBreached,
+	CaseQaReviewRequested: NotificationQaReview,
+}
+
+func NotificationTypeFrom(s string) (NotificationType, error) {
+	t := NotificationType(s)
+	if !slices.Contains(ValidNotificationTypes, t) {
+		return "", errors.Wrap(BadParameterError, fmt.Sprintf("invalid notification type %s", s))
+	}
+	return t, nil
+}
+
+// NotificationMessage is the text of a notification of the given type about a case
+func NotificationMessage(t NotificationType, caseName string) string {
+	switch t {
+	case NotificationCaseAssigned:
+		return fmt.Sprintf("You were assigned the case \"%s\"", caseName)
+	case NotificationCaseEscalated:
+		return fmt.Sprintf("The case \"%s\" was escalated to your inbox", caseName)
+	case NotificationMention:
+		return fmt.Sprintf("You were mentioned in a comment on the case \"%s\"", caseName)
+	case NotificationSlaBreached:
+		return fmt.Sprintf("The case \"%s\" breached its SLA", caseName)
+	case NotificationSnoozeExpired:
+		return fmt.Sprintf("The snooze of the case \"%s\" has expired", caseName)
+	case NotificationQaReview:
+		return fmt.Sprintf("You were asked to review the outcome of the case \"%s\"", caseName)
+	}
+	return fmt.Sprintf("New activity on the case \"%s\"", caseName)
+}
+
+type Notification struct {
+	Id             string
+	OrganizationId string
+	UserId         string
+	Type           NotificationType
+	CaseId         *string
+	CaseEventId    *string
+	// ActorId is the user whose action triggered the notification, if any
+	ActorId   *string
+	Message   string
+	ReadAt    *time.Time
+	CreatedAt time.Time
+}
+
+type NotificationCreate struct {
+	OrganizationId string
+	UserId         string
+	Type           NotificationType
+	CaseId         *string
+	CaseEventId    *string
+	ActorId        *string
+	Message        string
+}
+
+type NotificationFilters struct {
+	UserId     string
+	UnreadOnly bool
+	// Before only returns the notifications created before this time, to fetch the next page
+	Before *time.Time
+	Limit  int
+}
+
+// NotificationPreference is how a user is notified of one type of notification
+type NotificationPreference struct {
+	Type  NotificationType
+	InApp bool
+	Email bool
+}
+
+// DefaultNotificationPreference applies to the types of notifications a user has not configured
+func DefaultNotificationPreference(t NotificationType) NotificationPreference {
+	return NotificationPreference{Type: t, InApp: true, Email: true}
+}
+
+// NotificationPreferences completes the preferences saved by a user with the default preference of the other types
+func NotificationPreferences(saved []NotificationPreference) []NotificationPreference {
+	preferences := make([]NotificationPreference, 0, len(ValidNotificationTypes))
+	for _, t := range ValidNotificationTypes {
+		idx := slices.IndexFunc(saved, func(p NotificationPreference) bool { return p.Type == t })
+		if idx >= 0 {
+			preferences = append(preferences, saved[idx])
+		} else {
+			preferences = append(preferences, DefaultNotificationPreference(t))
+		}
+	}
+	return preferences
+}
+
+// NotificationCursor is the progress of the notification job of an organization. Case events are notified in the order
+// of the id of the transaction that wrote them, then of their id, like the change feed.
+type NotificationCursor struct {
+	EventTxId int64
+	EventId   *string
+	// SnoozesCheckedAt is the time up to which the expired snoozes of cases were notified
+	SnoozesCheckedAt time.Time
+}
+
+// NotifiableCaseEvent is a case event from which users are notified, with the id of the transaction that wrote it
+type NotifiableCaseEvent struct {
+	CaseEvent
+	TxId int64
+}
+
+type Email struct {
+	To      string
+	Subject string
+	Body    string
+}
@@ -10,3 +10,10 @@ type OffloadingWatermark struct {
 	CreatedAt     time.Time
 	UpdatedAt     time.Time
 }
+
+// DecisionRuleRef identifies a rule execution, and the key of its evaluation if it was offloaded to the offloading bucket
+type DecisionRuleRef struct {
+	DecisionId string
+	RuleId     string
+	Outcome    string
+}
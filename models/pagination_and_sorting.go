@@ -25,6 +25,7 @@ const (
 	SortingFieldUnknown SortingField = iota
 	SortingFieldCreatedAt
 	SortingFieldUpdatedAt
+	SortingFieldDueAt
 )
 
 func (sf SortingField) String() string {
@@ -33,6 +34,8 @@ func (sf SortingField) String() string {
 		return "created_at"
 	case SortingFieldUpdatedAt:
 		return "updated_at"
+	case SortingFieldDueAt:
+		return "due_at"
 	default:
 		return "unknown"
 	}
@@ -44,6 +47,8 @@ func SortingFieldFrom(s string) SortingField {
 		return SortingFieldCreatedAt
 	case "updated_at":
 		return SortingFieldUpdatedAt
+	case "due_at":
+		return SortingFieldDueAt
 	}
 	return SortingFieldUnknown
 }
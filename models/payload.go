@@ -3,6 +3,7 @@ package models
 import (
 	"encoding/json"
 	"reflect"
+	"time"
 )
 
 type DbFieldReadParams struct {
@@ -26,6 +27,10 @@ type ClientObject struct {
 	// It is used in the context of partial updates to fetch the missing fields from the database.
 	// It is not related to whether the field is actually required in the data model or not.
 	MissingFieldsToLookup []MissingField
+
+	// ReadAsOf, if set, makes reads of ingested data done while evaluating rules on this object (linked objects, aggregates)
+	// return the versions of the rows that were valid at that time, instead of the current ones.
+	ReadAsOf *time.Time
 }
 
 // expects format {"field_name": "error message", ...}
@@ -60,6 +60,7 @@ const (
 	SANCTION_CHECK_WHITELIST_WRITE
 	SANCTION_CHECK_FREEFORM_SEARCH
 	ANNOTATION_DELETE
+	DATA_ERASURE
 )
 
 func (r Permission) String() (string, error) {
@@ -117,6 +118,7 @@ func (r Permission) String() (string, error) {
 		"SANCTION_CHECK_WHITELIST_WRITE",
 		"SANCTION_CHECK_FREEFORM_SEARCH",
 		"ANNOTATION_DELETE",
+		"DATA_ERASURE",
 	}
 	if int(r) > len(permissions)-1 {
 		return "", errors.New("Invalid permission: no string representation has been set")
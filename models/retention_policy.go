@@ -0,0 +1,92 @@
+package models
+
+import "time"
+
+// RetentionTableDecisions is the table name used in retention policies that apply to decisions rather than to an
+// ingested data table.
+const RetentionTableDecisions = "decisions"
+
+type RetentionPolicyAction int
+
+const (
+	RetentionActionDelete RetentionPolicyAction = iota
+	RetentionActionArchive
+	RetentionActionUnknown
+)
+
+func (a RetentionPolicyAction) String() string {
+	switch a {
+	case RetentionActionDelete:
+		return "delete"
+	case RetentionActionArchive:
+		return "archive"
+	default:
+		return "unknown"
+	}
+}
+
+func RetentionPolicyActionFromString(s string) RetentionPolicyAction {
+	switch s {
+	case "delete":
+		return RetentionActionDelete
+	case "archive":
+		return RetentionActionArchive
+	default:
+		return RetentionActionUnknown
+	}
+}
+
+type RetentionPolicy struct {
+	Id             string
+	OrganizationId string
+	TableName      string
+	Action         RetentionPolicyAction
+	RetentionDays  int
+	// KeepLatestVersion only applies to ingested data tables: if true, the current version of an object is never
+	// removed and only its historical versions are purged.
+	KeepLatestVersion bool
+	Enabled           bool
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+// Cutoff returns the time before which rows fall under the policy
+func (p RetentionPolicy) Cutoff(now time.Time) time.Time {
+	return now.AddDate(0, 0, -p.RetentionDays)
+}
+
+type CreateRetentionPolicyInput struct {
+	OrganizationId    string
+	TableName         string
+	Action            RetentionPolicyAction
+	RetentionDays     int
+	KeepLatestVersion bool
+	Enabled           bool
+}
+
+type UpdateRetentionPolicyInput struct {
+	Id                string
+	Action            *RetentionPolicyAction
+	RetentionDays     *int
+	KeepLatestVersion *bool
+	Enabled           *bool
+}
+
+// RetentionReport describes what a retention policy would remove if it was executed now
+type RetentionReport struct {
+	PolicyId  string
+	TableName string
+	Action    RetentionPolicyAction
+	Cutoff    time.Time
+	// NbRows is the number of rows that would be deleted or archived
+	NbRows int
+	// NbRowsOnHold is the number of rows older than the cutoff that are kept because they are under legal hold
+	NbRowsOnHold int
+}
+
+// RetainedRow is a row selected by a retention policy, with its full content kept to be archived before deletion
+type RetainedRow struct {
+	Id        string
+	CreatedAt time.Time
+	Data      map[string]any
+}
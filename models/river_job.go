@@ -43,6 +43,12 @@ type TestRunSummaryArgs struct {
 
 func (TestRunSummaryArgs) Kind() string { return "test_run_summary" }
 
+type IdempotencyKeyCleanupArgs struct {
+	OrgId string `json:"org_id"`
+}
+
+func (IdempotencyKeyCleanupArgs) Kind() string { return "idempotency_key_cleanup" }
+
 type MatchEnrichmentArgs struct {
 	OrgId           string `json:"org_id"`
 	SanctionCheckId string `json:"sanction_check_id"`
@@ -55,3 +61,93 @@ type OffloadingArgs struct {
 }
 
 func (OffloadingArgs) Kind() string { return "offloading" }
+
+// job that creates decisions on all live scenarios after the ingestion of an object, on tables
+// configured with DecisionTriggerModeAsync. There is one job per object, so that a retry does not create the
+// decisions on the other ingested objects again.
+type IngestedObjectsDecisionArgs struct {
+	OrgId      string `json:"org_id"`
+	ObjectType string `json:"object_type"`
+	ObjectId   string `json:"object_id"`
+}
+
+func (IngestedObjectsDecisionArgs) Kind() string { return "ingested_objects_decision" }
+
+type ChangeFeedArgs struct {
+	OrgId string `json:"org_id"`
+}
+
+func (ChangeFeedArgs) Kind() string { return "change_feed" }
+
+type DecisionExportArgs struct {
+	OrgId    string `json:"org_id"`
+	ExportId string `json:"export_id"`
+}
+
+func (DecisionExportArgs) Kind() string { return "decision_export" }
+
+type DecisionRequestArgs struct {
+	OrgId     string `json:"org_id"`
+	RequestId string `json:"request_id"`
+}
+
+func (DecisionRequestArgs) Kind() string { return "decision_request" }
+
+type DecisionReevaluationBatchArgs struct {
+	OrgId   string `json:"org_id"`
+	BatchId string `json:"batch_id"`
+}
+
+func (DecisionReevaluationBatchArgs) Kind() string { return "decision_reevaluation_batch" }
+
+type CaseSlaArgs struct {
+	OrgId string `json:"org_id"`
+}
+
+func (CaseSlaArgs) Kind() string { return "case_sla" }
+
+type RetentionArgs struct {
+	OrgId string `json:"org_id"`
+}
+
+func (RetentionArgs) Kind() string { return "retention" }
+
+type CaseExportArgs struct {
+	OrgId    string `json:"org_id"`
+	ExportId string `json:"export_id"`
+}
+
+func (CaseExportArgs) Kind() string { return "case_export" }
+
+type CaseBulkActionArgs struct {
+	OrgId        string `json:"org_id"`
+	BulkActionId string `json:"bulk_action_id"`
+}
+
+func (CaseBulkActionArgs) Kind() string { return "case_bulk_action" }
+
+type NotificationArgs struct {
+	OrgId string `json:"org_id"`
+}
+
+func (NotificationArgs) Kind() string { return "notification" }
+
+// job that sends a notification to a user by email, which does not depend on the in-app notification that the user may
+// have disabled
+type NotificationEmailArgs struct {
+	OrgId   string `json:"org_id"`
+	UserId  string `json:"user_id"`
+	CaseId  string `json:"case_id"`
+	Message string `json:"message"`
+}
+
+func (NotificationEmailArgs) Kind() string { return "notification_email" }
+
+// job that deletes files once the rows that referenced them were deleted, so that a failed deletion of the rows does not
+// leave them pointing to missing files
+type BlobDeletionArgs struct {
+	OrgId string    `json:"org_id"`
+	Blobs []BlobRef `json:"blobs"`
+}
+
+func (BlobDeletionArgs) Kind() string { return "blob_deletion" }
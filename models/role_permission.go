@@ -37,6 +37,7 @@ var (
 		TAG_DELETE,
 		ORGANIZATIONS_UPDATE,
 		ANNOTATION_DELETE,
+		DATA_ERASURE,
 	)
 )
 
@@ -59,6 +60,7 @@ var ROLES_PERMISSIOMS = map[Role][]Permission{
 		SANCTION_CHECK_WHITELIST_READ,
 		SANCTION_CHECK_WHITELIST_WRITE,
 		SANCTION_CHECK_FREEFORM_SEARCH,
+		DATA_ERASURE,
 	},
 	TRANSFER_CHECK_API_CLIENT: {
 		TRANSFER_READ,
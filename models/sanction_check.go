@@ -205,6 +205,7 @@ type SanctionCheckFile struct {
 	BucketName      string
 	FileReference   string
 	FileName        string
+	ScanStatus      FileScanStatus
 	CreatedAt       time.Time
 }
 
@@ -213,6 +214,7 @@ type SanctionCheckFileInput struct {
 	BucketName      string
 	FileReference   string
 	FileName        string
+	ScanStatus      FileScanStatus
 }
 
 type SanctionCheckWhitelist struct {
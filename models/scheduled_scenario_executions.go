@@ -21,6 +21,8 @@ type ScheduledExecution struct {
 	NumberOfPlannedDecisions   *int
 	Scenario                   Scenario
 	Manual                     bool
+	// ReadAsOf, if set, evaluates the scenario on the objects and the ingested data as they were at that time
+	ReadAsOf *time.Time
 }
 
 type ScheduledExecutionStatus int
@@ -83,6 +85,7 @@ type CreateScheduledExecutionInput struct {
 	ScenarioId          string
 	ScenarioIterationId string
 	Manual              bool
+	ReadAsOf            *time.Time
 }
 
 type ListScheduledExecutionsFilters struct {
@@ -36,16 +36,20 @@ func SarStatusFromString(s string) SarStatus {
 }
 
 type SuspiciousActivityReport struct {
-	Id         string
-	ReportId   string
-	CaseId     string
-	Status     SarStatus
-	Bucket     *string
-	BlobKey    *string
+	Id       string
+	ReportId string
+	CaseId   string
+	Status   SarStatus
+	Bucket   *string
+	BlobKey  *string
+	// ScanStatus is the result of the malware scan of the uploaded file
+	ScanStatus FileScanStatus
 	CreatedBy  string
 	UploadedBy *string
-	CreatedAt  time.Time
-	DeletedAt  *time.Time
+	// Content is the structured content of the report, nil until it is first drafted
+	Content   *SarContent
+	CreatedAt time.Time
+	DeletedAt *time.Time
 }
 
 type SuspiciousActivityReportRequest struct {
@@ -55,7 +59,9 @@ type SuspiciousActivityReportRequest struct {
 	BlobKey    *string
 	Status     *SarStatus
 	File       *multipart.FileHeader
+	ScanStatus *FileScanStatus
 	CreatedBy  UserId
 	UploadedBy *UserId
+	Content    *SarContent
 	DeletedAt  *time.Time
 }
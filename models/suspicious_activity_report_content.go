@@ -0,0 +1,199 @@
+package models
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// SarContent is the structured content of a suspicious activity report, from which the file sent to the financial
+// intelligence unit is generated. It is stored as JSON alongside the report, and is prefilled from the case: the
+// subjects come from the pivot objects of the case, and the transactions from the trigger objects of its decisions.
+type SarContent struct {
+	ReportCode      SarReportCode      `json:"report_code"`
+	ReportingEntity SarReportingEntity `json:"reporting_entity"`
+	// CurrencyCode is the local currency of the reporting entity, in ISO 4217
+	CurrencyCode string           `json:"currency_code"`
+	Subjects     []SarSubject     `json:"subjects"`
+	Transactions []SarTransaction `json:"transactions"`
+	Narrative    string           `json:"narrative"`
+	ActionTaken  string           `json:"action_taken"`
+	Indicators   []string         `json:"indicators"`
+}
+
+type SarReportCode string
+
+const (
+	// Suspicious transaction report, about one or more transactions
+	SarReportCodeStr SarReportCode = "STR"
+	// Suspicious activity report, about the activity of the subjects without specific transactions
+	SarReportCodeSar SarReportCode = "SAR"
+)
+
+var ValidSarReportCodes = []SarReportCode{SarReportCodeStr, SarReportCodeSar}
+
+type SarReportingEntity struct {
+	// EntityId is the identifier of the reporting entity, assigned by the financial intelligence unit
+	EntityId                 string `json:"entity_id"`
+	Branch                   string `json:"branch"`
+	Name                     string `json:"name"`
+	ReportingPersonFirstName string `json:"reporting_person_first_name"`
+	ReportingPersonLastName  string `json:"reporting_person_last_name"`
+	ReportingPersonEmail     string `json:"reporting_person_email"`
+}
+
+type SarSubjectType string
+
+const (
+	SarSubjectPerson  SarSubjectType = "person"
+	SarSubjectEntity  SarSubjectType = "entity"
+	SarSubjectAccount SarSubjectType = "account"
+)
+
+var ValidSarSubjectTypes = []SarSubjectType{SarSubjectPerson, SarSubjectEntity, SarSubjectAccount}
+
+type SarSubject struct {
+	// Id is the reference of the subject in the transactions of the report, the pivot value when prefilled
+	Id         string         `json:"id"`
+	Type       SarSubjectType `json:"type"`
+	ObjectType string         `json:"object_type"`
+	ObjectId   string         `json:"object_id"`
+	// person fields
+	FirstName   string     `json:"first_name"`
+	LastName    string     `json:"last_name"`
+	BirthDate   *time.Time `json:"birth_date"`
+	Nationality string     `json:"nationality"`
+	// entity fields
+	Name                string `json:"name"`
+	IncorporationNumber string `json:"incorporation_number"`
+	// account fields
+	AccountNumber string `json:"account_number"`
+	Institution   string `json:"institution"`
+	// address, for persons and entities
+	Address     string `json:"address"`
+	City        string `json:"city"`
+	CountryCode string `json:"country_code"`
+}
+
+type SarTransaction struct {
+	DecisionId        string    `json:"decision_id"`
+	TransactionNumber string    `json:"transaction_number"`
+	Date              time.Time `json:"date"`
+	Amount            float64   `json:"amount"`
+	Description       string    `json:"description"`
+	FromSubjectId     string    `json:"from_subject_id"`
+	ToSubjectId       string    `json:"to_subject_id"`
+	// TransmodeCode is the way the transaction was conducted, and FromFundsCode and ToFundsCode the type of funds on
+	// either side. They are codes of the lookup tables of the financial intelligence unit, so they are not prefilled.
+	TransmodeCode string `json:"transmode_code"`
+	FromFundsCode string `json:"from_funds_code"`
+	ToFundsCode   string `json:"to_funds_code"`
+}
+
+// Validate returns the list of problems that prevent the content from being exported. An incomplete content can still
+// be saved as a draft.
+func (c SarContent) Validate() []string {
+	problems := make([]string, 0)
+	addProblem := func(format string, args ...any) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+
+	if !slices.Contains(ValidSarReportCodes, c.ReportCode) {
+		addProblem("report_code must be one of %v", ValidSarReportCodes)
+	}
+	if c.ReportingEntity.EntityId == "" {
+		addProblem("reporting_entity.entity_id is required")
+	}
+	if c.ReportingEntity.ReportingPersonLastName == "" {
+		addProblem("reporting_entity.reporting_person_last_name is required")
+	}
+	if len(c.CurrencyCode) != 3 {
+		addProblem("currency_code must be a 3 letters ISO 4217 code")
+	}
+	if strings.TrimSpace(c.Narrative) == "" {
+		addProblem("narrative is required")
+	}
+	if len(c.Subjects) == 0 {
+		addProblem("at least one subject is required")
+	}
+
+	subjectIds := make([]string, 0, len(c.Subjects))
+	for i, subject := range c.Subjects {
+		if subject.Id == "" {
+			addProblem("subjects[%d].id is required", i)
+		} else if slices.Contains(subjectIds, subject.Id) {
+			addProblem("subjects[%d].id %s is not unique", i, subject.Id)
+		}
+		subjectIds = append(subjectIds, subject.Id)
+
+		switch subject.Type {
+		case SarSubjectPerson:
+			if subject.LastName == "" {
+				addProblem("subjects[%d].last_name is required for a person", i)
+			}
+		case SarSubjectEntity:
+			if subject.Name == "" {
+				addProblem("subjects[%d].name is required for an entity", i)
+			}
+		case SarSubjectAccount:
+			if subject.AccountNumber == "" {
+				addProblem("subjects[%d].account_number is required for an account", i)
+			}
+		default:
+			addProblem("subjects[%d].type must be one of %v", i, ValidSarSubjectTypes)
+		}
+		if subject.CountryCode != "" && len(subject.CountryCode) != 2 {
+			addProblem("subjects[%d].country_code must be a 2 letters ISO 3166 code", i)
+		}
+	}
+
+	if c.ReportCode == SarReportCodeStr && len(c.Transactions) == 0 {
+		addProblem("at least one transaction is required for a suspicious transaction report")
+	}
+	if c.ReportCode == SarReportCodeSar && len(c.Transactions) > 0 {
+		addProblem("a suspicious activity report cannot contain transactions, use a suspicious transaction report")
+	}
+	for i, transaction := range c.Transactions {
+		if transaction.TransactionNumber == "" {
+			addProblem("transactions[%d].transaction_number is required", i)
+		}
+		if transaction.Date.IsZero() {
+			addProblem("transactions[%d].date is required", i)
+		}
+		if transaction.Amount <= 0 {
+			addProblem("transactions[%d].amount must be positive", i)
+		}
+		if transaction.TransmodeCode == "" {
+			addProblem("transactions[%d].transmode_code is required", i)
+		}
+		if transaction.FromFundsCode == "" {
+			addProblem("transactions[%d].from_funds_code is required", i)
+		}
+		if transaction.ToFundsCode == "" {
+			addProblem("transactions[%d].to_funds_code is required", i)
+		}
+		if !slices.Contains(subjectIds, transaction.FromSubjectId) {
+			addProblem("transactions[%d].from_subject_id must reference a subject", i)
+		}
+		if !slices.Contains(subjectIds, transaction.ToSubjectId) {
+			addProblem("transactions[%d].to_subject_id must reference a subject", i)
+		}
+	}
+
+	return problems
+}
+
+type SarExportFormat string
+
+const (
+	SarExportGoAml SarExportFormat = "goaml"
+	SarExportJson  SarExportFormat = "json"
+)
+
+// SarExport is a generated report file
+type SarExport struct {
+	FileName    string
+	ContentType string
+	Data        []byte
+}
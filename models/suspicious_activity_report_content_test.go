@@ -0,0 +1,65 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSarContent_Validate(t *testing.T) {
+	content := SarContent{
+		ReportCode:      SarReportCodeStr,
+		ReportingEntity: SarReportingEntity{EntityId: "1234", ReportingPersonLastName: "Officer"},
+		CurrencyCode:    "EUR",
+		Narrative:       "Structuring",
+		Subjects: []SarSubject{
+			{Id: "user_1", Type: SarSubjectPerson, LastName: "Doe"},
+			{Id: "company_1", Type: SarSubjectEntity, Name: "Acme"},
+		},
+		Transactions: []SarTransaction{
+			{
+				TransactionNumber: "tx_1",
+				Date:              time.Now(),
+				Amount:            100,
+				FromSubjectId:     "user_1",
+				ToSubjectId:       "company_1",
+				TransmodeCode:     "K",
+				FromFundsCode:     "K",
+				ToFundsCode:       "K",
+			},
+		},
+	}
+	assert.Empty(t, content.Validate())
+
+	content.Narrative = " "
+	content.Subjects[1].Name = ""
+	content.Transactions[0].ToSubjectId = "unknown"
+	content.Transactions[0].TransmodeCode = ""
+	assert.Equal(t, []string{
+		"narrative is required",
+		"subjects[1].name is required for an entity",
+		"transactions[0].transmode_code is required",
+		"transactions[0].to_subject_id must reference a subject",
+	}, content.Validate())
+
+	// a goAML report contains either transactions or an activity, not both
+	content.Narrative = "Structuring"
+	content.Subjects[1].Name = "Acme"
+	content.Transactions[0].ToSubjectId = "company_1"
+	content.Transactions[0].TransmodeCode = "K"
+	content.ReportCode = SarReportCodeSar
+	assert.Equal(t, []string{
+		"a suspicious activity report cannot contain transactions, use a suspicious transaction report",
+	}, content.Validate())
+
+	// a suspicious activity report does not need transactions
+	content = SarContent{
+		ReportCode:      SarReportCodeSar,
+		ReportingEntity: SarReportingEntity{EntityId: "1234", ReportingPersonLastName: "Officer"},
+		CurrencyCode:    "EUR",
+		Narrative:       "Unusual activity",
+		Subjects:        []SarSubject{{Id: "user_1", Type: SarSubjectPerson, LastName: "Doe"}},
+	}
+	assert.Empty(t, content.Validate())
+}
@@ -31,10 +31,13 @@ const (
 	WebhookEventType_CaseDecisionsUpdated  WebhookEventType = "case.decisions_updated"
 	WebhookEventType_CaseTagsUpdated       WebhookEventType = "case.tags_updated"
 	WebhookEventType_CaseCommentCreated    WebhookEventType = "case.comment_created"
+	WebhookEventType_CaseCommentUpdated    WebhookEventType = "case.comment_updated"
 	WebhookEventType_CaseFileCreated       WebhookEventType = "case.file_created"
 	WebhookEventType_CaseRuleSnoozeCreated WebhookEventType = "case.rule_snooze_created"
 	WebhookEventType_CaseDecisionReviewed  WebhookEventType = "case.decision_reviewed"
+	WebhookEventType_CaseSlaBreached       WebhookEventType = "case.sla_breached"
 	WebhookEventType_DecisionCreated       WebhookEventType = "decision.created"
+	WebhookEventType_DecisionAsyncResult   WebhookEventType = "decision.async_result"
 )
 
 var validWebhookEventTypes = []WebhookEventType{
@@ -44,10 +47,13 @@ var validWebhookEventTypes = []WebhookEventType{
 	WebhookEventType_CaseDecisionsUpdated,
 	WebhookEventType_CaseTagsUpdated,
 	WebhookEventType_CaseCommentCreated,
+	WebhookEventType_CaseCommentUpdated,
 	WebhookEventType_CaseFileCreated,
 	WebhookEventType_DecisionCreated,
 	WebhookEventType_CaseRuleSnoozeCreated,
 	WebhookEventType_CaseDecisionReviewed,
+	WebhookEventType_DecisionAsyncResult,
+	WebhookEventType_CaseSlaBreached,
 }
 
 type WebhookEventContent struct {
@@ -136,6 +142,29 @@ func NewWebhookEventDecisionCreated(id string) WebhookEventContent {
 	}
 }
 
+// NewWebhookEventDecisionAsyncResult is sent when an asynchronous decision request is no longer pending. The decision id
+// is only set if a decision was created.
+func NewWebhookEventDecisionAsyncResult(request DecisionRequest) WebhookEventContent {
+	var outcome *string
+	if request.Outcome != nil {
+		o := request.Outcome.String()
+		outcome = &o
+	}
+	return WebhookEventContent{
+		Type: WebhookEventType_DecisionAsyncResult,
+		Data: map[string]any{
+			"type": WebhookEventType_DecisionAsyncResult,
+			"content": map[string]any{"decision_request": map[string]any{
+				"id":          request.Id,
+				"status":      request.Status,
+				"outcome":     outcome,
+				"decision_id": request.DecisionId(),
+			}},
+			"timestamp": time.Now(),
+		},
+	}
+}
+
 func mapOfCaseWithId(id string) map[string]any {
 	return map[string]any{"case": map[string]any{"id": id}}
 }
@@ -171,8 +200,34 @@ func NewWebhookEventCaseTagsUpdated(c Case) WebhookEventContent {
 	return newWebhookContentCase(WebhookEventType_CaseTagsUpdated, c.Id)
 }
 
-func NewWebhookEventCaseCommentCreated(c Case) WebhookEventContent {
-	return newWebhookContentCase(WebhookEventType_CaseCommentCreated, c.Id)
+func newWebhookContentCaseComment(eventType WebhookEventType, caseId string, comment *CaseComment) WebhookEventContent {
+	content := mapOfCaseWithId(caseId)
+	if comment != nil {
+		content["comment"] = map[string]any{
+			"id":        comment.Id,
+			"parent_id": comment.ParentId,
+			"deleted":   comment.DeletedAt != nil,
+		}
+	}
+	return WebhookEventContent{
+		Type: eventType,
+		Data: map[string]any{
+			"type":      eventType,
+			"content":   content,
+			"timestamp": time.Now(),
+		},
+	}
+}
+
+// NewWebhookEventCaseCommentCreated is sent when a comment is posted on a case, or with a nil comment when a rule is
+// snoozed with a comment
+func NewWebhookEventCaseCommentCreated(caseId string, comment *CaseComment) WebhookEventContent {
+	return newWebhookContentCaseComment(WebhookEventType_CaseCommentCreated, caseId, comment)
+}
+
+// NewWebhookEventCaseCommentUpdated is sent when a comment is edited or deleted
+func NewWebhookEventCaseCommentUpdated(comment CaseComment) WebhookEventContent {
+	return newWebhookContentCaseComment(WebhookEventType_CaseCommentUpdated, comment.CaseId, &comment)
 }
 
 func NewWebhookEventCaseFileCreated(caseId string) WebhookEventContent {
@@ -197,6 +252,25 @@ func NewWebhookEventDecisionReviewed(c Case, decisionId string) WebhookEventCont
 	}
 }
 
+func NewWebhookEventCaseSlaBreached(breach CaseSlaBreach) WebhookEventContent {
+	return WebhookEventContent{
+		Type: WebhookEventType_CaseSlaBreached,
+		Data: map[string]any{
+			"type": WebhookEventType_CaseSlaBreached,
+			"content": map[string]any{
+				"case": map[string]any{"id": breach.CaseId},
+				"sla_breach": map[string]any{
+					"policy_id":     breach.PolicyId,
+					"kind":          breach.Kind,
+					"due_at":        breach.DueAt,
+					"breach_action": breach.BreachAction,
+				},
+			},
+			"timestamp": time.Now(),
+		},
+	}
+}
+
 type Webhook struct {
 	Id                string
 	OrganizationId    string
@@ -25,6 +25,7 @@ import (
 	_ "gocloud.dev/blob/fileblob"
 	"gocloud.dev/blob/gcsblob"
 	_ "gocloud.dev/blob/s3blob"
+	"gocloud.dev/gcerrors"
 	"gocloud.dev/gcp"
 )
 
@@ -202,7 +203,11 @@ func (repository *blobRepository) DeleteFile(ctx context.Context, bucketUrl, key
 	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
 	defer cancel()
 
-	return bucket.Delete(ctx, key)
+	err = bucket.Delete(ctx, key)
+	if gcerrors.Code(err) == gcerrors.NotFound {
+		return errors.Wrapf(models.NotFoundError, "file %s does not exist in bucket %s", key, bucketUrl)
+	}
+	return err
 }
 
 func (repo *blobRepository) GenerateSignedUrl(ctx context.Context, bucketUrl, key string) (string, error) {
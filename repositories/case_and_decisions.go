@@ -24,11 +24,12 @@ func (repo *MarbleDbRepository) SelectCasesWithPivot(
 		SELECT DISTINCT case_id FROM decisions WHERE org_id = $1 AND pivot_value = $3 AND case_id IS NOT NULL ORDER BY case_id
 		) AS d ON c.id = d.case_id
 	WHERE c.org_id = $1 
-		AND c.status IN ('pending', 'investigating')
+		AND c.status != ALL($4)
 		AND c.inbox_id = $2
 	`
 
-	rows, err := exec.Query(ctx, query, filters.OrganizationId, filters.InboxId, filters.PivotValue)
+	rows, err := exec.Query(ctx, query, filters.OrganizationId, filters.InboxId, filters.PivotValue,
+		finalizedCaseStatuses())
 	if err != nil {
 		return nil, err
 	}
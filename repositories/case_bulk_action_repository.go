@@ -0,0 +1,156 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/repositories/dbmodels"
+	"github.com/cockroachdb/errors"
+)
+
+// CreateCaseBulkAction creates a bulk action, with a pending item for each of its cases
+func (repo *MarbleDbRepository) CreateCaseBulkAction(ctx context.Context, exec Executor,
+	input models.CaseBulkActionCreate,
+) (models.CaseBulkAction, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return models.CaseBulkAction{}, err
+	}
+
+	params, err := json.Marshal(input.Params)
+	if err != nil {
+		return models.CaseBulkAction{}, errors.Wrap(err, "could not marshal the params of the bulk action")
+	}
+
+	sql := NewQueryBuilder().
+		Insert(dbmodels.TABLE_CASE_BULK_ACTIONS).
+		Columns(
+			"org_id",
+			"user_id",
+			"action",
+			"params",
+			"status",
+			"nb_cases",
+		).
+		Values(
+			input.OrganizationId,
+			input.UserId,
+			input.Action,
+			params,
+			models.CaseBulkActionPending,
+			len(input.CaseIds),
+		).
+		Suffix(fmt.Sprintf("RETURNING %s", strings.Join(dbmodels.SelectCaseBulkActionColumns, ",")))
+
+	bulkAction, err := SqlToModel(ctx, exec, sql, dbmodels.AdaptCaseBulkAction)
+	if err != nil {
+		return models.CaseBulkAction{}, err
+	}
+
+	items := NewQueryBuilder().
+		Insert(dbmodels.TABLE_CASE_BULK_ACTION_ITEMS).
+		Columns("bulk_action_id", "case_id", "status")
+	for _, caseId := range input.CaseIds {
+		items = items.Values(bulkAction.Id, caseId, models.CaseBulkActionItemPending)
+	}
+	if err := ExecBuilder(ctx, exec, items); err != nil {
+		return models.CaseBulkAction{}, err
+	}
+
+	return bulkAction, nil
+}
+
+func (repo *MarbleDbRepository) GetCaseBulkAction(ctx context.Context, exec Executor, id string) (models.CaseBulkAction, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return models.CaseBulkAction{}, err
+	}
+
+	sql := NewQueryBuilder().
+		Select(dbmodels.SelectCaseBulkActionColumns...).
+		From(dbmodels.TABLE_CASE_BULK_ACTIONS).
+		Where(squirrel.Eq{"id": id})
+
+	return SqlToModel(ctx, exec, sql, dbmodels.AdaptCaseBulkAction)
+}
+
+func (repo *MarbleDbRepository) ListCaseBulkActions(ctx context.Context, exec Executor,
+	organizationId string, userId models.UserId,
+) ([]models.CaseBulkAction, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	sql := NewQueryBuilder().
+		Select(dbmodels.SelectCaseBulkActionColumns...).
+		From(dbmodels.TABLE_CASE_BULK_ACTIONS).
+		Where(squirrel.Eq{"org_id": organizationId, "user_id": userId}).
+		OrderBy("created_at desc").
+		Limit(100)
+
+	return SqlToListOfModels(ctx, exec, sql, dbmodels.AdaptCaseBulkAction)
+}
+
+func (repo *MarbleDbRepository) ListCaseBulkActionItems(ctx context.Context, exec Executor,
+	bulkActionId string, status *models.CaseBulkActionItemStatus,
+) ([]models.CaseBulkActionItem, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	sql := NewQueryBuilder().
+		Select(dbmodels.SelectCaseBulkActionItemColumns...).
+		From(dbmodels.TABLE_CASE_BULK_ACTION_ITEMS).
+		Where(squirrel.Eq{"bulk_action_id": bulkActionId}).
+		OrderBy("case_id")
+	if status != nil {
+		sql = sql.Where(squirrel.Eq{"status": *status})
+	}
+
+	return SqlToListOfModels(ctx, exec, sql, dbmodels.AdaptCaseBulkActionItem)
+}
+
+func (repo *MarbleDbRepository) UpdateCaseBulkActionItem(ctx context.Context, exec Executor,
+	bulkActionId, caseId string, status models.CaseBulkActionItemStatus, errorMessage *string,
+) error {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return err
+	}
+
+	sql := NewQueryBuilder().
+		Update(dbmodels.TABLE_CASE_BULK_ACTION_ITEMS).
+		Set("status", status).
+		Set("error", errorMessage).
+		Set("updated_at", squirrel.Expr("now()")).
+		Where(squirrel.Eq{"bulk_action_id": bulkActionId, "case_id": caseId})
+
+	return ExecBuilder(ctx, exec, sql)
+}
+
+// UpdateCaseBulkActionStatus sets the status of a bulk action, and counts the results of its items
+func (repo *MarbleDbRepository) UpdateCaseBulkActionStatus(ctx context.Context, exec Executor,
+	id string, status models.CaseBulkActionStatus,
+) error {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return err
+	}
+
+	countItems := func(status models.CaseBulkActionItemStatus) squirrel.Sqlizer {
+		return squirrel.Expr("(?)", squirrel.
+			Select("count(*)").
+			From(dbmodels.TABLE_CASE_BULK_ACTION_ITEMS).
+			Where(squirrel.Eq{"bulk_action_id": id, "status": status}))
+	}
+
+	sql := NewQueryBuilder().
+		Update(dbmodels.TABLE_CASE_BULK_ACTIONS).
+		Set("status", status).
+		Set("nb_succeeded", countItems(models.CaseBulkActionItemSuccess)).
+		Set("nb_failed", countItems(models.CaseBulkActionItemFailed)).
+		Set("updated_at", squirrel.Expr("now()")).
+		Where(squirrel.Eq{"id": id})
+
+	return ExecBuilder(ctx, exec, sql)
+}
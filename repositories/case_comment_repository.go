@@ -0,0 +1,145 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/repositories/dbmodels"
+)
+
+func (repo *MarbleDbRepository) CreateCaseComment(ctx context.Context, exec Executor,
+	input models.CaseCommentCreate,
+) (models.CaseComment, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return models.CaseComment{}, err
+	}
+
+	sql := NewQueryBuilder().
+		Insert(dbmodels.TABLE_CASE_COMMENTS).
+		Columns(
+			"org_id",
+			"case_id",
+			"parent_id",
+			"author_id",
+			"body",
+			"mentioned_user_ids",
+		).
+		Values(
+			input.OrganizationId,
+			input.CaseId,
+			input.ParentId,
+			input.AuthorId,
+			input.Body,
+			input.MentionedUserIds,
+		).
+		Suffix(fmt.Sprintf("RETURNING %s", strings.Join(dbmodels.SelectCaseCommentColumns, ",")))
+
+	return SqlToModel(ctx, exec, sql, dbmodels.AdaptCaseComment)
+}
+
+func (repo *MarbleDbRepository) GetCaseCommentById(ctx context.Context, exec Executor,
+	id string, forUpdate bool,
+) (models.CaseComment, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return models.CaseComment{}, err
+	}
+
+	sql := NewQueryBuilder().
+		Select(dbmodels.SelectCaseCommentColumns...).
+		From(dbmodels.TABLE_CASE_COMMENTS).
+		Where(squirrel.Eq{"id": id})
+
+	if forUpdate {
+		sql = sql.Suffix("FOR UPDATE")
+	}
+
+	return SqlToModel(ctx, exec, sql, dbmodels.AdaptCaseComment)
+}
+
+// ListCaseComments returns the comments of a case in chronological order, including the deleted ones which are needed
+// to display their replies
+func (repo *MarbleDbRepository) ListCaseComments(ctx context.Context, exec Executor,
+	caseId string,
+) ([]models.CaseComment, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	sql := NewQueryBuilder().
+		Select(dbmodels.SelectCaseCommentColumns...).
+		From(dbmodels.TABLE_CASE_COMMENTS).
+		Where(squirrel.Eq{"case_id": caseId}).
+		OrderBy("created_at", "id")
+
+	return SqlToListOfModels(ctx, exec, sql, dbmodels.AdaptCaseComment)
+}
+
+// UpdateCaseComment replaces the content of a comment, after saving its previous content as a version
+func (repo *MarbleDbRepository) UpdateCaseComment(ctx context.Context, exec Executor,
+	comment models.CaseComment, editedBy string, update models.UpdateCaseCommentAttributes,
+) (models.CaseComment, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return models.CaseComment{}, err
+	}
+
+	err := ExecBuilder(ctx, exec, NewQueryBuilder().
+		Insert(dbmodels.TABLE_CASE_COMMENT_VERSIONS).
+		Columns(
+			"comment_id",
+			"body",
+			"mentioned_user_ids",
+			"edited_by",
+		).
+		Values(
+			comment.Id,
+			comment.Body,
+			comment.MentionedUserIds,
+			editedBy,
+		))
+	if err != nil {
+		return models.CaseComment{}, err
+	}
+
+	sql := NewQueryBuilder().
+		Update(dbmodels.TABLE_CASE_COMMENTS).
+		Set("body", update.Body).
+		Set("mentioned_user_ids", update.MentionedUserIds).
+		Set("edited_at", squirrel.Expr("now()")).
+		Where(squirrel.Eq{"id": comment.Id}).
+		Suffix(fmt.Sprintf("RETURNING %s", strings.Join(dbmodels.SelectCaseCommentColumns, ",")))
+
+	return SqlToModel(ctx, exec, sql, dbmodels.AdaptCaseComment)
+}
+
+func (repo *MarbleDbRepository) DeleteCaseComment(ctx context.Context, exec Executor, id string) (models.CaseComment, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return models.CaseComment{}, err
+	}
+
+	sql := NewQueryBuilder().
+		Update(dbmodels.TABLE_CASE_COMMENTS).
+		Set("deleted_at", squirrel.Expr("now()")).
+		Where(squirrel.Eq{"id": id}).
+		Suffix(fmt.Sprintf("RETURNING %s", strings.Join(dbmodels.SelectCaseCommentColumns, ",")))
+
+	return SqlToModel(ctx, exec, sql, dbmodels.AdaptCaseComment)
+}
+
+func (repo *MarbleDbRepository) ListCaseCommentVersions(ctx context.Context, exec Executor,
+	commentId string,
+) ([]models.CaseCommentVersion, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	sql := NewQueryBuilder().
+		Select(dbmodels.SelectCaseCommentVersionColumns...).
+		From(dbmodels.TABLE_CASE_COMMENT_VERSIONS).
+		Where(squirrel.Eq{"comment_id": commentId}).
+		OrderBy("created_at")
+
+	return SqlToListOfModels(ctx, exec, sql, dbmodels.AdaptCaseCommentVersion)
+}
@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/Masterminds/squirrel"
 	"github.com/checkmarble/marble-backend/models"
@@ -14,17 +15,21 @@ func (repo *MarbleDbRepository) ListCaseEvents(ctx context.Context, exec Executo
 		return nil, err
 	}
 
+	// the events of deleted comments are listed without the content of the comment
 	query := NewQueryBuilder().
-		Select(dbmodels.SelectCaseEventColumn...).
-		From(dbmodels.TABLE_CASE_EVENTS).
-		Where(squirrel.Eq{"case_id": caseId}).
-		OrderBy("created_at DESC")
+		Select(columnsNames("e", dbmodels.SelectCaseEventColumn)...).
+		Column("cc.deleted_at IS NOT NULL AS comment_deleted").
+		From(dbmodels.TABLE_CASE_EVENTS + " AS e").
+		LeftJoin(fmt.Sprintf("%s AS cc ON e.resource_type = '%s' AND cc.id = e.resource_id",
+			dbmodels.TABLE_CASE_COMMENTS, models.CaseCommentResourceType)).
+		Where(squirrel.Eq{"e.case_id": caseId}).
+		OrderBy("e.created_at DESC")
 
 	return SqlToListOfModels(
 		ctx,
 		exec,
 		query,
-		dbmodels.AdaptCaseEvent,
+		dbmodels.AdaptCaseEventWithComment,
 	)
 }
 
@@ -0,0 +1,82 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/repositories/dbmodels"
+)
+
+func (repo *MarbleDbRepository) CreateCaseExport(ctx context.Context, exec Executor,
+	organizationId, caseId string, requestedByUserId *models.UserId,
+) (models.CaseExport, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return models.CaseExport{}, err
+	}
+
+	sql := NewQueryBuilder().
+		Insert(dbmodels.TABLE_CASE_EXPORTS).
+		Columns(
+			"org_id",
+			"case_id",
+			"status",
+			"requested_by_user_id",
+		).
+		Values(
+			organizationId,
+			caseId,
+			models.CaseExportPending,
+			requestedByUserId,
+		).
+		Suffix(fmt.Sprintf("RETURNING %s", strings.Join(dbmodels.SelectCaseExportColumns, ",")))
+
+	return SqlToModel(ctx, exec, sql, dbmodels.AdaptCaseExport)
+}
+
+func (repo *MarbleDbRepository) GetCaseExport(ctx context.Context, exec Executor, id string) (models.CaseExport, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return models.CaseExport{}, err
+	}
+
+	sql := NewQueryBuilder().
+		Select(dbmodels.SelectCaseExportColumns...).
+		From(dbmodels.TABLE_CASE_EXPORTS).
+		Where(squirrel.Eq{"id": id})
+
+	return SqlToModel(ctx, exec, sql, dbmodels.AdaptCaseExport)
+}
+
+func (repo *MarbleDbRepository) ListCaseExports(ctx context.Context, exec Executor, caseId string) ([]models.CaseExport, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	sql := NewQueryBuilder().
+		Select(dbmodels.SelectCaseExportColumns...).
+		From(dbmodels.TABLE_CASE_EXPORTS).
+		Where(squirrel.Eq{"case_id": caseId}).
+		OrderBy("created_at desc")
+
+	return SqlToListOfModels(ctx, exec, sql, dbmodels.AdaptCaseExport)
+}
+
+func (repo *MarbleDbRepository) UpdateCaseExport(ctx context.Context, exec Executor, id string,
+	update models.CaseExportUpdate,
+) error {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return err
+	}
+
+	sql := NewQueryBuilder().
+		Update(dbmodels.TABLE_CASE_EXPORTS).
+		Set("status", update.Status).
+		Set("nb_files", update.NbFiles).
+		Set("size_bytes", update.SizeBytes).
+		Set("updated_at", squirrel.Expr("now()")).
+		Where(squirrel.Eq{"id": id})
+
+	return ExecBuilder(ctx, exec, sql)
+}
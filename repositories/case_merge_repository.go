@@ -0,0 +1,106 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/repositories/dbmodels"
+)
+
+// the case events that follow the resources moved by a case merge: comments, files and SARs
+var mergedCaseEventTypes = []models.CaseEventType{
+	models.CaseCommentAdded,
+	models.CaseCommentUpdated,
+	models.CaseCommentDeleted,
+	models.CaseCommentMention,
+	models.CaseFileAdded,
+	models.SarCreated,
+	models.SarDeleted,
+	models.SarStatusChanged,
+	models.SarFileUploaded,
+}
+
+// MoveCaseContent moves the decisions, comments, files, annotations and SARs of a case to another case of the same
+// organization, and copies its tags and contributors. The events of the moved comments, files and SARs are copied
+// rather than moved, so that the source case keeps its audit timeline.
+func (repo *MarbleDbRepository) MoveCaseContent(ctx context.Context, exec Executor,
+	organizationId, sourceCaseId, targetCaseId string,
+) error {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return err
+	}
+
+	moves := []squirrel.UpdateBuilder{
+		NewQueryBuilder().Update(dbmodels.TABLE_DECISIONS).
+			Set("case_id", targetCaseId).
+			Where(squirrel.Eq{"org_id": organizationId, "case_id": sourceCaseId}),
+		NewQueryBuilder().Update(dbmodels.TABLE_CASE_COMMENTS).
+			Set("case_id", targetCaseId).
+			Where(squirrel.Eq{"case_id": sourceCaseId}),
+		NewQueryBuilder().Update(dbmodels.TABLE_CASE_FILES).
+			Set("case_id", targetCaseId).
+			Where(squirrel.Eq{"case_id": sourceCaseId}),
+		NewQueryBuilder().Update(dbmodels.TABLE_ENTITY_ANNOTATIONS).
+			Set("case_id", targetCaseId).
+			Where(squirrel.Eq{"org_id": organizationId, "case_id": sourceCaseId}),
+		NewQueryBuilder().Update(dbmodels.TABLE_SUSPICIOUS_ACTIVITY_REPORTS).
+			Set("case_id", targetCaseId).
+			Where(squirrel.Eq{"case_id": sourceCaseId}),
+	}
+	for _, query := range moves {
+		if err := ExecBuilder(ctx, exec, query); err != nil {
+			return err
+		}
+	}
+
+	eventTypes := make([]string, len(mergedCaseEventTypes))
+	for i, eventType := range mergedCaseEventTypes {
+		eventTypes[i] = string(eventType)
+	}
+	// the copies have no transaction id, so that they are not notified again
+	_, err := exec.Exec(ctx, fmt.Sprintf(`insert into %s
+			(case_id, user_id, created_at, event_type, additional_note, resource_id, resource_type, new_value, previous_value,
+			txid)
+		select $1, user_id, created_at, event_type, additional_note, resource_id, resource_type, new_value, previous_value,
+			null
+		from %s where case_id = $2 and event_type = any($3)`, dbmodels.TABLE_CASE_EVENTS, dbmodels.TABLE_CASE_EVENTS),
+		targetCaseId, sourceCaseId, eventTypes)
+	if err != nil {
+		return err
+	}
+
+	copies := []string{
+		fmt.Sprintf(`insert into %s (case_id, tag_id)
+			select $1, tag_id from %s where case_id = $2 and deleted_at is null
+			on conflict do nothing`, dbmodels.TABLE_CASE_TAGS, dbmodels.TABLE_CASE_TAGS),
+		fmt.Sprintf(`insert into %s (case_id, user_id)
+			select $1, user_id from %s where case_id = $2
+			on conflict do nothing`, dbmodels.TABLE_CASE_CONTRIBUTORS, dbmodels.TABLE_CASE_CONTRIBUTORS),
+	}
+	for _, query := range copies {
+		if _, err := exec.Exec(ctx, query, targetCaseId, sourceCaseId); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CloseMergedCase closes a case that was merged into another case, and links it to the target case
+func (repo *MarbleDbRepository) CloseMergedCase(ctx context.Context, exec Executor, caseId, targetCaseId string) error {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return err
+	}
+
+	return ExecBuilder(ctx, exec, NewQueryBuilder().
+		Update(dbmodels.TABLE_CASES).
+		SetMap(map[string]any{
+			"status":         models.CaseClosed,
+			"merged_into_id": targetCaseId,
+			"snoozed_until":  nil,
+			"boost":          nil,
+		}).
+		Where(squirrel.Eq{"id": caseId}))
+}
@@ -0,0 +1,190 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/repositories/dbmodels"
+)
+
+// caseMetricsEvents selects the case events of the period, of the cases that are currently in the inboxes of the
+// filters
+func caseMetricsEvents(filters models.CaseMetricsFilters) squirrel.SelectBuilder {
+	return NewQueryBuilder().
+		Select().
+		From(dbmodels.TABLE_CASE_EVENTS + " as e").
+		InnerJoin(dbmodels.TABLE_CASES + " as c on c.id = e.case_id").
+		Where(squirrel.Eq{"c.org_id": filters.OrganizationId, "c.inbox_id": filters.InboxIds}).
+		Where(squirrel.GtOrEq{"e.created_at": filters.StartDate}).
+		Where(squirrel.Lt{"e.created_at": filters.EndDate})
+}
+
+var caseClosedEvents = squirrel.Eq{
+	"e.event_type": models.CaseStatusUpdated,
+	"e.new_value":  string(models.CaseClosed),
+}
+
+func (repo *MarbleDbRepository) CaseVolumeMetrics(ctx context.Context, exec Executor,
+	filters models.CaseMetricsFilters,
+) ([]models.CaseVolumeMetric, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	timezone := filters.Timezone
+	if timezone == "" {
+		timezone = "UTC"
+	}
+	sql := caseMetricsEvents(filters).
+		Column(squirrel.Expr("(date_trunc('day', e.created_at at time zone ?))::date as day", timezone)).
+		Column(fmt.Sprintf("count(*) filter (where e.event_type = '%s') as nb_opened", models.CaseCreated)).
+		Column(fmt.Sprintf("count(*) filter (where e.event_type = '%s') as nb_closed", models.CaseStatusUpdated)).
+		Where(squirrel.Or{squirrel.Eq{"e.event_type": models.CaseCreated}, caseClosedEvents}).
+		GroupBy("day").
+		OrderBy("day")
+
+	return SqlToListOfModels(ctx, exec, sql, dbmodels.AdaptCaseVolumeMetric)
+}
+
+// CaseTimeToCloseMetrics returns the median time to close of the cases closed in the period, by inbox or by the analyst
+// who closed them
+func (repo *MarbleDbRepository) CaseTimeToCloseMetrics(ctx context.Context, exec Executor,
+	filters models.CaseMetricsFilters, byAnalyst bool,
+) ([]models.CaseTimeToCloseMetric, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	groupColumn := "c.inbox_id::text"
+	if byAnalyst {
+		groupColumn = "e.user_id::text"
+	}
+	sql := caseMetricsEvents(filters).
+		Columns(
+			groupColumn+" as group_id",
+			"count(*) as nb_closed",
+			"percentile_cont(0.5) within group (order by extract(epoch from e.created_at - c.created_at)) "+
+				"as median_time_to_close_seconds",
+		).
+		Where(caseClosedEvents).
+		GroupBy(groupColumn).
+		OrderBy(groupColumn)
+	if byAnalyst {
+		sql = sql.Where(squirrel.NotEq{"e.user_id": nil})
+	}
+
+	return SqlToListOfModels(ctx, exec, sql, dbmodels.AdaptCaseTimeToCloseMetric)
+}
+
+// CaseBacklogAgeMetrics counts the cases of the inboxes that are currently open, by age bucket
+func (repo *MarbleDbRepository) CaseBacklogAgeMetrics(ctx context.Context, exec Executor,
+	filters models.CaseMetricsFilters,
+) ([]models.CaseBacklogAgeMetric, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	var bucket strings.Builder
+	args := make([]any, 0, len(models.CaseBacklogAgeBuckets))
+	bucket.WriteString("case")
+	for i, b := range models.CaseBacklogAgeBuckets[:len(models.CaseBacklogAgeBuckets)-1] {
+		fmt.Fprintf(&bucket, " when c.created_at > now() - make_interval(secs => ?) then %d", i)
+		args = append(args, b.MaxAge.Seconds())
+	}
+	fmt.Fprintf(&bucket, " else %d end as bucket", len(models.CaseBacklogAgeBuckets)-1)
+
+	sql := NewQueryBuilder().
+		Select().
+		Column(squirrel.Expr(bucket.String(), args...)).
+		Column("count(*) as nb_cases").
+		From(dbmodels.TABLE_CASES + " as c").
+		Where(squirrel.Eq{"c.org_id": filters.OrganizationId, "c.inbox_id": filters.InboxIds}).
+		Where(squirrel.NotEq{"c.status": models.FinalizedCaseStatuses}).
+		GroupBy("bucket")
+
+	counts, err := SqlToListOfModels(ctx, exec, sql, dbmodels.AdaptCaseBacklogAgeMetric)
+	if err != nil {
+		return nil, err
+	}
+
+	metrics := make([]models.CaseBacklogAgeMetric, len(models.CaseBacklogAgeBuckets))
+	for i, b := range models.CaseBacklogAgeBuckets {
+		metrics[i].Bucket = b.Label
+		for _, count := range counts {
+			if count.Bucket == b.Label {
+				metrics[i].NbCases = count.NbCases
+			}
+		}
+	}
+	return metrics, nil
+}
+
+// CaseOutcomeMetrics counts the cases closed in the period by their current outcome
+func (repo *MarbleDbRepository) CaseOutcomeMetrics(ctx context.Context, exec Executor,
+	filters models.CaseMetricsFilters,
+) ([]models.CaseOutcomeMetric, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	sql := caseMetricsEvents(filters).
+		Columns(
+			fmt.Sprintf("coalesce(c.outcome, '%s') as outcome", models.CaseOutcomeUnset),
+			"count(distinct c.id) as nb_cases",
+		).
+		Where(caseClosedEvents).
+		GroupBy("1").
+		OrderBy("1")
+
+	return SqlToListOfModels(ctx, exec, sql, dbmodels.AdaptCaseOutcomeMetric)
+}
+
+// CaseEscalationMetrics counts, for each inbox, the cases closed and the cases escalated out of the inbox in the period
+func (repo *MarbleDbRepository) CaseEscalationMetrics(ctx context.Context, exec Executor,
+	filters models.CaseMetricsFilters,
+) ([]models.CaseEscalationMetric, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	closed, err := SqlToListOfModels(ctx, exec, caseMetricsEvents(filters).
+		Columns("c.inbox_id::text as inbox_id", "count(*) as nb_closed", "0 as nb_escalated").
+		Where(caseClosedEvents).
+		GroupBy("c.inbox_id"), dbmodels.AdaptCaseEscalationMetric)
+	if err != nil {
+		return nil, err
+	}
+
+	// the inbox that escalated a case is the previous value of the event, the case is now in another inbox
+	escalated, err := SqlToListOfModels(ctx, exec, NewQueryBuilder().
+		Select("e.previous_value as inbox_id", "0 as nb_closed", "count(*) as nb_escalated").
+		From(dbmodels.TABLE_CASE_EVENTS+" as e").
+		InnerJoin(dbmodels.TABLE_CASES+" as c on c.id = e.case_id").
+		Where(squirrel.Eq{
+			"c.org_id":         filters.OrganizationId,
+			"e.event_type":     models.CaseEscalated,
+			"e.previous_value": filters.InboxIds,
+		}).
+		Where(squirrel.GtOrEq{"e.created_at": filters.StartDate}).
+		Where(squirrel.Lt{"e.created_at": filters.EndDate}).
+		GroupBy("e.previous_value"), dbmodels.AdaptCaseEscalationMetric)
+	if err != nil {
+		return nil, err
+	}
+
+	metrics := closed
+	for _, e := range escalated {
+		i := slices.IndexFunc(metrics, func(m models.CaseEscalationMetric) bool { return m.InboxId == e.InboxId })
+		if i < 0 {
+			metrics = append(metrics, e)
+			continue
+		}
+		metrics[i].NbEscalated = e.NbEscalated
+	}
+	slices.SortFunc(metrics, func(a, b models.CaseEscalationMetric) int { return strings.Compare(a.InboxId, b.InboxId) })
+	return metrics, nil
+}
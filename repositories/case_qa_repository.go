@@ -0,0 +1,271 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/repositories/dbmodels"
+	"github.com/jackc/pgx/v5"
+)
+
+// caseQaReviewsListLimit caps the number of QA reviews returned by a list
+const caseQaReviewsListLimit = 500
+
+func (repo *MarbleDbRepository) ListInboxQaRules(ctx context.Context, exec Executor,
+	inboxId string,
+) ([]models.InboxQaRule, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	sql := NewQueryBuilder().
+		Select(dbmodels.SelectInboxQaRuleColumns...).
+		From(dbmodels.TABLE_INBOX_QA_RULES).
+		Where(squirrel.Eq{"inbox_id": inboxId}).
+		OrderBy("priority, created_at, id")
+
+	return SqlToListOfModels(ctx, exec, sql, dbmodels.AdaptInboxQaRule)
+}
+
+func (repo *MarbleDbRepository) GetInboxQaRule(ctx context.Context, exec Executor, id string) (models.InboxQaRule, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return models.InboxQaRule{}, err
+	}
+
+	sql := NewQueryBuilder().
+		Select(dbmodels.SelectInboxQaRuleColumns...).
+		From(dbmodels.TABLE_INBOX_QA_RULES).
+		Where(squirrel.Eq{"id": id})
+
+	return SqlToModel(ctx, exec, sql, dbmodels.AdaptInboxQaRule)
+}
+
+func (repo *MarbleDbRepository) CreateInboxQaRule(ctx context.Context, exec Executor,
+	organizationId, inboxId string, input models.InboxQaRuleInput,
+) (models.InboxQaRule, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return models.InboxQaRule{}, err
+	}
+
+	sql := NewQueryBuilder().
+		Insert(dbmodels.TABLE_INBOX_QA_RULES).
+		Columns(
+			"org_id",
+			"inbox_id",
+			"name",
+			"priority",
+			"case_outcome",
+			"tag_id",
+			"sample_rate",
+			"reviewer_ids",
+		).
+		Values(
+			organizationId,
+			inboxId,
+			input.Name,
+			input.Priority,
+			input.CaseOutcome,
+			input.TagId,
+			input.SampleRate,
+			input.ReviewerIds,
+		).
+		Suffix(fmt.Sprintf("RETURNING %s", strings.Join(dbmodels.SelectInboxQaRuleColumns, ",")))
+
+	return SqlToModel(ctx, exec, sql, dbmodels.AdaptInboxQaRule)
+}
+
+func (repo *MarbleDbRepository) UpdateInboxQaRule(ctx context.Context, exec Executor,
+	id string, input models.InboxQaRuleInput,
+) (models.InboxQaRule, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return models.InboxQaRule{}, err
+	}
+
+	sql := NewQueryBuilder().
+		Update(dbmodels.TABLE_INBOX_QA_RULES).
+		Set("name", input.Name).
+		Set("priority", input.Priority).
+		Set("case_outcome", input.CaseOutcome).
+		Set("tag_id", input.TagId).
+		Set("sample_rate", input.SampleRate).
+		Set("reviewer_ids", input.ReviewerIds).
+		Set("updated_at", squirrel.Expr("now()")).
+		Where(squirrel.Eq{"id": id}).
+		Suffix(fmt.Sprintf("RETURNING %s", strings.Join(dbmodels.SelectInboxQaRuleColumns, ",")))
+
+	return SqlToModel(ctx, exec, sql, dbmodels.AdaptInboxQaRule)
+}
+
+func (repo *MarbleDbRepository) DeleteInboxQaRule(ctx context.Context, exec Executor, id string) error {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return err
+	}
+
+	return ExecBuilder(ctx, exec, NewQueryBuilder().
+		Delete(dbmodels.TABLE_INBOX_QA_RULES).
+		Where(squirrel.Eq{"id": id}))
+}
+
+func (repo *MarbleDbRepository) CreateCaseQaReview(ctx context.Context, exec Executor,
+	input models.CaseQaReviewCreate,
+) (models.CaseQaReview, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return models.CaseQaReview{}, err
+	}
+
+	sql := NewQueryBuilder().
+		Insert(dbmodels.TABLE_CASE_QA_REVIEWS).
+		Columns(
+			"org_id",
+			"inbox_id",
+			"case_id",
+			"rule_id",
+			"analyst_id",
+			"reviewer_id",
+			"case_outcome",
+			"status",
+		).
+		Values(
+			input.OrganizationId,
+			input.InboxId,
+			input.CaseId,
+			input.RuleId,
+			input.AnalystId,
+			input.ReviewerId,
+			input.CaseOutcome,
+			models.CaseQaReviewPending,
+		).
+		Suffix(fmt.Sprintf("RETURNING %s", strings.Join(dbmodels.SelectCaseQaReviewColumns, ",")))
+
+	return SqlToModel(ctx, exec, sql, dbmodels.AdaptCaseQaReview)
+}
+
+func (repo *MarbleDbRepository) GetCaseQaReview(ctx context.Context, exec Executor, id string) (models.CaseQaReview, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return models.CaseQaReview{}, err
+	}
+
+	sql := NewQueryBuilder().
+		Select(dbmodels.SelectCaseQaReviewColumns...).
+		From(dbmodels.TABLE_CASE_QA_REVIEWS).
+		Where(squirrel.Eq{"id": id})
+
+	return SqlToModel(ctx, exec, sql, dbmodels.AdaptCaseQaReview)
+}
+
+// ListCaseQaReviews returns the latest QA reviews that match the filters
+func (repo *MarbleDbRepository) ListCaseQaReviews(ctx context.Context, exec Executor,
+	filters models.CaseQaReviewFilters,
+) ([]models.CaseQaReview, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	sql := NewQueryBuilder().
+		Select(dbmodels.SelectCaseQaReviewColumns...).
+		From(dbmodels.TABLE_CASE_QA_REVIEWS).
+		Where(squirrel.Eq{"org_id": filters.OrganizationId}).
+		OrderBy("created_at desc, id").
+		Limit(caseQaReviewsListLimit)
+	if filters.ReviewerId != nil {
+		sql = sql.Where(squirrel.Eq{"reviewer_id": *filters.ReviewerId})
+	}
+	if filters.InboxId != nil {
+		sql = sql.Where(squirrel.Eq{"inbox_id": *filters.InboxId})
+	}
+	if filters.CaseId != nil {
+		sql = sql.Where(squirrel.Eq{"case_id": *filters.CaseId})
+	}
+	if filters.Status != nil {
+		sql = sql.Where(squirrel.Eq{"status": *filters.Status})
+	}
+
+	return SqlToListOfModels(ctx, exec, sql, dbmodels.AdaptCaseQaReview)
+}
+
+// CompleteCaseQaReview records the verdict of a pending QA review. It returns nil if the review is not pending anymore.
+func (repo *MarbleDbRepository) CompleteCaseQaReview(ctx context.Context, exec Executor,
+	id string, input models.CaseQaReviewCompletion,
+) (*models.CaseQaReview, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	sql := NewQueryBuilder().
+		Update(dbmodels.TABLE_CASE_QA_REVIEWS).
+		Set("status", models.CaseQaReviewCompleted).
+		Set("verdict", input.Verdict).
+		Set("reviewer_outcome", input.ReviewerOutcome).
+		Set("comment", input.Comment).
+		Set("completed_at", squirrel.Expr("now()")).
+		Where(squirrel.Eq{"id": id, "status": models.CaseQaReviewPending}).
+		Suffix(fmt.Sprintf("RETURNING %s", strings.Join(dbmodels.SelectCaseQaReviewColumns, ",")))
+
+	return SqlToOptionalModel(ctx, exec, sql, dbmodels.AdaptCaseQaReview)
+}
+
+// CountPendingCaseQaReviews counts the pending QA reviews of each of the reviewers
+func (repo *MarbleDbRepository) CountPendingCaseQaReviews(ctx context.Context, exec Executor,
+	reviewerIds []string,
+) (map[string]int, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	sql, args, err := NewQueryBuilder().
+		Select("reviewer_id", "count(*)").
+		From(dbmodels.TABLE_CASE_QA_REVIEWS).
+		Where(squirrel.Eq{"reviewer_id": reviewerIds, "status": models.CaseQaReviewPending}).
+		GroupBy("reviewer_id").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+	rows, err := exec.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int)
+	var reviewerId string
+	var count int
+	_, err = pgx.ForEachRow(rows, []any{&reviewerId, &count}, func() error {
+		counts[reviewerId] = count
+		return nil
+	})
+
+	return counts, err
+}
+
+// ListCaseQaScores returns the results of the QA reviews completed in a period, for each analyst whose cases were
+// reviewed
+func (repo *MarbleDbRepository) ListCaseQaScores(ctx context.Context, exec Executor,
+	filters models.CaseQaScoreFilters,
+) ([]models.CaseQaScore, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	sql := NewQueryBuilder().
+		Select(
+			"analyst_id",
+			"count(*) as nb_reviews",
+			fmt.Sprintf("count(*) filter (where verdict = '%s') as nb_agreements", models.CaseQaAgree),
+			fmt.Sprintf("count(*) filter (where verdict = '%s') as nb_disagreements", models.CaseQaDisagree),
+		).
+		From(dbmodels.TABLE_CASE_QA_REVIEWS).
+		Where(squirrel.Eq{"org_id": filters.OrganizationId, "status": models.CaseQaReviewCompleted}).
+		Where(squirrel.NotEq{"analyst_id": nil}).
+		Where(squirrel.GtOrEq{"completed_at": filters.StartDate}).
+		Where(squirrel.Lt{"completed_at": filters.EndDate}).
+		GroupBy("analyst_id").
+		OrderBy("analyst_id")
+	if filters.InboxId != nil {
+		sql = sql.Where(squirrel.Eq{"inbox_id": *filters.InboxId})
+	}
+
+	return SqlToListOfModels(ctx, exec, sql, dbmodels.AdaptCaseQaScore)
+}
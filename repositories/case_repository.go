@@ -9,6 +9,7 @@ import (
 	"github.com/Masterminds/squirrel"
 	"github.com/cockroachdb/errors"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 
 	"github.com/checkmarble/marble-backend/models"
 	"github.com/checkmarble/marble-backend/repositories/dbmodels"
@@ -340,9 +341,106 @@ func applyCaseFilters(query squirrel.SelectBuilder, filters models.CaseFilters)
 	if filters.AssigneeId != "" {
 		query = query.Where(squirrel.Eq{"assigned_to": filters.AssigneeId})
 	}
+	if filters.SlaBreached != nil {
+		if *filters.SlaBreached {
+			query = query.Where(squirrel.NotEq{"c.sla_breached_at": nil})
+		} else {
+			query = query.Where(squirrel.Eq{"c.sla_breached_at": nil})
+		}
+	}
+	if !filters.DueBefore.IsZero() {
+		query = query.Where(squirrel.Lt{"c.due_at": filters.DueBefore})
+	}
+	if filters.Query != "" {
+		query = query.Where(caseSearchCondition(filters.Query))
+	}
+	if len(filters.TagIds) > 0 {
+		query = query.Where("exists (?)", squirrel.
+			Select("1").
+			From(dbmodels.TABLE_CASE_TAGS+" AS ct").
+			Where("ct.case_id = c.id").
+			Where("ct.deleted_at is null").
+			Where(squirrel.Eq{"ct.tag_id": filters.TagIds}))
+	}
+	if len(filters.Outcomes) > 0 {
+		query = query.Where(squirrel.Eq{"c.outcome": filters.Outcomes})
+	}
+	if len(filters.ScenarioIds) > 0 {
+		query = query.Where("exists (?)", squirrel.
+			Select("1").
+			From(dbmodels.TABLE_DECISIONS+" AS d").
+			Where("d.org_id = c.org_id").
+			Where("d.case_id = c.id").
+			Where(squirrel.Eq{"d.scenario_id": filters.ScenarioIds}))
+	}
+	if len(filters.RuleIds) > 0 {
+		query = query.Where("exists (?)", caseTriggeredRulesQuery(filters.RuleIds))
+	}
+	if filters.MinScore != nil || filters.MaxScore != nil {
+		maxScore := fmt.Sprintf("(select max(d.score) from %s as d where d.org_id = c.org_id and d.case_id = c.id)",
+			dbmodels.TABLE_DECISIONS)
+		if filters.MinScore != nil {
+			query = query.Where(maxScore+" >= ?", *filters.MinScore)
+		}
+		if filters.MaxScore != nil {
+			query = query.Where(maxScore+" <= ?", *filters.MaxScore)
+		}
+	}
+	switch filters.SlaStatus {
+	case models.CaseSlaStatusNone:
+		query = query.Where("c.sla_breached_at is null and c.due_at = 'infinity'")
+	case models.CaseSlaStatusOnTrack:
+		query = query.Where("c.sla_breached_at is null and c.due_at > ? and c.due_at <> 'infinity'", time.Now())
+	case models.CaseSlaStatusOverdue:
+		query = query.Where("c.sla_breached_at is null and c.due_at <= ?", time.Now())
+	case models.CaseSlaStatusBreached:
+		query = query.Where("c.sla_breached_at is not null")
+	}
 	return query
 }
 
+// caseSearchCondition matches the cases whose name is similar to the search, or whose name or comments contain its
+// words, or that have a decision on the searched pivot value
+func caseSearchCondition(search string) squirrel.Sqlizer {
+	return squirrel.Or{
+		squirrel.Expr("c.name % ?", search),
+		squirrel.Expr("to_tsvector('simple', c.name) @@ websearch_to_tsquery('simple', ?)", search),
+		squirrel.Expr("exists (?)", squirrel.
+			Select("1").
+			From(dbmodels.TABLE_CASE_COMMENTS+" AS cm").
+			Where("cm.case_id = c.id").
+			Where("cm.deleted_at is null").
+			Where("to_tsvector('simple', cm.body) @@ websearch_to_tsquery('simple', ?)", search)),
+		squirrel.Expr("exists (?)", squirrel.
+			Select("1").
+			From(dbmodels.TABLE_DECISIONS+" AS d").
+			Where("d.org_id = c.org_id").
+			Where("d.case_id = c.id").
+			Where(squirrel.Eq{"d.pivot_value": search})),
+	}
+}
+
+// caseTriggeredRulesQuery selects the decisions of a case on which one of the rules was triggered. The rules are
+// matched on their stable id, so that the executions of the other versions of a rule are matched too.
+func caseTriggeredRulesQuery(ruleIds []string) squirrel.SelectBuilder {
+	return squirrel.
+		Select("1").
+		From(dbmodels.TABLE_DECISIONS + " AS d").
+		Join(dbmodels.TABLE_DECISION_RULES + " AS dr ON dr.decision_id = d.id").
+		Join(dbmodels.TABLE_RULES + " AS r ON r.id = dr.rule_id").
+		Where("d.org_id = c.org_id").
+		Where("d.case_id = c.id").
+		Where("dr.result").
+		Where(squirrel.Or{
+			squirrel.Eq{"r.id": ruleIds},
+			squirrel.Expr("r.stable_rule_id in (?)", squirrel.
+				Select("sr.stable_rule_id").
+				From(dbmodels.TABLE_RULES+" AS sr").
+				Where("sr.stable_rule_id is not null").
+				Where(squirrel.Eq{"sr.id": ruleIds})),
+		})
+}
+
 func applyCasesPagination(query squirrel.SelectBuilder, p models.PaginationAndSorting, offsetCase models.Case) (squirrel.SelectBuilder, error) {
 	if p.OffsetId == "" {
 		return query, nil
@@ -352,8 +450,14 @@ func applyCasesPagination(query squirrel.SelectBuilder, p models.PaginationAndSo
 	switch p.Sorting {
 	case models.CasesSortingCreatedAt:
 		offsetFieldVal = offsetCase.CreatedAt
+	case models.CasesSortingDueAt:
+		// the cases without a deadline have a due_at of 'infinity'
+		offsetFieldVal = pgtype.Timestamptz{InfinityModifier: pgtype.Infinity, Valid: true}
+		if offsetCase.DueAt != nil {
+			offsetFieldVal = *offsetCase.DueAt
+		}
 	default:
-		// only pagination by created_at is allowed for now
+		// only pagination by created_at and due_at is allowed for now
 		return query, fmt.Errorf("invalid sorting field: %w", models.BadParameterError)
 	}
 
@@ -411,6 +515,7 @@ func (repo *MarbleDbRepository) CreateDbCaseFile(ctx context.Context, exec Execu
 				"case_id",
 				"file_name",
 				"file_reference",
+				"scan_status",
 			).
 			Values(
 				createCaseFileAttributes.Id,
@@ -418,6 +523,7 @@ func (repo *MarbleDbRepository) CreateDbCaseFile(ctx context.Context, exec Execu
 				createCaseFileAttributes.CaseId,
 				createCaseFileAttributes.FileName,
 				createCaseFileAttributes.FileReference,
+				createCaseFileAttributes.ScanStatus,
 			),
 	)
 	return err
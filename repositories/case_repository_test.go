@@ -0,0 +1,34 @@
+package repositories
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCaseSearchCondition(t *testing.T) {
+	sql, args, err := caseSearchCondition("acme").ToSql()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "(c.name % ? "+
+		"OR to_tsvector('simple', c.name) @@ websearch_to_tsquery('simple', ?) "+
+		"OR exists (SELECT 1 FROM case_comments AS cm WHERE cm.case_id = c.id AND cm.deleted_at is null "+
+		"AND to_tsvector('simple', cm.body) @@ websearch_to_tsquery('simple', ?)) "+
+		"OR exists (SELECT 1 FROM decisions AS d WHERE d.org_id = c.org_id AND d.case_id = c.id AND d.pivot_value = ?))",
+		sql)
+	assert.Equal(t, []any{"acme", "acme", "acme", "acme"}, args)
+}
+
+func TestCaseTriggeredRulesQuery(t *testing.T) {
+	sql, args, err := caseTriggeredRulesQuery([]string{"rule-1", "rule-2"}).ToSql()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT 1 FROM decisions AS d "+
+		"JOIN decision_rules AS dr ON dr.decision_id = d.id "+
+		"JOIN scenario_iteration_rules AS r ON r.id = dr.rule_id "+
+		"WHERE d.org_id = c.org_id AND d.case_id = c.id AND dr.result "+
+		"AND (r.id IN (?,?) OR r.stable_rule_id in (SELECT sr.stable_rule_id FROM scenario_iteration_rules AS sr "+
+		"WHERE sr.stable_rule_id is not null AND sr.id IN (?,?)))",
+		sql)
+	assert.Equal(t, []any{"rule-1", "rule-2", "rule-1", "rule-2"}, args)
+}
@@ -0,0 +1,59 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/repositories/dbmodels"
+)
+
+func (repo *MarbleDbRepository) ListCaseRoutingRules(ctx context.Context, exec Executor,
+	scenarioId string,
+) ([]models.CaseRoutingRule, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	sql := NewQueryBuilder().
+		Select(dbmodels.SelectCaseRoutingRuleColumns...).
+		From(dbmodels.TABLE_CASE_ROUTING_RULES).
+		Where(squirrel.Eq{"scenario_id": scenarioId}).
+		OrderBy("priority")
+
+	return SqlToListOfModels(ctx, exec, sql, dbmodels.AdaptCaseRoutingRule)
+}
+
+// ReplaceCaseRoutingRules replaces the routing rules of a scenario, their priority is their position in the list
+func (repo *MarbleDbRepository) ReplaceCaseRoutingRules(ctx context.Context, exec Executor,
+	organizationId, scenarioId string, rules []models.CaseRoutingRuleInput,
+) error {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return err
+	}
+
+	err := ExecBuilder(ctx, exec, NewQueryBuilder().
+		Delete(dbmodels.TABLE_CASE_ROUTING_RULES).
+		Where(squirrel.Eq{"scenario_id": scenarioId}))
+	if err != nil || len(rules) == 0 {
+		return err
+	}
+
+	sql := NewQueryBuilder().
+		Insert(dbmodels.TABLE_CASE_ROUTING_RULES).
+		Columns("org_id", "scenario_id", "priority", "name", "formula", "inbox_id", "tag_ids")
+
+	for i, rule := range rules {
+		formula, err := dbmodels.SerializeFormulaAstExpression(rule.Formula)
+		if err != nil {
+			return err
+		}
+		tagIds := rule.TagIds
+		if tagIds == nil {
+			tagIds = []string{}
+		}
+		sql = sql.Values(organizationId, scenarioId, i, rule.Name, formula, rule.InboxId, tagIds)
+	}
+
+	return ExecBuilder(ctx, exec, sql)
+}
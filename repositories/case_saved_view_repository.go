@@ -0,0 +1,136 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/repositories/dbmodels"
+	"github.com/cockroachdb/errors"
+)
+
+func (repo *MarbleDbRepository) CreateCaseSavedView(ctx context.Context, exec Executor,
+	input models.CaseSavedViewInput,
+) (models.CaseSavedView, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return models.CaseSavedView{}, err
+	}
+
+	filters, err := json.Marshal(input.Filters)
+	if err != nil {
+		return models.CaseSavedView{}, errors.Wrap(err, "could not marshal the filters of the case view")
+	}
+
+	sql := NewQueryBuilder().
+		Insert(dbmodels.TABLE_CASE_SAVED_VIEWS).
+		Columns(
+			"org_id",
+			"owner_id",
+			"inbox_id",
+			"name",
+			"filters",
+			"sorting",
+			"sort_order",
+		).
+		Values(
+			input.OrganizationId,
+			input.OwnerId,
+			input.InboxId,
+			input.Name,
+			filters,
+			input.Sorting.String(),
+			input.Order.String(),
+		).
+		Suffix(fmt.Sprintf("RETURNING %s", strings.Join(dbmodels.SelectCaseSavedViewColumns, ",")))
+
+	return SqlToModel(ctx, exec, sql, dbmodels.AdaptCaseSavedView)
+}
+
+func (repo *MarbleDbRepository) GetCaseSavedViewById(ctx context.Context, exec Executor,
+	id string,
+) (models.CaseSavedView, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return models.CaseSavedView{}, err
+	}
+
+	sql := NewQueryBuilder().
+		Select(dbmodels.SelectCaseSavedViewColumns...).
+		From(dbmodels.TABLE_CASE_SAVED_VIEWS).
+		Where(squirrel.Eq{"id": id})
+
+	return SqlToModel(ctx, exec, sql, dbmodels.AdaptCaseSavedView)
+}
+
+// ListCaseSavedViews returns the views of a user, and the views shared with the given inboxes
+func (repo *MarbleDbRepository) ListCaseSavedViews(ctx context.Context, exec Executor,
+	organizationId, userId string, inboxIds []string,
+) ([]models.CaseSavedView, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	sql := NewQueryBuilder().
+		Select(dbmodels.SelectCaseSavedViewColumns...).
+		From(dbmodels.TABLE_CASE_SAVED_VIEWS).
+		Where(squirrel.Eq{"org_id": organizationId}).
+		Where(squirrel.Or{
+			squirrel.Eq{"owner_id": userId},
+			squirrel.Eq{"inbox_id": inboxIds},
+		}).
+		OrderBy("name", "created_at")
+
+	return SqlToListOfModels(ctx, exec, sql, dbmodels.AdaptCaseSavedView)
+}
+
+func (repo *MarbleDbRepository) UpdateCaseSavedView(ctx context.Context, exec Executor,
+	id string, input models.UpdateCaseSavedViewInput,
+) (models.CaseSavedView, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return models.CaseSavedView{}, err
+	}
+
+	sql := NewQueryBuilder().
+		Update(dbmodels.TABLE_CASE_SAVED_VIEWS).
+		Set("updated_at", squirrel.Expr("now()")).
+		Where(squirrel.Eq{"id": id}).
+		Suffix(fmt.Sprintf("RETURNING %s", strings.Join(dbmodels.SelectCaseSavedViewColumns, ",")))
+
+	if input.Name != nil {
+		sql = sql.Set("name", *input.Name)
+	}
+	if input.Filters != nil {
+		filters, err := json.Marshal(*input.Filters)
+		if err != nil {
+			return models.CaseSavedView{}, errors.Wrap(err, "could not marshal the filters of the case view")
+		}
+		sql = sql.Set("filters", filters)
+	}
+	if input.Sorting != nil {
+		sql = sql.Set("sorting", input.Sorting.String())
+	}
+	if input.Order != nil {
+		sql = sql.Set("sort_order", input.Order.String())
+	}
+	if input.InboxId != nil {
+		if *input.InboxId == "" {
+			sql = sql.Set("inbox_id", nil)
+		} else {
+			sql = sql.Set("inbox_id", *input.InboxId)
+		}
+	}
+
+	return SqlToModel(ctx, exec, sql, dbmodels.AdaptCaseSavedView)
+}
+
+func (repo *MarbleDbRepository) DeleteCaseSavedView(ctx context.Context, exec Executor, id string) error {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return err
+	}
+
+	return ExecBuilder(ctx, exec, NewQueryBuilder().
+		Delete(dbmodels.TABLE_CASE_SAVED_VIEWS).
+		Where(squirrel.Eq{"id": id}))
+}
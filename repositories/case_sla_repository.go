@@ -0,0 +1,231 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/repositories/dbmodels"
+)
+
+func (repo *MarbleDbRepository) ListInboxSlaPolicies(ctx context.Context, exec Executor,
+	inboxId string,
+) ([]models.InboxSlaPolicy, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	sql := NewQueryBuilder().
+		Select(dbmodels.SelectInboxSlaPolicyColumns...).
+		From(dbmodels.TABLE_INBOX_SLA_POLICIES).
+		Where(squirrel.Eq{"inbox_id": inboxId}).
+		OrderBy("priority, created_at, id")
+
+	return SqlToListOfModels(ctx, exec, sql, dbmodels.AdaptInboxSlaPolicy)
+}
+
+func (repo *MarbleDbRepository) GetInboxSlaPolicy(ctx context.Context, exec Executor,
+	id string,
+) (models.InboxSlaPolicy, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return models.InboxSlaPolicy{}, err
+	}
+
+	sql := NewQueryBuilder().
+		Select(dbmodels.SelectInboxSlaPolicyColumns...).
+		From(dbmodels.TABLE_INBOX_SLA_POLICIES).
+		Where(squirrel.Eq{"id": id})
+
+	return SqlToModel(ctx, exec, sql, dbmodels.AdaptInboxSlaPolicy)
+}
+
+func (repo *MarbleDbRepository) CreateInboxSlaPolicy(ctx context.Context, exec Executor,
+	organizationId, inboxId string, input models.InboxSlaPolicyInput,
+) (models.InboxSlaPolicy, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return models.InboxSlaPolicy{}, err
+	}
+
+	sql := NewQueryBuilder().
+		Insert(dbmodels.TABLE_INBOX_SLA_POLICIES).
+		Columns(
+			"org_id",
+			"inbox_id",
+			"name",
+			"priority",
+			"decision_outcome",
+			"tag_id",
+			"first_action_minutes",
+			"close_minutes",
+			"breach_action",
+		).
+		Values(
+			organizationId,
+			inboxId,
+			input.Name,
+			input.Priority,
+			slaPolicyOutcome(input.DecisionOutcome),
+			input.TagId,
+			input.FirstActionMinutes,
+			input.CloseMinutes,
+			input.BreachAction,
+		).
+		Suffix(fmt.Sprintf("RETURNING %s", strings.Join(dbmodels.SelectInboxSlaPolicyColumns, ",")))
+
+	return SqlToModel(ctx, exec, sql, dbmodels.AdaptInboxSlaPolicy)
+}
+
+func (repo *MarbleDbRepository) UpdateInboxSlaPolicy(ctx context.Context, exec Executor,
+	id string, input models.InboxSlaPolicyInput,
+) (models.InboxSlaPolicy, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return models.InboxSlaPolicy{}, err
+	}
+
+	sql := NewQueryBuilder().
+		Update(dbmodels.TABLE_INBOX_SLA_POLICIES).
+		Set("name", input.Name).
+		Set("priority", input.Priority).
+		Set("decision_outcome", slaPolicyOutcome(input.DecisionOutcome)).
+		Set("tag_id", input.TagId).
+		Set("first_action_minutes", input.FirstActionMinutes).
+		Set("close_minutes", input.CloseMinutes).
+		Set("breach_action", input.BreachAction).
+		Set("updated_at", squirrel.Expr("now()")).
+		Where(squirrel.Eq{"id": id}).
+		Suffix(fmt.Sprintf("RETURNING %s", strings.Join(dbmodels.SelectInboxSlaPolicyColumns, ",")))
+
+	return SqlToModel(ctx, exec, sql, dbmodels.AdaptInboxSlaPolicy)
+}
+
+func (repo *MarbleDbRepository) DeleteInboxSlaPolicy(ctx context.Context, exec Executor, id string) error {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return err
+	}
+
+	return ExecBuilder(ctx, exec, NewQueryBuilder().
+		Delete(dbmodels.TABLE_INBOX_SLA_POLICIES).
+		Where(squirrel.Eq{"id": id}))
+}
+
+func slaPolicyOutcome(outcome *models.Outcome) *string {
+	if outcome == nil {
+		return nil
+	}
+	o := outcome.String()
+	return &o
+}
+
+// RefreshCaseSlaDueDates sets the SLA policy and the deadlines of the open cases of an organization from the current
+// policies of their inbox. Breached cases are refreshed too, so that their deadlines follow the case when it changes
+// inbox or when the policy is edited. The deadlines that were already breached stay flagged, and are not breached again.
+func (repo *MarbleDbRepository) RefreshCaseSlaDueDates(ctx context.Context, exec Executor, organizationId string) error {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf(`
+		with matched as (
+			select c.id, p.id as policy_id,
+				c.created_at + make_interval(mins => p.first_action_minutes) as first_action_due_at,
+				c.created_at + make_interval(mins => p.close_minutes) as close_due_at
+			from %[1]s as c
+			left join lateral (
+				select p.id, p.first_action_minutes, p.close_minutes
+				from %[2]s as p
+				where p.inbox_id = c.inbox_id
+					and (p.tag_id is null or exists (
+						select 1 from %[3]s as ct
+						where ct.case_id = c.id and ct.tag_id = p.tag_id and ct.deleted_at is null))
+					and (p.decision_outcome is null or exists (
+						select 1 from %[4]s as d
+						where d.case_id = c.id and d.org_id = c.org_id and d.outcome = p.decision_outcome))
+				order by p.priority, p.created_at, p.id
+				limit 1
+			) as p on true
+			where c.org_id = $1
+				and c.status != all($2)
+		)
+		update %[1]s as c
+		set sla_policy_id = m.policy_id,
+			first_action_due_at = m.first_action_due_at,
+			close_due_at = m.close_due_at
+		from matched as m
+		where c.id = m.id
+			and (c.sla_policy_id, c.first_action_due_at, c.close_due_at)
+				is distinct from (m.policy_id, m.first_action_due_at, m.close_due_at)`,
+		dbmodels.TABLE_CASES,
+		dbmodels.TABLE_INBOX_SLA_POLICIES,
+		dbmodels.TABLE_CASE_TAGS,
+		dbmodels.TABLE_DECISIONS,
+	)
+
+	_, err := exec.Exec(ctx, query, organizationId, finalizedCaseStatuses())
+	return err
+}
+
+// ListCaseSlaBreaches returns the deadlines that open cases of an organization missed and that are not flagged as
+// breached yet, oldest deadline first. The first action deadline only applies while the case is pending, and a case
+// that missed it is listed again when it misses its close deadline.
+func (repo *MarbleDbRepository) ListCaseSlaBreaches(ctx context.Context, exec Executor,
+	organizationId string, limit int,
+) ([]models.CaseSlaBreach, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	breaches := func(kind models.SlaBreachKind, dueAtColumn, breachedAtColumn string) squirrel.SelectBuilder {
+		query := squirrel.
+			Select(
+				"c.id as case_id",
+				"c.org_id",
+				"c.inbox_id",
+				"p.id as policy_id",
+				fmt.Sprintf("'%s' as kind", kind),
+				fmt.Sprintf("c.%s as due_at", dueAtColumn),
+				"p.breach_action",
+			).
+			From(dbmodels.TABLE_CASES + " as c").
+			InnerJoin(dbmodels.TABLE_INBOX_SLA_POLICIES + " as p on p.id = c.sla_policy_id").
+			Where(squirrel.Eq{"c.org_id": organizationId, "c." + breachedAtColumn: nil}).
+			Where(fmt.Sprintf("c.%s < now()", dueAtColumn))
+		if kind == models.SlaBreachFirstAction {
+			return query.Where(squirrel.Eq{"c.status": models.CasePending})
+		}
+		return query.Where(squirrel.NotEq{"c.status": models.FinalizedCaseStatuses})
+	}
+
+	sql := NewQueryBuilder().
+		Select("*").
+		FromSelect(breaches(models.SlaBreachFirstAction, "first_action_due_at", "first_action_breached_at").
+			Suffix("union all ?", breaches(models.SlaBreachClose, "close_due_at", "close_breached_at")),
+			"breaches").
+		OrderBy("due_at, case_id, kind").
+		Limit(uint64(limit))
+
+	return SqlToListOfModels(ctx, exec, sql, dbmodels.AdaptCaseSlaBreach)
+}
+
+// FlagCaseSlaBreach marks a case as having breached one of its deadlines, and its SLA as breached if it was not yet. It
+// returns false if this deadline was already flagged.
+func (repo *MarbleDbRepository) FlagCaseSlaBreach(ctx context.Context, exec Executor,
+	caseId string, kind models.SlaBreachKind,
+) (bool, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return false, err
+	}
+
+	breachedAtColumn := "close_breached_at"
+	if kind == models.SlaBreachFirstAction {
+		breachedAtColumn = "first_action_breached_at"
+	}
+
+	rows, err := ExecBuilderRowsAffected(ctx, exec, NewQueryBuilder().
+		Update(dbmodels.TABLE_CASES).
+		Set(breachedAtColumn, squirrel.Expr("now()")).
+		Set("sla_breached_at", squirrel.Expr("coalesce(sla_breached_at, now())")).
+		Where(squirrel.Eq{"id": caseId, breachedAtColumn: nil}))
+	return rows > 0, err
+}
@@ -0,0 +1,144 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/repositories/dbmodels"
+)
+
+// ListChangeEvents returns the change events of an organization after the cursor, in feed order. Only events written
+// by transactions older than the oldest transaction still in progress are returned: a transaction that is still in
+// progress may commit events that sort before the ones that are already visible.
+func (repo *MarbleDbRepository) ListChangeEvents(ctx context.Context, exec Executor, orgId string,
+	after models.ChangeFeedCursor, limit int,
+) ([]models.ChangeEvent, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	sql := NewQueryBuilder().
+		Select(dbmodels.SelectChangeEventColumns...).
+		From(dbmodels.TABLE_CHANGE_EVENTS).
+		Where(squirrel.Eq{"org_id": orgId}).
+		Where("(txid, id) > (?::text::xid8, ?)", after.TxId, after.Id).
+		Where("txid < pg_snapshot_xmin(pg_current_snapshot())").
+		OrderBy("txid", "id").
+		Limit(uint64(limit))
+
+	return SqlToListOfModels(ctx, exec, sql, dbmodels.AdaptChangeEvent)
+}
+
+func (repo *MarbleDbRepository) DeleteChangeEventsBefore(ctx context.Context, exec Executor,
+	orgId string, before time.Time,
+) (int, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return 0, err
+	}
+
+	sql := NewQueryBuilder().
+		Delete(dbmodels.TABLE_CHANGE_EVENTS).
+		Where(squirrel.Eq{"org_id": orgId}).
+		Where(squirrel.Lt{"created_at": before})
+
+	return ExecBuilderRowsAffected(ctx, exec, sql)
+}
+
+// PseudonymiseChangeEventsForErasure replaces the personal data in the change events of erased decisions, and of their
+// sanction checks, that were not yet purged. It must run after the decisions are pseudonymised: their trigger object and
+// pivot value are copied into the events of the decision.
+func (repo *MarbleDbRepository) PseudonymiseChangeEventsForErasure(ctx context.Context, exec Executor,
+	orgId string, decisionIds []string,
+) (int, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return 0, err
+	}
+
+	decisionEvents := NewQueryBuilder().
+		Update(dbmodels.TABLE_CHANGE_EVENTS+" as ce").
+		Set("data", squirrel.Expr(fmt.Sprintf(`ce.data || (
+			select jsonb_build_object('trigger_object', d.trigger_object, 'pivot_value', d.pivot_value)
+			from %s as d
+			where d.id = ce.entity_id
+		)`, dbmodels.TABLE_DECISIONS))).
+		Where(squirrel.Eq{"ce.org_id": orgId, "ce.entity_type": "decision", "ce.entity_id": decisionIds})
+	nbDecisionEvents, err := ExecBuilderRowsAffected(ctx, exec, decisionEvents)
+	if err != nil {
+		return 0, err
+	}
+
+	sanctionCheckEvents := NewQueryBuilder().
+		Update(dbmodels.TABLE_CHANGE_EVENTS).
+		Set("data", squirrel.Expr(`data || '{"search_input": null}'::jsonb`)).
+		Where(squirrel.Eq{"org_id": orgId, "entity_type": "sanction_check"}).
+		Where("data->>'decision_id' = any(?)", decisionIds)
+	nbSanctionCheckEvents, err := ExecBuilderRowsAffected(ctx, exec, sanctionCheckEvents)
+	if err != nil {
+		return 0, err
+	}
+
+	return nbDecisionEvents + nbSanctionCheckEvents, nil
+}
+
+// GetChangeFeedConfig returns the change feed configuration of an organization, which is disabled if it was never set
+func (repo *MarbleDbRepository) GetChangeFeedConfig(ctx context.Context, exec Executor, orgId string) (models.ChangeFeedConfig, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return models.ChangeFeedConfig{}, err
+	}
+
+	sql := NewQueryBuilder().
+		Select(dbmodels.SelectChangeFeedConfigColumns...).
+		From(dbmodels.TABLE_CHANGE_FEED_CONFIGS).
+		Where(squirrel.Eq{"org_id": orgId})
+
+	config, err := SqlToOptionalModel(ctx, exec, sql, dbmodels.AdaptChangeFeedConfig)
+	if err != nil {
+		return models.ChangeFeedConfig{}, err
+	}
+	if config == nil {
+		return models.ChangeFeedConfig{OrgId: orgId}, nil
+	}
+	return *config, nil
+}
+
+func (repo *MarbleDbRepository) UpsertChangeFeedConfig(ctx context.Context, exec Executor,
+	input models.UpdateChangeFeedConfigInput,
+) (models.ChangeFeedConfig, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return models.ChangeFeedConfig{}, err
+	}
+
+	sql := NewQueryBuilder().
+		Insert(dbmodels.TABLE_CHANGE_FEED_CONFIGS).
+		Columns("org_id", "enabled", "sink_type", "sink_address", "sink_topic").
+		Values(input.OrgId, input.Enabled, input.SinkType, input.SinkAddress, input.SinkTopic).
+		Suffix(`on conflict (org_id) do update set
+			enabled = excluded.enabled,
+			sink_type = excluded.sink_type,
+			sink_address = excluded.sink_address,
+			sink_topic = excluded.sink_topic,
+			updated_at = now()`).
+		Suffix(fmt.Sprintf("RETURNING %s", strings.Join(dbmodels.SelectChangeFeedConfigColumns, ",")))
+
+	return SqlToModel(ctx, exec, sql, dbmodels.AdaptChangeFeedConfig)
+}
+
+func (repo *MarbleDbRepository) UpdateChangeFeedSinkCursor(ctx context.Context, exec Executor,
+	orgId string, cursor models.ChangeFeedCursor,
+) error {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return err
+	}
+
+	sql := NewQueryBuilder().
+		Update(dbmodels.TABLE_CHANGE_FEED_CONFIGS).
+		Set("sink_cursor_txid", squirrel.Expr("?::text::xid8", cursor.TxId)).
+		Set("sink_cursor_id", cursor.Id).
+		Where(squirrel.Eq{"org_id": orgId})
+
+	return ExecBuilder(ctx, exec, sql)
+}
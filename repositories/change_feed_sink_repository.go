@@ -0,0 +1,88 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/nats-io/nats.go"
+	"github.com/segmentio/kafka-go"
+
+	"github.com/checkmarble/marble-backend/dto"
+	"github.com/checkmarble/marble-backend/models"
+)
+
+// ChangeFeedSinkRepository pushes change events to the message broker configured by an organization. Events are
+// serialized as in the change feed endpoint.
+type ChangeFeedSinkRepository interface {
+	Publish(ctx context.Context, config models.ChangeFeedConfig, events []models.ChangeEvent) error
+}
+
+type changeFeedSinkRepository struct{}
+
+func NewChangeFeedSinkRepository() ChangeFeedSinkRepository {
+	return changeFeedSinkRepository{}
+}
+
+func (r changeFeedSinkRepository) Publish(ctx context.Context, config models.ChangeFeedConfig,
+	events []models.ChangeEvent,
+) error {
+	if config.SinkType == nil {
+		return errors.Wrap(models.BadParameterError, "no change feed sink is configured")
+	}
+	switch *config.SinkType {
+	case models.ChangeFeedSinkKafka:
+		return publishToKafka(ctx, config, events)
+	case models.ChangeFeedSinkNats:
+		return publishToNats(ctx, config, events)
+	}
+	return errors.Wrapf(models.BadParameterError, "unknown change feed sink type %s", *config.SinkType)
+}
+
+// Events are keyed by entity id, so that the changes of an entity are kept in order within a partition
+func publishToKafka(ctx context.Context, config models.ChangeFeedConfig, events []models.ChangeEvent) error {
+	writer := &kafka.Writer{
+		Addr:         kafka.TCP(strings.Split(config.SinkAddress, ",")...),
+		Topic:        config.SinkTopic,
+		Balancer:     &kafka.Hash{},
+		RequiredAcks: kafka.RequireAll,
+	}
+	defer writer.Close()
+
+	messages := make([]kafka.Message, len(events))
+	for i, event := range events {
+		value, err := json.Marshal(dto.AdaptChangeEventDto(event))
+		if err != nil {
+			return err
+		}
+		messages[i] = kafka.Message{Key: []byte(event.EntityId), Value: value}
+	}
+	if err := writer.WriteMessages(ctx, messages...); err != nil {
+		return errors.Wrap(err, "could not publish change events to kafka")
+	}
+	return nil
+}
+
+// Events are published on the subject "<topic>.<entity type>"
+func publishToNats(ctx context.Context, config models.ChangeFeedConfig, events []models.ChangeEvent) error {
+	conn, err := nats.Connect(config.SinkAddress)
+	if err != nil {
+		return errors.Wrap(err, "could not connect to nats")
+	}
+	defer conn.Close()
+
+	for _, event := range events {
+		value, err := json.Marshal(dto.AdaptChangeEventDto(event))
+		if err != nil {
+			return err
+		}
+		if err := conn.Publish(config.SinkTopic+"."+string(event.EntityType), value); err != nil {
+			return errors.Wrap(err, "could not publish change events to nats")
+		}
+	}
+	if err := conn.FlushWithContext(ctx); err != nil {
+		return errors.Wrap(err, "could not publish change events to nats")
+	}
+	return nil
+}
@@ -16,7 +16,8 @@ import (
 type DataModelRepository interface {
 	GetDataModel(ctx context.Context, exec Executor, organizationID string, fetchEnumValues bool) (models.DataModel, error)
 	CreateDataModelTable(ctx context.Context, exec Executor, organizationID, tableID, name, description string) error
-	UpdateDataModelTable(ctx context.Context, exec Executor, tableID, description string) error
+	UpdateDataModelTable(ctx context.Context, exec Executor, tableID, description string,
+		decisionTriggerMode *models.DecisionTriggerMode) error
 	GetDataModelTable(ctx context.Context, exec Executor, tableID string) (models.TableMetadata, error)
 	CreateDataModelField(ctx context.Context, exec Executor, fieldId string, field models.CreateFieldInput) error
 	UpdateDataModelField(
@@ -72,6 +73,11 @@ func (repo MarbleDbRepository) GetDataModel(
 	}
 
 	for _, field := range fields {
+		constraints, err := dbmodels.AdaptFieldConstraints(field.FieldConstraints)
+		if err != nil {
+			return models.DataModel{}, err
+		}
+
 		var values []any
 		if field.FieldIsEnum && fetchEnumValues {
 			values, err = repo.GetEnumValues(ctx, exec, field.FieldID)
@@ -83,11 +89,12 @@ func (repo MarbleDbRepository) GetDataModel(
 		_, ok := dataModel.Tables[field.TableName]
 		if !ok {
 			dataModel.Tables[field.TableName] = models.Table{
-				ID:            field.TableID,
-				Name:          field.TableName,
-				Description:   field.TableDescription,
-				Fields:        map[string]models.Field{},
-				LinksToSingle: make(map[string]models.LinkToSingle),
+				ID:                  field.TableID,
+				Name:                field.TableName,
+				Description:         field.TableDescription,
+				Fields:              map[string]models.Field{},
+				LinksToSingle:       make(map[string]models.LinkToSingle),
+				DecisionTriggerMode: models.DecisionTriggerModeFrom(field.TableTriggerMode),
 			}
 		}
 		dataModel.Tables[field.TableName].Fields[field.FieldName] = models.Field{
@@ -99,6 +106,7 @@ func (repo MarbleDbRepository) GetDataModel(
 			IsEnum:      field.FieldIsEnum,
 			TableId:     field.TableID,
 			Values:      values,
+			Constraints: constraints,
 		}
 
 	}
@@ -141,19 +149,25 @@ func (repo MarbleDbRepository) GetDataModelTable(ctx context.Context, exec Execu
 	)
 }
 
-func (repo MarbleDbRepository) UpdateDataModelTable(ctx context.Context, exec Executor, tableID, description string) error {
+func (repo MarbleDbRepository) UpdateDataModelTable(
+	ctx context.Context,
+	exec Executor,
+	tableID, description string,
+	decisionTriggerMode *models.DecisionTriggerMode,
+) error {
 	if err := validateMarbleDbExecutor(exec); err != nil {
 		return err
 	}
 
-	err := ExecBuilder(
-		ctx,
-		exec,
-		NewQueryBuilder().
-			Update(dbmodels.TableDataModelTables).
-			Set("description", description).
-			Where(squirrel.Eq{"id": tableID}),
-	)
+	query := NewQueryBuilder().
+		Update(dbmodels.TableDataModelTables).
+		Set("description", description).
+		Where(squirrel.Eq{"id": tableID})
+	if decisionTriggerMode != nil {
+		query = query.Set("decision_trigger_mode", *decisionTriggerMode)
+	}
+
+	err := ExecBuilder(ctx, exec, query)
 	return err
 }
 
@@ -167,12 +181,17 @@ func (repo MarbleDbRepository) CreateDataModelField(
 		return err
 	}
 
+	constraints, err := dbmodels.SerializeFieldConstraints(field.Constraints)
+	if err != nil {
+		return err
+	}
+
 	query := `
-		INSERT INTO data_model_fields (id, table_id, name, type, nullable, description, is_enum)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		INSERT INTO data_model_fields (id, table_id, name, type, nullable, description, is_enum, constraints)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 		RETURNING id`
 
-	_, err := exec.Exec(ctx,
+	_, err = exec.Exec(ctx,
 		query,
 		fieldId,
 		field.TableId,
@@ -181,6 +200,7 @@ func (repo MarbleDbRepository) CreateDataModelField(
 		field.Nullable,
 		field.Description,
 		field.IsEnum,
+		constraints,
 	)
 	if IsUniqueViolationError(err) {
 		return models.ConflictError
@@ -207,6 +227,13 @@ func (repo MarbleDbRepository) UpdateDataModelField(
 	if input.IsEnum != nil {
 		query = query.Set("is_enum", *input.IsEnum)
 	}
+	if input.Constraints != nil {
+		constraints, err := dbmodels.SerializeFieldConstraints(*input.Constraints)
+		if err != nil {
+			return err
+		}
+		query = query.Set("constraints", constraints)
+	}
 
 	err := ExecBuilder(
 		ctx,
@@ -281,12 +308,14 @@ func (repo MarbleDbRepository) getTablesAndFields(ctx context.Context, exec Exec
 			&dbModel.OrganizationID,
 			&dbModel.TableName,
 			&dbModel.TableDescription,
+			&dbModel.TableTriggerMode,
 			&dbModel.FieldID,
 			&dbModel.FieldName,
 			&dbModel.FieldType,
 			&dbModel.FieldNullable,
 			&dbModel.FieldDescription,
 			&dbModel.FieldIsEnum,
+			&dbModel.FieldConstraints,
 		); err != nil {
 			return dbmodels.DbDataModelTableJoinField{}, err
 		}
@@ -408,7 +437,8 @@ func (repo MarbleDbRepository) GetDataModelField(ctx context.Context, exec Execu
 			data_model_fields.name,
 			data_model_fields.nullable,
 			data_model_fields.table_id,
-			data_model_fields.type
+			data_model_fields.type,
+			data_model_fields.constraints
 		FROM data_model_fields
 		WHERE id = $1
 	`
@@ -417,6 +447,7 @@ func (repo MarbleDbRepository) GetDataModelField(ctx context.Context, exec Execu
 
 	var field models.FieldMetadata
 	var dataType string
+	var constraints []byte
 	if err := row.Scan(
 		&field.Description,
 		&field.IsEnum,
@@ -424,6 +455,7 @@ func (repo MarbleDbRepository) GetDataModelField(ctx context.Context, exec Execu
 		&field.Nullable,
 		&field.TableId,
 		&dataType,
+		&constraints,
 	); errors.Is(err, pgx.ErrNoRows) {
 		return models.FieldMetadata{}, fmt.Errorf("error in GetDataModelField: %w", models.NotFoundError)
 	} else if err != nil {
@@ -431,6 +463,11 @@ func (repo MarbleDbRepository) GetDataModelField(ctx context.Context, exec Execu
 	}
 	field.ID = fieldId
 	field.DataType = models.DataTypeFrom(dataType)
+	fieldConstraints, err := dbmodels.AdaptFieldConstraints(constraints)
+	if err != nil {
+		return models.FieldMetadata{}, err
+	}
+	field.Constraints = fieldConstraints
 
 	return field, nil
 }
@@ -9,16 +9,22 @@ import (
 )
 
 type DBCase struct {
-	Id             pgtype.Text      `db:"id"`
-	CreatedAt      pgtype.Timestamp `db:"created_at"`
-	InboxId        pgtype.Text      `db:"inbox_id"`
-	Name           pgtype.Text      `db:"name"`
-	OrganizationId pgtype.Text      `db:"org_id"`
-	AssignedTo     *string          `db:"assigned_to"`
-	Status         pgtype.Text      `db:"status"`
-	Outcome        pgtype.Text      `db:"outcome"`
-	SnoozedUntil   *time.Time       `db:"snoozed_until"`
-	Boost          *string          `db:"boost"`
+	Id               pgtype.Text        `db:"id"`
+	CreatedAt        pgtype.Timestamp   `db:"created_at"`
+	InboxId          pgtype.Text        `db:"inbox_id"`
+	Name             pgtype.Text        `db:"name"`
+	OrganizationId   pgtype.Text        `db:"org_id"`
+	AssignedTo       *string            `db:"assigned_to"`
+	Status           pgtype.Text        `db:"status"`
+	Outcome          pgtype.Text        `db:"outcome"`
+	SnoozedUntil     *time.Time         `db:"snoozed_until"`
+	Boost            *string            `db:"boost"`
+	SlaPolicyId      *string            `db:"sla_policy_id"`
+	FirstActionDueAt *time.Time         `db:"first_action_due_at"`
+	CloseDueAt       *time.Time         `db:"close_due_at"`
+	DueAt            pgtype.Timestamptz `db:"due_at"`
+	SlaBreachedAt    *time.Time         `db:"sla_breached_at"`
+	MergedIntoId     *string            `db:"merged_into_id"`
 }
 
 type DBCaseWithContributorsAndTags struct {
@@ -38,6 +44,8 @@ const TABLE_CASES = "cases"
 var SelectCaseColumn = []string{
 	"id", "created_at", "inbox_id", "name", "org_id", "assigned_to",
 	"status", "outcome", "snoozed_until", "boost",
+	"sla_policy_id", "first_action_due_at", "close_due_at", "due_at", "sla_breached_at",
+	"merged_into_id",
 }
 
 func AdaptCase(db DBCase) (models.Case, error) {
@@ -51,17 +59,29 @@ func AdaptCase(db DBCase) (models.Case, error) {
 		boostReason = utils.Ptr(models.BoostReason(*db.Boost))
 	}
 
+	// due_at is 'infinity' for the cases without a deadline
+	var dueAt *time.Time
+	if db.DueAt.Valid && db.DueAt.InfinityModifier == pgtype.Finite {
+		dueAt = &db.DueAt.Time
+	}
+
 	return models.Case{
-		Id:             db.Id.String,
-		CreatedAt:      db.CreatedAt.Time,
-		InboxId:        db.InboxId.String,
-		Name:           db.Name.String,
-		OrganizationId: db.OrganizationId.String,
-		AssignedTo:     assigneeId,
-		Status:         models.CaseStatus(db.Status.String),
-		Outcome:        models.CaseOutcome(db.Outcome.String),
-		SnoozedUntil:   db.SnoozedUntil,
-		Boost:          boostReason,
+		Id:               db.Id.String,
+		CreatedAt:        db.CreatedAt.Time,
+		InboxId:          db.InboxId.String,
+		Name:             db.Name.String,
+		OrganizationId:   db.OrganizationId.String,
+		AssignedTo:       assigneeId,
+		Status:           models.CaseStatus(db.Status.String),
+		Outcome:          models.CaseOutcome(db.Outcome.String),
+		SnoozedUntil:     db.SnoozedUntil,
+		Boost:            boostReason,
+		SlaPolicyId:      db.SlaPolicyId,
+		FirstActionDueAt: db.FirstActionDueAt,
+		CloseDueAt:       db.CloseDueAt,
+		DueAt:            dueAt,
+		SlaBreachedAt:    db.SlaBreachedAt,
+		MergedIntoId:     db.MergedIntoId,
 	}, nil
 }
 
@@ -0,0 +1,70 @@
+package dbmodels
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/utils"
+	"github.com/cockroachdb/errors"
+)
+
+type DbCaseBulkAction struct {
+	Id          string    `db:"id"`
+	OrgId       string    `db:"org_id"`
+	UserId      string    `db:"user_id"`
+	Action      string    `db:"action"`
+	Params      []byte    `db:"params"`
+	Status      string    `db:"status"`
+	NbCases     int       `db:"nb_cases"`
+	NbSucceeded int       `db:"nb_succeeded"`
+	NbFailed    int       `db:"nb_failed"`
+	CreatedAt   time.Time `db:"created_at"`
+	UpdatedAt   time.Time `db:"updated_at"`
+}
+
+const TABLE_CASE_BULK_ACTIONS = "case_bulk_actions"
+
+var SelectCaseBulkActionColumns = utils.ColumnList[DbCaseBulkAction]()
+
+func AdaptCaseBulkAction(db DbCaseBulkAction) (models.CaseBulkAction, error) {
+	var params models.CaseBulkActionParams
+	if err := json.Unmarshal(db.Params, &params); err != nil {
+		return models.CaseBulkAction{}, errors.Wrap(err, "could not unmarshal the params of the bulk action")
+	}
+
+	return models.CaseBulkAction{
+		Id:             db.Id,
+		OrganizationId: db.OrgId,
+		UserId:         models.UserId(db.UserId),
+		Action:         models.CaseBulkActionType(db.Action),
+		Params:         params,
+		Status:         models.CaseBulkActionStatus(db.Status),
+		NbCases:        db.NbCases,
+		NbSucceeded:    db.NbSucceeded,
+		NbFailed:       db.NbFailed,
+		CreatedAt:      db.CreatedAt,
+		UpdatedAt:      db.UpdatedAt,
+	}, nil
+}
+
+type DbCaseBulkActionItem struct {
+	BulkActionId string    `db:"bulk_action_id"`
+	CaseId       string    `db:"case_id"`
+	Status       string    `db:"status"`
+	Error        *string   `db:"error"`
+	UpdatedAt    time.Time `db:"updated_at"`
+}
+
+const TABLE_CASE_BULK_ACTION_ITEMS = "case_bulk_action_items"
+
+var SelectCaseBulkActionItemColumns = utils.ColumnList[DbCaseBulkActionItem]()
+
+func AdaptCaseBulkActionItem(db DbCaseBulkActionItem) (models.CaseBulkActionItem, error) {
+	return models.CaseBulkActionItem{
+		CaseId:    db.CaseId,
+		Status:    models.CaseBulkActionItemStatus(db.Status),
+		Error:     db.Error,
+		UpdatedAt: db.UpdatedAt,
+	}, nil
+}
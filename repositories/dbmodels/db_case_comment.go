@@ -0,0 +1,64 @@
+package dbmodels
+
+import (
+	"time"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/utils"
+)
+
+type DbCaseComment struct {
+	Id               string     `db:"id"`
+	OrgId            string     `db:"org_id"`
+	CaseId           string     `db:"case_id"`
+	ParentId         *string    `db:"parent_id"`
+	AuthorId         *string    `db:"author_id"`
+	Body             string     `db:"body"`
+	MentionedUserIds []string   `db:"mentioned_user_ids"`
+	CreatedAt        time.Time  `db:"created_at"`
+	EditedAt         *time.Time `db:"edited_at"`
+	DeletedAt        *time.Time `db:"deleted_at"`
+}
+
+const TABLE_CASE_COMMENTS = "case_comments"
+
+var SelectCaseCommentColumns = utils.ColumnList[DbCaseComment]()
+
+func AdaptCaseComment(db DbCaseComment) (models.CaseComment, error) {
+	return models.CaseComment{
+		Id:               db.Id,
+		OrganizationId:   db.OrgId,
+		CaseId:           db.CaseId,
+		ParentId:         db.ParentId,
+		AuthorId:         db.AuthorId,
+		Body:             db.Body,
+		MentionedUserIds: db.MentionedUserIds,
+		CreatedAt:        db.CreatedAt,
+		EditedAt:         db.EditedAt,
+		DeletedAt:        db.DeletedAt,
+	}, nil
+}
+
+type DbCaseCommentVersion struct {
+	Id               string    `db:"id"`
+	CommentId        string    `db:"comment_id"`
+	Body             string    `db:"body"`
+	MentionedUserIds []string  `db:"mentioned_user_ids"`
+	EditedBy         *string   `db:"edited_by"`
+	CreatedAt        time.Time `db:"created_at"`
+}
+
+const TABLE_CASE_COMMENT_VERSIONS = "case_comment_versions"
+
+var SelectCaseCommentVersionColumns = utils.ColumnList[DbCaseCommentVersion]()
+
+func AdaptCaseCommentVersion(db DbCaseCommentVersion) (models.CaseCommentVersion, error) {
+	return models.CaseCommentVersion{
+		Id:               db.Id,
+		CommentId:        db.CommentId,
+		Body:             db.Body,
+		MentionedUserIds: db.MentionedUserIds,
+		EditedBy:         db.EditedBy,
+		CreatedAt:        db.CreatedAt,
+	}, nil
+}
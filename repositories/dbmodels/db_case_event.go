@@ -55,3 +55,23 @@ func AdaptCaseEvent(caseEvent DBCaseEvent) (models.CaseEvent, error) {
 		PreviousValue:  previousValue,
 	}, nil
 }
+
+// DBCaseEventWithComment is a case event, with whether the comment it refers to was deleted
+type DBCaseEventWithComment struct {
+	DBCaseEvent
+	CommentDeleted bool `db:"comment_deleted"`
+}
+
+// AdaptCaseEventWithComment adapts a case event, without the content of the comment it refers to if it was deleted
+func AdaptCaseEventWithComment(caseEvent DBCaseEventWithComment) (models.CaseEvent, error) {
+	event := caseEvent.DBCaseEvent
+	if caseEvent.CommentDeleted {
+		switch models.CaseEventType(event.EventType) {
+		case models.CaseCommentAdded, models.CaseCommentUpdated, models.CaseCommentDeleted:
+			event.AdditionalNote = nil
+			event.NewValue = nil
+			event.PreviousValue = nil
+		}
+	}
+	return AdaptCaseEvent(event)
+}
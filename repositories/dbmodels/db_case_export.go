@@ -0,0 +1,43 @@
+package dbmodels
+
+import (
+	"time"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/utils"
+)
+
+type DbCaseExport struct {
+	Id                string    `db:"id"`
+	OrgId             string    `db:"org_id"`
+	CaseId            string    `db:"case_id"`
+	Status            string    `db:"status"`
+	NbFiles           int       `db:"nb_files"`
+	SizeBytes         int64     `db:"size_bytes"`
+	RequestedByUserId *string   `db:"requested_by_user_id"`
+	CreatedAt         time.Time `db:"created_at"`
+	UpdatedAt         time.Time `db:"updated_at"`
+}
+
+const TABLE_CASE_EXPORTS = "case_exports"
+
+var SelectCaseExportColumns = utils.ColumnList[DbCaseExport]()
+
+func AdaptCaseExport(db DbCaseExport) (models.CaseExport, error) {
+	var userId *models.UserId
+	if db.RequestedByUserId != nil {
+		userId = utils.Ptr(models.UserId(*db.RequestedByUserId))
+	}
+
+	return models.CaseExport{
+		Id:                db.Id,
+		OrganizationId:    db.OrgId,
+		CaseId:            db.CaseId,
+		Status:            models.CaseExportStatus(db.Status),
+		NbFiles:           db.NbFiles,
+		SizeBytes:         db.SizeBytes,
+		RequestedByUserId: userId,
+		CreatedAt:         db.CreatedAt,
+		UpdatedAt:         db.UpdatedAt,
+	}, nil
+}
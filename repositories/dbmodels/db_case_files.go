@@ -14,6 +14,7 @@ type DBCaseFile struct {
 	BucketName    string    `db:"bucket_name"`
 	FileReference string    `db:"file_reference"`
 	FileName      string    `db:"file_name"`
+	ScanStatus    string    `db:"scan_status"`
 }
 
 const TABLE_CASE_FILES = "case_files"
@@ -28,5 +29,6 @@ func AdaptCaseFile(db DBCaseFile) (models.CaseFile, error) {
 		BucketName:    db.BucketName,
 		FileName:      db.FileName,
 		FileReference: db.FileReference,
+		ScanStatus:    models.FileScanStatusFrom(db.ScanStatus),
 	}, nil
 }
@@ -0,0 +1,73 @@
+package dbmodels
+
+import (
+	"time"
+
+	"github.com/checkmarble/marble-backend/models"
+)
+
+type DbCaseVolumeMetric struct {
+	Day      time.Time `db:"day"`
+	NbOpened int       `db:"nb_opened"`
+	NbClosed int       `db:"nb_closed"`
+}
+
+func AdaptCaseVolumeMetric(db DbCaseVolumeMetric) (models.CaseVolumeMetric, error) {
+	return models.CaseVolumeMetric{
+		Day:      db.Day,
+		NbOpened: db.NbOpened,
+		NbClosed: db.NbClosed,
+	}, nil
+}
+
+type DbCaseTimeToCloseMetric struct {
+	GroupId                  string  `db:"group_id"`
+	NbClosed                 int     `db:"nb_closed"`
+	MedianTimeToCloseSeconds float64 `db:"median_time_to_close_seconds"`
+}
+
+func AdaptCaseTimeToCloseMetric(db DbCaseTimeToCloseMetric) (models.CaseTimeToCloseMetric, error) {
+	return models.CaseTimeToCloseMetric{
+		GroupId:           db.GroupId,
+		NbClosed:          db.NbClosed,
+		MedianTimeToClose: time.Duration(db.MedianTimeToCloseSeconds * float64(time.Second)),
+	}, nil
+}
+
+type DbCaseOutcomeMetric struct {
+	Outcome string `db:"outcome"`
+	NbCases int    `db:"nb_cases"`
+}
+
+func AdaptCaseOutcomeMetric(db DbCaseOutcomeMetric) (models.CaseOutcomeMetric, error) {
+	return models.CaseOutcomeMetric{
+		Outcome: models.CaseOutcome(db.Outcome),
+		NbCases: db.NbCases,
+	}, nil
+}
+
+type DbCaseEscalationMetric struct {
+	InboxId     string `db:"inbox_id"`
+	NbClosed    int    `db:"nb_closed"`
+	NbEscalated int    `db:"nb_escalated"`
+}
+
+func AdaptCaseEscalationMetric(db DbCaseEscalationMetric) (models.CaseEscalationMetric, error) {
+	return models.CaseEscalationMetric{
+		InboxId:     db.InboxId,
+		NbClosed:    db.NbClosed,
+		NbEscalated: db.NbEscalated,
+	}, nil
+}
+
+type DbCaseBacklogAgeMetric struct {
+	Bucket  int `db:"bucket"`
+	NbCases int `db:"nb_cases"`
+}
+
+func AdaptCaseBacklogAgeMetric(db DbCaseBacklogAgeMetric) (models.CaseBacklogAgeMetric, error) {
+	return models.CaseBacklogAgeMetric{
+		Bucket:  models.CaseBacklogAgeBuckets[db.Bucket].Label,
+		NbCases: db.NbCases,
+	}, nil
+}
@@ -0,0 +1,108 @@
+package dbmodels
+
+import (
+	"time"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/utils"
+)
+
+type DbInboxQaRule struct {
+	Id          string    `db:"id"`
+	OrgId       string    `db:"org_id"`
+	InboxId     string    `db:"inbox_id"`
+	Name        string    `db:"name"`
+	Priority    int       `db:"priority"`
+	CaseOutcome *string   `db:"case_outcome"`
+	TagId       *string   `db:"tag_id"`
+	SampleRate  float64   `db:"sample_rate"`
+	ReviewerIds []string  `db:"reviewer_ids"`
+	CreatedAt   time.Time `db:"created_at"`
+	UpdatedAt   time.Time `db:"updated_at"`
+}
+
+const TABLE_INBOX_QA_RULES = "inbox_qa_rules"
+
+var SelectInboxQaRuleColumns = utils.ColumnList[DbInboxQaRule]()
+
+func AdaptInboxQaRule(db DbInboxQaRule) (models.InboxQaRule, error) {
+	var outcome *models.CaseOutcome
+	if db.CaseOutcome != nil {
+		outcome = utils.Ptr(models.CaseOutcome(*db.CaseOutcome))
+	}
+
+	return models.InboxQaRule{
+		Id:             db.Id,
+		OrganizationId: db.OrgId,
+		InboxId:        db.InboxId,
+		Name:           db.Name,
+		Priority:       db.Priority,
+		CaseOutcome:    outcome,
+		TagId:          db.TagId,
+		SampleRate:     db.SampleRate,
+		ReviewerIds:    db.ReviewerIds,
+		CreatedAt:      db.CreatedAt,
+		UpdatedAt:      db.UpdatedAt,
+	}, nil
+}
+
+type DbCaseQaReview struct {
+	Id              string     `db:"id"`
+	OrgId           string     `db:"org_id"`
+	InboxId         string     `db:"inbox_id"`
+	CaseId          string     `db:"case_id"`
+	RuleId          *string    `db:"rule_id"`
+	AnalystId       *string    `db:"analyst_id"`
+	ReviewerId      string     `db:"reviewer_id"`
+	CaseOutcome     string     `db:"case_outcome"`
+	Status          string     `db:"status"`
+	Verdict         *string    `db:"verdict"`
+	ReviewerOutcome *string    `db:"reviewer_outcome"`
+	Comment         string     `db:"comment"`
+	CreatedAt       time.Time  `db:"created_at"`
+	CompletedAt     *time.Time `db:"completed_at"`
+}
+
+const TABLE_CASE_QA_REVIEWS = "case_qa_reviews"
+
+var SelectCaseQaReviewColumns = utils.ColumnList[DbCaseQaReview]()
+
+func AdaptCaseQaReview(db DbCaseQaReview) (models.CaseQaReview, error) {
+	review := models.CaseQaReview{
+		Id:             db.Id,
+		OrganizationId: db.OrgId,
+		InboxId:        db.InboxId,
+		CaseId:         db.CaseId,
+		RuleId:         db.RuleId,
+		AnalystId:      db.AnalystId,
+		ReviewerId:     db.ReviewerId,
+		CaseOutcome:    models.CaseOutcome(db.CaseOutcome),
+		Status:         models.CaseQaReviewStatus(db.Status),
+		Comment:        db.Comment,
+		CreatedAt:      db.CreatedAt,
+		CompletedAt:    db.CompletedAt,
+	}
+	if db.Verdict != nil {
+		review.Verdict = utils.Ptr(models.CaseQaVerdict(*db.Verdict))
+	}
+	if db.ReviewerOutcome != nil {
+		review.ReviewerOutcome = utils.Ptr(models.CaseOutcome(*db.ReviewerOutcome))
+	}
+	return review, nil
+}
+
+type DbCaseQaScore struct {
+	AnalystId       string `db:"analyst_id"`
+	NbReviews       int    `db:"nb_reviews"`
+	NbAgreements    int    `db:"nb_agreements"`
+	NbDisagreements int    `db:"nb_disagreements"`
+}
+
+func AdaptCaseQaScore(db DbCaseQaScore) (models.CaseQaScore, error) {
+	return models.CaseQaScore{
+		AnalystId:       db.AnalystId,
+		NbReviews:       db.NbReviews,
+		NbAgreements:    db.NbAgreements,
+		NbDisagreements: db.NbDisagreements,
+	}, nil
+}
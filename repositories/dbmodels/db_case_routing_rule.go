@@ -0,0 +1,44 @@
+package dbmodels
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/utils"
+)
+
+type DbCaseRoutingRule struct {
+	Id         string    `db:"id"`
+	OrgId      string    `db:"org_id"`
+	ScenarioId string    `db:"scenario_id"`
+	Priority   int       `db:"priority"`
+	Name       string    `db:"name"`
+	Formula    []byte    `db:"formula"`
+	InboxId    string    `db:"inbox_id"`
+	TagIds     []string  `db:"tag_ids"`
+	CreatedAt  time.Time `db:"created_at"`
+}
+
+const TABLE_CASE_ROUTING_RULES = "scenario_case_routing_rules"
+
+var SelectCaseRoutingRuleColumns = utils.ColumnList[DbCaseRoutingRule]()
+
+func AdaptCaseRoutingRule(db DbCaseRoutingRule) (models.CaseRoutingRule, error) {
+	formula, err := AdaptSerializedAstExpression(db.Formula)
+	if err != nil {
+		return models.CaseRoutingRule{}, fmt.Errorf("unable to unmarshal ast expression: %w", err)
+	}
+
+	return models.CaseRoutingRule{
+		Id:             db.Id,
+		OrganizationId: db.OrgId,
+		ScenarioId:     db.ScenarioId,
+		Priority:       db.Priority,
+		Name:           db.Name,
+		Formula:        formula,
+		InboxId:        db.InboxId,
+		TagIds:         db.TagIds,
+		CreatedAt:      db.CreatedAt,
+	}, nil
+}
@@ -0,0 +1,47 @@
+package dbmodels
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/utils"
+	"github.com/cockroachdb/errors"
+)
+
+type DbCaseSavedView struct {
+	Id        string    `db:"id"`
+	OrgId     string    `db:"org_id"`
+	OwnerId   string    `db:"owner_id"`
+	InboxId   *string   `db:"inbox_id"`
+	Name      string    `db:"name"`
+	Filters   []byte    `db:"filters"`
+	Sorting   string    `db:"sorting"`
+	SortOrder string    `db:"sort_order"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+const TABLE_CASE_SAVED_VIEWS = "case_saved_views"
+
+var SelectCaseSavedViewColumns = utils.ColumnList[DbCaseSavedView]()
+
+func AdaptCaseSavedView(db DbCaseSavedView) (models.CaseSavedView, error) {
+	var filters models.CaseViewFilters
+	if err := json.Unmarshal(db.Filters, &filters); err != nil {
+		return models.CaseSavedView{}, errors.Wrap(err, "could not unmarshal the filters of the case view")
+	}
+
+	return models.CaseSavedView{
+		Id:             db.Id,
+		OrganizationId: db.OrgId,
+		OwnerId:        db.OwnerId,
+		InboxId:        db.InboxId,
+		Name:           db.Name,
+		Filters:        filters,
+		Sorting:        models.SortingFieldFrom(db.Sorting),
+		Order:          models.SortingOrderFrom(db.SortOrder),
+		CreatedAt:      db.CreatedAt,
+		UpdatedAt:      db.UpdatedAt,
+	}, nil
+}
@@ -0,0 +1,91 @@
+package dbmodels
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/utils"
+)
+
+const (
+	TABLE_CHANGE_EVENTS       = "change_events"
+	TABLE_CHANGE_FEED_CONFIGS = "change_feed_configs"
+)
+
+type DbChangeEvent struct {
+	Id         int64           `db:"id"`
+	TxId       int64           `db:"txid"`
+	OrgId      string          `db:"org_id"`
+	EntityType string          `db:"entity_type"`
+	EntityId   string          `db:"entity_id"`
+	Operation  string          `db:"operation"`
+	Data       json.RawMessage `db:"data"`
+	CreatedAt  time.Time       `db:"created_at"`
+}
+
+// transaction ids are stored as xid8, which is read as a bigint
+var SelectChangeEventColumns = []string{
+	"id",
+	"txid::text::bigint as txid",
+	"org_id",
+	"entity_type",
+	"entity_id",
+	"operation",
+	"data",
+	"created_at",
+}
+
+func AdaptChangeEvent(db DbChangeEvent) (models.ChangeEvent, error) {
+	return models.ChangeEvent{
+		Cursor:     models.ChangeFeedCursor{TxId: db.TxId, Id: db.Id},
+		OrgId:      db.OrgId,
+		EntityType: models.ChangeEventEntityType(db.EntityType),
+		EntityId:   db.EntityId,
+		Operation:  models.ChangeEventOperation(db.Operation),
+		Data:       db.Data,
+		CreatedAt:  db.CreatedAt,
+	}, nil
+}
+
+type DbChangeFeedConfig struct {
+	OrgId          string    `db:"org_id"`
+	Enabled        bool      `db:"enabled"`
+	SinkType       *string   `db:"sink_type"`
+	SinkAddress    string    `db:"sink_address"`
+	SinkTopic      string    `db:"sink_topic"`
+	SinkCursorTxId *int64    `db:"sink_cursor_txid"`
+	SinkCursorId   *int64    `db:"sink_cursor_id"`
+	UpdatedAt      time.Time `db:"updated_at"`
+}
+
+var SelectChangeFeedConfigColumns = []string{
+	"org_id",
+	"enabled",
+	"sink_type",
+	"sink_address",
+	"sink_topic",
+	"sink_cursor_txid::text::bigint as sink_cursor_txid",
+	"sink_cursor_id",
+	"updated_at",
+}
+
+func AdaptChangeFeedConfig(db DbChangeFeedConfig) (models.ChangeFeedConfig, error) {
+	var sinkType *models.ChangeFeedSinkType
+	if db.SinkType != nil {
+		sinkType = utils.Ptr(models.ChangeFeedSinkType(*db.SinkType))
+	}
+
+	return models.ChangeFeedConfig{
+		OrgId:       db.OrgId,
+		Enabled:     db.Enabled,
+		SinkType:    sinkType,
+		SinkAddress: db.SinkAddress,
+		SinkTopic:   db.SinkTopic,
+		SinkCursor: models.ChangeFeedCursor{
+			TxId: utils.Or(db.SinkCursorTxId, 0),
+			Id:   utils.Or(db.SinkCursorId, 0),
+		},
+		UpdatedAt: db.UpdatedAt,
+	}, nil
+}
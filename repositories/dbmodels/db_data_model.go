@@ -35,10 +35,11 @@ func AdaptDataModel(dbDataModel DbDataModel) (models.DataModel, error) {
 }
 
 type DbDataModelTable struct {
-	ID             string `db:"id"`
-	OrganizationID string `db:"organization_id"`
-	Name           string `db:"name"`
-	Description    string `db:"description"`
+	ID                  string `db:"id"`
+	OrganizationID      string `db:"organization_id"`
+	Name                string `db:"name"`
+	Description         string `db:"description"`
+	DecisionTriggerMode string `db:"decision_trigger_mode"`
 }
 
 const (
@@ -50,10 +51,11 @@ var SelectDataModelTableColumns = utils.ColumnList[DbDataModelTable]()
 
 func AdaptTableMetadata(dbDataModelTable DbDataModelTable) (models.TableMetadata, error) {
 	return models.TableMetadata{
-		ID:             dbDataModelTable.ID,
-		OrganizationID: dbDataModelTable.OrganizationID,
-		Name:           dbDataModelTable.Name,
-		Description:    dbDataModelTable.Description,
+		ID:                  dbDataModelTable.ID,
+		OrganizationID:      dbDataModelTable.OrganizationID,
+		Name:                dbDataModelTable.Name,
+		Description:         dbDataModelTable.Description,
+		DecisionTriggerMode: models.DecisionTriggerModeFrom(dbDataModelTable.DecisionTriggerMode),
 	}, nil
 }
 
@@ -62,12 +64,14 @@ type DbDataModelTableJoinField struct {
 	OrganizationID   string `db:"data_model_tables.organization_id"`
 	TableName        string `db:"data_model_tables.name"`
 	TableDescription string `db:"data_model_tables.description"`
+	TableTriggerMode string `db:"data_model_tables.decision_trigger_mode"`
 	FieldID          string `db:"data_model_fields.id"`
 	FieldName        string `db:"data_model_fields.name"`
 	FieldType        string `db:"data_model_fields.type"`
 	FieldNullable    bool   `db:"data_model_fields.nullable"`
 	FieldDescription string `db:"data_model_fields.description"`
 	FieldIsEnum      bool   `db:"data_model_fields.is_enum"`
+	FieldConstraints []byte `db:"data_model_fields.constraints"`
 }
 
 var SelectDataModelTableJoinFieldColumns = utils.ColumnList[DbDataModelTableJoinField]()
@@ -101,3 +105,27 @@ func AdaptLinkToSingle(dbDataModelLink DbDataModelLink) models.LinkToSingle {
 		ChildFieldId:    dbDataModelLink.ChildFieldId,
 	}
 }
+
+type DbFieldConstraints struct {
+	Min           *float64 `json:"min,omitempty"`
+	Max           *float64 `json:"max,omitempty"`
+	MaxLength     *int     `json:"max_length,omitempty"`
+	Regex         *string  `json:"regex,omitempty"`
+	AllowedValues []any    `json:"allowed_values,omitempty"`
+	NotInFuture   bool     `json:"not_in_future,omitempty"`
+}
+
+func AdaptFieldConstraints(raw []byte) (models.FieldConstraints, error) {
+	if len(raw) == 0 {
+		return models.FieldConstraints{}, nil
+	}
+	var db DbFieldConstraints
+	if err := json.Unmarshal(raw, &db); err != nil {
+		return models.FieldConstraints{}, fmt.Errorf("unable to unmarshal field constraints: %w", err)
+	}
+	return models.FieldConstraints(db), nil
+}
+
+func SerializeFieldConstraints(constraints models.FieldConstraints) ([]byte, error) {
+	return json.Marshal(DbFieldConstraints(constraints))
+}
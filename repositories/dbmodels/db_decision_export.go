@@ -0,0 +1,55 @@
+package dbmodels
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/utils"
+)
+
+type DbDecisionExport struct {
+	Id                string          `db:"id"`
+	OrgId             string          `db:"org_id"`
+	Format            string          `db:"format"`
+	Filters           json.RawMessage `db:"filters"`
+	Status            string          `db:"status"`
+	NbFiles           int             `db:"nb_files"`
+	NbDecisions       int             `db:"nb_decisions"`
+	CursorDecisionId  *string         `db:"cursor_decision_id"`
+	RequestedByUserId *string         `db:"requested_by_user_id"`
+	RequestedByApiKey *string         `db:"requested_by_api_key"`
+	CreatedAt         time.Time       `db:"created_at"`
+	UpdatedAt         time.Time       `db:"updated_at"`
+}
+
+const TABLE_DECISION_EXPORTS = "decision_exports"
+
+var SelectDecisionExportColumns = utils.ColumnList[DbDecisionExport]()
+
+func AdaptDecisionExport(db DbDecisionExport) (models.DecisionExport, error) {
+	filters, err := AdaptDecisionFilters(db.Filters)
+	if err != nil {
+		return models.DecisionExport{}, err
+	}
+
+	var userId *models.UserId
+	if db.RequestedByUserId != nil {
+		userId = utils.Ptr(models.UserId(*db.RequestedByUserId))
+	}
+
+	return models.DecisionExport{
+		Id:                db.Id,
+		OrganizationId:    db.OrgId,
+		Format:            models.DecisionExportFormatFrom(db.Format),
+		Filters:           filters,
+		Status:            models.DecisionExportStatus(db.Status),
+		NbFiles:           db.NbFiles,
+		NbDecisions:       db.NbDecisions,
+		CursorDecisionId:  db.CursorDecisionId,
+		RequestedByUserId: userId,
+		RequestedByApiKey: utils.Or(db.RequestedByApiKey, ""),
+		CreatedAt:         db.CreatedAt,
+		UpdatedAt:         db.UpdatedAt,
+	}, nil
+}
@@ -0,0 +1,73 @@
+package dbmodels
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/pure_utils"
+)
+
+// DbDecisionFilters is the serialized form of models.DecisionFilters, stored with the background jobs that work on a
+// selection of decisions so that they apply the filters that were validated when the job was requested.
+type DbDecisionFilters struct {
+	CaseIds               []string  `json:"case_ids,omitempty"`
+	CaseInboxIds          []string  `json:"case_inbox_ids,omitempty"`
+	EndDate               time.Time `json:"end_date,omitzero"`
+	HasCase               *bool     `json:"has_case,omitempty"`
+	Outcomes              []string  `json:"outcomes,omitempty"`
+	PivotValue            *string   `json:"pivot_value,omitempty"`
+	ReviewStatuses        []string  `json:"review_statuses,omitempty"`
+	ScenarioIds           []string  `json:"scenario_ids,omitempty"`
+	ScheduledExecutionIds []string  `json:"scheduled_execution_ids,omitempty"`
+	StartDate             time.Time `json:"start_date,omitzero"`
+	TriggerObjects        []string  `json:"trigger_objects,omitempty"`
+	TriggerObjectId       *string   `json:"trigger_object_id,omitempty"`
+	HasLabel              *bool     `json:"has_label,omitempty"`
+	Labels                []string  `json:"labels,omitempty"`
+}
+
+func SerializeDecisionFilters(filters models.DecisionFilters) ([]byte, error) {
+	return json.Marshal(DbDecisionFilters{
+		CaseIds:               filters.CaseIds,
+		CaseInboxIds:          filters.CaseInboxIds,
+		EndDate:               filters.EndDate,
+		HasCase:               filters.HasCase,
+		Outcomes:              pure_utils.Map(filters.Outcomes, func(o models.Outcome) string { return o.String() }),
+		PivotValue:            filters.PivotValue,
+		ReviewStatuses:        filters.ReviewStatuses,
+		ScenarioIds:           filters.ScenarioIds,
+		ScheduledExecutionIds: filters.ScheduledExecutionIds,
+		StartDate:             filters.StartDate,
+		TriggerObjects:        filters.TriggerObjects,
+		TriggerObjectId:       filters.TriggerObjectId,
+		HasLabel:              filters.HasLabel,
+		Labels:                filters.Labels,
+	})
+}
+
+func AdaptDecisionFilters(raw json.RawMessage) (models.DecisionFilters, error) {
+	var filters DbDecisionFilters
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, &filters); err != nil {
+			return models.DecisionFilters{}, err
+		}
+	}
+
+	return models.DecisionFilters{
+		CaseIds:               filters.CaseIds,
+		CaseInboxIds:          filters.CaseInboxIds,
+		EndDate:               filters.EndDate,
+		HasCase:               filters.HasCase,
+		Outcomes:              pure_utils.Map(filters.Outcomes, models.OutcomeFrom),
+		PivotValue:            filters.PivotValue,
+		ReviewStatuses:        filters.ReviewStatuses,
+		ScenarioIds:           filters.ScenarioIds,
+		ScheduledExecutionIds: filters.ScheduledExecutionIds,
+		StartDate:             filters.StartDate,
+		TriggerObjects:        filters.TriggerObjects,
+		TriggerObjectId:       filters.TriggerObjectId,
+		HasLabel:              filters.HasLabel,
+		Labels:                filters.Labels,
+	}, nil
+}
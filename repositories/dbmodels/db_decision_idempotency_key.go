@@ -0,0 +1,40 @@
+package dbmodels
+
+import (
+	"time"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/utils"
+)
+
+type DbDecisionIdempotencyKey struct {
+	Id            string    `db:"id"`
+	OrgId         string    `db:"org_id"`
+	Actor         string    `db:"actor"`
+	Key           string    `db:"key"`
+	RequestHash   []byte    `db:"request_hash"`
+	TriggerPassed bool      `db:"trigger_passed"`
+	DecisionIds   []string  `db:"decision_ids"`
+	NbSkipped     int       `db:"nb_skipped"`
+	CreatedAt     time.Time `db:"created_at"`
+	ExpiresAt     time.Time `db:"expires_at"`
+}
+
+const TABLE_DECISION_IDEMPOTENCY_KEYS = "decision_idempotency_keys"
+
+var SelectDecisionIdempotencyKeyColumns = utils.ColumnList[DbDecisionIdempotencyKey]()
+
+func AdaptDecisionIdempotencyKey(db DbDecisionIdempotencyKey) (models.DecisionIdempotencyKey, error) {
+	return models.DecisionIdempotencyKey{
+		Id:             db.Id,
+		OrganizationId: db.OrgId,
+		Actor:          db.Actor,
+		Key:            db.Key,
+		RequestHash:    db.RequestHash,
+		TriggerPassed:  db.TriggerPassed,
+		DecisionIds:    db.DecisionIds,
+		NbSkipped:      db.NbSkipped,
+		CreatedAt:      db.CreatedAt,
+		ExpiresAt:      db.ExpiresAt,
+	}, nil
+}
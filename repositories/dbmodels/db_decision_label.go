@@ -0,0 +1,48 @@
+package dbmodels
+
+import (
+	"time"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/utils"
+)
+
+type DbDecisionLabel struct {
+	Id         string    `db:"id"`
+	OrgId      string    `db:"org_id"`
+	DecisionId *string   `db:"decision_id"`
+	ObjectType *string   `db:"object_type"`
+	ObjectId   *string   `db:"object_id"`
+	Label      string    `db:"label"`
+	Amount     *float64  `db:"amount"`
+	Currency   *string   `db:"currency"`
+	Source     string    `db:"source"`
+	OccurredAt time.Time `db:"occurred_at"`
+	CreatedAt  time.Time `db:"created_at"`
+}
+
+const TABLE_DECISION_LABELS = "decision_labels"
+
+var SelectDecisionLabelColumns = utils.ColumnList[DbDecisionLabel]()
+
+func AdaptDecisionLabel(db DbDecisionLabel) (models.DecisionLabel, error) {
+	return models.DecisionLabel{
+		Id:             db.Id,
+		OrganizationId: db.OrgId,
+		DecisionId:     db.DecisionId,
+		ObjectType:     db.ObjectType,
+		ObjectId:       db.ObjectId,
+		Label:          db.Label,
+		Amount:         db.Amount,
+		Currency:       db.Currency,
+		Source:         db.Source,
+		OccurredAt:     db.OccurredAt,
+		CreatedAt:      db.CreatedAt,
+	}, nil
+}
+
+// DbLabelOfDecision is a label, along with the id of one of the decisions it applies to
+type DbLabelOfDecision struct {
+	DbDecisionLabel
+	LabelledDecisionId string `db:"labelled_decision_id"`
+}
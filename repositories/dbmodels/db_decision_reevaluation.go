@@ -0,0 +1,90 @@
+package dbmodels
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/utils"
+)
+
+type DbDecisionReevaluation struct {
+	Id                     string    `db:"id"`
+	OrgId                  string    `db:"org_id"`
+	DecisionId             string    `db:"decision_id"`
+	ReevaluationDecisionId *string   `db:"reevaluation_decision_id"`
+	ScenarioIterationId    string    `db:"scenario_iteration_id"`
+	Outcome                *string   `db:"outcome"`
+	Score                  *int      `db:"score"`
+	BatchId                *string   `db:"batch_id"`
+	CreatedAt              time.Time `db:"created_at"`
+}
+
+const TABLE_DECISION_REEVALUATIONS = "decision_reevaluations"
+
+var SelectDecisionReevaluationColumns = utils.ColumnList[DbDecisionReevaluation]()
+
+func AdaptDecisionReevaluation(db DbDecisionReevaluation) (models.DecisionReevaluation, error) {
+	var outcome *models.Outcome
+	if db.Outcome != nil {
+		outcome = utils.Ptr(models.OutcomeFrom(*db.Outcome))
+	}
+
+	return models.DecisionReevaluation{
+		Id:                     db.Id,
+		OrganizationId:         db.OrgId,
+		DecisionId:             db.DecisionId,
+		ReevaluationDecisionId: db.ReevaluationDecisionId,
+		ScenarioIterationId:    db.ScenarioIterationId,
+		Outcome:                outcome,
+		Score:                  db.Score,
+		BatchId:                db.BatchId,
+		CreatedAt:              db.CreatedAt,
+	}, nil
+}
+
+type DbDecisionReevaluationBatch struct {
+	Id                  string          `db:"id"`
+	OrgId               string          `db:"org_id"`
+	ScenarioIterationId *string         `db:"scenario_iteration_id"`
+	Filters             json.RawMessage `db:"filters"`
+	Status              string          `db:"status"`
+	NbDecisions         int             `db:"nb_decisions"`
+	NbErrors            int             `db:"nb_errors"`
+	CursorDecisionId    *string         `db:"cursor_decision_id"`
+	RequestedByUserId   *string         `db:"requested_by_user_id"`
+	RequestedByApiKey   *string         `db:"requested_by_api_key"`
+	CreatedAt           time.Time       `db:"created_at"`
+	UpdatedAt           time.Time       `db:"updated_at"`
+}
+
+const TABLE_DECISION_REEVALUATION_BATCHES = "decision_reevaluation_batches"
+
+var SelectDecisionReevaluationBatchColumns = utils.ColumnList[DbDecisionReevaluationBatch]()
+
+func AdaptDecisionReevaluationBatch(db DbDecisionReevaluationBatch) (models.DecisionReevaluationBatch, error) {
+	filters, err := AdaptDecisionFilters(db.Filters)
+	if err != nil {
+		return models.DecisionReevaluationBatch{}, err
+	}
+
+	var userId *models.UserId
+	if db.RequestedByUserId != nil {
+		userId = utils.Ptr(models.UserId(*db.RequestedByUserId))
+	}
+
+	return models.DecisionReevaluationBatch{
+		Id:                  db.Id,
+		OrganizationId:      db.OrgId,
+		ScenarioIterationId: db.ScenarioIterationId,
+		Filters:             filters,
+		Status:              models.DecisionReevaluationBatchStatus(db.Status),
+		NbDecisions:         db.NbDecisions,
+		NbErrors:            db.NbErrors,
+		CursorDecisionId:    db.CursorDecisionId,
+		RequestedByUserId:   userId,
+		RequestedByApiKey:   utils.Or(db.RequestedByApiKey, ""),
+		CreatedAt:           db.CreatedAt,
+		UpdatedAt:           db.UpdatedAt,
+	}, nil
+}
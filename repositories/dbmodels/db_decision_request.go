@@ -0,0 +1,60 @@
+package dbmodels
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/utils"
+)
+
+type DbDecisionRequest struct {
+	Id                string          `db:"id"`
+	OrgId             string          `db:"org_id"`
+	ScenarioId        string          `db:"scenario_id"`
+	TriggerObjectType string          `db:"trigger_object_type"`
+	Payload           json.RawMessage `db:"payload"`
+	ReadAsOf          *time.Time      `db:"read_as_of"`
+	Status            string          `db:"status"`
+	FallbackOutcome   string          `db:"fallback_outcome"`
+	Outcome           *string         `db:"outcome"`
+	Error             string          `db:"error"`
+	Deadline          time.Time       `db:"deadline"`
+	RequestedByUserId *string         `db:"requested_by_user_id"`
+	RequestedByApiKey *string         `db:"requested_by_api_key"`
+	CreatedAt         time.Time       `db:"created_at"`
+	UpdatedAt         time.Time       `db:"updated_at"`
+}
+
+const TABLE_DECISION_REQUESTS = "decision_requests"
+
+var SelectDecisionRequestColumns = utils.ColumnList[DbDecisionRequest]()
+
+func AdaptDecisionRequest(db DbDecisionRequest) (models.DecisionRequest, error) {
+	var userId *models.UserId
+	if db.RequestedByUserId != nil {
+		userId = utils.Ptr(models.UserId(*db.RequestedByUserId))
+	}
+	var outcome *models.Outcome
+	if db.Outcome != nil {
+		outcome = utils.Ptr(models.OutcomeFrom(*db.Outcome))
+	}
+
+	return models.DecisionRequest{
+		Id:                 db.Id,
+		OrganizationId:     db.OrgId,
+		ScenarioId:         db.ScenarioId,
+		TriggerObjectTable: db.TriggerObjectType,
+		PayloadRaw:         db.Payload,
+		ReadAsOf:           db.ReadAsOf,
+		Status:             models.DecisionRequestStatus(db.Status),
+		FallbackOutcome:    models.OutcomeFrom(db.FallbackOutcome),
+		Outcome:            outcome,
+		Error:              db.Error,
+		Deadline:           db.Deadline,
+		RequestedByUserId:  userId,
+		RequestedByApiKey:  utils.Or(db.RequestedByApiKey, ""),
+		CreatedAt:          db.CreatedAt,
+		UpdatedAt:          db.UpdatedAt,
+	}, nil
+}
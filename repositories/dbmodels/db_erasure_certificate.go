@@ -0,0 +1,74 @@
+package dbmodels
+
+import (
+	"time"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/utils"
+)
+
+type DbErasureCertificate struct {
+	Id                  string    `db:"id"`
+	OrgId               string    `db:"org_id"`
+	ObjectType          string    `db:"object_type"`
+	SubjectPseudonym    string    `db:"subject_pseudonym"`
+	Mode                string    `db:"mode"`
+	RequestedByUserId   *string   `db:"requested_by_user_id"`
+	RequestedByApiKey   *string   `db:"requested_by_api_key"`
+	NbIngestedRows      int       `db:"nb_ingested_rows"`
+	NbLinkedRows        int       `db:"nb_linked_rows"`
+	NbDecisions         int       `db:"nb_decisions"`
+	NbRuleExecutions    int       `db:"nb_rule_executions"`
+	NbOffloadedRules    int       `db:"nb_offloaded_rules"`
+	NbEntityAnnotations int       `db:"nb_entity_annotations"`
+	NbCaseFiles         int       `db:"nb_case_files"`
+	NbSanctionChecks    int       `db:"nb_sanction_checks"`
+	NbWebhookEvents     int       `db:"nb_webhook_events"`
+	CreatedAt           time.Time `db:"created_at"`
+}
+
+const TABLE_ERASURE_CERTIFICATES = "erasure_certificates"
+
+var SelectErasureCertificateColumns = utils.ColumnList[DbErasureCertificate]()
+
+func AdaptErasureCertificate(db DbErasureCertificate) (models.ErasureCertificate, error) {
+	var userId *models.UserId
+	if db.RequestedByUserId != nil {
+		userId = utils.Ptr(models.UserId(*db.RequestedByUserId))
+	}
+
+	return models.ErasureCertificate{
+		Id:                db.Id,
+		OrganizationId:    db.OrgId,
+		ObjectType:        db.ObjectType,
+		SubjectPseudonym:  db.SubjectPseudonym,
+		Mode:              models.ErasureModeFromString(db.Mode),
+		RequestedByUserId: userId,
+		RequestedByApiKey: utils.Or(db.RequestedByApiKey, ""),
+		Counts: models.ErasureCounts{
+			IngestedRows:      db.NbIngestedRows,
+			LinkedRows:        db.NbLinkedRows,
+			Decisions:         db.NbDecisions,
+			RuleExecutions:    db.NbRuleExecutions,
+			OffloadedRules:    db.NbOffloadedRules,
+			EntityAnnotations: db.NbEntityAnnotations,
+			CaseFiles:         db.NbCaseFiles,
+			SanctionChecks:    db.NbSanctionChecks,
+			WebhookEvents:     db.NbWebhookEvents,
+		},
+		CreatedAt: db.CreatedAt,
+	}, nil
+}
+
+type DbErasableDecision struct {
+	Id                string    `db:"id"`
+	CaseId            *string   `db:"case_id"`
+	CreatedAt         time.Time `db:"created_at"`
+	TriggerObjectType string    `db:"trigger_object_type"`
+	ObjectId          string    `db:"object_id"`
+	OnLegalHold       bool      `db:"on_legal_hold"`
+}
+
+func AdaptErasableDecision(db DbErasableDecision) (models.ErasableDecision, error) {
+	return models.ErasableDecision(db), nil
+}
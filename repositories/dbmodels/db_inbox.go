@@ -10,13 +10,14 @@ import (
 // Inboxes
 
 type DBInbox struct {
-	Id                string    `db:"id"`
-	OrganizationId    string    `db:"organization_id"`
-	CreatedAt         time.Time `db:"created_at"`
-	UpdatedAt         time.Time `db:"updated_at"`
-	Name              string    `db:"name"`
-	Status            string    `db:"status"`
-	EscalationInboxId *string   `db:"escalation_inbox_id"`
+	Id                 string    `db:"id"`
+	OrganizationId     string    `db:"organization_id"`
+	CreatedAt          time.Time `db:"created_at"`
+	UpdatedAt          time.Time `db:"updated_at"`
+	Name               string    `db:"name"`
+	Status             string    `db:"status"`
+	EscalationInboxId  *string   `db:"escalation_inbox_id"`
+	AssignmentStrategy string    `db:"assignment_strategy"`
 }
 
 const TABLE_INBOXES = "inboxes"
@@ -25,25 +26,29 @@ var SelectInboxColumn = utils.ColumnList[DBInbox]()
 
 func AdaptInbox(db DBInbox) (models.Inbox, error) {
 	return models.Inbox{
-		Id:                db.Id,
-		OrganizationId:    db.OrganizationId,
-		CreatedAt:         db.CreatedAt,
-		UpdatedAt:         db.UpdatedAt,
-		Name:              db.Name,
-		Status:            models.InboxStatus(db.Status),
-		EscalationInboxId: db.EscalationInboxId,
+		Id:                 db.Id,
+		OrganizationId:     db.OrganizationId,
+		CreatedAt:          db.CreatedAt,
+		UpdatedAt:          db.UpdatedAt,
+		Name:               db.Name,
+		Status:             models.InboxStatus(db.Status),
+		EscalationInboxId:  db.EscalationInboxId,
+		AssignmentStrategy: models.InboxAssignmentStrategy(db.AssignmentStrategy),
 	}, nil
 }
 
 // Inbox users
 
 type DBInboxUser struct {
-	Id        string    `db:"id"`
-	InboxId   string    `db:"inbox_id"`
-	UserId    string    `db:"user_id"`
-	CreatedAt time.Time `db:"created_at"`
-	UpdatedAt time.Time `db:"updated_at"`
-	Role      string    `db:"role"`
+	Id             string     `db:"id"`
+	InboxId        string     `db:"inbox_id"`
+	UserId         string     `db:"user_id"`
+	CreatedAt      time.Time  `db:"created_at"`
+	UpdatedAt      time.Time  `db:"updated_at"`
+	Role           string     `db:"role"`
+	OutOfOffice    bool       `db:"out_of_office"`
+	SkillTagIds    []string   `db:"skill_tag_ids"`
+	LastAssignedAt *time.Time `db:"last_assigned_at"`
 }
 
 type DBInboxUserWithOrgId struct {
@@ -60,12 +65,15 @@ var (
 
 func AdaptInboxUser(db DBInboxUser) (models.InboxUser, error) {
 	return models.InboxUser{
-		Id:        db.Id,
-		InboxId:   db.InboxId,
-		UserId:    db.UserId,
-		CreatedAt: db.CreatedAt,
-		UpdatedAt: db.UpdatedAt,
-		Role:      models.InboxUserRole(db.Role),
+		Id:             db.Id,
+		InboxId:        db.InboxId,
+		UserId:         db.UserId,
+		CreatedAt:      db.CreatedAt,
+		UpdatedAt:      db.UpdatedAt,
+		Role:           models.InboxUserRole(db.Role),
+		OutOfOffice:    db.OutOfOffice,
+		SkillTagIds:    db.SkillTagIds,
+		LastAssignedAt: db.LastAssignedAt,
 	}, nil
 }
 
@@ -75,6 +83,24 @@ func AdaptInboxUserWithOrgId(db DBInboxUserWithOrgId) (models.InboxUser, error)
 	return inboxUser, nil
 }
 
+type DBCaseAssignmentCandidate struct {
+	InboxUserId    string     `db:"inbox_user_id"`
+	UserId         string     `db:"user_id"`
+	SkillTagIds    []string   `db:"skill_tag_ids"`
+	OpenCasesCount int        `db:"open_cases_count"`
+	LastAssignedAt *time.Time `db:"last_assigned_at"`
+}
+
+func AdaptCaseAssignmentCandidate(db DBCaseAssignmentCandidate) (models.CaseAssignmentCandidate, error) {
+	return models.CaseAssignmentCandidate{
+		InboxUserId:    db.InboxUserId,
+		UserId:         db.UserId,
+		SkillTagIds:    db.SkillTagIds,
+		OpenCasesCount: db.OpenCasesCount,
+		LastAssignedAt: db.LastAssignedAt,
+	}, nil
+}
+
 type DBInboxWithUsers struct {
 	DBInbox
 	InboxUsers []DBInboxUser `db:"inbox_users"`
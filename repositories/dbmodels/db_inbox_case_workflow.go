@@ -0,0 +1,38 @@
+package dbmodels
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/utils"
+	"github.com/cockroachdb/errors"
+)
+
+type DbInboxCaseWorkflow struct {
+	InboxId     string    `db:"inbox_id"`
+	OrgId       string    `db:"org_id"`
+	Statuses    []byte    `db:"statuses"`
+	Transitions []byte    `db:"transitions"`
+	CreatedAt   time.Time `db:"created_at"`
+	UpdatedAt   time.Time `db:"updated_at"`
+}
+
+const TABLE_INBOX_CASE_WORKFLOWS = "inbox_case_workflows"
+
+var SelectInboxCaseWorkflowColumns = utils.ColumnList[DbInboxCaseWorkflow]()
+
+func AdaptInboxCaseWorkflow(db DbInboxCaseWorkflow) (models.InboxCaseWorkflow, error) {
+	workflow := models.InboxCaseWorkflow{
+		InboxId:        db.InboxId,
+		OrganizationId: db.OrgId,
+		UpdatedAt:      db.UpdatedAt,
+	}
+	if err := json.Unmarshal(db.Statuses, &workflow.Statuses); err != nil {
+		return models.InboxCaseWorkflow{}, errors.Wrap(err, "could not unmarshal the statuses of the case workflow")
+	}
+	if err := json.Unmarshal(db.Transitions, &workflow.Transitions); err != nil {
+		return models.InboxCaseWorkflow{}, errors.Wrap(err, "could not unmarshal the transitions of the case workflow")
+	}
+	return workflow, nil
+}
@@ -0,0 +1,71 @@
+package dbmodels
+
+import (
+	"time"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/utils"
+)
+
+type DbInboxSlaPolicy struct {
+	Id                 string    `db:"id"`
+	OrgId              string    `db:"org_id"`
+	InboxId            string    `db:"inbox_id"`
+	Name               string    `db:"name"`
+	Priority           int       `db:"priority"`
+	DecisionOutcome    *string   `db:"decision_outcome"`
+	TagId              *string   `db:"tag_id"`
+	FirstActionMinutes *int      `db:"first_action_minutes"`
+	CloseMinutes       *int      `db:"close_minutes"`
+	BreachAction       string    `db:"breach_action"`
+	CreatedAt          time.Time `db:"created_at"`
+	UpdatedAt          time.Time `db:"updated_at"`
+}
+
+const TABLE_INBOX_SLA_POLICIES = "inbox_sla_policies"
+
+var SelectInboxSlaPolicyColumns = utils.ColumnList[DbInboxSlaPolicy]()
+
+func AdaptInboxSlaPolicy(db DbInboxSlaPolicy) (models.InboxSlaPolicy, error) {
+	var outcome *models.Outcome
+	if db.DecisionOutcome != nil {
+		outcome = utils.Ptr(models.OutcomeFrom(*db.DecisionOutcome))
+	}
+
+	return models.InboxSlaPolicy{
+		Id:                 db.Id,
+		OrganizationId:     db.OrgId,
+		InboxId:            db.InboxId,
+		Name:               db.Name,
+		Priority:           db.Priority,
+		DecisionOutcome:    outcome,
+		TagId:              db.TagId,
+		FirstActionMinutes: db.FirstActionMinutes,
+		CloseMinutes:       db.CloseMinutes,
+		BreachAction:       models.SlaBreachAction(db.BreachAction),
+		CreatedAt:          db.CreatedAt,
+		UpdatedAt:          db.UpdatedAt,
+	}, nil
+}
+
+type DbCaseSlaBreach struct {
+	CaseId       string    `db:"case_id"`
+	OrgId        string    `db:"org_id"`
+	InboxId      string    `db:"inbox_id"`
+	PolicyId     string    `db:"policy_id"`
+	Kind         string    `db:"kind"`
+	DueAt        time.Time `db:"due_at"`
+	BreachAction string    `db:"breach_action"`
+}
+
+func AdaptCaseSlaBreach(db DbCaseSlaBreach) (models.CaseSlaBreach, error) {
+	return models.CaseSlaBreach{
+		CaseId:         db.CaseId,
+		OrganizationId: db.OrgId,
+		InboxId:        db.InboxId,
+		PolicyId:       db.PolicyId,
+		Kind:           models.SlaBreachKind(db.Kind),
+		DueAt:          db.DueAt,
+		BreachAction:   models.SlaBreachAction(db.BreachAction),
+	}, nil
+}
@@ -0,0 +1,98 @@
+package dbmodels
+
+import (
+	"time"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/utils"
+)
+
+type DbNotification struct {
+	Id          string     `db:"id"`
+	OrgId       string     `db:"org_id"`
+	UserId      string     `db:"user_id"`
+	Type        string     `db:"type"`
+	CaseId      *string    `db:"case_id"`
+	CaseEventId *string    `db:"case_event_id"`
+	ActorId     *string    `db:"actor_id"`
+	Message     string     `db:"message"`
+	ReadAt      *time.Time `db:"read_at"`
+	CreatedAt   time.Time  `db:"created_at"`
+}
+
+const TABLE_NOTIFICATIONS = "notifications"
+
+var SelectNotificationColumns = utils.ColumnList[DbNotification]()
+
+func AdaptNotification(db DbNotification) (models.Notification, error) {
+	return models.Notification{
+		Id:             db.Id,
+		OrganizationId: db.OrgId,
+		UserId:         db.UserId,
+		Type:           models.NotificationType(db.Type),
+		CaseId:         db.CaseId,
+		CaseEventId:    db.CaseEventId,
+		ActorId:        db.ActorId,
+		Message:        db.Message,
+		ReadAt:         db.ReadAt,
+		CreatedAt:      db.CreatedAt,
+	}, nil
+}
+
+type DbNotificationPreference struct {
+	UserId    string    `db:"user_id"`
+	Type      string    `db:"type"`
+	InApp     bool      `db:"in_app"`
+	Email     bool      `db:"email"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+const TABLE_NOTIFICATION_PREFERENCES = "notification_preferences"
+
+var SelectNotificationPreferenceColumns = utils.ColumnList[DbNotificationPreference]()
+
+func AdaptNotificationPreference(db DbNotificationPreference) (models.NotificationPreference, error) {
+	return models.NotificationPreference{
+		Type:  models.NotificationType(db.Type),
+		InApp: db.InApp,
+		Email: db.Email,
+	}, nil
+}
+
+type DbNotificationCursor struct {
+	OrgId            string    `db:"org_id"`
+	EventTxId        int64     `db:"event_txid"`
+	EventId          *string   `db:"event_id"`
+	SnoozesCheckedAt time.Time `db:"snoozes_checked_at"`
+}
+
+const TABLE_NOTIFICATION_CURSORS = "notification_cursors"
+
+// transaction ids are stored as xid8, which is read as a bigint
+var SelectNotificationCursorColumns = []string{
+	"org_id",
+	"event_txid::text::bigint as event_txid",
+	"event_id",
+	"snoozes_checked_at",
+}
+
+func AdaptNotificationCursor(db DbNotificationCursor) (models.NotificationCursor, error) {
+	return models.NotificationCursor{
+		EventTxId:        db.EventTxId,
+		EventId:          db.EventId,
+		SnoozesCheckedAt: db.SnoozesCheckedAt,
+	}, nil
+}
+
+type DbNotifiableCaseEvent struct {
+	DBCaseEvent
+	TxId int64 `db:"txid"`
+}
+
+func AdaptNotifiableCaseEvent(db DbNotifiableCaseEvent) (models.NotifiableCaseEvent, error) {
+	event, err := AdaptCaseEvent(db.DBCaseEvent)
+	if err != nil {
+		return models.NotifiableCaseEvent{}, err
+	}
+	return models.NotifiableCaseEvent{CaseEvent: event, TxId: db.TxId}, nil
+}
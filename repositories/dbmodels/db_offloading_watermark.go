@@ -30,3 +30,13 @@ func AdaptOffloadingWatermark(db DbOffloadingWatermark) (models.OffloadingWaterm
 		UpdatedAt:     db.UpdatedAt,
 	}, nil
 }
+
+type DbDecisionRuleRef struct {
+	DecisionId string `db:"decision_id"`
+	RuleId     string `db:"rule_id"`
+	Outcome    string `db:"outcome"`
+}
+
+func AdaptDecisionRuleRef(db DbDecisionRuleRef) (models.DecisionRuleRef, error) {
+	return models.DecisionRuleRef(db), nil
+}
@@ -0,0 +1,52 @@
+package dbmodels
+
+import (
+	"time"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/utils"
+)
+
+type DbRetentionPolicy struct {
+	Id                string    `db:"id"`
+	OrgId             string    `db:"org_id"`
+	TableName         string    `db:"table_name"`
+	Action            string    `db:"action"`
+	RetentionDays     int       `db:"retention_days"`
+	KeepLatestVersion bool      `db:"keep_latest_version"`
+	Enabled           bool      `db:"enabled"`
+	CreatedAt         time.Time `db:"created_at"`
+	UpdatedAt         time.Time `db:"updated_at"`
+}
+
+const TABLE_RETENTION_POLICIES = "retention_policies"
+
+var SelectRetentionPolicyColumns = utils.ColumnList[DbRetentionPolicy]()
+
+func AdaptRetentionPolicy(db DbRetentionPolicy) (models.RetentionPolicy, error) {
+	return models.RetentionPolicy{
+		Id:                db.Id,
+		OrganizationId:    db.OrgId,
+		TableName:         db.TableName,
+		Action:            models.RetentionPolicyActionFromString(db.Action),
+		RetentionDays:     db.RetentionDays,
+		KeepLatestVersion: db.KeepLatestVersion,
+		Enabled:           db.Enabled,
+		CreatedAt:         db.CreatedAt,
+		UpdatedAt:         db.UpdatedAt,
+	}, nil
+}
+
+type DbRetainedRow struct {
+	Id        string         `db:"id"`
+	CreatedAt time.Time      `db:"created_at"`
+	Data      map[string]any `db:"data"`
+}
+
+func AdaptRetainedRow(db DbRetainedRow) (models.RetainedRow, error) {
+	return models.RetainedRow{
+		Id:        db.Id,
+		CreatedAt: db.CreatedAt,
+		Data:      db.Data,
+	}, nil
+}
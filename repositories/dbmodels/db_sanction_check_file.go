@@ -13,6 +13,7 @@ type DBSanctionCheckFile struct {
 	BucketName      string    `db:"bucket_name"`
 	FileReference   string    `db:"file_reference"`
 	FileName        string    `db:"file_name"`
+	ScanStatus      string    `db:"scan_status"`
 	CreatedAt       time.Time `db:"created_at"`
 }
 
@@ -28,5 +29,6 @@ func AdaptSanctionCheckFile(db DBSanctionCheckFile) (models.SanctionCheckFile, e
 		BucketName:      db.BucketName,
 		FileName:        db.FileName,
 		FileReference:   db.FileReference,
+		ScanStatus:      models.FileScanStatusFrom(db.ScanStatus),
 	}, nil
 }
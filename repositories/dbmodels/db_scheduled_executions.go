@@ -19,6 +19,7 @@ type DBScheduledExecution struct {
 	NumberOfEvaluatedDecisions int        `db:"number_of_evaluated_decisions"`
 	NumberOfPlannedDecisions   *int       `db:"number_of_planned_decisions"`
 	Manual                     bool       `db:"manual"`
+	ReadAsOf                   *time.Time `db:"read_as_of"`
 }
 
 const TABLE_SCHEDULED_EXECUTIONS = "scheduled_executions"
@@ -39,5 +40,6 @@ func AdaptScheduledExecution(db DBScheduledExecution, scenario models.Scenario)
 		NumberOfPlannedDecisions:   db.NumberOfPlannedDecisions,
 		Scenario:                   scenario,
 		Manual:                     db.Manual,
+		ReadAsOf:                   db.ReadAsOf,
 	}
 }
@@ -1,6 +1,7 @@
 package dbmodels
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/checkmarble/marble-backend/models"
@@ -14,10 +15,12 @@ type DbSuspiciousActivityReport struct {
 	Status     string     `db:"status"`
 	Bucket     *string    `db:"bucket"`
 	BlobKey    *string    `db:"blob_key"`
+	ScanStatus string     `db:"scan_status"`
 	CreatedBy  string     `db:"created_by"`
 	UploadedBy *string    `db:"uploaded_by"`
 	CreatedAt  time.Time  `db:"created_at"`
 	DeletedAt  *time.Time `db:"deleted_at"`
+	Content    []byte     `db:"content"`
 }
 
 const TABLE_SUSPICIOUS_ACTIVITY_REPORTS = "suspicious_activity_reports"
@@ -32,11 +35,20 @@ func AdaptSuspiciousActivityReport(db DbSuspiciousActivityReport) (models.Suspic
 		Status:     models.SarStatusFromString(db.Status),
 		Bucket:     db.Bucket,
 		BlobKey:    db.BlobKey,
+		ScanStatus: models.FileScanStatusFrom(db.ScanStatus),
 		CreatedBy:  db.CreatedBy,
 		UploadedBy: db.UploadedBy,
 		CreatedAt:  db.CreatedAt,
 		DeletedAt:  db.DeletedAt,
 	}
 
+	if db.Content != nil {
+		var content models.SarContent
+		if err := json.Unmarshal(db.Content, &content); err != nil {
+			return models.SuspiciousActivityReport{}, err
+		}
+		sar.Content = &content
+	}
+
 	return sar, nil
 }
@@ -0,0 +1,131 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/repositories/dbmodels"
+	"github.com/jackc/pgx/v5"
+)
+
+func (repo *MarbleDbRepository) CreateDecisionExport(ctx context.Context, exec Executor,
+	input models.DecisionExportCreate,
+) (models.DecisionExport, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return models.DecisionExport{}, err
+	}
+
+	filters, err := dbmodels.SerializeDecisionFilters(input.Filters)
+	if err != nil {
+		return models.DecisionExport{}, err
+	}
+	var apiKey *string
+	if input.RequestedByApiKey != "" {
+		apiKey = &input.RequestedByApiKey
+	}
+
+	sql := NewQueryBuilder().
+		Insert(dbmodels.TABLE_DECISION_EXPORTS).
+		Columns(
+			"org_id",
+			"format",
+			"filters",
+			"status",
+			"requested_by_user_id",
+			"requested_by_api_key",
+		).
+		Values(
+			input.OrganizationId,
+			input.Format.String(),
+			filters,
+			models.DecisionExportPending,
+			input.RequestedByUserId,
+			apiKey,
+		).
+		Suffix(fmt.Sprintf("RETURNING %s", strings.Join(dbmodels.SelectDecisionExportColumns, ",")))
+
+	return SqlToModel(ctx, exec, sql, dbmodels.AdaptDecisionExport)
+}
+
+func (repo *MarbleDbRepository) GetDecisionExport(ctx context.Context, exec Executor, id string) (models.DecisionExport, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return models.DecisionExport{}, err
+	}
+
+	sql := NewQueryBuilder().
+		Select(dbmodels.SelectDecisionExportColumns...).
+		From(dbmodels.TABLE_DECISION_EXPORTS).
+		Where(squirrel.Eq{"id": id})
+
+	return SqlToModel(ctx, exec, sql, dbmodels.AdaptDecisionExport)
+}
+
+func (repo *MarbleDbRepository) ListDecisionExports(ctx context.Context, exec Executor, orgId string) ([]models.DecisionExport, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	sql := NewQueryBuilder().
+		Select(dbmodels.SelectDecisionExportColumns...).
+		From(dbmodels.TABLE_DECISION_EXPORTS).
+		Where(squirrel.Eq{"org_id": orgId}).
+		OrderBy("created_at desc")
+
+	return SqlToListOfModels(ctx, exec, sql, dbmodels.AdaptDecisionExport)
+}
+
+func (repo *MarbleDbRepository) UpdateDecisionExport(ctx context.Context, exec Executor, id string,
+	update models.DecisionExportUpdate,
+) error {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return err
+	}
+
+	sql := NewQueryBuilder().
+		Update(dbmodels.TABLE_DECISION_EXPORTS).
+		Set("status", update.Status).
+		Set("nb_files", update.NbFiles).
+		Set("nb_decisions", update.NbDecisions).
+		Set("cursor_decision_id", update.CursorDecisionId).
+		Set("updated_at", squirrel.Expr("now()")).
+		Where(squirrel.Eq{"id": id})
+
+	return ExecBuilder(ctx, exec, sql)
+}
+
+// ListSanctionCheckStatusesOfDecisions returns the status of the current (non archived) sanction check of each
+// decision that has one, by decision id.
+func (repo *MarbleDbRepository) ListSanctionCheckStatusesOfDecisions(ctx context.Context, exec Executor,
+	decisionIds []string,
+) (map[string]string, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	sql := NewQueryBuilder().
+		Select("decision_id", "status").
+		From(dbmodels.TABLE_SANCTION_CHECKS).
+		Where(squirrel.Eq{"decision_id": decisionIds, "is_archived": false})
+
+	type decisionStatus struct {
+		decisionId string
+		status     string
+	}
+	rows, err := SqlToListOfRow(ctx, exec, sql, func(row pgx.CollectableRow) (decisionStatus, error) {
+		var s decisionStatus
+		err := row.Scan(&s.decisionId, &s.status)
+		return s, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make(map[string]string, len(rows))
+	for _, row := range rows {
+		statuses[row.decisionId] = row.status
+	}
+	return statuses, nil
+}
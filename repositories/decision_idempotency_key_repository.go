@@ -0,0 +1,97 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/repositories/dbmodels"
+)
+
+// GetDecisionIdempotencyKey returns the idempotency key if it was used and has not expired yet
+func (repo *MarbleDbRepository) GetDecisionIdempotencyKey(ctx context.Context, exec Executor,
+	orgId, actor, key string,
+) (models.DecisionIdempotencyKey, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return models.DecisionIdempotencyKey{}, err
+	}
+
+	sql := NewQueryBuilder().
+		Select(dbmodels.SelectDecisionIdempotencyKeyColumns...).
+		From(dbmodels.TABLE_DECISION_IDEMPOTENCY_KEYS).
+		Where(squirrel.Eq{
+			"org_id": orgId,
+			"actor":  actor,
+			"key":    key,
+		}).
+		Where("expires_at > now()")
+
+	return SqlToModel(ctx, exec, sql, dbmodels.AdaptDecisionIdempotencyKey)
+}
+
+// CreateDecisionIdempotencyKey stores an idempotency key, replacing it if it has expired. It returns false if the key
+// is already in use.
+func (repo *MarbleDbRepository) CreateDecisionIdempotencyKey(ctx context.Context, exec Executor,
+	input models.DecisionIdempotencyKeyCreate,
+) (bool, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return false, err
+	}
+
+	decisionIds := input.DecisionIds
+	if decisionIds == nil {
+		decisionIds = []string{}
+	}
+
+	sql := NewQueryBuilder().
+		Insert(dbmodels.TABLE_DECISION_IDEMPOTENCY_KEYS).
+		Columns(
+			"org_id",
+			"actor",
+			"key",
+			"request_hash",
+			"trigger_passed",
+			"decision_ids",
+			"nb_skipped",
+			"expires_at",
+		).
+		Values(
+			input.OrganizationId,
+			input.Actor,
+			input.Key,
+			input.RequestHash,
+			input.TriggerPassed,
+			decisionIds,
+			input.NbSkipped,
+			input.ExpiresAt,
+		).
+		Suffix(`on conflict (org_id, actor, key) do update set
+			request_hash = excluded.request_hash,
+			trigger_passed = excluded.trigger_passed,
+			decision_ids = excluded.decision_ids,
+			nb_skipped = excluded.nb_skipped,
+			created_at = now(),
+			expires_at = excluded.expires_at
+			where decision_idempotency_keys.expires_at <= now()`)
+
+	nbRows, err := ExecBuilderRowsAffected(ctx, exec, sql)
+	if err != nil {
+		return false, err
+	}
+	return nbRows > 0, nil
+}
+
+func (repo *MarbleDbRepository) DeleteExpiredDecisionIdempotencyKeys(ctx context.Context, exec Executor,
+	orgId string,
+) (int, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return 0, err
+	}
+
+	sql := NewQueryBuilder().
+		Delete(dbmodels.TABLE_DECISION_IDEMPOTENCY_KEYS).
+		Where(squirrel.Eq{"org_id": orgId}).
+		Where("expires_at <= now()")
+
+	return ExecBuilderRowsAffected(ctx, exec, sql)
+}
@@ -0,0 +1,184 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/repositories/dbmodels"
+	"github.com/jackc/pgx/v5"
+)
+
+// decisionLabelAppliesToDecision is the condition for a label "l" to apply to a decision "d": the label is either
+// attached to the decision, or to its trigger object.
+const decisionLabelAppliesToDecision = `l.org_id = d.org_id and (
+	l.decision_id = d.id
+	or (l.object_type = d.trigger_object_type and l.object_id = d.trigger_object->>'object_id')
+)`
+
+func decisionHasLabel(labels []string) squirrel.Sqlizer {
+	condition := fmt.Sprintf("exists (select 1 from %s as l where %s", dbmodels.TABLE_DECISION_LABELS,
+		decisionLabelAppliesToDecision)
+	if len(labels) == 0 {
+		return squirrel.Expr(condition + ")")
+	}
+	return squirrel.Expr(condition+" and l.label = any(?))", labels)
+}
+
+func (repo *MarbleDbRepository) CreateDecisionLabels(ctx context.Context, exec Executor,
+	inputs []models.CreateDecisionLabelInput,
+) ([]models.DecisionLabel, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	sql := NewQueryBuilder().
+		Insert(dbmodels.TABLE_DECISION_LABELS).
+		Columns(
+			"org_id",
+			"decision_id",
+			"object_type",
+			"object_id",
+			"label",
+			"amount",
+			"currency",
+			"source",
+			"occurred_at",
+		)
+	for _, input := range inputs {
+		sql = sql.Values(
+			input.OrganizationId,
+			input.DecisionId,
+			input.ObjectType,
+			input.ObjectId,
+			input.Label,
+			input.Amount,
+			input.Currency,
+			input.Source,
+			input.OccurredAt,
+		)
+	}
+	sql = sql.Suffix(fmt.Sprintf("RETURNING %s", strings.Join(dbmodels.SelectDecisionLabelColumns, ",")))
+
+	return SqlToListOfModels(ctx, exec, sql, dbmodels.AdaptDecisionLabel)
+}
+
+func (repo *MarbleDbRepository) GetDecisionLabel(ctx context.Context, exec Executor, id string) (models.DecisionLabel, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return models.DecisionLabel{}, err
+	}
+
+	sql := NewQueryBuilder().
+		Select(dbmodels.SelectDecisionLabelColumns...).
+		From(dbmodels.TABLE_DECISION_LABELS).
+		Where(squirrel.Eq{"id": id})
+
+	return SqlToModel(ctx, exec, sql, dbmodels.AdaptDecisionLabel)
+}
+
+func (repo *MarbleDbRepository) DeleteDecisionLabel(ctx context.Context, exec Executor, id string) error {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return err
+	}
+
+	sql := NewQueryBuilder().
+		Delete(dbmodels.TABLE_DECISION_LABELS).
+		Where(squirrel.Eq{"id": id})
+
+	return ExecBuilder(ctx, exec, sql)
+}
+
+// PseudonymiseDecisionLabelsForErasure pseudonymises the object id of the labels attached to erased objects, or to the
+// trigger objects of erased decisions, so that they keep applying to the pseudonymised decisions. It must run before
+// the decisions are pseudonymised.
+func (repo *MarbleDbRepository) PseudonymiseDecisionLabelsForErasure(ctx context.Context, exec Executor,
+	orgId, objectType string, objectIds []string, decisionIds []string,
+) (int, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return 0, err
+	}
+
+	sql := NewQueryBuilder().
+		Update(dbmodels.TABLE_DECISION_LABELS+" as l").
+		Set("object_id", squirrel.Expr(erasurePseudonymSql("l.object_id"), orgId)).
+		Where(squirrel.Eq{"l.org_id": orgId}).
+		Where(squirrel.Or{
+			squirrel.Eq{"l.object_type": objectType, "l.object_id": objectIds},
+			squirrel.Expr(fmt.Sprintf(`exists (
+				select 1 from %s as d
+				where d.id = any(?) and l.object_type = d.trigger_object_type and l.object_id = d.trigger_object->>'object_id'
+			)`, dbmodels.TABLE_DECISIONS), decisionIds),
+		})
+
+	return ExecBuilderRowsAffected(ctx, exec, sql)
+}
+
+// ListLabelsOfDecisions returns the labels that apply to each of the decisions, indexed by decision id
+func (repo *MarbleDbRepository) ListLabelsOfDecisions(ctx context.Context, exec Executor,
+	decisionIds []string,
+) (map[string][]models.DecisionLabel, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	sql := NewQueryBuilder().
+		Select(columnsNames("l", dbmodels.SelectDecisionLabelColumns)...).
+		Column("d.id as labelled_decision_id").
+		From(dbmodels.TABLE_DECISIONS + " as d").
+		Join(dbmodels.TABLE_DECISION_LABELS + " as l on " + decisionLabelAppliesToDecision).
+		Where(squirrel.Eq{"d.id": decisionIds}).
+		OrderBy("l.occurred_at, l.id")
+
+	labels, err := SqlToListOfRow(ctx, exec, sql, pgx.RowToStructByName[dbmodels.DbLabelOfDecision])
+	if err != nil {
+		return nil, err
+	}
+
+	labelsByDecision := make(map[string][]models.DecisionLabel, len(decisionIds))
+	for _, label := range labels {
+		adapted, err := dbmodels.AdaptDecisionLabel(label.DbDecisionLabel)
+		if err != nil {
+			return nil, err
+		}
+		labelsByDecision[label.LabelledDecisionId] = append(labelsByDecision[label.LabelledDecisionId], adapted)
+	}
+	return labelsByDecision, nil
+}
+
+// ListTriggeredRuleNamesOfDecisions returns the names of the rules that matched in each of the decisions, indexed by
+// decision id
+func (repo *MarbleDbRepository) ListTriggeredRuleNamesOfDecisions(ctx context.Context, exec Executor,
+	decisionIds []string,
+) (map[string][]string, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	sql := NewQueryBuilder().
+		Select("dr.decision_id", "r.name").
+		From(dbmodels.TABLE_DECISION_RULES + " as dr").
+		Join(dbmodels.TABLE_RULES + " as r on r.id = dr.rule_id").
+		Where(squirrel.Eq{"dr.decision_id": decisionIds, "dr.result": true}).
+		OrderBy("dr.decision_id, r.name")
+
+	type triggeredRule struct {
+		decisionId string
+		name       string
+	}
+	rules, err := SqlToListOfRow(ctx, exec, sql, func(row pgx.CollectableRow) (triggeredRule, error) {
+		var rule triggeredRule
+		err := row.Scan(&rule.decisionId, &rule.name)
+		return rule, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rulesByDecision := make(map[string][]string, len(decisionIds))
+	for _, rule := range rules {
+		rulesByDecision[rule.decisionId] = append(rulesByDecision[rule.decisionId], rule.name)
+	}
+	return rulesByDecision, nil
+}
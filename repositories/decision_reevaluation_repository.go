@@ -0,0 +1,155 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/repositories/dbmodels"
+)
+
+// decisionIsReevaluation is the condition for a decision "d" to have been made by re-evaluating another decision
+func decisionIsReevaluation() squirrel.Sqlizer {
+	return squirrel.Expr(fmt.Sprintf("exists (select 1 from %s as r where r.reevaluation_decision_id = d.id)",
+		dbmodels.TABLE_DECISION_REEVALUATIONS))
+}
+
+func (repo *MarbleDbRepository) CreateDecisionReevaluation(ctx context.Context, exec Executor,
+	input models.DecisionReevaluationCreate,
+) (models.DecisionReevaluation, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return models.DecisionReevaluation{}, err
+	}
+
+	var outcome *string
+	if input.Outcome != nil {
+		o := input.Outcome.String()
+		outcome = &o
+	}
+
+	sql := NewQueryBuilder().
+		Insert(dbmodels.TABLE_DECISION_REEVALUATIONS).
+		Columns(
+			"org_id",
+			"decision_id",
+			"reevaluation_decision_id",
+			"scenario_iteration_id",
+			"outcome",
+			"score",
+			"batch_id",
+		).
+		Values(
+			input.OrganizationId,
+			input.DecisionId,
+			input.ReevaluationDecisionId,
+			input.ScenarioIterationId,
+			outcome,
+			input.Score,
+			input.BatchId,
+		).
+		Suffix(fmt.Sprintf("RETURNING %s", strings.Join(dbmodels.SelectDecisionReevaluationColumns, ",")))
+
+	return SqlToModel(ctx, exec, sql, dbmodels.AdaptDecisionReevaluation)
+}
+
+// ListReevaluationsOfDecisions returns the re-evaluations of each of the decisions, most recent first, indexed by
+// decision id
+func (repo *MarbleDbRepository) ListReevaluationsOfDecisions(ctx context.Context, exec Executor,
+	decisionIds []string,
+) (map[string][]models.DecisionReevaluation, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	sql := NewQueryBuilder().
+		Select(dbmodels.SelectDecisionReevaluationColumns...).
+		From(dbmodels.TABLE_DECISION_REEVALUATIONS).
+		Where(squirrel.Eq{"decision_id": decisionIds}).
+		OrderBy("created_at desc, id")
+
+	reevaluations, err := SqlToListOfModels(ctx, exec, sql, dbmodels.AdaptDecisionReevaluation)
+	if err != nil {
+		return nil, err
+	}
+
+	reevaluationsByDecision := make(map[string][]models.DecisionReevaluation, len(decisionIds))
+	for _, r := range reevaluations {
+		reevaluationsByDecision[r.DecisionId] = append(reevaluationsByDecision[r.DecisionId], r)
+	}
+	return reevaluationsByDecision, nil
+}
+
+func (repo *MarbleDbRepository) CreateDecisionReevaluationBatch(ctx context.Context, exec Executor,
+	input models.DecisionReevaluationBatchCreate,
+) (models.DecisionReevaluationBatch, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return models.DecisionReevaluationBatch{}, err
+	}
+
+	filters, err := dbmodels.SerializeDecisionFilters(input.Filters)
+	if err != nil {
+		return models.DecisionReevaluationBatch{}, err
+	}
+	var apiKey *string
+	if input.RequestedByApiKey != "" {
+		apiKey = &input.RequestedByApiKey
+	}
+
+	sql := NewQueryBuilder().
+		Insert(dbmodels.TABLE_DECISION_REEVALUATION_BATCHES).
+		Columns(
+			"org_id",
+			"scenario_iteration_id",
+			"filters",
+			"status",
+			"requested_by_user_id",
+			"requested_by_api_key",
+		).
+		Values(
+			input.OrganizationId,
+			input.ScenarioIterationId,
+			filters,
+			models.DecisionReevaluationBatchPending,
+			input.RequestedByUserId,
+			apiKey,
+		).
+		Suffix(fmt.Sprintf("RETURNING %s", strings.Join(dbmodels.SelectDecisionReevaluationBatchColumns, ",")))
+
+	return SqlToModel(ctx, exec, sql, dbmodels.AdaptDecisionReevaluationBatch)
+}
+
+func (repo *MarbleDbRepository) GetDecisionReevaluationBatch(ctx context.Context, exec Executor,
+	id string,
+) (models.DecisionReevaluationBatch, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return models.DecisionReevaluationBatch{}, err
+	}
+
+	sql := NewQueryBuilder().
+		Select(dbmodels.SelectDecisionReevaluationBatchColumns...).
+		From(dbmodels.TABLE_DECISION_REEVALUATION_BATCHES).
+		Where(squirrel.Eq{"id": id})
+
+	return SqlToModel(ctx, exec, sql, dbmodels.AdaptDecisionReevaluationBatch)
+}
+
+func (repo *MarbleDbRepository) UpdateDecisionReevaluationBatch(ctx context.Context, exec Executor, id string,
+	update models.DecisionReevaluationBatchUpdate,
+) error {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return err
+	}
+
+	sql := NewQueryBuilder().
+		Update(dbmodels.TABLE_DECISION_REEVALUATION_BATCHES).
+		Set("status", update.Status).
+		Set("nb_decisions", update.NbDecisions).
+		Set("nb_errors", update.NbErrors).
+		Set("cursor_decision_id", update.CursorDecisionId).
+		Set("updated_at", squirrel.Expr("now()")).
+		Where(squirrel.Eq{"id": id})
+
+	return ExecBuilder(ctx, exec, sql)
+}
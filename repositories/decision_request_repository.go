@@ -0,0 +1,104 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/repositories/dbmodels"
+)
+
+func (repo *MarbleDbRepository) CreateDecisionRequest(ctx context.Context, exec Executor,
+	input models.DecisionRequestCreate,
+) (models.DecisionRequest, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return models.DecisionRequest{}, err
+	}
+
+	var apiKey *string
+	if input.RequestedByApiKey != "" {
+		apiKey = &input.RequestedByApiKey
+	}
+
+	sql := NewQueryBuilder().
+		Insert(dbmodels.TABLE_DECISION_REQUESTS).
+		Columns(
+			"id",
+			"org_id",
+			"scenario_id",
+			"trigger_object_type",
+			"payload",
+			"read_as_of",
+			"status",
+			"fallback_outcome",
+			"deadline",
+			"requested_by_user_id",
+			"requested_by_api_key",
+		).
+		Values(
+			input.Id,
+			input.OrganizationId,
+			input.ScenarioId,
+			input.TriggerObjectTable,
+			input.PayloadRaw,
+			input.ReadAsOf,
+			models.DecisionRequestPending,
+			input.FallbackOutcome.String(),
+			input.Deadline,
+			input.RequestedByUserId,
+			apiKey,
+		).
+		Suffix(fmt.Sprintf("RETURNING %s", strings.Join(dbmodels.SelectDecisionRequestColumns, ",")))
+
+	return SqlToModel(ctx, exec, sql, dbmodels.AdaptDecisionRequest)
+}
+
+func (repo *MarbleDbRepository) GetDecisionRequest(ctx context.Context, exec Executor, id string,
+	forUpdate bool,
+) (models.DecisionRequest, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return models.DecisionRequest{}, err
+	}
+
+	sql := NewQueryBuilder().
+		Select(dbmodels.SelectDecisionRequestColumns...).
+		From(dbmodels.TABLE_DECISION_REQUESTS).
+		Where(squirrel.Eq{"id": id})
+	if forUpdate {
+		sql = sql.Suffix("for update")
+	}
+
+	return SqlToModel(ctx, exec, sql, dbmodels.AdaptDecisionRequest)
+}
+
+// CompleteDecisionRequest records the result of a request that is still pending, and returns false if the request
+// already had a result.
+func (repo *MarbleDbRepository) CompleteDecisionRequest(ctx context.Context, exec Executor, id string,
+	result models.DecisionRequestResult,
+) (bool, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return false, err
+	}
+
+	var outcome *string
+	if result.Outcome != nil {
+		o := result.Outcome.String()
+		outcome = &o
+	}
+
+	sql := NewQueryBuilder().
+		Update(dbmodels.TABLE_DECISION_REQUESTS).
+		Set("status", result.Status).
+		Set("outcome", outcome).
+		Set("error", result.Error).
+		Set("updated_at", squirrel.Expr("now()")).
+		Where(squirrel.Eq{"id": id, "status": models.DecisionRequestPending})
+
+	rowsAffected, err := ExecBuilderRowsAffected(ctx, exec, sql)
+	if err != nil {
+		return false, err
+	}
+	return rowsAffected > 0, nil
+}
@@ -179,6 +179,10 @@ func (repo *MarbleDbRepository) DecisionsWithRuleExecutionsByIds(
 	if err != nil {
 		return nil, err
 	}
+	labels, err := repo.ListLabelsOfDecisions(ctx, exec, decisionIds)
+	if err != nil {
+		return nil, err
+	}
 
 	return SqlToListOfRow(
 		ctx,
@@ -200,11 +204,13 @@ func (repo *MarbleDbRepository) DecisionsWithRuleExecutionsByIds(
 				decisionCase = &decisionCaseValue
 			}
 
-			return dbmodels.AdaptDecisionWithRuleExecutions(
+			decision := dbmodels.AdaptDecisionWithRuleExecutions(
 				db.DbDecision,
 				rules[db.DbDecision.Id],
 				decisionCase,
-			), nil
+			)
+			decision.Labels = labels[db.DbDecision.Id]
+			return decision, nil
 		},
 	)
 }
@@ -298,8 +304,21 @@ func (repo *MarbleDbRepository) DecisionsByCaseIdFromCursor(
 	}
 
 	hasMore := len(decisions) > req.Limit
+	decisions = decisions[:min(len(decisions), req.Limit)]
+
+	decisionIds := make([]string, len(decisions))
+	for i, d := range decisions {
+		decisionIds[i] = d.DecisionId
+	}
+	reevaluations, err := repo.ListReevaluationsOfDecisions(ctx, exec, decisionIds)
+	if err != nil {
+		return nil, false, err
+	}
+	for i := range decisions {
+		decisions[i].Reevaluations = reevaluations[decisions[i].DecisionId]
+	}
 
-	return decisions[:min(len(decisions), req.Limit)], hasMore, nil
+	return decisions, hasMore, nil
 }
 
 func (repo *MarbleDbRepository) DecisionsByObjectId(
@@ -515,6 +534,18 @@ func selectDecisionsWithFilters(
 	if filters.PivotValue != nil {
 		query = query.Where(squirrel.Eq{"d.pivot_value": *filters.PivotValue})
 	}
+	if len(filters.Labels) > 0 {
+		query = query.Where(decisionHasLabel(filters.Labels))
+	}
+	if filters.HasLabel != nil && *filters.HasLabel {
+		query = query.Where(decisionHasLabel(nil))
+	}
+	if filters.HasLabel != nil && !*filters.HasLabel {
+		query = query.Where(squirrel.Expr("not ?", decisionHasLabel(nil)))
+	}
+	if filters.ExcludeReevaluations {
+		query = query.Where(squirrel.Expr("not ?", decisionIsReevaluation()))
+	}
 
 	// only if we want to filter by case inbox id, join the cases table
 	if len(filters.CaseInboxIds) > 0 {
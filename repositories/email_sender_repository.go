@@ -0,0 +1,155 @@
+package repositories
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"time"
+
+	"github.com/checkmarble/marble-backend/infra"
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/cockroachdb/errors"
+)
+
+// EmailSender sends the notification emails.
+type EmailSender interface {
+	// Enabled is false if no mail server is configured, in which case emails are silently dropped
+	Enabled() bool
+	Send(ctx context.Context, email models.Email) error
+}
+
+// NewEmailSender returns a sender using the configured SMTP server, or a sender that does not send anything if no
+// server is configured.
+func NewEmailSender(config infra.SmtpConfig) EmailSender {
+	if !config.IsSet() {
+		return NoopEmailSender{}
+	}
+	return NewSmtpEmailSender(config)
+}
+
+type NoopEmailSender struct{}
+
+func (NoopEmailSender) Enabled() bool { return false }
+
+func (NoopEmailSender) Send(ctx context.Context, email models.Email) error { return nil }
+
+const smtpTimeout = 30 * time.Second
+
+// SmtpEmailSender sends plain text emails through an SMTP server. The connection is upgraded with STARTTLS when the
+// server supports it, and the credentials, if any, are only sent over an encrypted connection (or to localhost).
+type SmtpEmailSender struct {
+	host     string
+	address  string
+	username string
+	password string
+	from     string
+	timeout  time.Duration
+}
+
+func NewSmtpEmailSender(config infra.SmtpConfig) SmtpEmailSender {
+	port := config.Port
+	if port == 0 {
+		port = 587
+	}
+	return SmtpEmailSender{
+		host:     config.Host,
+		address:  net.JoinHostPort(config.Host, strconv.Itoa(port)),
+		username: config.Username,
+		password: config.Password,
+		from:     config.From,
+		timeout:  smtpTimeout,
+	}
+}
+
+func (s SmtpEmailSender) Enabled() bool { return true }
+
+func (s SmtpEmailSender) Send(ctx context.Context, email models.Email) error {
+	from, err := mail.ParseAddress(s.from)
+	if err != nil {
+		return errors.Wrap(err, "invalid sender address")
+	}
+	to, err := mail.ParseAddress(email.To)
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("invalid recipient address %s", email.To))
+	}
+	message, err := buildEmailMessage(from, to, email, time.Now())
+	if err != nil {
+		return err
+	}
+
+	dialer := net.Dialer{Timeout: s.timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", s.address)
+	if err != nil {
+		return errors.Wrap(err, "could not connect to the SMTP server")
+	}
+	defer conn.Close()
+	if err := conn.SetDeadline(time.Now().Add(s.timeout)); err != nil {
+		return err
+	}
+
+	client, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		return errors.Wrap(err, "could not start the SMTP session")
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: s.host}); err != nil {
+			return errors.Wrap(err, "could not start TLS with the SMTP server")
+		}
+	}
+	if s.username != "" {
+		if err := client.Auth(smtp.PlainAuth("", s.username, s.password, s.host)); err != nil {
+			return errors.Wrap(err, "could not authenticate with the SMTP server")
+		}
+	}
+
+	if err := client.Mail(from.Address); err != nil {
+		return errors.Wrap(err, "SMTP server rejected the sender")
+	}
+	if err := client.Rcpt(to.Address); err != nil {
+		return errors.Wrap(err, "SMTP server rejected the recipient")
+	}
+	w, err := client.Data()
+	if err != nil {
+		return errors.Wrap(err, "SMTP server rejected the message")
+	}
+	if _, err := w.Write(message); err != nil {
+		return errors.Wrap(err, "could not send the message to the SMTP server")
+	}
+	if err := w.Close(); err != nil {
+		return errors.Wrap(err, "SMTP server rejected the message")
+	}
+
+	return client.Quit()
+}
+
+func buildEmailMessage(from, to *mail.Address, email models.Email, date time.Time) ([]byte, error) {
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", from.String())
+	fmt.Fprintf(&msg, "To: %s\r\n", to.String())
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", email.Subject))
+	fmt.Fprintf(&msg, "Date: %s\r\n", date.Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	msg.WriteString("Content-Transfer-Encoding: quoted-printable\r\n")
+	msg.WriteString("\r\n")
+
+	w := quotedprintable.NewWriter(&msg)
+	if _, err := w.Write(bytes.ReplaceAll([]byte(email.Body), []byte("\n"), []byte("\r\n"))); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	msg.WriteString("\r\n")
+
+	return msg.Bytes(), nil
+}
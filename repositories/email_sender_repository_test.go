@@ -0,0 +1,142 @@
+package repositories
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/checkmarble/marble-backend/infra"
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/stretchr/testify/assert"
+)
+
+type stubSmtpMessage struct {
+	from string
+	to   []string
+	data string
+}
+
+// startStubSmtpServer starts a server that implements the minimal SMTP exchange needed to send a message, without
+// STARTTLS or authentication, and sends the messages it receives to the returned channel.
+func startStubSmtpServer(t *testing.T) (string, int, <-chan stubSmtpMessage) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	messages := make(chan stubSmtpMessage, 10)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				tp := textproto.NewConn(conn)
+				_ = tp.PrintfLine("220 localhost stub SMTP")
+
+				var msg stubSmtpMessage
+				for {
+					line, err := tp.ReadLine()
+					if err != nil {
+						return
+					}
+					command := strings.ToUpper(line)
+					switch {
+					case strings.HasPrefix(command, "EHLO"), strings.HasPrefix(command, "HELO"):
+						_ = tp.PrintfLine("250 localhost")
+					case strings.HasPrefix(command, "MAIL FROM:"):
+						msg.from = strings.Trim(line[len("MAIL FROM:"):], "<>")
+						_ = tp.PrintfLine("250 OK")
+					case strings.HasPrefix(command, "RCPT TO:"):
+						msg.to = append(msg.to, strings.Trim(line[len("RCPT TO:"):], "<>"))
+						_ = tp.PrintfLine("250 OK")
+					case command == "DATA":
+						_ = tp.PrintfLine("354 end data with <CR><LF>.<CR><LF>")
+						data, err := io.ReadAll(tp.DotReader())
+						if err != nil {
+							return
+						}
+						msg.data = string(data)
+						messages <- msg
+						_ = tp.PrintfLine("250 OK")
+					case command == "QUIT":
+						_ = tp.PrintfLine("221 bye")
+						return
+					default:
+						_ = tp.PrintfLine("502 command not implemented")
+					}
+				}
+			}()
+		}
+	}()
+
+	host, port, _ := net.SplitHostPort(listener.Addr().String())
+	portNumber, _ := strconv.Atoi(port)
+	return host, portNumber, messages
+}
+
+func TestSmtpSendEmail(t *testing.T) {
+	host, port, messages := startStubSmtpServer(t)
+	sender := NewEmailSender(infra.SmtpConfig{
+		Host: host,
+		Port: port,
+		From: "Marble <notifications@marble.test>",
+	})
+	assert.True(t, sender.Enabled())
+
+	err := sender.Send(context.TODO(), models.Email{
+		To:      "analyst@marble.test",
+		Subject: "Case assigned: « Suspicious transfers »",
+		Body:    "You were assigned the case\nhttps://app.marble.test/cases/1",
+	})
+	assert.NoError(t, err)
+
+	msg := <-messages
+	assert.Equal(t, "notifications@marble.test", msg.from)
+	assert.Equal(t, []string{"analyst@marble.test"}, msg.to)
+
+	parsed, err := mail.ReadMessage(bufio.NewReader(strings.NewReader(msg.data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	subject, err := new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject"))
+	assert.NoError(t, err)
+	assert.Equal(t, "Case assigned: « Suspicious transfers »", subject)
+	assert.Equal(t, "<analyst@marble.test>", parsed.Header.Get("To"))
+
+	body, err := io.ReadAll(quotedprintable.NewReader(parsed.Body))
+	assert.NoError(t, err)
+	// the dot reader of the stub server normalizes line endings
+	assert.Equal(t, "You were assigned the case\nhttps://app.marble.test/cases/1\n", string(body))
+}
+
+func TestSmtpInvalidRecipient(t *testing.T) {
+	host, port, _ := startStubSmtpServer(t)
+	sender := NewEmailSender(infra.SmtpConfig{Host: host, Port: port, From: "notifications@marble.test"})
+
+	err := sender.Send(context.TODO(), models.Email{
+		To:      "analyst@marble.test\r\nBcc: someone@example.com",
+		Subject: "subject",
+		Body:    "body",
+	})
+
+	assert.Error(t, err)
+}
+
+func TestNoEmailIfNotConfigured(t *testing.T) {
+	sender := NewEmailSender(infra.SmtpConfig{})
+
+	assert.False(t, sender.Enabled())
+	assert.NoError(t, sender.Send(context.TODO(), models.Email{To: "analyst@marble.test"}))
+}
@@ -0,0 +1,241 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/repositories/dbmodels"
+	"github.com/google/uuid"
+)
+
+// erasurePseudonymSql computes, for a sql text expression, the same pseudonym as models.ErasurePseudonym. The first
+// placeholder is the organization id.
+func erasurePseudonymSql(expr string) string {
+	return fmt.Sprintf("'erased_' || encode(sha256(convert_to(?::text || ':' || %s, 'UTF8')), 'hex')", expr)
+}
+
+// ListDecisionsForErasure returns the decisions whose trigger object is one of the erased objects, or whose pivot value
+// is the erased pivot value.
+func (repo *MarbleDbRepository) ListDecisionsForErasure(ctx context.Context, exec Executor, orgId string,
+	objectType string, objectIds []string, pivotValue string,
+) ([]models.ErasableDecision, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	subject := squirrel.Or{squirrel.And{
+		squirrel.Eq{"d.trigger_object_type": objectType},
+		squirrel.Expr("d.trigger_object->>'object_id' = any(?)", objectIds),
+	}}
+	if pivotValue != "" {
+		subject = append(subject, squirrel.Eq{"d.pivot_value": pivotValue})
+	}
+
+	sql := NewQueryBuilder().
+		Select(
+			"d.id",
+			"d.case_id",
+			"d.created_at",
+			"d.trigger_object_type",
+			"d.trigger_object->>'object_id' as object_id",
+			decisionOnLegalHold+" as on_legal_hold",
+		).
+		From(dbmodels.TABLE_DECISIONS + " d").
+		Where(squirrel.Eq{"d.org_id": orgId}).
+		Where(subject)
+
+	return SqlToListOfModels(ctx, exec, sql, dbmodels.AdaptErasableDecision)
+}
+
+// PseudonymiseDecisions strips the trigger object of decisions down to a pseudonymised object id, and pseudonymises
+// their pivot value if it is one of the erased values. The rest of the decision (scenario, score, outcome, case) is kept
+// for audit purposes.
+func (repo *MarbleDbRepository) PseudonymiseDecisions(ctx context.Context, exec Executor, orgId string,
+	decisionIds []string, erasedValues []string,
+) (int, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return 0, err
+	}
+
+	sql := NewQueryBuilder().
+		Update(dbmodels.TABLE_DECISIONS).
+		Set("trigger_object", squirrel.Expr(
+			"jsonb_build_object('object_id', "+erasurePseudonymSql("trigger_object->>'object_id'")+")", orgId)).
+		Set("pivot_value", squirrel.Expr(
+			"case when pivot_value = any(?) then "+erasurePseudonymSql("pivot_value")+" else pivot_value end",
+			erasedValues, orgId)).
+		Where(squirrel.Eq{"id": decisionIds})
+
+	return ExecBuilderRowsAffected(ctx, exec, sql)
+}
+
+func (repo *MarbleDbRepository) ClearDecisionRuleEvaluations(ctx context.Context, exec Executor, decisionIds []string) (int, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return 0, err
+	}
+
+	sql := NewQueryBuilder().
+		Update(dbmodels.TABLE_DECISION_RULES).
+		Set("rule_evaluation", nil).
+		Where(squirrel.Eq{"decision_id": decisionIds}).
+		Where("rule_evaluation is not null")
+
+	return ExecBuilderRowsAffected(ctx, exec, sql)
+}
+
+// DeleteEntityAnnotationsForErasure hard deletes all the annotations of the erased objects, including those that were
+// already soft deleted, and returns them so that their files can be removed.
+func (repo *MarbleDbRepository) DeleteEntityAnnotationsForErasure(ctx context.Context, exec Executor,
+	orgId, objectType string, objectIds []string,
+) ([]models.EntityAnnotation, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	sql := NewQueryBuilder().
+		Delete(dbmodels.TABLE_ENTITY_ANNOTATIONS).
+		Where(squirrel.Eq{
+			"org_id":      orgId,
+			"object_type": objectType,
+			"object_id":   objectIds,
+		}).
+		Suffix("returning " + strings.Join(dbmodels.EntityAnnotationColumns, ","))
+
+	return SqlToListOfModels(ctx, exec, sql, dbmodels.AdaptEntityAnnotation)
+}
+
+// DeleteCaseFilesForErasure deletes the files of the cases that only contain erased decisions, and returns them so
+// that the corresponding blobs can be removed. Cases that also contain decisions on other subjects keep their files.
+func (repo *MarbleDbRepository) DeleteCaseFilesForErasure(ctx context.Context, exec Executor,
+	decisionIds []string,
+) ([]models.CaseFile, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	sql := NewQueryBuilder().
+		Delete(dbmodels.TABLE_CASE_FILES + " cf").
+		Where(squirrel.Expr(
+			"cf.case_id in (select case_id from decisions where id = any(?) and case_id is not null)",
+			decisionIds)).
+		Where(squirrel.Expr(
+			"not exists (select 1 from decisions d where d.case_id = cf.case_id and not d.id = any(?))",
+			decisionIds)).
+		Suffix("returning " + strings.Join(columnsNames("cf", dbmodels.SelectCaseFileColumn), ","))
+
+	return SqlToListOfModels(ctx, exec, sql, dbmodels.AdaptCaseFile)
+}
+
+func (repo *MarbleDbRepository) ClearSanctionCheckSearchInputs(ctx context.Context, exec Executor, decisionIds []string) (int, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return 0, err
+	}
+
+	sql := NewQueryBuilder().
+		Update(dbmodels.TABLE_SANCTION_CHECKS).
+		Set("search_input", nil).
+		Where(squirrel.Eq{"decision_id": decisionIds}).
+		Where("search_input is not null")
+
+	return ExecBuilderRowsAffected(ctx, exec, sql)
+}
+
+func (repo *MarbleDbRepository) DeleteWebhookEventsForDecisions(ctx context.Context, exec Executor,
+	orgId string, decisionIds []string,
+) (int, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return 0, err
+	}
+
+	sql := NewQueryBuilder().
+		Delete(dbmodels.TABLE_WEBHOOK_EVENTS).
+		Where(squirrel.Eq{"organization_id": orgId}).
+		Where(squirrel.Expr("event_data->'content'->'decision'->>'id' = any(?)", decisionIds))
+
+	return ExecBuilderRowsAffected(ctx, exec, sql)
+}
+
+func (repo *MarbleDbRepository) CreateErasureCertificate(ctx context.Context, exec Executor,
+	certificate models.ErasureCertificate,
+) (models.ErasureCertificate, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return models.ErasureCertificate{}, err
+	}
+
+	var apiKey *string
+	if certificate.RequestedByApiKey != "" {
+		apiKey = &certificate.RequestedByApiKey
+	}
+
+	sql := NewQueryBuilder().
+		Insert(dbmodels.TABLE_ERASURE_CERTIFICATES).
+		Columns(
+			"id",
+			"org_id",
+			"object_type",
+			"subject_pseudonym",
+			"mode",
+			"requested_by_user_id",
+			"requested_by_api_key",
+			"nb_ingested_rows",
+			"nb_linked_rows",
+			"nb_decisions",
+			"nb_rule_executions",
+			"nb_offloaded_rules",
+			"nb_entity_annotations",
+			"nb_case_files",
+			"nb_sanction_checks",
+			"nb_webhook_events",
+		).
+		Values(
+			uuid.NewString(),
+			certificate.OrganizationId,
+			certificate.ObjectType,
+			certificate.SubjectPseudonym,
+			certificate.Mode.String(),
+			certificate.RequestedByUserId,
+			apiKey,
+			certificate.Counts.IngestedRows,
+			certificate.Counts.LinkedRows,
+			certificate.Counts.Decisions,
+			certificate.Counts.RuleExecutions,
+			certificate.Counts.OffloadedRules,
+			certificate.Counts.EntityAnnotations,
+			certificate.Counts.CaseFiles,
+			certificate.Counts.SanctionChecks,
+			certificate.Counts.WebhookEvents,
+		).
+		Suffix("returning *")
+
+	return SqlToModel(ctx, exec, sql, dbmodels.AdaptErasureCertificate)
+}
+
+func (repo *MarbleDbRepository) ListErasureCertificates(ctx context.Context, exec Executor, orgId string) ([]models.ErasureCertificate, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	sql := NewQueryBuilder().
+		Select(dbmodels.SelectErasureCertificateColumns...).
+		From(dbmodels.TABLE_ERASURE_CERTIFICATES).
+		Where(squirrel.Eq{"org_id": orgId}).
+		OrderBy("created_at desc")
+
+	return SqlToListOfModels(ctx, exec, sql, dbmodels.AdaptErasureCertificate)
+}
+
+func (repo *MarbleDbRepository) GetErasureCertificate(ctx context.Context, exec Executor, id string) (models.ErasureCertificate, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return models.ErasureCertificate{}, err
+	}
+
+	sql := NewQueryBuilder().
+		Select(dbmodels.SelectErasureCertificateColumns...).
+		From(dbmodels.TABLE_ERASURE_CERTIFICATES).
+		Where(squirrel.Eq{"id": id})
+
+	return SqlToModel(ctx, exec, sql, dbmodels.AdaptErasureCertificate)
+}
@@ -0,0 +1,127 @@
+package repositories
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/cockroachdb/errors"
+)
+
+// FileScanner scans uploaded files for malware before they are written to blob storage.
+type FileScanner interface {
+	Scan(ctx context.Context, r io.Reader) (models.FileScanResult, error)
+}
+
+// NewFileScanner returns a scanner using the ClamAV daemon listening at the given address, or a scanner that does not
+// scan anything if the address is empty.
+func NewFileScanner(clamAvAddress string) FileScanner {
+	if clamAvAddress == "" {
+		return NoopFileScanner{}
+	}
+	return NewClamAvFileScanner(clamAvAddress)
+}
+
+type NoopFileScanner struct{}
+
+func (NoopFileScanner) Scan(ctx context.Context, r io.Reader) (models.FileScanResult, error) {
+	return models.FileScanResult{Status: models.FileScanNotScanned}, nil
+}
+
+const (
+	clamAvChunkSize = 64 * 1024
+	clamAvTimeout   = 60 * time.Second
+)
+
+// ClamAvFileScanner streams files to a ClamAV daemon with the INSTREAM command. The address is either a TCP address
+// ("tcp://clamav:3310" or "clamav:3310") or the path of a unix socket ("unix:///var/run/clamav/clamd.ctl").
+type ClamAvFileScanner struct {
+	network string
+	address string
+	timeout time.Duration
+}
+
+func NewClamAvFileScanner(address string) ClamAvFileScanner {
+	scanner := ClamAvFileScanner{network: "tcp", address: address, timeout: clamAvTimeout}
+	if path, ok := strings.CutPrefix(address, "unix://"); ok {
+		scanner.network = "unix"
+		scanner.address = path
+	} else if host, ok := strings.CutPrefix(address, "tcp://"); ok {
+		scanner.address = host
+	}
+	return scanner
+}
+
+func (s ClamAvFileScanner) Scan(ctx context.Context, r io.Reader) (models.FileScanResult, error) {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, s.network, s.address)
+	if err != nil {
+		return models.FileScanResult{}, errors.Wrap(err, "could not connect to the ClamAV daemon")
+	}
+	defer conn.Close()
+
+	deadline := time.Now().Add(s.timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		return models.FileScanResult{}, err
+	}
+
+	if _, err := conn.Write([]byte("zINSTREAM\x00")); err != nil {
+		return models.FileScanResult{}, errors.Wrap(err, "could not send the file to the ClamAV daemon")
+	}
+
+	// The file is sent in chunks prefixed by their length, and terminated by an empty chunk
+	chunk := make([]byte, clamAvChunkSize)
+	for {
+		n, readErr := r.Read(chunk)
+		if n > 0 {
+			if err := binary.Write(conn, binary.BigEndian, uint32(n)); err != nil {
+				return models.FileScanResult{}, errors.Wrap(err, "could not send the file to the ClamAV daemon")
+			}
+			if _, err := conn.Write(chunk[:n]); err != nil {
+				return models.FileScanResult{}, errors.Wrap(err, "could not send the file to the ClamAV daemon")
+			}
+		}
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			return models.FileScanResult{}, readErr
+		}
+	}
+	if err := binary.Write(conn, binary.BigEndian, uint32(0)); err != nil {
+		return models.FileScanResult{}, errors.Wrap(err, "could not send the file to the ClamAV daemon")
+	}
+
+	reply, err := bufio.NewReader(conn).ReadString('\x00')
+	if err != nil && !(errors.Is(err, io.EOF) && reply != "") {
+		return models.FileScanResult{}, errors.Wrap(err, "could not read the reply of the ClamAV daemon")
+	}
+
+	return parseClamAvReply(strings.TrimRight(reply, "\x00\n"))
+}
+
+// parseClamAvReply reads the reply to an INSTREAM command, such as "stream: OK" or "stream: Eicar-Signature FOUND"
+func parseClamAvReply(reply string) (models.FileScanResult, error) {
+	result := strings.TrimSpace(strings.TrimPrefix(reply, "stream:"))
+
+	switch {
+	case result == "OK":
+		return models.FileScanResult{Status: models.FileScanClean}, nil
+	case strings.HasSuffix(result, " FOUND"):
+		return models.FileScanResult{
+			Status:    models.FileScanQuarantined,
+			Signature: strings.TrimSuffix(result, " FOUND"),
+		}, nil
+	default:
+		return models.FileScanResult{}, fmt.Errorf("unexpected reply from the ClamAV daemon: %s", reply)
+	}
+}
@@ -0,0 +1,114 @@
+package repositories
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/stretchr/testify/assert"
+)
+
+const eicarTestFile = `X5O!P%@AP[4\PZX54(P^)7CC)7}$EICAR-STANDARD-ANTIVIRUS-TEST-FILE!$H+H*`
+
+// startFakeClamAv starts a daemon that implements the INSTREAM command, and reports any file containing the EICAR test
+// string as infected.
+func startFakeClamAv(t *testing.T) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				r := bufio.NewReader(conn)
+				if command, err := r.ReadString('\x00'); err != nil || command != "zINSTREAM\x00" {
+					return
+				}
+				var file bytes.Buffer
+				for {
+					var size uint32
+					if err := binary.Read(r, binary.BigEndian, &size); err != nil {
+						return
+					}
+					if size == 0 {
+						break
+					}
+					if _, err := io.CopyN(&file, r, int64(size)); err != nil {
+						return
+					}
+				}
+				if strings.Contains(file.String(), eicarTestFile) {
+					conn.Write([]byte("stream: Eicar-Test-Signature FOUND\x00"))
+				} else {
+					conn.Write([]byte("stream: OK\x00"))
+				}
+			}()
+		}
+	}()
+
+	return listener.Addr().String()
+}
+
+func TestClamAvScanCleanFile(t *testing.T) {
+	scanner := NewFileScanner("tcp://" + startFakeClamAv(t))
+
+	result, err := scanner.Scan(context.TODO(), strings.NewReader(strings.Repeat("a clean file\n", 10000)))
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.FileScanClean, result.Status)
+	assert.Empty(t, result.Signature)
+}
+
+func TestClamAvScanInfectedFile(t *testing.T) {
+	scanner := NewFileScanner(startFakeClamAv(t))
+
+	result, err := scanner.Scan(context.TODO(), strings.NewReader(eicarTestFile))
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.FileScanQuarantined, result.Status)
+	assert.Equal(t, "Eicar-Test-Signature", result.Signature)
+}
+
+func TestClamAvUnreachable(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	address := listener.Addr().String()
+	listener.Close()
+
+	_, err = NewFileScanner(address).Scan(context.TODO(), strings.NewReader("file"))
+
+	assert.Error(t, err)
+}
+
+func TestNoScanIfNotConfigured(t *testing.T) {
+	scanner := NewFileScanner("")
+
+	result, err := scanner.Scan(context.TODO(), strings.NewReader(eicarTestFile))
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.FileScanNotScanned, result.Status)
+}
+
+func TestParseClamAvReply(t *testing.T) {
+	_, err := parseClamAvReply("INSTREAM size limit exceeded. ERROR")
+	assert.Error(t, err)
+
+	result, err := parseClamAvReply("stream: OK")
+	assert.NoError(t, err)
+	assert.Equal(t, models.FileScanClean, result.Status)
+}
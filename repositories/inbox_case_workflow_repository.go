@@ -0,0 +1,90 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/repositories/dbmodels"
+	"github.com/cockroachdb/errors"
+)
+
+// GetInboxCaseWorkflow returns the case workflow of an inbox, or nil if the inbox uses the default workflow
+func (repo *MarbleDbRepository) GetInboxCaseWorkflow(ctx context.Context, exec Executor,
+	inboxId string,
+) (*models.InboxCaseWorkflow, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	sql := NewQueryBuilder().
+		Select(dbmodels.SelectInboxCaseWorkflowColumns...).
+		From(dbmodels.TABLE_INBOX_CASE_WORKFLOWS).
+		Where(squirrel.Eq{"inbox_id": inboxId})
+
+	return SqlToOptionalModel(ctx, exec, sql, dbmodels.AdaptInboxCaseWorkflow)
+}
+
+func (repo *MarbleDbRepository) UpsertInboxCaseWorkflow(ctx context.Context, exec Executor,
+	organizationId, inboxId string, input models.InboxCaseWorkflowInput,
+) (models.InboxCaseWorkflow, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return models.InboxCaseWorkflow{}, err
+	}
+
+	statuses, err := json.Marshal(input.Statuses)
+	if err != nil {
+		return models.InboxCaseWorkflow{}, errors.Wrap(err, "could not marshal the statuses of the case workflow")
+	}
+	transitions, err := json.Marshal(input.Transitions)
+	if err != nil {
+		return models.InboxCaseWorkflow{}, errors.Wrap(err, "could not marshal the transitions of the case workflow")
+	}
+
+	sql := NewQueryBuilder().
+		Insert(dbmodels.TABLE_INBOX_CASE_WORKFLOWS).
+		Columns("inbox_id", "org_id", "statuses", "transitions").
+		Values(inboxId, organizationId, statuses, transitions).
+		Suffix(fmt.Sprintf(`on conflict (inbox_id) do update set
+			statuses = excluded.statuses,
+			transitions = excluded.transitions,
+			updated_at = now()
+			RETURNING %s`, strings.Join(dbmodels.SelectInboxCaseWorkflowColumns, ",")))
+
+	return SqlToModel(ctx, exec, sql, dbmodels.AdaptInboxCaseWorkflow)
+}
+
+func (repo *MarbleDbRepository) DeleteInboxCaseWorkflow(ctx context.Context, exec Executor, inboxId string) error {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return err
+	}
+
+	return ExecBuilder(ctx, exec, NewQueryBuilder().
+		Delete(dbmodels.TABLE_INBOX_CASE_WORKFLOWS).
+		Where(squirrel.Eq{"inbox_id": inboxId}))
+}
+
+// CountInboxCasesWithStatuses counts the cases of an inbox that have one of the given statuses
+func (repo *MarbleDbRepository) CountInboxCasesWithStatuses(ctx context.Context, exec Executor,
+	inboxId string, statuses []models.CaseStatus,
+) (int, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return 0, err
+	}
+
+	sql, args, err := NewQueryBuilder().
+		Select("count(*)").
+		From(dbmodels.TABLE_CASES).
+		Where(squirrel.Eq{"inbox_id": inboxId, "status": statuses}).
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	var count int
+	err = exec.QueryRow(ctx, sql, args...).Scan(&count)
+	return count, err
+}
@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/checkmarble/marble-backend/models"
 	"github.com/checkmarble/marble-backend/repositories/dbmodels"
@@ -82,20 +83,28 @@ func (repo *MarbleDbRepository) CreateInboxUser(ctx context.Context, exec Execut
 	return err
 }
 
-func (repo *MarbleDbRepository) UpdateInboxUser(ctx context.Context, exec Executor, inboxUserId string, role models.InboxUserRole) error {
+func (repo *MarbleDbRepository) UpdateInboxUser(ctx context.Context, exec Executor,
+	inboxUserId string, input models.UpdateInboxUserInput,
+) error {
 	if err := validateMarbleDbExecutor(exec); err != nil {
 		return err
 	}
 
-	err := ExecBuilder(
-		ctx,
-		exec,
-		NewQueryBuilder().Update(dbmodels.TABLE_INBOX_USERS).
-			Set("role", role).
-			Set("updated_at", "NOW()").
-			Where(squirrel.Eq{"id": inboxUserId}),
-	)
-	return err
+	query := NewQueryBuilder().Update(dbmodels.TABLE_INBOX_USERS).
+		Set("updated_at", "NOW()").
+		Where(squirrel.Eq{"id": inboxUserId})
+
+	if input.Role != nil {
+		query = query.Set("role", *input.Role)
+	}
+	if input.OutOfOffice != nil {
+		query = query.Set("out_of_office", *input.OutOfOffice)
+	}
+	if input.SkillTagIds != nil {
+		query = query.Set("skill_tag_ids", *input.SkillTagIds)
+	}
+
+	return ExecBuilder(ctx, exec, query)
 }
 
 func (repo *MarbleDbRepository) DeleteInboxUser(ctx context.Context, exec Executor, inboxUserId string) error {
@@ -111,3 +120,58 @@ func (repo *MarbleDbRepository) DeleteInboxUser(ctx context.Context, exec Execut
 	)
 	return err
 }
+
+// ListCaseAssignmentCandidates returns the members of an inbox who can be assigned a case: the users who are not out of
+// office and not deleted, with their number of open cases in the organization
+func (repo *MarbleDbRepository) ListCaseAssignmentCandidates(ctx context.Context, exec Executor,
+	inboxId string,
+) ([]models.CaseAssignmentCandidate, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	query := NewQueryBuilder().
+		Select(
+			"u.id as inbox_user_id",
+			"u.user_id",
+			"u.skill_tag_ids",
+		).
+		Column(squirrel.Expr(fmt.Sprintf(`(select count(*) from %s as c where c.assigned_to = u.user_id
+				and c.status != all(?)) as open_cases_count`, dbmodels.TABLE_CASES), finalizedCaseStatuses())).
+		Column("u.last_assigned_at").
+		From(dbmodels.TABLE_INBOX_USERS + " as u").
+		Join(dbmodels.TABLE_USERS + " as us on us.id = u.user_id").
+		Where(squirrel.Eq{
+			"u.inbox_id":      inboxId,
+			"u.out_of_office": false,
+			"us.deleted_at":   nil,
+		})
+
+	return SqlToListOfModels(ctx, exec, query, dbmodels.AdaptCaseAssignmentCandidate)
+}
+
+// LockInboxUsersForAssignment locks the members of an inbox until the end of the transaction, so that concurrent
+// assignments of cases to the inbox are serialized and each one sees the assignments of the previous ones.
+func (repo *MarbleDbRepository) LockInboxUsersForAssignment(ctx context.Context, exec Executor, inboxId string) error {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return err
+	}
+
+	return ExecBuilder(ctx, exec, NewQueryBuilder().
+		Select("id").
+		From(dbmodels.TABLE_INBOX_USERS).
+		Where(squirrel.Eq{"inbox_id": inboxId}).
+		OrderBy("id").
+		Suffix("for update"))
+}
+
+func (repo *MarbleDbRepository) MarkInboxUserAssigned(ctx context.Context, exec Executor, inboxUserId string) error {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return err
+	}
+
+	return ExecBuilder(ctx, exec, NewQueryBuilder().
+		Update(dbmodels.TABLE_INBOX_USERS).
+		Set("last_assigned_at", squirrel.Expr("now()")).
+		Where(squirrel.Eq{"id": inboxUserId}))
+}
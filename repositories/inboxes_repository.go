@@ -93,21 +93,22 @@ func (repo *MarbleDbRepository) CreateInbox(ctx context.Context, exec Executor,
 	return err
 }
 
-func (repo *MarbleDbRepository) UpdateInbox(ctx context.Context, exec Executor, inboxId, name string, escalationInboxId *string) error {
+func (repo *MarbleDbRepository) UpdateInbox(ctx context.Context, exec Executor, input models.UpdateInboxInput) error {
 	if err := validateMarbleDbExecutor(exec); err != nil {
 		return err
 	}
 
-	err := ExecBuilder(
-		ctx,
-		exec,
-		NewQueryBuilder().Update(dbmodels.TABLE_INBOXES).
-			Set("name", name).
-			Set("updated_at", squirrel.Expr("NOW()")).
-			Set("escalation_inbox_id", escalationInboxId).
-			Where(squirrel.Eq{"id": inboxId}),
-	)
-	return err
+	query := NewQueryBuilder().Update(dbmodels.TABLE_INBOXES).
+		Set("name", input.Name).
+		Set("updated_at", squirrel.Expr("NOW()")).
+		Set("escalation_inbox_id", input.EscalationInboxId).
+		Where(squirrel.Eq{"id": input.Id})
+
+	if input.AssignmentStrategy != nil {
+		query = query.Set("assignment_strategy", *input.AssignmentStrategy)
+	}
+
+	return ExecBuilder(ctx, exec, query)
 }
 
 func (repo *MarbleDbRepository) SoftDeleteInbox(ctx context.Context, exec Executor, inboxId string) error {
@@ -0,0 +1,82 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+// ListObjectIdsByFieldValue returns the ids of the objects of a table, current or historical, for which a field has the
+// given value.
+func (repo *ClientDbRepository) ListObjectIdsByFieldValue(ctx context.Context, exec Executor,
+	tableName, fieldName, value string,
+) ([]string, error) {
+	if err := validateClientDbExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	sql := NewQueryBuilder().
+		Select("distinct object_id").
+		From(pgIdentifierWithSchema(exec, tableName)).
+		Where(squirrel.Expr(pgx.Identifier{fieldName}.Sanitize()+"::text = ?", value))
+
+	return SqlToListOfRow(ctx, exec, sql, func(row pgx.CollectableRow) (string, error) {
+		var objectId string
+		err := row.Scan(&objectId)
+		return objectId, err
+	})
+}
+
+// DeleteIngestedObjects deletes all the versions of the given objects
+func (repo *ClientDbRepository) DeleteIngestedObjects(ctx context.Context, exec Executor,
+	tableName string, objectIds []string,
+) (int, error) {
+	if err := validateClientDbExecutor(exec); err != nil {
+		return 0, err
+	}
+
+	sql := NewQueryBuilder().
+		Delete(pgIdentifierWithSchema(exec, tableName)).
+		Where(squirrel.Eq{"object_id": objectIds})
+
+	return ExecBuilderRowsAffected(ctx, exec, sql)
+}
+
+// PseudonymiseIngestedObjects replaces the object id of all the versions of the given objects with its pseudonym and
+// clears all their other fields. The versions themselves are kept, so that the history of the table stays consistent.
+func (repo *ClientDbRepository) PseudonymiseIngestedObjects(ctx context.Context, exec Executor,
+	orgId, tableName string, fieldNames []string, objectIds []string,
+) (int, error) {
+	if err := validateClientDbExecutor(exec); err != nil {
+		return 0, err
+	}
+
+	sql := NewQueryBuilder().
+		Update(pgIdentifierWithSchema(exec, tableName)).
+		Set("object_id", squirrel.Expr(erasurePseudonymSql("object_id"), orgId)).
+		Where(squirrel.Eq{"object_id": objectIds})
+	for _, fieldName := range fieldNames {
+		sql = sql.Set(pgx.Identifier{fieldName}.Sanitize(), nil)
+	}
+
+	return ExecBuilderRowsAffected(ctx, exec, sql)
+}
+
+// PseudonymiseIngestedLinks replaces the references to the given objects in a link field of a child table with their
+// pseudonym, so that links to a pseudonymised object are preserved.
+func (repo *ClientDbRepository) PseudonymiseIngestedLinks(ctx context.Context, exec Executor,
+	orgId, tableName, fieldName string, objectIds []string,
+) (int, error) {
+	if err := validateClientDbExecutor(exec); err != nil {
+		return 0, err
+	}
+
+	field := pgx.Identifier{fieldName}.Sanitize()
+	sql := NewQueryBuilder().
+		Update(pgIdentifierWithSchema(exec, tableName)).
+		Set(field, squirrel.Expr(erasurePseudonymSql(field), orgId)).
+		Where(squirrel.Eq{field: objectIds})
+
+	return ExecBuilderRowsAffected(ctx, exec, sql)
+}
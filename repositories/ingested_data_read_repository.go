@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"slices"
+	"time"
 
 	"github.com/Masterminds/squirrel"
 	"github.com/cockroachdb/errors"
@@ -19,6 +20,7 @@ type IngestedDataReadRepository interface {
 		ctx context.Context,
 		exec Executor,
 		tableName string,
+		asOf *time.Time,
 		filters ...models.Filter,
 	) ([]string, error)
 	QueryIngestedObject(
@@ -33,6 +35,13 @@ type IngestedDataReadRepository interface {
 		table models.Table,
 		uniqueFieldValue string,
 		uniqueFieldName string,
+		asOf *time.Time,
+	) ([]models.DataModelObject, error)
+	QueryIngestedObjectHistory(
+		ctx context.Context,
+		exec Executor,
+		table models.Table,
+		objectId string,
 	) ([]models.DataModelObject, error)
 	QueryAggregatedValue(
 		ctx context.Context,
@@ -42,6 +51,7 @@ type IngestedDataReadRepository interface {
 		fieldType models.DataType,
 		aggregator ast.Aggregator,
 		filters []models.FilterWithType,
+		asOf *time.Time,
 	) (any, error)
 	ListIngestedObjects(
 		ctx context.Context,
@@ -131,7 +141,7 @@ func createQueryDbForField(exec Executor, readParams models.DbFieldReadParams) (
 		Select(fmt.Sprintf("%s.%s", lastTableAlias, readParams.FieldName)).
 		From(fmt.Sprintf("%s AS %s", firstTableName, firstTableAlias)).
 		Where(squirrel.Eq{fmt.Sprintf("%s.%s", firstTableAlias, link.ParentFieldName): firstTableLinkValue}).
-		Where(rowIsValid(firstTableAlias))
+		Where(rowIsValidAt(firstTableAlias, readParams.ClientObject.ReadAsOf))
 
 	b, err = addJoinsOnIntermediateTables(exec, query, readParams, firstTable)
 	return false, b, err
@@ -172,7 +182,7 @@ func addJoinsOnIntermediateTables(
 			link.ParentFieldName)
 		query = query.
 			Join(joinClause).
-			Where(rowIsValid(aliastNextTable))
+			Where(rowIsValidAt(aliastNextTable, readParams.ClientObject.ReadAsOf))
 
 		currentTable = nextTable
 	}
@@ -183,10 +193,23 @@ func rowIsValid(tableName string) squirrel.Eq {
 	return squirrel.Eq{fmt.Sprintf("%s.valid_until", tableName): "Infinity"}
 }
 
+// rowIsValidAt selects the version of the rows that was valid at the given time, or the current version if asOf is nil.
+// Versions are valid in the interval [valid_from, valid_until), the current version having valid_until = 'Infinity'.
+func rowIsValidAt(tableName string, asOf *time.Time) squirrel.Sqlizer {
+	if asOf == nil {
+		return rowIsValid(tableName)
+	}
+	return squirrel.And{
+		squirrel.LtOrEq{fmt.Sprintf("%s.valid_from", tableName): *asOf},
+		squirrel.Gt{fmt.Sprintf("%s.valid_until", tableName): *asOf},
+	}
+}
+
 func (repo *IngestedDataReadRepositoryImpl) ListAllObjectIdsFromTable(
 	ctx context.Context,
 	exec Executor,
 	tableName string,
+	asOf *time.Time,
 	filters ...models.Filter,
 ) ([]string, error) {
 	if err := validateClientDbExecutor(exec); err != nil {
@@ -197,7 +220,7 @@ func (repo *IngestedDataReadRepositoryImpl) ListAllObjectIdsFromTable(
 	q := NewQueryBuilder().
 		Select("object_id").
 		From(qualifiedTableName).
-		Where(rowIsValid(qualifiedTableName))
+		Where(rowIsValidAt(qualifiedTableName, asOf))
 	for _, f := range filters {
 		sql, args := f.ToSql()
 		q = q.Where(sql, args...)
@@ -249,6 +272,7 @@ func (repo *IngestedDataReadRepositoryImpl) QueryIngestedObject(
 		exec,
 		qualifiedTableName,
 		append(columnNames, "valid_from"),
+		nil,
 		[]models.Filter{{
 			LeftSql:    fmt.Sprintf("%s.object_id", qualifiedTableName),
 			Operator:   ast.FUNC_EQUAL,
@@ -281,6 +305,7 @@ func (repo *IngestedDataReadRepositoryImpl) QueryIngestedObjectByUniqueField(
 	table models.Table,
 	uniqueFieldValue string,
 	uniqueFieldName string,
+	asOf *time.Time,
 ) ([]models.DataModelObject, error) {
 	if err := validateClientDbExecutor(exec); err != nil {
 		return nil, err
@@ -294,6 +319,7 @@ func (repo *IngestedDataReadRepositoryImpl) QueryIngestedObjectByUniqueField(
 		exec,
 		qualifiedTableName,
 		append(columnNames, "valid_from"),
+		asOf,
 		[]models.Filter{{
 			LeftSql:    fmt.Sprintf("%s.%s", qualifiedTableName, uniqueFieldName),
 			Operator:   ast.FUNC_EQUAL,
@@ -320,17 +346,70 @@ func (repo *IngestedDataReadRepositoryImpl) QueryIngestedObjectByUniqueField(
 	return ingestedObjects, nil
 }
 
+// Returns all the versions of an ingested object, including the obsolete ones, ordered from the oldest to the most recent.
+// The validity interval of each version is returned in the metadata.
+func (repo *IngestedDataReadRepositoryImpl) QueryIngestedObjectHistory(
+	ctx context.Context,
+	exec Executor,
+	table models.Table,
+	objectId string,
+) ([]models.DataModelObject, error) {
+	if err := validateClientDbExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	tableColumnNames := models.ColumnNames(table)
+	columnNames := append(tableColumnNames, "valid_from", "valid_until")
+	qualifiedTableName := pgIdentifierWithSchema(exec, table.Name)
+
+	q := NewQueryBuilder().
+		Select(columnNames...).
+		From(qualifiedTableName).
+		Where(squirrel.Eq{fmt.Sprintf("%s.object_id", qualifiedTableName): objectId}).
+		OrderBy("valid_from ASC", "id ASC")
+
+	sql, args, err := q.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "error while building SQL query in QueryIngestedObjectHistory")
+	}
+
+	rows, err := exec.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "error while querying DB in QueryIngestedObjectHistory")
+	}
+
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.DataModelObject, error) {
+		values, err := row.Values()
+		if err != nil {
+			return models.DataModelObject{}, errors.Wrap(err,
+				"error while fetching rows in QueryIngestedObjectHistory")
+		}
+
+		ingestedObject := models.DataModelObject{Data: map[string]any{}, Metadata: map[string]any{}}
+		for i, columnName := range columnNames {
+			if slices.Contains(tableColumnNames, columnName) {
+				ingestedObject.Data[columnName] = values[i]
+			} else {
+				ingestedObject.Metadata[columnName] = values[i]
+			}
+		}
+
+		return ingestedObject, nil
+	})
+}
+
 func queryWithDynamicColumnList(
 	ctx context.Context,
 	exec Executor,
 	qualifiedTableName string,
 	columnNames []string,
+	asOf *time.Time,
 	filters ...models.Filter,
 ) ([]map[string]any, error) {
 	q := NewQueryBuilder().
 		Select(columnNames...).
 		From(qualifiedTableName).
-		Where(rowIsValid(qualifiedTableName))
+		Where(rowIsValidAt(qualifiedTableName, asOf))
 	for _, f := range filters {
 		sql, args := f.ToSql()
 		q = q.Where(sql, args...)
@@ -373,6 +452,7 @@ func createQueryAggregated(
 	fieldType models.DataType,
 	aggregator ast.Aggregator,
 	filters []models.FilterWithType,
+	asOf *time.Time,
 ) (squirrel.SelectBuilder, error) {
 	var selectExpression string
 	if aggregator == ast.AGGREGATOR_COUNT_DISTINCT {
@@ -393,7 +473,7 @@ func createQueryAggregated(
 	query := NewQueryBuilder().
 		Select(selectExpression).
 		From(qualifiedTableName).
-		Where(rowIsValid(qualifiedTableName))
+		Where(rowIsValidAt(qualifiedTableName, asOf))
 
 	var err error
 	for _, filter := range filters {
@@ -415,12 +495,13 @@ func (repo *IngestedDataReadRepositoryImpl) QueryAggregatedValue(
 	fieldType models.DataType,
 	aggregator ast.Aggregator,
 	filters []models.FilterWithType,
+	asOf *time.Time,
 ) (any, error) {
 	if err := validateClientDbExecutor(exec); err != nil {
 		return nil, err
 	}
 
-	query, err := createQueryAggregated(exec, tableName, fieldName, fieldType, aggregator, filters)
+	query, err := createQueryAggregated(exec, tableName, fieldName, fieldType, aggregator, filters, asOf)
 	if err != nil {
 		return nil, fmt.Errorf("error while building SQL query: %w", err)
 	}
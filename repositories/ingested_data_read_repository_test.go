@@ -5,6 +5,7 @@ import (
 	"regexp"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
@@ -102,6 +103,44 @@ func TestIngestedDataGetDbFieldWithJoin(t *testing.T) {
 	assert.Equal(t, stripQuery(expected), stripQuery(sql))
 }
 
+func TestIngestedDataGetDbFieldAsOf(t *testing.T) {
+	path := []string{
+		utils.DummyTableNameSecond,
+		utils.DummyTableNameThird,
+	}
+	asOf := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	nullFilter, query, err := createQueryDbForField(TransactionTest{}, models.DbFieldReadParams{
+		TriggerTableName: utils.DummyTableNameFirst,
+		Path:             path,
+		FieldName:        utils.DummyFieldNameForInt,
+		DataModel:        utils.GetDummyDataModel(),
+		ClientObject: models.ClientObject{
+			TableName: utils.DummyTableNameFirst,
+			Data:      map[string]any{utils.DummyFieldNameId: utils.DummyFieldNameId},
+			ReadAsOf:  &asOf,
+		},
+	})
+	assert.False(t, nullFilter)
+	assert.Empty(t, err)
+	sql, args, err := query.ToSql()
+	assert.Empty(t, err)
+	if assert.Len(t, args, 5) {
+		assert.Equal(t, args[0], utils.DummyFieldNameId)
+		assert.Equal(t, args[1], asOf)
+		assert.Equal(t, args[2], asOf)
+	}
+	expected := `
+	SELECT table_2.int_var
+	FROM "test_schema"."second" AS table_1
+	JOIN "test_schema"."third" AS table_2 ON table_1.id = table_2.id
+	WHERE table_1.id = $1
+	AND (table_1.valid_from <= $2 AND table_1.valid_until > $3)
+	AND (table_2.valid_from <= $4 AND table_2.valid_until > $5)
+	`
+	assert.Equal(t, stripQuery(expected), stripQuery(sql))
+}
+
 func TestIngestedDataQueryAggregatedValueWithoutFilter(t *testing.T) {
 	query, err := createQueryAggregated(
 		TransactionTest{},
@@ -110,6 +149,7 @@ func TestIngestedDataQueryAggregatedValueWithoutFilter(t *testing.T) {
 		models.Int,
 		ast.AGGREGATOR_AVG,
 		[]models.FilterWithType{},
+		nil,
 	)
 	assert.Empty(t, err)
 	sql, args, err := query.ToSql()
@@ -132,7 +172,8 @@ func TestIngestedDataQueryCountWithoutFilter(t *testing.T) {
 		utils.DummyFieldNameForInt,
 		models.Int,
 		ast.AGGREGATOR_COUNT,
-		[]models.FilterWithType{})
+		[]models.FilterWithType{},
+		nil)
 	assert.Empty(t, err)
 	sql, args, err := query.ToSql()
 	assert.Empty(t, err)
@@ -175,7 +216,8 @@ func TestIngestedDataQueryAggregatedValueWithSimpleFilter(t *testing.T) {
 		utils.DummyFieldNameForInt,
 		models.Int,
 		ast.AGGREGATOR_AVG,
-		filters)
+		filters,
+		nil)
 	assert.Empty(t, err)
 	sql, args, err := query.ToSql()
 	assert.Empty(t, err)
@@ -219,7 +261,8 @@ func TestIngestedDataQueryAggregatedValueWithFuzzyMatchFilter_1(t *testing.T) {
 		"stringFieldName",
 		models.Int,
 		ast.AGGREGATOR_COUNT,
-		filters)
+		filters,
+		nil)
 	assert.Empty(t, err)
 	sql, args, err := query.ToSql()
 	assert.Empty(t, err)
@@ -269,7 +312,8 @@ func TestIngestedDataQueryAggregatedValueWithFuzzyMatchFilter_2(t *testing.T) {
 		"stringFieldName",
 		models.Int,
 		ast.AGGREGATOR_COUNT,
-		filters)
+		filters,
+		nil)
 	assert.Empty(t, err)
 	sql, args, err := query.ToSql()
 	assert.Empty(t, err)
@@ -0,0 +1,102 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/repositories/dbmodels"
+	"github.com/jackc/pgx/v5"
+)
+
+// retainableIngestedRows matches the versions of objects that are older than the cutoff: historical versions that stopped
+// being valid before the cutoff, and unless the latest version must be kept, current versions created before the cutoff.
+// Versions of objects on legal hold are never matched.
+func retainableIngestedRows(cutoff time.Time, keepLatestVersion bool, objectIdsOnHold []string) squirrel.Sqlizer {
+	outdated := squirrel.Or{squirrel.Lt{"t.valid_until": cutoff}}
+	if !keepLatestVersion {
+		outdated = append(outdated, squirrel.And{
+			squirrel.Eq{"t.valid_until": "Infinity"},
+			squirrel.Lt{"t.valid_from": cutoff},
+		})
+	}
+	if len(objectIdsOnHold) == 0 {
+		return outdated
+	}
+	return squirrel.And{
+		outdated,
+		squirrel.Expr("not (t.object_id = any(?))", objectIdsOnHold),
+	}
+}
+
+// ListRetainableIngestedRows returns the versions that can be removed, after the watermark if one is given
+func (repo *ClientDbRepository) ListRetainableIngestedRows(ctx context.Context, exec Executor,
+	tableName string, cutoff time.Time, keepLatestVersion bool, objectIdsOnHold []string,
+	watermark *models.OffloadingWatermark, limit int,
+) ([]models.RetainedRow, error) {
+	if err := validateClientDbExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	sql := NewQueryBuilder().
+		Select("t.id::text as id", "t.valid_from as created_at", "to_jsonb(t.*) as data").
+		From(pgIdentifierWithSchema(exec, tableName) + " t").
+		Where(retainableIngestedRows(cutoff, keepLatestVersion, objectIdsOnHold)).
+		OrderBy("t.valid_from, t.id").
+		Limit(uint64(limit))
+	if watermark != nil {
+		sql = sql.Where(squirrel.Expr("(t.valid_from, t.id) > (?, ?::uuid)",
+			watermark.WatermarkTime, watermark.WatermarkId))
+	}
+
+	return SqlToListOfModels(ctx, exec, sql, dbmodels.AdaptRetainedRow)
+}
+
+// CountRetainableIngestedRows returns the number of versions older than the cutoff that can be removed, and the number
+// of those that are kept because their object is on legal hold.
+func (repo *ClientDbRepository) CountRetainableIngestedRows(ctx context.Context, exec Executor,
+	tableName string, cutoff time.Time, keepLatestVersion bool, objectIdsOnHold []string,
+) (nbRows int, nbRowsOnHold int, err error) {
+	if err := validateClientDbExecutor(exec); err != nil {
+		return 0, 0, err
+	}
+
+	// count the rows older than the cutoff without the legal hold filter, then the rows that can actually be removed
+	allOutdated, allOutdatedArgs, err := retainableIngestedRows(cutoff, keepLatestVersion, nil).ToSql()
+	if err != nil {
+		return 0, 0, err
+	}
+	retainable, retainableArgs, err := retainableIngestedRows(cutoff, keepLatestVersion, objectIdsOnHold).ToSql()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	sql := NewQueryBuilder().
+		Select().
+		Column(squirrel.Expr("count(*) filter (where "+allOutdated+")", allOutdatedArgs...)).
+		Column(squirrel.Expr("count(*) filter (where "+retainable+")", retainableArgs...)).
+		From(pgIdentifierWithSchema(exec, tableName) + " t")
+
+	counts, err := SqlToRow(ctx, exec, sql, func(row pgx.CollectableRow) ([2]int, error) {
+		var counts [2]int
+		err := row.Scan(&counts[0], &counts[1])
+		return counts, err
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	return counts[1], counts[0] - counts[1], nil
+}
+
+func (repo *ClientDbRepository) DeleteIngestedRows(ctx context.Context, exec Executor, tableName string, ids []string) error {
+	if err := validateClientDbExecutor(exec); err != nil {
+		return err
+	}
+
+	sql := NewQueryBuilder().
+		Delete(pgIdentifierWithSchema(exec, tableName)).
+		Where(squirrel.Expr("id = any(?::uuid[])", ids))
+
+	return ExecBuilder(ctx, exec, sql)
+}
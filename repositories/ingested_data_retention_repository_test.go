@@ -0,0 +1,49 @@
+package repositories
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetainableIngestedRows(t *testing.T) {
+	cutoff := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name              string
+		keepLatestVersion bool
+		objectIdsOnHold   []string
+		expectedSql       string
+		expectedArgs      []any
+	}{
+		{
+			name:              "purge historical versions only",
+			keepLatestVersion: true,
+			expectedSql:       "(t.valid_until < ?)",
+			expectedArgs:      []any{cutoff},
+		},
+		{
+			name:              "include latest versions",
+			keepLatestVersion: false,
+			expectedSql:       "(t.valid_until < ? OR (t.valid_until = ? AND t.valid_from < ?))",
+			expectedArgs:      []any{cutoff, "Infinity", cutoff},
+		},
+		{
+			name:              "exclude objects on legal hold",
+			keepLatestVersion: true,
+			objectIdsOnHold:   []string{"a", "b"},
+			expectedSql:       "((t.valid_until < ?) AND not (t.object_id = any(?)))",
+			expectedArgs:      []any{cutoff, []string{"a", "b"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, args, err := retainableIngestedRows(cutoff, tt.keepLatestVersion, tt.objectIdsOnHold).ToSql()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedSql, sql)
+			assert.Equal(t, tt.expectedArgs, args)
+		})
+	}
+}
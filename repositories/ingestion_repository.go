@@ -11,22 +11,25 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/pure_utils"
 )
 
 type IngestionRepository interface {
-	IngestObjects(ctx context.Context, tx Transaction, payloads []models.ClientObject, table models.Table) (int, error)
+	IngestObjects(ctx context.Context, tx Transaction, payloads []models.ClientObject, table models.Table) ([]string, error)
 }
 
 type IngestionRepositoryImpl struct{}
 
+// IngestObjects writes the most recent version of each object, and returns the object_id of the objects that were
+// written. Payloads that are older than the ingested version of the object are skipped.
 func (repo *IngestionRepositoryImpl) IngestObjects(
 	ctx context.Context,
 	tx Transaction,
 	payloads []models.ClientObject,
 	table models.Table,
-) (int, error) {
+) ([]string, error) {
 	if err := validateClientDbExecutor(tx); err != nil {
-		return 0, err
+		return nil, err
 	}
 
 	mostRecentObjectIds, mostRecentPayloads := mostRecentPayloadsByObjectId(payloads)
@@ -35,7 +38,7 @@ func (repo *IngestionRepositoryImpl) IngestObjects(
 	previouslyIngestedObjects, err := repo.loadPreviouslyIngestedObjects(ctx, tx,
 		mostRecentObjectIds, table, fieldsToLoad)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 
 	payloadsToInsert, obsoleteIngestedObjectIds, validationErrors := compareAndMergePayloadsWithIngestedObjects(
@@ -43,7 +46,7 @@ func (repo *IngestionRepositoryImpl) IngestObjects(
 		previouslyIngestedObjects,
 	)
 	if len(validationErrors) > 0 {
-		return 0, errors.Join(models.BadParameterError, validationErrors)
+		return nil, errors.Join(models.BadParameterError, validationErrors)
 	}
 
 	if len(obsoleteIngestedObjectIds) > 0 {
@@ -54,17 +57,19 @@ func (repo *IngestionRepositoryImpl) IngestObjects(
 			obsoleteIngestedObjectIds,
 		)
 		if err != nil {
-			return 0, err
+			return nil, err
 		}
 	}
 
 	if len(payloadsToInsert) > 0 {
 		if err := repo.batchInsertPayloads(ctx, tx, payloadsToInsert, table); err != nil {
-			return 0, err
+			return nil, err
 		}
 	}
 
-	return len(payloadsToInsert), nil
+	return pure_utils.Map(payloadsToInsert, func(payload models.ClientObject) string {
+		return payload.Data["object_id"].(string)
+	}), nil
 }
 
 // Try to only load the fields that are actually missing from the payloads (in the case of a partial update).
@@ -0,0 +1,9 @@
+-- +goose Up
+
+alter table data_model_tables
+  add column decision_trigger_mode text not null default 'none';
+
+-- +goose Down
+
+alter table data_model_tables
+  drop column decision_trigger_mode;
@@ -0,0 +1,23 @@
+-- +goose Up
+
+create table retention_policies (
+  id uuid primary key default gen_random_uuid(),
+  org_id uuid not null,
+  table_name text not null,
+  action text not null check (action in ('delete', 'archive')),
+  retention_days integer not null check (retention_days > 0),
+  keep_latest_version boolean not null default true,
+  enabled boolean not null default true,
+  created_at timestamp with time zone not null default now(),
+  updated_at timestamp with time zone not null default now(),
+
+  constraint fk_org_id
+    foreign key (org_id) references organizations (id)
+    on delete cascade
+);
+
+create unique index idx_retention_policies_org_table on retention_policies (org_id, table_name);
+
+-- +goose Down
+
+drop table retention_policies;
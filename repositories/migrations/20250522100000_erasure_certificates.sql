@@ -0,0 +1,34 @@
+-- +goose Up
+
+create table erasure_certificates (
+  id uuid primary key default gen_random_uuid(),
+  org_id uuid not null,
+  object_type text not null,
+  subject_pseudonym text not null,
+  mode text not null check (mode in ('pseudonymise', 'delete')),
+  requested_by_user_id uuid,
+  requested_by_api_key text,
+  nb_ingested_rows integer not null default 0,
+  nb_linked_rows integer not null default 0,
+  nb_decisions integer not null default 0,
+  nb_rule_executions integer not null default 0,
+  nb_offloaded_rules integer not null default 0,
+  nb_entity_annotations integer not null default 0,
+  nb_case_files integer not null default 0,
+  nb_sanction_checks integer not null default 0,
+  nb_webhook_events integer not null default 0,
+  created_at timestamp with time zone not null default now(),
+
+  constraint fk_org_id
+    foreign key (org_id) references organizations (id)
+    on delete cascade,
+  constraint fk_requested_by_user_id
+    foreign key (requested_by_user_id) references users (id)
+    on delete set null
+);
+
+create index idx_erasure_certificates_org_subject on erasure_certificates (org_id, subject_pseudonym);
+
+-- +goose Down
+
+drop table erasure_certificates;
@@ -0,0 +1,7 @@
+-- +goose Up
+
+alter table data_model_fields add column constraints jsonb not null default '{}';
+
+-- +goose Down
+
+alter table data_model_fields drop column constraints;
@@ -0,0 +1,25 @@
+-- +goose Up
+
+create table decision_idempotency_keys (
+  id uuid primary key default gen_random_uuid(),
+  org_id uuid not null,
+  actor text not null,
+  key text not null,
+  request_hash bytea not null,
+  trigger_passed boolean not null,
+  decision_ids uuid[] not null default '{}',
+  nb_skipped integer not null default 0,
+  created_at timestamp with time zone not null default now(),
+  expires_at timestamp with time zone not null,
+
+  constraint fk_org_id
+    foreign key (org_id) references organizations (id)
+    on delete cascade
+);
+
+create unique index idx_decision_idempotency_keys_key on decision_idempotency_keys (org_id, actor, key);
+create index idx_decision_idempotency_keys_expires_at on decision_idempotency_keys (org_id, expires_at);
+
+-- +goose Down
+
+drop table decision_idempotency_keys;
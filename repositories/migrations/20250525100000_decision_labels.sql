@@ -0,0 +1,31 @@
+-- +goose Up
+
+create table decision_labels (
+  id uuid primary key default gen_random_uuid(),
+  org_id uuid not null,
+  decision_id uuid,
+  object_type text,
+  object_id text,
+  label text not null,
+  amount double precision,
+  currency text,
+  source text not null,
+  occurred_at timestamp with time zone not null,
+  created_at timestamp with time zone not null default now(),
+
+  constraint fk_org_id
+    foreign key (org_id) references organizations (id)
+    on delete cascade,
+  constraint fk_decision_id
+    foreign key (decision_id) references decisions (id)
+    on delete cascade,
+  constraint decision_or_object
+    check ((decision_id is null) <> (object_type is null and object_id is null))
+);
+
+create index idx_decision_labels_decision_id on decision_labels (decision_id) where decision_id is not null;
+create index idx_decision_labels_object on decision_labels (org_id, object_type, object_id) where object_id is not null;
+
+-- +goose Down
+
+drop table decision_labels;
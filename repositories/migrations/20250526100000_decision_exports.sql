@@ -0,0 +1,26 @@
+-- +goose Up
+
+create table decision_exports (
+  id uuid primary key default gen_random_uuid(),
+  org_id uuid not null,
+  format text not null,
+  filters jsonb not null default '{}',
+  status text not null default 'pending',
+  nb_files int not null default 0,
+  nb_decisions int not null default 0,
+  cursor_decision_id uuid,
+  requested_by_user_id uuid,
+  requested_by_api_key text,
+  created_at timestamp with time zone not null default now(),
+  updated_at timestamp with time zone not null default now(),
+
+  constraint fk_org_id
+    foreign key (org_id) references organizations (id)
+    on delete cascade
+);
+
+create index idx_decision_exports_org_id on decision_exports (org_id, created_at desc);
+
+-- +goose Down
+
+drop table decision_exports;
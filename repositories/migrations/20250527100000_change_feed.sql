@@ -0,0 +1,83 @@
+-- +goose Up
+-- +goose StatementBegin
+
+create table change_feed_configs (
+  org_id uuid primary key,
+  enabled boolean not null default false,
+  sink_type text,
+  sink_address text not null default '',
+  sink_topic text not null default '',
+  sink_cursor_txid xid8,
+  sink_cursor_id bigint,
+  updated_at timestamp with time zone not null default now(),
+
+  constraint fk_org_id
+    foreign key (org_id) references organizations (id)
+    on delete cascade
+);
+
+create table change_events (
+  id bigint generated always as identity primary key,
+  txid xid8 not null default pg_current_xact_id(),
+  org_id uuid not null,
+  entity_type text not null,
+  entity_id uuid not null,
+  operation text not null,
+  data jsonb not null,
+  created_at timestamp with time zone not null default now()
+);
+
+create index idx_change_events_org_cursor on change_events (org_id, txid, id);
+create index idx_change_events_created_at on change_events (created_at);
+
+create or replace function capture_change_event() returns trigger as $$
+  declare
+    v_org_id uuid;
+  begin
+    case tg_table_name
+      when 'decisions', 'cases' then
+        v_org_id := new.org_id;
+      when 'case_events' then
+        select org_id into v_org_id from cases where id = new.case_id;
+      when 'sanction_checks' then
+        select org_id into v_org_id from decisions where id = new.decision_id;
+      when 'rule_snoozes' then
+        select organization_id into v_org_id from snooze_groups where id = new.snooze_group_id;
+    end case;
+
+    if v_org_id is null or not exists (select 1 from change_feed_configs where org_id = v_org_id and enabled) then
+      return null;
+    end if;
+
+    insert into change_events (org_id, entity_type, entity_id, operation, data)
+    values (v_org_id, tg_argv[0], new.id, lower(tg_op), to_jsonb(new));
+    return null;
+  end;
+$$ language plpgsql;
+
+create trigger capture_change_event after insert or update on decisions
+  for each row execute function capture_change_event('decision');
+create trigger capture_change_event after insert or update on cases
+  for each row execute function capture_change_event('case');
+create trigger capture_change_event after insert or update on case_events
+  for each row execute function capture_change_event('case_event');
+create trigger capture_change_event after insert or update on sanction_checks
+  for each row execute function capture_change_event('sanction_check');
+create trigger capture_change_event after insert or update on rule_snoozes
+  for each row execute function capture_change_event('rule_snooze');
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+drop trigger capture_change_event on decisions;
+drop trigger capture_change_event on cases;
+drop trigger capture_change_event on case_events;
+drop trigger capture_change_event on sanction_checks;
+drop trigger capture_change_event on rule_snoozes;
+drop function capture_change_event;
+drop table change_events;
+drop table change_feed_configs;
+
+-- +goose StatementEnd
@@ -0,0 +1,29 @@
+-- +goose Up
+
+create table decision_requests (
+  id uuid primary key,
+  org_id uuid not null,
+  scenario_id uuid not null,
+  trigger_object_type text not null,
+  payload jsonb not null,
+  read_as_of timestamp with time zone,
+  status text not null default 'pending',
+  fallback_outcome text not null,
+  outcome text,
+  error text not null default '',
+  deadline timestamp with time zone not null,
+  requested_by_user_id uuid,
+  requested_by_api_key text,
+  created_at timestamp with time zone not null default now(),
+  updated_at timestamp with time zone not null default now(),
+
+  constraint fk_org_id
+    foreign key (org_id) references organizations (id)
+    on delete cascade
+);
+
+create index idx_decision_requests_org_id on decision_requests (org_id, created_at desc);
+
+-- +goose Down
+
+drop table decision_requests;
@@ -0,0 +1,55 @@
+-- +goose Up
+
+create table decision_reevaluation_batches (
+  id uuid primary key default gen_random_uuid(),
+  org_id uuid not null,
+  scenario_iteration_id uuid,
+  filters jsonb not null default '{}',
+  status text not null default 'pending',
+  nb_decisions int not null default 0,
+  nb_errors int not null default 0,
+  cursor_decision_id uuid,
+  requested_by_user_id uuid,
+  requested_by_api_key text,
+  created_at timestamp with time zone not null default now(),
+  updated_at timestamp with time zone not null default now(),
+
+  constraint fk_org_id
+    foreign key (org_id) references organizations (id)
+    on delete cascade
+);
+
+create index idx_decision_reevaluation_batches_org_id on decision_reevaluation_batches (org_id, created_at desc);
+
+create table decision_reevaluations (
+  id uuid primary key default gen_random_uuid(),
+  org_id uuid not null,
+  decision_id uuid not null,
+  reevaluation_decision_id uuid,
+  scenario_iteration_id uuid not null,
+  outcome text,
+  score int,
+  batch_id uuid,
+  created_at timestamp with time zone not null default now(),
+
+  constraint fk_org_id
+    foreign key (org_id) references organizations (id)
+    on delete cascade,
+  constraint fk_decision_id
+    foreign key (decision_id) references decisions (id)
+    on delete cascade,
+  constraint fk_reevaluation_decision_id
+    foreign key (reevaluation_decision_id) references decisions (id)
+    on delete cascade,
+  constraint fk_batch_id
+    foreign key (batch_id) references decision_reevaluation_batches (id)
+    on delete set null
+);
+
+create index idx_decision_reevaluations_decision_id on decision_reevaluations (decision_id, created_at desc);
+create unique index idx_decision_reevaluations_reevaluation_decision_id on decision_reevaluations (reevaluation_decision_id);
+
+-- +goose Down
+
+drop table decision_reevaluations;
+drop table decision_reevaluation_batches;
@@ -0,0 +1,65 @@
+-- +goose Up
+
+create table inbox_sla_policies (
+  id uuid primary key default gen_random_uuid(),
+  org_id uuid not null,
+  inbox_id uuid not null,
+  name text not null,
+  priority int not null default 0,
+  decision_outcome text,
+  tag_id uuid,
+  first_action_minutes int,
+  close_minutes int,
+  breach_action text not null default 'flag',
+  created_at timestamp with time zone not null default now(),
+  updated_at timestamp with time zone not null default now(),
+
+  constraint fk_org_id
+    foreign key (org_id) references organizations (id)
+    on delete cascade,
+  constraint fk_inbox_id
+    foreign key (inbox_id) references inboxes (id)
+    on delete cascade,
+  constraint fk_tag_id
+    foreign key (tag_id) references tags (id)
+    on delete cascade
+);
+
+create index idx_inbox_sla_policies_inbox_id on inbox_sla_policies (inbox_id, priority, created_at);
+
+alter table cases
+  add column sla_policy_id uuid,
+  add column first_action_due_at timestamp with time zone,
+  add column close_due_at timestamp with time zone,
+  add column sla_breached_at timestamp with time zone,
+  add constraint fk_sla_policy_id
+    foreign key (sla_policy_id) references inbox_sla_policies (id)
+    on delete set null;
+
+-- The next deadline of an open case, 'infinity' if it has none, so that cases can be sorted and paginated on it
+alter table cases
+  add column due_at timestamp with time zone not null generated always as (
+    coalesce(
+      case
+        when status = 'pending' then least(first_action_due_at, close_due_at)
+        when status = 'investigating' then close_due_at
+      end,
+      'infinity'
+    )
+  ) stored;
+
+create index idx_cases_sla_open on cases (org_id, due_at)
+  where (status in ('pending', 'investigating') and sla_breached_at is null);
+
+-- +goose Down
+
+drop index idx_cases_sla_open;
+
+alter table cases
+  drop column due_at,
+  drop column sla_breached_at,
+  drop column close_due_at,
+  drop column first_action_due_at,
+  drop column sla_policy_id;
+
+drop table inbox_sla_policies;
@@ -0,0 +1,24 @@
+-- +goose Up
+
+alter table inboxes
+  add column assignment_strategy text not null default 'manual';
+
+alter table inbox_users
+  add column out_of_office boolean not null default false,
+  add column skill_tag_ids text[] not null default '{}',
+  add column last_assigned_at timestamp with time zone;
+
+create index idx_cases_open_assignee on cases (assigned_to)
+  where (status in ('pending', 'investigating') and assigned_to is not null);
+
+-- +goose Down
+
+drop index idx_cases_open_assignee;
+
+alter table inbox_users
+  drop column last_assigned_at,
+  drop column skill_tag_ids,
+  drop column out_of_office;
+
+alter table inboxes
+  drop column assignment_strategy;
@@ -0,0 +1,30 @@
+-- +goose Up
+
+create table scenario_case_routing_rules (
+  id uuid primary key default gen_random_uuid(),
+  org_id uuid not null,
+  scenario_id uuid not null,
+  priority int not null,
+  name text not null,
+  formula jsonb not null,
+  inbox_id uuid not null,
+  tag_ids text[] not null default '{}',
+  created_at timestamp with time zone not null default now(),
+
+  constraint fk_org_id
+    foreign key (org_id) references organizations (id)
+    on delete cascade,
+  constraint fk_scenario_id
+    foreign key (scenario_id) references scenarios (id)
+    on delete cascade,
+  constraint fk_inbox_id
+    foreign key (inbox_id) references inboxes (id)
+    on delete cascade
+);
+
+create unique index idx_scenario_case_routing_rules_priority
+  on scenario_case_routing_rules (scenario_id, priority);
+
+-- +goose Down
+
+drop table scenario_case_routing_rules;
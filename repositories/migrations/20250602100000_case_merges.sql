@@ -0,0 +1,12 @@
+-- +goose Up
+
+alter table cases
+  add column merged_into_id uuid,
+  add constraint fk_merged_into_id
+    foreign key (merged_into_id) references cases (id)
+    on delete set null;
+
+-- +goose Down
+
+alter table cases
+  drop column merged_into_id;
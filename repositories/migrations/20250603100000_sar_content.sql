@@ -0,0 +1,9 @@
+-- +goose Up
+
+alter table suspicious_activity_reports
+  add column content jsonb;
+
+-- +goose Down
+
+alter table suspicious_activity_reports
+  drop column content;
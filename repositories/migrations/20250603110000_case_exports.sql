@@ -0,0 +1,26 @@
+-- +goose Up
+
+create table case_exports (
+  id uuid primary key default gen_random_uuid(),
+  org_id uuid not null,
+  case_id uuid not null,
+  status text not null default 'pending',
+  nb_files int not null default 0,
+  size_bytes bigint not null default 0,
+  requested_by_user_id uuid,
+  created_at timestamp with time zone not null default now(),
+  updated_at timestamp with time zone not null default now(),
+
+  constraint fk_org_id
+    foreign key (org_id) references organizations (id)
+    on delete cascade,
+  constraint fk_case_id
+    foreign key (case_id) references cases (id)
+    on delete cascade
+);
+
+create index idx_case_exports_case_id on case_exports (case_id, created_at desc);
+
+-- +goose Down
+
+drop table case_exports;
@@ -0,0 +1,21 @@
+-- +goose Up
+
+alter table case_files
+  add column scan_status text not null default 'not_scanned';
+
+alter table sanction_check_files
+  add column scan_status text not null default 'not_scanned';
+
+alter table suspicious_activity_reports
+  add column scan_status text not null default 'not_scanned';
+
+-- +goose Down
+
+alter table case_files
+  drop column scan_status;
+
+alter table sanction_check_files
+  drop column scan_status;
+
+alter table suspicious_activity_reports
+  drop column scan_status;
@@ -0,0 +1,61 @@
+-- +goose Up
+
+create table case_comments (
+  id uuid primary key default gen_random_uuid(),
+  org_id uuid not null,
+  case_id uuid not null,
+  parent_id uuid,
+  author_id uuid,
+  body text not null,
+  mentioned_user_ids uuid[] not null default '{}',
+  created_at timestamp with time zone not null default now(),
+  edited_at timestamp with time zone,
+  deleted_at timestamp with time zone,
+
+  constraint fk_org_id
+    foreign key (org_id) references organizations (id)
+    on delete cascade,
+  constraint fk_case_id
+    foreign key (case_id) references cases (id)
+    on delete cascade,
+  constraint fk_parent_id
+    foreign key (parent_id) references case_comments (id)
+    on delete cascade
+);
+
+create index idx_case_comments_case_id on case_comments (case_id, created_at);
+
+create table case_comment_versions (
+  id uuid primary key default gen_random_uuid(),
+  comment_id uuid not null,
+  body text not null,
+  mentioned_user_ids uuid[] not null default '{}',
+  edited_by uuid,
+  created_at timestamp with time zone not null default now(),
+
+  constraint fk_comment_id
+    foreign key (comment_id) references case_comments (id)
+    on delete cascade
+);
+
+create index idx_case_comment_versions_comment_id on case_comment_versions (comment_id, created_at);
+
+-- comments used to only exist as case events, they are copied with the id of their event
+insert into case_comments (id, org_id, case_id, author_id, body, created_at)
+select e.id, c.org_id, e.case_id, e.user_id, coalesce(e.additional_note, ''), e.created_at
+from case_events e
+inner join cases c on c.id = e.case_id
+where e.event_type = 'comment_added';
+
+update case_events
+set resource_type = 'case_comment', resource_id = id
+where event_type = 'comment_added';
+
+-- +goose Down
+
+update case_events
+set resource_type = null, resource_id = null
+where event_type = 'comment_added';
+
+drop table case_comment_versions;
+drop table case_comments;
@@ -0,0 +1,66 @@
+-- +goose Up
+
+create table notifications (
+  id uuid primary key default gen_random_uuid(),
+  org_id uuid not null,
+  user_id uuid not null,
+  type text not null,
+  case_id uuid,
+  case_event_id uuid,
+  actor_id uuid,
+  message text not null,
+  read_at timestamp with time zone,
+  created_at timestamp with time zone not null default now(),
+
+  constraint fk_org_id
+    foreign key (org_id) references organizations (id)
+    on delete cascade,
+  constraint fk_user_id
+    foreign key (user_id) references users (id)
+    on delete cascade,
+  constraint fk_case_id
+    foreign key (case_id) references cases (id)
+    on delete cascade
+);
+
+create index idx_notifications_user_id on notifications (user_id, created_at desc);
+create index idx_notifications_user_id_unread on notifications (user_id) where read_at is null;
+
+create table notification_preferences (
+  user_id uuid not null,
+  type text not null,
+  in_app boolean not null,
+  email boolean not null,
+  updated_at timestamp with time zone not null default now(),
+
+  primary key (user_id, type),
+  constraint fk_user_id
+    foreign key (user_id) references users (id)
+    on delete cascade
+);
+
+-- progress of the notification job of each organization: the last case event it handled, and the time up to which
+-- expired snoozes were looked for
+create table notification_cursors (
+  org_id uuid primary key,
+  event_created_at timestamp with time zone not null,
+  event_id uuid,
+  snoozes_checked_at timestamp with time zone not null,
+
+  constraint fk_org_id
+    foreign key (org_id) references organizations (id)
+    on delete cascade
+);
+
+create index idx_case_events_notifiable on case_events (created_at, id)
+  where event_type in ('case_assigned', 'case_escalated', 'comment_mention', 'sla_breached');
+
+create index idx_cases_snoozed_until on cases (org_id, snoozed_until) where snoozed_until is not null;
+
+-- +goose Down
+
+drop index idx_cases_snoozed_until;
+drop index idx_case_events_notifiable;
+drop table notification_cursors;
+drop table notification_preferences;
+drop table notifications;
@@ -0,0 +1,39 @@
+-- +goose Up
+
+create table case_saved_views (
+  id uuid primary key default gen_random_uuid(),
+  org_id uuid not null,
+  owner_id uuid not null,
+  -- the view is shared with the members of this inbox, it is private to its owner if null
+  inbox_id uuid,
+  name text not null,
+  filters jsonb not null default '{}',
+  sorting text not null default 'created_at',
+  sort_order text not null default 'DESC',
+  created_at timestamp with time zone not null default now(),
+  updated_at timestamp with time zone not null default now(),
+
+  constraint fk_org_id
+    foreign key (org_id) references organizations (id)
+    on delete cascade,
+  constraint fk_owner_id
+    foreign key (owner_id) references users (id)
+    on delete cascade,
+  constraint fk_inbox_id
+    foreign key (inbox_id) references inboxes (id)
+    on delete cascade
+);
+
+create index idx_case_saved_views_owner_id on case_saved_views (owner_id);
+create index idx_case_saved_views_inbox_id on case_saved_views (inbox_id) where inbox_id is not null;
+
+-- full text search of cases, over their name and the text of their comments
+create index idx_cases_name_fts on cases using gin (to_tsvector('simple', name));
+create index idx_case_comments_body_fts on case_comments using gin (to_tsvector('simple', body))
+  where deleted_at is null;
+
+-- +goose Down
+
+drop index idx_case_comments_body_fts;
+drop index idx_cases_name_fts;
+drop table case_saved_views;
@@ -0,0 +1,46 @@
+-- +goose Up
+
+create table case_bulk_actions (
+  id uuid primary key default gen_random_uuid(),
+  org_id uuid not null,
+  user_id uuid not null,
+  action text not null,
+  params jsonb not null default '{}',
+  status text not null default 'pending',
+  nb_cases int not null default 0,
+  nb_succeeded int not null default 0,
+  nb_failed int not null default 0,
+  created_at timestamp with time zone not null default now(),
+  updated_at timestamp with time zone not null default now(),
+
+  constraint fk_org_id
+    foreign key (org_id) references organizations (id)
+    on delete cascade,
+  constraint fk_user_id
+    foreign key (user_id) references users (id)
+    on delete cascade
+);
+
+create index idx_case_bulk_actions_org_id on case_bulk_actions (org_id, created_at desc);
+
+-- the result of a bulk action for each of its cases
+create table case_bulk_action_items (
+  bulk_action_id uuid not null,
+  case_id uuid not null,
+  status text not null default 'pending',
+  error text,
+  updated_at timestamp with time zone not null default now(),
+
+  primary key (bulk_action_id, case_id),
+  constraint fk_bulk_action_id
+    foreign key (bulk_action_id) references case_bulk_actions (id)
+    on delete cascade,
+  constraint fk_case_id
+    foreign key (case_id) references cases (id)
+    on delete cascade
+);
+
+-- +goose Down
+
+drop table case_bulk_action_items;
+drop table case_bulk_actions;
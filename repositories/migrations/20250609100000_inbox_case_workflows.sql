@@ -0,0 +1,71 @@
+-- +goose Up
+
+create table inbox_case_workflows (
+  inbox_id uuid primary key,
+  org_id uuid not null,
+  statuses jsonb not null default '[]',
+  transitions jsonb not null default '[]',
+  created_at timestamp with time zone not null default now(),
+  updated_at timestamp with time zone not null default now(),
+
+  constraint fk_org_id
+    foreign key (org_id) references organizations (id)
+    on delete cascade,
+  constraint fk_inbox_id
+    foreign key (inbox_id) references inboxes (id)
+    on delete cascade
+);
+
+-- cases can have the custom statuses of the workflow of their inbox, which are all open statuses
+alter table cases
+  drop constraint cases_status_check,
+  add constraint cases_status_check check (status ~ '^[a-z][a-z0-9_]*$');
+
+drop index idx_cases_open_assignee;
+create index idx_cases_open_assignee on cases (assigned_to)
+  where (status != 'closed' and assigned_to is not null);
+
+-- the close deadline applies to the cases in a custom status
+alter table cases drop column due_at;
+alter table cases
+  add column due_at timestamp with time zone not null generated always as (
+    coalesce(
+      case
+        when status = 'pending' then least(first_action_due_at, close_due_at)
+        when status != 'closed' then close_due_at
+      end,
+      'infinity'
+    )
+  ) stored;
+
+create index idx_cases_sla_open on cases (org_id, due_at)
+  where (status != 'closed' and sla_breached_at is null);
+
+-- +goose Down
+
+drop index idx_cases_open_assignee;
+create index idx_cases_open_assignee on cases (assigned_to)
+  where (status in ('pending', 'investigating') and assigned_to is not null);
+
+update cases set status = 'investigating' where status not in ('pending', 'investigating', 'closed');
+
+alter table cases drop column due_at;
+alter table cases
+  add column due_at timestamp with time zone not null generated always as (
+    coalesce(
+      case
+        when status = 'pending' then least(first_action_due_at, close_due_at)
+        when status = 'investigating' then close_due_at
+      end,
+      'infinity'
+    )
+  ) stored;
+
+create index idx_cases_sla_open on cases (org_id, due_at)
+  where (status in ('pending', 'investigating') and sla_breached_at is null);
+
+alter table cases
+  drop constraint cases_status_check,
+  add constraint cases_status_check check (status in ('pending', 'investigating', 'closed'));
+
+drop table inbox_case_workflows;
@@ -0,0 +1,77 @@
+-- +goose Up
+
+create table inbox_qa_rules (
+  id uuid primary key default gen_random_uuid(),
+  org_id uuid not null,
+  inbox_id uuid not null,
+  name text not null,
+  priority int not null default 0,
+  case_outcome text,
+  tag_id uuid,
+  sample_rate double precision not null,
+  reviewer_ids text[] not null default '{}',
+  created_at timestamp with time zone not null default now(),
+  updated_at timestamp with time zone not null default now(),
+
+  constraint fk_org_id
+    foreign key (org_id) references organizations (id)
+    on delete cascade,
+  constraint fk_inbox_id
+    foreign key (inbox_id) references inboxes (id)
+    on delete cascade,
+  constraint fk_tag_id
+    foreign key (tag_id) references tags (id)
+    on delete cascade
+);
+
+create index idx_inbox_qa_rules_inbox_id on inbox_qa_rules (inbox_id, priority, created_at);
+
+create table case_qa_reviews (
+  id uuid primary key default gen_random_uuid(),
+  org_id uuid not null,
+  inbox_id uuid not null,
+  case_id uuid not null,
+  rule_id uuid,
+  analyst_id uuid,
+  reviewer_id uuid not null,
+  case_outcome text not null,
+  status text not null default 'pending',
+  verdict text,
+  reviewer_outcome text,
+  comment text not null default '',
+  created_at timestamp with time zone not null default now(),
+  completed_at timestamp with time zone,
+
+  constraint fk_org_id
+    foreign key (org_id) references organizations (id)
+    on delete cascade,
+  constraint fk_case_id
+    foreign key (case_id) references cases (id)
+    on delete cascade,
+  constraint fk_rule_id
+    foreign key (rule_id) references inbox_qa_rules (id)
+    on delete set null,
+  constraint fk_analyst_id
+    foreign key (analyst_id) references users (id)
+    on delete set null,
+  constraint fk_reviewer_id
+    foreign key (reviewer_id) references users (id)
+    on delete cascade
+);
+
+create index idx_case_qa_reviews_reviewer_id on case_qa_reviews (reviewer_id, status, created_at desc);
+create index idx_case_qa_reviews_case_id on case_qa_reviews (case_id);
+create index idx_case_qa_reviews_completed on case_qa_reviews (org_id, completed_at) where status = 'completed';
+
+drop index idx_case_events_notifiable;
+create index idx_case_events_notifiable on case_events (created_at, id)
+  where event_type in ('case_assigned', 'case_escalated', 'comment_mention', 'sla_breached', 'qa_review_requested');
+
+-- +goose Down
+
+drop index idx_case_events_notifiable;
+create index idx_case_events_notifiable on case_events (created_at, id)
+  where event_type in ('case_assigned', 'case_escalated', 'comment_mention', 'sla_breached');
+
+drop table case_qa_reviews;
+drop table inbox_qa_rules;
@@ -0,0 +1,9 @@
+-- +goose Up
+
+-- the case metrics are computed from the case events of a period
+create index idx_case_events_metrics on case_events (event_type, created_at)
+  where event_type in ('case_created', 'status_updated', 'case_escalated');
+
+-- +goose Down
+
+drop index idx_case_events_metrics;
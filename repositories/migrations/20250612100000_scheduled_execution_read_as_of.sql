@@ -0,0 +1,7 @@
+-- +goose Up
+
+alter table scheduled_executions add column read_as_of timestamp with time zone;
+
+-- +goose Down
+
+alter table scheduled_executions drop column read_as_of;
@@ -0,0 +1,34 @@
+-- +goose Up
+
+-- A case can miss its first action deadline, and then its close deadline: each of them is flagged once
+alter table cases
+  add column first_action_breached_at timestamp with time zone,
+  add column close_breached_at timestamp with time zone;
+
+update cases as c
+set first_action_breached_at = e.created_at
+from case_events as e
+where e.case_id = c.id
+  and e.event_type = 'sla_breached'
+  and e.new_value = 'first_action';
+
+update cases as c
+set close_breached_at = e.created_at
+from case_events as e
+where e.case_id = c.id
+  and e.event_type = 'sla_breached'
+  and e.new_value = 'close';
+
+create index idx_cases_sla_first_action on cases (org_id, first_action_due_at)
+  where (status = 'pending' and first_action_breached_at is null);
+create index idx_cases_sla_close on cases (org_id, close_due_at)
+  where (status != 'closed' and close_breached_at is null);
+
+-- +goose Down
+
+drop index idx_cases_sla_close;
+drop index idx_cases_sla_first_action;
+
+alter table cases
+  drop column close_breached_at,
+  drop column first_action_breached_at;
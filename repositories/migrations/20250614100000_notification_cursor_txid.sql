@@ -0,0 +1,39 @@
+-- +goose Up
+
+-- Case events are notified in the order of the transaction that wrote them, like the change feed: a cursor on their
+-- creation time skips the events of transactions that commit after a later event was notified.
+alter table case_events add column txid xid8;
+alter table case_events alter column txid set default pg_current_xact_id();
+
+create index idx_case_events_txid on case_events (txid, id) where txid is not null;
+
+alter table notification_cursors add column event_txid xid8;
+
+-- the events that were not notified yet are given the transaction id of the migration, and the cursors restart before it.
+-- This is not a change of the events, so it is kept out of the change feed.
+alter table case_events disable trigger capture_change_event;
+
+update case_events as e
+set txid = pg_current_xact_id()
+from cases as c, notification_cursors as nc
+where c.id = e.case_id
+  and nc.org_id = c.org_id
+  and e.event_type in ('case_assigned', 'case_escalated', 'comment_mention', 'sla_breached', 'qa_review_requested')
+  and (e.created_at, e.id) > (nc.event_created_at, coalesce(nc.event_id, '00000000-0000-0000-0000-000000000000'));
+
+alter table case_events enable trigger capture_change_event;
+
+update notification_cursors set event_txid = '0', event_id = null;
+
+alter table notification_cursors
+  alter column event_txid set not null,
+  drop column event_created_at;
+
+-- +goose Down
+
+alter table notification_cursors add column event_created_at timestamp with time zone not null default now();
+alter table notification_cursors alter column event_created_at drop default;
+alter table notification_cursors drop column event_txid;
+
+drop index idx_case_events_txid;
+alter table case_events drop column txid;
@@ -0,0 +1,271 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/repositories/dbmodels"
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+)
+
+func (repo *MarbleDbRepository) CreateNotification(ctx context.Context, exec Executor,
+	input models.NotificationCreate,
+) (models.Notification, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return models.Notification{}, err
+	}
+
+	sql := NewQueryBuilder().
+		Insert(dbmodels.TABLE_NOTIFICATIONS).
+		Columns(
+			"org_id",
+			"user_id",
+			"type",
+			"case_id",
+			"case_event_id",
+			"actor_id",
+			"message",
+		).
+		Values(
+			input.OrganizationId,
+			input.UserId,
+			input.Type,
+			input.CaseId,
+			input.CaseEventId,
+			input.ActorId,
+			input.Message,
+		).
+		Suffix(fmt.Sprintf("RETURNING %s", strings.Join(dbmodels.SelectNotificationColumns, ",")))
+
+	return SqlToModel(ctx, exec, sql, dbmodels.AdaptNotification)
+}
+
+func (repo *MarbleDbRepository) GetNotificationById(ctx context.Context, exec Executor, id string) (models.Notification, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return models.Notification{}, err
+	}
+
+	sql := NewQueryBuilder().
+		Select(dbmodels.SelectNotificationColumns...).
+		From(dbmodels.TABLE_NOTIFICATIONS).
+		Where(squirrel.Eq{"id": id})
+
+	return SqlToModel(ctx, exec, sql, dbmodels.AdaptNotification)
+}
+
+// ListNotifications returns the notifications of a user, most recent first
+func (repo *MarbleDbRepository) ListNotifications(ctx context.Context, exec Executor,
+	filters models.NotificationFilters,
+) ([]models.Notification, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	sql := NewQueryBuilder().
+		Select(dbmodels.SelectNotificationColumns...).
+		From(dbmodels.TABLE_NOTIFICATIONS).
+		Where(squirrel.Eq{"user_id": filters.UserId}).
+		OrderBy("created_at desc", "id desc").
+		Limit(uint64(filters.Limit))
+
+	if filters.UnreadOnly {
+		sql = sql.Where("read_at is null")
+	}
+	if filters.Before != nil {
+		sql = sql.Where(squirrel.Lt{"created_at": *filters.Before})
+	}
+
+	return SqlToListOfModels(ctx, exec, sql, dbmodels.AdaptNotification)
+}
+
+func (repo *MarbleDbRepository) CountUnreadNotifications(ctx context.Context, exec Executor, userId string) (int, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return 0, err
+	}
+
+	query, args, err := NewQueryBuilder().
+		Select("count(*)").
+		From(dbmodels.TABLE_NOTIFICATIONS).
+		Where(squirrel.Eq{"user_id": userId}).
+		Where("read_at is null").
+		ToSql()
+	if err != nil {
+		return 0, errors.Wrap(err, "can't build sql query")
+	}
+
+	var count int
+	if err := exec.QueryRow(ctx, query, args...).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (repo *MarbleDbRepository) MarkNotificationRead(ctx context.Context, exec Executor, id string) error {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return err
+	}
+
+	return ExecBuilder(ctx, exec, NewQueryBuilder().
+		Update(dbmodels.TABLE_NOTIFICATIONS).
+		Set("read_at", squirrel.Expr("now()")).
+		Where(squirrel.Eq{"id": id}).
+		Where("read_at is null"))
+}
+
+func (repo *MarbleDbRepository) MarkAllNotificationsRead(ctx context.Context, exec Executor, userId string) (int, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return 0, err
+	}
+
+	return ExecBuilderRowsAffected(ctx, exec, NewQueryBuilder().
+		Update(dbmodels.TABLE_NOTIFICATIONS).
+		Set("read_at", squirrel.Expr("now()")).
+		Where(squirrel.Eq{"user_id": userId}).
+		Where("read_at is null"))
+}
+
+// ListNotificationPreferences returns the preferences saved by a user, which do not cover the types of notifications
+// the user did not configure
+func (repo *MarbleDbRepository) ListNotificationPreferences(ctx context.Context, exec Executor,
+	userId string,
+) ([]models.NotificationPreference, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	sql := NewQueryBuilder().
+		Select(dbmodels.SelectNotificationPreferenceColumns...).
+		From(dbmodels.TABLE_NOTIFICATION_PREFERENCES).
+		Where(squirrel.Eq{"user_id": userId}).
+		OrderBy("type")
+
+	return SqlToListOfModels(ctx, exec, sql, dbmodels.AdaptNotificationPreference)
+}
+
+func (repo *MarbleDbRepository) UpsertNotificationPreference(ctx context.Context, exec Executor,
+	userId string, preference models.NotificationPreference,
+) error {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return err
+	}
+
+	return ExecBuilder(ctx, exec, NewQueryBuilder().
+		Insert(dbmodels.TABLE_NOTIFICATION_PREFERENCES).
+		Columns("user_id", "type", "in_app", "email").
+		Values(userId, preference.Type, preference.InApp, preference.Email).
+		Suffix(`on conflict (user_id, type) do update set
+			in_app = excluded.in_app,
+			email = excluded.email,
+			updated_at = now()`))
+}
+
+func (repo *MarbleDbRepository) GetNotificationCursor(ctx context.Context, exec Executor,
+	orgId string,
+) (*models.NotificationCursor, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	sql := NewQueryBuilder().
+		Select(dbmodels.SelectNotificationCursorColumns...).
+		From(dbmodels.TABLE_NOTIFICATION_CURSORS).
+		Where(squirrel.Eq{"org_id": orgId})
+
+	return SqlToOptionalModel(ctx, exec, sql, dbmodels.AdaptNotificationCursor)
+}
+
+func (repo *MarbleDbRepository) SaveNotificationCursor(ctx context.Context, exec Executor,
+	orgId string, cursor models.NotificationCursor,
+) error {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return err
+	}
+
+	return ExecBuilder(ctx, exec, NewQueryBuilder().
+		Insert(dbmodels.TABLE_NOTIFICATION_CURSORS).
+		Columns("org_id", "event_txid", "event_id", "snoozes_checked_at").
+		Values(orgId, squirrel.Expr("?::text::xid8", cursor.EventTxId), cursor.EventId, cursor.SnoozesCheckedAt).
+		Suffix(`on conflict (org_id) do update set
+			event_txid = excluded.event_txid,
+			event_id = excluded.event_id,
+			snoozes_checked_at = excluded.snoozes_checked_at`))
+}
+
+// InitNotificationCursor creates the cursor of an organization, after the events of the transactions that are already
+// committed. The transactions that are still in progress are after the cursor.
+func (repo *MarbleDbRepository) InitNotificationCursor(ctx context.Context, exec Executor,
+	orgId string, snoozesCheckedAt time.Time,
+) error {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return err
+	}
+
+	return ExecBuilder(ctx, exec, NewQueryBuilder().
+		Insert(dbmodels.TABLE_NOTIFICATION_CURSORS).
+		Columns("org_id", "event_txid", "event_id", "snoozes_checked_at").
+		Values(orgId, squirrel.Expr("pg_snapshot_xmin(pg_current_snapshot())"), nil, snoozesCheckedAt).
+		Suffix("on conflict (org_id) do nothing"))
+}
+
+// ListNotifiableCaseEvents returns the case events of an organization from which users are notified, after the cursor,
+// in the order of the transaction that wrote them. Like the change feed, only the events written by transactions older
+// than the oldest transaction still in progress are returned: a transaction that is still in progress may commit events
+// that sort before the ones that are already visible.
+func (repo *MarbleDbRepository) ListNotifiableCaseEvents(ctx context.Context, exec Executor,
+	orgId string, after models.NotificationCursor, limit int,
+) ([]models.NotifiableCaseEvent, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	eventTypes := make([]models.CaseEventType, 0, len(models.NotifiableCaseEventTypes))
+	for eventType := range models.NotifiableCaseEventTypes {
+		eventTypes = append(eventTypes, eventType)
+	}
+
+	// the nil uuid sorts before any other, so that all the events of the transaction of the cursor are listed when it
+	// has no event id
+	afterId := uuid.Nil.String()
+	if after.EventId != nil {
+		afterId = *after.EventId
+	}
+
+	sql := NewQueryBuilder().
+		Select(columnsNames("e", dbmodels.SelectCaseEventColumn)...).
+		Column("e.txid::text::bigint AS txid").
+		From(dbmodels.TABLE_CASE_EVENTS+" AS e").
+		Join(dbmodels.TABLE_CASES+" AS c ON c.id = e.case_id").
+		Where(squirrel.Eq{"c.org_id": orgId}).
+		Where(squirrel.Eq{"e.event_type": eventTypes}).
+		Where("(e.txid, e.id) > (?::text::xid8, ?)", after.EventTxId, afterId).
+		Where("e.txid < pg_snapshot_xmin(pg_current_snapshot())").
+		OrderBy("e.txid", "e.id").
+		Limit(uint64(limit))
+
+	return SqlToListOfModels(ctx, exec, sql, dbmodels.AdaptNotifiableCaseEvent)
+}
+
+// ListCasesWithExpiredSnooze returns the cases of an organization whose snooze expired in the given time range
+func (repo *MarbleDbRepository) ListCasesWithExpiredSnooze(ctx context.Context, exec Executor,
+	orgId string, after, until time.Time,
+) ([]models.Case, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	sql := NewQueryBuilder().
+		Select(dbmodels.SelectCaseColumn...).
+		From(dbmodels.TABLE_CASES).
+		Where(squirrel.Eq{"org_id": orgId}).
+		Where(squirrel.Gt{"snoozed_until": after}).
+		Where(squirrel.LtOrEq{"snoozed_until": until}).
+		Where(squirrel.NotEq{"status": models.FinalizedCaseStatuses}).
+		OrderBy("snoozed_until")
+
+	return SqlToListOfModels(ctx, exec, sql, dbmodels.AdaptCase)
+}
@@ -62,3 +62,19 @@ func (repo *MarbleDbRepository) SaveOffloadingWatermark(ctx context.Context, tx
 
 	return ExecBuilder(ctx, tx, sql)
 }
+
+// ListDecisionRuleRefs returns the rule executions of decisions, to find the keys of their offloaded evaluations
+func (repo *MarbleDbRepository) ListDecisionRuleRefs(ctx context.Context, exec Executor,
+	decisionIds []string,
+) ([]models.DecisionRuleRef, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	sql := NewQueryBuilder().
+		Select("decision_id", "rule_id", "outcome").
+		From(dbmodels.TABLE_DECISION_RULES).
+		Where(squirrel.Eq{"decision_id": decisionIds})
+
+	return SqlToListOfModels(ctx, exec, sql, dbmodels.AdaptDecisionRuleRef)
+}
@@ -19,6 +19,8 @@ type options struct {
 	convoyClientProvider          ConvoyClientProvider
 	convoyRateLimit               int
 	openSanctions                 infra.OpenSanctions
+	clamAvAddress                 string
+	smtp                          infra.SmtpConfig
 	riverClient                   *river.Client[pgx.Tx]
 	tp                            trace.TracerProvider
 }
@@ -58,6 +60,18 @@ func WithOpenSanctions(openSanctionsConfig infra.OpenSanctions) Option {
 	}
 }
 
+func WithClamAvAddress(address string) Option {
+	return func(o *options) {
+		o.clamAvAddress = address
+	}
+}
+
+func WithSmtp(config infra.SmtpConfig) Option {
+	return func(o *options) {
+		o.smtp = config
+	}
+}
+
 func WithRiverClient(client *river.Client[pgx.Tx]) Option {
 	return func(o *options) {
 		o.riverClient = client
@@ -96,6 +110,9 @@ type Repositories struct {
 	TransferCheckEnrichmentRepository *TransferCheckEnrichmentRepository
 	TaskQueueRepository               TaskQueueRepository
 	ScenarioTestrunRepository         ScenarioTestRunRepository
+	ChangeFeedSinkRepository          ChangeFeedSinkRepository
+	FileScanner                       FileScanner
+	EmailSender                       EmailSender
 }
 
 func NewQueryBuilder() squirrel.StatementBuilderType {
@@ -136,6 +153,7 @@ func NewRepositories(
 		CustomListRepository:          &CustomListRepositoryPostgresql{},
 		UploadLogRepository:           &UploadLogRepositoryImpl{},
 		BlobRepository:                blobRepository,
+		ChangeFeedSinkRepository:      NewChangeFeedSinkRepository(),
 		MarbleAnalyticsRepository: MarbleAnalyticsRepository{
 			metabase: options.metabase,
 		},
@@ -151,5 +169,7 @@ func NewRepositories(
 			options.transfercheckEnrichmentBucket,
 		),
 		TaskQueueRepository: NewTaskQueueRepository(options.riverClient),
+		FileScanner:         NewFileScanner(options.clamAvAddress),
+		EmailSender:         NewEmailSender(options.smtp),
 	}
 }
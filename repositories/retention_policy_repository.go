@@ -0,0 +1,228 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/repositories/dbmodels"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// decisionOnLegalHold matches decisions attached to a case that is still open or that has a suspicious activity report.
+// Those decisions, and the versions of their trigger objects, must not be removed by a retention policy.
+const decisionOnLegalHold = `exists (
+	select 1 from cases c
+	where c.id = d.case_id
+	and (
+		c.status <> 'closed'
+		or exists (select 1 from suspicious_activity_reports sar where sar.case_id = c.id and sar.deleted_at is null)
+	)
+)`
+
+// decisionKept matches all the decisions that retention policies must keep. Decisions with a sanction check are kept
+// in addition to those on legal hold, because sanction check data is not covered by retention policies.
+const decisionKept = "(" + decisionOnLegalHold + " or exists (select 1 from sanction_checks sc where sc.decision_id = d.id))"
+
+func (repo *MarbleDbRepository) ListRetentionPolicies(ctx context.Context, exec Executor, orgId string) ([]models.RetentionPolicy, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	sql := NewQueryBuilder().
+		Select(dbmodels.SelectRetentionPolicyColumns...).
+		From(dbmodels.TABLE_RETENTION_POLICIES).
+		Where(squirrel.Eq{"org_id": orgId}).
+		OrderBy("table_name")
+
+	return SqlToListOfModels(ctx, exec, sql, dbmodels.AdaptRetentionPolicy)
+}
+
+func (repo *MarbleDbRepository) GetRetentionPolicy(ctx context.Context, exec Executor, id string) (models.RetentionPolicy, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return models.RetentionPolicy{}, err
+	}
+
+	sql := NewQueryBuilder().
+		Select(dbmodels.SelectRetentionPolicyColumns...).
+		From(dbmodels.TABLE_RETENTION_POLICIES).
+		Where(squirrel.Eq{"id": id})
+
+	return SqlToModel(ctx, exec, sql, dbmodels.AdaptRetentionPolicy)
+}
+
+func (repo *MarbleDbRepository) CreateRetentionPolicy(ctx context.Context, exec Executor,
+	input models.CreateRetentionPolicyInput,
+) (models.RetentionPolicy, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return models.RetentionPolicy{}, err
+	}
+
+	sql := NewQueryBuilder().
+		Insert(dbmodels.TABLE_RETENTION_POLICIES).
+		Columns("id", "org_id", "table_name", "action", "retention_days", "keep_latest_version", "enabled").
+		Values(
+			uuid.NewString(),
+			input.OrganizationId,
+			input.TableName,
+			input.Action.String(),
+			input.RetentionDays,
+			input.KeepLatestVersion,
+			input.Enabled,
+		).
+		Suffix("returning *")
+
+	return SqlToModel(ctx, exec, sql, dbmodels.AdaptRetentionPolicy)
+}
+
+func (repo *MarbleDbRepository) UpdateRetentionPolicy(ctx context.Context, exec Executor,
+	input models.UpdateRetentionPolicyInput,
+) (models.RetentionPolicy, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return models.RetentionPolicy{}, err
+	}
+
+	sql := NewQueryBuilder().
+		Update(dbmodels.TABLE_RETENTION_POLICIES).
+		Set("updated_at", time.Now()).
+		Where(squirrel.Eq{"id": input.Id}).
+		Suffix("returning *")
+
+	if input.Action != nil {
+		sql = sql.Set("action", input.Action.String())
+	}
+	if input.RetentionDays != nil {
+		sql = sql.Set("retention_days", *input.RetentionDays)
+	}
+	if input.KeepLatestVersion != nil {
+		sql = sql.Set("keep_latest_version", *input.KeepLatestVersion)
+	}
+	if input.Enabled != nil {
+		sql = sql.Set("enabled", *input.Enabled)
+	}
+
+	return SqlToModel(ctx, exec, sql, dbmodels.AdaptRetentionPolicy)
+}
+
+func (repo *MarbleDbRepository) DeleteRetentionPolicy(ctx context.Context, exec Executor, id string) error {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return err
+	}
+
+	sql := NewQueryBuilder().
+		Delete(dbmodels.TABLE_RETENTION_POLICIES).
+		Where(squirrel.Eq{"id": id})
+
+	return ExecBuilder(ctx, exec, sql)
+}
+
+// ListObjectIdsOnLegalHold returns the ids of the objects of a table that are the trigger object of a decision on legal hold.
+// Their versions must be kept by retention policies on ingested data.
+func (repo *MarbleDbRepository) ListObjectIdsOnLegalHold(ctx context.Context, exec Executor,
+	orgId, tableName string,
+) ([]string, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	sql := NewQueryBuilder().
+		Select("distinct d.trigger_object->>'object_id'").
+		From(dbmodels.TABLE_DECISIONS + " d").
+		Where(squirrel.Eq{
+			"d.org_id":              orgId,
+			"d.trigger_object_type": tableName,
+		}).
+		Where(decisionOnLegalHold)
+
+	return SqlToListOfRow(ctx, exec, sql, func(row pgx.CollectableRow) (string, error) {
+		var objectId string
+		err := row.Scan(&objectId)
+		return objectId, err
+	})
+}
+
+// ListRetainableDecisions returns the decisions that can be removed, with their rule executions so that they are archived
+// with the decision. Evaluations that were offloaded are not part of the rule executions.
+func (repo *MarbleDbRepository) ListRetainableDecisions(ctx context.Context, exec Executor,
+	orgId string, cutoff time.Time, watermark *models.OffloadingWatermark, limit int,
+) ([]models.RetainedRow, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	if watermark == nil {
+		watermark = &models.OffloadingWatermark{
+			WatermarkTime: time.Time{},
+			WatermarkId:   uuid.UUID{}.String(),
+		}
+	}
+
+	sql := NewQueryBuilder().
+		Select(
+			"d.id",
+			"d.created_at",
+			`to_jsonb(d.*) || jsonb_build_object('rule_executions', (
+				select coalesce(jsonb_agg(to_jsonb(dr.*)), '[]'::jsonb)
+				from decision_rules dr
+				where dr.decision_id = d.id
+			)) as data`,
+		).
+		From(dbmodels.TABLE_DECISIONS + " d").
+		Where(squirrel.And{
+			squirrel.Eq{"d.org_id": orgId},
+			squirrel.Lt{"d.created_at": cutoff},
+			squirrel.Expr("(d.created_at, d.id) > (?, ?)", watermark.WatermarkTime, watermark.WatermarkId),
+			squirrel.Expr("not " + decisionKept),
+		}).
+		OrderBy("d.created_at, d.id").
+		Limit(uint64(limit))
+
+	return SqlToListOfModels(ctx, exec, sql, dbmodels.AdaptRetainedRow)
+}
+
+// CountRetainableDecisions returns the number of decisions older than the cutoff that can be removed, and the number
+// of those that are kept because they are on legal hold or have a sanction check.
+func (repo *MarbleDbRepository) CountRetainableDecisions(ctx context.Context, exec Executor,
+	orgId string, cutoff time.Time,
+) (nbRows int, nbRowsOnHold int, err error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return 0, 0, err
+	}
+
+	sql := NewQueryBuilder().
+		Select(
+			"count(*) filter (where not "+decisionKept+")",
+			"count(*) filter (where "+decisionKept+")",
+		).
+		From(dbmodels.TABLE_DECISIONS + " d").
+		Where(squirrel.Eq{"d.org_id": orgId}).
+		Where(squirrel.Lt{"d.created_at": cutoff})
+
+	counts, err := SqlToRow(ctx, exec, sql, func(row pgx.CollectableRow) ([2]int, error) {
+		var counts [2]int
+		err := row.Scan(&counts[0], &counts[1])
+		return counts, err
+	})
+	return counts[0], counts[1], err
+}
+
+// DeleteDecisions deletes decisions and their rule executions
+func (repo *MarbleDbRepository) DeleteDecisions(ctx context.Context, exec Executor, ids []string) error {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return err
+	}
+
+	sql := NewQueryBuilder().
+		Delete(dbmodels.TABLE_DECISIONS).
+		Where(squirrel.Eq{"id": ids})
+
+	return ExecBuilder(ctx, exec, sql)
+}
+
+func (repo *MarbleDbRepository) GetRetentionArchiveKey(orgId, tableName, batchId string, createdAt time.Time) string {
+	return fmt.Sprintf("retention/%s/%s/%d/%d/%s.jsonl", tableName, orgId,
+		createdAt.Year(), createdAt.Month(), batchId)
+}
@@ -305,12 +305,14 @@ func (repo *MarbleDbRepository) CreateSanctionCheckFile(ctx context.Context, exe
 				"sanction_check_id",
 				"file_name",
 				"file_reference",
+				"scan_status",
 			).
 			Values(
 				input.BucketName,
 				input.SanctionCheckId,
 				input.FileName,
 				input.FileReference,
+				input.ScanStatus,
 			).
 			Suffix(fmt.Sprintf("RETURNING %s", strings.Join(dbmodels.SelectSanctionCheckFileColumn, ","))),
 		dbmodels.AdaptSanctionCheckFile,
@@ -105,6 +105,7 @@ func (repo *MarbleDbRepository) CreateScheduledExecution(ctx context.Context, ex
 				"scenario_iteration_id",
 				"status",
 				"manual",
+				"read_as_of",
 			).
 			Values(
 				newScheduledExecutionId,
@@ -113,6 +114,7 @@ func (repo *MarbleDbRepository) CreateScheduledExecution(ctx context.Context, ex
 				createScheduledEx.ScenarioIterationId,
 				models.ScheduledExecutionPending.String(),
 				createScheduledEx.Manual,
+				createScheduledEx.ReadAsOf,
 			),
 	)
 	return err
@@ -170,3 +170,16 @@ func ExecBuilder(ctx context.Context, exec Executor, builder SqlBuilder) (err er
 	}
 	return nil
 }
+
+func ExecBuilderRowsAffected(ctx context.Context, exec Executor, builder SqlBuilder) (int, error) {
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return 0, errors.Wrap(err, "can't build sql query")
+	}
+
+	tag, err := exec.Exec(ctx, query, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, fmt.Sprintf("error executing sql query: %s", query))
+	}
+	return int(tag.RowsAffected()), nil
+}
@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"encoding/json"
 	"time"
 
 	"github.com/Masterminds/squirrel"
@@ -67,17 +68,32 @@ func (repo *MarbleDbRepository) CreateSuspiciousActivityReport(ctx context.Conte
 		reportId = utils.Ptr(uuid.NewString())
 	}
 
+	var content []byte
+	if req.Content != nil {
+		var err error
+		if content, err = json.Marshal(req.Content); err != nil {
+			return models.SuspiciousActivityReport{}, err
+		}
+	}
+
+	scanStatus := models.FileScanNotScanned
+	if req.ScanStatus != nil {
+		scanStatus = *req.ScanStatus
+	}
+
 	sql := NewQueryBuilder().
 		Insert(dbmodels.TABLE_SUSPICIOUS_ACTIVITY_REPORTS).
-		Columns("report_id", "case_id", "status", "bucket", "blob_key", "created_by", "uploaded_by").
+		Columns("report_id", "case_id", "status", "bucket", "blob_key", "scan_status", "created_by", "uploaded_by", "content").
 		Values(
 			reportId,
 			req.CaseId,
 			req.Status.String(),
 			req.Bucket,
 			req.BlobKey,
+			scanStatus,
 			req.CreatedBy,
 			req.UploadedBy,
+			content,
 		).
 		Suffix("returning *")
 
@@ -125,6 +141,7 @@ func (repo *MarbleDbRepository) UploadSuspiciousActivityReport(ctx context.Conte
 			Set("status", req.Status).
 			Set("bucket", req.Bucket).
 			Set("blob_key", req.BlobKey).
+			Set("scan_status", req.ScanStatus).
 			Set("uploaded_by", req.UploadedBy).
 			Where(squirrel.Eq{
 				"case_id":    req.CaseId,
@@ -151,8 +168,10 @@ func (repo *MarbleDbRepository) UploadSuspiciousActivityReport(ctx context.Conte
 		Status:     &sar.Status,
 		Bucket:     req.Bucket,
 		BlobKey:    req.BlobKey,
+		ScanStatus: req.ScanStatus,
 		CreatedBy:  models.UserId(sar.CreatedBy),
 		UploadedBy: req.UploadedBy,
+		Content:    sar.Content,
 	}
 
 	return repo.CreateSuspiciousActivityReport(ctx, tx, create)
@@ -177,3 +196,30 @@ func (repo *MarbleDbRepository) DeleteSuspiciousActivityReport(ctx context.Conte
 
 	return ExecBuilder(ctx, exec, sql)
 }
+
+func (repo *MarbleDbRepository) UpdateSuspiciousActivityReportContent(ctx context.Context,
+	exec Executor,
+	caseId, reportId string,
+	content models.SarContent,
+) (models.SuspiciousActivityReport, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return models.SuspiciousActivityReport{}, err
+	}
+
+	serializedContent, err := json.Marshal(content)
+	if err != nil {
+		return models.SuspiciousActivityReport{}, err
+	}
+
+	sql := NewQueryBuilder().
+		Update(dbmodels.TABLE_SUSPICIOUS_ACTIVITY_REPORTS).
+		Set("content", serializedContent).
+		Where(squirrel.Eq{
+			"case_id":    caseId,
+			"report_id":  reportId,
+			"deleted_at": nil,
+		}).
+		Suffix("returning *")
+
+	return SqlToModel(ctx, exec, sql, dbmodels.AdaptSuspiciousActivityReport)
+}
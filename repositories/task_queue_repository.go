@@ -17,6 +17,10 @@ const (
 	priorityAsyncDecision        = 3 // nb: higher number is lower priority (between 1 and 4)
 	nbRetriesScheduledExecStatus = 7 // at 1sec*attempt^4, that's 6h for the 7th attempt
 	priorityScheduledExecStatus  = 2
+	nbRetriesIngestionDecision   = 6
+	priorityIngestionDecision    = 2
+	nbRetriesNotificationEmail   = 5 // at 1sec*attempt^4, that's 10min for the 5th attempt
+	nbRetriesBlobDeletion        = 8 // at 1sec*attempt^4, that's 1h08min for the 8th attempt
 )
 
 type TaskQueueRepository interface {
@@ -50,6 +54,57 @@ type TaskQueueRepository interface {
 		organizationId string,
 		sanctionCheckId string,
 	) error
+	EnqueueIngestedObjectsDecisionTask(
+		ctx context.Context,
+		organizationId string,
+		objectType string,
+		objectIds []string,
+	) error
+	EnqueueDecisionExportTask(
+		ctx context.Context,
+		tx Transaction,
+		organizationId string,
+		exportId string,
+	) error
+	EnqueueCaseExportTask(
+		ctx context.Context,
+		tx Transaction,
+		organizationId string,
+		exportId string,
+	) error
+	EnqueueCaseBulkActionTask(
+		ctx context.Context,
+		tx Transaction,
+		organizationId string,
+		bulkActionId string,
+	) error
+	EnqueueDecisionRequestTask(
+		ctx context.Context,
+		tx Transaction,
+		organizationId string,
+		requestId string,
+		deadline time.Time,
+	) error
+	EnqueueDecisionReevaluationBatchTask(
+		ctx context.Context,
+		tx Transaction,
+		organizationId string,
+		batchId string,
+	) error
+	EnqueueNotificationEmailTask(
+		ctx context.Context,
+		tx Transaction,
+		organizationId string,
+		userId string,
+		caseId string,
+		message string,
+	) error
+	EnqueueBlobDeletionTask(
+		ctx context.Context,
+		tx Transaction,
+		organizationId string,
+		blobs []models.BlobRef,
+	) error
 }
 
 type riverRepository struct {
@@ -198,3 +253,237 @@ func (r riverRepository) EnqueueMatchEnrichmentTask(
 
 	return nil
 }
+
+func (r riverRepository) EnqueueIngestedObjectsDecisionTask(
+	ctx context.Context,
+	organizationId string,
+	objectType string,
+	objectIds []string,
+) error {
+	params := make([]river.InsertManyParams, len(objectIds))
+	for i, objectId := range objectIds {
+		params[i] = river.InsertManyParams{
+			Args: models.IngestedObjectsDecisionArgs{
+				OrgId:      organizationId,
+				ObjectType: objectType,
+				ObjectId:   objectId,
+			},
+			InsertOpts: &river.InsertOpts{
+				MaxAttempts: nbRetriesIngestionDecision,
+				Priority:    priorityIngestionDecision,
+				Queue:       organizationId,
+			},
+		}
+	}
+
+	res, err := r.client.InsertMany(ctx, params)
+	if err != nil {
+		return err
+	}
+
+	logger := utils.LoggerFromContext(ctx)
+	logger.DebugContext(ctx, fmt.Sprintf("Enqueued %d ingested objects decision tasks", len(res)))
+
+	return nil
+}
+
+func (r riverRepository) EnqueueDecisionExportTask(
+	ctx context.Context,
+	tx Transaction,
+	organizationId string,
+	exportId string,
+) error {
+	res, err := r.client.InsertTx(
+		ctx,
+		tx.RawTx(),
+		models.DecisionExportArgs{
+			OrgId:    organizationId,
+			ExportId: exportId,
+		},
+		&river.InsertOpts{
+			Queue: organizationId,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	logger := utils.LoggerFromContext(ctx)
+	logger.DebugContext(ctx, "Enqueued decision export task", "export_id", exportId, "job_id", res.Job.ID)
+
+	return nil
+}
+
+func (r riverRepository) EnqueueCaseExportTask(
+	ctx context.Context,
+	tx Transaction,
+	organizationId string,
+	exportId string,
+) error {
+	res, err := r.client.InsertTx(
+		ctx,
+		tx.RawTx(),
+		models.CaseExportArgs{
+			OrgId:    organizationId,
+			ExportId: exportId,
+		},
+		&river.InsertOpts{
+			Queue: organizationId,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	logger := utils.LoggerFromContext(ctx)
+	logger.DebugContext(ctx, "Enqueued case export task", "export_id", exportId, "job_id", res.Job.ID)
+
+	return nil
+}
+
+// EnqueueDecisionRequestTask enqueues the evaluation of a decision request, and a second run of the same job at the
+// deadline of the request that records the fallback outcome if the request is still pending by then.
+func (r riverRepository) EnqueueCaseBulkActionTask(
+	ctx context.Context,
+	tx Transaction,
+	organizationId string,
+	bulkActionId string,
+) error {
+	res, err := r.client.InsertTx(
+		ctx,
+		tx.RawTx(),
+		models.CaseBulkActionArgs{
+			OrgId:        organizationId,
+			BulkActionId: bulkActionId,
+		},
+		&river.InsertOpts{
+			Queue: organizationId,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	logger := utils.LoggerFromContext(ctx)
+	logger.DebugContext(ctx, "Enqueued case bulk action task", "bulk_action_id", bulkActionId, "job_id", res.Job.ID)
+
+	return nil
+}
+
+// EnqueueDecisionRequestTask enqueues the evaluation of a decision request, and a second run of the same job at the
+// deadline of the request that records the fallback outcome if the request is still pending by then.
+
+func (r riverRepository) EnqueueDecisionRequestTask(
+	ctx context.Context,
+	tx Transaction,
+	organizationId string,
+	requestId string,
+	deadline time.Time,
+) error {
+	args := models.DecisionRequestArgs{
+		OrgId:     organizationId,
+		RequestId: requestId,
+	}
+	res, err := r.client.InsertManyTx(
+		ctx,
+		tx.RawTx(),
+		[]river.InsertManyParams{
+			{Args: args, InsertOpts: &river.InsertOpts{Queue: organizationId}},
+			{Args: args, InsertOpts: &river.InsertOpts{Queue: organizationId, ScheduledAt: deadline}},
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	logger := utils.LoggerFromContext(ctx)
+	logger.DebugContext(ctx, "Enqueued decision request task", "request_id", requestId, "job_id", res[0].Job.ID)
+
+	return nil
+}
+
+func (r riverRepository) EnqueueDecisionReevaluationBatchTask(
+	ctx context.Context,
+	tx Transaction,
+	organizationId string,
+	batchId string,
+) error {
+	res, err := r.client.InsertTx(
+		ctx,
+		tx.RawTx(),
+		models.DecisionReevaluationBatchArgs{
+			OrgId:   organizationId,
+			BatchId: batchId,
+		},
+		&river.InsertOpts{
+			Queue: organizationId,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	logger := utils.LoggerFromContext(ctx)
+	logger.DebugContext(ctx, "Enqueued decision re-evaluation batch task", "batch_id", batchId, "job_id", res.Job.ID)
+
+	return nil
+}
+
+func (r riverRepository) EnqueueNotificationEmailTask(
+	ctx context.Context,
+	tx Transaction,
+	organizationId string,
+	userId string,
+	caseId string,
+	message string,
+) error {
+	res, err := r.client.InsertTx(
+		ctx,
+		tx.RawTx(),
+		models.NotificationEmailArgs{
+			OrgId:   organizationId,
+			UserId:  userId,
+			CaseId:  caseId,
+			Message: message,
+		},
+		&river.InsertOpts{
+			Queue:       organizationId,
+			MaxAttempts: nbRetriesNotificationEmail,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	logger := utils.LoggerFromContext(ctx)
+	logger.DebugContext(ctx, "Enqueued notification email task", "user_id", userId, "job_id", res.Job.ID)
+
+	return nil
+}
+
+func (r riverRepository) EnqueueBlobDeletionTask(
+	ctx context.Context,
+	tx Transaction,
+	organizationId string,
+	blobs []models.BlobRef,
+) error {
+	res, err := r.client.InsertTx(
+		ctx,
+		tx.RawTx(),
+		models.BlobDeletionArgs{
+			OrgId: organizationId,
+			Blobs: blobs,
+		},
+		&river.InsertOpts{
+			Queue:       organizationId,
+			MaxAttempts: nbRetriesBlobDeletion,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	logger := utils.LoggerFromContext(ctx)
+	logger.DebugContext(ctx, "Enqueued blob deletion task", "nb_blobs", len(blobs), "job_id", res.Job.ID)
+	return nil
+}
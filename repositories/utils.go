@@ -22,6 +22,11 @@ func setCurrentUserIdContext(ctx context.Context, exec Executor, userId *models.
 	return nil
 }
 
+// finalizedCaseStatuses returns the finalized case statuses, as a query argument
+func finalizedCaseStatuses() []string {
+	return pure_utils.Map(models.FinalizedCaseStatuses, func(s models.CaseStatus) string { return string(s) })
+}
+
 func columnsNames(tablename string, fields []string) []string {
 	return pure_utils.Map(fields, func(f string) string {
 		return fmt.Sprintf("%s.%s", tablename, f)
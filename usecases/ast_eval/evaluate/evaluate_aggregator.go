@@ -141,7 +141,7 @@ func (a AggregatorEvaluator) runQueryInRepository(
 		return nil, err
 	}
 	return a.IngestedDataReadRepository.QueryAggregatedValue(ctx, db, tableName,
-		fieldName, fieldType, aggregator, filters)
+		fieldName, fieldType, aggregator, filters, a.ClientObject.ReadAsOf)
 }
 
 func (a AggregatorEvaluator) defaultValueForAggregator(aggregator ast.Aggregator) (any, []error) {
@@ -0,0 +1,60 @@
+package evaluate
+
+import (
+	"context"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/models/ast"
+	"github.com/checkmarble/marble-backend/pure_utils"
+)
+
+// DecisionAccess reads the fields of a decision that was already made. The triggered rules are the names of the rules
+// that hit, and the sanction check status is null if the decision has no sanction check.
+type DecisionAccess struct {
+	Decision models.DecisionWithRuleExecutions
+}
+
+func (d DecisionAccess) Evaluate(ctx context.Context, arguments ast.Arguments) (any, []error) {
+	field, err := AdaptNamedArgument(arguments.NamedArgs, "field", adaptArgumentToString)
+	if err != nil {
+		return nil, MakeAdaptedArgsErrors([]error{err})
+	}
+
+	switch field {
+	case ast.DecisionFieldScore:
+		return int64(d.Decision.Score), nil
+	case ast.DecisionFieldOutcome:
+		return d.Decision.Outcome.String(), nil
+	case ast.DecisionFieldTriggeredRules:
+		triggeredRules := make([]string, 0, len(d.Decision.RuleExecutions))
+		for _, execution := range d.Decision.RuleExecutions {
+			if execution.Result {
+				triggeredRules = append(triggeredRules, pure_utils.Normalize(execution.Rule.Name))
+			}
+		}
+		return triggeredRules, nil
+	case ast.DecisionFieldSanctionCheckStatus:
+		if d.Decision.SanctionCheckExecution == nil {
+			return nil, nil
+		}
+		return d.Decision.SanctionCheckExecution.Status.String(), nil
+	default:
+		return MakeEvaluateError(ast.NewNamedArgumentError("field"))
+	}
+}
+
+// DryRunDecision is a fake decision for the validation of the formulas that read a decision
+func DryRunDecision() models.DecisionWithRuleExecutions {
+	return models.DecisionWithRuleExecutions{
+		Decision: models.Decision{
+			Outcome: models.Review,
+			Score:   1,
+		},
+		RuleExecutions: []models.RuleExecution{
+			{Result: true, Rule: models.Rule{Name: "fake value for Decision:triggered_rules"}},
+		},
+		SanctionCheckExecution: &models.SanctionCheckWithMatches{
+			SanctionCheck: models.SanctionCheck{Status: models.SanctionStatusNoHit},
+		},
+	}
+}
@@ -0,0 +1,51 @@
+package evaluate
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/models/ast"
+)
+
+func TestDecisionAccess_Evaluate(t *testing.T) {
+	decision := models.DecisionWithRuleExecutions{
+		Decision: models.Decision{Outcome: models.BlockAndReview, Score: 42},
+		RuleExecutions: []models.RuleExecution{
+			{Result: true, Rule: models.Rule{Name: "High amount"}},
+			{Result: false, Rule: models.Rule{Name: "New account"}},
+		},
+	}
+	withSanctionCheck := decision
+	withSanctionCheck.SanctionCheckExecution = &models.SanctionCheckWithMatches{
+		SanctionCheck: models.SanctionCheck{Status: models.SanctionStatusInReview},
+	}
+
+	tests := []struct {
+		name     string
+		decision models.DecisionWithRuleExecutions
+		field    any
+		expected any
+	}{
+		{name: "score", decision: decision, field: "score", expected: int64(42)},
+		{name: "outcome", decision: decision, field: "outcome", expected: "block_and_review"},
+		{name: "triggered rules", decision: decision, field: "triggered_rules", expected: []string{"High amount"}},
+		{name: "no sanction check", decision: decision, field: "sanction_check_status", expected: nil},
+		{name: "sanction check", decision: withSanctionCheck, field: "sanction_check_status", expected: "in_review"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, errs := DecisionAccess{Decision: tt.decision}.Evaluate(context.Background(),
+				ast.Arguments{NamedArgs: map[string]any{"field": tt.field}})
+			assert.Empty(t, errs)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+
+	_, errs := DecisionAccess{Decision: decision}.Evaluate(context.Background(),
+		ast.Arguments{NamedArgs: map[string]any{"field": "pivot_value"}})
+	assert.NotEmpty(t, errs)
+}
@@ -35,3 +35,27 @@ func (evaluator EvaluateAstExpression) EvaluateAstExpression(
 
 	return evaluation, nil
 }
+
+// EvaluateDecisionAstExpression evaluates an expression that can read the fields of a decision, in addition to its
+// trigger object.
+func (evaluator EvaluateAstExpression) EvaluateDecisionAstExpression(
+	ctx context.Context,
+	astExpression ast.Node,
+	decision models.DecisionWithRuleExecutions,
+	dataModel models.DataModel,
+) (ast.NodeEvaluation, error) {
+	environment := evaluator.AstEvaluationEnvironmentFactory(EvaluationEnvironmentFactoryParams{
+		OrganizationId:                decision.OrganizationId,
+		ClientObject:                  decision.ClientObject,
+		DataModel:                     dataModel,
+		DatabaseAccessReturnFakeValue: false,
+		Decision:                      &decision,
+	})
+
+	evaluation, ok := EvaluateAst(ctx, nil, environment, astExpression)
+	if !ok {
+		return evaluation, errors.Join(evaluation.FlattenErrors()...)
+	}
+
+	return evaluation, nil
+}
@@ -7,6 +7,8 @@ type EvaluationEnvironmentFactoryParams struct {
 	ClientObject                  models.ClientObject
 	DataModel                     models.DataModel
 	DatabaseAccessReturnFakeValue bool
+	// Decision is only set to evaluate formulas after the decision is made, it makes the Decision function available
+	Decision *models.DecisionWithRuleExecutions
 }
 
 type AstEvaluationEnvironmentFactory func(params EvaluationEnvironmentFactoryParams) AstEvaluationEnvironment
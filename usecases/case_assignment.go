@@ -0,0 +1,63 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/pure_utils"
+	"github.com/checkmarble/marble-backend/repositories"
+	"github.com/checkmarble/marble-backend/utils"
+)
+
+type caseAssignmentRepository interface {
+	GetInboxById(ctx context.Context, exec repositories.Executor, inboxId string) (models.Inbox, error)
+	LockInboxUsersForAssignment(ctx context.Context, exec repositories.Executor, inboxId string) error
+	ListCaseAssignmentCandidates(ctx context.Context, exec repositories.Executor,
+		inboxId string) ([]models.CaseAssignmentCandidate, error)
+	MarkInboxUserAssigned(ctx context.Context, exec repositories.Executor, inboxUserId string) error
+}
+
+// AutoAssignCase assigns a case that enters an inbox to one of the available members of the inbox, following the
+// assignment strategy of the inbox. It does nothing if the strategy is manual or if no member is available. The
+// assignment is recorded as a case event with the strategy as additional note.
+func (usecase *CaseUseCase) AutoAssignCase(ctx context.Context, tx repositories.Transaction,
+	c models.Case, inboxId string,
+) error {
+	inbox, err := usecase.caseAssignmentRepository.GetInboxById(ctx, tx, inboxId)
+	if err != nil {
+		return err
+	}
+	if inbox.AssignmentStrategy == models.InboxAssignmentManual {
+		return nil
+	}
+
+	// The candidates are read after the lock is acquired, in a new statement: the open cases and last assignment of the
+	// members then include the cases assigned by concurrent transactions that held the lock before.
+	if err := usecase.caseAssignmentRepository.LockInboxUsersForAssignment(ctx, tx, inboxId); err != nil {
+		return err
+	}
+	candidates, err := usecase.caseAssignmentRepository.ListCaseAssignmentCandidates(ctx, tx, inboxId)
+	if err != nil {
+		return err
+	}
+	caseTagIds := pure_utils.Map(c.Tags, func(t models.CaseTag) string { return t.TagId })
+	assignee, ok := inbox.AssignmentStrategy.PickAssignee(candidates, caseTagIds)
+	if !ok {
+		return nil
+	}
+
+	if err := usecase.repository.AssignCase(ctx, tx, c.Id, utils.Ptr(models.UserId(assignee.UserId))); err != nil {
+		return err
+	}
+	if err := usecase.caseAssignmentRepository.MarkInboxUserAssigned(ctx, tx, assignee.InboxUserId); err != nil {
+		return err
+	}
+
+	return usecase.repository.CreateCaseEvent(ctx, tx, models.CreateCaseEventAttributes{
+		CaseId:         c.Id,
+		EventType:      models.CaseAssigned,
+		NewValue:       &assignee.UserId,
+		PreviousValue:  (*string)(c.AssignedTo),
+		AdditionalNote: utils.Ptr(string(inbox.AssignmentStrategy)),
+	})
+}
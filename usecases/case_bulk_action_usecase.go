@@ -0,0 +1,130 @@
+package usecases
+
+import (
+	"context"
+	"slices"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/checkmarble/marble-backend/dto"
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/pure_utils"
+	"github.com/checkmarble/marble-backend/repositories"
+	"github.com/checkmarble/marble-backend/usecases/executor_factory"
+)
+
+type CaseBulkActionRepository interface {
+	CreateCaseBulkAction(ctx context.Context, exec repositories.Executor,
+		input models.CaseBulkActionCreate) (models.CaseBulkAction, error)
+	GetCaseBulkAction(ctx context.Context, exec repositories.Executor, id string) (models.CaseBulkAction, error)
+	ListCaseBulkActions(ctx context.Context, exec repositories.Executor, organizationId string,
+		userId models.UserId) ([]models.CaseBulkAction, error)
+	ListCaseBulkActionItems(ctx context.Context, exec repositories.Executor, bulkActionId string,
+		status *models.CaseBulkActionItemStatus) ([]models.CaseBulkActionItem, error)
+}
+
+type caseBulkActionCaseLister interface {
+	ListCases(ctx context.Context, organizationId string, pagination models.PaginationAndSorting,
+		filters dto.CaseFilters) (models.CaseListPage, error)
+}
+
+type CaseBulkActionUsecase struct {
+	executorFactory     executor_factory.ExecutorFactory
+	transactionFactory  executor_factory.TransactionFactory
+	caseLister          caseBulkActionCaseLister
+	repository          CaseBulkActionRepository
+	taskQueueRepository repositories.TaskQueueRepository
+	credentials         models.Credentials
+}
+
+// CreateCaseBulkAction schedules the job that applies an action to a set of cases, selected either by their ids or by
+// the filters of the case list. The cases are checked one by one by the job, with the permissions of the user.
+func (uc CaseBulkActionUsecase) CreateCaseBulkAction(ctx context.Context, organizationId string,
+	action models.CaseBulkActionType, params models.CaseBulkActionParams, caseIds []string, filters *dto.CaseFilters,
+) (models.CaseBulkAction, error) {
+	userId := uc.credentials.ActorIdentity.UserId
+	if userId == "" {
+		return models.CaseBulkAction{}, errors.Wrap(models.ForbiddenError,
+			"bulk actions can only be performed by a user")
+	}
+	if err := params.Validate(action); err != nil {
+		return models.CaseBulkAction{}, err
+	}
+	if (len(caseIds) == 0) == (filters == nil) {
+		return models.CaseBulkAction{}, errors.Wrap(models.BadParameterError,
+			"either case_ids or filters must be provided")
+	}
+
+	if filters != nil {
+		page, err := uc.caseLister.ListCases(ctx, organizationId, models.PaginationAndSorting{
+			Limit:   models.MaxCaseBulkActionSize,
+			Sorting: models.CasesSortingCreatedAt,
+			Order:   models.SortingOrderDesc,
+		}, *filters)
+		if err != nil {
+			return models.CaseBulkAction{}, err
+		}
+		if page.HasNextPage {
+			return models.CaseBulkAction{}, errors.Wrapf(models.BadParameterError,
+				"the filters match more than %d cases", models.MaxCaseBulkActionSize)
+		}
+		caseIds = pure_utils.Map(page.Cases, func(c models.Case) string { return c.Id })
+	}
+
+	caseIds = slices.Clone(caseIds)
+	slices.Sort(caseIds)
+	caseIds = slices.Compact(caseIds)
+	if len(caseIds) == 0 {
+		return models.CaseBulkAction{}, errors.Wrap(models.BadParameterError, "no case matches the filters")
+	}
+	if len(caseIds) > models.MaxCaseBulkActionSize {
+		return models.CaseBulkAction{}, errors.Wrapf(models.BadParameterError,
+			"a bulk action can update at most %d cases", models.MaxCaseBulkActionSize)
+	}
+
+	return executor_factory.TransactionReturnValue(ctx, uc.transactionFactory, func(
+		tx repositories.Transaction,
+	) (models.CaseBulkAction, error) {
+		bulkAction, err := uc.repository.CreateCaseBulkAction(ctx, tx, models.CaseBulkActionCreate{
+			OrganizationId: organizationId,
+			UserId:         userId,
+			Action:         action,
+			Params:         params,
+			CaseIds:        caseIds,
+		})
+		if err != nil {
+			return models.CaseBulkAction{}, err
+		}
+		if err := uc.taskQueueRepository.EnqueueCaseBulkActionTask(ctx, tx, organizationId, bulkAction.Id); err != nil {
+			return models.CaseBulkAction{}, err
+		}
+		return bulkAction, nil
+	})
+}
+
+// ListCaseBulkActions returns the latest bulk actions of the user
+func (uc CaseBulkActionUsecase) ListCaseBulkActions(ctx context.Context, organizationId string,
+) ([]models.CaseBulkAction, error) {
+	return uc.repository.ListCaseBulkActions(ctx, uc.executorFactory.NewExecutor(), organizationId,
+		uc.credentials.ActorIdentity.UserId)
+}
+
+// GetCaseBulkAction returns a bulk action of the user, with the result of each of its cases
+func (uc CaseBulkActionUsecase) GetCaseBulkAction(ctx context.Context, organizationId, id string,
+) (models.CaseBulkActionWithItems, error) {
+	exec := uc.executorFactory.NewExecutor()
+	bulkAction, err := uc.repository.GetCaseBulkAction(ctx, exec, id)
+	if err != nil {
+		return models.CaseBulkActionWithItems{}, err
+	}
+	if bulkAction.OrganizationId != organizationId || bulkAction.UserId != uc.credentials.ActorIdentity.UserId {
+		return models.CaseBulkActionWithItems{}, errors.Wrapf(models.NotFoundError, "bulk action %s not found", id)
+	}
+
+	items, err := uc.repository.ListCaseBulkActionItems(ctx, exec, bulkAction.Id, nil)
+	if err != nil {
+		return models.CaseBulkActionWithItems{}, err
+	}
+
+	return models.CaseBulkActionWithItems{CaseBulkAction: bulkAction, Items: items}, nil
+}
@@ -0,0 +1,359 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/repositories"
+	"github.com/checkmarble/marble-backend/usecases/executor_factory"
+	"github.com/checkmarble/marble-backend/usecases/tracking"
+)
+
+func (usecase *CaseUseCase) CreateCaseComment(ctx context.Context, userId string,
+	caseCommentAttributes models.CreateCaseCommentAttributes,
+) (models.Case, error) {
+	webhookEventId := uuid.New().String()
+
+	updatedCase, err := executor_factory.TransactionReturnValue(ctx, usecase.transactionFactory, func(
+		tx repositories.Transaction,
+	) (models.Case, error) {
+		c, err := usecase.getReadableCase(ctx, tx, caseCommentAttributes.Id)
+		if err != nil {
+			return models.Case{}, err
+		}
+
+		var parentId *string
+		if caseCommentAttributes.ParentId != nil {
+			parent, err := usecase.getCaseComment(ctx, tx, c.Id, *caseCommentAttributes.ParentId, false)
+			if err != nil {
+				return models.Case{}, err
+			}
+			// replies are attached to the comment that started the thread
+			parentId = &parent.Id
+			if parent.ParentId != nil {
+				parentId = parent.ParentId
+			}
+		}
+
+		mentions, err := usecase.validateMentions(ctx, tx, c.OrganizationId, caseCommentAttributes.MentionedUserIds)
+		if err != nil {
+			return models.Case{}, err
+		}
+
+		if err := usecase.createCaseContributorIfNotExist(ctx, tx, caseCommentAttributes.Id, userId); err != nil {
+			return models.Case{}, err
+		}
+
+		comment, err := usecase.repository.CreateCaseComment(ctx, tx, models.CaseCommentCreate{
+			OrganizationId:   c.OrganizationId,
+			CaseId:           c.Id,
+			ParentId:         parentId,
+			AuthorId:         userId,
+			Body:             caseCommentAttributes.Comment,
+			MentionedUserIds: mentions,
+		})
+		if err != nil {
+			return models.Case{}, err
+		}
+
+		resourceType := models.CaseCommentResourceType
+		err = usecase.repository.CreateCaseEvent(ctx, tx, models.CreateCaseEventAttributes{
+			CaseId:         caseCommentAttributes.Id,
+			UserId:         &userId,
+			EventType:      models.CaseCommentAdded,
+			AdditionalNote: &caseCommentAttributes.Comment,
+			ResourceType:   &resourceType,
+			ResourceId:     &comment.Id,
+		})
+		if err != nil {
+			return models.Case{}, err
+		}
+		if err := usecase.createMentionEvents(ctx, tx, userId, comment, mentions); err != nil {
+			return models.Case{}, err
+		}
+
+		if err := usecase.PerformCaseActionSideEffects(ctx, tx, c); err != nil {
+			return models.Case{}, err
+		}
+
+		updatedCase, err := usecase.getCaseWithDetails(ctx, tx, caseCommentAttributes.Id)
+		if err != nil {
+			return models.Case{}, err
+		}
+
+		err = usecase.webhookEventsUsecase.CreateWebhookEvent(ctx, tx, models.WebhookEventCreate{
+			Id:             webhookEventId,
+			OrganizationId: updatedCase.OrganizationId,
+			EventContent:   models.NewWebhookEventCaseCommentCreated(updatedCase.Id, &comment),
+		})
+		if err != nil {
+			return models.Case{}, err
+		}
+
+		return updatedCase, nil
+	})
+	if err != nil {
+		return models.Case{}, err
+	}
+
+	usecase.webhookEventsUsecase.SendWebhookEventAsync(ctx, webhookEventId)
+
+	tracking.TrackEvent(ctx, models.AnalyticsCaseCommentCreated, map[string]interface{}{
+		"case_id": updatedCase.Id,
+	})
+	return updatedCase, nil
+}
+
+func (usecase *CaseUseCase) ListCaseComments(ctx context.Context, caseId string) ([]models.CaseComment, error) {
+	exec := usecase.executorFactory.NewExecutor()
+	if _, err := usecase.getReadableCase(ctx, exec, caseId); err != nil {
+		return nil, err
+	}
+
+	return usecase.repository.ListCaseComments(ctx, exec, caseId)
+}
+
+// UpdateCaseComment edits a comment. Only its author can edit it, and its previous content is kept as a version. Users
+// who were not mentioned before the edit are notified through a mention event.
+func (usecase *CaseUseCase) UpdateCaseComment(ctx context.Context, userId string,
+	attributes models.UpdateCaseCommentAttributes,
+) (models.CaseComment, error) {
+	webhookEventId := uuid.NewString()
+
+	comment, err := executor_factory.TransactionReturnValue(ctx, usecase.transactionFactory, func(
+		tx repositories.Transaction,
+	) (models.CaseComment, error) {
+		c, err := usecase.getReadableCase(ctx, tx, attributes.CaseId)
+		if err != nil {
+			return models.CaseComment{}, err
+		}
+		comment, err := usecase.getCaseComment(ctx, tx, c.Id, attributes.CommentId, true)
+		if err != nil {
+			return models.CaseComment{}, err
+		}
+		if comment.AuthorId == nil || *comment.AuthorId != userId {
+			return models.CaseComment{}, errors.Wrap(models.ForbiddenError,
+				"only the author of a comment can edit it")
+		}
+
+		mentions, err := usecase.validateMentions(ctx, tx, c.OrganizationId, attributes.MentionedUserIds)
+		if err != nil {
+			return models.CaseComment{}, err
+		}
+		attributes.MentionedUserIds = mentions
+
+		updated, err := usecase.repository.UpdateCaseComment(ctx, tx, comment, userId, attributes)
+		if err != nil {
+			return models.CaseComment{}, err
+		}
+
+		resourceType := models.CaseCommentResourceType
+		err = usecase.repository.CreateCaseEvent(ctx, tx, models.CreateCaseEventAttributes{
+			CaseId:        c.Id,
+			UserId:        &userId,
+			EventType:     models.CaseCommentUpdated,
+			ResourceType:  &resourceType,
+			ResourceId:    &updated.Id,
+			NewValue:      &updated.Body,
+			PreviousValue: &comment.Body,
+		})
+		if err != nil {
+			return models.CaseComment{}, err
+		}
+
+		newMentions := make([]string, 0, len(mentions))
+		for _, mention := range mentions {
+			if !slices.Contains(comment.MentionedUserIds, mention) {
+				newMentions = append(newMentions, mention)
+			}
+		}
+		if err := usecase.createMentionEvents(ctx, tx, userId, updated, newMentions); err != nil {
+			return models.CaseComment{}, err
+		}
+
+		err = usecase.webhookEventsUsecase.CreateWebhookEvent(ctx, tx, models.WebhookEventCreate{
+			Id:             webhookEventId,
+			OrganizationId: c.OrganizationId,
+			EventContent:   models.NewWebhookEventCaseCommentUpdated(updated),
+		})
+		if err != nil {
+			return models.CaseComment{}, err
+		}
+
+		return updated, nil
+	})
+	if err != nil {
+		return models.CaseComment{}, err
+	}
+
+	usecase.webhookEventsUsecase.SendWebhookEventAsync(ctx, webhookEventId)
+
+	return comment, nil
+}
+
+// DeleteCaseComment soft deletes a comment. Only its author can delete it, and its replies are kept. The events of the
+// comment are then listed without its content.
+func (usecase *CaseUseCase) DeleteCaseComment(ctx context.Context, userId, caseId, commentId string) error {
+	webhookEventId := uuid.NewString()
+
+	err := usecase.transactionFactory.Transaction(ctx, func(tx repositories.Transaction) error {
+		c, err := usecase.getReadableCase(ctx, tx, caseId)
+		if err != nil {
+			return err
+		}
+		comment, err := usecase.getCaseComment(ctx, tx, c.Id, commentId, true)
+		if err != nil {
+			return err
+		}
+		if comment.AuthorId == nil || *comment.AuthorId != userId {
+			return errors.Wrap(models.ForbiddenError, "only the author of a comment can delete it")
+		}
+
+		deleted, err := usecase.repository.DeleteCaseComment(ctx, tx, comment.Id)
+		if err != nil {
+			return err
+		}
+
+		// the event does not keep the content of the comment, which must not outlive its deletion
+		resourceType := models.CaseCommentResourceType
+		err = usecase.repository.CreateCaseEvent(ctx, tx, models.CreateCaseEventAttributes{
+			CaseId:       c.Id,
+			UserId:       &userId,
+			EventType:    models.CaseCommentDeleted,
+			ResourceType: &resourceType,
+			ResourceId:   &deleted.Id,
+		})
+		if err != nil {
+			return err
+		}
+
+		return usecase.webhookEventsUsecase.CreateWebhookEvent(ctx, tx, models.WebhookEventCreate{
+			Id:             webhookEventId,
+			OrganizationId: c.OrganizationId,
+			EventContent:   models.NewWebhookEventCaseCommentUpdated(deleted),
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	usecase.webhookEventsUsecase.SendWebhookEventAsync(ctx, webhookEventId)
+
+	return nil
+}
+
+// ListCaseCommentVersions returns the previous contents of a comment, oldest first
+func (usecase *CaseUseCase) ListCaseCommentVersions(ctx context.Context, caseId, commentId string,
+) ([]models.CaseCommentVersion, error) {
+	exec := usecase.executorFactory.NewExecutor()
+	if _, err := usecase.getReadableCase(ctx, exec, caseId); err != nil {
+		return nil, err
+	}
+	comment, err := usecase.repository.GetCaseCommentById(ctx, exec, commentId, false)
+	if err != nil {
+		return nil, err
+	}
+	if comment.CaseId != caseId {
+		return nil, errors.Wrap(models.NotFoundError, "comment not found in this case")
+	}
+	// like the events of a deleted comment, its versions are not shown
+	if comment.DeletedAt != nil {
+		return []models.CaseCommentVersion{}, nil
+	}
+
+	return usecase.repository.ListCaseCommentVersions(ctx, exec, comment.Id)
+}
+
+func (usecase *CaseUseCase) getReadableCase(ctx context.Context, exec repositories.Executor,
+	caseId string,
+) (models.Case, error) {
+	c, err := usecase.repository.GetCaseById(ctx, exec, caseId)
+	if err != nil {
+		return models.Case{}, err
+	}
+
+	availableInboxIds, err := usecase.getAvailableInboxIds(ctx, exec, c.OrganizationId)
+	if err != nil {
+		return models.Case{}, err
+	}
+	if err := usecase.enforceSecurity.ReadOrUpdateCase(c.GetMetadata(), availableInboxIds); err != nil {
+		return models.Case{}, err
+	}
+
+	return c, nil
+}
+
+// getCaseComment returns a comment of the case that is not deleted
+func (usecase *CaseUseCase) getCaseComment(ctx context.Context, exec repositories.Executor,
+	caseId, commentId string, forUpdate bool,
+) (models.CaseComment, error) {
+	comment, err := usecase.repository.GetCaseCommentById(ctx, exec, commentId, forUpdate)
+	if err != nil {
+		return models.CaseComment{}, err
+	}
+	if comment.CaseId != caseId {
+		return models.CaseComment{}, errors.Wrap(models.NotFoundError, "comment not found in this case")
+	}
+	if comment.DeletedAt != nil {
+		return models.CaseComment{}, errors.Wrap(models.UnprocessableEntityError, "the comment is deleted")
+	}
+
+	return comment, nil
+}
+
+// validateMentions removes duplicate mentions, and checks that the mentioned users are active users of the organization
+func (usecase *CaseUseCase) validateMentions(ctx context.Context, exec repositories.Executor,
+	organizationId string, userIds []string,
+) ([]string, error) {
+	mentions := make([]string, 0, len(userIds))
+	for _, userId := range userIds {
+		if slices.Contains(mentions, userId) {
+			continue
+		}
+		if _, err := uuid.Parse(userId); err != nil {
+			return nil, errors.Wrap(models.BadParameterError, fmt.Sprintf("invalid mentioned user id %s", userId))
+		}
+
+		user, err := usecase.userRepository.UserById(ctx, exec, userId)
+		if errors.Is(err, models.NotFoundError) {
+			return nil, errors.Wrap(models.BadParameterError, fmt.Sprintf("mentioned user %s not found", userId))
+		}
+		if err != nil {
+			return nil, err
+		}
+		if user.OrganizationId != organizationId || user.DeletedAt != nil {
+			return nil, errors.Wrap(models.BadParameterError, fmt.Sprintf("mentioned user %s not found", userId))
+		}
+
+		mentions = append(mentions, userId)
+	}
+	return mentions, nil
+}
+
+// createMentionEvents records one event per mentioned user, from which the mentioned users are notified
+func (usecase *CaseUseCase) createMentionEvents(ctx context.Context, exec repositories.Executor,
+	userId string, comment models.CaseComment, mentionedUserIds []string,
+) error {
+	if len(mentionedUserIds) == 0 {
+		return nil
+	}
+
+	resourceType := models.CaseCommentResourceType
+	events := make([]models.CreateCaseEventAttributes, 0, len(mentionedUserIds))
+	for _, mentionedUserId := range mentionedUserIds {
+		events = append(events, models.CreateCaseEventAttributes{
+			CaseId:       comment.CaseId,
+			UserId:       &userId,
+			EventType:    models.CaseCommentMention,
+			ResourceType: &resourceType,
+			ResourceId:   &comment.Id,
+			NewValue:     &mentionedUserId,
+		})
+	}
+	return usecase.repository.BatchCreateCaseEvents(ctx, exec, events)
+}
@@ -0,0 +1,214 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/repositories"
+	"github.com/checkmarble/marble-backend/utils"
+)
+
+// fakeCaseCommentRepository adds the comments of the cases to a fakeCaseMergeRepository
+type fakeCaseCommentRepository struct {
+	*fakeCaseMergeRepository
+
+	comments map[string]models.CaseComment
+}
+
+func (r *fakeCaseCommentRepository) CreateCaseComment(ctx context.Context, exec repositories.Executor,
+	input models.CaseCommentCreate,
+) (models.CaseComment, error) {
+	comment := models.CaseComment{
+		Id:               fmt.Sprintf("comment_%d", len(r.comments)+1),
+		OrganizationId:   input.OrganizationId,
+		CaseId:           input.CaseId,
+		ParentId:         input.ParentId,
+		AuthorId:         &input.AuthorId,
+		Body:             input.Body,
+		MentionedUserIds: input.MentionedUserIds,
+		CreatedAt:        time.Now(),
+	}
+	r.comments[comment.Id] = comment
+	return comment, nil
+}
+
+func (r *fakeCaseCommentRepository) GetCaseCommentById(ctx context.Context, exec repositories.Executor,
+	id string, forUpdate bool,
+) (models.CaseComment, error) {
+	comment, ok := r.comments[id]
+	if !ok {
+		return models.CaseComment{}, models.NotFoundError
+	}
+	return comment, nil
+}
+
+func (r *fakeCaseCommentRepository) UpdateCaseComment(ctx context.Context, exec repositories.Executor,
+	comment models.CaseComment, editedBy string, update models.UpdateCaseCommentAttributes,
+) (models.CaseComment, error) {
+	comment.Body = update.Body
+	comment.MentionedUserIds = update.MentionedUserIds
+	comment.EditedAt = utils.Ptr(time.Now())
+	r.comments[comment.Id] = comment
+	return comment, nil
+}
+
+func (r *fakeCaseCommentRepository) DeleteCaseComment(ctx context.Context, exec repositories.Executor,
+	id string,
+) (models.CaseComment, error) {
+	comment := r.comments[id]
+	comment.DeletedAt = utils.Ptr(time.Now())
+	r.comments[id] = comment
+	return comment, nil
+}
+
+func (r *fakeCaseCommentRepository) UnboostCase(ctx context.Context, exec repositories.Executor, id string) error {
+	return nil
+}
+
+// newCaseCommentTestUsecase builds a case usecase with a comment of caseMergeUserId and a comment of another user on
+// the assigned case caseMergeSourceCaseId
+func newCaseCommentTestUsecase() (*CaseUseCase, *fakeCaseCommentRepository) {
+	usecase, mergeRepo, _ := newCaseMergeTestUsecase()
+	mergeRepo.cases[caseMergeSourceCaseId].AssignedTo = utils.Ptr(models.UserId(caseMergeUserId))
+
+	repo := &fakeCaseCommentRepository{
+		fakeCaseMergeRepository: mergeRepo,
+		comments: map[string]models.CaseComment{
+			"own_comment": {
+				Id:       "own_comment",
+				CaseId:   caseMergeSourceCaseId,
+				AuthorId: utils.Ptr(caseMergeUserId),
+				Body:     "first",
+			},
+			"other_comment": {
+				Id:       "other_comment",
+				CaseId:   caseMergeSourceCaseId,
+				AuthorId: utils.Ptr("other_user"),
+				Body:     "second",
+			},
+		},
+	}
+	usecase.repository = repo
+	return usecase, repo
+}
+
+func TestUpdateCaseComment(t *testing.T) {
+	t.Run("by its author", func(t *testing.T) {
+		usecase, repo := newCaseCommentTestUsecase()
+		updated, err := usecase.UpdateCaseComment(context.Background(), caseMergeUserId,
+			models.UpdateCaseCommentAttributes{
+				CaseId:    caseMergeSourceCaseId,
+				CommentId: "own_comment",
+				Body:      "first, edited",
+			})
+		require.NoError(t, err)
+		assert.Equal(t, "first, edited", updated.Body)
+
+		require.Len(t, repo.events, 1)
+		assert.Equal(t, models.CaseCommentUpdated, repo.events[0].EventType)
+		assert.Equal(t, "first", *repo.events[0].PreviousValue)
+	})
+
+	t.Run("by another user", func(t *testing.T) {
+		usecase, repo := newCaseCommentTestUsecase()
+		_, err := usecase.UpdateCaseComment(context.Background(), caseMergeUserId,
+			models.UpdateCaseCommentAttributes{
+				CaseId:    caseMergeSourceCaseId,
+				CommentId: "other_comment",
+				Body:      "second, edited",
+			})
+		assert.ErrorIs(t, err, models.ForbiddenError)
+		assert.Equal(t, "second", repo.comments["other_comment"].Body)
+		assert.Empty(t, repo.events)
+	})
+
+	t.Run("through another case", func(t *testing.T) {
+		usecase, _ := newCaseCommentTestUsecase()
+		_, err := usecase.UpdateCaseComment(context.Background(), caseMergeUserId,
+			models.UpdateCaseCommentAttributes{
+				CaseId:    caseMergeTargetCaseId,
+				CommentId: "own_comment",
+				Body:      "first, edited",
+			})
+		assert.ErrorIs(t, err, models.NotFoundError)
+	})
+}
+
+func TestDeleteCaseComment(t *testing.T) {
+	t.Run("by its author", func(t *testing.T) {
+		usecase, repo := newCaseCommentTestUsecase()
+		err := usecase.DeleteCaseComment(context.Background(), caseMergeUserId,
+			caseMergeSourceCaseId, "own_comment")
+		require.NoError(t, err)
+		assert.NotNil(t, repo.comments["own_comment"].DeletedAt)
+
+		require.Len(t, repo.events, 1)
+		assert.Equal(t, models.CaseCommentDeleted, repo.events[0].EventType)
+		assert.Nil(t, repo.events[0].PreviousValue, "the event does not keep the deleted content")
+		assert.Nil(t, repo.events[0].NewValue)
+
+		err = usecase.DeleteCaseComment(context.Background(), caseMergeUserId,
+			caseMergeSourceCaseId, "own_comment")
+		assert.ErrorIs(t, err, models.UnprocessableEntityError)
+	})
+
+	t.Run("by another user", func(t *testing.T) {
+		usecase, repo := newCaseCommentTestUsecase()
+		err := usecase.DeleteCaseComment(context.Background(), caseMergeUserId,
+			caseMergeSourceCaseId, "other_comment")
+		assert.ErrorIs(t, err, models.ForbiddenError)
+		assert.Nil(t, repo.comments["other_comment"].DeletedAt)
+		assert.Empty(t, repo.events)
+	})
+}
+
+func TestCreateCaseComment_replies(t *testing.T) {
+	usecase, repo := newCaseCommentTestUsecase()
+	reply := func(parentId string) models.CaseComment {
+		_, err := usecase.CreateCaseComment(context.Background(), caseMergeUserId,
+			models.CreateCaseCommentAttributes{
+				Id:       caseMergeSourceCaseId,
+				Comment:  "reply",
+				ParentId: &parentId,
+			})
+		require.NoError(t, err)
+		return repo.comments[fmt.Sprintf("comment_%d", len(repo.comments))]
+	}
+
+	first := reply("other_comment")
+	assert.Equal(t, "other_comment", *first.ParentId)
+
+	// a reply to a reply is attached to the comment that started the thread
+	second := reply(first.Id)
+	assert.Equal(t, "other_comment", *second.ParentId)
+
+	t.Run("to a deleted comment", func(t *testing.T) {
+		require.NoError(t, usecase.DeleteCaseComment(context.Background(), caseMergeUserId,
+			caseMergeSourceCaseId, "own_comment"))
+		parentId := "own_comment"
+		_, err := usecase.CreateCaseComment(context.Background(), caseMergeUserId,
+			models.CreateCaseCommentAttributes{
+				Id:       caseMergeSourceCaseId,
+				Comment:  "reply",
+				ParentId: &parentId,
+			})
+		assert.ErrorIs(t, err, models.UnprocessableEntityError)
+	})
+
+	t.Run("to a comment of another case", func(t *testing.T) {
+		parentId := "other_comment"
+		_, err := usecase.CreateCaseComment(context.Background(), caseMergeUserId,
+			models.CreateCaseCommentAttributes{
+				Id:       caseMergeTargetCaseId,
+				Comment:  "reply",
+				ParentId: &parentId,
+			})
+		assert.ErrorIs(t, err, models.NotFoundError)
+	})
+}
@@ -0,0 +1,130 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/checkmarble/marble-backend/mocks"
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/repositories"
+	"github.com/checkmarble/marble-backend/utils"
+)
+
+// fakeCaseEscalationRepository moves the cases of a fakeCaseMergeRepository between inboxes that have the given case
+// workflows
+type fakeCaseEscalationRepository struct {
+	*fakeCaseMergeRepository
+
+	workflows map[string]*models.InboxCaseWorkflow
+}
+
+func (r *fakeCaseEscalationRepository) EscalateCase(ctx context.Context, exec repositories.Executor,
+	id, inboxId string,
+) error {
+	r.cases[id].InboxId = inboxId
+	r.cases[id].AssignedTo = nil
+	return nil
+}
+
+func (r *fakeCaseEscalationRepository) GetInboxCaseWorkflow(ctx context.Context, exec repositories.Executor,
+	inboxId string,
+) (*models.InboxCaseWorkflow, error) {
+	return r.workflows[inboxId], nil
+}
+
+func (r *fakeCaseEscalationRepository) UpdateCase(ctx context.Context, exec repositories.Executor,
+	updateCaseAttributes models.UpdateCaseAttributes,
+) error {
+	if updateCaseAttributes.Status != "" {
+		r.cases[updateCaseAttributes.Id].Status = updateCaseAttributes.Status
+	}
+	return nil
+}
+
+type fakeCaseEscalationAssignmentRepository struct {
+	caseAssignmentRepository
+}
+
+func (fakeCaseEscalationAssignmentRepository) GetInboxById(ctx context.Context, exec repositories.Executor,
+	inboxId string,
+) (models.Inbox, error) {
+	return models.Inbox{Id: inboxId, AssignmentStrategy: models.InboxAssignmentManual}, nil
+}
+
+// newCaseEscalationTestUsecase builds a case usecase where caseMergeInboxId escalates to caseMergeOtherInboxId, which
+// has a case workflow with the custom status "in_review" only
+func newCaseEscalationTestUsecase() (*CaseUseCase, *fakeCaseEscalationRepository) {
+	usecase, mergeRepo, _ := newCaseMergeTestUsecase()
+	repo := &fakeCaseEscalationRepository{
+		fakeCaseMergeRepository: mergeRepo,
+		workflows: map[string]*models.InboxCaseWorkflow{
+			caseMergeInboxId: {
+				InboxId:  caseMergeInboxId,
+				Statuses: []models.CaseWorkflowStatus{{Name: "four_eyes"}, {Name: "in_review"}},
+			},
+			caseMergeOtherInboxId: {
+				InboxId:  caseMergeOtherInboxId,
+				Statuses: []models.CaseWorkflowStatus{{Name: "in_review"}},
+			},
+		},
+	}
+	usecase.repository = repo
+	usecase.caseAssignmentRepository = fakeCaseEscalationAssignmentRepository{}
+
+	inboxRepository := usecase.inboxReader.InboxRepository.(*mocks.InboxRepository)
+	inboxRepository.On("GetInboxById", mock.Anything, caseMergeInboxId).Return(models.Inbox{
+		Id:                caseMergeInboxId,
+		OrganizationId:    caseMergeOrgId,
+		Status:            models.InboxStatusActive,
+		EscalationInboxId: utils.Ptr(caseMergeOtherInboxId),
+	}, nil)
+	inboxRepository.On("GetInboxById", mock.Anything, caseMergeOtherInboxId).Return(models.Inbox{
+		Id:             caseMergeOtherInboxId,
+		OrganizationId: caseMergeOrgId,
+		Status:         models.InboxStatusActive,
+	}, nil)
+	usecase.inboxReader.EnforceSecurity.(*mocks.EnforceSecurity).On("ReadInboxMetadata", mock.Anything).Return(nil)
+	return usecase, repo
+}
+
+func TestEscalateCase(t *testing.T) {
+	t.Run("with a status of the target inbox", func(t *testing.T) {
+		usecase, repo := newCaseEscalationTestUsecase()
+		repo.cases[caseMergeSourceCaseId].Status = "in_review"
+
+		require.NoError(t, usecase.EscalateCase(context.Background(), caseMergeSourceCaseId))
+		assert.Equal(t, caseMergeOtherInboxId, repo.cases[caseMergeSourceCaseId].InboxId)
+		assert.Equal(t, models.CaseStatus("in_review"), repo.cases[caseMergeSourceCaseId].Status)
+
+		require.Len(t, repo.events, 1)
+		assert.Equal(t, models.CaseEscalated, repo.events[0].EventType)
+	})
+
+	t.Run("with a status that the target inbox does not have", func(t *testing.T) {
+		usecase, repo := newCaseEscalationTestUsecase()
+		repo.cases[caseMergeSourceCaseId].Status = "four_eyes"
+
+		require.NoError(t, usecase.EscalateCase(context.Background(), caseMergeSourceCaseId))
+		assert.Equal(t, caseMergeOtherInboxId, repo.cases[caseMergeSourceCaseId].InboxId)
+		assert.Equal(t, models.CaseInvestigating, repo.cases[caseMergeSourceCaseId].Status)
+
+		require.Len(t, repo.events, 2)
+		assert.Equal(t, models.CaseEscalated, repo.events[0].EventType)
+		assert.Equal(t, models.CaseStatusUpdated, repo.events[1].EventType)
+		assert.Equal(t, "four_eyes", *repo.events[1].PreviousValue)
+		assert.Equal(t, string(models.CaseInvestigating), *repo.events[1].NewValue)
+	})
+
+	t.Run("to an inbox with the default workflow", func(t *testing.T) {
+		usecase, repo := newCaseEscalationTestUsecase()
+		repo.workflows[caseMergeOtherInboxId] = nil
+		repo.cases[caseMergeSourceCaseId].Status = "in_review"
+
+		require.NoError(t, usecase.EscalateCase(context.Background(), caseMergeSourceCaseId))
+		assert.Equal(t, models.CaseInvestigating, repo.cases[caseMergeSourceCaseId].Status)
+	})
+}
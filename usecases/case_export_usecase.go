@@ -0,0 +1,108 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/repositories"
+	"github.com/checkmarble/marble-backend/usecases/executor_factory"
+)
+
+type CaseExportRepository interface {
+	CreateCaseExport(ctx context.Context, exec repositories.Executor, organizationId, caseId string,
+		requestedByUserId *models.UserId) (models.CaseExport, error)
+	GetCaseExport(ctx context.Context, exec repositories.Executor, id string) (models.CaseExport, error)
+	ListCaseExports(ctx context.Context, exec repositories.Executor, caseId string) ([]models.CaseExport, error)
+	CreateCaseEvent(ctx context.Context, exec repositories.Executor,
+		createCaseEventAttributes models.CreateCaseEventAttributes) error
+}
+
+type caseExportCaseReader interface {
+	GetCase(ctx context.Context, caseId string) (models.Case, error)
+}
+
+type CaseExportUsecase struct {
+	executorFactory     executor_factory.ExecutorFactory
+	transactionFactory  executor_factory.TransactionFactory
+	caseReader          caseExportCaseReader
+	repository          CaseExportRepository
+	taskQueueRepository repositories.TaskQueueRepository
+	blobRepository      repositories.BlobRepository
+	bucketUrl           string
+	credentials         models.Credentials
+}
+
+// CreateCaseExport schedules the job that writes the complete file of a case to the case manager bucket. Any user who
+// can read the case can export it, and the export is recorded in the timeline of the case.
+func (uc CaseExportUsecase) CreateCaseExport(ctx context.Context, caseId string) (models.CaseExport, error) {
+	c, err := uc.caseReader.GetCase(ctx, caseId)
+	if err != nil {
+		return models.CaseExport{}, err
+	}
+	if uc.bucketUrl == "" {
+		return models.CaseExport{}, errors.Wrap(models.BadParameterError,
+			"case exports are not configured on this instance")
+	}
+
+	var userId *models.UserId
+	var eventUserId *string
+	if uc.credentials.ActorIdentity.UserId != "" {
+		userId = &uc.credentials.ActorIdentity.UserId
+		eventUserId = (*string)(userId)
+	}
+
+	return executor_factory.TransactionReturnValue(ctx, uc.transactionFactory, func(
+		tx repositories.Transaction,
+	) (models.CaseExport, error) {
+		export, err := uc.repository.CreateCaseExport(ctx, tx, c.OrganizationId, c.Id, userId)
+		if err != nil {
+			return models.CaseExport{}, err
+		}
+		if err := uc.repository.CreateCaseEvent(ctx, tx, models.CreateCaseEventAttributes{
+			CaseId:     c.Id,
+			UserId:     eventUserId,
+			EventType:  models.CaseExported,
+			ResourceId: &export.Id,
+		}); err != nil {
+			return models.CaseExport{}, err
+		}
+		if err := uc.taskQueueRepository.EnqueueCaseExportTask(ctx, tx, c.OrganizationId, export.Id); err != nil {
+			return models.CaseExport{}, err
+		}
+		return export, nil
+	})
+}
+
+func (uc CaseExportUsecase) ListCaseExports(ctx context.Context, caseId string) ([]models.CaseExport, error) {
+	if _, err := uc.caseReader.GetCase(ctx, caseId); err != nil {
+		return nil, err
+	}
+	return uc.repository.ListCaseExports(ctx, uc.executorFactory.NewExecutor(), caseId)
+}
+
+// GetCaseExport returns an export, with a signed url to download its archive once it has succeeded
+func (uc CaseExportUsecase) GetCaseExport(ctx context.Context, caseId, exportId string) (models.CaseExportWithUrl, error) {
+	if _, err := uc.caseReader.GetCase(ctx, caseId); err != nil {
+		return models.CaseExportWithUrl{}, err
+	}
+	export, err := uc.repository.GetCaseExport(ctx, uc.executorFactory.NewExecutor(), exportId)
+	if err != nil {
+		return models.CaseExportWithUrl{}, err
+	}
+	if export.CaseId != caseId {
+		return models.CaseExportWithUrl{}, errors.Wrap(models.NotFoundError, "case export not found")
+	}
+
+	result := models.CaseExportWithUrl{CaseExport: export}
+	if export.Status != models.CaseExportSuccess {
+		return result, nil
+	}
+	url, err := uc.blobRepository.GenerateSignedUrl(ctx, uc.bucketUrl, export.FileKey())
+	if err != nil {
+		return models.CaseExportWithUrl{}, err
+	}
+	result.FileUrl = &url
+	return result, nil
+}
@@ -0,0 +1,273 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/repositories"
+	"github.com/checkmarble/marble-backend/usecases/executor_factory"
+)
+
+// MergeCases moves the decisions, comments, files, tags, annotations and SARs of the source case into the target case,
+// then closes the source case with a link to the target case.
+func (usecase *CaseUseCase) MergeCases(ctx context.Context, userId, sourceCaseId, targetCaseId string) (models.Case, error) {
+	if sourceCaseId == targetCaseId {
+		return models.Case{}, errors.Wrap(models.BadParameterError, "cannot merge a case into itself")
+	}
+	sourceWebhookEventId := uuid.NewString()
+	targetWebhookEventId := uuid.NewString()
+
+	targetCase, err := executor_factory.TransactionReturnValue(ctx, usecase.transactionFactory, func(
+		tx repositories.Transaction,
+	) (models.Case, error) {
+		source, err := usecase.getUpdatableCase(ctx, tx, sourceCaseId)
+		if err != nil {
+			return models.Case{}, err
+		}
+		target, err := usecase.getUpdatableCase(ctx, tx, targetCaseId)
+		if err != nil {
+			return models.Case{}, err
+		}
+		if source.OrganizationId != target.OrganizationId {
+			return models.Case{}, errors.Wrap(models.BadParameterError,
+				"cannot merge cases of different organizations")
+		}
+
+		if err := usecase.repository.MoveCaseContent(ctx, tx, source.OrganizationId,
+			source.Id, target.Id); err != nil {
+			return models.Case{}, err
+		}
+		if err := usecase.repository.CloseMergedCase(ctx, tx, source.Id, target.Id); err != nil {
+			return models.Case{}, err
+		}
+
+		closedStatus := string(models.CaseClosed)
+		resourceType := models.CaseResourceType
+		events := []models.CreateCaseEventAttributes{
+			{
+				CaseId:        source.Id,
+				UserId:        &userId,
+				EventType:     models.CaseMerged,
+				ResourceId:    &target.Id,
+				ResourceType:  &resourceType,
+				NewValue:      &target.Id,
+				PreviousValue: &source.Id,
+			},
+			{
+				CaseId:        source.Id,
+				UserId:        &userId,
+				EventType:     models.CaseStatusUpdated,
+				NewValue:      &closedStatus,
+				PreviousValue: (*string)(&source.Status),
+			},
+			{
+				CaseId:        target.Id,
+				UserId:        &userId,
+				EventType:     models.CaseMerged,
+				ResourceId:    &source.Id,
+				ResourceType:  &resourceType,
+				NewValue:      &target.Id,
+				PreviousValue: &source.Id,
+			},
+		}
+		if err := usecase.repository.BatchCreateCaseEvents(ctx, tx, events); err != nil {
+			return models.Case{}, err
+		}
+		if err := usecase.createCaseContributorIfNotExist(ctx, tx, target.Id, userId); err != nil {
+			return models.Case{}, err
+		}
+
+		closedSource, err := usecase.getCaseWithDetails(ctx, tx, source.Id)
+		if err != nil {
+			return models.Case{}, err
+		}
+		updatedTarget, err := usecase.getCaseWithDetails(ctx, tx, target.Id)
+		if err != nil {
+			return models.Case{}, err
+		}
+
+		err = usecase.webhookEventsUsecase.CreateWebhookEvent(ctx, tx, models.WebhookEventCreate{
+			Id:             sourceWebhookEventId,
+			OrganizationId: closedSource.OrganizationId,
+			EventContent:   models.NewWebhookEventCaseUpdated(closedSource),
+		})
+		if err != nil {
+			return models.Case{}, err
+		}
+		err = usecase.webhookEventsUsecase.CreateWebhookEvent(ctx, tx, models.WebhookEventCreate{
+			Id:             targetWebhookEventId,
+			OrganizationId: updatedTarget.OrganizationId,
+			EventContent:   models.NewWebhookEventCaseDecisionsUpdated(updatedTarget.GetMetadata()),
+		})
+		if err != nil {
+			return models.Case{}, err
+		}
+
+		return updatedTarget, nil
+	})
+	if err != nil {
+		return models.Case{}, err
+	}
+
+	usecase.webhookEventsUsecase.SendWebhookEventAsync(ctx, sourceWebhookEventId)
+	usecase.webhookEventsUsecase.SendWebhookEventAsync(ctx, targetWebhookEventId)
+
+	return targetCase, nil
+}
+
+// SplitCase moves some decisions of a case into a new case in the same inbox. At least one decision must remain in the
+// original case.
+func (usecase *CaseUseCase) SplitCase(ctx context.Context, userId string,
+	input models.SplitCaseInput,
+) (models.Case, error) {
+	if len(input.DecisionIds) == 0 {
+		return models.Case{}, errors.Wrap(models.BadParameterError, "at least one decision is required to split a case")
+	}
+	sourceWebhookEventId := uuid.NewString()
+	newCaseWebhookEventId := uuid.NewString()
+
+	newCase, err := executor_factory.TransactionReturnValue(ctx, usecase.transactionFactory, func(
+		tx repositories.Transaction,
+	) (models.Case, error) {
+		source, err := usecase.getUpdatableCase(ctx, tx, input.CaseId)
+		if err != nil {
+			return models.Case{}, err
+		}
+
+		decisions, err := usecase.decisionRepository.DecisionsById(ctx, tx, input.DecisionIds)
+		if err != nil {
+			return models.Case{}, err
+		}
+		if len(decisions) != len(input.DecisionIds) {
+			return models.Case{}, errors.Wrap(models.NotFoundError, "some decisions were not found")
+		}
+		for _, decision := range decisions {
+			if decision.Case == nil || decision.Case.Id != source.Id {
+				return models.Case{}, errors.Wrapf(models.BadParameterError,
+					"decision %s does not belong to case %s", decision.DecisionId, source.Id)
+			}
+		}
+		if len(input.DecisionIds) >= source.DecisionsCount {
+			return models.Case{}, errors.Wrap(models.BadParameterError,
+				"cannot move all the decisions of a case, at least one must remain")
+		}
+
+		name := input.Name
+		if name == "" {
+			name = fmt.Sprintf("%s (split)", source.Name)
+		}
+		newCaseId := uuid.NewString()
+		err = usecase.repository.CreateCase(ctx, tx, models.CreateCaseAttributes{
+			InboxId:        source.InboxId,
+			Name:           name,
+			OrganizationId: source.OrganizationId,
+		}, newCaseId)
+		if err != nil {
+			return models.Case{}, err
+		}
+		if err := usecase.repository.CreateCaseEvent(ctx, tx, models.CreateCaseEventAttributes{
+			CaseId:    newCaseId,
+			UserId:    &userId,
+			EventType: models.CaseCreated,
+		}); err != nil {
+			return models.Case{}, err
+		}
+		if err := usecase.UpdateDecisionsWithEvents(ctx, tx, newCaseId, userId, input.DecisionIds); err != nil {
+			return models.Case{}, err
+		}
+
+		resourceType := models.CaseResourceType
+		movedDecisionsCount := fmt.Sprintf("%d", len(input.DecisionIds))
+		events := []models.CreateCaseEventAttributes{
+			{
+				CaseId:         source.Id,
+				UserId:         &userId,
+				EventType:      models.CaseSplit,
+				ResourceId:     &newCaseId,
+				ResourceType:   &resourceType,
+				NewValue:       &newCaseId,
+				PreviousValue:  &source.Id,
+				AdditionalNote: &movedDecisionsCount,
+			},
+			{
+				CaseId:         newCaseId,
+				UserId:         &userId,
+				EventType:      models.CaseSplit,
+				ResourceId:     &source.Id,
+				ResourceType:   &resourceType,
+				NewValue:       &newCaseId,
+				PreviousValue:  &source.Id,
+				AdditionalNote: &movedDecisionsCount,
+			},
+		}
+		if err := usecase.repository.BatchCreateCaseEvents(ctx, tx, events); err != nil {
+			return models.Case{}, err
+		}
+		if err := usecase.createCaseContributorIfNotExist(ctx, tx, newCaseId, userId); err != nil {
+			return models.Case{}, err
+		}
+		if err := usecase.createCaseContributorIfNotExist(ctx, tx, source.Id, userId); err != nil {
+			return models.Case{}, err
+		}
+
+		createdCase, err := usecase.getCaseWithDetails(ctx, tx, newCaseId)
+		if err != nil {
+			return models.Case{}, err
+		}
+
+		err = usecase.webhookEventsUsecase.CreateWebhookEvent(ctx, tx, models.WebhookEventCreate{
+			Id:             sourceWebhookEventId,
+			OrganizationId: source.OrganizationId,
+			EventContent:   models.NewWebhookEventCaseDecisionsUpdated(source.GetMetadata()),
+		})
+		if err != nil {
+			return models.Case{}, err
+		}
+		err = usecase.webhookEventsUsecase.CreateWebhookEvent(ctx, tx, models.WebhookEventCreate{
+			Id:             newCaseWebhookEventId,
+			OrganizationId: createdCase.OrganizationId,
+			EventContent:   models.NewWebhookEventCaseCreatedManually(createdCase.GetMetadata()),
+		})
+		if err != nil {
+			return models.Case{}, err
+		}
+
+		return createdCase, nil
+	})
+	if err != nil {
+		return models.Case{}, err
+	}
+
+	usecase.webhookEventsUsecase.SendWebhookEventAsync(ctx, sourceWebhookEventId)
+	usecase.webhookEventsUsecase.SendWebhookEventAsync(ctx, newCaseWebhookEventId)
+
+	return newCase, nil
+}
+
+// getUpdatableCase reads a case that the user can update, and that is not closed
+func (usecase *CaseUseCase) getUpdatableCase(ctx context.Context, tx repositories.Transaction,
+	caseId string,
+) (models.Case, error) {
+	c, err := usecase.repository.GetCaseById(ctx, tx, caseId)
+	if err != nil {
+		return models.Case{}, err
+	}
+
+	availableInboxIds, err := usecase.getAvailableInboxIds(ctx, tx, c.OrganizationId)
+	if err != nil {
+		return models.Case{}, err
+	}
+	if err := usecase.enforceSecurity.ReadOrUpdateCase(c.GetMetadata(), availableInboxIds); err != nil {
+		return models.Case{}, err
+	}
+	if c.Status.IsFinalized() {
+		return models.Case{}, errors.Wrap(models.BadParameterError,
+			fmt.Sprintf("case %s is closed", c.Id))
+	}
+
+	return c, nil
+}
@@ -0,0 +1,374 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/checkmarble/marble-backend/mocks"
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/repositories"
+	"github.com/checkmarble/marble-backend/usecases/executor_factory"
+	"github.com/checkmarble/marble-backend/usecases/inboxes"
+	"github.com/checkmarble/marble-backend/usecases/security"
+)
+
+// fakeCaseMergeRepository keeps the cases and the case of each decision in memory. Only the methods used by case
+// merges and splits are implemented.
+type fakeCaseMergeRepository struct {
+	CaseUseCaseRepository
+
+	cases         map[string]*models.Case
+	decisionCases map[string]string
+	events        []models.CreateCaseEventAttributes
+	mergedInto    map[string]string
+}
+
+func (r *fakeCaseMergeRepository) GetCaseById(ctx context.Context, exec repositories.Executor,
+	caseId string,
+) (models.Case, error) {
+	c, ok := r.cases[caseId]
+	if !ok {
+		return models.Case{}, models.NotFoundError
+	}
+	out := *c
+	out.DecisionsCount = 0
+	for _, decisionCaseId := range r.decisionCases {
+		if decisionCaseId == caseId {
+			out.DecisionsCount++
+		}
+	}
+	return out, nil
+}
+
+func (r *fakeCaseMergeRepository) CreateCase(ctx context.Context, exec repositories.Executor,
+	createCaseAttributes models.CreateCaseAttributes, newCaseId string,
+) error {
+	r.cases[newCaseId] = &models.Case{
+		Id:             newCaseId,
+		OrganizationId: createCaseAttributes.OrganizationId,
+		InboxId:        createCaseAttributes.InboxId,
+		Name:           createCaseAttributes.Name,
+		Status:         models.CasePending,
+	}
+	return nil
+}
+
+func (r *fakeCaseMergeRepository) UnsnoozeCase(ctx context.Context, exec repositories.Executor, caseId string) error {
+	return nil
+}
+
+func (r *fakeCaseMergeRepository) CreateCaseEvent(ctx context.Context, exec repositories.Executor,
+	createCaseEventAttributes models.CreateCaseEventAttributes,
+) error {
+	r.events = append(r.events, createCaseEventAttributes)
+	return nil
+}
+
+func (r *fakeCaseMergeRepository) BatchCreateCaseEvents(ctx context.Context, exec repositories.Executor,
+	createCaseEventAttributes []models.CreateCaseEventAttributes,
+) error {
+	r.events = append(r.events, createCaseEventAttributes...)
+	return nil
+}
+
+func (r *fakeCaseMergeRepository) ListCaseEvents(ctx context.Context, exec repositories.Executor,
+	caseId string,
+) ([]models.CaseEvent, error) {
+	return nil, nil
+}
+
+func (r *fakeCaseMergeRepository) GetCaseContributor(ctx context.Context, exec repositories.Executor,
+	caseId, userId string,
+) (*models.CaseContributor, error) {
+	return nil, nil
+}
+
+func (r *fakeCaseMergeRepository) CreateCaseContributor(ctx context.Context, exec repositories.Executor,
+	caseId, userId string,
+) error {
+	return nil
+}
+
+func (r *fakeCaseMergeRepository) GetCasesFileByCaseId(ctx context.Context, exec repositories.Executor,
+	caseId string,
+) ([]models.CaseFile, error) {
+	return nil, nil
+}
+
+func (r *fakeCaseMergeRepository) MoveCaseContent(ctx context.Context, exec repositories.Executor,
+	organizationId, sourceCaseId, targetCaseId string,
+) error {
+	for decisionId, caseId := range r.decisionCases {
+		if caseId == sourceCaseId {
+			r.decisionCases[decisionId] = targetCaseId
+		}
+	}
+	return nil
+}
+
+func (r *fakeCaseMergeRepository) CloseMergedCase(ctx context.Context, exec repositories.Executor,
+	caseId, targetCaseId string,
+) error {
+	r.cases[caseId].Status = models.CaseClosed
+	r.mergedInto[caseId] = targetCaseId
+	return nil
+}
+
+// fakeCaseMergeDecisionRepository reads and moves the decisions of a fakeCaseMergeRepository
+type fakeCaseMergeDecisionRepository struct {
+	repositories.DecisionRepository
+	*fakeCaseMergeRepository
+}
+
+func (r fakeCaseMergeDecisionRepository) DecisionsById(ctx context.Context, exec repositories.Executor,
+	decisionIds []string,
+) ([]models.Decision, error) {
+	decisions := make([]models.Decision, 0, len(decisionIds))
+	for _, decisionId := range decisionIds {
+		caseId, ok := r.decisionCases[decisionId]
+		if !ok {
+			continue
+		}
+		decisions = append(decisions, models.Decision{DecisionId: decisionId, Case: &models.Case{Id: caseId}})
+	}
+	return decisions, nil
+}
+
+func (r fakeCaseMergeDecisionRepository) DecisionsByCaseId(ctx context.Context, exec repositories.Executor,
+	organizationId, caseId string,
+) ([]models.DecisionWithRuleExecutions, error) {
+	return nil, nil
+}
+
+func (r fakeCaseMergeDecisionRepository) UpdateDecisionCaseId(ctx context.Context, exec repositories.Executor,
+	decisionIds []string, caseId string,
+) error {
+	for _, decisionId := range decisionIds {
+		r.decisionCases[decisionId] = caseId
+	}
+	return nil
+}
+
+type fakeCaseMergeWebhookEvents struct {
+	created []models.WebhookEventCreate
+}
+
+func (w *fakeCaseMergeWebhookEvents) CreateWebhookEvent(ctx context.Context, tx repositories.Transaction,
+	input models.WebhookEventCreate,
+) error {
+	w.created = append(w.created, input)
+	return nil
+}
+
+func (w *fakeCaseMergeWebhookEvents) SendWebhookEventAsync(ctx context.Context, webhookEventId string) {
+}
+
+const (
+	caseMergeOrgId         = "org"
+	caseMergeUserId        = "user"
+	caseMergeInboxId       = "inbox"
+	caseMergeOtherInboxId  = "other_inbox"
+	caseMergeSourceCaseId  = "source_case"
+	caseMergeTargetCaseId  = "target_case"
+	caseMergeForeignCaseId = "foreign_case"
+)
+
+// newCaseMergeTestUsecase builds a case usecase for a builder who is a member of caseMergeInboxId only
+func newCaseMergeTestUsecase() (*CaseUseCase, *fakeCaseMergeRepository, *fakeCaseMergeWebhookEvents) {
+	repo := &fakeCaseMergeRepository{
+		cases: map[string]*models.Case{
+			caseMergeSourceCaseId: {
+				Id: caseMergeSourceCaseId, OrganizationId: caseMergeOrgId, InboxId: caseMergeInboxId,
+				Name: "source", Status: models.CaseInvestigating,
+			},
+			caseMergeTargetCaseId: {
+				Id: caseMergeTargetCaseId, OrganizationId: caseMergeOrgId, InboxId: caseMergeInboxId,
+				Name: "target", Status: models.CasePending,
+			},
+			caseMergeForeignCaseId: {
+				Id: caseMergeForeignCaseId, OrganizationId: caseMergeOrgId, InboxId: caseMergeOtherInboxId,
+				Name: "foreign", Status: models.CasePending,
+			},
+		},
+		decisionCases: map[string]string{
+			"decision_1": caseMergeSourceCaseId,
+			"decision_2": caseMergeSourceCaseId,
+			"decision_3": caseMergeTargetCaseId,
+			"decision_4": caseMergeForeignCaseId,
+		},
+		mergedInto: map[string]string{},
+	}
+
+	user := models.User{
+		UserId:         caseMergeUserId,
+		OrganizationId: caseMergeOrgId,
+		Role:           models.BUILDER,
+	}
+	inboxRepository := new(mocks.InboxRepository)
+	inboxRepository.On("ListInboxUsers", mock.Anything, mock.Anything).Return(
+		[]models.InboxUser{{InboxId: caseMergeInboxId, UserId: caseMergeUserId}}, nil)
+	inboxRepository.On("ListInboxes", mock.Anything, caseMergeOrgId, []string{caseMergeInboxId}).Return(
+		[]models.Inbox{{Id: caseMergeInboxId, OrganizationId: caseMergeOrgId}}, nil)
+	enforceSecurityInboxes := new(mocks.EnforceSecurity)
+	enforceSecurityInboxes.On("ReadInbox", mock.Anything).Return(nil)
+
+	exec := executor_factory.NewExecutorFactoryStub()
+	webhooks := &fakeCaseMergeWebhookEvents{}
+	usecase := &CaseUseCase{
+		enforceSecurity:    security.EnforceSecurityCaseForUser(user),
+		repository:         repo,
+		decisionRepository: fakeCaseMergeDecisionRepository{fakeCaseMergeRepository: repo},
+		inboxReader: inboxes.InboxReader{
+			EnforceSecurity: enforceSecurityInboxes,
+			InboxRepository: inboxRepository,
+			Credentials:     models.NewCredentialWithUser(user),
+			ExecutorFactory: exec,
+		},
+		transactionFactory:   executor_factory.NewTransactionFactoryStub(exec),
+		executorFactory:      exec,
+		webhookEventsUsecase: webhooks,
+	}
+	return usecase, repo, webhooks
+}
+
+func TestMergeCases(t *testing.T) {
+	usecase, repo, webhooks := newCaseMergeTestUsecase()
+
+	_, err := usecase.MergeCases(context.Background(), caseMergeUserId, caseMergeSourceCaseId, caseMergeTargetCaseId)
+	require.NoError(t, err)
+
+	assert.Equal(t, models.CaseClosed, repo.cases[caseMergeSourceCaseId].Status)
+	assert.Equal(t, caseMergeTargetCaseId, repo.mergedInto[caseMergeSourceCaseId])
+	assert.Equal(t, caseMergeTargetCaseId, repo.decisionCases["decision_1"])
+	assert.Equal(t, caseMergeTargetCaseId, repo.decisionCases["decision_2"])
+
+	mergedEvents := make(map[string]int)
+	for _, event := range repo.events {
+		if event.EventType == models.CaseMerged {
+			mergedEvents[event.CaseId]++
+		}
+	}
+	assert.Equal(t, map[string]int{caseMergeSourceCaseId: 1, caseMergeTargetCaseId: 1}, mergedEvents,
+		"both cases record the merge in their timeline")
+	assert.Len(t, webhooks.created, 2)
+}
+
+func TestMergeCases_rejected(t *testing.T) {
+	t.Run("into itself", func(t *testing.T) {
+		usecase, _, _ := newCaseMergeTestUsecase()
+		_, err := usecase.MergeCases(context.Background(), caseMergeUserId,
+			caseMergeSourceCaseId, caseMergeSourceCaseId)
+		assert.ErrorIs(t, err, models.BadParameterError)
+	})
+
+	t.Run("into a case of an inbox the user cannot access", func(t *testing.T) {
+		usecase, repo, _ := newCaseMergeTestUsecase()
+		_, err := usecase.MergeCases(context.Background(), caseMergeUserId,
+			caseMergeSourceCaseId, caseMergeForeignCaseId)
+		assert.ErrorIs(t, err, models.ForbiddenError)
+		assert.Equal(t, models.CaseInvestigating, repo.cases[caseMergeSourceCaseId].Status)
+		assert.Empty(t, repo.events)
+	})
+
+	t.Run("from a case of an inbox the user cannot access", func(t *testing.T) {
+		usecase, repo, _ := newCaseMergeTestUsecase()
+		_, err := usecase.MergeCases(context.Background(), caseMergeUserId,
+			caseMergeForeignCaseId, caseMergeTargetCaseId)
+		assert.ErrorIs(t, err, models.ForbiddenError)
+		assert.Equal(t, caseMergeForeignCaseId, repo.decisionCases["decision_4"])
+	})
+
+	t.Run("from a closed case", func(t *testing.T) {
+		usecase, repo, _ := newCaseMergeTestUsecase()
+		repo.cases[caseMergeSourceCaseId].Status = models.CaseClosed
+		_, err := usecase.MergeCases(context.Background(), caseMergeUserId,
+			caseMergeSourceCaseId, caseMergeTargetCaseId)
+		assert.ErrorIs(t, err, models.BadParameterError)
+	})
+}
+
+func TestSplitCase(t *testing.T) {
+	usecase, repo, webhooks := newCaseMergeTestUsecase()
+
+	newCase, err := usecase.SplitCase(context.Background(), caseMergeUserId, models.SplitCaseInput{
+		CaseId:      caseMergeSourceCaseId,
+		DecisionIds: []string{"decision_2"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "source (split)", newCase.Name)
+	assert.Equal(t, caseMergeInboxId, newCase.InboxId)
+	assert.Equal(t, caseMergeSourceCaseId, repo.decisionCases["decision_1"])
+	assert.Equal(t, newCase.Id, repo.decisionCases["decision_2"])
+	assert.Equal(t, models.CaseInvestigating, repo.cases[caseMergeSourceCaseId].Status)
+
+	splitEvents := make(map[string]int)
+	for _, event := range repo.events {
+		if event.EventType == models.CaseSplit {
+			splitEvents[event.CaseId]++
+		}
+	}
+	assert.Equal(t, map[string]int{caseMergeSourceCaseId: 1, newCase.Id: 1}, splitEvents)
+	assert.Len(t, webhooks.created, 2)
+}
+
+func TestSplitCase_rejected(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       models.SplitCaseInput
+		expectedErr error
+	}{
+		{
+			name:        "no decisions",
+			input:       models.SplitCaseInput{CaseId: caseMergeSourceCaseId},
+			expectedErr: models.BadParameterError,
+		},
+		{
+			name: "all the decisions of the case",
+			input: models.SplitCaseInput{
+				CaseId:      caseMergeSourceCaseId,
+				DecisionIds: []string{"decision_1", "decision_2"},
+			},
+			expectedErr: models.BadParameterError,
+		},
+		{
+			name: "a decision of another case",
+			input: models.SplitCaseInput{
+				CaseId:      caseMergeSourceCaseId,
+				DecisionIds: []string{"decision_3"},
+			},
+			expectedErr: models.BadParameterError,
+		},
+		{
+			name: "an unknown decision",
+			input: models.SplitCaseInput{
+				CaseId:      caseMergeSourceCaseId,
+				DecisionIds: []string{"unknown"},
+			},
+			expectedErr: models.NotFoundError,
+		},
+		{
+			name: "a case of an inbox the user cannot access",
+			input: models.SplitCaseInput{
+				CaseId:      caseMergeForeignCaseId,
+				DecisionIds: []string{"decision_4"},
+			},
+			expectedErr: models.ForbiddenError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			usecase, repo, _ := newCaseMergeTestUsecase()
+			_, err := usecase.SplitCase(context.Background(), caseMergeUserId, tt.input)
+			assert.True(t, errors.Is(err, tt.expectedErr), "expected %v, got %v", tt.expectedErr, err)
+			assert.Len(t, repo.cases, 3, "no case is created")
+			assert.Empty(t, repo.events)
+		})
+	}
+}
@@ -0,0 +1,183 @@
+package usecases
+
+import (
+	"context"
+	"encoding/csv"
+	"io"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/pure_utils"
+	"github.com/checkmarble/marble-backend/repositories"
+	"github.com/checkmarble/marble-backend/usecases/executor_factory"
+	"github.com/checkmarble/marble-backend/usecases/security"
+)
+
+type CaseMetricsRepository interface {
+	CaseVolumeMetrics(ctx context.Context, exec repositories.Executor,
+		filters models.CaseMetricsFilters) ([]models.CaseVolumeMetric, error)
+	CaseTimeToCloseMetrics(ctx context.Context, exec repositories.Executor,
+		filters models.CaseMetricsFilters, byAnalyst bool) ([]models.CaseTimeToCloseMetric, error)
+	CaseBacklogAgeMetrics(ctx context.Context, exec repositories.Executor,
+		filters models.CaseMetricsFilters) ([]models.CaseBacklogAgeMetric, error)
+	CaseOutcomeMetrics(ctx context.Context, exec repositories.Executor,
+		filters models.CaseMetricsFilters) ([]models.CaseOutcomeMetric, error)
+	CaseEscalationMetrics(ctx context.Context, exec repositories.Executor,
+		filters models.CaseMetricsFilters) ([]models.CaseEscalationMetric, error)
+}
+
+type caseMetricsInboxReader interface {
+	ListInboxes(ctx context.Context, exec repositories.Executor, organizationId string,
+		withCaseCount bool) ([]models.Inbox, error)
+}
+
+// CaseMetricsUsecase computes the case management metrics of an organization from the case events, over the cases of
+// the active inboxes that the user can read
+type CaseMetricsUsecase struct {
+	executorFactory executor_factory.ExecutorFactory
+	enforceSecurity security.EnforceSecurity
+	inboxReader     caseMetricsInboxReader
+	repository      CaseMetricsRepository
+}
+
+func (uc CaseMetricsUsecase) GetCaseMetrics(ctx context.Context,
+	filters models.CaseMetricsFilters,
+) (models.CaseMetrics, error) {
+	exec := uc.executorFactory.NewExecutor()
+	filters, err := uc.prepareCaseMetricsFilters(ctx, exec, filters)
+	if err != nil {
+		return models.CaseMetrics{}, err
+	}
+
+	var metrics models.CaseMetrics
+	for _, metric := range models.ValidCaseMetricTypes {
+		if err := uc.computeCaseMetric(ctx, exec, filters, metric, &metrics); err != nil {
+			return models.CaseMetrics{}, err
+		}
+	}
+	return metrics, nil
+}
+
+// ExportCaseMetric writes a metric as CSV, with a header line
+func (uc CaseMetricsUsecase) ExportCaseMetric(ctx context.Context, w io.Writer,
+	filters models.CaseMetricsFilters, metric models.CaseMetricType,
+) error {
+	exec := uc.executorFactory.NewExecutor()
+	filters, err := uc.prepareCaseMetricsFilters(ctx, exec, filters)
+	if err != nil {
+		return err
+	}
+
+	var metrics models.CaseMetrics
+	if err := uc.computeCaseMetric(ctx, exec, filters, metric, &metrics); err != nil {
+		return err
+	}
+
+	csvWriter := csv.NewWriter(w)
+	if err := csvWriter.WriteAll(caseMetricCsvRows(metric, metrics)); err != nil {
+		return err
+	}
+	return csvWriter.Error()
+}
+
+// prepareCaseMetricsFilters checks the permission of the user, and restricts the metrics to the inboxes that they can
+// read
+func (uc CaseMetricsUsecase) prepareCaseMetricsFilters(ctx context.Context, exec repositories.Executor,
+	filters models.CaseMetricsFilters,
+) (models.CaseMetricsFilters, error) {
+	if err := errors.Join(
+		uc.enforceSecurity.Permission(models.ANALYTICS_READ),
+		uc.enforceSecurity.ReadOrganization(filters.OrganizationId),
+	); err != nil {
+		return models.CaseMetricsFilters{}, err
+	}
+	if err := filters.Validate(); err != nil {
+		return models.CaseMetricsFilters{}, err
+	}
+
+	inboxes, err := uc.inboxReader.ListInboxes(ctx, exec, filters.OrganizationId, false)
+	if err != nil {
+		return models.CaseMetricsFilters{}, err
+	}
+	availableInboxIds := pure_utils.Map(inboxes, func(i models.Inbox) string { return i.Id })
+	for _, inboxId := range filters.InboxIds {
+		if !slices.Contains(availableInboxIds, inboxId) {
+			return models.CaseMetricsFilters{}, errors.Wrapf(models.ForbiddenError,
+				"user does not have access to the inbox %s", inboxId)
+		}
+	}
+	if len(filters.InboxIds) == 0 {
+		filters.InboxIds = availableInboxIds
+	}
+	return filters, nil
+}
+
+func (uc CaseMetricsUsecase) computeCaseMetric(ctx context.Context, exec repositories.Executor,
+	filters models.CaseMetricsFilters, metric models.CaseMetricType, metrics *models.CaseMetrics,
+) error {
+	var err error
+	switch metric {
+	case models.CaseMetricVolume:
+		metrics.Volume, err = uc.repository.CaseVolumeMetrics(ctx, exec, filters)
+	case models.CaseMetricTimeToCloseByInbox:
+		metrics.TimeToCloseByInbox, err = uc.repository.CaseTimeToCloseMetrics(ctx, exec, filters, false)
+	case models.CaseMetricTimeToCloseByAnalyst:
+		metrics.TimeToCloseByAnalyst, err = uc.repository.CaseTimeToCloseMetrics(ctx, exec, filters, true)
+	case models.CaseMetricBacklogAge:
+		metrics.BacklogAge, err = uc.repository.CaseBacklogAgeMetrics(ctx, exec, filters)
+	case models.CaseMetricOutcomes:
+		metrics.Outcomes, err = uc.repository.CaseOutcomeMetrics(ctx, exec, filters)
+	case models.CaseMetricEscalations:
+		metrics.Escalations, err = uc.repository.CaseEscalationMetrics(ctx, exec, filters)
+	default:
+		return errors.Wrapf(models.BadParameterError, "invalid case metric '%s'", metric)
+	}
+	return err
+}
+
+func caseMetricCsvRows(metric models.CaseMetricType, metrics models.CaseMetrics) [][]string {
+	itoa := strconv.Itoa
+	ftoa := func(f float64) string { return strconv.FormatFloat(f, 'f', 2, 64) }
+
+	switch metric {
+	case models.CaseMetricVolume:
+		rows := [][]string{{"day", "nb_opened", "nb_closed"}}
+		for _, m := range metrics.Volume {
+			rows = append(rows, []string{m.Day.Format(time.DateOnly), itoa(m.NbOpened), itoa(m.NbClosed)})
+		}
+		return rows
+	case models.CaseMetricTimeToCloseByInbox, models.CaseMetricTimeToCloseByAnalyst:
+		groupColumn, values := "inbox_id", metrics.TimeToCloseByInbox
+		if metric == models.CaseMetricTimeToCloseByAnalyst {
+			groupColumn, values = "analyst_id", metrics.TimeToCloseByAnalyst
+		}
+		rows := [][]string{{groupColumn, "nb_closed", "median_time_to_close_seconds"}}
+		for _, m := range values {
+			rows = append(rows, []string{m.GroupId, itoa(m.NbClosed), ftoa(m.MedianTimeToClose.Seconds())})
+		}
+		return rows
+	case models.CaseMetricBacklogAge:
+		rows := [][]string{{"age", "nb_cases"}}
+		for _, m := range metrics.BacklogAge {
+			rows = append(rows, []string{m.Bucket, itoa(m.NbCases)})
+		}
+		return rows
+	case models.CaseMetricOutcomes:
+		rows := [][]string{{"outcome", "nb_cases"}}
+		for _, m := range metrics.Outcomes {
+			rows = append(rows, []string{string(m.Outcome), itoa(m.NbCases)})
+		}
+		return rows
+	case models.CaseMetricEscalations:
+		rows := [][]string{{"inbox_id", "nb_closed", "nb_escalated", "escalation_rate"}}
+		for _, m := range metrics.Escalations {
+			rows = append(rows, []string{m.InboxId, itoa(m.NbClosed), itoa(m.NbEscalated), ftoa(m.EscalationRate())})
+		}
+		return rows
+	}
+	return nil
+}
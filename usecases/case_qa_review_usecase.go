@@ -0,0 +1,168 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/repositories"
+	"github.com/checkmarble/marble-backend/usecases/executor_factory"
+	"github.com/checkmarble/marble-backend/utils"
+)
+
+type CaseQaReviewRepository interface {
+	GetCaseQaReview(ctx context.Context, exec repositories.Executor, id string) (models.CaseQaReview, error)
+	ListCaseQaReviews(ctx context.Context, exec repositories.Executor,
+		filters models.CaseQaReviewFilters) ([]models.CaseQaReview, error)
+	CompleteCaseQaReview(ctx context.Context, exec repositories.Executor, id string,
+		input models.CaseQaReviewCompletion) (*models.CaseQaReview, error)
+	ListCaseQaScores(ctx context.Context, exec repositories.Executor,
+		filters models.CaseQaScoreFilters) ([]models.CaseQaScore, error)
+	GetInboxById(ctx context.Context, exec repositories.Executor, inboxId string) (models.Inbox, error)
+	CreateCaseEvent(ctx context.Context, exec repositories.Executor,
+		createCaseEventAttributes models.CreateCaseEventAttributes) error
+}
+
+type caseQaReviewSecurity interface {
+	Permission(permission models.Permission) error
+	ReadOrganization(organizationId string) error
+	UpdateInbox(inbox models.Inbox) error
+}
+
+// CaseQaReviewUsecase handles the QA reviews of closed cases. Reviewers see and complete the reviews assigned to them,
+// and the admins of an inbox see all the reviews and the QA scores of the inbox.
+type CaseQaReviewUsecase struct {
+	executorFactory    executor_factory.ExecutorFactory
+	transactionFactory executor_factory.TransactionFactory
+	enforceSecurity    caseQaReviewSecurity
+	repository         CaseQaReviewRepository
+	credentials        models.Credentials
+}
+
+// ListCaseQaReviews returns the reviews assigned to the user, or any review of an inbox that the user manages
+func (uc CaseQaReviewUsecase) ListCaseQaReviews(ctx context.Context,
+	filters models.CaseQaReviewFilters,
+) ([]models.CaseQaReview, error) {
+	exec := uc.executorFactory.NewExecutor()
+	filters.OrganizationId = uc.credentials.OrganizationId
+	userId := string(uc.credentials.ActorIdentity.UserId)
+	if filters.ReviewerId == nil || *filters.ReviewerId != userId {
+		if err := uc.checkQaManager(ctx, exec, filters.InboxId); err != nil {
+			return nil, err
+		}
+	}
+
+	return uc.repository.ListCaseQaReviews(ctx, exec, filters)
+}
+
+func (uc CaseQaReviewUsecase) GetCaseQaReview(ctx context.Context, id string) (models.CaseQaReview, error) {
+	exec := uc.executorFactory.NewExecutor()
+	review, err := uc.getCaseQaReview(ctx, exec, id)
+	if err != nil {
+		return models.CaseQaReview{}, err
+	}
+	if review.ReviewerId != string(uc.credentials.ActorIdentity.UserId) {
+		if err := uc.checkQaManager(ctx, exec, &review.InboxId); err != nil {
+			return models.CaseQaReview{}, err
+		}
+	}
+	return review, nil
+}
+
+// CompleteCaseQaReview records whether the reviewer agrees with the outcome of the case. Only the reviewer the review is
+// assigned to can complete it, and a review cannot be changed once completed.
+func (uc CaseQaReviewUsecase) CompleteCaseQaReview(ctx context.Context, id string,
+	input models.CaseQaReviewCompletion,
+) (models.CaseQaReview, error) {
+	userId := string(uc.credentials.ActorIdentity.UserId)
+
+	return executor_factory.TransactionReturnValue(ctx, uc.transactionFactory, func(
+		tx repositories.Transaction,
+	) (models.CaseQaReview, error) {
+		review, err := uc.getCaseQaReview(ctx, tx, id)
+		if err != nil {
+			return models.CaseQaReview{}, err
+		}
+		if review.ReviewerId != userId {
+			return models.CaseQaReview{}, errors.Wrap(models.ForbiddenError,
+				"the QA review is assigned to another reviewer")
+		}
+		if err := input.Validate(review); err != nil {
+			return models.CaseQaReview{}, err
+		}
+
+		completed, err := uc.repository.CompleteCaseQaReview(ctx, tx, review.Id, input)
+		if err != nil {
+			return models.CaseQaReview{}, err
+		}
+		if completed == nil {
+			return models.CaseQaReview{}, errors.Wrap(models.ConflictError, "the QA review is already completed")
+		}
+
+		event := models.CreateCaseEventAttributes{
+			CaseId:        review.CaseId,
+			UserId:        &userId,
+			EventType:     models.CaseQaReviewed,
+			NewValue:      utils.Ptr(string(input.Verdict)),
+			PreviousValue: utils.Ptr(string(review.CaseOutcome)),
+			ResourceId:    &review.Id,
+			ResourceType:  utils.Ptr(models.CaseQaReviewResourceType),
+		}
+		if input.Comment != "" {
+			event.AdditionalNote = &input.Comment
+		}
+		if err := uc.repository.CreateCaseEvent(ctx, tx, event); err != nil {
+			return models.CaseQaReview{}, err
+		}
+
+		return *completed, nil
+	})
+}
+
+// ListCaseQaScores returns the QA scores of the analysts of an inbox, or of the organization if no inbox is given
+func (uc CaseQaReviewUsecase) ListCaseQaScores(ctx context.Context,
+	filters models.CaseQaScoreFilters,
+) ([]models.CaseQaScore, error) {
+	exec := uc.executorFactory.NewExecutor()
+	filters.OrganizationId = uc.credentials.OrganizationId
+	if err := uc.checkQaManager(ctx, exec, filters.InboxId); err != nil {
+		return nil, err
+	}
+	if !filters.StartDate.Before(filters.EndDate) {
+		return nil, errors.Wrap(models.BadParameterError, "the start date must be before the end date")
+	}
+
+	return uc.repository.ListCaseQaScores(ctx, exec, filters)
+}
+
+func (uc CaseQaReviewUsecase) getCaseQaReview(ctx context.Context, exec repositories.Executor,
+	id string,
+) (models.CaseQaReview, error) {
+	review, err := uc.repository.GetCaseQaReview(ctx, exec, id)
+	if err != nil {
+		return models.CaseQaReview{}, err
+	}
+	if review.OrganizationId != uc.credentials.OrganizationId {
+		return models.CaseQaReview{}, errors.Wrapf(models.NotFoundError, "QA review %s not found", id)
+	}
+	return review, nil
+}
+
+// checkQaManager checks that the user manages the QA of an inbox as one of its admins, or the QA of every inbox as an
+// organization admin if no inbox is given
+func (uc CaseQaReviewUsecase) checkQaManager(ctx context.Context, exec repositories.Executor, inboxId *string) error {
+	if inboxId == nil {
+		return errors.Join(uc.enforceSecurity.Permission(models.INBOX_EDITOR),
+			uc.enforceSecurity.ReadOrganization(uc.credentials.OrganizationId))
+	}
+
+	inbox, err := uc.repository.GetInboxById(ctx, exec, *inboxId)
+	if err != nil {
+		return err
+	}
+	if inbox.OrganizationId != uc.credentials.OrganizationId {
+		return errors.Wrapf(models.NotFoundError, "inbox %s not found", *inboxId)
+	}
+	return uc.enforceSecurity.UpdateInbox(inbox)
+}
@@ -0,0 +1,148 @@
+package usecases
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/repositories"
+	"github.com/checkmarble/marble-backend/usecases/executor_factory"
+)
+
+// ListCaseSavedViews returns the views of the user, and the views shared with the inboxes they can access
+func (usecase *CaseUseCase) ListCaseSavedViews(ctx context.Context, organizationId, userId string,
+) ([]models.CaseSavedView, error) {
+	exec := usecase.executorFactory.NewExecutor()
+	availableInboxIds, err := usecase.getAvailableInboxIds(ctx, exec, organizationId)
+	if err != nil {
+		return nil, err
+	}
+
+	return usecase.repository.ListCaseSavedViews(ctx, exec, organizationId, userId, availableInboxIds)
+}
+
+func (usecase *CaseUseCase) CreateCaseSavedView(ctx context.Context, input models.CaseSavedViewInput,
+) (models.CaseSavedView, error) {
+	input.Name = strings.TrimSpace(input.Name)
+	if input.Name == "" {
+		return models.CaseSavedView{}, errors.Wrap(models.BadParameterError, "the name of the view is required")
+	}
+	if err := validateCaseSavedViewSorting(input.Sorting, input.Order); err != nil {
+		return models.CaseSavedView{}, err
+	}
+	if err := input.Filters.Validate(); err != nil {
+		return models.CaseSavedView{}, err
+	}
+
+	return executor_factory.TransactionReturnValue(ctx, usecase.transactionFactory, func(
+		tx repositories.Transaction,
+	) (models.CaseSavedView, error) {
+		availableInboxIds, err := usecase.getAvailableInboxIds(ctx, tx, input.OrganizationId)
+		if err != nil {
+			return models.CaseSavedView{}, err
+		}
+		if input.InboxId != nil && !slices.Contains(availableInboxIds, *input.InboxId) {
+			return models.CaseSavedView{}, errors.Wrapf(models.ForbiddenError,
+				"inbox %s is not accessible", *input.InboxId)
+		}
+
+		return usecase.repository.CreateCaseSavedView(ctx, tx, input)
+	})
+}
+
+// UpdateCaseSavedView edits a view. Only its owner can edit it.
+func (usecase *CaseUseCase) UpdateCaseSavedView(ctx context.Context, organizationId, userId, viewId string,
+	input models.UpdateCaseSavedViewInput,
+) (models.CaseSavedView, error) {
+	if input.Name != nil {
+		name := strings.TrimSpace(*input.Name)
+		if name == "" {
+			return models.CaseSavedView{}, errors.Wrap(models.BadParameterError, "the name of the view is required")
+		}
+		input.Name = &name
+	}
+	if input.Filters != nil {
+		if err := input.Filters.Validate(); err != nil {
+			return models.CaseSavedView{}, err
+		}
+	}
+
+	return executor_factory.TransactionReturnValue(ctx, usecase.transactionFactory, func(
+		tx repositories.Transaction,
+	) (models.CaseSavedView, error) {
+		availableInboxIds, err := usecase.getAvailableInboxIds(ctx, tx, organizationId)
+		if err != nil {
+			return models.CaseSavedView{}, err
+		}
+		view, err := usecase.getOwnedCaseSavedView(ctx, tx, organizationId, userId, viewId, availableInboxIds)
+		if err != nil {
+			return models.CaseSavedView{}, err
+		}
+
+		sorting, order := view.Sorting, view.Order
+		if input.Sorting != nil {
+			sorting = *input.Sorting
+		}
+		if input.Order != nil {
+			order = *input.Order
+		}
+		if err := validateCaseSavedViewSorting(sorting, order); err != nil {
+			return models.CaseSavedView{}, err
+		}
+		if input.InboxId != nil && *input.InboxId != "" && !slices.Contains(availableInboxIds, *input.InboxId) {
+			return models.CaseSavedView{}, errors.Wrapf(models.ForbiddenError,
+				"inbox %s is not accessible", *input.InboxId)
+		}
+
+		return usecase.repository.UpdateCaseSavedView(ctx, tx, view.Id, input)
+	})
+}
+
+// DeleteCaseSavedView deletes a view. Only its owner can delete it.
+func (usecase *CaseUseCase) DeleteCaseSavedView(ctx context.Context, organizationId, userId, viewId string) error {
+	return usecase.transactionFactory.Transaction(ctx, func(tx repositories.Transaction) error {
+		availableInboxIds, err := usecase.getAvailableInboxIds(ctx, tx, organizationId)
+		if err != nil {
+			return err
+		}
+		view, err := usecase.getOwnedCaseSavedView(ctx, tx, organizationId, userId, viewId, availableInboxIds)
+		if err != nil {
+			return err
+		}
+
+		return usecase.repository.DeleteCaseSavedView(ctx, tx, view.Id)
+	})
+}
+
+// getOwnedCaseSavedView returns a view that the user owns. A view the user cannot see is reported as not found, and a
+// view shared with them by someone else as forbidden.
+func (usecase *CaseUseCase) getOwnedCaseSavedView(ctx context.Context, exec repositories.Executor,
+	organizationId, userId, viewId string, availableInboxIds []string,
+) (models.CaseSavedView, error) {
+	view, err := usecase.repository.GetCaseSavedViewById(ctx, exec, viewId)
+	if err != nil {
+		return models.CaseSavedView{}, err
+	}
+	visible := view.OwnerId == userId || (view.InboxId != nil && slices.Contains(availableInboxIds, *view.InboxId))
+	if view.OrganizationId != organizationId || !visible {
+		return models.CaseSavedView{}, errors.Wrapf(models.NotFoundError, "case view %s not found", viewId)
+	}
+	if view.OwnerId != userId {
+		return models.CaseSavedView{}, errors.Wrap(models.ForbiddenError, "only the owner of a view can edit it")
+	}
+	return view, nil
+}
+
+func validateCaseSavedViewSorting(sorting models.SortingField, order models.SortingOrder) error {
+	if sorting != models.CasesSortingCreatedAt && sorting != models.CasesSortingDueAt {
+		return errors.Wrapf(models.BadParameterError,
+			"cases can only be sorted by created_at or due_at, received %s", sorting)
+	}
+	if order == models.SortingOrderUnknown {
+		return errors.Wrapf(models.BadParameterError, "order must be either ASC or DESC, received %s", order)
+	}
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"math/rand/v2"
 	"mime/multipart"
 	"slices"
 	"strings"
@@ -63,10 +64,45 @@ type CaseUseCaseRepository interface {
 
 	EscalateCase(ctx context.Context, exec repositories.Executor, id, inboxId string) error
 
+	MoveCaseContent(ctx context.Context, exec repositories.Executor,
+		organizationId, sourceCaseId, targetCaseId string) error
+	CloseMergedCase(ctx context.Context, exec repositories.Executor, caseId, targetCaseId string) error
+
 	GetCasesWithPivotValue(ctx context.Context, exec repositories.Executor,
 		orgId, pivotValue string) ([]models.Case, error)
 
 	GetNextCase(ctx context.Context, exec repositories.Executor, c models.Case) (string, error)
+
+	CreateCaseComment(ctx context.Context, exec repositories.Executor,
+		input models.CaseCommentCreate) (models.CaseComment, error)
+	GetCaseCommentById(ctx context.Context, exec repositories.Executor, id string,
+		forUpdate bool) (models.CaseComment, error)
+	ListCaseComments(ctx context.Context, exec repositories.Executor, caseId string) ([]models.CaseComment, error)
+	UpdateCaseComment(ctx context.Context, exec repositories.Executor, comment models.CaseComment,
+		editedBy string, update models.UpdateCaseCommentAttributes) (models.CaseComment, error)
+	DeleteCaseComment(ctx context.Context, exec repositories.Executor, id string) (models.CaseComment, error)
+	ListCaseCommentVersions(ctx context.Context, exec repositories.Executor,
+		commentId string) ([]models.CaseCommentVersion, error)
+
+	CreateCaseSavedView(ctx context.Context, exec repositories.Executor,
+		input models.CaseSavedViewInput) (models.CaseSavedView, error)
+	GetCaseSavedViewById(ctx context.Context, exec repositories.Executor, id string) (models.CaseSavedView, error)
+	ListCaseSavedViews(ctx context.Context, exec repositories.Executor, organizationId, userId string,
+		inboxIds []string) ([]models.CaseSavedView, error)
+	UpdateCaseSavedView(ctx context.Context, exec repositories.Executor, id string,
+		input models.UpdateCaseSavedViewInput) (models.CaseSavedView, error)
+	DeleteCaseSavedView(ctx context.Context, exec repositories.Executor, id string) error
+
+	GetInboxCaseWorkflow(ctx context.Context, exec repositories.Executor,
+		inboxId string) (*models.InboxCaseWorkflow, error)
+	ListSuspiciousActivityReportsByCaseId(ctx context.Context, exec repositories.Executor,
+		caseId string) ([]models.SuspiciousActivityReport, error)
+
+	ListInboxQaRules(ctx context.Context, exec repositories.Executor, inboxId string) ([]models.InboxQaRule, error)
+	CountPendingCaseQaReviews(ctx context.Context, exec repositories.Executor,
+		reviewerIds []string) (map[string]int, error)
+	CreateCaseQaReview(ctx context.Context, exec repositories.Executor,
+		input models.CaseQaReviewCreate) (models.CaseQaReview, error)
 }
 
 type CaseUsecaseSanctionCheckRepository interface {
@@ -96,18 +132,20 @@ type caseUsecaseIngestedDataReader interface {
 }
 
 type CaseUseCase struct {
-	enforceSecurity         security.EnforceSecurityCase
-	repository              CaseUseCaseRepository
-	decisionRepository      repositories.DecisionRepository
-	inboxReader             inboxes.InboxReader
-	blobRepository          repositories.BlobRepository
-	userRepository          CaseUsecaseUserRepository
-	caseManagerBucketUrl    string
-	transactionFactory      executor_factory.TransactionFactory
-	executorFactory         executor_factory.ExecutorFactory
-	webhookEventsUsecase    webhookEventsUsecase
-	sanctionCheckRepository CaseUsecaseSanctionCheckRepository
-	ingestedDataReader      caseUsecaseIngestedDataReader
+	enforceSecurity          security.EnforceSecurityCase
+	repository               CaseUseCaseRepository
+	decisionRepository       repositories.DecisionRepository
+	inboxReader              inboxes.InboxReader
+	blobRepository           repositories.BlobRepository
+	fileScanner              repositories.FileScanner
+	userRepository           CaseUsecaseUserRepository
+	caseManagerBucketUrl     string
+	transactionFactory       executor_factory.TransactionFactory
+	executorFactory          executor_factory.ExecutorFactory
+	webhookEventsUsecase     webhookEventsUsecase
+	sanctionCheckRepository  CaseUsecaseSanctionCheckRepository
+	ingestedDataReader       caseUsecaseIngestedDataReader
+	caseAssignmentRepository caseAssignmentRepository
 }
 
 func (usecase *CaseUseCase) ListCases(
@@ -124,10 +162,25 @@ func (usecase *CaseUseCase) ListCases(
 	if err != nil {
 		return models.CaseListPage{}, err
 	}
+	outcomes, err := models.ValidateCaseOutcomes(filters.Outcomes)
+	if err != nil {
+		return models.CaseListPage{}, err
+	}
+	slaStatus, err := models.CaseSlaStatusFrom(filters.SlaStatus)
+	if err != nil {
+		return models.CaseListPage{}, err
+	}
+	if filters.MinScore != nil && filters.MaxScore != nil && *filters.MinScore > *filters.MaxScore {
+		return models.CaseListPage{}, errors.Wrap(models.BadParameterError, "min_score must be lower than max_score")
+	}
 
 	if err := models.ValidatePagination(pagination); err != nil {
 		return models.CaseListPage{}, err
 	}
+	if pagination.Sorting != models.CasesSortingCreatedAt && pagination.Sorting != models.CasesSortingDueAt {
+		return models.CaseListPage{}, errors.Wrapf(models.BadParameterError,
+			"invalid pagination: cases can only be sorted by created_at or due_at, received %s", pagination.Sorting)
+	}
 
 	return executor_factory.TransactionReturnValue(
 		ctx,
@@ -155,6 +208,16 @@ func (usecase *CaseUseCase) ListCases(
 				IncludeSnoozed:  filters.IncludeSnoozed,
 				ExcludeAssigned: filters.ExcludeAssigned,
 				AssigneeId:      filters.AssigneeId,
+				SlaBreached:     filters.SlaBreached,
+				DueBefore:       filters.DueBefore,
+				Query:           strings.TrimSpace(filters.Query),
+				TagIds:          filters.TagIds,
+				Outcomes:        outcomes,
+				ScenarioIds:     filters.ScenarioIds,
+				RuleIds:         filters.RuleIds,
+				MinScore:        filters.MinScore,
+				MaxScore:        filters.MaxScore,
+				SlaStatus:       slaStatus,
 			}
 			if len(filters.InboxIds) > 0 {
 				repoFilters.InboxIds = filters.InboxIds
@@ -294,9 +357,45 @@ func (usecase *CaseUseCase) CreateCase(
 		return models.Case{}, err
 	}
 
+	if len(createCaseAttributes.TagIds) > 0 {
+		if err := usecase.createInitialCaseTags(ctx, tx, newCaseId, createCaseAttributes.TagIds); err != nil {
+			return models.Case{}, err
+		}
+	}
+
 	return usecase.getCaseWithDetails(ctx, tx, newCaseId)
 }
 
+// createInitialCaseTags adds the tags set by a case routing rule to a new case. The tags that were deleted since the
+// rule was configured are ignored.
+func (usecase *CaseUseCase) createInitialCaseTags(ctx context.Context, tx repositories.Transaction,
+	caseId string, tagIds []string,
+) error {
+	addedTagIds := make([]string, 0, len(tagIds))
+	for _, tagId := range tagIds {
+		tag, err := usecase.repository.GetTagById(ctx, tx, tagId)
+		if err != nil {
+			return err
+		}
+		if tag.DeletedAt != nil {
+			continue
+		}
+		if err := usecase.repository.CreateCaseTag(ctx, tx, caseId, tagId); err != nil {
+			return err
+		}
+		addedTagIds = append(addedTagIds, tagId)
+	}
+
+	if len(addedTagIds) == 0 {
+		return nil
+	}
+	return usecase.repository.CreateCaseEvent(ctx, tx, models.CreateCaseEventAttributes{
+		CaseId:    caseId,
+		EventType: models.CaseTagsUpdated,
+		NewValue:  utils.Ptr(strings.Join(addedTagIds, ",")),
+	})
+}
+
 func (usecase *CaseUseCase) CreateCaseAsUser(
 	ctx context.Context,
 	organizationId string,
@@ -384,14 +483,16 @@ func (usecase *CaseUseCase) UpdateCase(
 			return models.Case{}, err
 		}
 
+		// a pending case is implicitly moved to investigating by any update, which every workflow allows
+		implicitStatus := false
 		if c.Status == models.CasePending && (updateCaseAttributes.Status == "" ||
 			updateCaseAttributes.Status == models.CasePending) {
 			updateCaseAttributes.Status = models.CaseInvestigating
+			implicitStatus = true
 		}
 
-		if updateCaseAttributes.Status != "" && !c.Status.CanTransition(updateCaseAttributes.Status) {
-			return c, errors.Wrap(models.BadParameterError,
-				fmt.Sprintf("invalid case status transition from %s to %s", c.Status, updateCaseAttributes.Status))
+		if err := usecase.validateCaseStatusUpdate(ctx, tx, c, updateCaseAttributes, implicitStatus); err != nil {
+			return c, err
 		}
 
 		if updateCaseAttributes.Outcome != "" && !slices.Contains(models.ValidCaseOutcomes, updateCaseAttributes.Outcome) {
@@ -415,6 +516,12 @@ func (usecase *CaseUseCase) UpdateCase(
 			return models.Case{}, err
 		}
 
+		if updateCaseAttributes.Status == models.CaseClosed && c.Status != models.CaseClosed {
+			if err := usecase.sampleCaseForQa(ctx, tx, c, updateCaseAttributes, userId); err != nil {
+				return models.Case{}, err
+			}
+		}
+
 		updatedCase, err := usecase.getCaseWithDetails(ctx, tx, updateCaseAttributes.Id)
 		if err != nil {
 			return models.Case{}, err
@@ -444,6 +551,207 @@ func (usecase *CaseUseCase) UpdateCase(
 	return updatedCase, nil
 }
 
+// validateCaseStatusUpdate checks a status change against the case workflow of the inbox of the case, or against the
+// default workflow if the inbox has none. A case moved to another inbox must have a status of the workflow of the new
+// inbox.
+func (usecase *CaseUseCase) validateCaseStatusUpdate(ctx context.Context, exec repositories.Executor,
+	c models.Case, updateCaseAttributes models.UpdateCaseAttributes, implicitStatus bool,
+) error {
+	newStatus := c.Status
+	if updateCaseAttributes.Status != "" {
+		newStatus = updateCaseAttributes.Status
+	}
+
+	workflow, err := usecase.repository.GetInboxCaseWorkflow(ctx, exec, c.InboxId)
+	if err != nil {
+		return err
+	}
+	if newStatus != c.Status && !implicitStatus {
+		if workflow == nil {
+			if !c.Status.CanTransition(newStatus) {
+				return errors.Wrap(models.BadParameterError,
+					fmt.Sprintf("invalid case status transition from %s to %s", c.Status, newStatus))
+			}
+		} else {
+			transition, ok := workflow.Transition(c.Status, newStatus)
+			if !ok {
+				return errors.Wrap(models.BadParameterError,
+					fmt.Sprintf("the case workflow of the inbox does not allow the transition from %s to %s",
+						c.Status, newStatus))
+			}
+			if err := usecase.checkCaseStatusTransition(ctx, exec, c, updateCaseAttributes, transition); err != nil {
+				return err
+			}
+		}
+	}
+
+	if updateCaseAttributes.InboxId != "" && updateCaseAttributes.InboxId != c.InboxId {
+		workflow, err = usecase.repository.GetInboxCaseWorkflow(ctx, exec, updateCaseAttributes.InboxId)
+		if err != nil {
+			return err
+		}
+	}
+	if !inboxHasCaseStatus(workflow, newStatus) {
+		return errors.Wrap(models.BadParameterError,
+			fmt.Sprintf("the status %s does not exist in the inbox of the case", newStatus))
+	}
+	return nil
+}
+
+// inboxHasCaseStatus tells whether a status exists in the case workflow of an inbox, or in the default workflow if the
+// inbox has none
+func inboxHasCaseStatus(workflow *models.InboxCaseWorkflow, status models.CaseStatus) bool {
+	if workflow == nil {
+		return slices.Contains([]models.CaseStatus{models.CasePending, models.CaseInvestigating, models.CaseClosed},
+			status)
+	}
+	return workflow.HasStatus(status)
+}
+
+// resetCaseStatusForInbox moves a case that enters another inbox back to investigating when the case workflow of the
+// inbox does not have the status of the case, and records the status change
+func (usecase *CaseUseCase) resetCaseStatusForInbox(ctx context.Context, tx repositories.Transaction,
+	c models.Case, inboxId string, userId *string,
+) error {
+	workflow, err := usecase.repository.GetInboxCaseWorkflow(ctx, tx, inboxId)
+	if err != nil {
+		return err
+	}
+	if inboxHasCaseStatus(workflow, c.Status) {
+		return nil
+	}
+
+	update := models.UpdateCaseAttributes{Id: c.Id, Status: models.CaseInvestigating}
+	if err := usecase.repository.UpdateCase(ctx, tx, update); err != nil {
+		return err
+	}
+	return usecase.repository.CreateCaseEvent(ctx, tx, models.CreateCaseEventAttributes{
+		CaseId:        c.Id,
+		UserId:        userId,
+		EventType:     models.CaseStatusUpdated,
+		NewValue:      utils.Ptr(string(models.CaseInvestigating)),
+		PreviousValue: (*string)(&c.Status),
+	})
+}
+
+// checkCaseStatusTransition checks the fields and the inbox role that a transition of a case workflow requires
+func (usecase *CaseUseCase) checkCaseStatusTransition(ctx context.Context, exec repositories.Executor,
+	c models.Case, updateCaseAttributes models.UpdateCaseAttributes, transition models.CaseStatusTransition,
+) error {
+	if transition.RequireOutcome {
+		outcome := c.Outcome
+		if updateCaseAttributes.Outcome != "" {
+			outcome = updateCaseAttributes.Outcome
+		}
+		if outcome == "" || outcome == models.CaseOutcomeUnset {
+			return errors.Wrap(models.BadParameterError,
+				fmt.Sprintf("an outcome is required to move the case to %s", transition.To))
+		}
+	}
+	if transition.RequireComment && strings.TrimSpace(updateCaseAttributes.Comment) == "" {
+		return errors.Wrap(models.BadParameterError,
+			fmt.Sprintf("a comment is required to move the case to %s", transition.To))
+	}
+	if transition.RequireSar {
+		sars, err := usecase.repository.ListSuspiciousActivityReportsByCaseId(ctx, exec, c.Id)
+		if err != nil {
+			return err
+		}
+		if len(sars) == 0 {
+			return errors.Wrap(models.BadParameterError,
+				fmt.Sprintf("a suspicious activity report is required to move the case to %s", transition.To))
+		}
+	}
+
+	if len(transition.AllowedRoles) == 0 || usecase.enforceSecurity.Permission(models.INBOX_EDITOR) == nil {
+		return nil
+	}
+	userId := usecase.enforceSecurity.UserId()
+	if userId != nil {
+		inbox, err := usecase.inboxReader.GetInboxById(ctx, c.InboxId)
+		if err != nil {
+			return err
+		}
+		for _, inboxUser := range inbox.InboxUsers {
+			if inboxUser.UserId == *userId && slices.Contains(transition.AllowedRoles, inboxUser.Role) {
+				return nil
+			}
+		}
+	}
+	return errors.Wrap(models.ForbiddenError,
+		fmt.Sprintf("your role in the inbox does not allow to move the case to %s", transition.To))
+}
+
+// sampleCaseForQa creates the QA review of a case closed by a user, if the first QA rule of its inbox that matches the
+// case samples it. The review is assigned to the reviewer of the rule with the fewest pending reviews, other than the
+// analysts of the case.
+func (usecase *CaseUseCase) sampleCaseForQa(ctx context.Context, exec repositories.Executor,
+	c models.Case, updateCaseAttributes models.UpdateCaseAttributes, userId string,
+) error {
+	if userId == "" {
+		return nil
+	}
+	inboxId := c.InboxId
+	if updateCaseAttributes.InboxId != "" {
+		inboxId = updateCaseAttributes.InboxId
+	}
+	outcome := c.Outcome
+	if updateCaseAttributes.Outcome != "" {
+		outcome = updateCaseAttributes.Outcome
+	}
+
+	rules, err := usecase.repository.ListInboxQaRules(ctx, exec, inboxId)
+	if err != nil || len(rules) == 0 {
+		return err
+	}
+	tags, err := usecase.repository.ListCaseTagsByCaseId(ctx, exec, c.Id)
+	if err != nil {
+		return err
+	}
+	tagIds := pure_utils.Map(tags, func(t models.CaseTag) string { return t.TagId })
+	i := slices.IndexFunc(rules, func(r models.InboxQaRule) bool { return r.Matches(outcome, tagIds) })
+	if i < 0 || rand.Float64()*100 >= rules[i].SampleRate {
+		return nil
+	}
+	rule := rules[i]
+
+	pendingReviews, err := usecase.repository.CountPendingCaseQaReviews(ctx, exec, rule.ReviewerIds)
+	if err != nil {
+		return err
+	}
+	excluded := []string{userId}
+	if c.AssignedTo != nil {
+		excluded = append(excluded, string(*c.AssignedTo))
+	}
+	reviewerId, ok := rule.PickQaReviewer(pendingReviews, excluded...)
+	if !ok {
+		utils.LoggerFromContext(ctx).WarnContext(ctx, "no reviewer available for the QA review of a case",
+			"case_id", c.Id, "qa_rule_id", rule.Id)
+		return nil
+	}
+
+	review, err := usecase.repository.CreateCaseQaReview(ctx, exec, models.CaseQaReviewCreate{
+		OrganizationId: c.OrganizationId,
+		InboxId:        inboxId,
+		CaseId:         c.Id,
+		RuleId:         rule.Id,
+		AnalystId:      userId,
+		ReviewerId:     reviewerId,
+		CaseOutcome:    outcome,
+	})
+	if err != nil {
+		return err
+	}
+	return usecase.repository.CreateCaseEvent(ctx, exec, models.CreateCaseEventAttributes{
+		CaseId:       c.Id,
+		UserId:       &userId,
+		EventType:    models.CaseQaReviewRequested,
+		NewValue:     &reviewerId,
+		ResourceId:   &review.Id,
+		ResourceType: utils.Ptr(models.CaseQaReviewResourceType),
+	})
+}
+
 func (uc *CaseUseCase) Snooze(ctx context.Context, req models.CaseSnoozeRequest) error {
 	c, err := uc.repository.GetCaseById(ctx, uc.executorFactory.NewExecutor(), req.CaseId)
 	if err != nil {
@@ -683,12 +991,17 @@ func (usecase *CaseUseCase) updateCaseCreateEvents(ctx context.Context, exec rep
 
 	if updateCaseAttributes.Status != "" && updateCaseAttributes.Status != oldCase.Status {
 		newStatus := string(updateCaseAttributes.Status)
+		var comment *string
+		if updateCaseAttributes.Comment != "" {
+			comment = &updateCaseAttributes.Comment
+		}
 		err = usecase.repository.CreateCaseEvent(ctx, exec, models.CreateCaseEventAttributes{
-			CaseId:        updateCaseAttributes.Id,
-			UserId:        &userId,
-			EventType:     models.CaseStatusUpdated,
-			NewValue:      &newStatus,
-			PreviousValue: (*string)(&oldCase.Status),
+			CaseId:         updateCaseAttributes.Id,
+			UserId:         &userId,
+			EventType:      models.CaseStatusUpdated,
+			NewValue:       &newStatus,
+			PreviousValue:  (*string)(&oldCase.Status),
+			AdditionalNote: comment,
 		})
 		if err != nil {
 			return err
@@ -787,73 +1100,6 @@ func (usecase *CaseUseCase) AddDecisionsToCase(ctx context.Context, userId, case
 	return updatedCase, nil
 }
 
-func (usecase *CaseUseCase) CreateCaseComment(ctx context.Context, userId string,
-	caseCommentAttributes models.CreateCaseCommentAttributes,
-) (models.Case, error) {
-	webhookEventId := uuid.New().String()
-
-	updatedCase, err := executor_factory.TransactionReturnValue(ctx, usecase.transactionFactory, func(
-		tx repositories.Transaction,
-	) (models.Case, error) {
-		c, err := usecase.repository.GetCaseById(ctx, tx, caseCommentAttributes.Id)
-		if err != nil {
-			return models.Case{}, err
-		}
-
-		availableInboxIds, err := usecase.getAvailableInboxIds(ctx, tx, c.OrganizationId)
-		if err != nil {
-			return models.Case{}, err
-		}
-		if err := usecase.enforceSecurity.ReadOrUpdateCase(c.GetMetadata(), availableInboxIds); err != nil {
-			return models.Case{}, err
-		}
-
-		if err := usecase.createCaseContributorIfNotExist(ctx, tx, caseCommentAttributes.Id, userId); err != nil {
-			return models.Case{}, err
-		}
-
-		err = usecase.repository.CreateCaseEvent(ctx, tx, models.CreateCaseEventAttributes{
-			CaseId:         caseCommentAttributes.Id,
-			UserId:         &userId,
-			EventType:      models.CaseCommentAdded,
-			AdditionalNote: &caseCommentAttributes.Comment,
-		})
-		if err != nil {
-			return models.Case{}, err
-		}
-
-		if err := usecase.PerformCaseActionSideEffects(ctx, tx, c); err != nil {
-			return models.Case{}, err
-		}
-
-		updatedCase, err := usecase.getCaseWithDetails(ctx, tx, caseCommentAttributes.Id)
-		if err != nil {
-			return models.Case{}, err
-		}
-
-		err = usecase.webhookEventsUsecase.CreateWebhookEvent(ctx, tx, models.WebhookEventCreate{
-			Id:             webhookEventId,
-			OrganizationId: updatedCase.OrganizationId,
-			EventContent:   models.NewWebhookEventCaseCommentCreated(updatedCase),
-		})
-		if err != nil {
-			return models.Case{}, err
-		}
-
-		return updatedCase, nil
-	})
-	if err != nil {
-		return models.Case{}, err
-	}
-
-	usecase.webhookEventsUsecase.SendWebhookEventAsync(ctx, webhookEventId)
-
-	tracking.TrackEvent(ctx, models.AnalyticsCaseCommentCreated, map[string]interface{}{
-		"case_id": updatedCase.Id,
-	})
-	return updatedCase, nil
-}
-
 func (usecase *CaseUseCase) CreateCaseTags(ctx context.Context, userId string,
 	caseTagAttributes models.CreateCaseTagsAttributes,
 ) (models.Case, error) {
@@ -1107,9 +1353,16 @@ func (usecase *CaseUseCase) CreateCaseFiles(ctx context.Context, input models.Cr
 	type uploadedFileMetadata struct {
 		fileReference string
 		fileName      string
+		scanStatus    models.FileScanStatus
 	}
 	uploadedFilesMetadata := make([]uploadedFileMetadata, 0, len(input.Files))
 	for _, fileHeader := range input.Files {
+		var scanStatus models.FileScanStatus
+		scanStatus, err = scanUploadedFile(ctx, usecase.fileScanner, fileHeader)
+		if err != nil {
+			break
+		}
+
 		newFileReference := fmt.Sprintf("%s/%s/%s", creds.OrganizationId, input.CaseId, uuid.NewString())
 		err = writeToBlobStorage(ctx, usecase, fileHeader, newFileReference)
 		if err != nil {
@@ -1119,6 +1372,7 @@ func (usecase *CaseUseCase) CreateCaseFiles(ctx context.Context, input models.Cr
 		uploadedFilesMetadata = append(uploadedFilesMetadata, uploadedFileMetadata{
 			fileReference: newFileReference,
 			fileName:      fileHeader.Filename,
+			scanStatus:    scanStatus,
 		})
 	}
 	if err != nil {
@@ -1153,6 +1407,7 @@ func (usecase *CaseUseCase) CreateCaseFiles(ctx context.Context, input models.Cr
 					CaseId:        input.CaseId,
 					FileName:      uploadedFile.fileName,
 					FileReference: uploadedFile.fileReference,
+					ScanStatus:    uploadedFile.scanStatus,
 				},
 			); err != nil {
 				return err
@@ -1199,28 +1454,6 @@ func (usecase *CaseUseCase) CreateCaseFiles(ctx context.Context, input models.Cr
 	return usecase.getCaseWithDetails(ctx, exec, input.CaseId)
 }
 
-func validateFileType(file multipart.FileHeader) error {
-	supportedFileTypes := []string{
-		"text/",
-		"application/vnd.ms-excel",
-		"application/vnd.openxmlformats-officedocument.",
-		"application/msword",
-		"application/zip",
-		"application/x-zip-compressed",
-		"application/pdf",
-		"image/",
-	}
-	errFileType := errors.Wrap(models.BadParameterError,
-		fmt.Sprintf("file type not supported: %s", file.Header.Get("Content-Type")))
-	for _, supportedFileType := range supportedFileTypes {
-		if strings.HasPrefix(file.Header.Get("Content-Type"), supportedFileType) {
-			return nil
-		}
-	}
-
-	return errFileType
-}
-
 func writeToBlobStorage(ctx context.Context, usecase *CaseUseCase, fileHeader multipart.FileHeader, newFileReference string) error {
 	writer, err := usecase.blobRepository.OpenStream(ctx, usecase.caseManagerBucketUrl, newFileReference, fileHeader.Filename)
 	if err != nil {
@@ -1260,6 +1493,9 @@ func (usecase *CaseUseCase) GetCaseFileUrl(ctx context.Context, caseFileId strin
 	if err := usecase.enforceSecurity.ReadOrUpdateCase(c.GetMetadata(), availableInboxIds); err != nil {
 		return "", err
 	}
+	if err := errIfQuarantined(cf.ScanStatus); err != nil {
+		return "", err
+	}
 
 	return usecase.blobRepository.GenerateSignedUrl(ctx, usecase.caseManagerBucketUrl, cf.FileReference)
 }
@@ -1309,7 +1545,7 @@ func (usecase *CaseUseCase) CreateRuleSnoozeEvent(ctx context.Context, tx reposi
 	err = usecase.webhookEventsUsecase.CreateWebhookEvent(ctx, tx, models.WebhookEventCreate{
 		Id:             input.WebhookEventId,
 		OrganizationId: updatedCase.OrganizationId,
-		EventContent:   models.NewWebhookEventCaseCommentCreated(updatedCase),
+		EventContent:   models.NewWebhookEventCaseCommentCreated(updatedCase.Id, nil),
 	})
 	if err != nil {
 		return err
@@ -1509,7 +1745,7 @@ func (uc *CaseUseCase) EscalateCase(ctx context.Context, caseId string) error {
 	if err != nil {
 		return err
 	}
-	if c.Status == models.CaseClosed {
+	if c.Status.IsFinalized() {
 		return errors.New("case is already closed, cannot escalate")
 	}
 
@@ -1546,6 +1782,15 @@ func (uc *CaseUseCase) EscalateCase(ctx context.Context, caseId string) error {
 	}
 
 	return uc.transactionFactory.Transaction(ctx, func(tx repositories.Transaction) error {
+		// the status is read again in the transaction, it may have changed since the checks
+		c, err := uc.repository.GetCaseById(ctx, tx, caseId)
+		if err != nil {
+			return err
+		}
+		if c.Status.IsFinalized() {
+			return errors.New("case is already closed, cannot escalate")
+		}
+
 		if err := uc.repository.EscalateCase(ctx, tx, caseId, targetInbox.Id); err != nil {
 			return errors.Wrap(err, "could not escalate case")
 		}
@@ -1561,8 +1806,13 @@ func (uc *CaseUseCase) EscalateCase(ctx context.Context, caseId string) error {
 		if err := uc.repository.CreateCaseEvent(ctx, tx, event); err != nil {
 			return err
 		}
+		if err := uc.resetCaseStatusForInbox(ctx, tx, c, targetInbox.Id, userId); err != nil {
+			return err
+		}
 
-		return nil
+		// the escalation unassigns the case
+		c.AssignedTo = nil
+		return uc.AutoAssignCase(ctx, tx, c, targetInbox.Id)
 	})
 }
 
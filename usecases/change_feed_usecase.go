@@ -0,0 +1,94 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/repositories"
+	"github.com/checkmarble/marble-backend/usecases/executor_factory"
+	"github.com/checkmarble/marble-backend/usecases/security"
+)
+
+const changeFeedPollInterval = time.Second
+
+type ChangeFeedRepository interface {
+	ListChangeEvents(ctx context.Context, exec repositories.Executor, orgId string,
+		after models.ChangeFeedCursor, limit int) ([]models.ChangeEvent, error)
+	GetChangeFeedConfig(ctx context.Context, exec repositories.Executor, orgId string) (models.ChangeFeedConfig, error)
+	UpsertChangeFeedConfig(ctx context.Context, exec repositories.Executor,
+		input models.UpdateChangeFeedConfigInput) (models.ChangeFeedConfig, error)
+}
+
+type ChangeFeedUsecase struct {
+	enforceSecurity security.EnforceSecurityChangeFeed
+	executorFactory executor_factory.ExecutorFactory
+	repository      ChangeFeedRepository
+}
+
+// ReadChangeFeed returns the change events of the organization after the cursor. If there are none yet, it long-polls
+// for new events for up to the requested wait duration, and returns an empty page with the same cursor if none came.
+func (uc ChangeFeedUsecase) ReadChangeFeed(ctx context.Context, organizationId string, cursor string,
+	limit int, wait time.Duration,
+) (models.ChangeFeedPage, error) {
+	if err := uc.enforceSecurity.ReadChangeFeed(organizationId); err != nil {
+		return models.ChangeFeedPage{}, err
+	}
+	after, err := models.ParseChangeFeedCursor(cursor)
+	if err != nil {
+		return models.ChangeFeedPage{}, err
+	}
+	if limit <= 0 || limit > models.ChangeFeedMaxLimit {
+		limit = models.ChangeFeedDefaultLimit
+	}
+	wait = min(wait, models.ChangeFeedMaxWait)
+
+	exec := uc.executorFactory.NewExecutor()
+	deadline := time.Now().Add(wait)
+	for {
+		// fetch one more event to know if there are more to read
+		events, err := uc.repository.ListChangeEvents(ctx, exec, organizationId, after, limit+1)
+		if err != nil {
+			return models.ChangeFeedPage{}, err
+		}
+		if len(events) > 0 || !time.Now().Add(changeFeedPollInterval).Before(deadline) {
+			page := models.ChangeFeedPage{
+				Events:     events,
+				NextCursor: after,
+				HasMore:    len(events) > limit,
+			}
+			if page.HasMore {
+				page.Events = events[:limit]
+			}
+			if len(page.Events) > 0 {
+				page.NextCursor = page.Events[len(page.Events)-1].Cursor
+			}
+			return page, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return models.ChangeFeedPage{}, ctx.Err()
+		case <-time.After(changeFeedPollInterval):
+		}
+	}
+}
+
+func (uc ChangeFeedUsecase) GetChangeFeedConfig(ctx context.Context, organizationId string) (models.ChangeFeedConfig, error) {
+	if err := uc.enforceSecurity.ManageChangeFeed(organizationId); err != nil {
+		return models.ChangeFeedConfig{}, err
+	}
+	return uc.repository.GetChangeFeedConfig(ctx, uc.executorFactory.NewExecutor(), organizationId)
+}
+
+func (uc ChangeFeedUsecase) UpdateChangeFeedConfig(ctx context.Context,
+	input models.UpdateChangeFeedConfigInput,
+) (models.ChangeFeedConfig, error) {
+	if err := uc.enforceSecurity.ManageChangeFeed(input.OrgId); err != nil {
+		return models.ChangeFeedConfig{}, err
+	}
+	if err := input.Validate(); err != nil {
+		return models.ChangeFeedConfig{}, err
+	}
+	return uc.repository.UpsertChangeFeedConfig(ctx, uc.executorFactory.NewExecutor(), input)
+}
@@ -0,0 +1,92 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/checkmarble/marble-backend/mocks"
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/repositories"
+	"github.com/checkmarble/marble-backend/usecases/executor_factory"
+	"github.com/checkmarble/marble-backend/usecases/security"
+)
+
+type changeFeedRepositoryMock struct {
+	mock.Mock
+	ChangeFeedRepository
+}
+
+func (m *changeFeedRepositoryMock) ListChangeEvents(ctx context.Context, exec repositories.Executor,
+	orgId string, after models.ChangeFeedCursor, limit int,
+) ([]models.ChangeEvent, error) {
+	args := m.Called(orgId, after, limit)
+	return args.Get(0).([]models.ChangeEvent), args.Error(1)
+}
+
+func newChangeFeedUsecaseForTest() (ChangeFeedUsecase, *changeFeedRepositoryMock) {
+	enforceSecurity := &mocks.EnforceSecurity{}
+	enforceSecurity.On("Permission", mock.Anything).Return(nil)
+	enforceSecurity.On("ReadOrganization", "org_id").Return(nil)
+	repository := &changeFeedRepositoryMock{}
+
+	return ChangeFeedUsecase{
+		enforceSecurity: &security.EnforceSecurityChangeFeedImpl{EnforceSecurity: enforceSecurity},
+		executorFactory: executor_factory.NewExecutorFactoryStub(),
+		repository:      repository,
+	}, repository
+}
+
+func TestReadChangeFeed(t *testing.T) {
+	events := []models.ChangeEvent{
+		{Cursor: models.ChangeFeedCursor{TxId: 10, Id: 1}},
+		{Cursor: models.ChangeFeedCursor{TxId: 10, Id: 2}},
+		{Cursor: models.ChangeFeedCursor{TxId: 11, Id: 3}},
+	}
+
+	t.Run("page with more events", func(t *testing.T) {
+		uc, repository := newChangeFeedUsecaseForTest()
+		repository.On("ListChangeEvents", "org_id", models.ChangeFeedCursor{TxId: 9, Id: 5}, 3).Return(events, nil)
+
+		page, err := uc.ReadChangeFeed(context.Background(), "org_id", "9_5", 2, 0)
+		require.NoError(t, err)
+		assert.Equal(t, events[:2], page.Events)
+		assert.Equal(t, models.ChangeFeedCursor{TxId: 10, Id: 2}, page.NextCursor)
+		assert.True(t, page.HasMore)
+	})
+
+	t.Run("empty feed keeps the cursor", func(t *testing.T) {
+		uc, repository := newChangeFeedUsecaseForTest()
+		repository.On("ListChangeEvents", "org_id", models.ChangeFeedCursor{TxId: 11, Id: 3},
+			models.ChangeFeedDefaultLimit+1).Return([]models.ChangeEvent{}, nil)
+
+		page, err := uc.ReadChangeFeed(context.Background(), "org_id", "11_3", 0, 0)
+		require.NoError(t, err)
+		assert.Empty(t, page.Events)
+		assert.Equal(t, models.ChangeFeedCursor{TxId: 11, Id: 3}, page.NextCursor)
+		assert.False(t, page.HasMore)
+	})
+
+	t.Run("long polling until events arrive", func(t *testing.T) {
+		uc, repository := newChangeFeedUsecaseForTest()
+		repository.On("ListChangeEvents", "org_id", models.ChangeFeedCursor{}, 101).
+			Return([]models.ChangeEvent{}, nil).Once()
+		repository.On("ListChangeEvents", "org_id", models.ChangeFeedCursor{}, 101).
+			Return(events[:1], nil).Once()
+
+		page, err := uc.ReadChangeFeed(context.Background(), "org_id", "", 100, 5*time.Second)
+		require.NoError(t, err)
+		assert.Equal(t, events[:1], page.Events)
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		uc, _ := newChangeFeedUsecaseForTest()
+		_, err := uc.ReadChangeFeed(context.Background(), "org_id", "invalid", 10, 0)
+		assert.ErrorIs(t, err, models.BadParameterError)
+	})
+}
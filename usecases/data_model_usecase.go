@@ -154,7 +154,15 @@ func (usecase *DataModelUseCase) CreateDataModelTable(ctx context.Context, organ
 	return tableId, err
 }
 
-func (usecase *DataModelUseCase) UpdateDataModelTable(ctx context.Context, tableID, description string) error {
+func (usecase *DataModelUseCase) UpdateDataModelTable(
+	ctx context.Context,
+	tableID, description string,
+	decisionTriggerMode *models.DecisionTriggerMode,
+) error {
+	if decisionTriggerMode != nil && !decisionTriggerMode.IsValid() {
+		return errors.Wrapf(models.BadParameterError, "invalid decision trigger mode %s", *decisionTriggerMode)
+	}
+
 	exec := usecase.executorFactory.NewExecutor()
 	if table, err := usecase.dataModelRepository.GetDataModelTable(ctx, exec, tableID); err != nil {
 		return err
@@ -162,7 +170,7 @@ func (usecase *DataModelUseCase) UpdateDataModelTable(ctx context.Context, table
 		return err
 	}
 
-	return usecase.dataModelRepository.UpdateDataModelTable(ctx, exec, tableID, description)
+	return usecase.dataModelRepository.UpdateDataModelTable(ctx, exec, tableID, description, decisionTriggerMode)
 }
 
 func (usecase *DataModelUseCase) CreateDataModelField(ctx context.Context, field models.CreateFieldInput) (string, error) {
@@ -176,6 +184,9 @@ func (usecase *DataModelUseCase) CreateDataModelField(ctx context.Context, field
 		return "", errors.Wrap(models.BadParameterError,
 			"field name must only contain lower case alphanumeric characters and underscores, and start by a letter")
 	}
+	if err := field.Constraints.Validate(field.DataType, field.IsEnum); err != nil {
+		return "", err
+	}
 
 	fieldId := uuid.New().String()
 	var tableName string
@@ -309,6 +320,18 @@ func validateFieldUpdateRules(
 			"enum fields can only be of type string or numeric")
 	}
 
+	isEnum := field.IsEnum
+	if input.IsEnum != nil {
+		isEnum = *input.IsEnum
+	}
+	constraints := field.Constraints
+	if input.Constraints != nil {
+		constraints = *input.Constraints
+	}
+	if err := constraints.Validate(field.DataType, isEnum); err != nil {
+		return false, false, err
+	}
+
 	currentField := dataModel.Tables[table.Name].Fields[field.Name]
 	isUnique := currentField.UnicityConstraint != models.NoUnicityConstraint
 
@@ -411,10 +411,10 @@ func (suite *DatamodelUsecaseTestSuite) TestUpdateDataModelTable_nominal() {
 		Return(table, nil)
 	suite.enforceSecurity.On("WriteDataModel", suite.organizationId).Return(nil)
 	suite.dataModelRepository.On("UpdateDataModelTable",
-		suite.ctx, suite.transaction, tableId, "description").
+		suite.ctx, suite.transaction, tableId, "description", (*models.DecisionTriggerMode)(nil)).
 		Return(nil)
 
-	err := usecase.UpdateDataModelTable(suite.ctx, tableId, "description")
+	err := usecase.UpdateDataModelTable(suite.ctx, tableId, "description", nil)
 	suite.Require().NoError(err, "no error expected")
 
 	suite.AssertExpectations()
@@ -433,7 +433,7 @@ func (suite *DatamodelUsecaseTestSuite) TestUpdateDataModelTable_security_error(
 		Return(table, nil)
 	suite.enforceSecurity.On("WriteDataModel", suite.organizationId).Return(suite.securityError)
 
-	err := usecase.UpdateDataModelTable(suite.ctx, tableId, "description")
+	err := usecase.UpdateDataModelTable(suite.ctx, tableId, "description", nil)
 	suite.Require().Error(err, "error expected")
 	suite.Require().Equal(suite.securityError, err, "expected error should be returned")
 
@@ -453,10 +453,10 @@ func (suite *DatamodelUsecaseTestSuite) TestUpdateDataModelTable_repository_erro
 		Return(table, nil)
 	suite.enforceSecurity.On("WriteDataModel", suite.organizationId).Return(nil)
 	suite.dataModelRepository.On("UpdateDataModelTable",
-		suite.ctx, suite.transaction, tableId, "description").
+		suite.ctx, suite.transaction, tableId, "description", (*models.DecisionTriggerMode)(nil)).
 		Return(suite.repositoryError)
 
-	err := usecase.UpdateDataModelTable(suite.ctx, tableId, "description")
+	err := usecase.UpdateDataModelTable(suite.ctx, tableId, "description", nil)
 	suite.Require().Error(err, "error expected")
 	suite.Require().Equal(suite.repositoryError, err, "expected error should be returned")
 
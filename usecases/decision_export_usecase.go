@@ -0,0 +1,114 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/checkmarble/marble-backend/dto"
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/repositories"
+	"github.com/checkmarble/marble-backend/usecases/executor_factory"
+	"github.com/checkmarble/marble-backend/usecases/security"
+)
+
+type DecisionExportRepository interface {
+	CreateDecisionExport(ctx context.Context, exec repositories.Executor,
+		input models.DecisionExportCreate) (models.DecisionExport, error)
+	GetDecisionExport(ctx context.Context, exec repositories.Executor, id string) (models.DecisionExport, error)
+	ListDecisionExports(ctx context.Context, exec repositories.Executor, orgId string) ([]models.DecisionExport, error)
+}
+
+type decisionFiltersValidator interface {
+	ValidateDecisionFilters(ctx context.Context, organizationId string,
+		filters dto.DecisionFilters) (models.DecisionFilters, error)
+}
+
+type DecisionExportUsecase struct {
+	enforceSecurity     security.EnforceSecurityDecisionExport
+	executorFactory     executor_factory.ExecutorFactory
+	transactionFactory  executor_factory.TransactionFactory
+	repository          DecisionExportRepository
+	taskQueueRepository repositories.TaskQueueRepository
+	blobRepository      repositories.BlobRepository
+	filtersValidator    decisionFiltersValidator
+	bucketUrl           string
+	credentials         models.Credentials
+}
+
+// CreateDecisionExport validates the filters of the export and schedules the job that writes the matching decisions
+// to the export bucket.
+func (uc DecisionExportUsecase) CreateDecisionExport(ctx context.Context, organizationId string,
+	format models.DecisionExportFormat, filters dto.DecisionFilters,
+) (models.DecisionExport, error) {
+	if err := uc.enforceSecurity.ExportDecisions(organizationId); err != nil {
+		return models.DecisionExport{}, err
+	}
+	if uc.bucketUrl == "" {
+		return models.DecisionExport{}, errors.Wrap(models.BadParameterError,
+			"decision exports are not configured on this instance")
+	}
+	if format == models.DecisionExportFormatUnknown {
+		return models.DecisionExport{}, errors.Wrap(models.BadParameterError,
+			"export format must be one of csv, parquet")
+	}
+
+	decisionFilters, err := uc.filtersValidator.ValidateDecisionFilters(ctx, organizationId, filters)
+	if err != nil {
+		return models.DecisionExport{}, err
+	}
+
+	input := models.DecisionExportCreate{
+		OrganizationId:    organizationId,
+		Format:            format,
+		Filters:           decisionFilters,
+		RequestedByApiKey: uc.credentials.ActorIdentity.ApiKeyName,
+	}
+	if uc.credentials.ActorIdentity.UserId != "" {
+		input.RequestedByUserId = &uc.credentials.ActorIdentity.UserId
+	}
+
+	return executor_factory.TransactionReturnValue(ctx, uc.transactionFactory, func(
+		tx repositories.Transaction,
+	) (models.DecisionExport, error) {
+		export, err := uc.repository.CreateDecisionExport(ctx, tx, input)
+		if err != nil {
+			return models.DecisionExport{}, err
+		}
+		if err := uc.taskQueueRepository.EnqueueDecisionExportTask(ctx, tx, organizationId, export.Id); err != nil {
+			return models.DecisionExport{}, err
+		}
+		return export, nil
+	})
+}
+
+func (uc DecisionExportUsecase) ListDecisionExports(ctx context.Context, organizationId string) ([]models.DecisionExport, error) {
+	if err := uc.enforceSecurity.ExportDecisions(organizationId); err != nil {
+		return nil, err
+	}
+	return uc.repository.ListDecisionExports(ctx, uc.executorFactory.NewExecutor(), organizationId)
+}
+
+// GetDecisionExport returns an export, with signed urls to download its files once it has succeeded
+func (uc DecisionExportUsecase) GetDecisionExport(ctx context.Context, exportId string) (models.DecisionExportWithFiles, error) {
+	export, err := uc.repository.GetDecisionExport(ctx, uc.executorFactory.NewExecutor(), exportId)
+	if err != nil {
+		return models.DecisionExportWithFiles{}, err
+	}
+	if err := uc.enforceSecurity.ExportDecisions(export.OrganizationId); err != nil {
+		return models.DecisionExportWithFiles{}, err
+	}
+
+	result := models.DecisionExportWithFiles{DecisionExport: export}
+	if export.Status != models.DecisionExportSuccess {
+		return result, nil
+	}
+	for _, key := range export.FileKeys() {
+		url, err := uc.blobRepository.GenerateSignedUrl(ctx, uc.bucketUrl, key)
+		if err != nil {
+			return models.DecisionExportWithFiles{}, err
+		}
+		result.FileUrls = append(result.FileUrls, url)
+	}
+	return result, nil
+}
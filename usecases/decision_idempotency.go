@@ -0,0 +1,125 @@
+package usecases
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"time"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/repositories"
+)
+
+type decisionIdempotencyRepository interface {
+	GetDecisionIdempotencyKey(ctx context.Context, exec repositories.Executor,
+		orgId, actor, key string) (models.DecisionIdempotencyKey, error)
+	CreateDecisionIdempotencyKey(ctx context.Context, exec repositories.Executor,
+		input models.DecisionIdempotencyKeyCreate) (bool, error)
+}
+
+// decisionRequestHash identifies the content of a decision creation request. The payload is normalized so that
+// retries that only differ in formatting or in the order of the fields are considered identical.
+func decisionRequestHash(endpoint, scenarioId, triggerObjectTable string, payloadRaw json.RawMessage,
+	readAsOf *time.Time,
+) ([]byte, error) {
+	request := struct {
+		Endpoint           string     `json:"endpoint"`
+		ScenarioId         string     `json:"scenario_id"`
+		TriggerObjectTable string     `json:"trigger_object_table"`
+		Payload            any        `json:"payload"`
+		ReadAsOf           *time.Time `json:"read_as_of"`
+	}{
+		Endpoint:           endpoint,
+		ScenarioId:         scenarioId,
+		TriggerObjectTable: triggerObjectTable,
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(payloadRaw))
+	decoder.UseNumber()
+	if err := decoder.Decode(&request.Payload); err != nil {
+		return nil, errors.Wrap(models.BadParameterError, "could not parse the trigger object")
+	}
+	if readAsOf != nil {
+		asOf := readAsOf.UTC()
+		request.ReadAsOf = &asOf
+	}
+
+	serialized, err := json.Marshal(request)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not serialize the decision request")
+	}
+	hash := sha256.Sum256(serialized)
+	return hash[:], nil
+}
+
+func validateIdempotencyKey(key string, creds models.Credentials) error {
+	if len(key) > models.IdempotencyKeyMaxLength {
+		return errors.Wrapf(models.BadParameterError,
+			"idempotency key must be at most %d characters long", models.IdempotencyKeyMaxLength)
+	}
+	_, err := models.IdempotencyKeyActor(creds)
+	return err
+}
+
+// previousIdempotentRequest returns the outcome of a previous request sent with the same idempotency key, or nil if the
+// key was not used in the idempotency window. Reusing a key with a different payload is a conflict.
+func (usecase *DecisionUsecase) previousIdempotentRequest(ctx context.Context, exec repositories.Executor,
+	organizationId, key string, requestHash []byte,
+) (*models.DecisionIdempotencyKey, error) {
+	actor, err := models.IdempotencyKeyActor(usecase.credentials)
+	if err != nil {
+		return nil, err
+	}
+	previous, err := usecase.idempotencyRepository.GetDecisionIdempotencyKey(ctx, exec,
+		organizationId, actor, key)
+	if errors.Is(err, models.NotFoundError) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Wrap(err, "error reading idempotency key")
+	}
+
+	if !bytes.Equal(previous.RequestHash, requestHash) {
+		return nil, errors.Wrapf(models.ConflictError,
+			"idempotency key %s was already used with a different request", key)
+	}
+	return &previous, nil
+}
+
+// saveIdempotencyKey records the outcome of a request. Called in the transaction that stores the decisions, it also
+// prevents concurrent requests with the same key from creating duplicates: the second one waits for the first one to
+// commit, and then fails with a conflict.
+func (usecase *DecisionUsecase) saveIdempotencyKey(ctx context.Context, exec repositories.Executor,
+	organizationId, key string, requestHash []byte, triggerPassed bool, decisionIds []string, nbSkipped int,
+) error {
+	ttl := usecase.idempotencyKeyTtl
+	if ttl == 0 {
+		ttl = models.DefaultIdempotencyKeyTtl
+	}
+
+	actor, err := models.IdempotencyKeyActor(usecase.credentials)
+	if err != nil {
+		return err
+	}
+	created, err := usecase.idempotencyRepository.CreateDecisionIdempotencyKey(ctx, exec,
+		models.DecisionIdempotencyKeyCreate{
+			OrganizationId: organizationId,
+			Actor:          actor,
+			Key:            key,
+			RequestHash:    requestHash,
+			TriggerPassed:  triggerPassed,
+			DecisionIds:    decisionIds,
+			NbSkipped:      nbSkipped,
+			ExpiresAt:      time.Now().Add(ttl),
+		})
+	if err != nil {
+		return errors.Wrap(err, "error saving idempotency key")
+	}
+	if !created {
+		return errors.Wrapf(models.ConflictError,
+			"a request with the idempotency key %s is already being processed", key)
+	}
+	return nil
+}
@@ -0,0 +1,163 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/checkmarble/marble-backend/mocks"
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/models/ast"
+	"github.com/checkmarble/marble-backend/repositories"
+	"github.com/checkmarble/marble-backend/usecases/executor_factory"
+	"github.com/checkmarble/marble-backend/utils"
+)
+
+func TestDecisionRequestHash(t *testing.T) {
+	asOf := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	reference, err := decisionRequestHash("decision", "scenario_id", "transactions",
+		[]byte(`{"object_id": "1", "amount": 10.50}`), &asOf)
+	assert.NoError(t, err)
+
+	t.Run("same request with different formatting", func(t *testing.T) {
+		paris := time.FixedZone("Europe/Paris", 3600)
+		hash, err := decisionRequestHash("decision", "scenario_id", "transactions",
+			[]byte(`{"amount":10.50,"object_id":"1"}`), utils.Ptr(asOf.In(paris)))
+		assert.NoError(t, err)
+		assert.Equal(t, reference, hash)
+	})
+
+	t.Run("different payload", func(t *testing.T) {
+		hash, err := decisionRequestHash("decision", "scenario_id", "transactions",
+			[]byte(`{"object_id": "1", "amount": 10.5}`), &asOf)
+		assert.NoError(t, err)
+		assert.NotEqual(t, reference, hash)
+	})
+
+	t.Run("different endpoint", func(t *testing.T) {
+		hash, err := decisionRequestHash("decisions/all", "scenario_id", "transactions",
+			[]byte(`{"object_id": "1", "amount": 10.50}`), &asOf)
+		assert.NoError(t, err)
+		assert.NotEqual(t, reference, hash)
+	})
+
+	t.Run("invalid payload", func(t *testing.T) {
+		_, err := decisionRequestHash("decision", "scenario_id", "transactions", []byte(`{`), nil)
+		assert.ErrorIs(t, err, models.BadParameterError)
+	})
+}
+
+func TestIdempotencyKeyActor(t *testing.T) {
+	actor, err := models.IdempotencyKeyActor(models.Credentials{
+		ActorIdentity: models.Identity{ApiKeyId: "key_id", ApiKeyName: "Api key abc***"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "api_key:key_id", actor)
+
+	actor, err = models.IdempotencyKeyActor(models.Credentials{
+		ActorIdentity: models.Identity{UserId: "user_id"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "user:user_id", actor)
+
+	// legacy tokens that do not identify their API key would share their scope with the other keys of the organization
+	_, err = models.IdempotencyKeyActor(models.Credentials{
+		OrganizationId: "org_id",
+		ActorIdentity:  models.Identity{ApiKeyName: "Api key abc***"},
+	})
+	assert.ErrorIs(t, err, models.UnAuthorizedError)
+	assert.ErrorIs(t, validateIdempotencyKey("key", models.Credentials{OrganizationId: "org_id"}),
+		models.UnAuthorizedError)
+}
+
+// fakeIdempotentDecisionRepository holds a decision that was created by a previous request with an idempotency key
+type fakeIdempotentDecisionRepository struct {
+	DecisionUsecaseRepository
+
+	decision models.DecisionWithRuleExecutions
+	key      models.DecisionIdempotencyKey
+}
+
+func (r *fakeIdempotentDecisionRepository) GetScenarioById(ctx context.Context, exec repositories.Executor,
+	scenarioId string,
+) (models.Scenario, error) {
+	return models.Scenario{Id: scenarioId, OrganizationId: "org_id"}, nil
+}
+
+func (r *fakeIdempotentDecisionRepository) DecisionWithRuleExecutionsById(ctx context.Context,
+	exec repositories.Executor, decisionId string,
+) (models.DecisionWithRuleExecutions, error) {
+	decision := r.decision
+	decision.RuleExecutions = append([]models.RuleExecution(nil), r.decision.RuleExecutions...)
+	return decision, nil
+}
+
+func (r *fakeIdempotentDecisionRepository) GetDecisionIdempotencyKey(ctx context.Context,
+	exec repositories.Executor, orgId, actor, key string,
+) (models.DecisionIdempotencyKey, error) {
+	return r.key, nil
+}
+
+func (r *fakeIdempotentDecisionRepository) CreateDecisionIdempotencyKey(ctx context.Context,
+	exec repositories.Executor, input models.DecisionIdempotencyKeyCreate,
+) (bool, error) {
+	return false, nil
+}
+
+func TestCreateDecision_idempotent_replay_rule_execution_details(t *testing.T) {
+	payload := json.RawMessage(`{"object_id": "1"}`)
+	requestHash, err := decisionRequestHash("decision", "scenario_id", "transactions", payload, nil)
+	require.NoError(t, err)
+
+	repo := &fakeIdempotentDecisionRepository{
+		decision: models.DecisionWithRuleExecutions{
+			Decision: models.Decision{DecisionId: "decision_id"},
+			RuleExecutions: []models.RuleExecution{
+				{Id: "rule_execution_id", Evaluation: &ast.NodeEvaluationDto{}},
+			},
+		},
+		key: models.DecisionIdempotencyKey{
+			RequestHash:   requestHash,
+			TriggerPassed: true,
+			DecisionIds:   []string{"decision_id"},
+		},
+	}
+	enforceSecurity := new(mocks.EnforceSecurity)
+	enforceSecurity.On("CreateDecision", mock.Anything).Return(nil)
+	usecase := DecisionUsecase{
+		enforceSecurity:       enforceSecurity,
+		executorFactory:       executor_factory.NewExecutorFactoryStub(),
+		repository:            repo,
+		idempotencyRepository: repo,
+		credentials: models.Credentials{
+			OrganizationId: "org_id",
+			ActorIdentity:  models.Identity{ApiKeyId: "key_id"},
+		},
+	}
+	input := models.CreateDecisionInput{
+		OrganizationId:     "org_id",
+		PayloadRaw:         payload,
+		ScenarioId:         "scenario_id",
+		TriggerObjectTable: "transactions",
+		IdempotencyKey:     "key",
+	}
+
+	triggerPassed, decision, err := usecase.CreateDecision(context.Background(), input,
+		models.CreateDecisionParams{WithRuleExecutionDetails: false})
+	require.NoError(t, err)
+	assert.True(t, triggerPassed)
+	assert.Equal(t, "decision_id", decision.DecisionId)
+	require.Len(t, decision.RuleExecutions, 1)
+	assert.Nil(t, decision.RuleExecutions[0].Evaluation, "the replay does not return the rule evaluations")
+
+	_, decision, err = usecase.CreateDecision(context.Background(), input,
+		models.CreateDecisionParams{WithRuleExecutionDetails: true})
+	require.NoError(t, err)
+	assert.NotNil(t, decision.RuleExecutions[0].Evaluation)
+}
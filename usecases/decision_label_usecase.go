@@ -0,0 +1,272 @@
+package usecases
+
+import (
+	"context"
+	"encoding/csv"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/pure_utils"
+	"github.com/checkmarble/marble-backend/repositories"
+	"github.com/checkmarble/marble-backend/usecases/executor_factory"
+	"github.com/checkmarble/marble-backend/usecases/security"
+)
+
+const (
+	DecisionLabelsMaxBatchSize   = 1000
+	decisionLabelExportBatchSize = 1000
+)
+
+type DecisionLabelRepository interface {
+	GetDataModel(ctx context.Context, exec repositories.Executor, organizationID string,
+		fetchEnumValues bool) (models.DataModel, error)
+	GetScenarioById(ctx context.Context, exec repositories.Executor, scenarioId string) (models.Scenario, error)
+	DecisionsById(ctx context.Context, exec repositories.Executor, decisionIds []string) ([]models.Decision, error)
+	DecisionsOfOrganization(ctx context.Context, exec repositories.Executor, organizationId string,
+		paginationAndSorting models.PaginationAndSorting, filters models.DecisionFilters) ([]models.Decision, error)
+
+	CreateDecisionLabels(ctx context.Context, exec repositories.Executor,
+		inputs []models.CreateDecisionLabelInput) ([]models.DecisionLabel, error)
+	GetDecisionLabel(ctx context.Context, exec repositories.Executor, id string) (models.DecisionLabel, error)
+	DeleteDecisionLabel(ctx context.Context, exec repositories.Executor, id string) error
+	ListLabelsOfDecisions(ctx context.Context, exec repositories.Executor,
+		decisionIds []string) (map[string][]models.DecisionLabel, error)
+	ListTriggeredRuleNamesOfDecisions(ctx context.Context, exec repositories.Executor,
+		decisionIds []string) (map[string][]string, error)
+}
+
+type DecisionLabelUsecase struct {
+	enforceSecurity security.EnforceSecurityDecisionLabel
+	executorFactory executor_factory.ExecutorFactory
+	repository      DecisionLabelRepository
+}
+
+func (uc DecisionLabelUsecase) CreateDecisionLabels(ctx context.Context, organizationId string,
+	inputs []models.CreateDecisionLabelInput,
+) ([]models.DecisionLabel, error) {
+	if err := uc.enforceSecurity.WriteDecisionLabels(organizationId); err != nil {
+		return nil, err
+	}
+	if len(inputs) == 0 || len(inputs) > DecisionLabelsMaxBatchSize {
+		return nil, errors.Wrapf(models.BadParameterError,
+			"between 1 and %d labels can be sent at once", DecisionLabelsMaxBatchSize)
+	}
+
+	exec := uc.executorFactory.NewExecutor()
+	dataModel, err := uc.repository.GetDataModel(ctx, exec, organizationId, false)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	decisionIds := make([]string, 0, len(inputs))
+	for i := range inputs {
+		inputs[i].OrganizationId = organizationId
+		if inputs[i].OccurredAt.IsZero() {
+			inputs[i].OccurredAt = now
+		}
+		if err := inputs[i].Validate(); err != nil {
+			return nil, errors.Wrapf(err, "invalid label at index %d", i)
+		}
+		if inputs[i].DecisionId != nil {
+			decisionIds = append(decisionIds, *inputs[i].DecisionId)
+		} else if _, ok := dataModel.Tables[*inputs[i].ObjectType]; !ok {
+			return nil, errors.Wrapf(models.NotFoundError,
+				"invalid label at index %d: table %s not found in data model", i, *inputs[i].ObjectType)
+		}
+	}
+
+	if len(decisionIds) > 0 {
+		slices.Sort(decisionIds)
+		decisionIds = slices.Compact(decisionIds)
+		decisions, err := uc.repository.DecisionsById(ctx, exec, decisionIds)
+		if err != nil {
+			return nil, err
+		}
+		nbDecisionsOfOrg := 0
+		for _, decision := range decisions {
+			if decision.OrganizationId == organizationId {
+				nbDecisionsOfOrg++
+			}
+		}
+		if nbDecisionsOfOrg != len(decisionIds) {
+			return nil, errors.Wrap(models.NotFoundError, "some of the labelled decisions were not found")
+		}
+	}
+
+	return uc.repository.CreateDecisionLabels(ctx, exec, inputs)
+}
+
+func (uc DecisionLabelUsecase) DeleteDecisionLabel(ctx context.Context, labelId string) error {
+	exec := uc.executorFactory.NewExecutor()
+	label, err := uc.repository.GetDecisionLabel(ctx, exec, labelId)
+	if err != nil {
+		return err
+	}
+	if err := uc.enforceSecurity.WriteDecisionLabels(label.OrganizationId); err != nil {
+		return err
+	}
+
+	return uc.repository.DeleteDecisionLabel(ctx, exec, labelId)
+}
+
+var decisionLabelExportHeader = []string{
+	"decision_id",
+	"decision_created_at",
+	"scenario_id",
+	"scenario_iteration_id",
+	"trigger_object_type",
+	"object_id",
+	"pivot_value",
+	"score",
+	"outcome",
+	"review_status",
+	"case_id",
+	"triggered_rules",
+	"label",
+	"label_source",
+	"label_amount",
+	"label_currency",
+	"label_occurred_at",
+}
+
+// ExportLabelledDecisions writes the decisions of a scenario made over a period as CSV, with one row per label that
+// applies to them and the names of the rules that matched, for model training and rule precision analysis. Decisions
+// without any label are only exported if requested, with empty label columns.
+func (uc DecisionLabelUsecase) ExportLabelledDecisions(ctx context.Context, w io.Writer,
+	filters models.DecisionLabelExportFilters,
+) error {
+	if err := uc.enforceSecurity.ReadDecisionLabels(filters.OrganizationId); err != nil {
+		return err
+	}
+	if err := filters.Validate(); err != nil {
+		return err
+	}
+
+	exec := uc.executorFactory.NewExecutor()
+	scenario, err := uc.repository.GetScenarioById(ctx, exec, filters.ScenarioId)
+	if err != nil {
+		return err
+	}
+	if scenario.OrganizationId != filters.OrganizationId {
+		return errors.Wrap(models.NotFoundError, "scenario not found")
+	}
+
+	decisionFilters := models.DecisionFilters{
+		ScenarioIds: []string{filters.ScenarioId},
+		StartDate:   filters.StartDate,
+		EndDate:     filters.EndDate,
+	}
+	if !filters.IncludeUnlabelled {
+		hasLabel := true
+		decisionFilters.HasLabel = &hasLabel
+	}
+	pagination := models.PaginationAndSorting{
+		Sorting: models.DecisionSortingCreatedAt,
+		Order:   models.SortingOrderAsc,
+		Limit:   decisionLabelExportBatchSize,
+	}
+
+	csvWriter := csv.NewWriter(w)
+	if err := csvWriter.Write(decisionLabelExportHeader); err != nil {
+		return err
+	}
+
+	for {
+		decisions, err := uc.repository.DecisionsOfOrganization(ctx, exec,
+			filters.OrganizationId, pagination, decisionFilters)
+		if err != nil {
+			return err
+		}
+		if len(decisions) == 0 {
+			break
+		}
+
+		decisionIds := pure_utils.Map(decisions, func(d models.Decision) string { return d.DecisionId })
+		labels, err := uc.repository.ListLabelsOfDecisions(ctx, exec, decisionIds)
+		if err != nil {
+			return err
+		}
+		triggeredRules, err := uc.repository.ListTriggeredRuleNamesOfDecisions(ctx, exec, decisionIds)
+		if err != nil {
+			return err
+		}
+
+		for _, decision := range decisions {
+			decisionLabels := labels[decision.DecisionId]
+			if len(decisionLabels) == 0 {
+				if !filters.IncludeUnlabelled {
+					continue
+				}
+				decisionLabels = []models.DecisionLabel{{}}
+			}
+			for _, label := range decisionLabels {
+				row := decisionLabelExportRow(decision, triggeredRules[decision.DecisionId], label)
+				if err := csvWriter.Write(row); err != nil {
+					return err
+				}
+			}
+		}
+		csvWriter.Flush()
+		if err := csvWriter.Error(); err != nil {
+			return err
+		}
+
+		if len(decisions) < pagination.Limit {
+			break
+		}
+		pagination.OffsetId = decisions[len(decisions)-1].DecisionId
+	}
+
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
+
+func decisionLabelExportRow(decision models.Decision, triggeredRules []string, label models.DecisionLabel) []string {
+	optionalString := func(s *string) string {
+		if s == nil {
+			return ""
+		}
+		return *s
+	}
+
+	caseId := ""
+	if decision.Case != nil {
+		caseId = decision.Case.Id
+	}
+	objectId, _ := decision.ClientObject.Data["object_id"].(string)
+
+	amount, occurredAt := "", ""
+	if label.Amount != nil {
+		amount = strconv.FormatFloat(*label.Amount, 'f', -1, 64)
+	}
+	if !label.OccurredAt.IsZero() {
+		occurredAt = label.OccurredAt.UTC().Format(time.RFC3339)
+	}
+
+	return []string{
+		decision.DecisionId,
+		decision.CreatedAt.UTC().Format(time.RFC3339),
+		decision.ScenarioId,
+		decision.ScenarioIterationId,
+		decision.ClientObject.TableName,
+		objectId,
+		optionalString(decision.PivotValue),
+		strconv.Itoa(decision.Score),
+		decision.Outcome.String(),
+		optionalString(decision.ReviewStatus),
+		caseId,
+		strings.Join(triggeredRules, "|"),
+		label.Label,
+		label.Source,
+		amount,
+		optionalString(label.Currency),
+		occurredAt,
+	}
+}
@@ -0,0 +1,42 @@
+package usecases
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/utils"
+)
+
+func TestDecisionLabelExportRow(t *testing.T) {
+	decision := models.Decision{
+		DecisionId:          "decision_id",
+		CreatedAt:           time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC),
+		ClientObject:        models.ClientObject{TableName: "transactions", Data: map[string]any{"object_id": "tx_1"}},
+		Outcome:             models.Decline,
+		PivotValue:          utils.Ptr("account_1"),
+		ScenarioId:          "scenario_id",
+		ScenarioIterationId: "iteration_id",
+		Score:               120,
+		Case:                &models.Case{Id: "case_id"},
+	}
+
+	row := decisionLabelExportRow(decision, []string{"High amount", "New beneficiary"}, models.DecisionLabel{
+		Label:      "chargeback",
+		Source:     "processor",
+		Amount:     utils.Ptr(99.9),
+		Currency:   utils.Ptr("EUR"),
+		OccurredAt: time.Date(2025, 3, 20, 0, 0, 0, 0, time.UTC),
+	})
+	assert.Equal(t, []string{
+		"decision_id", "2025-03-01T10:00:00Z", "scenario_id", "iteration_id", "transactions", "tx_1", "account_1",
+		"120", "decline", "", "case_id", "High amount|New beneficiary",
+		"chargeback", "processor", "99.9", "EUR", "2025-03-20T00:00:00Z",
+	}, row)
+	assert.Len(t, row, len(decisionLabelExportHeader))
+
+	unlabelled := decisionLabelExportRow(decision, nil, models.DecisionLabel{})
+	assert.Equal(t, []string{"", "", "", "", ""}, unlabelled[12:])
+}
@@ -0,0 +1,244 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/checkmarble/marble-backend/dto"
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/repositories"
+	"github.com/checkmarble/marble-backend/usecases/evaluate_scenario"
+	"github.com/checkmarble/marble-backend/usecases/executor_factory"
+)
+
+type decisionReevaluationRepository interface {
+	GetScenarioIteration(ctx context.Context, exec repositories.Executor,
+		scenarioIterationId string) (models.ScenarioIteration, error)
+	CreateDecisionReevaluation(ctx context.Context, exec repositories.Executor,
+		input models.DecisionReevaluationCreate) (models.DecisionReevaluation, error)
+	ListReevaluationsOfDecisions(ctx context.Context, exec repositories.Executor,
+		decisionIds []string) (map[string][]models.DecisionReevaluation, error)
+	CreateDecisionReevaluationBatch(ctx context.Context, exec repositories.Executor,
+		input models.DecisionReevaluationBatchCreate) (models.DecisionReevaluationBatch, error)
+	GetDecisionReevaluationBatch(ctx context.Context, exec repositories.Executor,
+		id string) (models.DecisionReevaluationBatch, error)
+}
+
+// ReevaluateDecision runs a scenario iteration again on the trigger object of a decision, against the current data,
+// and stores the result as a new decision linked to the original one. The original decision is not modified, and the
+// new decision does not trigger webhooks or case workflows.
+func (usecase *DecisionUsecase) ReevaluateDecision(
+	ctx context.Context,
+	input models.ReevaluateDecisionInput,
+) (models.DecisionReevaluation, error) {
+	exec := usecase.executorFactory.NewExecutor()
+	original, err := usecase.repository.DecisionWithRuleExecutionsById(ctx, exec, input.DecisionId)
+	if err != nil {
+		return models.DecisionReevaluation{}, err
+	}
+	if err := usecase.enforceSecurity.ReadDecision(original.Decision); err != nil {
+		return models.DecisionReevaluation{}, err
+	}
+	if err := usecase.enforceSecurity.CreateDecision(original.OrganizationId); err != nil {
+		return models.DecisionReevaluation{}, err
+	}
+
+	scenario, err := usecase.repository.GetScenarioById(ctx, exec, original.ScenarioId)
+	if err != nil {
+		return models.DecisionReevaluation{}, errors.Wrap(err, "error getting scenario")
+	}
+	iterationId, err := usecase.reevaluationIterationId(ctx, exec, scenario, input.ScenarioIterationId)
+	if err != nil {
+		return models.DecisionReevaluation{}, err
+	}
+
+	// The trigger object is parsed again, so that it has the same types as in the original evaluation
+	payloadRaw, err := json.Marshal(original.ClientObject.Data)
+	if err != nil {
+		return models.DecisionReevaluation{}, errors.Wrap(err, "could not serialize the trigger object")
+	}
+	payload, dataModel, err := usecase.validatePayload(ctx, original.OrganizationId,
+		original.ClientObject.TableName, nil, payloadRaw)
+	if err != nil {
+		return models.DecisionReevaluation{}, err
+	}
+	pivotsMeta, err := usecase.dataModelRepository.ListPivots(ctx, exec, original.OrganizationId, nil)
+	if err != nil {
+		return models.DecisionReevaluation{}, err
+	}
+
+	triggerPassed, scenarioExecution, err := usecase.scenarioEvaluator.EvalScenario(ctx,
+		evaluate_scenario.ScenarioEvaluationParameters{
+			Scenario:          scenario,
+			TargetIterationId: &iterationId,
+			ClientObject:      payload,
+			DataModel:         dataModel,
+			Pivot:             models.FindPivot(pivotsMeta, original.ClientObject.TableName, dataModel),
+		})
+	if err != nil {
+		return models.DecisionReevaluation{}, errors.Wrap(err, "error evaluating scenario")
+	}
+
+	create := models.DecisionReevaluationCreate{
+		OrganizationId:      original.OrganizationId,
+		DecisionId:          original.DecisionId,
+		ScenarioIterationId: iterationId,
+		BatchId:             input.BatchId,
+	}
+
+	return executor_factory.TransactionReturnValue(ctx, usecase.transactionFactory, func(
+		tx repositories.Transaction,
+	) (models.DecisionReevaluation, error) {
+		if triggerPassed {
+			decision := models.AdaptScenarExecToDecision(scenarioExecution, payload, nil)
+			if err := usecase.repository.StoreDecision(ctx, tx, decision,
+				decision.OrganizationId, decision.DecisionId); err != nil {
+				return models.DecisionReevaluation{}, errors.Wrap(err, "error storing decision")
+			}
+			if decision.SanctionCheckExecution != nil {
+				if _, err := usecase.sanctionCheckRepository.InsertSanctionCheck(ctx, tx,
+					decision.DecisionId, *decision.SanctionCheckExecution, true); err != nil {
+					return models.DecisionReevaluation{}, errors.Wrap(err,
+						"could not store sanction check execution")
+				}
+			}
+			create.ReevaluationDecisionId = &decision.DecisionId
+			create.Outcome = &decision.Outcome
+			create.Score = &decision.Score
+		}
+
+		return usecase.decisionReevaluationRepository.CreateDecisionReevaluation(ctx, tx, create)
+	})
+}
+
+// reevaluationIterationId returns the iteration to use for a re-evaluation: the given one if it is a published
+// iteration of the scenario, or the live iteration of the scenario.
+func (usecase *DecisionUsecase) reevaluationIterationId(
+	ctx context.Context,
+	exec repositories.Executor,
+	scenario models.Scenario,
+	scenarioIterationId *string,
+) (string, error) {
+	if scenarioIterationId == nil {
+		if scenario.LiveVersionID == nil {
+			return "", errors.Wrap(models.BadParameterError, "the scenario has no live iteration")
+		}
+		return *scenario.LiveVersionID, nil
+	}
+
+	iteration, err := usecase.decisionReevaluationRepository.GetScenarioIteration(ctx, exec, *scenarioIterationId)
+	if err != nil {
+		return "", err
+	}
+	if iteration.ScenarioId != scenario.Id {
+		return "", errors.Wrap(models.BadParameterError,
+			"the scenario iteration does not belong to the scenario of the decision")
+	}
+	if iteration.Version == nil {
+		return "", errors.Wrap(models.BadParameterError, "a draft scenario iteration cannot be used")
+	}
+	return iteration.Id, nil
+}
+
+func (usecase *DecisionUsecase) ListDecisionReevaluations(ctx context.Context,
+	decisionId string,
+) ([]models.DecisionReevaluation, error) {
+	exec := usecase.executorFactory.NewExecutor()
+	decision, err := usecase.repository.DecisionWithRuleExecutionsById(ctx, exec, decisionId)
+	if err != nil {
+		return nil, err
+	}
+	if err := usecase.enforceSecurity.ReadDecision(decision.Decision); err != nil {
+		return nil, err
+	}
+
+	reevaluations, err := usecase.decisionReevaluationRepository.ListReevaluationsOfDecisions(ctx, exec,
+		[]string{decisionId})
+	if err != nil {
+		return nil, err
+	}
+	if reevaluations[decisionId] == nil {
+		return []models.DecisionReevaluation{}, nil
+	}
+	return reevaluations[decisionId], nil
+}
+
+// CreateDecisionReevaluationBatch requests the re-evaluation of all the decisions matching the filters, by a
+// background job. If an iteration is given, the selection is restricted to the decisions of its scenario.
+func (usecase *DecisionUsecase) CreateDecisionReevaluationBatch(
+	ctx context.Context,
+	organizationId string,
+	scenarioIterationId *string,
+	filters dto.DecisionFilters,
+) (models.DecisionReevaluationBatch, error) {
+	if err := usecase.enforceSecurity.CreateDecision(organizationId); err != nil {
+		return models.DecisionReevaluationBatch{}, err
+	}
+
+	decisionFilters, err := usecase.ValidateDecisionFilters(ctx, organizationId, filters)
+	if err != nil {
+		return models.DecisionReevaluationBatch{}, err
+	}
+
+	if scenarioIterationId != nil {
+		iteration, err := usecase.decisionReevaluationRepository.GetScenarioIteration(ctx,
+			usecase.executorFactory.NewExecutor(), *scenarioIterationId)
+		if err != nil {
+			return models.DecisionReevaluationBatch{}, err
+		}
+		if iteration.OrganizationId != organizationId {
+			return models.DecisionReevaluationBatch{}, errors.Wrap(models.NotFoundError,
+				"scenario iteration not found")
+		}
+		if iteration.Version == nil {
+			return models.DecisionReevaluationBatch{}, errors.Wrap(models.BadParameterError,
+				"a draft scenario iteration cannot be used")
+		}
+		if len(decisionFilters.ScenarioIds) > 0 && !slices.Contains(decisionFilters.ScenarioIds, iteration.ScenarioId) {
+			return models.DecisionReevaluationBatch{}, errors.Wrap(models.BadParameterError,
+				"the scenario iteration does not belong to the selected scenarios")
+		}
+		decisionFilters.ScenarioIds = []string{iteration.ScenarioId}
+	}
+
+	input := models.DecisionReevaluationBatchCreate{
+		OrganizationId:      organizationId,
+		ScenarioIterationId: scenarioIterationId,
+		Filters:             decisionFilters,
+		RequestedByApiKey:   usecase.credentials.ActorIdentity.ApiKeyName,
+	}
+	if usecase.credentials.ActorIdentity.UserId != "" {
+		input.RequestedByUserId = &usecase.credentials.ActorIdentity.UserId
+	}
+
+	return executor_factory.TransactionReturnValue(ctx, usecase.transactionFactory, func(
+		tx repositories.Transaction,
+	) (models.DecisionReevaluationBatch, error) {
+		batch, err := usecase.decisionReevaluationRepository.CreateDecisionReevaluationBatch(ctx, tx, input)
+		if err != nil {
+			return models.DecisionReevaluationBatch{}, err
+		}
+		if err := usecase.taskQueueRepository.EnqueueDecisionReevaluationBatchTask(ctx, tx,
+			organizationId, batch.Id); err != nil {
+			return models.DecisionReevaluationBatch{}, err
+		}
+		return batch, nil
+	})
+}
+
+func (usecase *DecisionUsecase) GetDecisionReevaluationBatch(ctx context.Context,
+	batchId string,
+) (models.DecisionReevaluationBatch, error) {
+	batch, err := usecase.decisionReevaluationRepository.GetDecisionReevaluationBatch(ctx,
+		usecase.executorFactory.NewExecutor(), batchId)
+	if err != nil {
+		return models.DecisionReevaluationBatch{}, err
+	}
+	if err := usecase.enforceSecurity.ReadDecisionReevaluationBatch(batch); err != nil {
+		return models.DecisionReevaluationBatch{}, err
+	}
+	return batch, nil
+}
@@ -0,0 +1,59 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/repositories"
+	"github.com/checkmarble/marble-backend/utils"
+)
+
+type iterationReaderStub struct {
+	decisionReevaluationRepository
+	iterations map[string]models.ScenarioIteration
+}
+
+func (s iterationReaderStub) GetScenarioIteration(ctx context.Context, exec repositories.Executor,
+	scenarioIterationId string,
+) (models.ScenarioIteration, error) {
+	iteration, ok := s.iterations[scenarioIterationId]
+	if !ok {
+		return models.ScenarioIteration{}, models.NotFoundError
+	}
+	return iteration, nil
+}
+
+func TestReevaluationIterationId(t *testing.T) {
+	ctx := context.Background()
+	usecase := DecisionUsecase{decisionReevaluationRepository: iterationReaderStub{
+		iterations: map[string]models.ScenarioIteration{
+			"published": {Id: "published", ScenarioId: "scenario", Version: utils.Ptr(2)},
+			"draft":     {Id: "draft", ScenarioId: "scenario"},
+			"other":     {Id: "other", ScenarioId: "other_scenario", Version: utils.Ptr(1)},
+		},
+	}}
+	scenario := models.Scenario{Id: "scenario", LiveVersionID: utils.Ptr("live")}
+
+	id, err := usecase.reevaluationIterationId(ctx, nil, scenario, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "live", id)
+
+	id, err = usecase.reevaluationIterationId(ctx, nil, scenario, utils.Ptr("published"))
+	assert.NoError(t, err)
+	assert.Equal(t, "published", id)
+
+	_, err = usecase.reevaluationIterationId(ctx, nil, scenario, utils.Ptr("draft"))
+	assert.ErrorIs(t, err, models.BadParameterError)
+
+	_, err = usecase.reevaluationIterationId(ctx, nil, scenario, utils.Ptr("other"))
+	assert.ErrorIs(t, err, models.BadParameterError)
+
+	_, err = usecase.reevaluationIterationId(ctx, nil, scenario, utils.Ptr("missing"))
+	assert.ErrorIs(t, err, models.NotFoundError)
+
+	_, err = usecase.reevaluationIterationId(ctx, nil, models.Scenario{Id: "scenario"}, nil)
+	assert.ErrorIs(t, err, models.BadParameterError)
+}
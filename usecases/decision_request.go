@@ -0,0 +1,111 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/repositories"
+	"github.com/checkmarble/marble-backend/usecases/executor_factory"
+)
+
+type decisionRequestRepository interface {
+	CreateDecisionRequest(ctx context.Context, exec repositories.Executor,
+		input models.DecisionRequestCreate) (models.DecisionRequest, error)
+	GetDecisionRequest(ctx context.Context, exec repositories.Executor, id string,
+		forUpdate bool) (models.DecisionRequest, error)
+}
+
+// CreateDecisionRequest registers a request to evaluate a scenario asynchronously. The scenario and the payload are
+// validated before the request is accepted, so that the job only fails for reasons that a retry could fix.
+func (usecase *DecisionUsecase) CreateDecisionRequest(
+	ctx context.Context,
+	input models.CreateDecisionRequestInput,
+) (models.DecisionRequest, error) {
+	if err := usecase.enforceSecurity.CreateDecision(input.OrganizationId); err != nil {
+		return models.DecisionRequest{}, err
+	}
+	if err := input.Validate(); err != nil {
+		return models.DecisionRequest{}, err
+	}
+
+	exec := usecase.executorFactory.NewExecutor()
+	scenario, err := usecase.repository.GetScenarioById(ctx, exec, input.ScenarioId)
+	if errors.Is(err, models.NotFoundError) {
+		return models.DecisionRequest{}, errors.Wrap(err, "scenario not found")
+	} else if err != nil {
+		return models.DecisionRequest{}, errors.Wrap(err, "error getting scenario")
+	}
+	if err := usecase.enforceSecurityScenario.ReadScenario(scenario); err != nil {
+		return models.DecisionRequest{}, err
+	}
+	if scenario.TriggerObjectType != input.TriggerObjectTable {
+		return models.DecisionRequest{}, errors.Wrapf(models.BadParameterError,
+			"the scenario is triggered on %s objects, not %s", scenario.TriggerObjectType, input.TriggerObjectTable)
+	}
+	if _, _, err := usecase.validatePayload(ctx, input.OrganizationId,
+		input.TriggerObjectTable, nil, input.PayloadRaw); err != nil {
+		return models.DecisionRequest{}, err
+	}
+
+	deadline := input.Deadline
+	if deadline == 0 {
+		deadline = usecase.decisionRequestDeadline
+	}
+	if deadline == 0 {
+		deadline = models.DefaultDecisionRequestDeadline
+	}
+
+	create := models.DecisionRequestCreate{
+		Id:                 uuid.Must(uuid.NewV7()).String(),
+		OrganizationId:     input.OrganizationId,
+		ScenarioId:         input.ScenarioId,
+		TriggerObjectTable: input.TriggerObjectTable,
+		PayloadRaw:         input.PayloadRaw,
+		ReadAsOf:           input.ReadAsOf,
+		FallbackOutcome:    input.FallbackOutcome,
+		Deadline:           time.Now().Add(deadline),
+		RequestedByApiKey:  usecase.credentials.ActorIdentity.ApiKeyName,
+	}
+	if usecase.credentials.ActorIdentity.UserId != "" {
+		create.RequestedByUserId = &usecase.credentials.ActorIdentity.UserId
+	}
+
+	return executor_factory.TransactionReturnValue(ctx, usecase.transactionFactory, func(
+		tx repositories.Transaction,
+	) (models.DecisionRequest, error) {
+		request, err := usecase.decisionRequestRepository.CreateDecisionRequest(ctx, tx, create)
+		if err != nil {
+			return models.DecisionRequest{}, err
+		}
+		if err := usecase.taskQueueRepository.EnqueueDecisionRequestTask(ctx, tx,
+			request.OrganizationId, request.Id, request.Deadline); err != nil {
+			return models.DecisionRequest{}, err
+		}
+		return request, nil
+	})
+}
+
+func (usecase *DecisionUsecase) GetDecisionRequest(ctx context.Context, id string) (models.DecisionRequestWithDecision, error) {
+	exec := usecase.executorFactory.NewExecutor()
+	request, err := usecase.decisionRequestRepository.GetDecisionRequest(ctx, exec, id, false)
+	if err != nil {
+		return models.DecisionRequestWithDecision{}, err
+	}
+	if err := usecase.enforceSecurity.ReadDecisionRequest(request); err != nil {
+		return models.DecisionRequestWithDecision{}, err
+	}
+
+	out := models.DecisionRequestWithDecision{DecisionRequest: request}
+	if decisionId := request.DecisionId(); decisionId != nil {
+		decision, err := usecase.repository.DecisionWithRuleExecutionsById(ctx, exec, *decisionId)
+		if err != nil {
+			return models.DecisionRequestWithDecision{}, err
+		}
+		out.Decision = &decision
+	}
+	return out, nil
+}
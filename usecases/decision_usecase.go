@@ -100,22 +100,29 @@ type decisionUsecaseSanctionCheckWriter interface {
 }
 
 type DecisionUsecase struct {
-	enforceSecurity           security.EnforceSecurityDecision
-	enforceSecurityScenario   security.EnforceSecurityScenario
-	transactionFactory        executor_factory.TransactionFactory
-	executorFactory           executor_factory.ExecutorFactory
-	dataModelRepository       repositories.DataModelRepository
-	repository                DecisionUsecaseRepository
-	sanctionCheckRepository   decisionUsecaseSanctionCheckWriter
-	scenarioTestRunRepository repositories.ScenarioTestRunRepository
-	decisionWorkflows         decisionWorkflowsUsecase
-	offloadedReader           OffloadedReader
-	webhookEventsSender       webhookEventsUsecase
-	phantomUseCase            decision_phantom.PhantomDecisionUsecase
-	featureAccessReader       decisionUsecaseFeatureAccessReader
-	scenarioEvaluator         ScenarioEvaluator
-	openSanctionsRepository   repositories.OpenSanctionsRepository
-	taskQueueRepository       repositories.TaskQueueRepository
+	enforceSecurity                security.EnforceSecurityDecision
+	enforceSecurityScenario        security.EnforceSecurityScenario
+	transactionFactory             executor_factory.TransactionFactory
+	executorFactory                executor_factory.ExecutorFactory
+	dataModelRepository            repositories.DataModelRepository
+	repository                     DecisionUsecaseRepository
+	sanctionCheckRepository        decisionUsecaseSanctionCheckWriter
+	scenarioTestRunRepository      repositories.ScenarioTestRunRepository
+	decisionWorkflows              decisionWorkflowsUsecase
+	offloadedReader                OffloadedReader
+	webhookEventsSender            webhookEventsUsecase
+	phantomUseCase                 decision_phantom.PhantomDecisionUsecase
+	featureAccessReader            decisionUsecaseFeatureAccessReader
+	scenarioEvaluator              ScenarioEvaluator
+	openSanctionsRepository        repositories.OpenSanctionsRepository
+	taskQueueRepository            repositories.TaskQueueRepository
+	ingestedDataReadRepository     repositories.IngestedDataReadRepository
+	idempotencyRepository          decisionIdempotencyRepository
+	idempotencyKeyTtl              time.Duration
+	decisionRequestRepository      decisionRequestRepository
+	decisionRequestDeadline        time.Duration
+	decisionReevaluationRepository decisionReevaluationRepository
+	credentials                    models.Credentials
 }
 
 func (usecase *DecisionUsecase) GetDecision(ctx context.Context, decisionId string) (models.DecisionWithRuleExecutions, error) {
@@ -172,22 +179,7 @@ func (usecase *DecisionUsecase) ListDecisionsWithIndexes(
 	paginationAndSorting models.PaginationAndSorting,
 	filters dto.DecisionFilters,
 ) (models.DecisionListPageWithIndexes, error) {
-	if err := usecase.validateScenarioIds(ctx, filters.ScenarioIds, organizationId); err != nil {
-		return models.DecisionListPageWithIndexes{}, err
-	}
-
-	outcomes, err := usecase.validateOutcomes(filters.Outcomes)
-	if err != nil {
-		return models.DecisionListPageWithIndexes{}, err
-	}
-
-	if !filters.StartDate.IsZero() && !filters.EndDate.IsZero() &&
-		filters.StartDate.After(filters.EndDate) {
-		return models.DecisionListPageWithIndexes{}, fmt.Errorf(
-			"start date must be before end date: %w", models.BadParameterError)
-	}
-
-	triggerObjectTypes, err := usecase.validateTriggerObjects(ctx, filters.TriggerObjects, organizationId)
+	decisionFilters, err := usecase.ValidateDecisionFilters(ctx, organizationId, filters)
 	if err != nil {
 		return models.DecisionListPageWithIndexes{}, err
 	}
@@ -204,19 +196,7 @@ func (usecase *DecisionUsecase) ListDecisionsWithIndexes(
 		usecase.executorFactory.NewExecutor(),
 		organizationId,
 		paginationAndSortingWithOneMore,
-		models.DecisionFilters{
-			CaseIds:               filters.CaseIds,
-			CaseInboxIds:          filters.CaseInboxIds,
-			EndDate:               filters.EndDate,
-			HasCase:               filters.HasCase,
-			Outcomes:              outcomes,
-			PivotValue:            filters.PivotValue,
-			ReviewStatuses:        filters.ReviewStatuses,
-			ScenarioIds:           filters.ScenarioIds,
-			ScheduledExecutionIds: filters.ScheduledExecutionIds,
-			StartDate:             filters.StartDate,
-			TriggerObjects:        triggerObjectTypes,
-		})
+		decisionFilters)
 	if err != nil {
 		return models.DecisionListPageWithIndexes{}, err
 	}
@@ -253,22 +233,7 @@ func (usecase *DecisionUsecase) ListDecisions(
 	paginationAndSorting models.PaginationAndSorting,
 	filters dto.DecisionFilters,
 ) (models.DecisionListPage, error) {
-	if err := usecase.validateScenarioIds(ctx, filters.ScenarioIds, organizationId); err != nil {
-		return models.DecisionListPage{}, err
-	}
-
-	outcomes, err := usecase.validateOutcomes(filters.Outcomes)
-	if err != nil {
-		return models.DecisionListPage{}, err
-	}
-
-	if !filters.StartDate.IsZero() && !filters.EndDate.IsZero() &&
-		filters.StartDate.After(filters.EndDate) {
-		return models.DecisionListPage{}, fmt.Errorf(
-			"start date must be before end date: %w", models.BadParameterError)
-	}
-
-	triggerObjectTypes, err := usecase.validateTriggerObjects(ctx, filters.TriggerObjects, organizationId)
+	decisionFilters, err := usecase.ValidateDecisionFilters(ctx, organizationId, filters)
 	if err != nil {
 		return models.DecisionListPage{}, err
 	}
@@ -285,20 +250,7 @@ func (usecase *DecisionUsecase) ListDecisions(
 		usecase.executorFactory.NewExecutor(),
 		organizationId,
 		paginationAndSortingWithOneMore,
-		models.DecisionFilters{
-			CaseIds:               filters.CaseIds,
-			CaseInboxIds:          filters.CaseInboxIds,
-			EndDate:               filters.EndDate,
-			HasCase:               filters.HasCase,
-			Outcomes:              outcomes,
-			PivotValue:            filters.PivotValue,
-			ReviewStatuses:        filters.ReviewStatuses,
-			ScenarioIds:           filters.ScenarioIds,
-			ScheduledExecutionIds: filters.ScheduledExecutionIds,
-			StartDate:             filters.StartDate,
-			TriggerObjects:        triggerObjectTypes,
-			TriggerObjectId:       filters.TriggerObjectId,
-		})
+		decisionFilters)
 	if err != nil {
 		return models.DecisionListPage{}, err
 	}
@@ -319,6 +271,49 @@ func (usecase *DecisionUsecase) ListDecisions(
 	}, nil
 }
 
+// ValidateDecisionFilters checks that the decision filters sent by a client are valid for the organization, and
+// converts them to their domain representation.
+func (usecase *DecisionUsecase) ValidateDecisionFilters(ctx context.Context, organizationId string,
+	filters dto.DecisionFilters,
+) (models.DecisionFilters, error) {
+	if err := usecase.validateScenarioIds(ctx, filters.ScenarioIds, organizationId); err != nil {
+		return models.DecisionFilters{}, err
+	}
+
+	outcomes, err := usecase.validateOutcomes(filters.Outcomes)
+	if err != nil {
+		return models.DecisionFilters{}, err
+	}
+
+	if !filters.StartDate.IsZero() && !filters.EndDate.IsZero() &&
+		filters.StartDate.After(filters.EndDate) {
+		return models.DecisionFilters{}, fmt.Errorf(
+			"start date must be before end date: %w", models.BadParameterError)
+	}
+
+	triggerObjectTypes, err := usecase.validateTriggerObjects(ctx, filters.TriggerObjects, organizationId)
+	if err != nil {
+		return models.DecisionFilters{}, err
+	}
+
+	return models.DecisionFilters{
+		CaseIds:               filters.CaseIds,
+		CaseInboxIds:          filters.CaseInboxIds,
+		EndDate:               filters.EndDate,
+		HasCase:               filters.HasCase,
+		Outcomes:              outcomes,
+		PivotValue:            filters.PivotValue,
+		ReviewStatuses:        filters.ReviewStatuses,
+		ScenarioIds:           filters.ScenarioIds,
+		ScheduledExecutionIds: filters.ScheduledExecutionIds,
+		StartDate:             filters.StartDate,
+		TriggerObjects:        triggerObjectTypes,
+		TriggerObjectId:       filters.TriggerObjectId,
+		HasLabel:              filters.HasLabel,
+		Labels:                filters.Labels,
+	}, nil
+}
+
 func (usecase *DecisionUsecase) validateScenarioIds(ctx context.Context, scenarioIds []string, organizationId string) error {
 	scenarios, err := usecase.repository.ListScenariosOfOrganization(ctx,
 		usecase.executorFactory.NewExecutor(), organizationId)
@@ -400,6 +395,36 @@ func (usecase *DecisionUsecase) CreateDecision(
 		}
 	}
 
+	var requestHash []byte
+	if input.IdempotencyKey != "" {
+		if err := validateIdempotencyKey(input.IdempotencyKey, usecase.credentials); err != nil {
+			return false, models.DecisionWithRuleExecutions{}, err
+		}
+		requestHash, err = decisionRequestHash("decision", input.ScenarioId,
+			input.TriggerObjectTable, input.PayloadRaw, input.ReadAsOf)
+		if err != nil {
+			return false, models.DecisionWithRuleExecutions{}, err
+		}
+		previous, err := usecase.previousIdempotentRequest(ctx, exec,
+			input.OrganizationId, input.IdempotencyKey, requestHash)
+		if err != nil {
+			return false, models.DecisionWithRuleExecutions{}, err
+		}
+		if previous != nil {
+			if !previous.TriggerPassed || len(previous.DecisionIds) == 0 {
+				return false, models.DecisionWithRuleExecutions{}, nil
+			}
+			decision, err := usecase.repository.DecisionWithRuleExecutionsById(ctx, exec, previous.DecisionIds[0])
+			if err != nil {
+				return false, models.DecisionWithRuleExecutions{}, err
+			}
+			if !params.WithRuleExecutionDetails {
+				removeRuleExecutionDetails(&decision)
+			}
+			return true, decision, nil
+		}
+	}
+
 	payload, dataModel, err := usecase.validatePayload(
 		ctx,
 		input.OrganizationId,
@@ -410,6 +435,9 @@ func (usecase *DecisionUsecase) CreateDecision(
 	if err != nil {
 		return false, models.DecisionWithRuleExecutions{}, err
 	}
+	if input.ReadAsOf != nil {
+		payload.ReadAsOf = input.ReadAsOf
+	}
 
 	pivotsMeta, err := usecase.dataModelRepository.ListPivots(ctx, exec, input.OrganizationId, nil)
 	if err != nil {
@@ -431,15 +459,22 @@ func (usecase *DecisionUsecase) CreateDecision(
 			fmt.Errorf("error evaluating scenario: %w", err)
 	}
 	if !triggerPassed {
+		if input.IdempotencyKey != "" {
+			if err := usecase.saveIdempotencyKey(ctx, exec, input.OrganizationId,
+				input.IdempotencyKey, requestHash, false, nil, 0); err != nil {
+				return false, models.DecisionWithRuleExecutions{}, err
+			}
+		}
 		usecase.executeTestRun(ctx, input.OrganizationId, input.TriggerObjectTable, evaluationParameters, scenario, nil)
 		return false, models.DecisionWithRuleExecutions{}, nil
 	}
 
 	decision := models.AdaptScenarExecToDecision(scenarioExecution, payload, nil)
+	if input.DecisionId != "" {
+		decision.DecisionId = input.DecisionId
+	}
 	if !params.WithRuleExecutionDetails {
-		for i := range decision.RuleExecutions {
-			decision.RuleExecutions[i].Evaluation = nil
-		}
+		removeRuleExecutionDetails(&decision)
 	}
 
 	ctx, span = tracer.Start(
@@ -454,6 +489,25 @@ func (usecase *DecisionUsecase) CreateDecision(
 	newDecision, err := executor_factory.TransactionReturnValue(ctx, usecase.transactionFactory, func(
 		tx repositories.Transaction,
 	) (models.DecisionWithRuleExecutions, error) {
+		// the request is locked until the decision is stored, so that its deadline fallback is not recorded meanwhile
+		if input.DecisionRequestId != "" {
+			request, err := usecase.decisionRequestRepository.GetDecisionRequest(ctx, tx,
+				input.DecisionRequestId, true)
+			if err != nil {
+				return models.DecisionWithRuleExecutions{}, err
+			}
+			if request.Status != models.DecisionRequestPending {
+				return models.DecisionWithRuleExecutions{}, models.ErrDecisionRequestCompleted
+			}
+		}
+
+		if input.IdempotencyKey != "" {
+			if err := usecase.saveIdempotencyKey(ctx, tx, input.OrganizationId, input.IdempotencyKey,
+				requestHash, true, []string{decision.DecisionId}, 0); err != nil {
+				return models.DecisionWithRuleExecutions{}, err
+			}
+		}
+
 		if err = usecase.repository.StoreDecision(
 			ctx,
 			tx,
@@ -555,6 +609,13 @@ func (usecase *DecisionUsecase) CreateDecision(
 	return true, newDecision, nil
 }
 
+// removeRuleExecutionDetails strips the evaluation of the rules from a decision, for callers that did not ask for them
+func removeRuleExecutionDetails(decision *models.DecisionWithRuleExecutions) {
+	for i := range decision.RuleExecutions {
+		decision.RuleExecutions[i].Evaluation = nil
+	}
+}
+
 func (usecase *DecisionUsecase) CreateAllDecisions(
 	ctx context.Context,
 	input models.CreateAllDecisionsInput,
@@ -569,16 +630,43 @@ func (usecase *DecisionUsecase) CreateAllDecisions(
 		return
 	}
 
+	var requestHash []byte
+	if input.IdempotencyKey != "" {
+		if err := validateIdempotencyKey(input.IdempotencyKey, usecase.credentials); err != nil {
+			return nil, 0, err
+		}
+		requestHash, err = decisionRequestHash("decisions/all", "",
+			input.TriggerObjectTable, input.PayloadRaw, input.ReadAsOf)
+		if err != nil {
+			return nil, 0, err
+		}
+		previous, err := usecase.previousIdempotentRequest(ctx, exec,
+			input.OrganizationId, input.IdempotencyKey, requestHash)
+		if err != nil {
+			return nil, 0, err
+		}
+		if previous != nil {
+			decisions, err := usecase.repository.DecisionsWithRuleExecutionsByIds(ctx, exec, previous.DecisionIds)
+			if err != nil {
+				return nil, 0, err
+			}
+			return decisions, previous.NbSkipped, nil
+		}
+	}
+
 	payload, dataModel, err := usecase.validatePayload(
 		ctx,
 		input.OrganizationId,
 		input.TriggerObjectTable,
-		nil,
+		input.ClientObject,
 		input.PayloadRaw,
 	)
 	if err != nil {
 		return nil, 0, err
 	}
+	if input.ReadAsOf != nil {
+		payload.ReadAsOf = input.ReadAsOf
+	}
 
 	pivotsMeta, err := usecase.dataModelRepository.ListPivots(ctx, exec, input.OrganizationId, nil)
 	if err != nil {
@@ -649,6 +737,17 @@ func (usecase *DecisionUsecase) CreateAllDecisions(
 	decisions, err = executor_factory.TransactionReturnValue(ctx, usecase.transactionFactory, func(
 		tx repositories.Transaction,
 	) ([]models.DecisionWithRuleExecutions, error) {
+		if input.IdempotencyKey != "" {
+			decisionIds := make([]string, len(items))
+			for i, item := range items {
+				decisionIds[i] = item.decision.DecisionId
+			}
+			if err := usecase.saveIdempotencyKey(ctx, tx, input.OrganizationId, input.IdempotencyKey,
+				requestHash, len(items) > 0, decisionIds, nbSkipped); err != nil {
+				return nil, err
+			}
+		}
+
 		var ids []string
 		for _, item := range items {
 			ids = append(ids, item.decision.DecisionId)
@@ -740,6 +839,60 @@ func (usecase *DecisionUsecase) CreateAllDecisions(
 	return
 }
 
+// CreateAllDecisionsForIngestedObjects creates decisions on all the live scenarios triggered by the given objects, as
+// they are currently stored in the client database. It is used to make decisions right after ingestion. The failure
+// to create the decisions of an object does not prevent the creation of the decisions of the other objects, it is
+// reported in the Errors of the result.
+func (usecase *DecisionUsecase) CreateAllDecisionsForIngestedObjects(
+	ctx context.Context,
+	organizationId string,
+	objectType string,
+	objectIds []string,
+) (models.IngestionDecisions, error) {
+	exec := usecase.executorFactory.NewExecutor()
+	dataModel, err := usecase.dataModelRepository.GetDataModel(ctx, exec, organizationId, false)
+	if err != nil {
+		return models.IngestionDecisions{}, errors.Wrap(err,
+			"error getting data model in CreateAllDecisionsForIngestedObjects")
+	}
+	table, ok := dataModel.Tables[objectType]
+	if !ok {
+		return models.IngestionDecisions{}, errors.Wrapf(models.NotFoundError,
+			"table %s not found in data model in CreateAllDecisionsForIngestedObjects", objectType)
+	}
+
+	clientDbExec, err := usecase.executorFactory.NewClientDbExecutor(ctx, organizationId)
+	if err != nil {
+		return models.IngestionDecisions{}, err
+	}
+
+	out := models.IngestionDecisions{Decisions: make([]models.DecisionWithRuleExecutions, 0)}
+	for _, objectId := range objectIds {
+		objects, err := usecase.ingestedDataReadRepository.QueryIngestedObject(ctx, clientDbExec, table, objectId)
+		if err != nil {
+			out.Errors = append(out.Errors, models.IngestionDecisionError{ObjectId: objectId, Err: err})
+			continue
+		}
+		if len(objects) == 0 {
+			continue
+		}
+
+		decisions, nbSkipped, err := usecase.CreateAllDecisions(ctx, models.CreateAllDecisionsInput{
+			OrganizationId:     organizationId,
+			ClientObject:       &models.ClientObject{TableName: objectType, Data: objects[0].Data},
+			TriggerObjectTable: objectType,
+		})
+		if err != nil {
+			out.Errors = append(out.Errors, models.IngestionDecisionError{ObjectId: objectId, Err: err})
+			continue
+		}
+		out.Decisions = append(out.Decisions, decisions...)
+		out.NbSkipped += nbSkipped
+	}
+
+	return out, nil
+}
+
 func (usecase *DecisionUsecase) executeTestRun(
 	ctx context.Context,
 	organizationId string,
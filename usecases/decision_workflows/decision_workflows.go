@@ -25,6 +25,7 @@ type caseEditor interface {
 		caseId, userId string,
 		decisionIdsToAdd []string,
 	) error
+	AutoAssignCase(ctx context.Context, tx repositories.Transaction, c models.Case, inboxId string) error
 }
 
 type caseAndDecisionRepository interface {
@@ -39,6 +40,11 @@ type caseAndDecisionRepository interface {
 		organizationId string,
 		caseIds []string,
 	) (map[string]int, error)
+	ListCaseRoutingRules(
+		ctx context.Context,
+		exec repositories.Executor,
+		scenarioId string,
+	) ([]models.CaseRoutingRule, error)
 }
 
 type webhookEventCreator interface {
@@ -54,11 +60,18 @@ type CaseNameEvaluator interface {
 		scenario models.Scenario) (string, error)
 }
 
+type CaseRouteEvaluator interface {
+	EvalCaseRoute(ctx context.Context, params evaluate_scenario.ScenarioEvaluationParameters,
+		scenario models.Scenario, decision models.DecisionWithRuleExecutions,
+		rules []models.CaseRoutingRule) models.CaseRoute
+}
+
 type DecisionsWorkflows struct {
 	caseEditor          caseEditor
 	repository          caseAndDecisionRepository
 	webhookEventCreator webhookEventCreator
 	caseNameEvaluator   CaseNameEvaluator
+	caseRouteEvaluator  CaseRouteEvaluator
 }
 
 func NewDecisionWorkflows(
@@ -66,12 +79,14 @@ func NewDecisionWorkflows(
 	repository caseAndDecisionRepository,
 	webhookEventCreator webhookEventCreator,
 	caseNameEvaluator CaseNameEvaluator,
+	caseRouteEvaluator CaseRouteEvaluator,
 ) DecisionsWorkflows {
 	return DecisionsWorkflows{
 		caseEditor:          caseEditor,
 		repository:          repository,
 		webhookEventCreator: webhookEventCreator,
 		caseNameEvaluator:   caseNameEvaluator,
+		caseRouteEvaluator:  caseRouteEvaluator,
 	}
 }
 
@@ -90,17 +105,26 @@ func (d DecisionsWorkflows) AutomaticDecisionToCase(
 		return false, nil
 	}
 
+	routingRules, err := d.repository.ListCaseRoutingRules(ctx, tx, scenario.Id)
+	if err != nil {
+		return false, errors.Wrap(err, "error listing case routing rules")
+	}
+	route := d.caseRouteEvaluator.EvalCaseRoute(ctx, params, scenario, decision, routingRules)
+
 	createNewCaseForDecision := func(ctx context.Context) (bool, error) {
 		caseName, err := d.caseNameEvaluator.EvalCaseName(ctx, params, scenario)
 		if err != nil {
 			return false, errors.Wrap(err, "error creating case for decision")
 		}
 
-		input := automaticCreateCaseAttributes(scenario, decision, caseName)
+		input := automaticCreateCaseAttributes(scenario, decision, caseName, route)
 		newCase, err := d.caseEditor.CreateCase(ctx, tx, "", input, false)
 		if err != nil {
 			return false, errors.Wrap(err, "error creating case for decision")
 		}
+		if err := d.caseEditor.AutoAssignCase(ctx, tx, newCase, newCase.InboxId); err != nil {
+			return false, errors.Wrap(err, "error assigning case for decision")
+		}
 
 		err = d.webhookEventCreator.CreateWebhookEvent(ctx, tx, models.WebhookEventCreate{
 			Id:             webhookEventId,
@@ -114,7 +138,7 @@ func (d DecisionsWorkflows) AutomaticDecisionToCase(
 	case models.WorkflowCreateCase:
 		return createNewCaseForDecision(ctx)
 	case models.WorkflowAddToCaseIfPossible:
-		matchedCase, added, err := d.addToOpenCase(ctx, tx, scenario, decision)
+		matchedCase, added, err := d.addToOpenCase(ctx, tx, scenario, decision, route.InboxId)
 		if err != nil {
 			return false, errors.Wrap(err, "error adding decision to open case")
 		}
@@ -141,12 +165,14 @@ func automaticCreateCaseAttributes(
 	scenario models.Scenario,
 	decision models.DecisionWithRuleExecutions,
 	name string,
+	route models.CaseRoute,
 ) models.CreateCaseAttributes {
 	return models.CreateCaseAttributes{
 		DecisionIds:    []string{decision.DecisionId},
-		InboxId:        *scenario.DecisionToCaseInboxId,
+		InboxId:        route.InboxId,
 		Name:           name,
 		OrganizationId: scenario.OrganizationId,
+		TagIds:         route.TagIds,
 	}
 }
 
@@ -155,13 +181,14 @@ func (d DecisionsWorkflows) addToOpenCase(
 	tx repositories.Transaction,
 	scenario models.Scenario,
 	decision models.DecisionWithRuleExecutions,
+	inboxId string,
 ) (models.CaseMetadata, bool, error) {
 	if decision.PivotValue == nil {
 		return models.CaseMetadata{}, false, nil
 	}
 
 	eligibleCases, err := d.repository.SelectCasesWithPivot(ctx, tx, models.DecisionWorkflowFilters{
-		InboxId:        *scenario.DecisionToCaseInboxId,
+		InboxId:        inboxId,
 		OrganizationId: scenario.OrganizationId,
 		PivotValue:     *decision.PivotValue,
 	})
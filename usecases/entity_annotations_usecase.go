@@ -48,6 +48,7 @@ type EntityAnnotationUsecase struct {
 	tagRepository              TagRepository
 
 	blobRepository repositories.BlobRepository
+	fileScanner    repositories.FileScanner
 	bucketUrl      string
 
 	executorFactory    executor_factory.ExecutorFactory
@@ -105,6 +106,11 @@ func (uc EntityAnnotationUsecase) AttachFile(ctx context.Context,
 	metadata := make([]models.EntityAnnotationFilePayloadFile, len(files))
 
 	for idx, file := range files {
+		scanStatus, err := scanUploadedFile(ctx, uc.fileScanner, file)
+		if err != nil {
+			return models.EntityAnnotation{}, err
+		}
+
 		key := fmt.Sprintf("annotations/%s/%s/%s", req.OrgId, req.ObjectType, uuid.NewString())
 
 		if err := uc.writeFileAnnotationToBlobStorage(ctx, file, key); err != nil {
@@ -112,9 +118,10 @@ func (uc EntityAnnotationUsecase) AttachFile(ctx context.Context,
 		}
 
 		metadata[idx] = models.EntityAnnotationFilePayloadFile{
-			Id:       uuid.NewString(),
-			Key:      key,
-			Filename: file.Filename,
+			Id:         uuid.NewString(),
+			Key:        key,
+			Filename:   file.Filename,
+			ScanStatus: scanStatus,
 		}
 	}
 
@@ -151,6 +158,9 @@ func (uc EntityAnnotationUsecase) GetFileDownloadUrl(ctx context.Context,
 
 	for _, part := range fp.Files {
 		if part.Id == partId {
+			if err := errIfQuarantined(part.ScanStatus); err != nil {
+				return "", err
+			}
 			return uc.blobRepository.GenerateSignedUrl(ctx, uc.bucketUrl, part.Key)
 		}
 	}
@@ -0,0 +1,374 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/pure_utils"
+	"github.com/checkmarble/marble-backend/repositories"
+	"github.com/checkmarble/marble-backend/usecases/executor_factory"
+	"github.com/checkmarble/marble-backend/usecases/security"
+
+	"github.com/cockroachdb/errors"
+)
+
+type ErasureRepository interface {
+	GetDataModel(ctx context.Context, exec repositories.Executor, organizationID string, fetchEnumValues bool) (models.DataModel, error)
+	ListPivots(ctx context.Context, exec repositories.Executor, organizationId string, tableId *string) ([]models.PivotMetadata, error)
+	GetOffloadedDecisionRuleKey(orgId, decisionId, ruleId, outcome string, createdAt time.Time) string
+
+	ListDecisionsForErasure(ctx context.Context, exec repositories.Executor, orgId string,
+		objectType string, objectIds []string, pivotValue string) ([]models.ErasableDecision, error)
+	ListDecisionRuleRefs(ctx context.Context, exec repositories.Executor,
+		decisionIds []string) ([]models.DecisionRuleRef, error)
+	PseudonymiseDecisions(ctx context.Context, exec repositories.Executor, orgId string,
+		decisionIds []string, erasedValues []string) (int, error)
+	PseudonymiseDecisionLabelsForErasure(ctx context.Context, exec repositories.Executor,
+		orgId, objectType string, objectIds []string, decisionIds []string) (int, error)
+	ClearDecisionRuleEvaluations(ctx context.Context, exec repositories.Executor, decisionIds []string) (int, error)
+	DeleteEntityAnnotationsForErasure(ctx context.Context, exec repositories.Executor,
+		orgId, objectType string, objectIds []string) ([]models.EntityAnnotation, error)
+	DeleteCaseFilesForErasure(ctx context.Context, exec repositories.Executor, decisionIds []string) ([]models.CaseFile, error)
+	ClearSanctionCheckSearchInputs(ctx context.Context, exec repositories.Executor, decisionIds []string) (int, error)
+	PseudonymiseChangeEventsForErasure(ctx context.Context, exec repositories.Executor,
+		orgId string, decisionIds []string) (int, error)
+	DeleteWebhookEventsForDecisions(ctx context.Context, exec repositories.Executor,
+		orgId string, decisionIds []string) (int, error)
+
+	CreateErasureCertificate(ctx context.Context, exec repositories.Executor,
+		certificate models.ErasureCertificate) (models.ErasureCertificate, error)
+	ListErasureCertificates(ctx context.Context, exec repositories.Executor, orgId string) ([]models.ErasureCertificate, error)
+	GetErasureCertificate(ctx context.Context, exec repositories.Executor, id string) (models.ErasureCertificate, error)
+}
+
+type ErasureClientDbRepository interface {
+	ListObjectIdsByFieldValue(ctx context.Context, exec repositories.Executor,
+		tableName, fieldName, value string) ([]string, error)
+	DeleteIngestedObjects(ctx context.Context, exec repositories.Executor, tableName string, objectIds []string) (int, error)
+	PseudonymiseIngestedObjects(ctx context.Context, exec repositories.Executor,
+		orgId, tableName string, fieldNames []string, objectIds []string) (int, error)
+	PseudonymiseIngestedLinks(ctx context.Context, exec repositories.Executor,
+		orgId, tableName, fieldName string, objectIds []string) (int, error)
+}
+
+type ErasureUsecase struct {
+	enforceSecurity     security.EnforceSecurityErasure
+	executorFactory     executor_factory.ExecutorFactory
+	transactionFactory  executor_factory.TransactionFactory
+	repository          ErasureRepository
+	clientDbRepository  ErasureClientDbRepository
+	taskQueueRepository repositories.TaskQueueRepository
+	blobRepository      repositories.BlobRepository
+	offloadingBucketUrl string
+	credentials         models.Credentials
+}
+
+// Erase removes the personal data of a data subject from all the stores of an organization:
+//   - the ingested versions of the subject's objects are deleted or pseudonymised, and references to them in linked
+//     tables are pseudonymised,
+//   - decisions on the subject keep their audit skeleton (scenario, score, outcome, case) but lose their trigger object
+//     payload, rule evaluations (including offloaded ones), sanction check inputs and webhook events, also in the
+//     change feed,
+//   - entity annotations on the subject, and files of cases that only concern the subject, are deleted.
+//
+// Files are deleted by a job enqueued with the erasure transaction, once the rows that reference them are gone.
+//
+// Subjects that are linked to an open case or to a suspicious activity report are under legal hold and cannot be erased.
+func (uc ErasureUsecase) Erase(ctx context.Context, req models.ErasureRequest) (models.ErasureCertificate, error) {
+	if err := uc.enforceSecurity.EraseData(req.OrganizationId); err != nil {
+		return models.ErasureCertificate{}, err
+	}
+	if (req.ObjectId == "") == (req.PivotValue == "") {
+		return models.ErasureCertificate{}, errors.Wrap(models.BadParameterError,
+			"exactly one of object_id or pivot_value must be provided")
+	}
+	if req.Mode == models.ErasureModeUnknown {
+		return models.ErasureCertificate{}, errors.Wrap(models.BadParameterError,
+			"erasure mode must be one of pseudonymise, delete")
+	}
+
+	exec := uc.executorFactory.NewExecutor()
+	dataModel, err := uc.repository.GetDataModel(ctx, exec, req.OrganizationId, false)
+	if err != nil {
+		return models.ErasureCertificate{}, err
+	}
+	table, ok := dataModel.Tables[req.ObjectType]
+	if !ok {
+		return models.ErasureCertificate{}, errors.Wrapf(models.NotFoundError,
+			"table %s does not exist in the data model", req.ObjectType)
+	}
+
+	clientExec, err := uc.executorFactory.NewClientDbExecutor(ctx, req.OrganizationId)
+	if err != nil {
+		return models.ErasureCertificate{}, err
+	}
+
+	objectIds, decisions, err := uc.findSubject(ctx, clientExec, dataModel, req)
+	if err != nil {
+		return models.ErasureCertificate{}, err
+	}
+	if slices.ContainsFunc(decisions, func(d models.ErasableDecision) bool { return d.OnLegalHold }) {
+		return models.ErasureCertificate{}, errors.Wrap(models.ConflictError,
+			"the subject is linked to an open case or a suspicious activity report and is under legal hold")
+	}
+	decisionIds := pure_utils.Map(decisions, func(d models.ErasableDecision) string { return d.Id })
+	erasedValues := slices.Clone(objectIds)
+	if req.PivotValue != "" {
+		erasedValues = append(erasedValues, req.PivotValue)
+	}
+
+	certificate := models.ErasureCertificate{
+		OrganizationId:    req.OrganizationId,
+		ObjectType:        req.ObjectType,
+		SubjectPseudonym:  models.ErasurePseudonym(req.OrganizationId, req.ObjectId+req.PivotValue),
+		Mode:              req.Mode,
+		RequestedByApiKey: uc.credentials.ActorIdentity.ApiKeyName,
+	}
+	if uc.credentials.ActorIdentity.UserId != "" {
+		certificate.RequestedByUserId = &uc.credentials.ActorIdentity.UserId
+	}
+
+	// The client database can be separate from the marble database, so ingested data is erased first and outside of
+	// the transaction. All the steps below are idempotent, so a failed erasure can safely be retried.
+	if err := uc.eraseIngestedData(ctx, clientExec, dataModel, table, req, objectIds, &certificate.Counts); err != nil {
+		return models.ErasureCertificate{}, err
+	}
+	blobs, err := uc.listOffloadedRules(ctx, exec, req.OrganizationId, decisions)
+	if err != nil {
+		return models.ErasureCertificate{}, err
+	}
+	certificate.Counts.OffloadedRules = len(blobs)
+
+	return executor_factory.TransactionReturnValue(ctx, uc.transactionFactory, func(
+		tx repositories.Transaction,
+	) (models.ErasureCertificate, error) {
+		counts := &certificate.Counts
+		var err error
+
+		// labels only hold an object id, they are not accounted for in the certificate
+		if _, err = uc.repository.PseudonymiseDecisionLabelsForErasure(ctx, tx,
+			req.OrganizationId, req.ObjectType, objectIds, decisionIds); err != nil {
+			return models.ErasureCertificate{}, err
+		}
+		if counts.Decisions, err = uc.repository.PseudonymiseDecisions(ctx, tx,
+			req.OrganizationId, decisionIds, erasedValues); err != nil {
+			return models.ErasureCertificate{}, err
+		}
+		if counts.RuleExecutions, err = uc.repository.ClearDecisionRuleEvaluations(ctx, tx, decisionIds); err != nil {
+			return models.ErasureCertificate{}, err
+		}
+		if counts.SanctionChecks, err = uc.repository.ClearSanctionCheckSearchInputs(ctx, tx, decisionIds); err != nil {
+			return models.ErasureCertificate{}, err
+		}
+		// the change feed keeps copies of the decisions and sanction checks until they are purged. Like labels, they are
+		// not accounted for in the certificate.
+		if _, err = uc.repository.PseudonymiseChangeEventsForErasure(ctx, tx,
+			req.OrganizationId, decisionIds); err != nil {
+			return models.ErasureCertificate{}, err
+		}
+		if counts.WebhookEvents, err = uc.repository.DeleteWebhookEventsForDecisions(ctx, tx,
+			req.OrganizationId, decisionIds); err != nil {
+			return models.ErasureCertificate{}, err
+		}
+
+		annotations, err := uc.repository.DeleteEntityAnnotationsForErasure(ctx, tx,
+			req.OrganizationId, req.ObjectType, objectIds)
+		if err != nil {
+			return models.ErasureCertificate{}, err
+		}
+		counts.EntityAnnotations = len(annotations)
+		for _, annotation := range annotations {
+			files, err := annotationFiles(annotation)
+			if err != nil {
+				return models.ErasureCertificate{}, err
+			}
+			blobs = append(blobs, files...)
+		}
+
+		caseFiles, err := uc.repository.DeleteCaseFilesForErasure(ctx, tx, decisionIds)
+		if err != nil {
+			return models.ErasureCertificate{}, err
+		}
+		counts.CaseFiles = len(caseFiles)
+		for _, file := range caseFiles {
+			blobs = append(blobs, models.BlobRef{BucketUrl: file.BucketName, Key: file.FileReference})
+		}
+
+		if len(blobs) > 0 {
+			if err := uc.taskQueueRepository.EnqueueBlobDeletionTask(ctx, tx, req.OrganizationId, blobs); err != nil {
+				return models.ErasureCertificate{}, err
+			}
+		}
+
+		return uc.repository.CreateErasureCertificate(ctx, tx, certificate)
+	})
+}
+
+// findSubject returns the ids of the subject's objects in the requested table, and the decisions that concern them.
+// When erasing by pivot value, the objects are those whose pivot field has this value, and those that triggered a
+// decision with this pivot value.
+func (uc ErasureUsecase) findSubject(ctx context.Context, clientExec repositories.Executor,
+	dataModel models.DataModel, req models.ErasureRequest,
+) ([]string, []models.ErasableDecision, error) {
+	exec := uc.executorFactory.NewExecutor()
+
+	if req.ObjectId != "" {
+		objectIds := []string{req.ObjectId}
+		decisions, err := uc.repository.ListDecisionsForErasure(ctx, exec,
+			req.OrganizationId, req.ObjectType, objectIds, "")
+		return objectIds, decisions, err
+	}
+
+	pivotsMeta, err := uc.repository.ListPivots(ctx, exec, req.OrganizationId, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	objectIds := make([]string, 0)
+	for _, pivotMeta := range pivotsMeta {
+		pivot := pivotMeta.Enrich(dataModel)
+		if pivot.BaseTable != req.ObjectType || len(pivot.PathLinks) > 0 {
+			continue
+		}
+		ids, err := uc.clientDbRepository.ListObjectIdsByFieldValue(ctx, clientExec,
+			pivot.BaseTable, pivot.Field, req.PivotValue)
+		if err != nil {
+			return nil, nil, err
+		}
+		objectIds = append(objectIds, ids...)
+	}
+
+	decisions, err := uc.repository.ListDecisionsForErasure(ctx, exec,
+		req.OrganizationId, req.ObjectType, objectIds, req.PivotValue)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, decision := range decisions {
+		if decision.TriggerObjectType == req.ObjectType {
+			objectIds = append(objectIds, decision.ObjectId)
+		}
+	}
+	slices.Sort(objectIds)
+
+	return slices.Compact(objectIds), decisions, nil
+}
+
+func (uc ErasureUsecase) eraseIngestedData(ctx context.Context, clientExec repositories.Executor,
+	dataModel models.DataModel, table models.Table, req models.ErasureRequest, objectIds []string,
+	counts *models.ErasureCounts,
+) error {
+	var err error
+	switch req.Mode {
+	case models.ErasureModeDelete:
+		counts.IngestedRows, err = uc.clientDbRepository.DeleteIngestedObjects(ctx, clientExec, table.Name, objectIds)
+	default:
+		fieldNames := make([]string, 0, len(table.Fields))
+		for name := range table.Fields {
+			if name != "object_id" && name != "updated_at" {
+				fieldNames = append(fieldNames, name)
+			}
+		}
+		counts.IngestedRows, err = uc.clientDbRepository.PseudonymiseIngestedObjects(ctx, clientExec,
+			req.OrganizationId, table.Name, fieldNames, objectIds)
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, childTable := range dataModel.Tables {
+		for _, link := range childTable.LinksToSingle {
+			if link.ParentTableName != table.Name || link.ParentFieldName != "object_id" {
+				continue
+			}
+			nb, err := uc.clientDbRepository.PseudonymiseIngestedLinks(ctx, clientExec,
+				req.OrganizationId, link.ChildTableName, link.ChildFieldName, objectIds)
+			if err != nil {
+				return err
+			}
+			counts.LinkedRows += nb
+		}
+	}
+
+	return nil
+}
+
+// listOffloadedRules returns the offloaded evaluations of the rules of the decisions. Rules that were not offloaded, or
+// whose evaluation was already deleted by a previous attempt, have no blob.
+func (uc ErasureUsecase) listOffloadedRules(ctx context.Context, exec repositories.Executor, orgId string,
+	decisions []models.ErasableDecision,
+) ([]models.BlobRef, error) {
+	blobs := make([]models.BlobRef, 0)
+	if uc.offloadingBucketUrl == "" || len(decisions) == 0 {
+		return blobs, nil
+	}
+
+	decisionsById := make(map[string]models.ErasableDecision, len(decisions))
+	for _, decision := range decisions {
+		decisionsById[decision.Id] = decision
+	}
+
+	rules, err := uc.repository.ListDecisionRuleRefs(ctx, exec, slices.Collect(maps.Keys(decisionsById)))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rule := range rules {
+		key := uc.repository.GetOffloadedDecisionRuleKey(orgId, rule.DecisionId, rule.RuleId,
+			rule.Outcome, decisionsById[rule.DecisionId].CreatedAt)
+
+		blob, err := uc.blobRepository.GetBlob(ctx, uc.offloadingBucketUrl, key)
+		if errors.Is(err, models.NotFoundError) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		blob.ReadCloser.Close()
+		blobs = append(blobs, models.BlobRef{BucketUrl: uc.offloadingBucketUrl, Key: key})
+	}
+
+	return blobs, nil
+}
+
+func annotationFiles(annotation models.EntityAnnotation) ([]models.BlobRef, error) {
+	if annotation.AnnotationType != models.EntityAnnotationFile {
+		return nil, nil
+	}
+
+	var payload models.EntityAnnotationFilePayload
+	if err := json.Unmarshal(annotation.Payload, &payload); err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("could not parse payload of annotation %s", annotation.Id))
+	}
+	return pure_utils.Map(payload.Files, func(file models.EntityAnnotationFilePayloadFile) models.BlobRef {
+		return models.BlobRef{BucketUrl: payload.Bucket, Key: file.Key}
+	}), nil
+}
+
+func (uc ErasureUsecase) ListErasureCertificates(ctx context.Context, orgId string) ([]models.ErasureCertificate, error) {
+	certificates, err := uc.repository.ListErasureCertificates(ctx, uc.executorFactory.NewExecutor(), orgId)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, certificate := range certificates {
+		if err := uc.enforceSecurity.ReadErasureCertificate(certificate); err != nil {
+			return nil, err
+		}
+	}
+	return certificates, nil
+}
+
+func (uc ErasureUsecase) GetErasureCertificate(ctx context.Context, id string) (models.ErasureCertificate, error) {
+	certificate, err := uc.repository.GetErasureCertificate(ctx, uc.executorFactory.NewExecutor(), id)
+	if err != nil {
+		return models.ErasureCertificate{}, err
+	}
+	if err := uc.enforceSecurity.ReadErasureCertificate(certificate); err != nil {
+		return models.ErasureCertificate{}, err
+	}
+	return certificate, nil
+}
@@ -0,0 +1,391 @@
+package usecases
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gocloud.dev/blob"
+
+	"github.com/checkmarble/marble-backend/mocks"
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/repositories"
+	"github.com/checkmarble/marble-backend/usecases/executor_factory"
+	"github.com/checkmarble/marble-backend/utils"
+)
+
+// fakeErasureRepository serves a fixed data model and subject, and records what the usecase erased
+type fakeErasureRepository struct {
+	dataModel   models.DataModel
+	pivots      []models.PivotMetadata
+	decisions   []models.ErasableDecision
+	rules       []models.DecisionRuleRef
+	annotations []models.EntityAnnotation
+	caseFiles   []models.CaseFile
+
+	listedObjectIds     []string
+	listedPivotValue    string
+	pseudonymisedIds    []string
+	erasedValues        []string
+	clearedRuleIds      []string
+	clearedSanctionsIds []string
+	// decisions whose change events were pseudonymised, and the decisions already pseudonymised at that time
+	changeEventsIds              []string
+	pseudonymisedBeforeEventsIds []string
+}
+
+func (r *fakeErasureRepository) GetDataModel(ctx context.Context, exec repositories.Executor,
+	organizationID string, fetchEnumValues bool,
+) (models.DataModel, error) {
+	return r.dataModel, nil
+}
+
+func (r *fakeErasureRepository) ListPivots(ctx context.Context, exec repositories.Executor,
+	organizationId string, tableId *string,
+) ([]models.PivotMetadata, error) {
+	return r.pivots, nil
+}
+
+func (r *fakeErasureRepository) GetOffloadedDecisionRuleKey(orgId, decisionId, ruleId, outcome string,
+	createdAt time.Time,
+) string {
+	return fmt.Sprintf("offloading/decision_rules/%s/%s/%s/%s", orgId, decisionId, ruleId, outcome)
+}
+
+func (r *fakeErasureRepository) ListDecisionsForErasure(ctx context.Context, exec repositories.Executor,
+	orgId string, objectType string, objectIds []string, pivotValue string,
+) ([]models.ErasableDecision, error) {
+	r.listedObjectIds = objectIds
+	r.listedPivotValue = pivotValue
+	return r.decisions, nil
+}
+
+func (r *fakeErasureRepository) ListDecisionRuleRefs(ctx context.Context, exec repositories.Executor,
+	decisionIds []string,
+) ([]models.DecisionRuleRef, error) {
+	return r.rules, nil
+}
+
+func (r *fakeErasureRepository) PseudonymiseDecisions(ctx context.Context, exec repositories.Executor,
+	orgId string, decisionIds []string, erasedValues []string,
+) (int, error) {
+	r.pseudonymisedIds = decisionIds
+	r.erasedValues = erasedValues
+	return len(decisionIds), nil
+}
+
+func (r *fakeErasureRepository) PseudonymiseDecisionLabelsForErasure(ctx context.Context, exec repositories.Executor,
+	orgId, objectType string, objectIds []string, decisionIds []string,
+) (int, error) {
+	return 0, nil
+}
+
+func (r *fakeErasureRepository) ClearDecisionRuleEvaluations(ctx context.Context, exec repositories.Executor,
+	decisionIds []string,
+) (int, error) {
+	r.clearedRuleIds = decisionIds
+	return len(r.rules), nil
+}
+
+func (r *fakeErasureRepository) DeleteEntityAnnotationsForErasure(ctx context.Context, exec repositories.Executor,
+	orgId, objectType string, objectIds []string,
+) ([]models.EntityAnnotation, error) {
+	return r.annotations, nil
+}
+
+func (r *fakeErasureRepository) DeleteCaseFilesForErasure(ctx context.Context, exec repositories.Executor,
+	decisionIds []string,
+) ([]models.CaseFile, error) {
+	return r.caseFiles, nil
+}
+
+func (r *fakeErasureRepository) ClearSanctionCheckSearchInputs(ctx context.Context, exec repositories.Executor,
+	decisionIds []string,
+) (int, error) {
+	r.clearedSanctionsIds = decisionIds
+	return 0, nil
+}
+
+func (r *fakeErasureRepository) PseudonymiseChangeEventsForErasure(ctx context.Context, exec repositories.Executor,
+	orgId string, decisionIds []string,
+) (int, error) {
+	r.changeEventsIds = decisionIds
+	r.pseudonymisedBeforeEventsIds = r.pseudonymisedIds
+	return len(decisionIds), nil
+}
+
+func (r *fakeErasureRepository) DeleteWebhookEventsForDecisions(ctx context.Context, exec repositories.Executor,
+	orgId string, decisionIds []string,
+) (int, error) {
+	return 0, nil
+}
+
+func (r *fakeErasureRepository) CreateErasureCertificate(ctx context.Context, exec repositories.Executor,
+	certificate models.ErasureCertificate,
+) (models.ErasureCertificate, error) {
+	return certificate, nil
+}
+
+func (r *fakeErasureRepository) ListErasureCertificates(ctx context.Context, exec repositories.Executor,
+	orgId string,
+) ([]models.ErasureCertificate, error) {
+	return nil, nil
+}
+
+func (r *fakeErasureRepository) GetErasureCertificate(ctx context.Context, exec repositories.Executor,
+	id string,
+) (models.ErasureCertificate, error) {
+	return models.ErasureCertificate{}, nil
+}
+
+type fakeErasureClientDbRepository struct {
+	objectIdsByValue map[string][]string
+
+	deletedObjectIds      []string
+	pseudonymisedFields   []string
+	pseudonymisedObjects  []string
+	pseudonymisedLinksIn  []string
+	pseudonymisedLinkedTo []string
+}
+
+func (r *fakeErasureClientDbRepository) ListObjectIdsByFieldValue(ctx context.Context, exec repositories.Executor,
+	tableName, fieldName, value string,
+) ([]string, error) {
+	return r.objectIdsByValue[tableName+"."+fieldName+"="+value], nil
+}
+
+func (r *fakeErasureClientDbRepository) DeleteIngestedObjects(ctx context.Context, exec repositories.Executor,
+	tableName string, objectIds []string,
+) (int, error) {
+	r.deletedObjectIds = objectIds
+	return len(objectIds), nil
+}
+
+func (r *fakeErasureClientDbRepository) PseudonymiseIngestedObjects(ctx context.Context, exec repositories.Executor,
+	orgId, tableName string, fieldNames []string, objectIds []string,
+) (int, error) {
+	r.pseudonymisedFields = fieldNames
+	r.pseudonymisedObjects = objectIds
+	return len(objectIds), nil
+}
+
+func (r *fakeErasureClientDbRepository) PseudonymiseIngestedLinks(ctx context.Context, exec repositories.Executor,
+	orgId, tableName, fieldName string, objectIds []string,
+) (int, error) {
+	r.pseudonymisedLinksIn = append(r.pseudonymisedLinksIn, tableName+"."+fieldName)
+	r.pseudonymisedLinkedTo = objectIds
+	return 1, nil
+}
+
+// fakeErasureBlobRepository only knows the blobs it was created with, and fails if the usecase deletes any of them
+type fakeErasureBlobRepository struct {
+	blobs map[string]string
+}
+
+func (r fakeErasureBlobRepository) GetBlob(ctx context.Context, bucketUrl, key string) (models.Blob, error) {
+	content, ok := r.blobs[bucketUrl+"/"+key]
+	if !ok {
+		return models.Blob{}, models.NotFoundError
+	}
+	return models.Blob{FileName: key, ReadCloser: io.NopCloser(bytes.NewBufferString(content))}, nil
+}
+
+func (r fakeErasureBlobRepository) OpenStream(ctx context.Context, bucketUrl, key string,
+	fileName string,
+) (io.WriteCloser, error) {
+	return nil, fmt.Errorf("unexpected write of %s", key)
+}
+
+func (r fakeErasureBlobRepository) OpenStreamWithOptions(ctx context.Context, bucketUrl, key string,
+	opts *blob.WriterOptions,
+) (io.WriteCloser, error) {
+	return nil, fmt.Errorf("unexpected write of %s", key)
+}
+
+func (r fakeErasureBlobRepository) DeleteFile(ctx context.Context, bucketUrl, key string) error {
+	return fmt.Errorf("blob %s deleted before the erasure was committed", key)
+}
+
+func (r fakeErasureBlobRepository) GenerateSignedUrl(ctx context.Context, bucketUrl, key string) (string, error) {
+	return bucketUrl + "/" + key, nil
+}
+
+type erasureTestEnv struct {
+	usecase    ErasureUsecase
+	repository *fakeErasureRepository
+	clientDb   *fakeErasureClientDbRepository
+	taskQueue  *mocks.TaskQueueRepository
+}
+
+func newErasureTestEnv() erasureTestEnv {
+	accounts := models.Table{
+		ID:   "accounts_id",
+		Name: "accounts",
+		Fields: map[string]models.Field{
+			"object_id":  {ID: "account_object_id", Name: "object_id", TableId: "accounts_id"},
+			"updated_at": {ID: "account_updated_at", Name: "updated_at", TableId: "accounts_id"},
+			"iban":       {ID: "account_iban", Name: "iban", TableId: "accounts_id"},
+			"name":       {ID: "account_name", Name: "name", TableId: "accounts_id"},
+		},
+	}
+	transactions := models.Table{
+		ID:   "transactions_id",
+		Name: "transactions",
+		LinksToSingle: map[string]models.LinkToSingle{
+			"account": {
+				ParentTableName: "accounts",
+				ParentFieldName: "object_id",
+				ChildTableName:  "transactions",
+				ChildFieldName:  "account_id",
+			},
+		},
+	}
+
+	createdAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	repository := &fakeErasureRepository{
+		dataModel: models.DataModel{Tables: map[string]models.Table{
+			"accounts":     accounts,
+			"transactions": transactions,
+		}},
+		pivots: []models.PivotMetadata{{Id: "pivot", BaseTableId: "accounts_id", FieldId: utils.Ptr("account_iban")}},
+		decisions: []models.ErasableDecision{
+			{Id: "decision_1", CreatedAt: createdAt, TriggerObjectType: "accounts", ObjectId: "account_1"},
+			// a decision on an account that was deleted from the ingested data, found through its pivot value
+			{Id: "decision_2", CreatedAt: createdAt, TriggerObjectType: "accounts", ObjectId: "account_2"},
+		},
+		rules: []models.DecisionRuleRef{
+			{DecisionId: "decision_1", RuleId: "rule_1", Outcome: "hit"},
+			{DecisionId: "decision_1", RuleId: "rule_2", Outcome: "no_hit"},
+		},
+	}
+	clientDb := &fakeErasureClientDbRepository{
+		objectIdsByValue: map[string][]string{"accounts.iban=FR76": {"account_1"}},
+	}
+	blobs := fakeErasureBlobRepository{blobs: map[string]string{
+		"offloading/offloading/decision_rules/org/decision_1/rule_1/hit": `{"return_value":true}`,
+	}}
+
+	enforceSecurity := new(mocks.EnforceSecurity)
+	enforceSecurity.On("EraseData", "org").Return(nil)
+	taskQueue := new(mocks.TaskQueueRepository)
+	exec := executor_factory.NewExecutorFactoryStub()
+
+	return erasureTestEnv{
+		usecase: ErasureUsecase{
+			enforceSecurity:     enforceSecurity,
+			executorFactory:     exec,
+			transactionFactory:  executor_factory.NewTransactionFactoryStub(exec),
+			repository:          repository,
+			clientDbRepository:  clientDb,
+			taskQueueRepository: taskQueue,
+			blobRepository:      blobs,
+			offloadingBucketUrl: "offloading",
+			credentials:         models.Credentials{ActorIdentity: models.Identity{ApiKeyName: "erasure_key"}},
+		},
+		repository: repository,
+		clientDb:   clientDb,
+		taskQueue:  taskQueue,
+	}
+}
+
+func TestErasureUsecase_Erase_by_pivot_value(t *testing.T) {
+	env := newErasureTestEnv()
+	payload, _ := json.Marshal(models.EntityAnnotationFilePayload{
+		Bucket: "files",
+		Files:  []models.EntityAnnotationFilePayloadFile{{Key: "id_card.pdf"}},
+	})
+	env.repository.annotations = []models.EntityAnnotation{
+		{Id: "annotation_1", AnnotationType: models.EntityAnnotationFile, Payload: payload},
+		{Id: "annotation_2", AnnotationType: models.EntityAnnotationComment},
+	}
+	env.repository.caseFiles = []models.CaseFile{{BucketName: "case_files", FileReference: "statement.pdf"}}
+	env.taskQueue.On("EnqueueBlobDeletionTask", mock.Anything, mock.Anything, "org", []models.BlobRef{
+		{BucketUrl: "offloading", Key: "offloading/decision_rules/org/decision_1/rule_1/hit"},
+		{BucketUrl: "files", Key: "id_card.pdf"},
+		{BucketUrl: "case_files", Key: "statement.pdf"},
+	}).Return(nil)
+
+	certificate, err := env.usecase.Erase(context.Background(), models.ErasureRequest{
+		OrganizationId: "org",
+		ObjectType:     "accounts",
+		PivotValue:     "FR76",
+		Mode:           models.ErasureModePseudonymise,
+	})
+	require.NoError(t, err)
+
+	// the subject is made of the objects with the pivot value, and of the trigger objects of the decisions on it
+	assert.Equal(t, []string{"account_1"}, env.repository.listedObjectIds)
+	assert.Equal(t, "FR76", env.repository.listedPivotValue)
+	assert.Equal(t, []string{"account_1", "account_2"}, env.clientDb.pseudonymisedObjects)
+	assert.Equal(t, []string{"account_1", "account_2"}, env.clientDb.pseudonymisedLinkedTo)
+	assert.Equal(t, []string{"transactions.account_id"}, env.clientDb.pseudonymisedLinksIn)
+	slices.Sort(env.clientDb.pseudonymisedFields)
+	assert.Equal(t, []string{"iban", "name"}, env.clientDb.pseudonymisedFields)
+	assert.Nil(t, env.clientDb.deletedObjectIds)
+
+	assert.Equal(t, []string{"decision_1", "decision_2"}, env.repository.pseudonymisedIds)
+	assert.Equal(t, []string{"account_1", "account_2", "FR76"}, env.repository.erasedValues)
+	assert.Equal(t, []string{"decision_1", "decision_2"}, env.repository.clearedRuleIds)
+	assert.Equal(t, []string{"decision_1", "decision_2"}, env.repository.clearedSanctionsIds)
+	// the change events copy the decisions once they are pseudonymised
+	assert.Equal(t, []string{"decision_1", "decision_2"}, env.repository.changeEventsIds)
+	assert.Equal(t, env.repository.changeEventsIds, env.repository.pseudonymisedBeforeEventsIds)
+
+	assert.Equal(t, models.ErasureCounts{
+		IngestedRows:      2,
+		LinkedRows:        1,
+		Decisions:         2,
+		RuleExecutions:    2,
+		OffloadedRules:    1,
+		EntityAnnotations: 2,
+		CaseFiles:         1,
+	}, certificate.Counts)
+	assert.Equal(t, models.ErasurePseudonym("org", "FR76"), certificate.SubjectPseudonym)
+	assert.Equal(t, "erasure_key", certificate.RequestedByApiKey)
+	env.taskQueue.AssertExpectations(t)
+}
+
+func TestErasureUsecase_Erase_by_object_id(t *testing.T) {
+	env := newErasureTestEnv()
+	env.repository.decisions = env.repository.decisions[:1]
+	env.usecase.offloadingBucketUrl = ""
+
+	certificate, err := env.usecase.Erase(context.Background(), models.ErasureRequest{
+		OrganizationId: "org",
+		ObjectType:     "accounts",
+		ObjectId:       "account_1",
+		Mode:           models.ErasureModeDelete,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"account_1"}, env.clientDb.deletedObjectIds)
+	assert.Nil(t, env.clientDb.pseudonymisedObjects)
+	assert.Equal(t, []string{"account_1"}, env.repository.erasedValues)
+	assert.Equal(t, 0, certificate.Counts.OffloadedRules)
+	// there is no file to delete, so no job is enqueued
+	env.taskQueue.AssertNotCalled(t, "EnqueueBlobDeletionTask", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestErasureUsecase_Erase_legal_hold(t *testing.T) {
+	env := newErasureTestEnv()
+	env.repository.decisions[1].OnLegalHold = true
+
+	_, err := env.usecase.Erase(context.Background(), models.ErasureRequest{
+		OrganizationId: "org",
+		ObjectType:     "accounts",
+		PivotValue:     "FR76",
+		Mode:           models.ErasureModeDelete,
+	})
+	assert.ErrorIs(t, err, models.ConflictError)
+	assert.Nil(t, env.clientDb.deletedObjectIds)
+	assert.Nil(t, env.repository.pseudonymisedIds)
+	assert.Nil(t, env.repository.changeEventsIds)
+}
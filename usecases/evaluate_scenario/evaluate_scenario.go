@@ -81,6 +81,12 @@ type EvaluateAstExpression interface {
 		payload models.ClientObject,
 		dataModel models.DataModel,
 	) (ast.NodeEvaluation, error)
+	EvaluateDecisionAstExpression(
+		ctx context.Context,
+		astExpression ast.Node,
+		decision models.DecisionWithRuleExecutions,
+		dataModel models.DataModel,
+	) (ast.NodeEvaluation, error)
 }
 
 type ScenarioEvaluator struct {
@@ -719,3 +725,39 @@ func (e ScenarioEvaluator) EvalCaseName(
 
 	return returnValue, nil
 }
+
+// EvalCaseRoute returns the inbox and initial tags of the case of a decision, from the first routing rule of the
+// scenario that matches the decision. It falls back to the default inbox of the scenario if no rule matches. A rule
+// that cannot be evaluated is ignored.
+func (e ScenarioEvaluator) EvalCaseRoute(
+	ctx context.Context,
+	params ScenarioEvaluationParameters,
+	scenario models.Scenario,
+	decision models.DecisionWithRuleExecutions,
+	rules []models.CaseRoutingRule,
+) models.CaseRoute {
+	logger := utils.LoggerFromContext(ctx)
+	route := models.CaseRoute{InboxId: *scenario.DecisionToCaseInboxId}
+
+	for _, rule := range rules {
+		evaluation, err := e.evaluateAstExpression.EvaluateDecisionAstExpression(
+			ctx,
+			*rule.Formula,
+			decision,
+			params.DataModel,
+		)
+		if err != nil {
+			if !ast.IsAuthorizedError(err) {
+				logger.WarnContext(ctx, "could not evaluate case routing rule",
+					"rule_id", rule.Id, "scenario_id", scenario.Id, "error", err)
+			}
+			continue
+		}
+
+		if matched, ok := evaluation.ReturnValue.(bool); ok && matched {
+			return models.CaseRoute{InboxId: rule.InboxId, TagIds: rule.TagIds}
+		}
+	}
+
+	return route
+}
@@ -0,0 +1,111 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/repositories"
+)
+
+type inboxCaseWorkflowRepository interface {
+	GetInboxCaseWorkflow(ctx context.Context, exec repositories.Executor,
+		inboxId string) (*models.InboxCaseWorkflow, error)
+	UpsertInboxCaseWorkflow(ctx context.Context, exec repositories.Executor, organizationId, inboxId string,
+		input models.InboxCaseWorkflowInput) (models.InboxCaseWorkflow, error)
+	DeleteInboxCaseWorkflow(ctx context.Context, exec repositories.Executor, inboxId string) error
+	CountInboxCasesWithStatuses(ctx context.Context, exec repositories.Executor, inboxId string,
+		statuses []models.CaseStatus) (int, error)
+}
+
+// GetInboxCaseWorkflow returns the case workflow of an inbox, or nil if the inbox uses the default workflow
+func (usecase *InboxUsecase) GetInboxCaseWorkflow(ctx context.Context, inboxId string) (*models.InboxCaseWorkflow, error) {
+	exec := usecase.executorFactory.NewExecutor()
+	inbox, err := usecase.inboxRepository.GetInboxById(ctx, exec, inboxId)
+	if err != nil {
+		return nil, err
+	}
+	if err := usecase.enforceSecurity.ReadInbox(inbox); err != nil {
+		return nil, err
+	}
+
+	return usecase.caseWorkflowRepository.GetInboxCaseWorkflow(ctx, exec, inboxId)
+}
+
+// UpsertInboxCaseWorkflow replaces the case workflow of an inbox. A custom status cannot be removed while cases of the
+// inbox have it.
+func (usecase *InboxUsecase) UpsertInboxCaseWorkflow(ctx context.Context, inboxId string,
+	input models.InboxCaseWorkflowInput,
+) (models.InboxCaseWorkflow, error) {
+	exec := usecase.executorFactory.NewExecutor()
+	inbox, err := usecase.getUpdatableInbox(ctx, exec, inboxId)
+	if err != nil {
+		return models.InboxCaseWorkflow{}, err
+	}
+	if err := input.Validate(); err != nil {
+		return models.InboxCaseWorkflow{}, err
+	}
+
+	workflow, err := usecase.caseWorkflowRepository.GetInboxCaseWorkflow(ctx, exec, inbox.Id)
+	if err != nil {
+		return models.InboxCaseWorkflow{}, err
+	}
+	if workflow != nil {
+		next := models.InboxCaseWorkflow{Statuses: input.Statuses}
+		removed := make([]models.CaseStatus, 0)
+		for _, status := range workflow.Statuses {
+			if !next.HasStatus(status.Name) {
+				removed = append(removed, status.Name)
+			}
+		}
+		if err := usecase.checkNoCaseWithStatuses(ctx, exec, inbox.Id, removed); err != nil {
+			return models.InboxCaseWorkflow{}, err
+		}
+	}
+
+	return usecase.caseWorkflowRepository.UpsertInboxCaseWorkflow(ctx, exec, inbox.OrganizationId, inbox.Id, input)
+}
+
+// DeleteInboxCaseWorkflow restores the default case workflow of an inbox
+func (usecase *InboxUsecase) DeleteInboxCaseWorkflow(ctx context.Context, inboxId string) error {
+	exec := usecase.executorFactory.NewExecutor()
+	inbox, err := usecase.getUpdatableInbox(ctx, exec, inboxId)
+	if err != nil {
+		return err
+	}
+
+	workflow, err := usecase.caseWorkflowRepository.GetInboxCaseWorkflow(ctx, exec, inbox.Id)
+	if err != nil {
+		return err
+	}
+	if workflow == nil {
+		return errors.Wrap(models.NotFoundError, "this inbox uses the default case workflow")
+	}
+	statuses := make([]models.CaseStatus, 0, len(workflow.Statuses))
+	for _, status := range workflow.Statuses {
+		statuses = append(statuses, status.Name)
+	}
+	if err := usecase.checkNoCaseWithStatuses(ctx, exec, inbox.Id, statuses); err != nil {
+		return err
+	}
+
+	return usecase.caseWorkflowRepository.DeleteInboxCaseWorkflow(ctx, exec, inbox.Id)
+}
+
+func (usecase *InboxUsecase) checkNoCaseWithStatuses(ctx context.Context, exec repositories.Executor,
+	inboxId string, statuses []models.CaseStatus,
+) error {
+	if len(statuses) == 0 {
+		return nil
+	}
+	count, err := usecase.caseWorkflowRepository.CountInboxCasesWithStatuses(ctx, exec, inboxId, statuses)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return errors.Wrapf(models.ConflictError,
+			"%d cases of the inbox have a status that would be removed, their status must be changed first", count)
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/repositories"
+)
+
+type inboxQaRuleRepository interface {
+	ListInboxQaRules(ctx context.Context, exec repositories.Executor, inboxId string) ([]models.InboxQaRule, error)
+	GetInboxQaRule(ctx context.Context, exec repositories.Executor, id string) (models.InboxQaRule, error)
+	CreateInboxQaRule(ctx context.Context, exec repositories.Executor, organizationId, inboxId string,
+		input models.InboxQaRuleInput) (models.InboxQaRule, error)
+	UpdateInboxQaRule(ctx context.Context, exec repositories.Executor, id string,
+		input models.InboxQaRuleInput) (models.InboxQaRule, error)
+	DeleteInboxQaRule(ctx context.Context, exec repositories.Executor, id string) error
+}
+
+func (usecase *InboxUsecase) ListInboxQaRules(ctx context.Context, inboxId string) ([]models.InboxQaRule, error) {
+	exec := usecase.executorFactory.NewExecutor()
+	inbox, err := usecase.inboxRepository.GetInboxById(ctx, exec, inboxId)
+	if err != nil {
+		return nil, err
+	}
+	if err := usecase.enforceSecurity.ReadInbox(inbox); err != nil {
+		return nil, err
+	}
+
+	return usecase.qaRuleRepository.ListInboxQaRules(ctx, exec, inboxId)
+}
+
+// CreateInboxQaRule adds a QA rule to an inbox. It samples the cases of the inbox that are closed from now on.
+func (usecase *InboxUsecase) CreateInboxQaRule(ctx context.Context, inboxId string,
+	input models.InboxQaRuleInput,
+) (models.InboxQaRule, error) {
+	exec := usecase.executorFactory.NewExecutor()
+	inbox, err := usecase.getUpdatableInbox(ctx, exec, inboxId)
+	if err != nil {
+		return models.InboxQaRule{}, err
+	}
+	if err := usecase.validateInboxQaRule(ctx, exec, inbox, input); err != nil {
+		return models.InboxQaRule{}, err
+	}
+
+	return usecase.qaRuleRepository.CreateInboxQaRule(ctx, exec, inbox.OrganizationId, inbox.Id, input)
+}
+
+func (usecase *InboxUsecase) UpdateInboxQaRule(ctx context.Context, inboxId, ruleId string,
+	input models.InboxQaRuleInput,
+) (models.InboxQaRule, error) {
+	exec := usecase.executorFactory.NewExecutor()
+	inbox, err := usecase.getUpdatableInbox(ctx, exec, inboxId)
+	if err != nil {
+		return models.InboxQaRule{}, err
+	}
+	if _, err := usecase.getInboxQaRule(ctx, exec, inbox, ruleId); err != nil {
+		return models.InboxQaRule{}, err
+	}
+	if err := usecase.validateInboxQaRule(ctx, exec, inbox, input); err != nil {
+		return models.InboxQaRule{}, err
+	}
+
+	return usecase.qaRuleRepository.UpdateInboxQaRule(ctx, exec, ruleId, input)
+}
+
+// DeleteInboxQaRule removes a QA rule from an inbox, the reviews it created are kept
+func (usecase *InboxUsecase) DeleteInboxQaRule(ctx context.Context, inboxId, ruleId string) error {
+	exec := usecase.executorFactory.NewExecutor()
+	inbox, err := usecase.getUpdatableInbox(ctx, exec, inboxId)
+	if err != nil {
+		return err
+	}
+	if _, err := usecase.getInboxQaRule(ctx, exec, inbox, ruleId); err != nil {
+		return err
+	}
+
+	return usecase.qaRuleRepository.DeleteInboxQaRule(ctx, exec, ruleId)
+}
+
+func (usecase *InboxUsecase) getInboxQaRule(ctx context.Context, exec repositories.Executor,
+	inbox models.Inbox, ruleId string,
+) (models.InboxQaRule, error) {
+	rule, err := usecase.qaRuleRepository.GetInboxQaRule(ctx, exec, ruleId)
+	if err != nil {
+		return models.InboxQaRule{}, err
+	}
+	if rule.InboxId != inbox.Id {
+		return models.InboxQaRule{}, errors.Wrap(models.NotFoundError, "QA rule not found in this inbox")
+	}
+	return rule, nil
+}
+
+// validateInboxQaRule checks that the reviewers of a QA rule are users of the inbox, so that they can read the cases
+// they review
+func (usecase *InboxUsecase) validateInboxQaRule(ctx context.Context, exec repositories.Executor,
+	inbox models.Inbox, input models.InboxQaRuleInput,
+) error {
+	if err := input.Validate(); err != nil {
+		return err
+	}
+	for _, reviewerId := range input.ReviewerIds {
+		isInboxUser := false
+		for _, inboxUser := range inbox.InboxUsers {
+			if inboxUser.UserId == reviewerId {
+				isInboxUser = true
+				break
+			}
+		}
+		if !isInboxUser {
+			return errors.Wrapf(models.BadParameterError, "the reviewer %s is not a user of the inbox", reviewerId)
+		}
+	}
+	if input.TagId == nil {
+		return nil
+	}
+
+	tag, err := usecase.slaPolicyRepository.GetTagById(ctx, exec, *input.TagId)
+	if errors.Is(err, models.NotFoundError) {
+		return errors.Wrap(models.BadParameterError, "tag not found")
+	} else if err != nil {
+		return err
+	}
+	if tag.OrganizationId != inbox.OrganizationId || tag.Target != models.TagTargetCase {
+		return errors.Wrap(models.BadParameterError, "the tag of a QA rule must be a case tag of the organization")
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/repositories"
+)
+
+type inboxSlaPolicyRepository interface {
+	ListInboxSlaPolicies(ctx context.Context, exec repositories.Executor,
+		inboxId string) ([]models.InboxSlaPolicy, error)
+	GetInboxSlaPolicy(ctx context.Context, exec repositories.Executor, id string) (models.InboxSlaPolicy, error)
+	CreateInboxSlaPolicy(ctx context.Context, exec repositories.Executor, organizationId, inboxId string,
+		input models.InboxSlaPolicyInput) (models.InboxSlaPolicy, error)
+	UpdateInboxSlaPolicy(ctx context.Context, exec repositories.Executor, id string,
+		input models.InboxSlaPolicyInput) (models.InboxSlaPolicy, error)
+	DeleteInboxSlaPolicy(ctx context.Context, exec repositories.Executor, id string) error
+	GetTagById(ctx context.Context, exec repositories.Executor, tagId string) (models.Tag, error)
+}
+
+func (usecase *InboxUsecase) ListInboxSlaPolicies(ctx context.Context, inboxId string) ([]models.InboxSlaPolicy, error) {
+	exec := usecase.executorFactory.NewExecutor()
+	inbox, err := usecase.inboxRepository.GetInboxById(ctx, exec, inboxId)
+	if err != nil {
+		return nil, err
+	}
+	if err := usecase.enforceSecurity.ReadInbox(inbox); err != nil {
+		return nil, err
+	}
+
+	return usecase.slaPolicyRepository.ListInboxSlaPolicies(ctx, exec, inboxId)
+}
+
+// CreateInboxSlaPolicy adds an SLA policy to an inbox. The deadlines of the open cases of the inbox are updated by the
+// next run of the case SLA job.
+func (usecase *InboxUsecase) CreateInboxSlaPolicy(ctx context.Context, inboxId string,
+	input models.InboxSlaPolicyInput,
+) (models.InboxSlaPolicy, error) {
+	exec := usecase.executorFactory.NewExecutor()
+	inbox, err := usecase.getUpdatableInbox(ctx, exec, inboxId)
+	if err != nil {
+		return models.InboxSlaPolicy{}, err
+	}
+	if err := usecase.validateInboxSlaPolicy(ctx, exec, inbox, input); err != nil {
+		return models.InboxSlaPolicy{}, err
+	}
+
+	return usecase.slaPolicyRepository.CreateInboxSlaPolicy(ctx, exec, inbox.OrganizationId, inbox.Id, input)
+}
+
+func (usecase *InboxUsecase) UpdateInboxSlaPolicy(ctx context.Context, inboxId, policyId string,
+	input models.InboxSlaPolicyInput,
+) (models.InboxSlaPolicy, error) {
+	exec := usecase.executorFactory.NewExecutor()
+	inbox, err := usecase.getUpdatableInbox(ctx, exec, inboxId)
+	if err != nil {
+		return models.InboxSlaPolicy{}, err
+	}
+	if _, err := usecase.getInboxSlaPolicy(ctx, exec, inbox, policyId); err != nil {
+		return models.InboxSlaPolicy{}, err
+	}
+	if err := usecase.validateInboxSlaPolicy(ctx, exec, inbox, input); err != nil {
+		return models.InboxSlaPolicy{}, err
+	}
+
+	return usecase.slaPolicyRepository.UpdateInboxSlaPolicy(ctx, exec, policyId, input)
+}
+
+func (usecase *InboxUsecase) DeleteInboxSlaPolicy(ctx context.Context, inboxId, policyId string) error {
+	exec := usecase.executorFactory.NewExecutor()
+	inbox, err := usecase.getUpdatableInbox(ctx, exec, inboxId)
+	if err != nil {
+		return err
+	}
+	if _, err := usecase.getInboxSlaPolicy(ctx, exec, inbox, policyId); err != nil {
+		return err
+	}
+
+	return usecase.slaPolicyRepository.DeleteInboxSlaPolicy(ctx, exec, policyId)
+}
+
+func (usecase *InboxUsecase) getUpdatableInbox(ctx context.Context, exec repositories.Executor,
+	inboxId string,
+) (models.Inbox, error) {
+	inbox, err := usecase.inboxRepository.GetInboxById(ctx, exec, inboxId)
+	if err != nil {
+		return models.Inbox{}, err
+	}
+	if inbox.Status != models.InboxStatusActive {
+		return models.Inbox{}, errors.Wrap(models.ForbiddenError, "This inbox is archived and cannot be updated")
+	}
+	if err := usecase.enforceSecurity.UpdateInbox(inbox); err != nil {
+		return models.Inbox{}, err
+	}
+	return inbox, nil
+}
+
+func (usecase *InboxUsecase) getInboxSlaPolicy(ctx context.Context, exec repositories.Executor,
+	inbox models.Inbox, policyId string,
+) (models.InboxSlaPolicy, error) {
+	policy, err := usecase.slaPolicyRepository.GetInboxSlaPolicy(ctx, exec, policyId)
+	if err != nil {
+		return models.InboxSlaPolicy{}, err
+	}
+	if policy.InboxId != inbox.Id {
+		return models.InboxSlaPolicy{}, errors.Wrap(models.NotFoundError, "SLA policy not found in this inbox")
+	}
+	return policy, nil
+}
+
+func (usecase *InboxUsecase) validateInboxSlaPolicy(ctx context.Context, exec repositories.Executor,
+	inbox models.Inbox, input models.InboxSlaPolicyInput,
+) error {
+	if err := input.Validate(); err != nil {
+		return err
+	}
+	if input.TagId == nil {
+		return nil
+	}
+
+	tag, err := usecase.slaPolicyRepository.GetTagById(ctx, exec, *input.TagId)
+	if errors.Is(err, models.NotFoundError) {
+		return errors.Wrap(models.BadParameterError, "tag not found")
+	} else if err != nil {
+		return err
+	}
+	if tag.OrganizationId != inbox.OrganizationId || tag.Target != models.TagTargetCase {
+		return errors.Wrap(models.BadParameterError, "the tag of an SLA policy must be a case tag of the organization")
+	}
+	return nil
+}
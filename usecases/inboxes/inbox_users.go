@@ -18,7 +18,8 @@ type InboxUserRepository interface {
 		filters models.InboxUserFilterInput) ([]models.InboxUser, error)
 	CreateInboxUser(ctx context.Context, exec repositories.Executor,
 		createInboxUserAttributes models.CreateInboxUserInput, newInboxUserId string) error
-	UpdateInboxUser(ctx context.Context, exec repositories.Executor, inboxUserId string, role models.InboxUserRole) error
+	UpdateInboxUser(ctx context.Context, exec repositories.Executor, inboxUserId string,
+		input models.UpdateInboxUserInput) error
 	DeleteInboxUser(ctx context.Context, exec repositories.Executor, inboxUserId string) error
 }
 
@@ -143,7 +144,9 @@ func (usecase *InboxUsers) CreateInboxUser(ctx context.Context, input models.Cre
 	return inboxUser, nil
 }
 
-func (usecase *InboxUsers) UpdateInboxUser(ctx context.Context, inboxUserId string, role models.InboxUserRole) (models.InboxUser, error) {
+func (usecase *InboxUsers) UpdateInboxUser(ctx context.Context, inboxUserId string,
+	input models.UpdateInboxUserInput,
+) (models.InboxUser, error) {
 	inboxUser, err := executor_factory.TransactionReturnValue(
 		ctx,
 		usecase.TransactionFactory,
@@ -160,12 +163,17 @@ func (usecase *InboxUsers) UpdateInboxUser(ctx context.Context, inboxUserId stri
 				return models.InboxUser{}, err
 			}
 
-			err = usecase.EnforceSecurity.UpdateInboxUser(inboxUser, thisUsersInboxes)
-			if err != nil {
-				return models.InboxUser{}, err
+			// users can set their own availability, other changes require to be an admin of the inbox
+			isOwnAvailability := input.Role == nil && input.SkillTagIds == nil &&
+				inboxUser.UserId == string(usecase.Credentials.ActorIdentity.UserId)
+			if !isOwnAvailability {
+				err = usecase.EnforceSecurity.UpdateInboxUser(inboxUser, thisUsersInboxes)
+				if err != nil {
+					return models.InboxUser{}, err
+				}
 			}
 
-			if err := usecase.InboxUserRepository.UpdateInboxUser(ctx, tx, inboxUserId, role); err != nil {
+			if err := usecase.InboxUserRepository.UpdateInboxUser(ctx, tx, inboxUserId, input); err != nil {
 				return models.InboxUser{}, err
 			}
 
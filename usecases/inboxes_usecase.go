@@ -2,6 +2,7 @@ package usecases
 
 import (
 	"context"
+	"slices"
 
 	"github.com/checkmarble/marble-backend/models"
 	"github.com/checkmarble/marble-backend/pure_utils"
@@ -18,8 +19,7 @@ type InboxRepository interface {
 		inboxIds []string, withCaseCount bool) ([]models.Inbox, error)
 	CreateInbox(ctx context.Context, exec repositories.Executor,
 		createInboxAttributes models.CreateInboxInput, newInboxId string) error
-	UpdateInbox(ctx context.Context, exec repositories.Executor,
-		inboxId, name string, escalationInboxId *string) error
+	UpdateInbox(ctx context.Context, exec repositories.Executor, input models.UpdateInboxInput) error
 	SoftDeleteInbox(ctx context.Context, exec repositories.Executor, inboxId string) error
 
 	ListOrganizationCases(ctx context.Context, exec repositories.Executor, filters models.CaseFilters,
@@ -34,14 +34,17 @@ type EnforceSecurityInboxes interface {
 }
 
 type InboxUsecase struct {
-	transactionFactory executor_factory.TransactionFactory
-	executorFactory    executor_factory.ExecutorFactory
-	enforceSecurity    EnforceSecurityInboxes
-	inboxRepository    InboxRepository
-	userRepository     repositories.UserRepository
-	credentials        models.Credentials
-	inboxReader        inboxes.InboxReader
-	inboxUsers         inboxes.InboxUsers
+	transactionFactory     executor_factory.TransactionFactory
+	executorFactory        executor_factory.ExecutorFactory
+	enforceSecurity        EnforceSecurityInboxes
+	inboxRepository        InboxRepository
+	userRepository         repositories.UserRepository
+	credentials            models.Credentials
+	inboxReader            inboxes.InboxReader
+	inboxUsers             inboxes.InboxUsers
+	slaPolicyRepository    inboxSlaPolicyRepository
+	caseWorkflowRepository inboxCaseWorkflowRepository
+	qaRuleRepository       inboxQaRuleRepository
 }
 
 func (usecase *InboxUsecase) GetInboxMetadataById(ctx context.Context, inboxId string) (models.InboxMetadata, error) {
@@ -109,12 +112,18 @@ func (usecase *InboxUsecase) CreateInbox(ctx context.Context, input models.Creat
 	return inbox, nil
 }
 
-func (usecase *InboxUsecase) UpdateInbox(ctx context.Context, inboxId, name string, escalationInboxId *string) (models.Inbox, error) {
+func (usecase *InboxUsecase) UpdateInbox(ctx context.Context, input models.UpdateInboxInput) (models.Inbox, error) {
+	if input.AssignmentStrategy != nil &&
+		!slices.Contains(models.ValidInboxAssignmentStrategies, *input.AssignmentStrategy) {
+		return models.Inbox{}, errors.Wrapf(models.BadParameterError,
+			"invalid assignment strategy %s", *input.AssignmentStrategy)
+	}
+
 	inbox, err := executor_factory.TransactionReturnValue(
 		ctx,
 		usecase.transactionFactory,
 		func(tx repositories.Transaction) (models.Inbox, error) {
-			inbox, err := usecase.inboxRepository.GetInboxById(ctx, tx, inboxId)
+			inbox, err := usecase.inboxRepository.GetInboxById(ctx, tx, input.Id)
 			if err != nil {
 				return models.Inbox{}, err
 			}
@@ -128,11 +137,11 @@ func (usecase *InboxUsecase) UpdateInbox(ctx context.Context, inboxId, name stri
 				return models.Inbox{}, err
 			}
 
-			if err := usecase.inboxRepository.UpdateInbox(ctx, tx, inboxId, name, escalationInboxId); err != nil {
+			if err := usecase.inboxRepository.UpdateInbox(ctx, tx, input); err != nil {
 				return models.Inbox{}, err
 			}
 
-			return usecase.inboxRepository.GetInboxById(ctx, tx, inboxId)
+			return usecase.inboxRepository.GetInboxById(ctx, tx, input.Id)
 		})
 	if err != nil {
 		return models.Inbox{}, err
@@ -201,8 +210,10 @@ func (usecase *InboxUsecase) CreateInboxUser(ctx context.Context, input models.C
 	return usecase.inboxUsers.CreateInboxUser(ctx, input)
 }
 
-func (usecase *InboxUsecase) UpdateInboxUser(ctx context.Context, inboxUserId string, role models.InboxUserRole) (models.InboxUser, error) {
-	return usecase.inboxUsers.UpdateInboxUser(ctx, inboxUserId, role)
+func (usecase *InboxUsecase) UpdateInboxUser(ctx context.Context, inboxUserId string,
+	input models.UpdateInboxUserInput,
+) (models.InboxUser, error) {
+	return usecase.inboxUsers.UpdateInboxUser(ctx, inboxUserId, input)
 }
 
 func (usecase *InboxUsecase) DeleteInboxUser(ctx context.Context, inboxUserId string) error {
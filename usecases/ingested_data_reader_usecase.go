@@ -27,6 +27,13 @@ type ingestedDataReaderClientDbRepository interface {
 		table models.Table,
 		uniqueFieldValue string,
 		uniqueFieldName string,
+		asOf *time.Time,
+	) ([]models.DataModelObject, error)
+	QueryIngestedObjectHistory(
+		ctx context.Context,
+		exec repositories.Executor,
+		table models.Table,
+		objectId string,
 	) ([]models.DataModelObject, error)
 }
 
@@ -76,6 +83,7 @@ func (usecase IngestedDataReaderUsecase) GetIngestedObject(
 	objectType string,
 	uniqueFieldValue string,
 	uniqueFieldName string,
+	asOf *time.Time,
 ) ([]models.ClientObjectDetail, error) {
 	if dataModel == nil {
 		d, err := usecase.dataModelUsecase.GetDataModel(ctx, organizationId, models.DataModelReadOptions{})
@@ -92,7 +100,8 @@ func (usecase IngestedDataReaderUsecase) GetIngestedObject(
 		return nil, err
 	}
 
-	objects, err := usecase.clientDbRepository.QueryIngestedObjectByUniqueField(ctx, db, table, uniqueFieldValue, uniqueFieldName)
+	objects, err := usecase.clientDbRepository.QueryIngestedObjectByUniqueField(ctx, db, table,
+		uniqueFieldValue, uniqueFieldName, asOf)
 	if err != nil {
 		return nil, err
 	}
@@ -109,6 +118,57 @@ func (usecase IngestedDataReaderUsecase) GetIngestedObject(
 	return clientObjects, nil
 }
 
+// GetIngestedObjectHistory returns all the versions of an ingested object, from the oldest to the most recent, with the
+// fields that changed compared to the previous version.
+func (usecase IngestedDataReaderUsecase) GetIngestedObjectHistory(
+	ctx context.Context,
+	organizationId string,
+	objectType string,
+	objectId string,
+) ([]models.ClientObjectVersion, error) {
+	dataModel, err := usecase.dataModelUsecase.GetDataModel(ctx, organizationId, models.DataModelReadOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	table, ok := dataModel.Tables[objectType]
+	if !ok {
+		return nil, errors.Wrapf(models.NotFoundError, "table %s not found in data model", objectType)
+	}
+
+	db, err := usecase.executorFactory.NewClientDbExecutor(ctx, organizationId)
+	if err != nil {
+		return nil, err
+	}
+
+	objects, err := usecase.clientDbRepository.QueryIngestedObjectHistory(ctx, db, table, objectId)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]models.ClientObjectVersion, len(objects))
+	var previousData map[string]any
+	for i, object := range objects {
+		validFrom, _ := object.Metadata["valid_from"].(time.Time)
+		// The current version has valid_until = 'Infinity', which is not read as a time.Time
+		var validUntil *time.Time
+		if t, ok := object.Metadata["valid_until"].(time.Time); ok {
+			validUntil = &t
+		}
+
+		versions[i] = models.ClientObjectVersion{
+			ObjectType: objectType,
+			ValidFrom:  validFrom,
+			ValidUntil: validUntil,
+			Data:       object.Data,
+			Changes:    models.DiffClientObjectData(previousData, object.Data),
+		}
+		previousData = object.Data
+	}
+
+	return versions, nil
+}
+
 func (usecase IngestedDataReaderUsecase) ReadPivotObjectsFromValues(
 	ctx context.Context,
 	orgId string,
@@ -235,7 +295,8 @@ func (usecase IngestedDataReaderUsecase) enrichPivotObjectWithData(
 		&dataModel,
 		pivotObject.PivotObjectName,
 		pivotObject.PivotValue,
-		pivotObject.PivotFieldName)
+		pivotObject.PivotFieldName,
+		nil)
 	if err != nil {
 		return models.PivotObject{}, err
 	}
@@ -481,7 +542,8 @@ func (usecase IngestedDataReaderUsecase) enrichClientDataObjectWithRelatedObject
 			&dataModel,
 			relatedObjectObjectType,
 			linkValueStr,
-			relatedObjectUniqueField)
+			relatedObjectUniqueField,
+			nil)
 		if err != nil {
 			return models.ClientObjectDetail{}, errors.Wrapf(err,
 				"failed to read related object data of type %s through link %s for object %s: %s",
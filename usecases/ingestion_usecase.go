@@ -33,6 +33,15 @@ const (
 	DefaultApiBatchIngestionSize = 100
 )
 
+type ingestionDecisionUsecase interface {
+	CreateAllDecisionsForIngestedObjects(
+		ctx context.Context,
+		organizationId string,
+		objectType string,
+		objectIds []string,
+	) (models.IngestionDecisions, error)
+}
+
 type IngestionUseCase struct {
 	transactionFactory    executor_factory.TransactionFactory
 	executorFactory       executor_factory.ExecutorFactory
@@ -41,6 +50,8 @@ type IngestionUseCase struct {
 	blobRepository        repositories.BlobRepository
 	dataModelRepository   repositories.DataModelRepository
 	uploadLogRepository   repositories.UploadLogRepository
+	decisionUsecase       ingestionDecisionUsecase
+	taskQueueRepository   repositories.TaskQueueRepository
 	ingestionBucketUrl    string
 	batchIngestionMaxSize int
 }
@@ -51,7 +62,7 @@ func (usecase *IngestionUseCase) IngestObject(
 	objectType string,
 	objectBody json.RawMessage,
 	parserOpts ...payload_parser.ParserOpt,
-) (int, error) {
+) (int, *models.IngestionDecisions, error) {
 	logger := utils.LoggerFromContext(ctx)
 	tracer := utils.OpenTelemetryTracerFromContext(ctx)
 	ctx, span := tracer.Start(
@@ -62,19 +73,19 @@ func (usecase *IngestionUseCase) IngestObject(
 	defer span.End()
 
 	if err := usecase.enforceSecurity.CanIngest(organizationId); err != nil {
-		return 0, err
+		return 0, nil, err
 	}
 
 	exec := usecase.executorFactory.NewExecutor()
 	dataModel, err := usecase.dataModelRepository.GetDataModel(ctx, exec, organizationId, false)
 	if err != nil {
-		return 0, errors.Wrap(err, "error getting data model in IngestObject")
+		return 0, nil, errors.Wrap(err, "error getting data model in IngestObject")
 	}
 
 	tables := dataModel.Tables
 	table, ok := tables[objectType]
 	if !ok {
-		return 0, errors.Wrapf(
+		return 0, nil, errors.Wrapf(
 			models.NotFoundError,
 			"table %s not found in data model in IngestObject", objectType,
 		)
@@ -83,12 +94,13 @@ func (usecase *IngestionUseCase) IngestObject(
 	parser := payload_parser.NewParser(parserOpts...)
 	payload, err := parser.ParsePayload(table, objectBody)
 	if err != nil {
-		return 0, errors.Wrap(err, "error parsing payload in decision usecase validate payload")
+		return 0, nil, errors.Wrap(err, "error parsing payload in decision usecase validate payload")
 	}
 
-	var nb int
+	var ingestedObjectIds []string
 	err = retryIngestion(ctx, func() error {
-		nb, err = usecase.insertEnumValuesAndIngest(ctx, organizationId, []models.ClientObject{payload}, table)
+		ingestedObjectIds, err = usecase.insertEnumValuesAndIngest(ctx, organizationId,
+			[]models.ClientObject{payload}, table)
 		return err
 	})
 	if err != nil {
@@ -96,12 +108,13 @@ func (usecase *IngestionUseCase) IngestObject(
 		if errors.As(err, &validationErrors) {
 			// if err is not nil, the call to the repository may return a models.IngestionValidationErrorsMultiple
 			// instance error, in which case it should have just one entry (with the input object_id as key)
-			// return 0, models.IngestionValidationErrorsSingle(
+			// return 0, nil, models.IngestionValidationErrorsSingle(
 			// 	validationErrors[payload.Data["object_id"].(string)])
-			return 0, validationErrors
+			return 0, nil, validationErrors
 		}
-		return 0, err
+		return 0, nil, err
 	}
+	nb := len(ingestedObjectIds)
 
 	logger.DebugContext(ctx, fmt.Sprintf("Successfully ingested objects: %d objects", nb),
 		slog.String("organization_id", organizationId),
@@ -109,7 +122,15 @@ func (usecase *IngestionUseCase) IngestObject(
 		slog.Int("nb_objects", nb),
 	)
 
-	return nb, nil
+	if nb == 0 {
+		return nb, nil, nil
+	}
+	decisions, err := usecase.triggerDecisions(ctx, organizationId, table, ingestedObjectIds)
+	if err != nil {
+		return nb, nil, err
+	}
+
+	return nb, decisions, nil
 }
 
 func (usecase *IngestionUseCase) IngestObjects(
@@ -118,7 +139,7 @@ func (usecase *IngestionUseCase) IngestObjects(
 	objectType string,
 	objectBody json.RawMessage,
 	parserOpts ...payload_parser.ParserOpt,
-) (int, error) {
+) (int, *models.IngestionDecisions, error) {
 	logger := utils.LoggerFromContext(ctx)
 	tracer := utils.OpenTelemetryTracerFromContext(ctx)
 	ctx, span := tracer.Start(
@@ -129,27 +150,27 @@ func (usecase *IngestionUseCase) IngestObjects(
 	defer span.End()
 
 	if err := usecase.enforceSecurity.CanIngest(organizationId); err != nil {
-		return 0, err
+		return 0, nil, err
 	}
 
 	var rawMessages []json.RawMessage
 	if err := json.Unmarshal(objectBody, &rawMessages); err != nil {
-		return 0, errors.Wrap(models.BadParameterError,
+		return 0, nil, errors.Wrap(models.BadParameterError,
 			"error unmarshalling objectBody in IngestObjects")
 	}
 	if len(rawMessages) > usecase.batchIngestionMaxSize {
-		return 0, errors.Wrap(models.BadParameterError, "too many objects in the batch")
+		return 0, nil, errors.Wrap(models.BadParameterError, "too many objects in the batch")
 	}
 
 	exec := usecase.executorFactory.NewExecutor()
 	dataModel, err := usecase.dataModelRepository.GetDataModel(ctx, exec, organizationId, false)
 	if err != nil {
-		return 0, errors.Wrap(err, "error getting data model in IngestObjects")
+		return 0, nil, errors.Wrap(err, "error getting data model in IngestObjects")
 	}
 
 	table, ok := dataModel.Tables[objectType]
 	if !ok {
-		return 0, errors.Wrapf(
+		return 0, nil, errors.Wrapf(
 			models.NotFoundError,
 			"table %s not found in data model in IngestObjects", objectType,
 		)
@@ -167,31 +188,32 @@ func (usecase *IngestionUseCase) IngestObjects(
 			validationErrorsGroup[objectId] = errMap
 			continue
 		} else if err != nil {
-			return 0, errors.Wrapf(
+			return 0, nil, errors.Wrapf(
 				models.BadParameterError,
 				"Error while validating payload in IngestObjects: %v", err,
 			)
 		}
 		objectId := payload.Data["object_id"].(string)
 		if _, ok := objectIds[objectId]; ok {
-			return 0, errors.Wrapf(models.BadParameterError,
+			return 0, nil, errors.Wrapf(models.BadParameterError,
 				"duplicate object_id %s in the batch", objectId)
 		}
 		objectIds[objectId] = struct{}{}
 		clientObjects = append(clientObjects, payload)
 	}
 	if len(validationErrorsGroup) > 0 {
-		return 0, validationErrorsGroup
+		return 0, nil, validationErrorsGroup
 	}
 
-	var nb int
+	var ingestedObjectIds []string
 	err = retryIngestion(ctx, func() error {
-		nb, err = usecase.insertEnumValuesAndIngest(ctx, organizationId, clientObjects, table)
+		ingestedObjectIds, err = usecase.insertEnumValuesAndIngest(ctx, organizationId, clientObjects, table)
 		return err
 	})
 	if err != nil {
-		return 0, err
+		return 0, nil, err
 	}
+	nb := len(ingestedObjectIds)
 
 	logger.DebugContext(ctx, fmt.Sprintf("Successfully ingested objects: %d objects", nb),
 		slog.String("organization_id", organizationId),
@@ -199,7 +221,50 @@ func (usecase *IngestionUseCase) IngestObjects(
 		slog.Int("nb_objects", nb),
 	)
 
-	return nb, nil
+	if nb == 0 {
+		return nb, nil, nil
+	}
+	decisions, err := usecase.triggerDecisions(ctx, organizationId, table, ingestedObjectIds)
+	if err != nil {
+		return nb, nil, err
+	}
+
+	return nb, decisions, nil
+}
+
+// triggerDecisions creates decisions on the freshly ingested objects, depending on the decision trigger mode of the table.
+// Only the objects that were written are passed, the payloads that were older than the ingested objects are skipped.
+// Decisions are only returned in sync mode, in async mode they are created later by a task queue worker. In sync mode,
+// the objects are already ingested when the decisions are created, so a failure to create them is reported in the
+// result instead of failing the ingestion.
+func (usecase *IngestionUseCase) triggerDecisions(
+	ctx context.Context,
+	organizationId string,
+	table models.Table,
+	objectIds []string,
+) (*models.IngestionDecisions, error) {
+	switch table.DecisionTriggerMode {
+	case models.DecisionTriggerModeSync:
+		decisions, err := usecase.decisionUsecase.CreateAllDecisionsForIngestedObjects(
+			ctx, organizationId, table.Name, objectIds)
+		if err != nil {
+			decisions = models.IngestionDecisions{Decisions: make([]models.DecisionWithRuleExecutions, 0)}
+			for _, objectId := range objectIds {
+				decisions.Errors = append(decisions.Errors, models.IngestionDecisionError{ObjectId: objectId, Err: err})
+			}
+		}
+		for _, decisionError := range decisions.Errors {
+			utils.LogAndReportSentryError(ctx, errors.Wrapf(decisionError.Err,
+				"error creating decisions on ingested object %s", decisionError.ObjectId))
+		}
+		return &decisions, nil
+	case models.DecisionTriggerModeAsync:
+		if err := usecase.taskQueueRepository.EnqueueIngestedObjectsDecisionTask(
+			ctx, organizationId, table.Name, objectIds); err != nil {
+			return nil, errors.Wrap(err, "error enqueuing decisions on ingested objects")
+		}
+	}
+	return nil, nil
 }
 
 func (usecase *IngestionUseCase) ListUploadLogs(ctx context.Context,
@@ -549,9 +614,9 @@ func (usecase *IngestionUseCase) ingestObjectsFromCSV(ctx context.Context, organ
 			clientObjects = append(clientObjects, clientObject)
 		}
 
-		var nb int
+		var ingestedObjectIds []string
 		if err := retryIngestion(ctx, func() error {
-			nb, err = usecase.insertEnumValuesAndIngest(ctx, organizationId, clientObjects, table)
+			ingestedObjectIds, err = usecase.insertEnumValuesAndIngest(ctx, organizationId, clientObjects, table)
 			return err
 		}); err != nil {
 			return ingestionResult{
@@ -559,7 +624,7 @@ func (usecase *IngestionUseCase) ingestObjectsFromCSV(ctx context.Context, organ
 				err:             err,
 			}
 		}
-		total += nb
+		total += len(ingestedObjectIds)
 	}
 
 	return ingestionResult{
@@ -578,6 +643,7 @@ func containsString(arr []string, s string) bool {
 
 func parseStringValuesToMap(headers []string, values []string, table models.Table) (map[string]any, error) {
 	result := make(map[string]any)
+	now := time.Now()
 
 	for i, value := range values {
 		fieldName := headers[i]
@@ -635,6 +701,9 @@ func parseStringValuesToMap(headers []string, values []string, table models.Tabl
 			return nil, fmt.Errorf("invalid data type %s for field %s", field.DataType, fieldName)
 		}
 
+		if err := field.Constraints.Check(result[fieldName], now); err != nil {
+			return nil, fmt.Errorf("value %s for field %s %w", value, fieldName, err)
+		}
 	}
 	return result, nil
 }
@@ -662,15 +731,15 @@ func (usecase *IngestionUseCase) insertEnumValuesAndIngest(
 	organizationId string,
 	payloads []models.ClientObject,
 	table models.Table,
-) (int, error) {
-	var nb int
+) ([]string, error) {
+	var ingestedObjectIds []string
 	var err error
 	err = usecase.transactionFactory.TransactionInOrgSchema(ctx, organizationId, func(tx repositories.Transaction) error {
-		nb, err = usecase.ingestionRepository.IngestObjects(ctx, tx, payloads, table)
+		ingestedObjectIds, err = usecase.ingestionRepository.IngestObjects(ctx, tx, payloads, table)
 		return err
 	})
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 
 	go func() {
@@ -693,7 +762,7 @@ func (usecase *IngestionUseCase) insertEnumValuesAndIngest(
 		}
 	}()
 
-	return nb, nil
+	return ingestedObjectIds, nil
 }
 
 func buildEnumValuesContainersFromTable(table models.Table) models.EnumValues {
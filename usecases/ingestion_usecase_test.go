@@ -3,6 +3,7 @@ package usecases
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"testing"
 	"time"
 
@@ -114,6 +115,7 @@ type IngestionUsecaseTestSuite struct {
 	executorFactory     executor_factory.ExecutorFactoryStub
 	transactionFactory  executor_factory.TransactionFactoryStub
 	dataModelRepository *mocks.DataModelRepository
+	taskQueueRepository *mocks.TaskQueueRepository
 
 	organizationId string
 	dataModel      models.DataModel
@@ -128,6 +130,7 @@ func (suite *IngestionUsecaseTestSuite) makeUsecase() *IngestionUseCase {
 		enforceSecurity:       suite.enforceSecurity,
 		ingestionRepository:   &repositories.IngestionRepositoryImpl{},
 		dataModelRepository:   suite.dataModelRepository,
+		taskQueueRepository:   suite.taskQueueRepository,
 		batchIngestionMaxSize: 100,
 	}
 }
@@ -137,6 +140,7 @@ func (suite *IngestionUsecaseTestSuite) SetupTest() {
 	suite.executorFactory = executor_factory.NewExecutorFactoryStub()
 	suite.transactionFactory = executor_factory.NewTransactionFactoryStub(suite.executorFactory)
 	suite.dataModelRepository = new(mocks.DataModelRepository)
+	suite.taskQueueRepository = new(mocks.TaskQueueRepository)
 
 	suite.organizationId = "org_id"
 	suite.dataModel = models.DataModel{
@@ -170,6 +174,7 @@ func (suite *IngestionUsecaseTestSuite) AssertExpectations() {
 		"ExecutorFactory expectations were not met")
 	suite.dataModelRepository.AssertExpectations(t)
 	suite.dataModelRepository.AssertExpectations(t)
+	suite.taskQueueRepository.AssertExpectations(t)
 	suite.enforceSecurity.AssertExpectations(t)
 }
 
@@ -203,7 +208,7 @@ func (suite *IngestionUsecaseTestSuite) TestIngestionUsecase_IngestObject_nomina
 		mock.MatchedBy(matchExec), models.EnumValues{}, suite.dataModel.Tables["transactions"]).
 		Return(nil)
 
-	nb, err := uc.IngestObject(suite.ctx, suite.organizationId, "transactions",
+	nb, _, err := uc.IngestObject(suite.ctx, suite.organizationId, "transactions",
 		json.RawMessage(`{"object_id": "1", "updated_at": "2020-01-01T00:00:00Z", "value": 1.0, "status": "OK"}`))
 	asserts := assert.New(t)
 	asserts.NoError(err, "Error ingesting object")
@@ -233,7 +238,7 @@ func (suite *IngestionUsecaseTestSuite) TestIngestionUsecase_IngestObject_nomina
 		mock.MatchedBy(matchExec), models.EnumValues{}, suite.dataModel.Tables["transactions"]).
 		Return(nil)
 
-	nb, err := uc.IngestObject(suite.ctx, suite.organizationId, "transactions",
+	nb, _, err := uc.IngestObject(suite.ctx, suite.organizationId, "transactions",
 		json.RawMessage(`{"object_id": "1", "updated_at": "2020-01-01T00:00:00Z", "value": 1.0, "status": "OK"}`))
 	asserts := assert.New(t)
 	asserts.NoError(err, "Error ingesting object")
@@ -272,7 +277,7 @@ func (suite *IngestionUsecaseTestSuite) TestIngestionUsecase_IngestObject_nomina
 		mock.MatchedBy(matchExec), values, dataModel.Tables["transactions"]).
 		Return(nil)
 
-	nb, err := uc.IngestObject(suite.ctx, suite.organizationId, "transactions",
+	nb, _, err := uc.IngestObject(suite.ctx, suite.organizationId, "transactions",
 		json.RawMessage(`{"object_id": "1", "updated_at": "2020-01-01T00:00:00Z", "value": 1.0, "status": "OK"}`))
 
 	asserts := assert.New(t)
@@ -302,7 +307,7 @@ func (suite *IngestionUsecaseTestSuite) TestIngestionUsecase_IngestObject_nomina
 		mock.MatchedBy(matchExec), models.EnumValues{}, suite.dataModel.Tables["transactions"]).
 		Return(nil)
 
-	nb, err := uc.IngestObject(suite.ctx, suite.organizationId, "transactions",
+	nb, _, err := uc.IngestObject(suite.ctx, suite.organizationId, "transactions",
 		json.RawMessage(`{"object_id": "1", "updated_at": "2020-01-01T00:00:00Z", "value": 1.0, "status": "OK"}`))
 	asserts := assert.New(t)
 	asserts.NoError(err, "Error ingesting object")
@@ -340,7 +345,7 @@ func (suite *IngestionUsecaseTestSuite) TestIngestionUsecase_IngestObject_nomina
 		mock.MatchedBy(matchExec), models.EnumValues{}, suite.dataModel.Tables["transactions"]).
 		Return(nil)
 
-	nb, err := uc.IngestObject(suite.ctx, suite.organizationId, "transactions",
+	nb, _, err := uc.IngestObject(suite.ctx, suite.organizationId, "transactions",
 		json.RawMessage(`{"object_id": "1", "updated_at": "2020-01-01T00:00:00Z"}`), payload_parser.WithAllowPatch())
 	asserts := assert.New(t)
 	asserts.NoError(err, "Error ingesting object")
@@ -371,7 +376,7 @@ func (suite *IngestionUsecaseTestSuite) TestIngestionUsecase_IngestObject_withou
 		mock.MatchedBy(matchExec), models.EnumValues{}, suite.dataModel.Tables["transactions"]).
 		Return(nil)
 
-	_, err := uc.IngestObject(suite.ctx, suite.organizationId, "transactions",
+	_, _, err := uc.IngestObject(suite.ctx, suite.organizationId, "transactions",
 		json.RawMessage(`{"object_id": "1", "updated_at": "2020-01-01T00:00:00Z"}`), payload_parser.WithAllowPatch())
 	asserts := assert.New(t)
 	asserts.ErrorAs(err, &models.IngestionValidationErrors{}, "Error ingesting object")
@@ -402,13 +407,154 @@ func (suite *IngestionUsecaseTestSuite) TestIngestionUsecase_IngestObjects_nomin
 		mock.MatchedBy(matchExec), models.EnumValues{}, suite.dataModel.Tables["transactions"]).
 		Return(nil)
 
-	nb, err := uc.IngestObjects(suite.ctx, suite.organizationId, "transactions",
+	nb, _, err := uc.IngestObjects(suite.ctx, suite.organizationId, "transactions",
 		json.RawMessage(`[{"object_id": "1", "updated_at": "2020-01-01T00:00:00Z", "value": 1.0, "status": "OK"}, {"object_id": "2", "updated_at": "2020-01-01T00:00:00Z", "value": 2.0, "status": "OK"}]`))
 	asserts := assert.New(t)
 	asserts.NoError(err, "Error ingesting objects")
 	asserts.Equal(2, nb, "Number of rows affected")
 }
 
+func (suite *IngestionUsecaseTestSuite) TestIngestionUsecase_IngestObjects_async_decisions() {
+	t := suite.T()
+	uc := suite.makeUsecase()
+
+	dataModel := suite.dataModel.Copy()
+	table := dataModel.Tables["transactions"]
+	table.DecisionTriggerMode = models.DecisionTriggerModeAsync
+	dataModel.Tables["transactions"] = table
+
+	suite.enforceSecurity.On("CanIngest", suite.organizationId).Return(nil)
+	suite.dataModelRepository.On("GetDataModel", mock.MatchedBy(matchContext),
+		mock.MatchedBy(matchExec), suite.organizationId, false).
+		Return(dataModel, nil)
+
+	updAt, _ := time.Parse(time.RFC3339, "2020-01-01T00:00:00Z")
+	suite.executorFactory.Mock.ExpectQuery(escapeSql(`SELECT object_id, updated_at, id FROM "test"."transactions" WHERE "test"."transactions".valid_until = $1 AND object_id IN ($2,$3)`)).
+		WithArgs("Infinity", "1", "2").
+		WillReturnRows(pgxmock.NewRows([]string{"object_id", "updated_at", "id"}))
+	suite.executorFactory.Mock.ExpectExec(escapeSql(`INSERT INTO "test"."transactions" (object_id,status,updated_at,value,id) VALUES ($1,$2,$3,$4,$5),($6,$7,$8,$9,$10)`)).
+		WithArgs(
+			"1", "OK", updAt, 1.0, anyUuid{},
+			"2", "OK", updAt, 2.0, anyUuid{}).
+		WillReturnResult(pgxmock.NewResult("INSERT", 2))
+
+	suite.dataModelRepository.On("BatchInsertEnumValues", mock.MatchedBy(matchContext),
+		mock.MatchedBy(matchExec), models.EnumValues{}, table).
+		Return(nil)
+	suite.taskQueueRepository.On("EnqueueIngestedObjectsDecisionTask", mock.MatchedBy(matchContext),
+		suite.organizationId, "transactions", []string{"1", "2"}).
+		Return(nil)
+
+	nb, decisions, err := uc.IngestObjects(suite.ctx, suite.organizationId, "transactions",
+		json.RawMessage(`[{"object_id": "1", "updated_at": "2020-01-01T00:00:00Z", "value": 1.0, "status": "OK"}, {"object_id": "2", "updated_at": "2020-01-01T00:00:00Z", "value": 2.0, "status": "OK"}]`))
+	asserts := assert.New(t)
+	asserts.NoError(err, "Error ingesting objects")
+	asserts.Equal(2, nb, "Number of rows affected")
+	asserts.Nil(decisions, "No decisions are returned in async mode")
+	suite.AssertExpectations()
+}
+
+func (suite *IngestionUsecaseTestSuite) TestIngestionUsecase_IngestObjects_decisions_on_written_objects_only() {
+	t := suite.T()
+	uc := suite.makeUsecase()
+
+	dataModel := suite.dataModel.Copy()
+	table := dataModel.Tables["transactions"]
+	table.DecisionTriggerMode = models.DecisionTriggerModeAsync
+	dataModel.Tables["transactions"] = table
+
+	suite.enforceSecurity.On("CanIngest", suite.organizationId).Return(nil)
+	suite.dataModelRepository.On("GetDataModel", mock.MatchedBy(matchContext),
+		mock.MatchedBy(matchExec), suite.organizationId, false).
+		Return(dataModel, nil)
+
+	rowId2 := utils.ByteUuid("27c5805e-eb8f-48f1-afd4-10ad5494954b")
+	updAt, _ := time.Parse(time.RFC3339, "2020-01-01T00:00:00Z")
+	// the ingested version of object 2 is more recent than the payload
+	suite.executorFactory.Mock.ExpectQuery(escapeSql(`SELECT object_id, updated_at, id FROM "test"."transactions" WHERE "test"."transactions".valid_until = $1 AND object_id IN ($2,$3)`)).
+		WithArgs("Infinity", "1", "2").
+		WillReturnRows(pgxmock.NewRows([]string{"object_id", "updated_at", "id"}).
+			AddRow("2", updAt.Add(time.Hour), rowId2))
+	suite.executorFactory.Mock.ExpectExec(escapeSql(`INSERT INTO "test"."transactions" (object_id,status,updated_at,value,id) VALUES ($1,$2,$3,$4,$5)`)).
+		WithArgs("1", "OK", updAt, 1.0, anyUuid{}).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+	suite.dataModelRepository.On("BatchInsertEnumValues", mock.MatchedBy(matchContext),
+		mock.MatchedBy(matchExec), models.EnumValues{}, table).
+		Return(nil)
+	suite.taskQueueRepository.On("EnqueueIngestedObjectsDecisionTask", mock.MatchedBy(matchContext),
+		suite.organizationId, "transactions", []string{"1"}).
+		Return(nil)
+
+	nb, _, err := uc.IngestObjects(suite.ctx, suite.organizationId, "transactions",
+		json.RawMessage(`[{"object_id": "1", "updated_at": "2020-01-01T00:00:00Z", "value": 1.0, "status": "OK"}, {"object_id": "2", "updated_at": "2020-01-01T00:00:00Z", "value": 2.0, "status": "OK"}]`))
+	asserts := assert.New(t)
+	asserts.NoError(err, "Error ingesting objects")
+	asserts.Equal(1, nb, "Number of rows affected")
+	suite.AssertExpectations()
+}
+
+type ingestionDecisionUsecaseMock struct {
+	mock.Mock
+}
+
+func (m *ingestionDecisionUsecaseMock) CreateAllDecisionsForIngestedObjects(ctx context.Context,
+	organizationId string, objectType string, objectIds []string,
+) (models.IngestionDecisions, error) {
+	args := m.Called(organizationId, objectType, objectIds)
+	return args.Get(0).(models.IngestionDecisions), args.Error(1)
+}
+
+func (suite *IngestionUsecaseTestSuite) TestIngestionUsecase_IngestObjects_sync_decisions_error() {
+	t := suite.T()
+	uc := suite.makeUsecase()
+	decisionUsecase := &ingestionDecisionUsecaseMock{}
+	uc.decisionUsecase = decisionUsecase
+
+	dataModel := suite.dataModel.Copy()
+	table := dataModel.Tables["transactions"]
+	table.DecisionTriggerMode = models.DecisionTriggerModeSync
+	dataModel.Tables["transactions"] = table
+
+	suite.enforceSecurity.On("CanIngest", suite.organizationId).Return(nil)
+	suite.dataModelRepository.On("GetDataModel", mock.MatchedBy(matchContext),
+		mock.MatchedBy(matchExec), suite.organizationId, false).
+		Return(dataModel, nil)
+
+	updAt, _ := time.Parse(time.RFC3339, "2020-01-01T00:00:00Z")
+	suite.executorFactory.Mock.ExpectQuery(escapeSql(`SELECT object_id, updated_at, id FROM "test"."transactions" WHERE "test"."transactions".valid_until = $1 AND object_id IN ($2,$3)`)).
+		WithArgs("Infinity", "1", "2").
+		WillReturnRows(pgxmock.NewRows([]string{"object_id", "updated_at", "id"}))
+	suite.executorFactory.Mock.ExpectExec(escapeSql(`INSERT INTO "test"."transactions" (object_id,status,updated_at,value,id) VALUES ($1,$2,$3,$4,$5),($6,$7,$8,$9,$10)`)).
+		WithArgs(
+			"1", "OK", updAt, 1.0, anyUuid{},
+			"2", "OK", updAt, 2.0, anyUuid{}).
+		WillReturnResult(pgxmock.NewResult("INSERT", 2))
+
+	suite.dataModelRepository.On("BatchInsertEnumValues", mock.MatchedBy(matchContext),
+		mock.MatchedBy(matchExec), models.EnumValues{}, table).
+		Return(nil)
+	decisionError := models.IngestionDecisionError{ObjectId: "2", Err: errors.New("scenario evaluation failed")}
+	decisionUsecase.On("CreateAllDecisionsForIngestedObjects", suite.organizationId, "transactions",
+		[]string{"1", "2"}).
+		Return(models.IngestionDecisions{
+			Decisions: []models.DecisionWithRuleExecutions{{Decision: models.Decision{DecisionId: "decision"}}},
+			Errors:    []models.IngestionDecisionError{decisionError},
+		}, nil)
+
+	nb, decisions, err := uc.IngestObjects(suite.ctx, suite.organizationId, "transactions",
+		json.RawMessage(`[{"object_id": "1", "updated_at": "2020-01-01T00:00:00Z", "value": 1.0, "status": "OK"}, {"object_id": "2", "updated_at": "2020-01-01T00:00:00Z", "value": 2.0, "status": "OK"}]`))
+	asserts := assert.New(t)
+	asserts.NoError(err, "The ingestion succeeds even if decisions could not be created")
+	asserts.Equal(2, nb, "Number of rows affected")
+	if asserts.NotNil(decisions) {
+		asserts.Len(decisions.Decisions, 1)
+		asserts.Equal([]models.IngestionDecisionError{decisionError}, decisions.Errors)
+	}
+	suite.AssertExpectations()
+	decisionUsecase.AssertExpectations(t)
+}
+
 func (suite *IngestionUsecaseTestSuite) TestIngestionUsecase_IngestObjects_with_previous_versions() {
 	t := suite.T()
 	uc := suite.makeUsecase()
@@ -445,7 +591,7 @@ func (suite *IngestionUsecaseTestSuite) TestIngestionUsecase_IngestObjects_with_
 		mock.MatchedBy(matchExec), models.EnumValues{}, suite.dataModel.Tables["transactions"]).
 		Return(nil)
 
-	nb, err := uc.IngestObjects(suite.ctx, suite.organizationId, "transactions",
+	nb, _, err := uc.IngestObjects(suite.ctx, suite.organizationId, "transactions",
 		json.RawMessage(`[{"object_id": "1", "updated_at": "2020-01-01T00:00:00Z", "value": 1.0, "status": "OK"}, {"object_id": "2", "updated_at": "2020-01-01T00:00:00Z", "value": 2.0, "status": "OK"}]`))
 	asserts := assert.New(t)
 	asserts.NoError(err, "Error ingesting objects")
@@ -482,7 +628,7 @@ func (suite *IngestionUsecaseTestSuite) TestIngestionUsecase_IngestObjects_with_
 		mock.MatchedBy(matchExec), models.EnumValues{}, suite.dataModel.Tables["transactions"]).
 		Return(nil)
 
-	nb, err := uc.IngestObjects(suite.ctx, suite.organizationId, "transactions",
+	nb, _, err := uc.IngestObjects(suite.ctx, suite.organizationId, "transactions",
 		json.RawMessage(`[{"object_id": "1", "updated_at": "2020-01-01T00:00:00Z"}, {"object_id": "2", "updated_at": "2020-01-01T00:00:00Z", "value": 2.0, "status": "OK"}]`), payload_parser.WithAllowPatch())
 	asserts := assert.New(t)
 	asserts.NoError(err, "Error ingesting objects")
@@ -498,7 +644,7 @@ func (suite *IngestionUsecaseTestSuite) TestIngestionUsecase_IngestObjects_with_
 		mock.MatchedBy(matchExec), suite.organizationId, false).
 		Return(suite.dataModel, nil)
 
-	_, err := uc.IngestObjects(suite.ctx, suite.organizationId, "transactions",
+	_, _, err := uc.IngestObjects(suite.ctx, suite.organizationId, "transactions",
 		json.RawMessage(`[{"object_id": "", "updated_at": "2020-01-01T00:00:00Z", "value": 1.0, "status": "OK"}, {"object_id": "2", "updated_at": "2020-01-01T00:00:00Z", "value": 2.0, "status": "OK"}]`))
 	asserts := assert.New(t)
 	asserts.ErrorAs(err, &models.IngestionValidationErrors{}, "Error ingesting objects")
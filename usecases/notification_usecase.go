@@ -0,0 +1,145 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/repositories"
+	"github.com/checkmarble/marble-backend/usecases/executor_factory"
+)
+
+const (
+	defaultNotificationsLimit = 50
+	maxNotificationsLimit     = 200
+)
+
+type NotificationRepository interface {
+	GetNotificationById(ctx context.Context, exec repositories.Executor, id string) (models.Notification, error)
+	ListNotifications(ctx context.Context, exec repositories.Executor,
+		filters models.NotificationFilters) ([]models.Notification, error)
+	CountUnreadNotifications(ctx context.Context, exec repositories.Executor, userId string) (int, error)
+	MarkNotificationRead(ctx context.Context, exec repositories.Executor, id string) error
+	MarkAllNotificationsRead(ctx context.Context, exec repositories.Executor, userId string) (int, error)
+	ListNotificationPreferences(ctx context.Context, exec repositories.Executor,
+		userId string) ([]models.NotificationPreference, error)
+	UpsertNotificationPreference(ctx context.Context, exec repositories.Executor,
+		userId string, preference models.NotificationPreference) error
+}
+
+// NotificationUsecase manages the notification inbox and the notification preferences of the current user. The
+// notifications themselves are created by the notification job.
+type NotificationUsecase struct {
+	executorFactory    executor_factory.ExecutorFactory
+	transactionFactory executor_factory.TransactionFactory
+	repository         NotificationRepository
+	credentials        models.Credentials
+}
+
+// currentUserId returns the user the notifications belong to, API keys do not have any notifications
+func (uc NotificationUsecase) currentUserId() (string, error) {
+	userId := string(uc.credentials.ActorIdentity.UserId)
+	if userId == "" {
+		return "", errors.Wrap(models.ForbiddenError, "notifications are only available to users")
+	}
+	return userId, nil
+}
+
+func (uc NotificationUsecase) ListNotifications(ctx context.Context,
+	filters models.NotificationFilters,
+) ([]models.Notification, error) {
+	userId, err := uc.currentUserId()
+	if err != nil {
+		return nil, err
+	}
+	filters.UserId = userId
+	if filters.Limit <= 0 {
+		filters.Limit = defaultNotificationsLimit
+	}
+	if filters.Limit > maxNotificationsLimit {
+		return nil, errors.Wrapf(models.BadParameterError, "limit must be at most %d", maxNotificationsLimit)
+	}
+
+	return uc.repository.ListNotifications(ctx, uc.executorFactory.NewExecutor(), filters)
+}
+
+func (uc NotificationUsecase) CountUnreadNotifications(ctx context.Context) (int, error) {
+	userId, err := uc.currentUserId()
+	if err != nil {
+		return 0, err
+	}
+	return uc.repository.CountUnreadNotifications(ctx, uc.executorFactory.NewExecutor(), userId)
+}
+
+func (uc NotificationUsecase) MarkNotificationRead(ctx context.Context, id string) (models.Notification, error) {
+	userId, err := uc.currentUserId()
+	if err != nil {
+		return models.Notification{}, err
+	}
+
+	return executor_factory.TransactionReturnValue(ctx, uc.transactionFactory, func(
+		tx repositories.Transaction,
+	) (models.Notification, error) {
+		notification, err := uc.repository.GetNotificationById(ctx, tx, id)
+		if err != nil {
+			return models.Notification{}, err
+		}
+		// the notifications of other users are reported as not found rather than forbidden
+		if notification.UserId != userId {
+			return models.Notification{}, errors.Wrap(models.NotFoundError, "notification not found")
+		}
+		if err := uc.repository.MarkNotificationRead(ctx, tx, id); err != nil {
+			return models.Notification{}, err
+		}
+		return uc.repository.GetNotificationById(ctx, tx, id)
+	})
+}
+
+func (uc NotificationUsecase) MarkAllNotificationsRead(ctx context.Context) (int, error) {
+	userId, err := uc.currentUserId()
+	if err != nil {
+		return 0, err
+	}
+	return uc.repository.MarkAllNotificationsRead(ctx, uc.executorFactory.NewExecutor(), userId)
+}
+
+// GetNotificationPreferences returns the preferences of the current user for every type of notification
+func (uc NotificationUsecase) GetNotificationPreferences(ctx context.Context) ([]models.NotificationPreference, error) {
+	userId, err := uc.currentUserId()
+	if err != nil {
+		return nil, err
+	}
+
+	saved, err := uc.repository.ListNotificationPreferences(ctx, uc.executorFactory.NewExecutor(), userId)
+	if err != nil {
+		return nil, err
+	}
+	return models.NotificationPreferences(saved), nil
+}
+
+// UpdateNotificationPreferences saves the given preferences, the types of notifications that are not part of the
+// input keep their current preference
+func (uc NotificationUsecase) UpdateNotificationPreferences(ctx context.Context,
+	preferences []models.NotificationPreference,
+) ([]models.NotificationPreference, error) {
+	userId, err := uc.currentUserId()
+	if err != nil {
+		return nil, err
+	}
+
+	return executor_factory.TransactionReturnValue(ctx, uc.transactionFactory, func(
+		tx repositories.Transaction,
+	) ([]models.NotificationPreference, error) {
+		for _, preference := range preferences {
+			if err := uc.repository.UpsertNotificationPreference(ctx, tx, userId, preference); err != nil {
+				return nil, err
+			}
+		}
+		saved, err := uc.repository.ListNotificationPreferences(ctx, tx, userId)
+		if err != nil {
+			return nil, err
+		}
+		return models.NotificationPreferences(saved), nil
+	})
+}
@@ -43,6 +43,7 @@ func (p *Parser) ParsePayload(table models.Table, json []byte) (models.ClientObj
 
 	allErrors := make(models.IngestionValidationErrors)
 	out := make(map[string]any)
+	now := time.Now()
 	result := gjson.ParseBytes(json)
 	missingFields := make([]models.MissingField, 0, len(table.Fields))
 
@@ -83,6 +84,8 @@ func (p *Parser) ParsePayload(table models.Table, json []byte) (models.ClientObj
 		}
 		if val, err := parseField(value); err != nil {
 			addError(allErrors, objectId, name, err)
+		} else if err := field.Constraints.Check(val, now); err != nil {
+			addError(allErrors, objectId, name, err)
 		} else {
 			out[name] = val
 		}
@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/utils"
 )
 
 func TestParser_ParsePayload(t *testing.T) {
@@ -279,3 +280,69 @@ func TestParser_ParsePayload(t *testing.T) {
 		})
 	}
 }
+
+func TestParser_ParsePayload_constraints(t *testing.T) {
+	table := models.Table{
+		Name: "transactions",
+		Fields: map[string]models.Field{
+			"object_id":  {DataType: models.String},
+			"updated_at": {DataType: models.Timestamp},
+			"amount": {
+				DataType:    models.Float,
+				Nullable:    true,
+				Constraints: models.FieldConstraints{Min: utils.Ptr(0.0), Max: utils.Ptr(1000.0)},
+			},
+			"iban": {
+				DataType: models.String,
+				Nullable: true,
+				Constraints: models.FieldConstraints{
+					MaxLength: utils.Ptr(34),
+					Regex:     utils.Ptr("^[A-Z]{2}[0-9]{2}"),
+				},
+			},
+			"currency": {
+				DataType:    models.String,
+				Nullable:    true,
+				IsEnum:      true,
+				Constraints: models.FieldConstraints{AllowedValues: []any{"EUR", "USD"}},
+			},
+			"booked_at": {
+				DataType:    models.Timestamp,
+				Nullable:    true,
+				Constraints: models.FieldConstraints{NotInFuture: true},
+			},
+		},
+	}
+
+	t.Run("valid values", func(t *testing.T) {
+		out, err := NewParser().ParsePayload(table, []byte(`{
+			"object_id": "id",
+			"updated_at": "2023-10-19T17:33:22Z",
+			"amount": 1000,
+			"iban": "FR7630006000011234567890189",
+			"currency": "EUR",
+			"booked_at": "2023-10-19T17:33:22Z"
+		}`))
+		assert.NoError(t, err)
+		assert.Equal(t, 1000.0, out.Data["amount"])
+	})
+
+	t.Run("constraint violations", func(t *testing.T) {
+		_, err := NewParser().ParsePayload(table, []byte(`{
+			"object_id": "id",
+			"updated_at": "2023-10-19T17:33:22Z",
+			"amount": -1,
+			"iban": "not an iban",
+			"currency": "GBP",
+			"booked_at": "2999-01-01T00:00:00Z"
+		}`))
+		var validationErrors models.IngestionValidationErrors
+		assert.ErrorAs(t, err, &validationErrors)
+		assert.Equal(t, models.IngestionValidationErrorsSingle{
+			"amount":    "is lower than the minimum 0",
+			"iban":      "does not match the pattern ^[A-Z]{2}[0-9]{2}",
+			"currency":  "is not one of the allowed values [EUR USD]",
+			"booked_at": "is in the future",
+		}, validationErrors["id"])
+	})
+}
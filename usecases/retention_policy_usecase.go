@@ -0,0 +1,178 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/checkmarble/marble-backend/infra"
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/repositories"
+	"github.com/checkmarble/marble-backend/usecases/executor_factory"
+	"github.com/checkmarble/marble-backend/usecases/security"
+
+	"github.com/cockroachdb/errors"
+)
+
+type RetentionPolicyRepository interface {
+	GetDataModel(ctx context.Context, exec repositories.Executor, organizationID string, fetchEnumValues bool) (models.DataModel, error)
+	ListRetentionPolicies(ctx context.Context, exec repositories.Executor, orgId string) ([]models.RetentionPolicy, error)
+	GetRetentionPolicy(ctx context.Context, exec repositories.Executor, id string) (models.RetentionPolicy, error)
+	CreateRetentionPolicy(ctx context.Context, exec repositories.Executor,
+		input models.CreateRetentionPolicyInput) (models.RetentionPolicy, error)
+	UpdateRetentionPolicy(ctx context.Context, exec repositories.Executor,
+		input models.UpdateRetentionPolicyInput) (models.RetentionPolicy, error)
+	DeleteRetentionPolicy(ctx context.Context, exec repositories.Executor, id string) error
+	ListObjectIdsOnLegalHold(ctx context.Context, exec repositories.Executor, orgId, tableName string) ([]string, error)
+	CountRetainableDecisions(ctx context.Context, exec repositories.Executor,
+		orgId string, cutoff time.Time) (nbRows int, nbRowsOnHold int, err error)
+}
+
+type RetentionPolicyClientDbRepository interface {
+	CountRetainableIngestedRows(ctx context.Context, exec repositories.Executor, tableName string, cutoff time.Time,
+		keepLatestVersion bool, objectIdsOnHold []string) (nbRows int, nbRowsOnHold int, err error)
+}
+
+type RetentionPolicyUsecase struct {
+	enforceSecurity    security.EnforceSecurityOrganization
+	executorFactory    executor_factory.ExecutorFactory
+	repository         RetentionPolicyRepository
+	clientDbRepository RetentionPolicyClientDbRepository
+	config             infra.RetentionConfig
+}
+
+func (uc RetentionPolicyUsecase) ListRetentionPolicies(ctx context.Context, orgId string) ([]models.RetentionPolicy, error) {
+	if err := uc.enforceSecurity.ReadDataModel(); err != nil {
+		return nil, err
+	}
+	if err := uc.enforceSecurity.ReadOrganization(orgId); err != nil {
+		return nil, err
+	}
+
+	return uc.repository.ListRetentionPolicies(ctx, uc.executorFactory.NewExecutor(), orgId)
+}
+
+func (uc RetentionPolicyUsecase) CreateRetentionPolicy(ctx context.Context,
+	input models.CreateRetentionPolicyInput,
+) (models.RetentionPolicy, error) {
+	if err := uc.enforceSecurity.WriteDataModel(input.OrganizationId); err != nil {
+		return models.RetentionPolicy{}, err
+	}
+
+	exec := uc.executorFactory.NewExecutor()
+
+	if input.TableName != models.RetentionTableDecisions {
+		dataModel, err := uc.repository.GetDataModel(ctx, exec, input.OrganizationId, false)
+		if err != nil {
+			return models.RetentionPolicy{}, err
+		}
+		if _, ok := dataModel.Tables[input.TableName]; !ok {
+			return models.RetentionPolicy{}, errors.Wrapf(models.BadParameterError,
+				"table %s does not exist in the data model", input.TableName)
+		}
+	}
+	if err := uc.validate(input.Action, input.RetentionDays); err != nil {
+		return models.RetentionPolicy{}, err
+	}
+
+	policy, err := uc.repository.CreateRetentionPolicy(ctx, exec, input)
+	if repositories.IsUniqueViolationError(err) {
+		return models.RetentionPolicy{}, errors.Wrapf(models.ConflictError,
+			"there is already a retention policy for table %s", input.TableName)
+	}
+	return policy, err
+}
+
+func (uc RetentionPolicyUsecase) UpdateRetentionPolicy(ctx context.Context,
+	input models.UpdateRetentionPolicyInput,
+) (models.RetentionPolicy, error) {
+	exec := uc.executorFactory.NewExecutor()
+
+	policy, err := uc.repository.GetRetentionPolicy(ctx, exec, input.Id)
+	if err != nil {
+		return models.RetentionPolicy{}, err
+	}
+	if err := uc.enforceSecurity.WriteDataModel(policy.OrganizationId); err != nil {
+		return models.RetentionPolicy{}, err
+	}
+
+	if input.Action != nil {
+		policy.Action = *input.Action
+	}
+	if input.RetentionDays != nil {
+		policy.RetentionDays = *input.RetentionDays
+	}
+	if err := uc.validate(policy.Action, policy.RetentionDays); err != nil {
+		return models.RetentionPolicy{}, err
+	}
+
+	return uc.repository.UpdateRetentionPolicy(ctx, exec, input)
+}
+
+func (uc RetentionPolicyUsecase) DeleteRetentionPolicy(ctx context.Context, policyId string) error {
+	exec := uc.executorFactory.NewExecutor()
+
+	policy, err := uc.repository.GetRetentionPolicy(ctx, exec, policyId)
+	if err != nil {
+		return err
+	}
+	if err := uc.enforceSecurity.WriteDataModel(policy.OrganizationId); err != nil {
+		return err
+	}
+
+	return uc.repository.DeleteRetentionPolicy(ctx, exec, policyId)
+}
+
+// DryRunRetentionPolicy reports what the policy would remove if it was executed now, without modifying any data.
+func (uc RetentionPolicyUsecase) DryRunRetentionPolicy(ctx context.Context, policyId string) (models.RetentionReport, error) {
+	exec := uc.executorFactory.NewExecutor()
+
+	policy, err := uc.repository.GetRetentionPolicy(ctx, exec, policyId)
+	if err != nil {
+		return models.RetentionReport{}, err
+	}
+	if err := uc.enforceSecurity.ReadDataModel(); err != nil {
+		return models.RetentionReport{}, err
+	}
+	if err := uc.enforceSecurity.ReadOrganization(policy.OrganizationId); err != nil {
+		return models.RetentionReport{}, err
+	}
+
+	report := models.RetentionReport{
+		PolicyId:  policy.Id,
+		TableName: policy.TableName,
+		Action:    policy.Action,
+		Cutoff:    policy.Cutoff(time.Now()),
+	}
+
+	if policy.TableName == models.RetentionTableDecisions {
+		report.NbRows, report.NbRowsOnHold, err = uc.repository.CountRetainableDecisions(ctx,
+			exec, policy.OrganizationId, report.Cutoff)
+		return report, err
+	}
+
+	objectIdsOnHold, err := uc.repository.ListObjectIdsOnLegalHold(ctx, exec, policy.OrganizationId, policy.TableName)
+	if err != nil {
+		return models.RetentionReport{}, err
+	}
+	clientExec, err := uc.executorFactory.NewClientDbExecutor(ctx, policy.OrganizationId)
+	if err != nil {
+		return models.RetentionReport{}, err
+	}
+	report.NbRows, report.NbRowsOnHold, err = uc.clientDbRepository.CountRetainableIngestedRows(ctx, clientExec,
+		policy.TableName, report.Cutoff, policy.KeepLatestVersion, objectIdsOnHold)
+
+	return report, err
+}
+
+func (uc RetentionPolicyUsecase) validate(action models.RetentionPolicyAction, retentionDays int) error {
+	if action == models.RetentionActionUnknown {
+		return errors.Wrap(models.BadParameterError, "retention policy action must be one of delete, archive")
+	}
+	if action == models.RetentionActionArchive && uc.config.ArchiveBucketUrl == "" {
+		return errors.Wrap(models.BadParameterError, "archiving requires RETENTION_ARCHIVE_BUCKET_URL to be configured")
+	}
+	if retentionDays <= 0 {
+		return errors.Wrap(models.BadParameterError, "retention_days must be greater than 0")
+	}
+	return nil
+}
@@ -138,6 +138,7 @@ type SanctionCheckUsecase struct {
 	openSanctionsProvider SanctionCheckProvider
 	blobBucketUrl         string
 	blobRepository        repositories.BlobRepository
+	fileScanner           repositories.FileScanner
 
 	executorFactory    executor_factory.ExecutorFactory
 	transactionFactory executor_factory.TransactionFactory
@@ -717,20 +718,21 @@ func (uc SanctionCheckUsecase) CreateFiles(ctx context.Context, creds models.Cre
 		}
 	}
 
-	for _, fileHeader := range files {
-		if err := validateFileType(fileHeader); err != nil {
-			return nil, err
-		}
-	}
-
 	type uploadedFileMetadata struct {
 		fileReference string
 		fileName      string
+		scanStatus    models.FileScanStatus
 	}
 
 	metadata := make([]uploadedFileMetadata, 0, len(files))
 
 	for _, fileHeader := range files {
+		var scanStatus models.FileScanStatus
+		scanStatus, err = scanUploadedFile(ctx, uc.fileScanner, fileHeader)
+		if err != nil {
+			break
+		}
+
 		newFileReference := fmt.Sprintf("%s/%s/%s", creds.OrganizationId, sc.Id, uuid.NewString())
 		err = writeSanctionCheckFileToBlobStorage(ctx, uc.blobRepository, uc.blobBucketUrl, fileHeader, newFileReference)
 		if err != nil {
@@ -740,6 +742,7 @@ func (uc SanctionCheckUsecase) CreateFiles(ctx context.Context, creds models.Cre
 		metadata = append(metadata, uploadedFileMetadata{
 			fileReference: newFileReference,
 			fileName:      fileHeader.Filename,
+			scanStatus:    scanStatus,
 		})
 	}
 
@@ -771,6 +774,7 @@ func (uc SanctionCheckUsecase) CreateFiles(ctx context.Context, creds models.Cre
 					SanctionCheckId: sc.Id,
 					FileName:        uploadedFile.fileName,
 					FileReference:   uploadedFile.fileReference,
+					ScanStatus:      uploadedFile.scanStatus,
 				},
 			)
 			if err != nil {
@@ -826,6 +830,9 @@ func (uc SanctionCheckUsecase) GetFileDownloadUrl(ctx context.Context, sanctionC
 	if err != nil {
 		return "", err
 	}
+	if err := errIfQuarantined(file.ScanStatus); err != nil {
+		return "", err
+	}
 
 	return uc.blobRepository.GenerateSignedUrl(ctx, uc.blobBucketUrl, file.FileReference)
 }
@@ -0,0 +1,124 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/models/ast"
+	"github.com/checkmarble/marble-backend/pure_utils"
+	"github.com/checkmarble/marble-backend/repositories"
+	"github.com/checkmarble/marble-backend/usecases/executor_factory"
+)
+
+type caseRoutingRepository interface {
+	ListCaseRoutingRules(ctx context.Context, exec repositories.Executor,
+		scenarioId string) ([]models.CaseRoutingRule, error)
+	ReplaceCaseRoutingRules(ctx context.Context, exec repositories.Executor, organizationId, scenarioId string,
+		rules []models.CaseRoutingRuleInput) error
+	GetInboxById(ctx context.Context, exec repositories.Executor, inboxId string) (models.Inbox, error)
+	GetTagById(ctx context.Context, exec repositories.Executor, tagId string) (models.Tag, error)
+}
+
+func (usecase *ScenarioUsecase) ListCaseRoutingRules(ctx context.Context, scenarioId string) ([]models.CaseRoutingRule, error) {
+	exec := usecase.executorFactory.NewExecutor()
+	scenario, err := usecase.repository.GetScenarioById(ctx, exec, scenarioId)
+	if err != nil {
+		return nil, err
+	}
+	if err := usecase.enforceSecurity.ReadScenario(scenario); err != nil {
+		return nil, err
+	}
+
+	return usecase.caseRoutingRepository.ListCaseRoutingRules(ctx, exec, scenarioId)
+}
+
+// UpdateCaseRoutingRules replaces the case routing rules of a scenario, in order of priority. Like the other decision to
+// case settings, it requires the permission to publish the scenario.
+func (usecase *ScenarioUsecase) UpdateCaseRoutingRules(ctx context.Context, scenarioId string,
+	rules []models.CaseRoutingRuleInput,
+) ([]models.CaseRoutingRule, error) {
+	scenario, err := usecase.repository.GetScenarioById(ctx, usecase.executorFactory.NewExecutor(), scenarioId)
+	if err != nil {
+		return nil, err
+	}
+	if err := usecase.enforceSecurity.PublishScenario(scenario); err != nil {
+		return nil, err
+	}
+
+	for _, rule := range rules {
+		if err := usecase.validateCaseRoutingRule(ctx, scenario, rule); err != nil {
+			return nil, err
+		}
+	}
+
+	return executor_factory.TransactionReturnValue(ctx, usecase.transactionFactory, func(
+		tx repositories.Transaction,
+	) ([]models.CaseRoutingRule, error) {
+		if err := usecase.caseRoutingRepository.ReplaceCaseRoutingRules(ctx, tx,
+			scenario.OrganizationId, scenario.Id, rules); err != nil {
+			return nil, err
+		}
+		return usecase.caseRoutingRepository.ListCaseRoutingRules(ctx, tx, scenario.Id)
+	})
+}
+
+func (usecase *ScenarioUsecase) ValidateCaseRoutingRuleAst(ctx context.Context, scenarioId string,
+	astNode *ast.Node,
+) (models.AstValidation, error) {
+	scenario, err := usecase.repository.GetScenarioById(ctx, usecase.executorFactory.NewExecutor(), scenarioId)
+	if err != nil {
+		return models.AstValidation{}, err
+	}
+	if err := usecase.enforceSecurity.ReadScenario(scenario); err != nil {
+		return models.AstValidation{}, err
+	}
+
+	return usecase.validateScenarioAst.ValidateCaseRoutingRule(ctx, scenario, astNode), nil
+}
+
+func (usecase *ScenarioUsecase) validateCaseRoutingRule(ctx context.Context, scenario models.Scenario,
+	rule models.CaseRoutingRuleInput,
+) error {
+	if err := rule.Validate(); err != nil {
+		return err
+	}
+
+	exec := usecase.executorFactory.NewExecutor()
+	inbox, err := usecase.caseRoutingRepository.GetInboxById(ctx, exec, rule.InboxId)
+	if errors.Is(err, models.NotFoundError) {
+		return errors.Wrap(models.BadParameterError, "inbox not found")
+	} else if err != nil {
+		return err
+	}
+	if inbox.OrganizationId != scenario.OrganizationId || inbox.Status != models.InboxStatusActive {
+		return errors.Wrap(models.BadParameterError, "the inbox of a routing rule must be an active inbox of the organization")
+	}
+
+	for _, tagId := range rule.TagIds {
+		tag, err := usecase.caseRoutingRepository.GetTagById(ctx, exec, tagId)
+		if errors.Is(err, models.NotFoundError) {
+			return errors.Wrap(models.BadParameterError, "tag not found")
+		} else if err != nil {
+			return err
+		}
+		if tag.OrganizationId != scenario.OrganizationId || tag.Target != models.TagTargetCase ||
+			tag.DeletedAt != nil {
+			return errors.Wrap(models.BadParameterError, "the tags of a routing rule must be case tags of the organization")
+		}
+	}
+
+	validation := usecase.validateScenarioAst.ValidateCaseRoutingRule(ctx, scenario, rule.Formula)
+	if len(validation.Errors) > 0 || len(validation.Evaluation.FlattenErrors()) > 0 {
+		errs := append(
+			validation.Evaluation.FlattenErrors(),
+			pure_utils.Map(validation.Errors, func(err models.ScenarioValidationError) error {
+				return err.Error
+			})...,
+		)
+		return errors.Wrapf(models.BadParameterError, "invalid formula for routing rule %s: %s",
+			rule.Name, errors.Join(errs...))
+	}
+	return nil
+}
@@ -17,12 +17,13 @@ import (
 )
 
 type ScenarioUsecase struct {
-	transactionFactory  executor_factory.TransactionFactory
-	scenarioFetcher     scenarios.ScenarioFetcher
-	validateScenarioAst scenarios.ValidateScenarioAst
-	executorFactory     executor_factory.ExecutorFactory
-	enforceSecurity     security.EnforceSecurityScenario
-	repository          repositories.ScenarioUsecaseRepository
+	transactionFactory    executor_factory.TransactionFactory
+	scenarioFetcher       scenarios.ScenarioFetcher
+	validateScenarioAst   scenarios.ValidateScenarioAst
+	executorFactory       executor_factory.ExecutorFactory
+	enforceSecurity       security.EnforceSecurityScenario
+	repository            repositories.ScenarioUsecaseRepository
+	caseRoutingRepository caseRoutingRepository
 }
 
 func (usecase *ScenarioUsecase) ListScenarios(ctx context.Context, organizationId string) ([]models.Scenario, error) {
@@ -224,6 +224,7 @@ func (self *ValidateScenarioIterationImpl) Validate(ctx context.Context,
 type ValidateScenarioAst interface {
 	Validate(ctx context.Context, scenario models.Scenario, astNode *ast.Node,
 		expectedReturnType ...string) models.AstValidation
+	ValidateCaseRoutingRule(ctx context.Context, scenario models.Scenario, astNode *ast.Node) models.AstValidation
 }
 
 type ValidateScenarioAstImpl struct {
@@ -269,6 +270,32 @@ func (self *ValidateScenarioAstImpl) Validate(ctx context.Context,
 	return result
 }
 
+// ValidateCaseRoutingRule validates the formula of a case routing rule, which can also read the fields of the decision
+func (self *ValidateScenarioAstImpl) ValidateCaseRoutingRule(ctx context.Context,
+	scenario models.Scenario,
+	astNode *ast.Node,
+) models.AstValidation {
+	result := models.NewAstValidation()
+
+	dryRunEnvironment, err := self.AstValidator.MakeDryRunEnvironment(ctx, scenario)
+	if err != nil {
+		result.Errors = append(result.Errors, *err)
+		return result
+	}
+	dryRunEnvironment.AddEvaluator(ast.FUNC_DECISION, evaluate.DecisionAccess{Decision: evaluate.DryRunDecision()})
+
+	result.Evaluation, _ = ast_eval.EvaluateAst(ctx, nil, dryRunEnvironment, *astNode)
+
+	if _, ok := result.Evaluation.ReturnValue.(bool); !ok {
+		result.Errors = append(result.Errors, models.ScenarioValidationError{
+			Error: errors.Wrap(models.BadParameterError, "case routing rule formula does not return a boolean"),
+			Code:  models.FormulaMustReturnBoolean,
+		})
+	}
+
+	return result
+}
+
 func getTypeFromString(typeStr string) (reflect.Type, bool) {
 	switch typeStr {
 	case "string":
@@ -273,7 +273,8 @@ func (w *AsyncDecisionWorker) createSingleDecisionForObjectId(
 	if err != nil {
 		return false, nil, nil, err
 	}
-	objectMap, err := w.ingestedDataReadRepository.QueryIngestedObject(ctx, db, table, args.ObjectId)
+	objectMap, err := w.ingestedDataReadRepository.QueryIngestedObjectByUniqueField(ctx, db, table,
+		args.ObjectId, "object_id", scheduledExecution.ReadAsOf)
 	if err != nil {
 		return false, nil, nil, errors.Wrap(err, "error while querying ingested objects in AsyncDecisionWorker.createSingleDecisionForObjectId")
 	} else if len(objectMap) == 0 {
@@ -281,7 +282,7 @@ func (w *AsyncDecisionWorker) createSingleDecisionForObjectId(
 		return false, nil, nil, nil
 	}
 
-	object := models.ClientObject{TableName: table.Name, Data: objectMap[0].Data}
+	object := models.ClientObject{TableName: table.Name, Data: objectMap[0].Data, ReadAsOf: scheduledExecution.ReadAsOf}
 
 	evaluationParameters := evaluate_scenario.ScenarioEvaluationParameters{
 		Scenario:          scenario,
@@ -0,0 +1,34 @@
+package scheduled_execution
+
+import (
+	"context"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/repositories"
+	"github.com/cockroachdb/errors"
+	"github.com/riverqueue/river"
+)
+
+// BlobDeletionWorker deletes files whose rows were deleted by a committed transaction. Files already deleted by a
+// previous attempt are not found, so the job can be retried as a whole.
+type BlobDeletionWorker struct {
+	river.WorkerDefaults[models.BlobDeletionArgs]
+
+	blobRepository repositories.BlobRepository
+}
+
+func NewBlobDeletionWorker(blobRepository repositories.BlobRepository) BlobDeletionWorker {
+	return BlobDeletionWorker{
+		blobRepository: blobRepository,
+	}
+}
+
+func (w *BlobDeletionWorker) Work(ctx context.Context, job *river.Job[models.BlobDeletionArgs]) error {
+	for _, blob := range job.Args.Blobs {
+		err := w.blobRepository.DeleteFile(ctx, blob.BucketUrl, blob.Key)
+		if err != nil && !errors.Is(err, models.NotFoundError) {
+			return errors.Wrapf(err, "could not delete blob %s", blob.Key)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package scheduled_execution
+
+import (
+	"context"
+	"testing"
+
+	"github.com/riverqueue/river"
+	"github.com/riverqueue/river/rivertype"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/checkmarble/marble-backend/models"
+)
+
+func TestBlobDeletionWorker(t *testing.T) {
+	blobs := &fakeBlobRepository{blobs: map[string][]byte{
+		"files/a.pdf": []byte("a"),
+		"files/c.pdf": []byte("c"),
+	}}
+	worker := NewBlobDeletionWorker(blobs)
+
+	job := &river.Job[models.BlobDeletionArgs]{
+		JobRow: &rivertype.JobRow{},
+		Args: models.BlobDeletionArgs{OrgId: "org", Blobs: []models.BlobRef{
+			{BucketUrl: "files", Key: "a.pdf"},
+			// already deleted by a previous attempt
+			{BucketUrl: "files", Key: "b.pdf"},
+			{BucketUrl: "files", Key: "c.pdf"},
+		}},
+	}
+
+	assert.NoError(t, worker.Work(context.Background(), job))
+	assert.Empty(t, blobs.blobs)
+}
@@ -0,0 +1,257 @@
+package scheduled_execution
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/riverqueue/river"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/repositories"
+	"github.com/checkmarble/marble-backend/usecases/executor_factory"
+	"github.com/checkmarble/marble-backend/utils"
+)
+
+const caseBulkActionTimeout = 30 * time.Minute
+
+type caseBulkActionRepository interface {
+	GetCaseBulkAction(ctx context.Context, exec repositories.Executor, id string) (models.CaseBulkAction, error)
+	ListCaseBulkActionItems(ctx context.Context, exec repositories.Executor, bulkActionId string,
+		status *models.CaseBulkActionItemStatus) ([]models.CaseBulkActionItem, error)
+	UpdateCaseBulkActionItem(ctx context.Context, exec repositories.Executor, bulkActionId, caseId string,
+		status models.CaseBulkActionItemStatus, errorMessage *string) error
+	UpdateCaseBulkActionStatus(ctx context.Context, exec repositories.Executor, id string,
+		status models.CaseBulkActionStatus) error
+}
+
+type caseBulkActionUserRepository interface {
+	UserById(ctx context.Context, exec repositories.Executor, userId string) (models.User, error)
+}
+
+// CaseBulkActionCaseUsecase is the case usecase of the user who requested a bulk action, so that every case goes
+// through the same permission checks, events and webhooks as a single action of the user
+type CaseBulkActionCaseUsecase interface {
+	GetCase(ctx context.Context, caseId string) (models.Case, error)
+	UpdateCase(ctx context.Context, userId string, attributes models.UpdateCaseAttributes) (models.Case, error)
+	AssignCase(ctx context.Context, req models.CaseAssignementRequest) error
+	CreateCaseTags(ctx context.Context, userId string, attributes models.CreateCaseTagsAttributes) (models.Case, error)
+	Snooze(ctx context.Context, req models.CaseSnoozeRequest) error
+	ReviewCaseDecisions(ctx context.Context, input models.ReviewCaseDecisionsBody) (models.Case, error)
+}
+
+// CaseBulkActionWorker applies a bulk action to its cases one by one, and records the result of every case. A case that
+// fails does not stop the action, and a retried job only processes the cases that are still pending. The result of a
+// case is recorded in the transaction of the action on the case, so that a retried job does not apply it twice.
+type CaseBulkActionWorker struct {
+	river.WorkerDefaults[models.CaseBulkActionArgs]
+
+	executorFactory    executor_factory.ExecutorFactory
+	transactionFactory executor_factory.TransactionFactory
+	repository         caseBulkActionRepository
+	userRepository     caseBulkActionUserRepository
+	caseUsecase        func(creds models.Credentials,
+		transactionFactory executor_factory.TransactionFactory) CaseBulkActionCaseUsecase
+}
+
+func NewCaseBulkActionWorker(
+	executorFactory executor_factory.ExecutorFactory,
+	transactionFactory executor_factory.TransactionFactory,
+	repository caseBulkActionRepository,
+	userRepository caseBulkActionUserRepository,
+	caseUsecase func(creds models.Credentials,
+		transactionFactory executor_factory.TransactionFactory) CaseBulkActionCaseUsecase,
+) CaseBulkActionWorker {
+	return CaseBulkActionWorker{
+		executorFactory:    executorFactory,
+		transactionFactory: transactionFactory,
+		repository:         repository,
+		userRepository:     userRepository,
+		caseUsecase:        caseUsecase,
+	}
+}
+
+// caseBulkActionItemTransactionFactory records the success of a case at the end of the transaction that applies the
+// action to the case
+type caseBulkActionItemTransactionFactory struct {
+	executor_factory.TransactionFactory
+
+	recordSuccess func(ctx context.Context, tx repositories.Transaction) error
+	recorded      bool
+}
+
+func (f *caseBulkActionItemTransactionFactory) Transaction(ctx context.Context,
+	fn func(tx repositories.Transaction) error,
+) error {
+	return f.TransactionFactory.Transaction(ctx, func(tx repositories.Transaction) error {
+		if err := fn(tx); err != nil {
+			return err
+		}
+		if err := f.recordSuccess(ctx, tx); err != nil {
+			return err
+		}
+		f.recorded = true
+		return nil
+	})
+}
+
+func (w *CaseBulkActionWorker) Timeout(job *river.Job[models.CaseBulkActionArgs]) time.Duration {
+	return caseBulkActionTimeout
+}
+
+func (w *CaseBulkActionWorker) Work(ctx context.Context, job *river.Job[models.CaseBulkActionArgs]) error {
+	exec := w.executorFactory.NewExecutor()
+	bulkAction, err := w.repository.GetCaseBulkAction(ctx, exec, job.Args.BulkActionId)
+	if errors.Is(err, models.NotFoundError) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if bulkAction.Status == models.CaseBulkActionCompleted {
+		return nil
+	}
+
+	user, err := w.userRepository.UserById(ctx, exec, string(bulkAction.UserId))
+	if err != nil {
+		return err
+	}
+	if user.OrganizationId != bulkAction.OrganizationId {
+		return river.JobCancel(errors.Wrapf(models.ForbiddenError,
+			"user %s does not belong to the organization of the bulk action", user.UserId))
+	}
+	creds := models.NewCredentialWithUser(user)
+	ctx = context.WithValue(ctx, utils.ContextKeyCredentials, creds)
+
+	if err := w.repository.UpdateCaseBulkActionStatus(ctx, exec, bulkAction.Id,
+		models.CaseBulkActionRunning); err != nil {
+		return err
+	}
+
+	items, err := w.repository.ListCaseBulkActionItems(ctx, exec, bulkAction.Id,
+		utils.Ptr(models.CaseBulkActionItemPending))
+	if err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		itemTransactionFactory := &caseBulkActionItemTransactionFactory{
+			TransactionFactory: w.transactionFactory,
+			recordSuccess: func(ctx context.Context, tx repositories.Transaction) error {
+				return w.repository.UpdateCaseBulkActionItem(ctx, tx, bulkAction.Id, item.CaseId,
+					models.CaseBulkActionItemSuccess, nil)
+			},
+		}
+		caseUsecase := w.caseUsecase(creds, itemTransactionFactory)
+
+		status, errorMessage := models.CaseBulkActionItemSuccess, (*string)(nil)
+		if err := applyCaseBulkAction(ctx, caseUsecase, bulkAction, item.CaseId); err != nil {
+			if !isCaseBulkActionError(err) {
+				return err
+			}
+			status, errorMessage = models.CaseBulkActionItemFailed, utils.Ptr(err.Error())
+		} else if itemTransactionFactory.recorded {
+			continue
+		}
+		// the action failed, or had nothing to change on the case
+		if err := w.repository.UpdateCaseBulkActionItem(ctx, exec, bulkAction.Id, item.CaseId,
+			status, errorMessage); err != nil {
+			return err
+		}
+	}
+
+	if err := w.repository.UpdateCaseBulkActionStatus(ctx, exec, bulkAction.Id,
+		models.CaseBulkActionCompleted); err != nil {
+		return err
+	}
+	utils.LoggerFromContext(ctx).InfoContext(ctx, "case bulk action done",
+		"bulk_action_id", bulkAction.Id,
+		"action", bulkAction.Action,
+		"nb_cases", bulkAction.NbCases)
+	return nil
+}
+
+// isCaseBulkActionError tells whether an error is the result of the action on a case, which is recorded, rather than
+// a failure of the job, which is retried
+func isCaseBulkActionError(err error) bool {
+	return errors.Is(err, models.BadParameterError) ||
+		errors.Is(err, models.ForbiddenError) ||
+		errors.Is(err, models.NotFoundError) ||
+		errors.Is(err, models.UnprocessableEntityError) ||
+		errors.Is(err, models.ConflictError)
+}
+
+func applyCaseBulkAction(ctx context.Context, uc CaseBulkActionCaseUsecase,
+	bulkAction models.CaseBulkAction, caseId string,
+) error {
+	c, err := uc.GetCase(ctx, caseId)
+	if err != nil {
+		return err
+	}
+	userId := string(bulkAction.UserId)
+	params := bulkAction.Params
+
+	switch bulkAction.Action {
+	case models.CaseBulkActionClose:
+		_, err = uc.UpdateCase(ctx, userId, models.UpdateCaseAttributes{
+			Id:      c.Id,
+			Status:  models.CaseClosed,
+			Outcome: params.Outcome,
+		})
+	case models.CaseBulkActionAssign:
+		err = uc.AssignCase(ctx, models.CaseAssignementRequest{
+			UserId:     bulkAction.UserId,
+			CaseId:     c.Id,
+			AssigneeId: &params.AssigneeId,
+		})
+	case models.CaseBulkActionMoveInbox:
+		_, err = uc.UpdateCase(ctx, userId, models.UpdateCaseAttributes{
+			Id:      c.Id,
+			InboxId: params.InboxId,
+		})
+	case models.CaseBulkActionTag:
+		tagIds := make([]string, 0, len(c.Tags)+len(params.TagIds))
+		for _, tag := range c.Tags {
+			tagIds = append(tagIds, tag.TagId)
+		}
+		for _, tagId := range params.TagIds {
+			if !slices.Contains(tagIds, tagId) {
+				tagIds = append(tagIds, tagId)
+			}
+		}
+		_, err = uc.CreateCaseTags(ctx, userId, models.CreateCaseTagsAttributes{
+			CaseId: c.Id,
+			TagIds: tagIds,
+		})
+	case models.CaseBulkActionSnooze:
+		if params.SnoozeUntil == nil {
+			return errors.Wrap(models.BadParameterError, "snooze_until is required to snooze cases")
+		}
+		err = uc.Snooze(ctx, models.CaseSnoozeRequest{
+			UserId: bulkAction.UserId,
+			CaseId: c.Id,
+			Until:  *params.SnoozeUntil,
+		})
+	case models.CaseBulkActionReviewDecisions:
+		for _, decision := range c.Decisions {
+			if decision.ReviewStatus == nil || *decision.ReviewStatus != models.ReviewStatusPending {
+				continue
+			}
+			if _, err = uc.ReviewCaseDecisions(ctx, models.ReviewCaseDecisionsBody{
+				DecisionId:    decision.DecisionId,
+				ReviewComment: params.ReviewComment,
+				ReviewStatus:  params.ReviewStatus,
+				UserId:        userId,
+			}); err != nil {
+				return err
+			}
+		}
+	default:
+		return errors.Wrapf(models.BadParameterError, "invalid bulk action '%s'", bulkAction.Action)
+	}
+	return err
+}
@@ -0,0 +1,221 @@
+package scheduled_execution
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/riverqueue/river"
+	"github.com/riverqueue/river/rivertype"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/checkmarble/marble-backend/mocks"
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/repositories"
+	"github.com/checkmarble/marble-backend/usecases/executor_factory"
+)
+
+type fakeCaseBulkActionRepository struct {
+	bulkAction models.CaseBulkAction
+	items      []models.CaseBulkActionItem
+	// recordedInTx lists the cases whose result was recorded in a transaction
+	recordedInTx []string
+}
+
+func (r *fakeCaseBulkActionRepository) GetCaseBulkAction(ctx context.Context, exec repositories.Executor,
+	id string,
+) (models.CaseBulkAction, error) {
+	return r.bulkAction, nil
+}
+
+func (r *fakeCaseBulkActionRepository) ListCaseBulkActionItems(ctx context.Context, exec repositories.Executor,
+	bulkActionId string, status *models.CaseBulkActionItemStatus,
+) ([]models.CaseBulkActionItem, error) {
+	items := make([]models.CaseBulkActionItem, 0)
+	for _, item := range r.items {
+		if status == nil || item.Status == *status {
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
+
+func (r *fakeCaseBulkActionRepository) UpdateCaseBulkActionItem(ctx context.Context, exec repositories.Executor,
+	bulkActionId, caseId string, status models.CaseBulkActionItemStatus, errorMessage *string,
+) error {
+	if _, ok := exec.(repositories.Transaction); ok {
+		r.recordedInTx = append(r.recordedInTx, caseId)
+	}
+	for i := range r.items {
+		if r.items[i].CaseId == caseId {
+			r.items[i].Status = status
+			r.items[i].Error = errorMessage
+		}
+	}
+	return nil
+}
+
+func (r *fakeCaseBulkActionRepository) UpdateCaseBulkActionStatus(ctx context.Context, exec repositories.Executor,
+	id string, status models.CaseBulkActionStatus,
+) error {
+	r.bulkAction.Status = status
+	return nil
+}
+
+type fakeCaseBulkActionUserRepository struct{}
+
+func (fakeCaseBulkActionUserRepository) UserById(ctx context.Context, exec repositories.Executor,
+	userId string,
+) (models.User, error) {
+	return models.User{UserId: models.UserId(userId), OrganizationId: "org", Role: models.BUILDER}, nil
+}
+
+// fakeCaseBulkActionCaseUsecase only lets the user read the cases of the inbox "inbox"
+type fakeCaseBulkActionCaseUsecase struct {
+	CaseBulkActionCaseUsecase
+
+	creds              models.Credentials
+	transactionFactory executor_factory.TransactionFactory
+	cases              map[string]models.Case
+	updates            []models.UpdateCaseAttributes
+	tags               []models.CreateCaseTagsAttributes
+}
+
+func (uc *fakeCaseBulkActionCaseUsecase) GetCase(ctx context.Context, caseId string) (models.Case, error) {
+	c, ok := uc.cases[caseId]
+	if !ok {
+		return models.Case{}, errors.Wrap(models.NotFoundError, "case not found")
+	}
+	if c.InboxId != "inbox" {
+		return models.Case{}, errors.Wrap(models.ForbiddenError, "inbox not accessible")
+	}
+	return c, nil
+}
+
+func (uc *fakeCaseBulkActionCaseUsecase) UpdateCase(ctx context.Context, userId string,
+	attributes models.UpdateCaseAttributes,
+) (models.Case, error) {
+	if attributes.Id == "broken" {
+		return models.Case{}, errors.New("connection reset")
+	}
+	err := uc.transactionFactory.Transaction(ctx, func(tx repositories.Transaction) error {
+		uc.updates = append(uc.updates, attributes)
+		return nil
+	})
+	return uc.cases[attributes.Id], err
+}
+
+func (uc *fakeCaseBulkActionCaseUsecase) CreateCaseTags(ctx context.Context, userId string,
+	attributes models.CreateCaseTagsAttributes,
+) (models.Case, error) {
+	err := uc.transactionFactory.Transaction(ctx, func(tx repositories.Transaction) error {
+		uc.tags = append(uc.tags, attributes)
+		return nil
+	})
+	return uc.cases[attributes.CaseId], err
+}
+
+func newCaseBulkActionWorkerTest(action models.CaseBulkActionType, params models.CaseBulkActionParams,
+	caseIds ...string,
+) (CaseBulkActionWorker, *fakeCaseBulkActionRepository, *fakeCaseBulkActionCaseUsecase) {
+	repository := &fakeCaseBulkActionRepository{
+		bulkAction: models.CaseBulkAction{
+			Id:             "bulk",
+			OrganizationId: "org",
+			UserId:         "analyst",
+			Action:         action,
+			Params:         params,
+			Status:         models.CaseBulkActionPending,
+			NbCases:        len(caseIds),
+		},
+	}
+	for _, caseId := range caseIds {
+		repository.items = append(repository.items, models.CaseBulkActionItem{
+			CaseId: caseId,
+			Status: models.CaseBulkActionItemPending,
+		})
+	}
+	caseUsecase := &fakeCaseBulkActionCaseUsecase{
+		cases: map[string]models.Case{
+			"case-1":  {Id: "case-1", InboxId: "inbox", Tags: []models.CaseTag{{TagId: "tag-a"}}},
+			"case-2":  {Id: "case-2", InboxId: "inbox"},
+			"private": {Id: "private", InboxId: "other-inbox"},
+			"broken":  {Id: "broken", InboxId: "inbox"},
+		},
+	}
+
+	executorFactory := new(mocks.ExecutorFactory)
+	executorFactory.On("NewExecutor").Return(new(mocks.Executor))
+
+	exec := executor_factory.NewExecutorFactoryStub()
+	worker := NewCaseBulkActionWorker(executorFactory, executor_factory.NewTransactionFactoryStub(exec),
+		repository, fakeCaseBulkActionUserRepository{},
+		func(creds models.Credentials, transactionFactory executor_factory.TransactionFactory) CaseBulkActionCaseUsecase {
+			caseUsecase.creds = creds
+			caseUsecase.transactionFactory = transactionFactory
+			return caseUsecase
+		})
+	return worker, repository, caseUsecase
+}
+
+func caseBulkActionJob() *river.Job[models.CaseBulkActionArgs] {
+	return &river.Job[models.CaseBulkActionArgs]{
+		JobRow: &rivertype.JobRow{Attempt: 1, MaxAttempts: 3},
+		Args:   models.CaseBulkActionArgs{OrgId: "org", BulkActionId: "bulk"},
+	}
+}
+
+func TestCaseBulkActionWorker_RecordsTheResultOfEveryCase(t *testing.T) {
+	worker, repository, caseUsecase := newCaseBulkActionWorkerTest(models.CaseBulkActionClose,
+		models.CaseBulkActionParams{Outcome: models.CaseFalsePositive}, "case-1", "private", "missing", "case-2")
+
+	err := worker.Work(context.Background(), caseBulkActionJob())
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.CaseBulkActionCompleted, repository.bulkAction.Status)
+	assert.Equal(t, models.UserId("analyst"), caseUsecase.creds.ActorIdentity.UserId)
+	assert.Equal(t, []models.UpdateCaseAttributes{
+		{Id: "case-1", Status: models.CaseClosed, Outcome: models.CaseFalsePositive},
+		{Id: "case-2", Status: models.CaseClosed, Outcome: models.CaseFalsePositive},
+	}, caseUsecase.updates)
+
+	statuses := make(map[string]models.CaseBulkActionItemStatus)
+	for _, item := range repository.items {
+		statuses[item.CaseId] = item.Status
+	}
+	assert.Equal(t, map[string]models.CaseBulkActionItemStatus{
+		"case-1":  models.CaseBulkActionItemSuccess,
+		"private": models.CaseBulkActionItemFailed,
+		"missing": models.CaseBulkActionItemFailed,
+		"case-2":  models.CaseBulkActionItemSuccess,
+	}, statuses)
+	assert.NotNil(t, repository.items[1].Error)
+	assert.Equal(t, []string{"case-1", "case-2"}, repository.recordedInTx,
+		"the success of a case is recorded in the transaction that updates it")
+}
+
+func TestCaseBulkActionWorker_RetriesOnUnexpectedErrors(t *testing.T) {
+	worker, repository, _ := newCaseBulkActionWorkerTest(models.CaseBulkActionClose,
+		models.CaseBulkActionParams{Outcome: models.CaseFalsePositive}, "case-1", "broken", "case-2")
+
+	err := worker.Work(context.Background(), caseBulkActionJob())
+
+	assert.Error(t, err)
+	assert.Equal(t, models.CaseBulkActionRunning, repository.bulkAction.Status)
+	assert.Equal(t, models.CaseBulkActionItemSuccess, repository.items[0].Status)
+	assert.Equal(t, models.CaseBulkActionItemPending, repository.items[1].Status)
+	assert.Equal(t, models.CaseBulkActionItemPending, repository.items[2].Status)
+}
+
+func TestCaseBulkActionWorker_AddsTags(t *testing.T) {
+	worker, _, caseUsecase := newCaseBulkActionWorkerTest(models.CaseBulkActionTag,
+		models.CaseBulkActionParams{TagIds: []string{"tag-a", "tag-b"}}, "case-1", "case-2")
+
+	err := worker.Work(context.Background(), caseBulkActionJob())
+
+	assert.NoError(t, err)
+	assert.Equal(t, []models.CreateCaseTagsAttributes{
+		{CaseId: "case-1", TagIds: []string{"tag-a", "tag-b"}},
+		{CaseId: "case-2", TagIds: []string{"tag-a", "tag-b"}},
+	}, caseUsecase.tags)
+}
@@ -0,0 +1,327 @@
+package scheduled_execution
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/riverqueue/river"
+
+	"github.com/checkmarble/marble-backend/dto"
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/pure_utils"
+	"github.com/checkmarble/marble-backend/repositories"
+	"github.com/checkmarble/marble-backend/usecases/executor_factory"
+	"github.com/checkmarble/marble-backend/utils"
+)
+
+const caseExportTimeout = 30 * time.Minute
+
+type caseExportRepository interface {
+	GetCaseExport(ctx context.Context, exec repositories.Executor, id string) (models.CaseExport, error)
+	UpdateCaseExport(ctx context.Context, exec repositories.Executor, id string, update models.CaseExportUpdate) error
+	GetCaseById(ctx context.Context, exec repositories.Executor, caseId string) (models.Case, error)
+	ListCaseEvents(ctx context.Context, exec repositories.Executor, caseId string) ([]models.CaseEvent, error)
+	GetCasesFileByCaseId(ctx context.Context, exec repositories.Executor, caseId string) ([]models.CaseFile, error)
+	DecisionsByCaseId(ctx context.Context, exec repositories.Executor,
+		organizationId, caseId string) ([]models.DecisionWithRuleExecutions, error)
+	GetSanctionChecksForDecision(ctx context.Context, exec repositories.Executor, decisionId string,
+		initialOnly bool) ([]models.SanctionCheckWithMatches, error)
+	ListSanctionCheckCommentsByIds(ctx context.Context, exec repositories.Executor,
+		ids []string) ([]models.SanctionCheckMatchComment, error)
+	GetEntityAnnotationsForCase(ctx context.Context, exec repositories.Executor,
+		req models.CaseEntityAnnotationRequest) ([]models.EntityAnnotation, error)
+	ListSuspiciousActivityReportsByCaseId(ctx context.Context, exec repositories.Executor,
+		caseId string) ([]models.SuspiciousActivityReport, error)
+}
+
+// CaseExportWorker writes the complete file of a case to a ZIP archive in blob storage. The archive is written in a
+// single run of the job, and a failed run starts over.
+type CaseExportWorker struct {
+	river.WorkerDefaults[models.CaseExportArgs]
+
+	executorFactory executor_factory.ExecutorFactory
+	repository      caseExportRepository
+	blobRepository  repositories.BlobRepository
+	bucketUrl       string
+}
+
+func NewCaseExportWorker(
+	executorFactory executor_factory.ExecutorFactory,
+	repository caseExportRepository,
+	blobRepository repositories.BlobRepository,
+	bucketUrl string,
+) CaseExportWorker {
+	return CaseExportWorker{
+		executorFactory: executorFactory,
+		repository:      repository,
+		blobRepository:  blobRepository,
+		bucketUrl:       bucketUrl,
+	}
+}
+
+func (w *CaseExportWorker) Timeout(job *river.Job[models.CaseExportArgs]) time.Duration {
+	return caseExportTimeout
+}
+
+func (w *CaseExportWorker) Work(ctx context.Context, job *river.Job[models.CaseExportArgs]) error {
+	exec := w.executorFactory.NewExecutor()
+	export, err := w.repository.GetCaseExport(ctx, exec, job.Args.ExportId)
+	if err != nil {
+		return err
+	}
+	if export.Status == models.CaseExportSuccess || export.Status == models.CaseExportFailed {
+		return nil
+	}
+
+	if err := w.repository.UpdateCaseExport(ctx, exec, export.Id, models.CaseExportUpdate{
+		Status: models.CaseExportRunning,
+	}); err != nil {
+		return err
+	}
+
+	update, err := w.writeArchive(ctx, exec, export)
+	if err != nil {
+		if job.Attempt >= job.MaxAttempts {
+			utils.LoggerFromContext(ctx).ErrorContext(ctx, "case export failed",
+				"export_id", export.Id, "error", err)
+			if updateErr := w.repository.UpdateCaseExport(ctx, exec, export.Id, models.CaseExportUpdate{
+				Status: models.CaseExportFailed,
+			}); updateErr != nil {
+				return updateErr
+			}
+		}
+		return err
+	}
+
+	if err := w.repository.UpdateCaseExport(ctx, exec, export.Id, update); err != nil {
+		return err
+	}
+	utils.LoggerFromContext(ctx).InfoContext(ctx, "case export done",
+		"export_id", export.Id,
+		"nb_files", update.NbFiles,
+		"size_bytes", update.SizeBytes)
+	return nil
+}
+
+func (w *CaseExportWorker) writeArchive(ctx context.Context, exec repositories.Executor,
+	export models.CaseExport,
+) (models.CaseExportUpdate, error) {
+	c, err := w.repository.GetCaseById(ctx, exec, export.CaseId)
+	if err != nil {
+		return models.CaseExportUpdate{}, err
+	}
+	events, err := w.repository.ListCaseEvents(ctx, exec, c.Id)
+	if err != nil {
+		return models.CaseExportUpdate{}, err
+	}
+	files, err := w.repository.GetCasesFileByCaseId(ctx, exec, c.Id)
+	if err != nil {
+		return models.CaseExportUpdate{}, err
+	}
+	decisions, err := w.repository.DecisionsByCaseId(ctx, exec, c.OrganizationId, c.Id)
+	if err != nil {
+		return models.CaseExportUpdate{}, err
+	}
+	sanctionChecks, err := w.sanctionChecksOfDecisions(ctx, exec, decisions)
+	if err != nil {
+		return models.CaseExportUpdate{}, err
+	}
+	annotations, err := w.repository.GetEntityAnnotationsForCase(ctx, exec, models.CaseEntityAnnotationRequest{
+		OrgId:  c.OrganizationId,
+		CaseId: c.Id,
+	})
+	if err != nil {
+		return models.CaseExportUpdate{}, err
+	}
+	reports, err := w.repository.ListSuspiciousActivityReportsByCaseId(ctx, exec, c.Id)
+	if err != nil {
+		return models.CaseExportUpdate{}, err
+	}
+
+	stream, err := w.blobRepository.OpenStream(ctx, w.bucketUrl, export.FileKey(), export.FileName())
+	if err != nil {
+		return models.CaseExportUpdate{}, err
+	}
+	defer stream.Close()
+
+	counter := &byteCounter{w: stream}
+	writer := newCaseExportWriter(counter, export, time.Now())
+
+	if err := writer.WriteSummary(caseExportSummary{
+		Case:           c,
+		GeneratedAt:    time.Now(),
+		Decisions:      decisions,
+		SanctionChecks: sanctionChecks,
+		Events:         events,
+		Files:          files,
+		Reports:        reports,
+		NbAnnotations:  len(annotations),
+	}); err != nil {
+		return models.CaseExportUpdate{}, err
+	}
+	if err := writer.WriteJson("case.json", dto.AdaptCaseDto(c)); err != nil {
+		return models.CaseExportUpdate{}, err
+	}
+	if err := writer.WriteJson("events.json", pure_utils.Map(events, dto.NewAPICaseEvent)); err != nil {
+		return models.CaseExportUpdate{}, err
+	}
+	if err := writer.WriteJson("decisions.json", pure_utils.Map(decisions,
+		func(d models.DecisionWithRuleExecutions) dto.DecisionWithRules {
+			return dto.NewDecisionWithRuleDto(d, nil, true)
+		})); err != nil {
+		return models.CaseExportUpdate{}, err
+	}
+	if err := writer.WriteJson("sanction_checks.json",
+		pure_utils.Map(sanctionChecks, dto.AdaptSanctionCheckDto)); err != nil {
+		return models.CaseExportUpdate{}, err
+	}
+	if err := w.writeAnnotations(ctx, writer, annotations); err != nil {
+		return models.CaseExportUpdate{}, err
+	}
+	if err := writer.WriteJson("suspicious_activity_reports.json",
+		pure_utils.Map(reports, dto.AdaptSuspiciousActivityReportDto)); err != nil {
+		return models.CaseExportUpdate{}, err
+	}
+
+	// Quarantined files are left out of the archive, they are still listed in the summary
+	for _, file := range files {
+		if file.ScanStatus.IsQuarantined() {
+			continue
+		}
+		if err := w.copyBlob(ctx, writer, file.BucketName, file.FileReference,
+			caseExportFilePath("files", file.Id, file.FileName)); err != nil {
+			return models.CaseExportUpdate{}, err
+		}
+	}
+	for _, report := range reports {
+		if report.Content != nil {
+			if err := writer.WriteJson(caseExportFilePath("suspicious_activity_reports",
+				report.ReportId, "content.json"), report.Content); err != nil {
+				return models.CaseExportUpdate{}, err
+			}
+		}
+		if report.Bucket != nil && report.BlobKey != nil && !report.ScanStatus.IsQuarantined() {
+			if err := w.copyBlob(ctx, writer, *report.Bucket, *report.BlobKey,
+				caseExportFilePath("suspicious_activity_reports", report.ReportId, "report")); err != nil {
+				return models.CaseExportUpdate{}, err
+			}
+		}
+	}
+
+	nbFiles := writer.NbFiles()
+	if err := writer.Close(); err != nil {
+		return models.CaseExportUpdate{}, err
+	}
+	if err := stream.Close(); err != nil {
+		return models.CaseExportUpdate{}, err
+	}
+
+	return models.CaseExportUpdate{
+		Status:    models.CaseExportSuccess,
+		NbFiles:   nbFiles,
+		SizeBytes: counter.n,
+	}, nil
+}
+
+// sanctionChecksOfDecisions returns the current sanction checks of the decisions, with the reviewer comments of their
+// matches
+func (w *CaseExportWorker) sanctionChecksOfDecisions(ctx context.Context, exec repositories.Executor,
+	decisions []models.DecisionWithRuleExecutions,
+) ([]models.SanctionCheckWithMatches, error) {
+	sanctionChecks := make([]models.SanctionCheckWithMatches, 0)
+	for _, decision := range decisions {
+		decisionSanctionChecks, err := w.repository.GetSanctionChecksForDecision(ctx, exec, decision.DecisionId, false)
+		if err != nil {
+			return nil, err
+		}
+		sanctionChecks = append(sanctionChecks, decisionSanctionChecks...)
+	}
+
+	matchIds := make([]string, 0)
+	for _, sc := range sanctionChecks {
+		for _, match := range sc.Matches {
+			matchIds = append(matchIds, match.Id)
+		}
+	}
+	if len(matchIds) == 0 {
+		return sanctionChecks, nil
+	}
+
+	comments, err := w.repository.ListSanctionCheckCommentsByIds(ctx, exec, matchIds)
+	if err != nil {
+		return nil, err
+	}
+	commentsByMatch := make(map[string][]models.SanctionCheckMatchComment)
+	for _, comment := range comments {
+		commentsByMatch[comment.MatchId] = append(commentsByMatch[comment.MatchId], comment)
+	}
+	for i := range sanctionChecks {
+		for j := range sanctionChecks[i].Matches {
+			sanctionChecks[i].Matches[j].Comments = commentsByMatch[sanctionChecks[i].Matches[j].Id]
+		}
+	}
+
+	return sanctionChecks, nil
+}
+
+// writeAnnotations writes the annotations of the case, and the files attached to file annotations
+func (w *CaseExportWorker) writeAnnotations(ctx context.Context, writer *caseExportWriter,
+	annotations []models.EntityAnnotation,
+) error {
+	annotationDtos := make([]dto.EntityAnnotationDto, 0, len(annotations))
+	for _, annotation := range annotations {
+		annotationDto, err := dto.AdaptEntityAnnotation(annotation)
+		if err != nil {
+			return err
+		}
+		annotationDtos = append(annotationDtos, annotationDto)
+	}
+	if err := writer.WriteJson("annotations.json", annotationDtos); err != nil {
+		return err
+	}
+
+	for _, annotation := range annotations {
+		if annotation.AnnotationType != models.EntityAnnotationFile {
+			continue
+		}
+		var payload models.EntityAnnotationFilePayload
+		if err := json.Unmarshal(annotation.Payload, &payload); err != nil {
+			return err
+		}
+		for _, file := range payload.Files {
+			if file.ScanStatus.IsQuarantined() {
+				continue
+			}
+			if err := w.copyBlob(ctx, writer, payload.Bucket, file.Key,
+				caseExportFilePath("annotations", file.Id, file.Filename)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (w *CaseExportWorker) copyBlob(ctx context.Context, writer *caseExportWriter,
+	bucketUrl, key, filePath string,
+) error {
+	blob, err := w.blobRepository.GetBlob(ctx, bucketUrl, key)
+	if err != nil {
+		return err
+	}
+	defer blob.ReadCloser.Close()
+
+	return writer.WriteFile(filePath, blob.ReadCloser)
+}
+
+type byteCounter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *byteCounter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
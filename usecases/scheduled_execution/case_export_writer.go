@@ -0,0 +1,189 @@
+package scheduled_execution
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"html/template"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/checkmarble/marble-backend/models"
+)
+
+const caseExportManifestPath = "manifest.json"
+
+// caseExportWriter writes the files of a case export to a ZIP archive, and keeps track of their hash for the manifest
+// that is written last, when the writer is closed.
+type caseExportWriter struct {
+	zip      *zip.Writer
+	manifest models.CaseExportManifest
+}
+
+func newCaseExportWriter(w io.Writer, export models.CaseExport, generatedAt time.Time) *caseExportWriter {
+	return &caseExportWriter{
+		zip: zip.NewWriter(w),
+		manifest: models.CaseExportManifest{
+			CaseId:      export.CaseId,
+			ExportId:    export.Id,
+			GeneratedAt: generatedAt.UTC(),
+			Files:       make([]models.CaseExportManifestFile, 0),
+		},
+	}
+}
+
+func (w *caseExportWriter) WriteFile(filePath string, r io.Reader) error {
+	fileWriter, err := w.zip.CreateHeader(&zip.FileHeader{
+		Name:     filePath,
+		Method:   zip.Deflate,
+		Modified: w.manifest.GeneratedAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(fileWriter, hash), r)
+	if err != nil {
+		return err
+	}
+
+	w.manifest.Files = append(w.manifest.Files, models.CaseExportManifestFile{
+		Path:      filePath,
+		SizeBytes: size,
+		Sha256:    hex.EncodeToString(hash.Sum(nil)),
+	})
+	return nil
+}
+
+func (w *caseExportWriter) WriteJson(filePath string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return w.WriteFile(filePath, bytes.NewReader(data))
+}
+
+func (w *caseExportWriter) WriteSummary(summary caseExportSummary) error {
+	var buf bytes.Buffer
+	if err := caseExportSummaryTemplate.Execute(&buf, summary); err != nil {
+		return err
+	}
+	return w.WriteFile("summary.html", &buf)
+}
+
+// NbFiles is the number of files written so far, excluding the manifest
+func (w *caseExportWriter) NbFiles() int {
+	return len(w.manifest.Files)
+}
+
+func (w *caseExportWriter) Close() error {
+	fileWriter, err := w.zip.CreateHeader(&zip.FileHeader{
+		Name:     caseExportManifestPath,
+		Method:   zip.Deflate,
+		Modified: w.manifest.GeneratedAt,
+	})
+	if err != nil {
+		return err
+	}
+	if err := json.NewEncoder(fileWriter).Encode(w.manifest); err != nil {
+		return err
+	}
+	return w.zip.Close()
+}
+
+// caseExportFilePath returns the path of an attached file in the archive. The id of the file is prepended to its name,
+// because several files of a case can have the same name.
+func caseExportFilePath(dir, id, fileName string) string {
+	name := path.Base(strings.ReplaceAll(fileName, "\\", "/"))
+	if name == "." || name == "/" {
+		name = "file"
+	}
+	return path.Join(dir, id+"_"+name)
+}
+
+type caseExportSummary struct {
+	Case           models.Case
+	GeneratedAt    time.Time
+	Decisions      []models.DecisionWithRuleExecutions
+	SanctionChecks []models.SanctionCheckWithMatches
+	Events         []models.CaseEvent
+	Files          []models.CaseFile
+	Reports        []models.SuspiciousActivityReport
+	NbAnnotations  int
+}
+
+var caseExportSummaryTemplate = template.Must(template.New("summary").Funcs(template.FuncMap{
+	"date": func(t time.Time) string { return t.UTC().Format("2006-01-02 15:04:05 UTC") },
+	"hitRules": func(d models.DecisionWithRuleExecutions) string {
+		names := make([]string, 0)
+		for _, re := range d.RuleExecutions {
+			if re.Outcome == "hit" {
+				names = append(names, re.Rule.Name)
+			}
+		}
+		return strings.Join(names, ", ")
+	},
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Case {{ .Case.Name }}</title>
+<style>
+body { font-family: sans-serif; font-size: 13px; margin: 2em; }
+table { border-collapse: collapse; width: 100%; margin-bottom: 2em; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; vertical-align: top; }
+th { background: #f3f3f3; }
+</style>
+</head>
+<body>
+<h1>Case {{ .Case.Name }}</h1>
+<table>
+<tr><th>Id</th><td>{{ .Case.Id }}</td></tr>
+<tr><th>Status</th><td>{{ .Case.Status }}</td></tr>
+<tr><th>Outcome</th><td>{{ .Case.Outcome }}</td></tr>
+<tr><th>Inbox</th><td>{{ .Case.InboxId }}</td></tr>
+<tr><th>Assignee</th><td>{{ with .Case.AssignedTo }}{{ . }}{{ end }}</td></tr>
+<tr><th>Created at</th><td>{{ date .Case.CreatedAt }}</td></tr>
+<tr><th>Exported at</th><td>{{ date .GeneratedAt }}</td></tr>
+</table>
+
+<h2>Decisions ({{ len .Decisions }})</h2>
+<table>
+<tr><th>Created at</th><th>Id</th><th>Scenario</th><th>Score</th><th>Outcome</th><th>Review status</th><th>Hit rules</th></tr>
+{{ range .Decisions }}<tr><td>{{ date .CreatedAt }}</td><td>{{ .DecisionId }}</td><td>{{ .ScenarioName }} (v{{ .ScenarioVersion }})</td><td>{{ .Score }}</td><td>{{ .Outcome }}</td><td>{{ with .ReviewStatus }}{{ . }}{{ end }}</td><td>{{ hitRules . }}</td></tr>
+{{ end }}</table>
+
+<h2>Sanction checks ({{ len .SanctionChecks }})</h2>
+<table>
+<tr><th>Decision</th><th>Status</th><th>Match</th><th>Match status</th><th>Reviewer comments</th></tr>
+{{ range $sc := .SanctionChecks }}{{ range .Matches }}<tr><td>{{ $sc.DecisionId }}</td><td>{{ $sc.Status }}</td><td>{{ .EntityId }}</td><td>{{ .Status }}</td><td>{{ range .Comments }}<p>{{ date .CreatedAt }} {{ .CommenterId }}: {{ .Comment }}</p>{{ end }}</td></tr>
+{{ else }}<tr><td>{{ $sc.DecisionId }}</td><td>{{ $sc.Status }}</td><td colspan="3">No match</td></tr>
+{{ end }}{{ end }}</table>
+
+<h2>Timeline ({{ len .Events }})</h2>
+<table>
+<tr><th>Date</th><th>Event</th><th>User</th><th>Resource</th><th>Value</th><th>Note</th></tr>
+{{ range .Events }}<tr><td>{{ date .CreatedAt }}</td><td>{{ .EventType }}</td><td>{{ .UserId.ValueOrZero }}</td><td>{{ .ResourceType }} {{ .ResourceId }}</td><td>{{ .NewValue }}</td><td>{{ .AdditionalNote }}</td></tr>
+{{ end }}</table>
+
+<h2>Files ({{ len .Files }})</h2>
+<table>
+<tr><th>Uploaded at</th><th>Name</th><th>Scan status</th></tr>
+{{ range .Files }}<tr><td>{{ date .CreatedAt }}</td><td>{{ .FileName }}</td><td>{{ .ScanStatus }}</td></tr>
+{{ end }}</table>
+
+<h2>Suspicious activity reports ({{ len .Reports }})</h2>
+<table>
+<tr><th>Created at</th><th>Id</th><th>Status</th><th>Created by</th></tr>
+{{ range .Reports }}<tr><td>{{ date .CreatedAt }}</td><td>{{ .ReportId }}</td><td>{{ .Status }}</td><td>{{ .CreatedBy }}</td></tr>
+{{ end }}</table>
+
+<p>{{ .NbAnnotations }} entity annotations are included in annotations.json.</p>
+</body>
+</html>
+`))
@@ -0,0 +1,72 @@
+package scheduled_execution
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/checkmarble/marble-backend/models"
+)
+
+func TestCaseExportWriter(t *testing.T) {
+	var buf bytes.Buffer
+	export := models.CaseExport{Id: "export_1", CaseId: "case_1"}
+	generatedAt := time.Date(2025, 6, 3, 10, 0, 0, 0, time.UTC)
+
+	w := newCaseExportWriter(&buf, export, generatedAt)
+	require.NoError(t, w.WriteSummary(caseExportSummary{
+		Case:        models.Case{Id: "case_1", Name: "<Fraud> ring", Status: models.CaseInvestigating},
+		GeneratedAt: generatedAt,
+		Decisions: []models.DecisionWithRuleExecutions{{
+			Decision: models.Decision{DecisionId: "decision_1", ScenarioName: "Transfers"},
+			RuleExecutions: []models.RuleExecution{
+				{Outcome: "hit", Rule: models.Rule{Name: "Large amount"}},
+				{Outcome: "no_hit", Rule: models.Rule{Name: "New account"}},
+			},
+		}},
+	}))
+	require.NoError(t, w.WriteJson("case.json", map[string]string{"id": "case_1"}))
+	require.NoError(t, w.WriteFile(caseExportFilePath("files", "file_1", "../../report.pdf"),
+		strings.NewReader("pdf content")))
+	assert.Equal(t, 3, w.NbFiles())
+	require.NoError(t, w.Close())
+
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+
+	contents := make(map[string][]byte)
+	for _, f := range archive.File {
+		r, err := f.Open()
+		require.NoError(t, err)
+		data, err := io.ReadAll(r)
+		require.NoError(t, err)
+		contents[f.Name] = data
+	}
+	require.Len(t, contents, 4)
+
+	summary := string(contents["summary.html"])
+	assert.Contains(t, summary, "Case &lt;Fraud&gt; ring")
+	assert.Contains(t, summary, "<td>Large amount</td>")
+	assert.Contains(t, contents, "files/file_1_report.pdf")
+
+	var manifest models.CaseExportManifest
+	require.NoError(t, json.Unmarshal(contents[caseExportManifestPath], &manifest))
+	assert.Equal(t, "case_1", manifest.CaseId)
+	assert.Equal(t, "export_1", manifest.ExportId)
+	assert.Equal(t, generatedAt, manifest.GeneratedAt)
+	require.Len(t, manifest.Files, 3)
+	for _, file := range manifest.Files {
+		hash := sha256.Sum256(contents[file.Path])
+		assert.Equal(t, hex.EncodeToString(hash[:]), file.Sha256, file.Path)
+		assert.Equal(t, int64(len(contents[file.Path])), file.SizeBytes, file.Path)
+	}
+}
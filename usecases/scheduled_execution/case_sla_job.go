@@ -0,0 +1,160 @@
+package scheduled_execution
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/riverqueue/river"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/repositories"
+	"github.com/checkmarble/marble-backend/usecases/executor_factory"
+	"github.com/checkmarble/marble-backend/utils"
+)
+
+const (
+	CASE_SLA_WORKER_INTERVAL = time.Minute
+	caseSlaBreachBatchSize   = 100
+)
+
+type caseSlaRepository interface {
+	RefreshCaseSlaDueDates(ctx context.Context, exec repositories.Executor, organizationId string) error
+	ListCaseSlaBreaches(ctx context.Context, exec repositories.Executor, organizationId string,
+		limit int) ([]models.CaseSlaBreach, error)
+	FlagCaseSlaBreach(ctx context.Context, exec repositories.Executor, caseId string,
+		kind models.SlaBreachKind) (bool, error)
+	BoostCase(ctx context.Context, exec repositories.Executor, id string, reason models.BoostReason) error
+	CreateCaseEvent(ctx context.Context, exec repositories.Executor,
+		createCaseEventAttributes models.CreateCaseEventAttributes) error
+}
+
+type caseEscalator interface {
+	EscalateCase(ctx context.Context, caseId string) error
+}
+
+func NewCaseSlaPeriodicJob(orgId string) *river.PeriodicJob {
+	return river.NewPeriodicJob(
+		river.PeriodicInterval(CASE_SLA_WORKER_INTERVAL),
+		func() (river.JobArgs, *river.InsertOpts) {
+			return models.CaseSlaArgs{
+				OrgId: orgId,
+			}, &river.InsertOpts{
+				Queue: orgId,
+				UniqueOpts: river.UniqueOpts{
+					ByQueue:  true,
+					ByPeriod: CASE_SLA_WORKER_INTERVAL,
+				},
+			}
+		},
+		&river.PeriodicJobOpts{RunOnStart: true},
+	)
+}
+
+// CaseSlaWorker computes the deadlines of the open cases of an organization from the SLA policies of their inbox, and
+// handles the cases that missed a deadline. A breached case is flagged, and boosted or escalated depending on its
+// policy. Each deadline of a case is breached at most once.
+type CaseSlaWorker struct {
+	river.WorkerDefaults[models.CaseSlaArgs]
+
+	executorFactory     executor_factory.ExecutorFactory
+	transactionFactory  executor_factory.TransactionFactory
+	repository          caseSlaRepository
+	caseEscalator       caseEscalator
+	webhookEventsSender webhookEventsUsecase
+}
+
+func NewCaseSlaWorker(
+	executorFactory executor_factory.ExecutorFactory,
+	transactionFactory executor_factory.TransactionFactory,
+	repository caseSlaRepository,
+	caseEscalator caseEscalator,
+	webhookEventsSender webhookEventsUsecase,
+) CaseSlaWorker {
+	return CaseSlaWorker{
+		executorFactory:     executorFactory,
+		transactionFactory:  transactionFactory,
+		repository:          repository,
+		caseEscalator:       caseEscalator,
+		webhookEventsSender: webhookEventsSender,
+	}
+}
+
+func (w *CaseSlaWorker) Timeout(job *river.Job[models.CaseSlaArgs]) time.Duration {
+	return CASE_SLA_WORKER_INTERVAL
+}
+
+func (w *CaseSlaWorker) Work(ctx context.Context, job *river.Job[models.CaseSlaArgs]) error {
+	exec := w.executorFactory.NewExecutor()
+	if err := w.repository.RefreshCaseSlaDueDates(ctx, exec, job.Args.OrgId); err != nil {
+		return err
+	}
+
+	for {
+		breaches, err := w.repository.ListCaseSlaBreaches(ctx, exec, job.Args.OrgId, caseSlaBreachBatchSize)
+		if err != nil {
+			return err
+		}
+		for _, breach := range breaches {
+			if err := w.handleBreach(ctx, breach); err != nil {
+				return err
+			}
+		}
+		if len(breaches) < caseSlaBreachBatchSize {
+			return nil
+		}
+	}
+}
+
+// handleBreach flags the case and sends the webhook, then escalates the case if its policy requires it. A failed
+// escalation is not retried, because the breach is already recorded.
+func (w *CaseSlaWorker) handleBreach(ctx context.Context, breach models.CaseSlaBreach) error {
+	logger := utils.LoggerFromContext(ctx)
+	webhookEventId := uuid.NewString()
+
+	flagged, err := executor_factory.TransactionReturnValue(ctx, w.transactionFactory, func(
+		tx repositories.Transaction,
+	) (bool, error) {
+		ok, err := w.repository.FlagCaseSlaBreach(ctx, tx, breach.CaseId, breach.Kind)
+		if err != nil || !ok {
+			return false, err
+		}
+
+		if breach.BreachAction == models.SlaBreachBoost {
+			if err := w.repository.BoostCase(ctx, tx, breach.CaseId, models.BoostSlaBreached); err != nil {
+				return false, err
+			}
+		}
+
+		err = w.repository.CreateCaseEvent(ctx, tx, models.CreateCaseEventAttributes{
+			CaseId:    breach.CaseId,
+			EventType: models.CaseSlaBreached,
+			NewValue:  utils.Ptr(string(breach.Kind)),
+		})
+		if err != nil {
+			return false, err
+		}
+
+		err = w.webhookEventsSender.CreateWebhookEvent(ctx, tx, models.WebhookEventCreate{
+			Id:             webhookEventId,
+			OrganizationId: breach.OrganizationId,
+			EventContent:   models.NewWebhookEventCaseSlaBreached(breach),
+		})
+		return err == nil, err
+	})
+	if err != nil {
+		return err
+	}
+	if !flagged {
+		return nil
+	}
+	w.webhookEventsSender.SendWebhookEventAsync(ctx, webhookEventId)
+
+	if breach.BreachAction == models.SlaBreachEscalate {
+		if err := w.caseEscalator.EscalateCase(ctx, breach.CaseId); err != nil {
+			logger.WarnContext(ctx, "could not escalate a case that breached its SLA",
+				"case_id", breach.CaseId, "error", err)
+		}
+	}
+	return nil
+}
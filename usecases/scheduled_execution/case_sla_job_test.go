@@ -0,0 +1,141 @@
+package scheduled_execution
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/riverqueue/river"
+	"github.com/riverqueue/river/rivertype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/repositories"
+	"github.com/checkmarble/marble-backend/usecases/executor_factory"
+)
+
+type fakeSlaCase struct {
+	id                    string
+	status                models.CaseStatus
+	firstActionDueAt      time.Time
+	closeDueAt            time.Time
+	firstActionBreachedAt *time.Time
+	closeBreachedAt       *time.Time
+}
+
+// fakeCaseSlaRepository lists the missed deadlines of its cases like the repository does
+type fakeCaseSlaRepository struct {
+	cases  []*fakeSlaCase
+	now    time.Time
+	events []models.CreateCaseEventAttributes
+	boosts []string
+}
+
+func (r *fakeCaseSlaRepository) RefreshCaseSlaDueDates(ctx context.Context, exec repositories.Executor,
+	organizationId string,
+) error {
+	return nil
+}
+
+func (r *fakeCaseSlaRepository) ListCaseSlaBreaches(ctx context.Context, exec repositories.Executor,
+	organizationId string, limit int,
+) ([]models.CaseSlaBreach, error) {
+	breaches := make([]models.CaseSlaBreach, 0)
+	for _, c := range r.cases {
+		breach := models.CaseSlaBreach{CaseId: c.id, OrganizationId: organizationId, BreachAction: models.SlaBreachBoost}
+		if c.status == models.CasePending && c.firstActionBreachedAt == nil && c.firstActionDueAt.Before(r.now) {
+			breach.Kind, breach.DueAt = models.SlaBreachFirstAction, c.firstActionDueAt
+			breaches = append(breaches, breach)
+		}
+		if c.status != models.CaseClosed && c.closeBreachedAt == nil && c.closeDueAt.Before(r.now) {
+			breach.Kind, breach.DueAt = models.SlaBreachClose, c.closeDueAt
+			breaches = append(breaches, breach)
+		}
+	}
+	return breaches, nil
+}
+
+func (r *fakeCaseSlaRepository) FlagCaseSlaBreach(ctx context.Context, exec repositories.Executor,
+	caseId string, kind models.SlaBreachKind,
+) (bool, error) {
+	for _, c := range r.cases {
+		if c.id != caseId {
+			continue
+		}
+		breachedAt := &c.closeBreachedAt
+		if kind == models.SlaBreachFirstAction {
+			breachedAt = &c.firstActionBreachedAt
+		}
+		if *breachedAt != nil {
+			return false, nil
+		}
+		*breachedAt = &r.now
+		return true, nil
+	}
+	return false, nil
+}
+
+func (r *fakeCaseSlaRepository) BoostCase(ctx context.Context, exec repositories.Executor, id string,
+	reason models.BoostReason,
+) error {
+	r.boosts = append(r.boosts, id)
+	return nil
+}
+
+func (r *fakeCaseSlaRepository) CreateCaseEvent(ctx context.Context, exec repositories.Executor,
+	createCaseEventAttributes models.CreateCaseEventAttributes,
+) error {
+	r.events = append(r.events, createCaseEventAttributes)
+	return nil
+}
+
+type fakeSlaWebhookEventsSender struct {
+	created []models.WebhookEventCreate
+}
+
+func (s *fakeSlaWebhookEventsSender) CreateWebhookEvent(ctx context.Context, tx repositories.Transaction,
+	input models.WebhookEventCreate,
+) error {
+	s.created = append(s.created, input)
+	return nil
+}
+
+func (s *fakeSlaWebhookEventsSender) SendWebhookEventAsync(ctx context.Context, webhookEventId string) {
+}
+
+type fakeCaseEscalator struct{}
+
+func (fakeCaseEscalator) EscalateCase(ctx context.Context, caseId string) error { return nil }
+
+func TestCaseSlaWorker_breaches_each_deadline_once(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	c := &fakeSlaCase{
+		id:               "case_id",
+		status:           models.CasePending,
+		firstActionDueAt: now.Add(-time.Hour),
+		closeDueAt:       now.Add(time.Hour),
+	}
+	repo := &fakeCaseSlaRepository{cases: []*fakeSlaCase{c}, now: now}
+	webhooks := &fakeSlaWebhookEventsSender{}
+	exec := executor_factory.NewExecutorFactoryStub()
+	worker := NewCaseSlaWorker(exec, executor_factory.NewTransactionFactoryStub(exec),
+		repo, fakeCaseEscalator{}, webhooks)
+	job := &river.Job[models.CaseSlaArgs]{JobRow: &rivertype.JobRow{}, Args: models.CaseSlaArgs{OrgId: "org"}}
+
+	require.NoError(t, worker.Work(context.Background(), job))
+	require.NoError(t, worker.Work(context.Background(), job))
+	require.Len(t, repo.events, 1)
+	assert.Equal(t, string(models.SlaBreachFirstAction), *repo.events[0].NewValue)
+
+	// the case was picked up late, and then missed its close deadline too
+	c.status = models.CaseInvestigating
+	repo.now = now.Add(2 * time.Hour)
+
+	require.NoError(t, worker.Work(context.Background(), job))
+	require.NoError(t, worker.Work(context.Background(), job))
+	require.Len(t, repo.events, 2)
+	assert.Equal(t, string(models.SlaBreachClose), *repo.events[1].NewValue)
+	assert.Len(t, webhooks.created, 2)
+	assert.Equal(t, []string{"case_id", "case_id"}, repo.boosts)
+}
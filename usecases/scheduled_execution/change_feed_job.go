@@ -0,0 +1,126 @@
+package scheduled_execution
+
+import (
+	"context"
+	"time"
+
+	"github.com/riverqueue/river"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/repositories"
+	"github.com/checkmarble/marble-backend/usecases/executor_factory"
+	"github.com/checkmarble/marble-backend/utils"
+)
+
+const (
+	CHANGE_FEED_WORKER_INTERVAL = time.Minute
+	changeFeedRetention         = 7 * 24 * time.Hour
+	changeFeedPushBatchSize     = 500
+	// leave some margin before the next run of the job is scheduled
+	changeFeedPushBudget = 50 * time.Second
+)
+
+type changeFeedWorkerRepository interface {
+	GetChangeFeedConfig(ctx context.Context, exec repositories.Executor, orgId string) (models.ChangeFeedConfig, error)
+	ListChangeEvents(ctx context.Context, exec repositories.Executor, orgId string,
+		after models.ChangeFeedCursor, limit int) ([]models.ChangeEvent, error)
+	UpdateChangeFeedSinkCursor(ctx context.Context, exec repositories.Executor,
+		orgId string, cursor models.ChangeFeedCursor) error
+	DeleteChangeEventsBefore(ctx context.Context, exec repositories.Executor, orgId string, before time.Time) (int, error)
+}
+
+func NewChangeFeedPeriodicJob(orgId string) *river.PeriodicJob {
+	return river.NewPeriodicJob(
+		river.PeriodicInterval(CHANGE_FEED_WORKER_INTERVAL),
+		func() (river.JobArgs, *river.InsertOpts) {
+			return models.ChangeFeedArgs{
+				OrgId: orgId,
+			}, &river.InsertOpts{
+				Queue: orgId,
+				UniqueOpts: river.UniqueOpts{
+					ByQueue:  true,
+					ByPeriod: CHANGE_FEED_WORKER_INTERVAL,
+				},
+			}
+		},
+		&river.PeriodicJobOpts{RunOnStart: true},
+	)
+}
+
+// ChangeFeedWorker pushes the new change events of an organization to its configured sink, and deletes the events
+// that are past the change feed retention period. The sink cursor is saved after each published batch, so events are
+// delivered at least once.
+type ChangeFeedWorker struct {
+	river.WorkerDefaults[models.ChangeFeedArgs]
+
+	executorFactory executor_factory.ExecutorFactory
+	repository      changeFeedWorkerRepository
+	sinkRepository  repositories.ChangeFeedSinkRepository
+}
+
+func NewChangeFeedWorker(
+	executorFactory executor_factory.ExecutorFactory,
+	repository changeFeedWorkerRepository,
+	sinkRepository repositories.ChangeFeedSinkRepository,
+) ChangeFeedWorker {
+	return ChangeFeedWorker{
+		executorFactory: executorFactory,
+		repository:      repository,
+		sinkRepository:  sinkRepository,
+	}
+}
+
+func (w *ChangeFeedWorker) Timeout(job *river.Job[models.ChangeFeedArgs]) time.Duration {
+	return CHANGE_FEED_WORKER_INTERVAL
+}
+
+func (w *ChangeFeedWorker) Work(ctx context.Context, job *river.Job[models.ChangeFeedArgs]) error {
+	logger := utils.LoggerFromContext(ctx)
+	exec := w.executorFactory.NewExecutor()
+
+	nbDeleted, err := w.repository.DeleteChangeEventsBefore(ctx, exec,
+		job.Args.OrgId, time.Now().Add(-changeFeedRetention))
+	if err != nil {
+		return err
+	}
+	if nbDeleted > 0 {
+		logger.InfoContext(ctx, "deleted expired change events", "org_id", job.Args.OrgId, "nb_deleted", nbDeleted)
+	}
+
+	config, err := w.repository.GetChangeFeedConfig(ctx, exec, job.Args.OrgId)
+	if err != nil {
+		return err
+	}
+	if !config.Enabled || config.SinkType == nil {
+		return nil
+	}
+
+	deadline := time.Now().Add(changeFeedPushBudget)
+	cursor := config.SinkCursor
+	nbPushed := 0
+	for time.Now().Before(deadline) {
+		events, err := w.repository.ListChangeEvents(ctx, exec, job.Args.OrgId, cursor, changeFeedPushBatchSize)
+		if err != nil {
+			return err
+		}
+		if len(events) == 0 {
+			break
+		}
+		if err := w.sinkRepository.Publish(ctx, config, events); err != nil {
+			return err
+		}
+		cursor = events[len(events)-1].Cursor
+		if err := w.repository.UpdateChangeFeedSinkCursor(ctx, exec, job.Args.OrgId, cursor); err != nil {
+			return err
+		}
+		nbPushed += len(events)
+	}
+
+	if nbPushed > 0 {
+		logger.DebugContext(ctx, "pushed change events to sink",
+			"org_id", job.Args.OrgId,
+			"sink_type", *config.SinkType,
+			"nb_events", nbPushed)
+	}
+	return nil
+}
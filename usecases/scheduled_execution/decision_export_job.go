@@ -0,0 +1,220 @@
+package scheduled_execution
+
+import (
+	"context"
+	"time"
+
+	"github.com/riverqueue/river"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/pure_utils"
+	"github.com/checkmarble/marble-backend/repositories"
+	"github.com/checkmarble/marble-backend/usecases/executor_factory"
+	"github.com/checkmarble/marble-backend/utils"
+)
+
+const (
+	decisionExportPageSize = 1000
+	decisionExportTimeout  = 30 * time.Minute
+)
+
+type decisionExportRepository interface {
+	GetDecisionExport(ctx context.Context, exec repositories.Executor, id string) (models.DecisionExport, error)
+	UpdateDecisionExport(ctx context.Context, exec repositories.Executor, id string, update models.DecisionExportUpdate) error
+	DecisionsOfOrganization(ctx context.Context, exec repositories.Executor, organizationId string,
+		paginationAndSorting models.PaginationAndSorting, filters models.DecisionFilters) ([]models.Decision, error)
+	DecisionsWithRuleExecutionsByIds(ctx context.Context, exec repositories.Executor,
+		decisionIds []string) ([]models.DecisionWithRuleExecutions, error)
+	ListSanctionCheckStatusesOfDecisions(ctx context.Context, exec repositories.Executor,
+		decisionIds []string) (map[string]string, error)
+}
+
+// DecisionExportWorker writes the decisions of an export to blob storage. Each run of the job writes one file of at
+// most models.DecisionExportFileSize decisions and saves its progress, then snoozes itself so that the next file is
+// written by a new run. A failed run is retried from the last completed file.
+type DecisionExportWorker struct {
+	river.WorkerDefaults[models.DecisionExportArgs]
+
+	executorFactory executor_factory.ExecutorFactory
+	repository      decisionExportRepository
+	blobRepository  repositories.BlobRepository
+	bucketUrl       string
+}
+
+func NewDecisionExportWorker(
+	executorFactory executor_factory.ExecutorFactory,
+	repository decisionExportRepository,
+	blobRepository repositories.BlobRepository,
+	bucketUrl string,
+) DecisionExportWorker {
+	return DecisionExportWorker{
+		executorFactory: executorFactory,
+		repository:      repository,
+		blobRepository:  blobRepository,
+		bucketUrl:       bucketUrl,
+	}
+}
+
+func (w *DecisionExportWorker) Timeout(job *river.Job[models.DecisionExportArgs]) time.Duration {
+	return decisionExportTimeout
+}
+
+func (w *DecisionExportWorker) Work(ctx context.Context, job *river.Job[models.DecisionExportArgs]) error {
+	exec := w.executorFactory.NewExecutor()
+	export, err := w.repository.GetDecisionExport(ctx, exec, job.Args.ExportId)
+	if err != nil {
+		return err
+	}
+	if export.Status == models.DecisionExportSuccess || export.Status == models.DecisionExportFailed {
+		return nil
+	}
+
+	done, err := w.exportNextFile(ctx, exec, &export)
+	if err != nil {
+		if job.Attempt >= job.MaxAttempts {
+			utils.LoggerFromContext(ctx).ErrorContext(ctx, "decision export failed",
+				"export_id", export.Id, "error", err)
+			export.Status = models.DecisionExportFailed
+			if updateErr := w.updateExport(ctx, exec, export); updateErr != nil {
+				return updateErr
+			}
+		}
+		return err
+	}
+
+	if done {
+		export.Status = models.DecisionExportSuccess
+		if err := w.updateExport(ctx, exec, export); err != nil {
+			return err
+		}
+		utils.LoggerFromContext(ctx).InfoContext(ctx, "decision export done",
+			"export_id", export.Id,
+			"nb_files", export.NbFiles,
+			"nb_decisions", export.NbDecisions)
+		return nil
+	}
+
+	export.Status = models.DecisionExportRunning
+	if err := w.updateExport(ctx, exec, export); err != nil {
+		return err
+	}
+	return river.JobSnooze(0)
+}
+
+// exportNextFile writes the next file of the export, and updates its progress in place. It returns true when all the
+// decisions have been exported.
+func (w *DecisionExportWorker) exportNextFile(ctx context.Context, exec repositories.Executor,
+	export *models.DecisionExport,
+) (bool, error) {
+	pagination := models.PaginationAndSorting{
+		Sorting: models.DecisionSortingCreatedAt,
+		Order:   models.SortingOrderAsc,
+		Limit:   decisionExportPageSize,
+	}
+	if export.CursorDecisionId != nil {
+		pagination.OffsetId = *export.CursorDecisionId
+	}
+
+	decisions, err := w.repository.DecisionsOfOrganization(ctx, exec,
+		export.OrganizationId, pagination, export.Filters)
+	if err != nil {
+		return false, err
+	}
+	// An export without any decision still gets a file, with only the headers for csv
+	if len(decisions) == 0 && export.NbFiles > 0 {
+		return true, nil
+	}
+
+	stream, err := w.blobRepository.OpenStream(ctx, w.bucketUrl,
+		export.FileKey(export.NbFiles+1), export.FileKey(export.NbFiles+1))
+	if err != nil {
+		return false, err
+	}
+	defer stream.Close()
+
+	writer, err := newDecisionExportWriter(export.Format, stream)
+	if err != nil {
+		return false, err
+	}
+
+	nbWritten := 0
+	cursor := export.CursorDecisionId
+	for len(decisions) > 0 {
+		rows, err := w.exportRows(ctx, exec, decisions)
+		if err != nil {
+			return false, err
+		}
+		if err := writer.Write(rows); err != nil {
+			return false, err
+		}
+		nbWritten += len(decisions)
+		cursor = &decisions[len(decisions)-1].DecisionId
+
+		if len(decisions) < pagination.Limit || nbWritten >= models.DecisionExportFileSize {
+			break
+		}
+		pagination.OffsetId = *cursor
+		decisions, err = w.repository.DecisionsOfOrganization(ctx, exec,
+			export.OrganizationId, pagination, export.Filters)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return false, err
+	}
+	if err := stream.Close(); err != nil {
+		return false, err
+	}
+
+	export.NbFiles++
+	export.NbDecisions += nbWritten
+	export.CursorDecisionId = cursor
+	return nbWritten < models.DecisionExportFileSize, nil
+}
+
+func (w *DecisionExportWorker) exportRows(ctx context.Context, exec repositories.Executor,
+	decisions []models.Decision,
+) ([]decisionExportRow, error) {
+	decisionIds := pure_utils.Map(decisions, func(d models.Decision) string { return d.DecisionId })
+	decisionsWithRules, err := w.repository.DecisionsWithRuleExecutionsByIds(ctx, exec, decisionIds)
+	if err != nil {
+		return nil, err
+	}
+	sanctionCheckStatuses, err := w.repository.ListSanctionCheckStatusesOfDecisions(ctx, exec, decisionIds)
+	if err != nil {
+		return nil, err
+	}
+
+	decisionsById := make(map[string]models.DecisionWithRuleExecutions, len(decisionsWithRules))
+	for _, d := range decisionsWithRules {
+		decisionsById[d.DecisionId] = d
+	}
+
+	// keep the chronological order of the paginated decisions
+	rows := make([]decisionExportRow, 0, len(decisions))
+	for _, decision := range decisions {
+		d, ok := decisionsById[decision.DecisionId]
+		if !ok {
+			continue
+		}
+		var sanctionCheckStatus *string
+		if status, ok := sanctionCheckStatuses[decision.DecisionId]; ok {
+			sanctionCheckStatus = &status
+		}
+		rows = append(rows, newDecisionExportRow(d, sanctionCheckStatus))
+	}
+	return rows, nil
+}
+
+func (w *DecisionExportWorker) updateExport(ctx context.Context, exec repositories.Executor,
+	export models.DecisionExport,
+) error {
+	return w.repository.UpdateDecisionExport(ctx, exec, export.Id, models.DecisionExportUpdate{
+		Status:           export.Status,
+		NbFiles:          export.NbFiles,
+		NbDecisions:      export.NbDecisions,
+		CursorDecisionId: export.CursorDecisionId,
+	})
+}
@@ -0,0 +1,179 @@
+package scheduled_execution
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/pure_utils"
+)
+
+// decisionExportRow is the flat representation of a decision in export files. Rule executions are nested in parquet
+// files, and serialized as a JSON array in csv files.
+type decisionExportRow struct {
+	DecisionId           string                        `parquet:"decision_id"`
+	CreatedAt            time.Time                     `parquet:"created_at,timestamp(millisecond)"`
+	ScenarioId           string                        `parquet:"scenario_id"`
+	ScenarioIterationId  string                        `parquet:"scenario_iteration_id"`
+	ScenarioName         string                        `parquet:"scenario_name"`
+	ScenarioVersion      int64                         `parquet:"scenario_version"`
+	TriggerObjectType    string                        `parquet:"trigger_object_type"`
+	ObjectId             string                        `parquet:"object_id"`
+	PivotId              *string                       `parquet:"pivot_id,optional"`
+	PivotValue           *string                       `parquet:"pivot_value,optional"`
+	Score                int64                         `parquet:"score"`
+	Outcome              string                        `parquet:"outcome"`
+	ReviewStatus         *string                       `parquet:"review_status,optional"`
+	CaseId               *string                       `parquet:"case_id,optional"`
+	ScheduledExecutionId *string                       `parquet:"scheduled_execution_id,optional"`
+	SanctionCheckStatus  *string                       `parquet:"sanction_check_status,optional"`
+	RuleExecutions       []decisionExportRuleExecution `parquet:"rule_executions,list"`
+}
+
+type decisionExportRuleExecution struct {
+	RuleId         string `parquet:"rule_id" json:"rule_id"`
+	RuleName       string `parquet:"rule_name" json:"rule_name"`
+	Outcome        string `parquet:"outcome" json:"outcome"`
+	Result         bool   `parquet:"result" json:"result"`
+	ScoreModifier  int64  `parquet:"score_modifier" json:"score_modifier"`
+	ExecutionError int64  `parquet:"execution_error" json:"execution_error"`
+}
+
+func newDecisionExportRow(decision models.DecisionWithRuleExecutions, sanctionCheckStatus *string) decisionExportRow {
+	objectId, _ := decision.ClientObject.Data["object_id"].(string)
+	var caseId *string
+	if decision.Case != nil {
+		caseId = &decision.Case.Id
+	}
+
+	return decisionExportRow{
+		DecisionId:           decision.DecisionId,
+		CreatedAt:            decision.CreatedAt.UTC(),
+		ScenarioId:           decision.ScenarioId,
+		ScenarioIterationId:  decision.ScenarioIterationId,
+		ScenarioName:         decision.ScenarioName,
+		ScenarioVersion:      int64(decision.ScenarioVersion),
+		TriggerObjectType:    decision.ClientObject.TableName,
+		ObjectId:             objectId,
+		PivotId:              decision.PivotId,
+		PivotValue:           decision.PivotValue,
+		Score:                int64(decision.Score),
+		Outcome:              decision.Outcome.String(),
+		ReviewStatus:         decision.ReviewStatus,
+		CaseId:               caseId,
+		ScheduledExecutionId: decision.ScheduledExecutionId,
+		SanctionCheckStatus:  sanctionCheckStatus,
+		RuleExecutions: pure_utils.Map(decision.RuleExecutions, func(re models.RuleExecution) decisionExportRuleExecution {
+			return decisionExportRuleExecution{
+				RuleId:         re.Rule.Id,
+				RuleName:       re.Rule.Name,
+				Outcome:        re.Outcome,
+				Result:         re.Result,
+				ScoreModifier:  int64(re.ResultScoreModifier),
+				ExecutionError: int64(re.ExecutionError),
+			}
+		}),
+	}
+}
+
+type decisionExportWriter interface {
+	Write(rows []decisionExportRow) error
+	// Close flushes the buffered rows, it does not close the underlying writer
+	Close() error
+}
+
+func newDecisionExportWriter(format models.DecisionExportFormat, w io.Writer) (decisionExportWriter, error) {
+	switch format {
+	case models.DecisionExportFormatCsv:
+		cw := &csvDecisionExportWriter{w: csv.NewWriter(w)}
+		return cw, cw.w.Write(decisionExportCsvHeader)
+	case models.DecisionExportFormatParquet:
+		return parquetDecisionExportWriter{w: parquet.NewGenericWriter[decisionExportRow](w)}, nil
+	}
+	return nil, models.BadParameterError
+}
+
+var decisionExportCsvHeader = []string{
+	"decision_id",
+	"created_at",
+	"scenario_id",
+	"scenario_iteration_id",
+	"scenario_name",
+	"scenario_version",
+	"trigger_object_type",
+	"object_id",
+	"pivot_id",
+	"pivot_value",
+	"score",
+	"outcome",
+	"review_status",
+	"case_id",
+	"scheduled_execution_id",
+	"sanction_check_status",
+	"rule_executions",
+}
+
+type csvDecisionExportWriter struct {
+	w *csv.Writer
+}
+
+func (cw *csvDecisionExportWriter) Write(rows []decisionExportRow) error {
+	optionalString := func(s *string) string {
+		if s == nil {
+			return ""
+		}
+		return *s
+	}
+
+	for _, row := range rows {
+		ruleExecutions, err := json.Marshal(row.RuleExecutions)
+		if err != nil {
+			return err
+		}
+		if err := cw.w.Write([]string{
+			row.DecisionId,
+			row.CreatedAt.Format(time.RFC3339Nano),
+			row.ScenarioId,
+			row.ScenarioIterationId,
+			row.ScenarioName,
+			strconv.FormatInt(row.ScenarioVersion, 10),
+			row.TriggerObjectType,
+			row.ObjectId,
+			optionalString(row.PivotId),
+			optionalString(row.PivotValue),
+			strconv.FormatInt(row.Score, 10),
+			row.Outcome,
+			optionalString(row.ReviewStatus),
+			optionalString(row.CaseId),
+			optionalString(row.ScheduledExecutionId),
+			optionalString(row.SanctionCheckStatus),
+			string(ruleExecutions),
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (cw *csvDecisionExportWriter) Close() error {
+	cw.w.Flush()
+	return cw.w.Error()
+}
+
+type parquetDecisionExportWriter struct {
+	w *parquet.GenericWriter[decisionExportRow]
+}
+
+func (pw parquetDecisionExportWriter) Write(rows []decisionExportRow) error {
+	_, err := pw.w.Write(rows)
+	return err
+}
+
+func (pw parquetDecisionExportWriter) Close() error {
+	return pw.w.Close()
+}
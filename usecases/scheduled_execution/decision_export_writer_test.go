@@ -0,0 +1,83 @@
+package scheduled_execution
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/utils"
+)
+
+func testDecisionExportRows() []decisionExportRow {
+	decision := models.DecisionWithRuleExecutions{
+		Decision: models.Decision{
+			DecisionId:          "decision_1",
+			CreatedAt:           time.Date(2025, 5, 26, 10, 0, 0, 0, time.UTC),
+			ClientObject:        models.ClientObject{TableName: "transactions", Data: map[string]any{"object_id": "tx_1"}},
+			Outcome:             models.Decline,
+			PivotValue:          utils.Ptr("account_1"),
+			ScenarioId:          "scenario_1",
+			ScenarioIterationId: "iteration_1",
+			ScenarioName:        "Scenario",
+			ScenarioVersion:     2,
+			Score:               100,
+			Case:                &models.Case{Id: "case_1"},
+		},
+		RuleExecutions: []models.RuleExecution{
+			{Outcome: "hit", Result: true, ResultScoreModifier: 100, Rule: models.Rule{Id: "rule_1", Name: "Rule 1"}},
+			{Outcome: "no_hit", Rule: models.Rule{Id: "rule_2", Name: "Rule 2"}},
+		},
+	}
+	return []decisionExportRow{
+		newDecisionExportRow(decision, utils.Ptr("confirmed_hit")),
+		newDecisionExportRow(models.DecisionWithRuleExecutions{Decision: models.Decision{
+			DecisionId: "decision_2",
+			CreatedAt:  time.Date(2025, 5, 26, 11, 0, 0, 0, time.UTC),
+			Outcome:    models.Approve,
+		}}, nil),
+	}
+}
+
+func TestDecisionExportWriter_csv(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := newDecisionExportWriter(models.DecisionExportFormatCsv, &buf)
+	require.NoError(t, err)
+	require.NoError(t, w.Write(testDecisionExportRows()))
+	require.NoError(t, w.Close())
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	assert.Equal(t, decisionExportCsvHeader, records[0])
+	assert.Equal(t, []string{
+		"decision_1", "2025-05-26T10:00:00Z", "scenario_1", "iteration_1", "Scenario", "2",
+		"transactions", "tx_1", "", "account_1", "100", "decline", "", "case_1", "", "confirmed_hit",
+		`[{"rule_id":"rule_1","rule_name":"Rule 1","outcome":"hit","result":true,"score_modifier":100,"execution_error":0},` +
+			`{"rule_id":"rule_2","rule_name":"Rule 2","outcome":"no_hit","result":false,"score_modifier":0,"execution_error":0}]`,
+	}, records[1])
+	assert.Equal(t, "decision_2", records[2][0])
+	assert.Equal(t, "[]", records[2][16])
+}
+
+func TestDecisionExportWriter_parquet(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := newDecisionExportWriter(models.DecisionExportFormatParquet, &buf)
+	require.NoError(t, err)
+	rows := testDecisionExportRows()
+	require.NoError(t, w.Write(rows))
+	require.NoError(t, w.Close())
+
+	read, err := parquet.Read[decisionExportRow](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	require.Len(t, read, 2)
+	assert.Equal(t, rows[0], read[0])
+	assert.Equal(t, "decision_2", read[1].DecisionId)
+	assert.Nil(t, read[1].CaseId)
+	assert.Empty(t, read[1].RuleExecutions)
+}
@@ -0,0 +1,173 @@
+package scheduled_execution
+
+import (
+	"context"
+	"time"
+
+	"github.com/riverqueue/river"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/repositories"
+	"github.com/checkmarble/marble-backend/usecases/executor_factory"
+	"github.com/checkmarble/marble-backend/utils"
+)
+
+const (
+	decisionReevaluationPageSize = 100
+	// decisionReevaluationRunBudget is the duration after which a run of the job saves its progress and snoozes
+	decisionReevaluationRunBudget = 5 * time.Minute
+	decisionReevaluationTimeout   = 10 * time.Minute
+)
+
+type decisionReevaluationBatchRepository interface {
+	GetDecisionReevaluationBatch(ctx context.Context, exec repositories.Executor,
+		id string) (models.DecisionReevaluationBatch, error)
+	UpdateDecisionReevaluationBatch(ctx context.Context, exec repositories.Executor, id string,
+		update models.DecisionReevaluationBatchUpdate) error
+	DecisionsOfOrganization(ctx context.Context, exec repositories.Executor, organizationId string,
+		paginationAndSorting models.PaginationAndSorting, filters models.DecisionFilters) ([]models.Decision, error)
+}
+
+type decisionReevaluator interface {
+	ReevaluateDecision(ctx context.Context, input models.ReevaluateDecisionInput) (models.DecisionReevaluation, error)
+}
+
+// DecisionReevaluationBatchWorker re-evaluates the decisions of a batch in chronological order. Each run of the job
+// processes decisions for a limited time and saves its progress, then snoozes itself so that the next decisions are
+// processed by a new run. A decision that cannot be re-evaluated is counted as an error and skipped.
+type DecisionReevaluationBatchWorker struct {
+	river.WorkerDefaults[models.DecisionReevaluationBatchArgs]
+
+	executorFactory executor_factory.ExecutorFactory
+	repository      decisionReevaluationBatchRepository
+	reevaluator     decisionReevaluator
+}
+
+func NewDecisionReevaluationBatchWorker(
+	executorFactory executor_factory.ExecutorFactory,
+	repository decisionReevaluationBatchRepository,
+	reevaluator decisionReevaluator,
+) DecisionReevaluationBatchWorker {
+	return DecisionReevaluationBatchWorker{
+		executorFactory: executorFactory,
+		repository:      repository,
+		reevaluator:     reevaluator,
+	}
+}
+
+func (w *DecisionReevaluationBatchWorker) Timeout(job *river.Job[models.DecisionReevaluationBatchArgs]) time.Duration {
+	return decisionReevaluationTimeout
+}
+
+func (w *DecisionReevaluationBatchWorker) Work(ctx context.Context,
+	job *river.Job[models.DecisionReevaluationBatchArgs],
+) error {
+	exec := w.executorFactory.NewExecutor()
+	batch, err := w.repository.GetDecisionReevaluationBatch(ctx, exec, job.Args.BatchId)
+	if err != nil {
+		return err
+	}
+	if batch.Status == models.DecisionReevaluationBatchSuccess ||
+		batch.Status == models.DecisionReevaluationBatchFailed {
+		return nil
+	}
+
+	done, err := w.reevaluateNextDecisions(ctx, exec, &batch)
+	if err != nil {
+		if job.Attempt >= job.MaxAttempts {
+			utils.LoggerFromContext(ctx).ErrorContext(ctx, "decision re-evaluation batch failed",
+				"batch_id", batch.Id, "error", err)
+			batch.Status = models.DecisionReevaluationBatchFailed
+		}
+		if updateErr := w.updateBatch(ctx, exec, batch); updateErr != nil {
+			return updateErr
+		}
+		return err
+	}
+
+	if done {
+		batch.Status = models.DecisionReevaluationBatchSuccess
+		if err := w.updateBatch(ctx, exec, batch); err != nil {
+			return err
+		}
+		utils.LoggerFromContext(ctx).InfoContext(ctx, "decision re-evaluation batch done",
+			"batch_id", batch.Id,
+			"nb_decisions", batch.NbDecisions,
+			"nb_errors", batch.NbErrors)
+		return nil
+	}
+
+	batch.Status = models.DecisionReevaluationBatchRunning
+	if err := w.updateBatch(ctx, exec, batch); err != nil {
+		return err
+	}
+	return river.JobSnooze(0)
+}
+
+// reevaluateNextDecisions re-evaluates decisions until the run budget is spent, and updates the progress of the batch
+// in place. It returns true when all the decisions have been processed.
+func (w *DecisionReevaluationBatchWorker) reevaluateNextDecisions(ctx context.Context, exec repositories.Executor,
+	batch *models.DecisionReevaluationBatch,
+) (bool, error) {
+	logger := utils.LoggerFromContext(ctx)
+	start := time.Now()
+
+	// The decisions made after the batch was requested, including its own re-evaluations, are not selected
+	filters := batch.Filters
+	filters.ExcludeReevaluations = true
+	if filters.EndDate.IsZero() || filters.EndDate.After(batch.CreatedAt) {
+		filters.EndDate = batch.CreatedAt
+	}
+
+	pagination := models.PaginationAndSorting{
+		Sorting: models.DecisionSortingCreatedAt,
+		Order:   models.SortingOrderAsc,
+		Limit:   decisionReevaluationPageSize,
+	}
+
+	for time.Since(start) < decisionReevaluationRunBudget {
+		pagination.OffsetId = ""
+		if batch.CursorDecisionId != nil {
+			pagination.OffsetId = *batch.CursorDecisionId
+		}
+		decisions, err := w.repository.DecisionsOfOrganization(ctx, exec,
+			batch.OrganizationId, pagination, filters)
+		if err != nil {
+			return false, err
+		}
+
+		for _, decision := range decisions {
+			_, err := w.reevaluator.ReevaluateDecision(ctx, models.ReevaluateDecisionInput{
+				DecisionId:          decision.DecisionId,
+				ScenarioIterationId: batch.ScenarioIterationId,
+				BatchId:             &batch.Id,
+			})
+			if ctx.Err() != nil {
+				return false, ctx.Err()
+			}
+			if err != nil {
+				logger.WarnContext(ctx, "could not re-evaluate decision",
+					"batch_id", batch.Id, "decision_id", decision.DecisionId, "error", err)
+				batch.NbErrors++
+			}
+			batch.NbDecisions++
+			batch.CursorDecisionId = &decision.DecisionId
+		}
+
+		if len(decisions) < pagination.Limit {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (w *DecisionReevaluationBatchWorker) updateBatch(ctx context.Context, exec repositories.Executor,
+	batch models.DecisionReevaluationBatch,
+) error {
+	return w.repository.UpdateDecisionReevaluationBatch(ctx, exec, batch.Id, models.DecisionReevaluationBatchUpdate{
+		Status:           batch.Status,
+		NbDecisions:      batch.NbDecisions,
+		NbErrors:         batch.NbErrors,
+		CursorDecisionId: batch.CursorDecisionId,
+	})
+}
@@ -0,0 +1,190 @@
+package scheduled_execution
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+	"github.com/riverqueue/river"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/repositories"
+	"github.com/checkmarble/marble-backend/usecases/executor_factory"
+	"github.com/checkmarble/marble-backend/utils"
+)
+
+type decisionRequestRepository interface {
+	GetDecisionRequest(ctx context.Context, exec repositories.Executor, id string,
+		forUpdate bool) (models.DecisionRequest, error)
+	CompleteDecisionRequest(ctx context.Context, exec repositories.Executor, id string,
+		result models.DecisionRequestResult) (bool, error)
+	DecisionWithRuleExecutionsById(ctx context.Context, exec repositories.Executor,
+		decisionId string) (models.DecisionWithRuleExecutions, error)
+}
+
+type decisionRequestUsecase interface {
+	CreateDecision(
+		ctx context.Context,
+		input models.CreateDecisionInput,
+		params models.CreateDecisionParams,
+	) (bool, models.DecisionWithRuleExecutions, error)
+}
+
+// DecisionRequestWorker evaluates the scenario of an asynchronous decision request, and records its result. Two jobs
+// are enqueued for each request: one that evaluates it right away, and one scheduled at the deadline of the request
+// that records the fallback outcome if no decision was made by then.
+type DecisionRequestWorker struct {
+	river.WorkerDefaults[models.DecisionRequestArgs]
+
+	executorFactory     executor_factory.ExecutorFactory
+	transactionFactory  executor_factory.TransactionFactory
+	repository          decisionRequestRepository
+	decisionUsecase     decisionRequestUsecase
+	webhookEventsSender webhookEventsUsecase
+}
+
+func NewDecisionRequestWorker(
+	executorFactory executor_factory.ExecutorFactory,
+	transactionFactory executor_factory.TransactionFactory,
+	repository decisionRequestRepository,
+	decisionUsecase decisionRequestUsecase,
+	webhookEventsSender webhookEventsUsecase,
+) DecisionRequestWorker {
+	return DecisionRequestWorker{
+		executorFactory:     executorFactory,
+		transactionFactory:  transactionFactory,
+		repository:          repository,
+		decisionUsecase:     decisionUsecase,
+		webhookEventsSender: webhookEventsSender,
+	}
+}
+
+// The evaluation itself is bounded by the deadline of the request
+func (w *DecisionRequestWorker) Timeout(job *river.Job[models.DecisionRequestArgs]) time.Duration {
+	return models.MaxDecisionRequestDeadline
+}
+
+func (w *DecisionRequestWorker) Work(ctx context.Context, job *river.Job[models.DecisionRequestArgs]) error {
+	exec := w.executorFactory.NewExecutor()
+	request, err := w.repository.GetDecisionRequest(ctx, exec, job.Args.RequestId, false)
+	if err != nil {
+		return err
+	}
+	if request.Status != models.DecisionRequestPending {
+		return nil
+	}
+	if !time.Now().Before(request.Deadline) {
+		return w.complete(ctx, request, fallbackResult(request, "the deadline was reached before a decision was made"))
+	}
+
+	// A previous attempt may have stored the decision but failed to record the result
+	decision, err := w.repository.DecisionWithRuleExecutionsById(ctx, exec, request.Id)
+	if err == nil {
+		return w.complete(ctx, request, models.DecisionRequestResult{
+			Status:  models.DecisionRequestCompleted,
+			Outcome: &decision.Outcome,
+		})
+	} else if !errors.Is(err, models.NotFoundError) {
+		return err
+	}
+
+	evalCtx, cancel := context.WithDeadline(ctx, request.Deadline)
+	defer cancel()
+	triggerPassed, decision, err := w.decisionUsecase.CreateDecision(
+		evalCtx,
+		models.CreateDecisionInput{
+			OrganizationId:     request.OrganizationId,
+			PayloadRaw:         request.PayloadRaw,
+			ScenarioId:         request.ScenarioId,
+			TriggerObjectTable: request.TriggerObjectTable,
+			ReadAsOf:           request.ReadAsOf,
+			DecisionId:         request.Id,
+			DecisionRequestId:  request.Id,
+		},
+		models.CreateDecisionParams{
+			WithDecisionWebhooks:     true,
+			WithRuleExecutionDetails: true,
+		},
+	)
+
+	switch {
+	case errors.Is(err, models.ErrDecisionRequestCompleted):
+		return nil
+	case err == nil && !triggerPassed:
+		return w.complete(ctx, request, models.DecisionRequestResult{Status: models.DecisionRequestTriggerNotPassed})
+	case err == nil:
+		return w.complete(ctx, request, models.DecisionRequestResult{
+			Status:  models.DecisionRequestCompleted,
+			Outcome: &decision.Outcome,
+		})
+	case ctx.Err() == nil && errors.Is(evalCtx.Err(), context.DeadlineExceeded):
+		return w.complete(ctx, request, fallbackResult(request, "the deadline was reached before a decision was made"))
+	case errors.Is(err, models.BadParameterError) || errors.Is(err, models.NotFoundError) ||
+		job.Attempt >= job.MaxAttempts:
+		utils.LoggerFromContext(ctx).WarnContext(ctx, "could not make the decision of a decision request",
+			"request_id", request.Id, "error", err)
+		return w.complete(ctx, request, fallbackResult(request, err.Error()))
+	default:
+		return err
+	}
+}
+
+func fallbackResult(request models.DecisionRequest, reason string) models.DecisionRequestResult {
+	return models.DecisionRequestResult{
+		Status:  models.DecisionRequestFallback,
+		Outcome: &request.FallbackOutcome,
+		Error:   reason,
+	}
+}
+
+// complete records the result of the request and sends the webhook, unless a concurrent job already recorded a result.
+// The request is locked while its result is recorded: a fallback is replaced by the decision if the evaluation stored one
+// in the meantime, and the evaluation does not store a decision once the request has a result.
+func (w *DecisionRequestWorker) complete(ctx context.Context, request models.DecisionRequest,
+	result models.DecisionRequestResult,
+) error {
+	webhookEventId := uuid.NewString()
+	completed, err := executor_factory.TransactionReturnValue(ctx, w.transactionFactory, func(
+		tx repositories.Transaction,
+	) (bool, error) {
+		request, err := w.repository.GetDecisionRequest(ctx, tx, request.Id, true)
+		if err != nil || request.Status != models.DecisionRequestPending {
+			return false, err
+		}
+		if result.Status == models.DecisionRequestFallback {
+			decision, err := w.repository.DecisionWithRuleExecutionsById(ctx, tx, request.Id)
+			if err == nil {
+				result = models.DecisionRequestResult{
+					Status:  models.DecisionRequestCompleted,
+					Outcome: &decision.Outcome,
+				}
+			} else if !errors.Is(err, models.NotFoundError) {
+				return false, err
+			}
+		}
+
+		ok, err := w.repository.CompleteDecisionRequest(ctx, tx, request.Id, result)
+		if err != nil || !ok {
+			return false, err
+		}
+
+		request.Status = result.Status
+		request.Outcome = result.Outcome
+		request.Error = result.Error
+		err = w.webhookEventsSender.CreateWebhookEvent(ctx, tx, models.WebhookEventCreate{
+			Id:             webhookEventId,
+			OrganizationId: request.OrganizationId,
+			EventContent:   models.NewWebhookEventDecisionAsyncResult(request),
+		})
+		return err == nil, err
+	})
+	if err != nil {
+		return err
+	}
+
+	if completed {
+		w.webhookEventsSender.SendWebhookEventAsync(ctx, webhookEventId)
+	}
+	return nil
+}
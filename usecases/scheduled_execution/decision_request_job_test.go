@@ -0,0 +1,127 @@
+package scheduled_execution
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/riverqueue/river"
+	"github.com/riverqueue/river/rivertype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/repositories"
+	"github.com/checkmarble/marble-backend/usecases/executor_factory"
+)
+
+// fakeDecisionRequestRepository holds one request, and the decision stored for it if any
+type fakeDecisionRequestRepository struct {
+	request  models.DecisionRequest
+	decision *models.DecisionWithRuleExecutions
+}
+
+func (r *fakeDecisionRequestRepository) GetDecisionRequest(ctx context.Context, exec repositories.Executor,
+	id string, forUpdate bool,
+) (models.DecisionRequest, error) {
+	return r.request, nil
+}
+
+func (r *fakeDecisionRequestRepository) CompleteDecisionRequest(ctx context.Context, exec repositories.Executor,
+	id string, result models.DecisionRequestResult,
+) (bool, error) {
+	if r.request.Status != models.DecisionRequestPending {
+		return false, nil
+	}
+	r.request.Status = result.Status
+	r.request.Outcome = result.Outcome
+	r.request.Error = result.Error
+	return true, nil
+}
+
+func (r *fakeDecisionRequestRepository) DecisionWithRuleExecutionsById(ctx context.Context,
+	exec repositories.Executor, decisionId string,
+) (models.DecisionWithRuleExecutions, error) {
+	if r.decision == nil {
+		return models.DecisionWithRuleExecutions{}, models.NotFoundError
+	}
+	return *r.decision, nil
+}
+
+// fakeDecisionRequestUsecase runs a callback in place of the evaluation, to interleave the deadline job with it
+type fakeDecisionRequestUsecase struct {
+	createDecision func() (bool, models.DecisionWithRuleExecutions, error)
+}
+
+func (u fakeDecisionRequestUsecase) CreateDecision(ctx context.Context, input models.CreateDecisionInput,
+	params models.CreateDecisionParams,
+) (bool, models.DecisionWithRuleExecutions, error) {
+	return u.createDecision()
+}
+
+func newDecisionRequestTestWorker(repo *fakeDecisionRequestRepository, usecase fakeDecisionRequestUsecase) (
+	DecisionRequestWorker, *fakeSlaWebhookEventsSender,
+) {
+	webhooks := &fakeSlaWebhookEventsSender{}
+	exec := executor_factory.NewExecutorFactoryStub()
+	return NewDecisionRequestWorker(exec, executor_factory.NewTransactionFactoryStub(exec),
+		repo, usecase, webhooks), webhooks
+}
+
+func TestDecisionRequestWorker_deadline_after_the_decision_was_stored(t *testing.T) {
+	decision := models.DecisionWithRuleExecutions{Decision: models.Decision{
+		DecisionId: "request_id",
+		Outcome:    models.Approve,
+	}}
+	repo := &fakeDecisionRequestRepository{
+		request: models.DecisionRequest{
+			Id:              "request_id",
+			OrganizationId:  "org",
+			Status:          models.DecisionRequestPending,
+			FallbackOutcome: models.Decline,
+			Deadline:        time.Now().Add(-time.Minute),
+		},
+		// the evaluation stored the decision but did not record the result yet
+		decision: &decision,
+	}
+	worker, webhooks := newDecisionRequestTestWorker(repo, fakeDecisionRequestUsecase{})
+	job := &river.Job[models.DecisionRequestArgs]{
+		JobRow: &rivertype.JobRow{},
+		Args:   models.DecisionRequestArgs{OrgId: "org", RequestId: "request_id"},
+	}
+
+	require.NoError(t, worker.Work(context.Background(), job))
+	assert.Equal(t, models.DecisionRequestCompleted, repo.request.Status,
+		"the decision is recorded instead of the fallback")
+	assert.Equal(t, models.Approve, *repo.request.Outcome)
+	assert.Len(t, webhooks.created, 1)
+}
+
+func TestDecisionRequestWorker_evaluation_after_the_fallback(t *testing.T) {
+	repo := &fakeDecisionRequestRepository{
+		request: models.DecisionRequest{
+			Id:              "request_id",
+			OrganizationId:  "org",
+			Status:          models.DecisionRequestPending,
+			FallbackOutcome: models.Decline,
+			Deadline:        time.Now().Add(time.Minute),
+		},
+	}
+	worker, webhooks := newDecisionRequestTestWorker(repo, fakeDecisionRequestUsecase{
+		createDecision: func() (bool, models.DecisionWithRuleExecutions, error) {
+			// the deadline job records the fallback while the scenario is evaluated, the decision is not stored
+			repo.request.Status = models.DecisionRequestFallback
+			repo.request.Outcome = &repo.request.FallbackOutcome
+			return false, models.DecisionWithRuleExecutions{}, models.ErrDecisionRequestCompleted
+		},
+	})
+	job := &river.Job[models.DecisionRequestArgs]{
+		JobRow: &rivertype.JobRow{},
+		Args:   models.DecisionRequestArgs{OrgId: "org", RequestId: "request_id"},
+	}
+
+	require.NoError(t, worker.Work(context.Background(), job))
+	assert.Equal(t, models.DecisionRequestFallback, repo.request.Status)
+	assert.Equal(t, models.Decline, *repo.request.Outcome)
+	assert.Empty(t, webhooks.created)
+}
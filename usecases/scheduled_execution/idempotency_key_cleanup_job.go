@@ -0,0 +1,69 @@
+package scheduled_execution
+
+import (
+	"context"
+	"time"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/repositories"
+	"github.com/checkmarble/marble-backend/usecases/executor_factory"
+	"github.com/checkmarble/marble-backend/utils"
+	"github.com/riverqueue/river"
+)
+
+const IDEMPOTENCY_KEY_CLEANUP_WORKER_INTERVAL = time.Hour
+
+type idempotencyKeyCleanupRepository interface {
+	DeleteExpiredDecisionIdempotencyKeys(ctx context.Context, exec repositories.Executor, orgId string) (int, error)
+}
+
+func NewIdempotencyKeyCleanupPeriodicJob(orgId string) *river.PeriodicJob {
+	return river.NewPeriodicJob(
+		river.PeriodicInterval(IDEMPOTENCY_KEY_CLEANUP_WORKER_INTERVAL),
+		func() (river.JobArgs, *river.InsertOpts) {
+			return models.IdempotencyKeyCleanupArgs{
+				OrgId: orgId,
+			}, &river.InsertOpts{
+				Queue: orgId,
+				UniqueOpts: river.UniqueOpts{
+					ByQueue:  true,
+					ByPeriod: IDEMPOTENCY_KEY_CLEANUP_WORKER_INTERVAL,
+				},
+			}
+		},
+		&river.PeriodicJobOpts{RunOnStart: true},
+	)
+}
+
+// IdempotencyKeyCleanupWorker deletes the decision idempotency keys that are past their idempotency window
+type IdempotencyKeyCleanupWorker struct {
+	river.WorkerDefaults[models.IdempotencyKeyCleanupArgs]
+
+	executorFactory executor_factory.ExecutorFactory
+	repository      idempotencyKeyCleanupRepository
+}
+
+func NewIdempotencyKeyCleanupWorker(
+	executorFactory executor_factory.ExecutorFactory,
+	repository idempotencyKeyCleanupRepository,
+) IdempotencyKeyCleanupWorker {
+	return IdempotencyKeyCleanupWorker{
+		executorFactory: executorFactory,
+		repository:      repository,
+	}
+}
+
+func (w *IdempotencyKeyCleanupWorker) Work(ctx context.Context, job *river.Job[models.IdempotencyKeyCleanupArgs]) error {
+	nbDeleted, err := w.repository.DeleteExpiredDecisionIdempotencyKeys(ctx,
+		w.executorFactory.NewExecutor(), job.Args.OrgId)
+	if err != nil {
+		return err
+	}
+
+	if nbDeleted > 0 {
+		utils.LoggerFromContext(ctx).InfoContext(ctx, "deleted expired idempotency keys",
+			"org_id", job.Args.OrgId,
+			"nb_deleted", nbDeleted)
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package scheduled_execution
+
+import (
+	"context"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/riverqueue/river"
+)
+
+type ingestedObjectsDecisionUsecase interface {
+	CreateAllDecisionsForIngestedObjects(
+		ctx context.Context,
+		organizationId string,
+		objectType string,
+		objectIds []string,
+	) (models.IngestionDecisions, error)
+}
+
+// IngestedObjectsDecisionWorker creates decisions on ingested objects, for tables configured to create decisions
+// asynchronously on ingestion.
+type IngestedObjectsDecisionWorker struct {
+	river.WorkerDefaults[models.IngestedObjectsDecisionArgs]
+
+	decisionUsecase ingestedObjectsDecisionUsecase
+}
+
+func NewIngestedObjectsDecisionWorker(decisionUsecase ingestedObjectsDecisionUsecase) IngestedObjectsDecisionWorker {
+	return IngestedObjectsDecisionWorker{
+		decisionUsecase: decisionUsecase,
+	}
+}
+
+func (w *IngestedObjectsDecisionWorker) Work(ctx context.Context, job *river.Job[models.IngestedObjectsDecisionArgs]) error {
+	decisions, err := w.decisionUsecase.CreateAllDecisionsForIngestedObjects(
+		ctx,
+		job.Args.OrgId,
+		job.Args.ObjectType,
+		[]string{job.Args.ObjectId},
+	)
+	if err != nil {
+		return err
+	}
+	// the decisions of an object are stored in a single transaction, so the job can be retried as a whole
+	if len(decisions.Errors) > 0 {
+		return decisions.Errors[0].Err
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package scheduled_execution
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/riverqueue/river"
+	"github.com/riverqueue/river/rivertype"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/checkmarble/marble-backend/models"
+)
+
+type fakeIngestedObjectsDecisionUsecase struct {
+	objectIds []string
+	errors    []models.IngestionDecisionError
+}
+
+func (u *fakeIngestedObjectsDecisionUsecase) CreateAllDecisionsForIngestedObjects(ctx context.Context,
+	organizationId string, objectType string, objectIds []string,
+) (models.IngestionDecisions, error) {
+	u.objectIds = append(u.objectIds, objectIds...)
+	return models.IngestionDecisions{Errors: u.errors}, nil
+}
+
+func TestIngestedObjectsDecisionWorker(t *testing.T) {
+	job := &river.Job[models.IngestedObjectsDecisionArgs]{
+		JobRow: &rivertype.JobRow{},
+		Args:   models.IngestedObjectsDecisionArgs{OrgId: "org", ObjectType: "transactions", ObjectId: "1"},
+	}
+
+	t.Run("creates the decisions of the object of the job", func(t *testing.T) {
+		usecase := &fakeIngestedObjectsDecisionUsecase{}
+		worker := NewIngestedObjectsDecisionWorker(usecase)
+
+		assert.NoError(t, worker.Work(context.Background(), job))
+		assert.Equal(t, []string{"1"}, usecase.objectIds)
+	})
+
+	t.Run("fails so that the job is retried", func(t *testing.T) {
+		err := errors.New("scenario evaluation failed")
+		usecase := &fakeIngestedObjectsDecisionUsecase{
+			errors: []models.IngestionDecisionError{{ObjectId: "1", Err: err}},
+		}
+		worker := NewIngestedObjectsDecisionWorker(usecase)
+
+		assert.ErrorIs(t, worker.Work(context.Background(), job), err)
+	})
+}
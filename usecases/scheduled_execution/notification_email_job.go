@@ -0,0 +1,74 @@
+package scheduled_execution
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/riverqueue/river"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/repositories"
+	"github.com/checkmarble/marble-backend/usecases/executor_factory"
+)
+
+const NOTIFICATION_EMAIL_WORKER_TIMEOUT = time.Minute
+
+// NotificationEmailWorker sends a notification to a user by email. The email links to the case if the address of the
+// Marble app is configured.
+type NotificationEmailWorker struct {
+	river.WorkerDefaults[models.NotificationEmailArgs]
+
+	executorFactory executor_factory.ExecutorFactory
+	userRepository  notificationUserRepository
+	emailSender     repositories.EmailSender
+	marbleAppUrl    string
+}
+
+func NewNotificationEmailWorker(
+	executorFactory executor_factory.ExecutorFactory,
+	userRepository notificationUserRepository,
+	emailSender repositories.EmailSender,
+	marbleAppUrl string,
+) NotificationEmailWorker {
+	return NotificationEmailWorker{
+		executorFactory: executorFactory,
+		userRepository:  userRepository,
+		emailSender:     emailSender,
+		marbleAppUrl:    strings.TrimSuffix(marbleAppUrl, "/"),
+	}
+}
+
+func (w *NotificationEmailWorker) Timeout(job *river.Job[models.NotificationEmailArgs]) time.Duration {
+	return NOTIFICATION_EMAIL_WORKER_TIMEOUT
+}
+
+func (w *NotificationEmailWorker) Work(ctx context.Context, job *river.Job[models.NotificationEmailArgs]) error {
+	user, err := w.userRepository.UserById(ctx, w.executorFactory.NewExecutor(), job.Args.UserId)
+	if errors.Is(err, models.NotFoundError) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if user.DeletedAt != nil {
+		return nil
+	}
+
+	return w.emailSender.Send(ctx, notificationEmail(user.Email, job.Args, w.marbleAppUrl))
+}
+
+func notificationEmail(to string, args models.NotificationEmailArgs, marbleAppUrl string) models.Email {
+	body := args.Message + "\n"
+	if marbleAppUrl != "" && args.CaseId != "" {
+		body += fmt.Sprintf("\nOpen the case: %s/cases/%s\n", marbleAppUrl, args.CaseId)
+	}
+
+	return models.Email{
+		To:      to,
+		Subject: fmt.Sprintf("[Marble] %s", args.Message),
+		Body:    body,
+	}
+}
@@ -0,0 +1,286 @@
+package scheduled_execution
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/riverqueue/river"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/repositories"
+	"github.com/checkmarble/marble-backend/usecases/executor_factory"
+	"github.com/checkmarble/marble-backend/utils"
+)
+
+const (
+	NOTIFICATION_WORKER_INTERVAL = time.Minute
+	notificationEventBatchSize   = 200
+)
+
+type notificationRepository interface {
+	GetNotificationCursor(ctx context.Context, exec repositories.Executor, orgId string) (*models.NotificationCursor, error)
+	InitNotificationCursor(ctx context.Context, exec repositories.Executor, orgId string, snoozesCheckedAt time.Time) error
+	SaveNotificationCursor(ctx context.Context, exec repositories.Executor, orgId string,
+		cursor models.NotificationCursor) error
+	ListNotifiableCaseEvents(ctx context.Context, exec repositories.Executor, orgId string,
+		after models.NotificationCursor, limit int) ([]models.NotifiableCaseEvent, error)
+	ListCasesWithExpiredSnooze(ctx context.Context, exec repositories.Executor, orgId string,
+		after, until time.Time) ([]models.Case, error)
+	GetCaseById(ctx context.Context, exec repositories.Executor, caseId string) (models.Case, error)
+	ListInboxUsers(ctx context.Context, exec repositories.Executor,
+		filters models.InboxUserFilterInput) ([]models.InboxUser, error)
+	ListNotificationPreferences(ctx context.Context, exec repositories.Executor,
+		userId string) ([]models.NotificationPreference, error)
+	CreateNotification(ctx context.Context, exec repositories.Executor,
+		input models.NotificationCreate) (models.Notification, error)
+}
+
+type notificationUserRepository interface {
+	UserById(ctx context.Context, exec repositories.Executor, userId string) (models.User, error)
+}
+
+type notificationTaskQueue interface {
+	EnqueueNotificationEmailTask(ctx context.Context, tx repositories.Transaction,
+		organizationId string, userId string, caseId string, message string) error
+}
+
+func NewNotificationPeriodicJob(orgId string) *river.PeriodicJob {
+	return river.NewPeriodicJob(
+		river.PeriodicInterval(NOTIFICATION_WORKER_INTERVAL),
+		func() (river.JobArgs, *river.InsertOpts) {
+			return models.NotificationArgs{
+				OrgId: orgId,
+			}, &river.InsertOpts{
+				Queue: orgId,
+				UniqueOpts: river.UniqueOpts{
+					ByQueue:  true,
+					ByPeriod: NOTIFICATION_WORKER_INTERVAL,
+				},
+			}
+		},
+		&river.PeriodicJobOpts{RunOnStart: true},
+	)
+}
+
+// NotificationWorker notifies the users of an organization of the case events that concern them (assignments,
+// escalations, mentions and SLA breaches) and of the expired snoozes of their cases. The notifications are created in
+// the inbox of the users and sent by email, depending on their preferences. Each case event is handled in a transaction
+// that also moves the cursor of the organization, so that an event is notified exactly once.
+type NotificationWorker struct {
+	river.WorkerDefaults[models.NotificationArgs]
+
+	executorFactory    executor_factory.ExecutorFactory
+	transactionFactory executor_factory.TransactionFactory
+	repository         notificationRepository
+	userRepository     notificationUserRepository
+	taskQueue          notificationTaskQueue
+	emailEnabled       bool
+}
+
+func NewNotificationWorker(
+	executorFactory executor_factory.ExecutorFactory,
+	transactionFactory executor_factory.TransactionFactory,
+	repository notificationRepository,
+	userRepository notificationUserRepository,
+	taskQueue notificationTaskQueue,
+	emailEnabled bool,
+) NotificationWorker {
+	return NotificationWorker{
+		executorFactory:    executorFactory,
+		transactionFactory: transactionFactory,
+		repository:         repository,
+		userRepository:     userRepository,
+		taskQueue:          taskQueue,
+		emailEnabled:       emailEnabled,
+	}
+}
+
+func (w *NotificationWorker) Timeout(job *river.Job[models.NotificationArgs]) time.Duration {
+	return NOTIFICATION_WORKER_INTERVAL
+}
+
+func (w *NotificationWorker) Work(ctx context.Context, job *river.Job[models.NotificationArgs]) error {
+	exec := w.executorFactory.NewExecutor()
+	orgId := job.Args.OrgId
+	until := time.Now()
+
+	saved, err := w.repository.GetNotificationCursor(ctx, exec, orgId)
+	if err != nil {
+		return err
+	}
+	if saved == nil {
+		// the notifications of an organization start with the first run of the job, past events are not notified
+		return w.repository.InitNotificationCursor(ctx, exec, orgId, until)
+	}
+	cursor := *saved
+
+	for {
+		events, err := w.repository.ListNotifiableCaseEvents(ctx, exec, orgId, cursor, notificationEventBatchSize)
+		if err != nil {
+			return err
+		}
+		for _, event := range events {
+			cursor.EventTxId = event.TxId
+			cursor.EventId = &event.Id
+			err := w.transactionFactory.Transaction(ctx, func(tx repositories.Transaction) error {
+				if err := w.notifyCaseEvent(ctx, tx, orgId, event.CaseEvent); err != nil {
+					return err
+				}
+				return w.repository.SaveNotificationCursor(ctx, tx, orgId, cursor)
+			})
+			if err != nil {
+				return err
+			}
+		}
+		if len(events) < notificationEventBatchSize {
+			break
+		}
+	}
+
+	cases, err := w.repository.ListCasesWithExpiredSnooze(ctx, exec, orgId, cursor.SnoozesCheckedAt, until)
+	if err != nil {
+		return err
+	}
+	cursor.SnoozesCheckedAt = until
+	return w.transactionFactory.Transaction(ctx, func(tx repositories.Transaction) error {
+		for _, c := range cases {
+			recipients, err := w.caseOwners(ctx, tx, c)
+			if err != nil {
+				return err
+			}
+			if err := w.notify(ctx, tx, c, models.NotificationSnoozeExpired, recipients, nil, nil); err != nil {
+				return err
+			}
+		}
+		return w.repository.SaveNotificationCursor(ctx, tx, orgId, cursor)
+	})
+}
+
+func (w *NotificationWorker) notifyCaseEvent(ctx context.Context, tx repositories.Transaction,
+	orgId string, event models.CaseEvent,
+) error {
+	notificationType, ok := models.NotifiableCaseEventTypes[event.EventType]
+	if !ok {
+		return nil
+	}
+	c, err := w.repository.GetCaseById(ctx, tx, event.CaseId)
+	if err != nil {
+		return err
+	}
+	if c.OrganizationId != orgId {
+		return nil
+	}
+
+	var recipients []string
+	switch event.EventType {
+	case models.CaseAssigned, models.CaseCommentMention, models.CaseQaReviewRequested:
+		// the new value is the assigned, mentioned or QA reviewing user, empty when a case is unassigned
+		if event.NewValue != "" {
+			recipients = []string{event.NewValue}
+		}
+	case models.CaseEscalated:
+		// the new value is the inbox the case was escalated to
+		recipients, err = w.inboxAdmins(ctx, tx, event.NewValue)
+	case models.CaseSlaBreached:
+		recipients, err = w.caseOwners(ctx, tx, c)
+	}
+	if err != nil {
+		return err
+	}
+
+	var actorId *string
+	if event.UserId.Valid {
+		actorId = &event.UserId.String
+	}
+	return w.notify(ctx, tx, c, notificationType, recipients, &event.Id, actorId)
+}
+
+// caseOwners are the users in charge of a case: its assignee, or the admins of its inbox if it is not assigned
+func (w *NotificationWorker) caseOwners(ctx context.Context, exec repositories.Executor, c models.Case) ([]string, error) {
+	if c.AssignedTo != nil {
+		return []string{string(*c.AssignedTo)}, nil
+	}
+	return w.inboxAdmins(ctx, exec, c.InboxId)
+}
+
+func (w *NotificationWorker) inboxAdmins(ctx context.Context, exec repositories.Executor, inboxId string) ([]string, error) {
+	if inboxId == "" {
+		return nil, nil
+	}
+	inboxUsers, err := w.repository.ListInboxUsers(ctx, exec, models.InboxUserFilterInput{InboxId: inboxId})
+	if err != nil {
+		return nil, err
+	}
+	admins := make([]string, 0, len(inboxUsers))
+	for _, inboxUser := range inboxUsers {
+		if inboxUser.Role == models.InboxUserRoleAdmin {
+			admins = append(admins, inboxUser.UserId)
+		}
+	}
+	return admins, nil
+}
+
+// notify creates the in-app notifications and enqueues the emails of the recipients, depending on their preferences.
+// Users are not notified of their own actions, and deleted users are not notified.
+func (w *NotificationWorker) notify(ctx context.Context, tx repositories.Transaction, c models.Case,
+	notificationType models.NotificationType, recipients []string, eventId, actorId *string,
+) error {
+	message := models.NotificationMessage(notificationType, c.Name)
+
+	for _, userId := range recipients {
+		if actorId != nil && *actorId == userId {
+			continue
+		}
+		user, err := w.userRepository.UserById(ctx, tx, userId)
+		if errors.Is(err, models.NotFoundError) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if user.DeletedAt != nil || user.OrganizationId != c.OrganizationId {
+			continue
+		}
+
+		saved, err := w.repository.ListNotificationPreferences(ctx, tx, userId)
+		if err != nil {
+			return err
+		}
+		preference := models.DefaultNotificationPreference(notificationType)
+		for _, p := range saved {
+			if p.Type == notificationType {
+				preference = p
+			}
+		}
+
+		if preference.InApp {
+			_, err := w.repository.CreateNotification(ctx, tx, models.NotificationCreate{
+				OrganizationId: c.OrganizationId,
+				UserId:         userId,
+				Type:           notificationType,
+				CaseId:         &c.Id,
+				CaseEventId:    eventId,
+				ActorId:        actorId,
+				Message:        message,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		if preference.Email && w.emailEnabled {
+			if err := w.taskQueue.EnqueueNotificationEmailTask(ctx, tx,
+				c.OrganizationId, userId, c.Id, message); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(recipients) > 0 {
+		utils.LoggerFromContext(ctx).DebugContext(ctx, "notified case users",
+			"case_id", c.Id,
+			"type", notificationType,
+			"nb_recipients", len(recipients))
+	}
+	return nil
+}
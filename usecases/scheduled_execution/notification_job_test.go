@@ -0,0 +1,279 @@
+package scheduled_execution
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/guregu/null/v5"
+	"github.com/riverqueue/river"
+	"github.com/riverqueue/river/rivertype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/checkmarble/marble-backend/mocks"
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/repositories"
+	"github.com/checkmarble/marble-backend/usecases/executor_factory"
+	"github.com/checkmarble/marble-backend/utils"
+)
+
+type fakeNotificationRepository struct {
+	notificationRepository
+
+	cases         map[string]models.Case
+	inboxUsers    []models.InboxUser
+	preferences   map[string][]models.NotificationPreference
+	notifications []models.NotificationCreate
+
+	cursor *models.NotificationCursor
+	events []models.NotifiableCaseEvent
+	// xmin is the id of the oldest transaction still in progress, whose events are not visible to the job yet
+	xmin int64
+}
+
+func (r *fakeNotificationRepository) GetNotificationCursor(ctx context.Context, exec repositories.Executor,
+	orgId string,
+) (*models.NotificationCursor, error) {
+	return r.cursor, nil
+}
+
+func (r *fakeNotificationRepository) InitNotificationCursor(ctx context.Context, exec repositories.Executor,
+	orgId string, snoozesCheckedAt time.Time,
+) error {
+	r.cursor = &models.NotificationCursor{EventTxId: r.xmin, SnoozesCheckedAt: snoozesCheckedAt}
+	return nil
+}
+
+func (r *fakeNotificationRepository) SaveNotificationCursor(ctx context.Context, exec repositories.Executor,
+	orgId string, cursor models.NotificationCursor,
+) error {
+	r.cursor = &cursor
+	return nil
+}
+
+func (r *fakeNotificationRepository) ListNotifiableCaseEvents(ctx context.Context, exec repositories.Executor,
+	orgId string, after models.NotificationCursor, limit int,
+) ([]models.NotifiableCaseEvent, error) {
+	events := make([]models.NotifiableCaseEvent, 0)
+	for _, event := range r.events {
+		if event.TxId >= r.xmin {
+			continue
+		}
+		if event.TxId < after.EventTxId ||
+			(event.TxId == after.EventTxId && after.EventId != nil && event.Id <= *after.EventId) {
+			continue
+		}
+		events = append(events, event)
+	}
+	slices.SortFunc(events, func(a, b models.NotifiableCaseEvent) int {
+		if a.TxId != b.TxId {
+			return cmp.Compare(a.TxId, b.TxId)
+		}
+		return cmp.Compare(a.Id, b.Id)
+	})
+	if len(events) > limit {
+		events = events[:limit]
+	}
+	return events, nil
+}
+
+func (r *fakeNotificationRepository) ListCasesWithExpiredSnooze(ctx context.Context, exec repositories.Executor,
+	orgId string, after, until time.Time,
+) ([]models.Case, error) {
+	return nil, nil
+}
+
+func (r *fakeNotificationRepository) GetCaseById(ctx context.Context, exec repositories.Executor,
+	caseId string,
+) (models.Case, error) {
+	return r.cases[caseId], nil
+}
+
+func (r *fakeNotificationRepository) ListInboxUsers(ctx context.Context, exec repositories.Executor,
+	filters models.InboxUserFilterInput,
+) ([]models.InboxUser, error) {
+	inboxUsers := make([]models.InboxUser, 0)
+	for _, u := range r.inboxUsers {
+		if u.InboxId == filters.InboxId {
+			inboxUsers = append(inboxUsers, u)
+		}
+	}
+	return inboxUsers, nil
+}
+
+func (r *fakeNotificationRepository) ListNotificationPreferences(ctx context.Context, exec repositories.Executor,
+	userId string,
+) ([]models.NotificationPreference, error) {
+	return r.preferences[userId], nil
+}
+
+func (r *fakeNotificationRepository) CreateNotification(ctx context.Context, exec repositories.Executor,
+	input models.NotificationCreate,
+) (models.Notification, error) {
+	r.notifications = append(r.notifications, input)
+	return models.Notification{}, nil
+}
+
+type notificationWorkerTest struct {
+	worker     NotificationWorker
+	repository *fakeNotificationRepository
+	taskQueue  *mocks.TaskQueueRepository
+	tx         *mocks.Transaction
+}
+
+func newNotificationWorkerTest() notificationWorkerTest {
+	repository := &fakeNotificationRepository{
+		cases: map[string]models.Case{
+			"assigned": {
+				Id: "assigned", OrganizationId: "org", InboxId: "inbox", Name: "Assigned case",
+				AssignedTo: utils.Ptr(models.UserId("analyst")),
+			},
+			"unassigned": {Id: "unassigned", OrganizationId: "org", InboxId: "inbox", Name: "Unassigned case"},
+		},
+		inboxUsers: []models.InboxUser{
+			{InboxId: "inbox", UserId: "admin", Role: models.InboxUserRoleAdmin},
+			{InboxId: "inbox", UserId: "analyst", Role: models.InboxUserRoleMember},
+		},
+		preferences: map[string][]models.NotificationPreference{
+			"admin": {{Type: models.NotificationSlaBreached, InApp: true, Email: false}},
+		},
+	}
+
+	userRepository := new(mocks.UserRepository)
+	for _, userId := range []string{"admin", "analyst", "lead"} {
+		userRepository.On("UserById", mock.Anything, mock.Anything, userId).
+			Return(models.User{UserId: models.UserId(userId), OrganizationId: "org"}, nil)
+	}
+
+	taskQueue := new(mocks.TaskQueueRepository)
+
+	return notificationWorkerTest{
+		worker:     NewNotificationWorker(nil, nil, repository, userRepository, taskQueue, true),
+		repository: repository,
+		taskQueue:  taskQueue,
+		tx:         new(mocks.Transaction),
+	}
+}
+
+func TestNotifyCaseAssignment(t *testing.T) {
+	test := newNotificationWorkerTest()
+	test.taskQueue.On("EnqueueNotificationEmailTask", mock.Anything, test.tx, "org", "analyst", "assigned",
+		`You were assigned the case "Assigned case"`).Return(nil)
+
+	err := test.worker.notifyCaseEvent(context.TODO(), test.tx, "org", models.CaseEvent{
+		Id:        "event",
+		CaseId:    "assigned",
+		UserId:    null.StringFrom("lead"),
+		EventType: models.CaseAssigned,
+		NewValue:  "analyst",
+		CreatedAt: time.Now(),
+	})
+
+	assert.NoError(t, err)
+	if assert.Len(t, test.repository.notifications, 1) {
+		notification := test.repository.notifications[0]
+		assert.Equal(t, "analyst", notification.UserId)
+		assert.Equal(t, models.NotificationCaseAssigned, notification.Type)
+		assert.Equal(t, utils.Ptr("event"), notification.CaseEventId)
+		assert.Equal(t, utils.Ptr("lead"), notification.ActorId)
+	}
+	test.taskQueue.AssertExpectations(t)
+}
+
+func TestNoNotificationOfOwnAction(t *testing.T) {
+	test := newNotificationWorkerTest()
+
+	err := test.worker.notifyCaseEvent(context.TODO(), test.tx, "org", models.CaseEvent{
+		Id:        "event",
+		CaseId:    "assigned",
+		UserId:    null.StringFrom("analyst"),
+		EventType: models.CaseAssigned,
+		NewValue:  "analyst",
+	})
+
+	assert.NoError(t, err)
+	assert.Empty(t, test.repository.notifications)
+	test.taskQueue.AssertNotCalled(t, "EnqueueNotificationEmailTask")
+}
+
+func TestNotifySlaBreachOfUnassignedCase(t *testing.T) {
+	test := newNotificationWorkerTest()
+
+	err := test.worker.notifyCaseEvent(context.TODO(), test.tx, "org", models.CaseEvent{
+		Id:        "event",
+		CaseId:    "unassigned",
+		EventType: models.CaseSlaBreached,
+		NewValue:  string(models.SlaBreachClose),
+	})
+
+	assert.NoError(t, err)
+	// the breach of an unassigned case goes to the admins of its inbox, and the admin disabled the emails
+	if assert.Len(t, test.repository.notifications, 1) {
+		assert.Equal(t, "admin", test.repository.notifications[0].UserId)
+		assert.Nil(t, test.repository.notifications[0].ActorId)
+	}
+	test.taskQueue.AssertNotCalled(t, "EnqueueNotificationEmailTask")
+}
+
+func TestNotificationWorker_notifies_events_committed_late(t *testing.T) {
+	test := newNotificationWorkerTest()
+	test.taskQueue.On("EnqueueNotificationEmailTask", mock.Anything, mock.Anything, "org", "analyst",
+		mock.Anything, mock.Anything).Return(nil)
+	exec := executor_factory.NewExecutorFactoryStub()
+	worker := NewNotificationWorker(exec, executor_factory.NewTransactionFactoryStub(exec),
+		test.repository, test.worker.userRepository, test.taskQueue, true)
+	job := &river.Job[models.NotificationArgs]{JobRow: &rivertype.JobRow{}, Args: models.NotificationArgs{OrgId: "org"}}
+	mention := func(id string, txId int64, createdAt time.Time) models.NotifiableCaseEvent {
+		return models.NotifiableCaseEvent{
+			CaseEvent: models.CaseEvent{
+				Id:        id,
+				CaseId:    "assigned",
+				UserId:    null.StringFrom("lead"),
+				EventType: models.CaseCommentMention,
+				NewValue:  "analyst",
+				CreatedAt: createdAt,
+			},
+			TxId: txId,
+		}
+	}
+	now := time.Now()
+
+	// the first run starts the cursor after the committed transactions
+	test.repository.events = []models.NotifiableCaseEvent{mention("past", 9, now.Add(-time.Hour))}
+	test.repository.xmin = 10
+	require.NoError(t, worker.Work(context.Background(), job))
+	assert.Empty(t, test.repository.notifications)
+
+	// the transaction 11 commits while the transaction 10, which wrote an older event, is still in progress
+	test.repository.events = append(test.repository.events,
+		mention("slow", 10, now.Add(-time.Minute)),
+		mention("fast", 11, now))
+	test.repository.xmin = 10
+	require.NoError(t, worker.Work(context.Background(), job))
+	assert.Empty(t, test.repository.notifications)
+
+	test.repository.xmin = 12
+	require.NoError(t, worker.Work(context.Background(), job))
+	require.Len(t, test.repository.notifications, 2)
+	assert.Equal(t, utils.Ptr("slow"), test.repository.notifications[0].CaseEventId)
+	assert.Equal(t, utils.Ptr("fast"), test.repository.notifications[1].CaseEventId)
+	assert.Equal(t, int64(11), test.repository.cursor.EventTxId)
+
+	require.NoError(t, worker.Work(context.Background(), job))
+	assert.Len(t, test.repository.notifications, 2, "the events are notified once")
+}
+
+func TestNotificationEmail(t *testing.T) {
+	email := notificationEmail("analyst@marble.test", models.NotificationEmailArgs{
+		CaseId:  "case",
+		Message: `You were assigned the case "Case"`,
+	}, "https://app.marble.test")
+
+	assert.Equal(t, "analyst@marble.test", email.To)
+	assert.Equal(t, `[Marble] You were assigned the case "Case"`, email.Subject)
+	assert.Contains(t, email.Body, "https://app.marble.test/cases/case")
+}
@@ -0,0 +1,333 @@
+package scheduled_execution
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/checkmarble/marble-backend/infra"
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/pure_utils"
+	"github.com/checkmarble/marble-backend/repositories"
+	"github.com/checkmarble/marble-backend/usecases/executor_factory"
+	"github.com/checkmarble/marble-backend/utils"
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+	"github.com/riverqueue/river"
+)
+
+const retentionWatermarkPrefix = "retention/"
+
+type retentionRepository interface {
+	ListRetentionPolicies(ctx context.Context, exec repositories.Executor, orgId string) ([]models.RetentionPolicy, error)
+	ListObjectIdsOnLegalHold(ctx context.Context, exec repositories.Executor, orgId, tableName string) ([]string, error)
+	ListRetainableDecisions(ctx context.Context, exec repositories.Executor, orgId string, cutoff time.Time,
+		watermark *models.OffloadingWatermark, limit int) ([]models.RetainedRow, error)
+	DeleteDecisions(ctx context.Context, exec repositories.Executor, ids []string) error
+	GetRetentionArchiveKey(orgId, tableName, batchId string, createdAt time.Time) string
+	ListDecisionRuleRefs(ctx context.Context, exec repositories.Executor,
+		decisionIds []string) ([]models.DecisionRuleRef, error)
+	GetOffloadedDecisionRuleKey(orgId, decisionId, ruleId, outcome string, createdAt time.Time) string
+
+	GetOffloadingWatermark(ctx context.Context, exec repositories.Executor, orgId, table string) (*models.OffloadingWatermark, error)
+	SaveOffloadingWatermark(ctx context.Context, tx repositories.Transaction,
+		orgId, table, watermarkId string, watermarkTime time.Time) error
+}
+
+type retentionClientDbRepository interface {
+	ListRetainableIngestedRows(ctx context.Context, exec repositories.Executor, tableName string, cutoff time.Time,
+		keepLatestVersion bool, objectIdsOnHold []string, watermark *models.OffloadingWatermark,
+		limit int) ([]models.RetainedRow, error)
+	DeleteIngestedRows(ctx context.Context, exec repositories.Executor, tableName string, ids []string) error
+}
+
+func NewRetentionPeriodicJob(orgId string, interval time.Duration) *river.PeriodicJob {
+	return river.NewPeriodicJob(
+		river.PeriodicInterval(interval),
+		func() (river.JobArgs, *river.InsertOpts) {
+			return models.RetentionArgs{
+				OrgId: orgId,
+			}, &river.InsertOpts{
+				Queue: orgId,
+				UniqueOpts: river.UniqueOpts{
+					ByQueue:  true,
+					ByPeriod: interval,
+				},
+			}
+		},
+		&river.PeriodicJobOpts{RunOnStart: true},
+	)
+}
+
+// RetentionWorker applies the retention policies of an organization. Rows are processed in batches ordered by creation
+// time, with a watermark per table to resume where the previous job stopped. Once all the rows older than the cutoff have
+// been processed, the watermark is reset so that rows released from legal hold are picked up on the next run.
+type RetentionWorker struct {
+	river.WorkerDefaults[models.RetentionArgs]
+
+	executorFactory    executor_factory.ExecutorFactory
+	transactionFactory executor_factory.TransactionFactory
+	repository         retentionRepository
+	clientDbRepository retentionClientDbRepository
+	blobRepository     repositories.BlobRepository
+
+	offloadingBucketUrl string
+	config              infra.RetentionConfig
+}
+
+func NewRetentionWorker(executorFactory executor_factory.ExecutorFactory, transactionFactory executor_factory.TransactionFactory,
+	repository retentionRepository, clientDbRepository retentionClientDbRepository,
+	blobRepository repositories.BlobRepository, offloadingBucketUrl string, config infra.RetentionConfig,
+) *RetentionWorker {
+	return &RetentionWorker{
+		executorFactory:     executorFactory,
+		transactionFactory:  transactionFactory,
+		repository:          repository,
+		clientDbRepository:  clientDbRepository,
+		blobRepository:      blobRepository,
+		offloadingBucketUrl: offloadingBucketUrl,
+		config:              config,
+	}
+}
+
+func (w *RetentionWorker) Timeout(job *river.Job[models.RetentionArgs]) time.Duration {
+	return w.config.JobInterval
+}
+
+func (w *RetentionWorker) Work(ctx context.Context, job *river.Job[models.RetentionArgs]) error {
+	logger := utils.LoggerFromContext(ctx)
+
+	policies, err := w.repository.ListRetentionPolicies(ctx, w.executorFactory.NewExecutor(), job.Args.OrgId)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, policy := range policies {
+		if !policy.Enabled {
+			continue
+		}
+
+		var nb int
+		if policy.TableName == models.RetentionTableDecisions {
+			nb, err = w.applyOnDecisions(ctx, policy, now)
+		} else {
+			nb, err = w.applyOnIngestedTable(ctx, policy, now)
+		}
+		if err != nil {
+			return err
+		}
+
+		logger.DebugContext(ctx, fmt.Sprintf("retention policy removed %d rows from %s", nb, policy.TableName),
+			"org_id", job.Args.OrgId, "policy_id", policy.Id, "action", policy.Action.String())
+	}
+
+	return nil
+}
+
+func (w *RetentionWorker) applyOnDecisions(ctx context.Context, policy models.RetentionPolicy, now time.Time) (int, error) {
+	exec := w.executorFactory.NewExecutor()
+	cutoff := policy.Cutoff(now)
+
+	return w.processBatches(ctx, policy,
+		func(watermark *models.OffloadingWatermark) ([]models.RetainedRow, error) {
+			return w.repository.ListRetainableDecisions(ctx, exec, policy.OrganizationId,
+				cutoff, watermark, w.config.BatchSize)
+		},
+		func(rows []models.RetainedRow) ([]string, error) {
+			return w.withOffloadedRuleEvaluations(ctx, policy.OrganizationId, rows)
+		},
+		func(tx repositories.Transaction, ids []string) error {
+			return w.repository.DeleteDecisions(ctx, tx, ids)
+		},
+	)
+}
+
+// withOffloadedRuleEvaluations adds the evaluations of the rules of the decisions that were offloaded to the rows, so
+// that they are archived with the decision, and returns the keys of their blobs.
+func (w *RetentionWorker) withOffloadedRuleEvaluations(ctx context.Context, orgId string,
+	rows []models.RetainedRow,
+) ([]string, error) {
+	if w.offloadingBucketUrl == "" {
+		return nil, nil
+	}
+	exec := w.executorFactory.NewExecutor()
+
+	offloadingWatermark, err := w.repository.GetOffloadingWatermark(ctx, exec, orgId, repositories.OffloadingDecisionRules)
+	if err != nil || offloadingWatermark == nil {
+		return nil, err
+	}
+
+	rowsById := make(map[string]models.RetainedRow, len(rows))
+	for _, row := range rows {
+		if !row.CreatedAt.After(offloadingWatermark.WatermarkTime) {
+			rowsById[row.Id] = row
+		}
+	}
+	if len(rowsById) == 0 {
+		return nil, nil
+	}
+
+	rules, err := w.repository.ListDecisionRuleRefs(ctx, exec, slices.Collect(maps.Keys(rowsById)))
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		row := rowsById[rule.DecisionId]
+		key := w.repository.GetOffloadedDecisionRuleKey(orgId, rule.DecisionId, rule.RuleId, rule.Outcome, row.CreatedAt)
+
+		blob, err := w.blobRepository.GetBlob(ctx, w.offloadingBucketUrl, key)
+		if errors.Is(err, models.NotFoundError) {
+			// the rule had no evaluation to offload
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		content, err := io.ReadAll(blob.ReadCloser)
+		blob.ReadCloser.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		evaluations, _ := row.Data["offloaded_rule_evaluations"].(map[string]json.RawMessage)
+		if evaluations == nil {
+			evaluations = make(map[string]json.RawMessage)
+			row.Data["offloaded_rule_evaluations"] = evaluations
+		}
+		evaluations[rule.RuleId] = content
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+func (w *RetentionWorker) applyOnIngestedTable(ctx context.Context, policy models.RetentionPolicy, now time.Time) (int, error) {
+	cutoff := policy.Cutoff(now)
+
+	objectIdsOnHold, err := w.repository.ListObjectIdsOnLegalHold(ctx,
+		w.executorFactory.NewExecutor(), policy.OrganizationId, policy.TableName)
+	if err != nil {
+		return 0, err
+	}
+
+	clientExec, err := w.executorFactory.NewClientDbExecutor(ctx, policy.OrganizationId)
+	if err != nil {
+		return 0, err
+	}
+
+	return w.processBatches(ctx, policy,
+		func(watermark *models.OffloadingWatermark) ([]models.RetainedRow, error) {
+			return w.clientDbRepository.ListRetainableIngestedRows(ctx, clientExec, policy.TableName,
+				cutoff, policy.KeepLatestVersion, objectIdsOnHold, watermark, w.config.BatchSize)
+		},
+		nil,
+		func(_ repositories.Transaction, ids []string) error {
+			// ingested data may live in a different database than the watermarks, so the deletion is not part of the
+			// watermark transaction. Rows that are deleted twice are simply not found.
+			return w.clientDbRepository.DeleteIngestedRows(ctx, clientExec, policy.TableName, ids)
+		},
+	)
+}
+
+func (w *RetentionWorker) processBatches(
+	ctx context.Context,
+	policy models.RetentionPolicy,
+	list func(watermark *models.OffloadingWatermark) ([]models.RetainedRow, error),
+	// prepare, if not nil, completes the rows before they are archived and returns the keys of the blobs that belong
+	// to them
+	prepare func(rows []models.RetainedRow) ([]string, error),
+	remove func(tx repositories.Transaction, ids []string) error,
+) (int, error) {
+	exec := w.executorFactory.NewExecutor()
+	watermarkTable := retentionWatermarkPrefix + policy.TableName
+	nb := 0
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return nb, err
+		}
+
+		watermark, err := w.repository.GetOffloadingWatermark(ctx, exec, policy.OrganizationId, watermarkTable)
+		if err != nil {
+			return nb, err
+		}
+
+		rows, err := list(watermark)
+		if err != nil {
+			return nb, err
+		}
+
+		if len(rows) == 0 {
+			// all rows older than the cutoff were processed: rewind so that the next run starts from the beginning again
+			if watermark == nil {
+				return nb, nil
+			}
+			return nb, w.transactionFactory.Transaction(ctx, func(tx repositories.Transaction) error {
+				return w.repository.SaveOffloadingWatermark(ctx, tx, policy.OrganizationId,
+					watermarkTable, uuid.UUID{}.String(), time.Time{})
+			})
+		}
+
+		var blobKeys []string
+		if prepare != nil {
+			if blobKeys, err = prepare(rows); err != nil {
+				return nb, err
+			}
+		}
+
+		if policy.Action == models.RetentionActionArchive {
+			if err := w.archive(ctx, policy, rows); err != nil {
+				return nb, err
+			}
+		}
+
+		// The blobs are deleted before the rows, because their keys can only be found from the rows: deleting them after
+		// would leave them orphaned if the job stopped in between. Blobs already deleted are not found again on retry.
+		for _, key := range blobKeys {
+			if err := w.blobRepository.DeleteFile(ctx, w.offloadingBucketUrl, key); err != nil &&
+				!errors.Is(err, models.NotFoundError) {
+				return nb, err
+			}
+		}
+
+		last := rows[len(rows)-1]
+		err = w.transactionFactory.Transaction(ctx, func(tx repositories.Transaction) error {
+			if err := remove(tx, pure_utils.Map(rows, func(r models.RetainedRow) string { return r.Id })); err != nil {
+				return err
+			}
+			return w.repository.SaveOffloadingWatermark(ctx, tx, policy.OrganizationId,
+				watermarkTable, last.Id, last.CreatedAt)
+		})
+		if err != nil {
+			return nb, err
+		}
+		nb += len(rows)
+	}
+}
+
+// archive writes the rows as json lines to the archive bucket. The key only depends on the first row of the batch, so
+// that a batch archived again after a failed deletion overwrites the previous file.
+func (w *RetentionWorker) archive(ctx context.Context, policy models.RetentionPolicy, rows []models.RetainedRow) error {
+	key := w.repository.GetRetentionArchiveKey(policy.OrganizationId, policy.TableName, rows[0].Id, rows[0].CreatedAt)
+
+	wr, err := w.blobRepository.OpenStream(ctx, w.config.ArchiveBucketUrl, key, key)
+	if err != nil {
+		return err
+	}
+	defer wr.Close()
+
+	enc := json.NewEncoder(wr)
+	for _, row := range rows {
+		if err := enc.Encode(row.Data); err != nil {
+			return err
+		}
+	}
+
+	return wr.Close()
+}
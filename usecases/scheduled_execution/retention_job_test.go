@@ -0,0 +1,277 @@
+package scheduled_execution
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/riverqueue/river"
+	"github.com/riverqueue/river/rivertype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gocloud.dev/blob"
+
+	"github.com/checkmarble/marble-backend/infra"
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/repositories"
+	"github.com/checkmarble/marble-backend/usecases/executor_factory"
+)
+
+// fakeRetentionRepository keeps the decisions and the ingested rows in memory, ordered by creation time.
+type fakeRetentionRepository struct {
+	policies         []models.RetentionPolicy
+	decisions        []models.RetainedRow
+	ingestedRows     []models.RetainedRow
+	rules            []models.DecisionRuleRef
+	watermarks       map[string]*models.OffloadingWatermark
+	ingestedListings []*models.OffloadingWatermark
+}
+
+func afterWatermark(rows []models.RetainedRow, watermark *models.OffloadingWatermark, limit int) []models.RetainedRow {
+	out := make([]models.RetainedRow, 0)
+	for _, row := range rows {
+		if watermark != nil && !row.CreatedAt.After(watermark.WatermarkTime) {
+			continue
+		}
+		if len(out) == limit {
+			break
+		}
+		out = append(out, row)
+	}
+	return out
+}
+
+func (r *fakeRetentionRepository) ListRetentionPolicies(ctx context.Context, exec repositories.Executor,
+	orgId string,
+) ([]models.RetentionPolicy, error) {
+	return r.policies, nil
+}
+
+func (r *fakeRetentionRepository) ListObjectIdsOnLegalHold(ctx context.Context, exec repositories.Executor,
+	orgId, tableName string,
+) ([]string, error) {
+	return nil, nil
+}
+
+func (r *fakeRetentionRepository) ListRetainableDecisions(ctx context.Context, exec repositories.Executor,
+	orgId string, cutoff time.Time, watermark *models.OffloadingWatermark, limit int,
+) ([]models.RetainedRow, error) {
+	return afterWatermark(r.decisions, watermark, limit), nil
+}
+
+func (r *fakeRetentionRepository) DeleteDecisions(ctx context.Context, exec repositories.Executor, ids []string) error {
+	r.decisions = slices.DeleteFunc(r.decisions, func(row models.RetainedRow) bool { return slices.Contains(ids, row.Id) })
+	r.rules = slices.DeleteFunc(r.rules, func(rule models.DecisionRuleRef) bool {
+		return slices.Contains(ids, rule.DecisionId)
+	})
+	return nil
+}
+
+func (r *fakeRetentionRepository) GetRetentionArchiveKey(orgId, tableName, batchId string, createdAt time.Time) string {
+	return fmt.Sprintf("retention/%s/%s/%s.jsonl", orgId, tableName, batchId)
+}
+
+func (r *fakeRetentionRepository) ListDecisionRuleRefs(ctx context.Context, exec repositories.Executor,
+	decisionIds []string,
+) ([]models.DecisionRuleRef, error) {
+	out := make([]models.DecisionRuleRef, 0)
+	for _, rule := range r.rules {
+		if slices.Contains(decisionIds, rule.DecisionId) {
+			out = append(out, rule)
+		}
+	}
+	return out, nil
+}
+
+func (r *fakeRetentionRepository) GetOffloadedDecisionRuleKey(orgId, decisionId, ruleId, outcome string,
+	createdAt time.Time,
+) string {
+	return fmt.Sprintf("offloading/decision_rules/%s/%s/%s/%s", orgId, decisionId, ruleId, outcome)
+}
+
+func (r *fakeRetentionRepository) GetOffloadingWatermark(ctx context.Context, exec repositories.Executor,
+	orgId, table string,
+) (*models.OffloadingWatermark, error) {
+	return r.watermarks[table], nil
+}
+
+func (r *fakeRetentionRepository) SaveOffloadingWatermark(ctx context.Context, tx repositories.Transaction,
+	orgId, table, watermarkId string, watermarkTime time.Time,
+) error {
+	r.watermarks[table] = &models.OffloadingWatermark{
+		OrgId:         orgId,
+		TableName:     table,
+		WatermarkTime: watermarkTime,
+		WatermarkId:   watermarkId,
+	}
+	return nil
+}
+
+func (r *fakeRetentionRepository) ListRetainableIngestedRows(ctx context.Context, exec repositories.Executor,
+	tableName string, cutoff time.Time, keepLatestVersion bool, objectIdsOnHold []string,
+	watermark *models.OffloadingWatermark, limit int,
+) ([]models.RetainedRow, error) {
+	r.ingestedListings = append(r.ingestedListings, watermark)
+	return afterWatermark(r.ingestedRows, watermark, limit), nil
+}
+
+func (r *fakeRetentionRepository) DeleteIngestedRows(ctx context.Context, exec repositories.Executor,
+	tableName string, ids []string,
+) error {
+	r.ingestedRows = slices.DeleteFunc(r.ingestedRows, func(row models.RetainedRow) bool {
+		return slices.Contains(ids, row.Id)
+	})
+	return nil
+}
+
+type fakeBlobRepository struct {
+	blobs map[string][]byte
+}
+
+type fakeBlobWriter struct {
+	bytes.Buffer
+	repo *fakeBlobRepository
+	key  string
+}
+
+func (w *fakeBlobWriter) Close() error {
+	w.repo.blobs[w.key] = w.Bytes()
+	return nil
+}
+
+func (r *fakeBlobRepository) GetBlob(ctx context.Context, bucketUrl, key string) (models.Blob, error) {
+	content, ok := r.blobs[bucketUrl+"/"+key]
+	if !ok {
+		return models.Blob{}, models.NotFoundError
+	}
+	return models.Blob{FileName: key, ReadCloser: io.NopCloser(bytes.NewReader(content))}, nil
+}
+
+func (r *fakeBlobRepository) OpenStream(ctx context.Context, bucketUrl, key string, fileName string) (io.WriteCloser, error) {
+	return &fakeBlobWriter{repo: r, key: bucketUrl + "/" + key}, nil
+}
+
+func (r *fakeBlobRepository) OpenStreamWithOptions(ctx context.Context, bucketUrl, key string,
+	opts *blob.WriterOptions,
+) (io.WriteCloser, error) {
+	return r.OpenStream(ctx, bucketUrl, key, key)
+}
+
+func (r *fakeBlobRepository) DeleteFile(ctx context.Context, bucketUrl, key string) error {
+	if _, ok := r.blobs[bucketUrl+"/"+key]; !ok {
+		return models.NotFoundError
+	}
+	delete(r.blobs, bucketUrl+"/"+key)
+	return nil
+}
+
+func (r *fakeBlobRepository) GenerateSignedUrl(ctx context.Context, bucketUrl, key string) (string, error) {
+	return bucketUrl + "/" + key, nil
+}
+
+func retainedRows(prefix string, start time.Time, nb int) []models.RetainedRow {
+	rows := make([]models.RetainedRow, nb)
+	for i := range rows {
+		id := fmt.Sprintf("%s%d", prefix, i)
+		rows[i] = models.RetainedRow{
+			Id:        id,
+			CreatedAt: start.Add(time.Duration(i) * time.Minute),
+			Data:      map[string]any{"id": id},
+		}
+	}
+	return rows
+}
+
+func newRetentionTestWorker(repo *fakeRetentionRepository, blobs *fakeBlobRepository) *RetentionWorker {
+	exec := executor_factory.NewExecutorFactoryStub()
+	return NewRetentionWorker(exec, executor_factory.NewTransactionFactoryStub(exec), repo, repo, blobs,
+		"offloading", infra.RetentionConfig{ArchiveBucketUrl: "archive", BatchSize: 2})
+}
+
+func retentionJob() *river.Job[models.RetentionArgs] {
+	return &river.Job[models.RetentionArgs]{
+		JobRow: &rivertype.JobRow{},
+		Args:   models.RetentionArgs{OrgId: "org"},
+	}
+}
+
+func TestRetentionWorker_decisions(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	repo := &fakeRetentionRepository{
+		policies: []models.RetentionPolicy{{
+			Id:             "policy",
+			OrganizationId: "org",
+			TableName:      models.RetentionTableDecisions,
+			Action:         models.RetentionActionArchive,
+			RetentionDays:  30,
+			Enabled:        true,
+		}},
+		decisions: retainedRows("decision-", start, 3),
+		rules: []models.DecisionRuleRef{
+			{DecisionId: "decision-0", RuleId: "rule-a", Outcome: "hit"},
+			{DecisionId: "decision-0", RuleId: "rule-b", Outcome: "no_hit"},
+			{DecisionId: "decision-2", RuleId: "rule-a", Outcome: "hit"},
+		},
+		watermarks: map[string]*models.OffloadingWatermark{
+			// only the first two decisions were offloaded
+			repositories.OffloadingDecisionRules: {WatermarkTime: start.Add(time.Minute)},
+		},
+	}
+	blobs := &fakeBlobRepository{blobs: map[string][]byte{
+		"offloading/offloading/decision_rules/org/decision-0/rule-a/hit": []byte(`{"return_value":true}`),
+	}}
+
+	require.NoError(t, newRetentionTestWorker(repo, blobs).Work(context.Background(), retentionJob()))
+
+	assert.Empty(t, repo.decisions)
+	assert.Empty(t, repo.rules)
+	assert.NotContains(t, blobs.blobs, "offloading/offloading/decision_rules/org/decision-0/rule-a/hit",
+		"the offloaded evaluation is deleted with its decision")
+	assert.True(t, repo.watermarks["retention/decisions"].WatermarkTime.IsZero())
+
+	archive := string(blobs.blobs["archive/retention/org/decisions/decision-0.jsonl"])
+	lines := strings.Split(strings.TrimSpace(archive), "\n")
+	require.Len(t, lines, 2)
+
+	var first map[string]any
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &first))
+	assert.Equal(t, map[string]any{"rule-a": map[string]any{"return_value": true}},
+		first["offloaded_rule_evaluations"])
+	assert.Contains(t, blobs.blobs, "archive/retention/org/decisions/decision-2.jsonl")
+}
+
+func TestRetentionWorker_ingested_data_resumes_from_watermark(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	rows := retainedRows("tx-", start, 3)
+	repo := &fakeRetentionRepository{
+		policies: []models.RetentionPolicy{{
+			Id:             "policy",
+			OrganizationId: "org",
+			TableName:      "transactions",
+			Action:         models.RetentionActionDelete,
+			RetentionDays:  30,
+			Enabled:        true,
+		}},
+		// the first row was processed by a previous run that stopped before deleting it
+		ingestedRows: rows,
+		watermarks: map[string]*models.OffloadingWatermark{
+			"retention/transactions": {WatermarkId: rows[0].Id, WatermarkTime: rows[0].CreatedAt},
+		},
+	}
+	blobs := &fakeBlobRepository{blobs: map[string][]byte{}}
+
+	require.NoError(t, newRetentionTestWorker(repo, blobs).Work(context.Background(), retentionJob()))
+
+	assert.Equal(t, []models.RetainedRow{rows[0]}, repo.ingestedRows)
+	require.NotEmpty(t, repo.ingestedListings)
+	assert.Equal(t, rows[0].Id, repo.ingestedListings[0].WatermarkId)
+	assert.True(t, repo.watermarks["retention/transactions"].WatermarkTime.IsZero(),
+		"the watermark is rewound once all rows are processed")
+	assert.Empty(t, blobs.blobs)
+}
@@ -187,7 +187,8 @@ func (usecase *RunScheduledExecution) insertAsyncDecisionTasks(
 		)
 	}
 
-	objectIds, err := usecase.ingestedDataReadRepository.ListAllObjectIdsFromTable(ctx, db, scenario.TriggerObjectType, filters...)
+	objectIds, err := usecase.ingestedDataReadRepository.ListAllObjectIdsFromTable(ctx, db,
+		scenario.TriggerObjectType, scheduledExecution.ReadAsOf, filters...)
 	if err != nil {
 		return err
 	}
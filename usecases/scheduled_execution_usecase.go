@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/checkmarble/marble-backend/models"
 	"github.com/checkmarble/marble-backend/pure_utils"
@@ -147,6 +148,9 @@ func (usecase *ScheduledExecutionUsecase) CreateScheduledExecution(ctx context.C
 	if len(pendingExecutions) > 0 {
 		return fmt.Errorf("a pending execution already exists for this scenario %w", models.BadParameterError)
 	}
+	if input.ReadAsOf != nil && input.ReadAsOf.After(time.Now()) {
+		return fmt.Errorf("the execution cannot read data as of a future date %w", models.BadParameterError)
+	}
 
 	id := pure_utils.NewPrimaryKey(input.OrganizationId)
 	return usecase.repository.CreateScheduledExecution(ctx, exec, models.CreateScheduledExecutionInput{
@@ -154,5 +158,6 @@ func (usecase *ScheduledExecutionUsecase) CreateScheduledExecution(ctx context.C
 		ScenarioId:          scenario.Id,
 		ScenarioIterationId: input.ScenarioIterationId,
 		Manual:              true,
+		ReadAsOf:            input.ReadAsOf,
 	}, id)
 }
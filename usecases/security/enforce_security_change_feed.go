@@ -0,0 +1,33 @@
+package security
+
+import (
+	"errors"
+
+	"github.com/checkmarble/marble-backend/models"
+)
+
+type EnforceSecurityChangeFeed interface {
+	ReadChangeFeed(organizationId string) error
+	ManageChangeFeed(organizationId string) error
+}
+
+type EnforceSecurityChangeFeedImpl struct {
+	EnforceSecurity
+	Credentials models.Credentials
+}
+
+// The change feed contains decisions as well as cases, so both permissions are required to read it
+func (e *EnforceSecurityChangeFeedImpl) ReadChangeFeed(organizationId string) error {
+	return errors.Join(
+		e.Permission(models.DECISION_READ),
+		e.Permission(models.CASE_READ_WRITE),
+		e.ReadOrganization(organizationId),
+	)
+}
+
+func (e *EnforceSecurityChangeFeedImpl) ManageChangeFeed(organizationId string) error {
+	return errors.Join(
+		e.Permission(models.ORGANIZATIONS_UPDATE),
+		e.ReadOrganization(organizationId),
+	)
+}
@@ -9,6 +9,8 @@ import (
 type EnforceSecurityDecision interface {
 	EnforceSecurity
 	ReadDecision(decision models.Decision) error
+	ReadDecisionRequest(request models.DecisionRequest) error
+	ReadDecisionReevaluationBatch(batch models.DecisionReevaluationBatch) error
 	ReadScheduledExecution(scheduledExecution models.ScheduledExecution) error
 	CreateDecision(organizationId string) error
 	CreateScheduledExecution(organizationId string) error
@@ -26,6 +28,20 @@ func (e *EnforceSecurityDecisionImpl) ReadDecision(decision models.Decision) err
 	)
 }
 
+func (e *EnforceSecurityDecisionImpl) ReadDecisionRequest(request models.DecisionRequest) error {
+	return errors.Join(
+		e.Permission(models.DECISION_READ),
+		e.ReadOrganization(request.OrganizationId),
+	)
+}
+
+func (e *EnforceSecurityDecisionImpl) ReadDecisionReevaluationBatch(batch models.DecisionReevaluationBatch) error {
+	return errors.Join(
+		e.Permission(models.DECISION_READ),
+		e.ReadOrganization(batch.OrganizationId),
+	)
+}
+
 func (e *EnforceSecurityDecisionImpl) CreateDecision(organizationId string) error {
 	return errors.Join(
 		e.Permission(models.DECISION_CREATE),
@@ -0,0 +1,23 @@
+package security
+
+import (
+	"errors"
+
+	"github.com/checkmarble/marble-backend/models"
+)
+
+type EnforceSecurityDecisionExport interface {
+	ExportDecisions(organizationId string) error
+}
+
+type EnforceSecurityDecisionExportImpl struct {
+	EnforceSecurity
+	Credentials models.Credentials
+}
+
+func (e *EnforceSecurityDecisionExportImpl) ExportDecisions(organizationId string) error {
+	return errors.Join(
+		e.Permission(models.DECISION_READ),
+		e.ReadOrganization(organizationId),
+	)
+}
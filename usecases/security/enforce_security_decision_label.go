@@ -0,0 +1,32 @@
+package security
+
+import (
+	"errors"
+
+	"github.com/checkmarble/marble-backend/models"
+)
+
+type EnforceSecurityDecisionLabel interface {
+	WriteDecisionLabels(organizationId string) error
+	ReadDecisionLabels(organizationId string) error
+}
+
+type EnforceSecurityDecisionLabelImpl struct {
+	EnforceSecurity
+	Credentials models.Credentials
+}
+
+// Labels are sent by the same systems that create decisions, typically with an API key
+func (e *EnforceSecurityDecisionLabelImpl) WriteDecisionLabels(organizationId string) error {
+	return errors.Join(
+		e.Permission(models.DECISION_CREATE),
+		e.ReadOrganization(organizationId),
+	)
+}
+
+func (e *EnforceSecurityDecisionLabelImpl) ReadDecisionLabels(organizationId string) error {
+	return errors.Join(
+		e.Permission(models.DECISION_READ),
+		e.ReadOrganization(organizationId),
+	)
+}
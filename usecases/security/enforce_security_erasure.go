@@ -0,0 +1,31 @@
+package security
+
+import (
+	"errors"
+
+	"github.com/checkmarble/marble-backend/models"
+)
+
+type EnforceSecurityErasure interface {
+	EraseData(organizationId string) error
+	ReadErasureCertificate(certificate models.ErasureCertificate) error
+}
+
+type EnforceSecurityErasureImpl struct {
+	EnforceSecurity
+	Credentials models.Credentials
+}
+
+func (e *EnforceSecurityErasureImpl) EraseData(organizationId string) error {
+	return errors.Join(
+		e.Permission(models.DATA_ERASURE),
+		e.ReadOrganization(organizationId),
+	)
+}
+
+func (e *EnforceSecurityErasureImpl) ReadErasureCertificate(certificate models.ErasureCertificate) error {
+	return errors.Join(
+		e.Permission(models.DATA_ERASURE),
+		e.ReadOrganization(certificate.OrganizationId),
+	)
+}
@@ -0,0 +1,323 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/repositories"
+	"github.com/checkmarble/marble-backend/usecases/executor_factory"
+	"github.com/checkmarble/marble-backend/utils"
+)
+
+// Field names looked up in the ingested objects to prefill the content of a report. Any field that is not found is left
+// empty, for the compliance officer to fill in.
+var (
+	sarFirstNameFields     = []string{"first_name", "firstname"}
+	sarLastNameFields      = []string{"last_name", "lastname"}
+	sarBirthDateFields     = []string{"birth_date", "birthdate", "date_of_birth"}
+	sarNationalityFields   = []string{"nationality"}
+	sarEntityNameFields    = []string{"name", "company_name", "legal_name"}
+	sarIncorporationFields = []string{"registration_number", "incorporation_number", "company_number"}
+	sarAccountFields       = []string{"iban", "account_number"}
+	sarInstitutionFields   = []string{"bank_name", "institution_name"}
+	sarAddressFields       = []string{"address", "street_address"}
+	sarCityFields          = []string{"city"}
+	sarCountryFields       = []string{"country", "country_code"}
+	sarAmountFields        = []string{"amount", "transaction_amount", "value"}
+	sarCurrencyFields      = []string{"currency", "currency_code"}
+	sarDateFields          = []string{"transaction_at", "transaction_date", "updated_at"}
+)
+
+// GetReportContent returns the structured content of a report. If it was never saved, it is prefilled from the case,
+// and the returned boolean is true.
+func (uc SuspiciousActivityReportUsecase) GetReportContent(
+	ctx context.Context,
+	orgId, caseId, reportId string,
+) (models.SarContent, bool, error) {
+	exec := uc.executorFactory.NewExecutor()
+
+	c, err := uc.hasCasePermissions(ctx, exec, orgId, caseId)
+	if err != nil {
+		return models.SarContent{}, false, err
+	}
+
+	sar, err := uc.repository.GetSuspiciousActivityReportById(ctx, exec, caseId, reportId, false)
+	if err != nil {
+		return models.SarContent{}, false, err
+	}
+	if sar.Content != nil {
+		return *sar.Content, false, nil
+	}
+
+	content, err := uc.prefillReportContent(ctx, exec, orgId, c)
+	if err != nil {
+		return models.SarContent{}, false, err
+	}
+	return content, true, nil
+}
+
+// UpdateReportContent saves the structured content of a report. The content does not need to be valid to be saved,
+// so that the report can be drafted over time, but it cannot be changed after the report is completed.
+func (uc SuspiciousActivityReportUsecase) UpdateReportContent(
+	ctx context.Context,
+	orgId, caseId, reportId string,
+	content models.SarContent,
+) (models.SuspiciousActivityReport, error) {
+	exec := uc.executorFactory.NewExecutor()
+
+	c, err := uc.hasCasePermissions(ctx, exec, orgId, caseId)
+	if err != nil {
+		return models.SuspiciousActivityReport{}, err
+	}
+
+	var userId *string
+
+	if creds, ok := utils.CredentialsFromCtx(ctx); ok {
+		userId = utils.Ptr(string(creds.ActorIdentity.UserId))
+	}
+
+	return executor_factory.TransactionReturnValue(ctx, uc.transactionFactory, func(
+		tx repositories.Transaction,
+	) (models.SuspiciousActivityReport, error) {
+		sar, err := uc.repository.GetSuspiciousActivityReportById(ctx, tx, caseId, reportId, true)
+		if err != nil {
+			return models.SuspiciousActivityReport{}, err
+		}
+		if sar.Status == models.SarCompleted {
+			return models.SuspiciousActivityReport{}, errors.Wrap(models.UnprocessableEntityError,
+				"the suspicious activity report is marked as completed")
+		}
+
+		updatedSar, err := uc.repository.UpdateSuspiciousActivityReportContent(ctx, tx, caseId, reportId, content)
+		if err != nil {
+			return models.SuspiciousActivityReport{}, err
+		}
+
+		if err := uc.caseUsecase.PerformCaseActionSideEffects(ctx, tx, c); err != nil {
+			return models.SuspiciousActivityReport{}, err
+		}
+
+		if err := uc.repository.CreateCaseEvent(ctx, tx, models.CreateCaseEventAttributes{
+			CaseId:       sar.CaseId,
+			UserId:       userId,
+			EventType:    models.SarContentUpdated,
+			ResourceType: utils.Ptr(models.SarResourceType),
+			ResourceId:   utils.Ptr(updatedSar.Id),
+		}); err != nil {
+			return models.SuspiciousActivityReport{}, err
+		}
+
+		return updatedSar, nil
+	})
+}
+
+// ExportReport generates the file of a report from its saved structured content, which must be valid.
+func (uc SuspiciousActivityReportUsecase) ExportReport(
+	ctx context.Context,
+	orgId, caseId, reportId string,
+	format models.SarExportFormat,
+) (models.SarExport, error) {
+	exec := uc.executorFactory.NewExecutor()
+
+	if _, err := uc.hasCasePermissions(ctx, exec, orgId, caseId); err != nil {
+		return models.SarExport{}, err
+	}
+
+	sar, err := uc.repository.GetSuspiciousActivityReportById(ctx, exec, caseId, reportId, false)
+	if err != nil {
+		return models.SarExport{}, err
+	}
+	if sar.Content == nil {
+		return models.SarExport{}, errors.Wrap(models.UnprocessableEntityError,
+			"the suspicious activity report does not have a structured content")
+	}
+	if problems := sar.Content.Validate(); len(problems) > 0 {
+		return models.SarExport{}, errors.Wrap(models.UnprocessableEntityError,
+			fmt.Sprintf("the suspicious activity report is not valid: %s", strings.Join(problems, ", ")))
+	}
+
+	now := time.Now()
+
+	switch format {
+	case models.SarExportGoAml:
+		data, err := sarToGoAml(sar, *sar.Content, now)
+		if err != nil {
+			return models.SarExport{}, err
+		}
+		return models.SarExport{
+			FileName:    fmt.Sprintf("sar-%s.xml", sar.ReportId),
+			ContentType: "application/xml",
+			Data:        data,
+		}, nil
+	case models.SarExportJson:
+		data, err := json.MarshalIndent(map[string]any{
+			"report_id":    sar.ReportId,
+			"case_id":      sar.CaseId,
+			"generated_at": now.UTC(),
+			"content":      sar.Content,
+		}, "", "  ")
+		if err != nil {
+			return models.SarExport{}, err
+		}
+		return models.SarExport{
+			FileName:    fmt.Sprintf("sar-%s.json", sar.ReportId),
+			ContentType: "application/json",
+			Data:        data,
+		}, nil
+	default:
+		return models.SarExport{}, errors.Wrap(models.BadParameterError,
+			fmt.Sprintf("unknown export format %s", format))
+	}
+}
+
+func (uc SuspiciousActivityReportUsecase) prefillReportContent(ctx context.Context,
+	exec repositories.Executor, orgId string, c models.Case,
+) (models.SarContent, error) {
+	org, err := uc.organizationRepository.GetOrganizationById(ctx, exec, orgId)
+	if err != nil {
+		return models.SarContent{}, err
+	}
+
+	pivotObjects, err := uc.caseUsecase.ReadCasePivotObjects(ctx, c.Id)
+	if err != nil {
+		return models.SarContent{}, err
+	}
+
+	return prefillSarContent(org, pivotObjects, c.Decisions), nil
+}
+
+func prefillSarContent(
+	org models.Organization,
+	pivotObjects []models.PivotObject,
+	decisions []models.DecisionWithRuleExecutions,
+) models.SarContent {
+	content := models.SarContent{
+		ReportCode:      models.SarReportCodeSar,
+		ReportingEntity: models.SarReportingEntity{Name: org.Name},
+		Subjects:        make([]models.SarSubject, 0, len(pivotObjects)),
+		Transactions:    make([]models.SarTransaction, 0, len(decisions)),
+		Indicators:      make([]string, 0),
+	}
+
+	for _, pivotObject := range pivotObjects {
+		content.Subjects = append(content.Subjects, prefillSarSubject(pivotObject))
+	}
+
+	for _, decision := range decisions {
+		data := decision.ClientObject.Data
+
+		if content.CurrencyCode == "" {
+			content.CurrencyCode = strings.ToUpper(sarStringField(data, sarCurrencyFields))
+		}
+
+		amount, ok := sarAmountField(data)
+		if ok {
+			transaction := models.SarTransaction{
+				DecisionId:        decision.DecisionId,
+				TransactionNumber: sarStringField(data, []string{"object_id"}),
+				Date:              decision.CreatedAt,
+				Amount:            amount,
+				Description:       decision.ScenarioName,
+			}
+			if date, ok := sarTimeField(data, sarDateFields); ok {
+				transaction.Date = date
+			}
+			if decision.PivotValue != nil {
+				transaction.FromSubjectId = *decision.PivotValue
+			}
+			content.Transactions = append(content.Transactions, transaction)
+		}
+
+		for _, ruleExecution := range decision.RuleExecutions {
+			if ruleExecution.Outcome == "hit" && !slices.Contains(content.Indicators, ruleExecution.Rule.Name) {
+				content.Indicators = append(content.Indicators, ruleExecution.Rule.Name)
+			}
+		}
+	}
+
+	if len(content.Transactions) > 0 {
+		content.ReportCode = models.SarReportCodeStr
+	}
+
+	return content
+}
+
+func prefillSarSubject(pivotObject models.PivotObject) models.SarSubject {
+	data := pivotObject.PivotObjectData.Data
+	subject := models.SarSubject{
+		Id:                  pivotObject.PivotValue,
+		ObjectType:          pivotObject.PivotObjectName,
+		ObjectId:            pivotObject.PivotObjectId,
+		FirstName:           sarStringField(data, sarFirstNameFields),
+		LastName:            sarStringField(data, sarLastNameFields),
+		Nationality:         sarStringField(data, sarNationalityFields),
+		IncorporationNumber: sarStringField(data, sarIncorporationFields),
+		AccountNumber:       sarStringField(data, sarAccountFields),
+		Institution:         sarStringField(data, sarInstitutionFields),
+		Address:             sarStringField(data, sarAddressFields),
+		City:                sarStringField(data, sarCityFields),
+		CountryCode:         strings.ToUpper(sarStringField(data, sarCountryFields)),
+	}
+	if birthDate, ok := sarTimeField(data, sarBirthDateFields); ok {
+		subject.BirthDate = &birthDate
+	}
+
+	switch {
+	case subject.FirstName != "" || subject.LastName != "":
+		subject.Type = models.SarSubjectPerson
+	case subject.AccountNumber != "":
+		subject.Type = models.SarSubjectAccount
+	default:
+		subject.Type = models.SarSubjectEntity
+		subject.Name = sarStringField(data, sarEntityNameFields)
+	}
+
+	return subject
+}
+
+func sarStringField(data map[string]any, names []string) string {
+	for _, name := range names {
+		if value, ok := data[name].(string); ok && value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+func sarAmountField(data map[string]any) (float64, bool) {
+	for _, name := range sarAmountFields {
+		switch value := data[name].(type) {
+		case float64:
+			return value, true
+		case int64:
+			return float64(value), true
+		case int:
+			return float64(value), true
+		}
+	}
+	return 0, false
+}
+
+func sarTimeField(data map[string]any, names []string) (time.Time, bool) {
+	for _, name := range names {
+		switch value := data[name].(type) {
+		case time.Time:
+			return value, true
+		case string:
+			if t, err := time.Parse(time.RFC3339, value); err == nil {
+				return t, true
+			}
+			if t, err := time.Parse(time.DateOnly, value); err == nil {
+				return t, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,196 @@
+package usecases
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/utils"
+)
+
+var updateGoldenFiles = flag.Bool("update", false, "update the golden files of the tests")
+
+func TestPrefillSarContent(t *testing.T) {
+	transactionAt := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	pivotObjects := []models.PivotObject{
+		{
+			PivotValue:      "user_1",
+			PivotObjectName: "users",
+			PivotObjectId:   "user_1",
+			PivotObjectData: models.ClientObjectDetail{Data: map[string]any{
+				"first_name": "Jane",
+				"last_name":  "Doe",
+				"country":    "fr",
+			}},
+		},
+		{
+			PivotValue:      "company_1",
+			PivotObjectName: "companies",
+			PivotObjectData: models.ClientObjectDetail{Data: map[string]any{"name": "Acme"}},
+		},
+	}
+	decisions := []models.DecisionWithRuleExecutions{
+		{
+			Decision: models.Decision{
+				DecisionId:   "decision_1",
+				CreatedAt:    transactionAt.Add(time.Hour),
+				ScenarioName: "Transfers",
+				PivotValue:   utils.Ptr("user_1"),
+				ClientObject: models.ClientObject{Data: map[string]any{
+					"object_id":      "tx_1",
+					"amount":         150.5,
+					"currency":       "eur",
+					"transaction_at": transactionAt,
+				}},
+			},
+			RuleExecutions: []models.RuleExecution{
+				{Outcome: "hit", Rule: models.Rule{Name: "Large amount"}},
+				{Outcome: "no_hit", Rule: models.Rule{Name: "New account"}},
+			},
+		},
+		{
+			Decision: models.Decision{
+				DecisionId:   "decision_2",
+				ClientObject: models.ClientObject{Data: map[string]any{"object_id": "login_1"}},
+			},
+			RuleExecutions: []models.RuleExecution{
+				{Outcome: "hit", Rule: models.Rule{Name: "Large amount"}},
+			},
+		},
+	}
+
+	content := prefillSarContent(models.Organization{Name: "Bank"}, pivotObjects, decisions)
+
+	assert.Equal(t, models.SarReportCodeStr, content.ReportCode)
+	assert.Equal(t, "Bank", content.ReportingEntity.Name)
+	assert.Equal(t, "EUR", content.CurrencyCode)
+	assert.Equal(t, []string{"Large amount"}, content.Indicators)
+
+	require.Len(t, content.Subjects, 2)
+	assert.Equal(t, models.SarSubjectPerson, content.Subjects[0].Type)
+	assert.Equal(t, "Doe", content.Subjects[0].LastName)
+	assert.Equal(t, "FR", content.Subjects[0].CountryCode)
+	assert.Equal(t, models.SarSubjectEntity, content.Subjects[1].Type)
+	assert.Equal(t, "Acme", content.Subjects[1].Name)
+
+	// only the trigger objects with an amount are transactions
+	require.Len(t, content.Transactions, 1)
+	assert.Equal(t, models.SarTransaction{
+		DecisionId:        "decision_1",
+		TransactionNumber: "tx_1",
+		Date:              transactionAt,
+		Amount:            150.5,
+		Description:       "Transfers",
+		FromSubjectId:     "user_1",
+	}, content.Transactions[0])
+}
+
+// TestSarToGoAml compares the generated files with golden files. Run the test with -update to regenerate them after a
+// deliberate change, and review the new files against the element order of the goAML report schema.
+func TestSarToGoAml(t *testing.T) {
+	reportingEntity := models.SarReportingEntity{
+		EntityId:                 "1234",
+		Branch:                   "Paris",
+		ReportingPersonFirstName: "Ann",
+		ReportingPersonLastName:  "Officer",
+		ReportingPersonEmail:     "ann.officer@bank.com",
+	}
+	subjects := []models.SarSubject{
+		{
+			Id:          "user_1",
+			Type:        models.SarSubjectPerson,
+			FirstName:   "Jane",
+			LastName:    "Doe",
+			BirthDate:   utils.Ptr(time.Date(1980, 5, 17, 0, 0, 0, 0, time.UTC)),
+			Nationality: "FR",
+			Address:     "1 rue de la Paix",
+			City:        "Paris",
+			CountryCode: "FR",
+		},
+		{Id: "company_1", Type: models.SarSubjectEntity, Name: "Acme", IncorporationNumber: "552100554"},
+		{
+			Id:            "account_1",
+			Type:          models.SarSubjectAccount,
+			AccountNumber: "FR7630006000011234567890189",
+			Institution:   "Other Bank",
+		},
+	}
+
+	tests := []struct {
+		name    string
+		content models.SarContent
+	}{
+		{
+			name: "sar_goaml_str",
+			content: models.SarContent{
+				ReportCode:      models.SarReportCodeStr,
+				ReportingEntity: reportingEntity,
+				CurrencyCode:    "EUR",
+				Narrative:       "Structuring",
+				ActionTaken:     "Account frozen",
+				Subjects:        subjects,
+				Transactions: []models.SarTransaction{
+					{
+						DecisionId:        "decision_1",
+						TransactionNumber: "tx_1",
+						Date:              time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC),
+						Amount:            150.5,
+						Description:       "Transfers",
+						FromSubjectId:     "user_1",
+						ToSubjectId:       "account_1",
+						TransmodeCode:     "B",
+						FromFundsCode:     "K",
+						ToFundsCode:       "K",
+					},
+					{
+						TransactionNumber: "tx_2",
+						Date:              time.Date(2025, 3, 1, 11, 0, 0, 0, time.UTC),
+						Amount:            9000,
+						FromSubjectId:     "company_1",
+						ToSubjectId:       "user_1",
+						TransmodeCode:     "A",
+						FromFundsCode:     "A",
+						ToFundsCode:       "K",
+					},
+				},
+				Indicators: []string{"Large amount"},
+			},
+		},
+		{
+			name: "sar_goaml_sar",
+			content: models.SarContent{
+				ReportCode:      models.SarReportCodeSar,
+				ReportingEntity: reportingEntity,
+				CurrencyCode:    "EUR",
+				Narrative:       "Unusual activity",
+				Subjects:        subjects,
+				Indicators:      []string{"New account"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Empty(t, tt.content.Validate())
+
+			data, err := sarToGoAml(models.SuspiciousActivityReport{ReportId: "report_1"}, tt.content,
+				time.Date(2025, 3, 2, 0, 0, 0, 0, time.UTC))
+			require.NoError(t, err)
+
+			goldenFile := filepath.Join("testdata", tt.name+".xml")
+			if *updateGoldenFiles {
+				require.NoError(t, os.WriteFile(goldenFile, data, 0o644))
+			}
+			expected, err := os.ReadFile(goldenFile)
+			require.NoError(t, err)
+			assert.Equal(t, string(expected), string(data))
+		})
+	}
+}
@@ -0,0 +1,225 @@
+package usecases
+
+import (
+	"encoding/xml"
+	"fmt"
+	"time"
+
+	"github.com/checkmarble/marble-backend/models"
+)
+
+// The structures below follow the element names and order of the goAML 4 report schema, but only represent the
+// elements that can be filled from the structured content of a report. A report contains either transactions (STR) or
+// an activity (SAR), and the lookup codes are copied as is from the content, where the user picks them from the tables
+// of their financial intelligence unit.
+
+const goAmlDateFormat = "2006-01-02T15:04:05"
+
+type goAmlReport struct {
+	XMLName           xml.Name             `xml:"report"`
+	RentityId         string               `xml:"rentity_id"`
+	RentityBranch     string               `xml:"rentity_branch,omitempty"`
+	SubmissionCode    string               `xml:"submission_code"`
+	ReportCode        string               `xml:"report_code"`
+	EntityReference   string               `xml:"entity_reference"`
+	SubmissionDate    string               `xml:"submission_date"`
+	CurrencyCodeLocal string               `xml:"currency_code_local"`
+	ReportingPerson   goAmlReportingPerson `xml:"reporting_person"`
+	Reason            string               `xml:"reason"`
+	Action            string               `xml:"action,omitempty"`
+	Transactions      []goAmlTransaction   `xml:"transaction"`
+	Activity          *goAmlActivity       `xml:"activity"`
+	Indicators        []string             `xml:"report_indicators>indicator"`
+}
+
+type goAmlReportingPerson struct {
+	FirstName string `xml:"first_name,omitempty"`
+	LastName  string `xml:"last_name"`
+	Email     string `xml:"email,omitempty"`
+}
+
+type goAmlTransaction struct {
+	TransactionNumber      string    `xml:"transactionnumber"`
+	InternalRefNumber      string    `xml:"internal_ref_number,omitempty"`
+	TransactionDescription string    `xml:"transaction_description,omitempty"`
+	DateTransaction        string    `xml:"date_transaction"`
+	TransmodeCode          string    `xml:"transmode_code"`
+	AmountLocal            string    `xml:"amount_local"`
+	From                   goAmlFrom `xml:"t_from"`
+	To                     goAmlTo   `xml:"t_to"`
+}
+
+type goAmlFrom struct {
+	FundsCode string        `xml:"from_funds_code"`
+	Person    *goAmlPerson  `xml:"from_person"`
+	Entity    *goAmlEntity  `xml:"from_entity"`
+	Account   *goAmlAccount `xml:"from_account"`
+	Country   string        `xml:"from_country,omitempty"`
+}
+
+type goAmlTo struct {
+	FundsCode string        `xml:"to_funds_code"`
+	Person    *goAmlPerson  `xml:"to_person"`
+	Entity    *goAmlEntity  `xml:"to_entity"`
+	Account   *goAmlAccount `xml:"to_account"`
+	Country   string        `xml:"to_country,omitempty"`
+}
+
+type goAmlActivity struct {
+	ReportParties []goAmlReportParty `xml:"report_parties>report_party"`
+}
+
+type goAmlReportParty struct {
+	Person  *goAmlPerson  `xml:"person"`
+	Entity  *goAmlEntity  `xml:"entity"`
+	Account *goAmlAccount `xml:"account"`
+}
+
+type goAmlPerson struct {
+	FirstName    string          `xml:"first_name,omitempty"`
+	LastName     string          `xml:"last_name"`
+	Birthdate    string          `xml:"birthdate,omitempty"`
+	Nationality1 string          `xml:"nationality1,omitempty"`
+	Addresses    *goAmlAddresses `xml:"addresses"`
+}
+
+type goAmlEntity struct {
+	Name                string          `xml:"name"`
+	IncorporationNumber string          `xml:"incorporation_number,omitempty"`
+	Addresses           *goAmlAddresses `xml:"addresses"`
+}
+
+type goAmlAccount struct {
+	InstitutionName string `xml:"institution_name,omitempty"`
+	Account         string `xml:"account"`
+}
+
+// goAmlAddresses is a pointer in the parties, as the schema does not allow an empty list of addresses
+type goAmlAddresses struct {
+	Addresses []goAmlAddress `xml:"address"`
+}
+
+type goAmlAddress struct {
+	Address     string `xml:"address"`
+	City        string `xml:"city,omitempty"`
+	CountryCode string `xml:"country_code,omitempty"`
+}
+
+// sarToGoAml generates the goAML XML file of a report. The content must have been validated beforehand.
+func sarToGoAml(sar models.SuspiciousActivityReport, content models.SarContent, submissionDate time.Time) ([]byte, error) {
+	subjects := make(map[string]models.SarSubject, len(content.Subjects))
+	for _, subject := range content.Subjects {
+		subjects[subject.Id] = subject
+	}
+
+	report := goAmlReport{
+		RentityId:         content.ReportingEntity.EntityId,
+		RentityBranch:     content.ReportingEntity.Branch,
+		SubmissionCode:    "E",
+		ReportCode:        string(content.ReportCode),
+		EntityReference:   sar.ReportId,
+		SubmissionDate:    submissionDate.UTC().Format(goAmlDateFormat),
+		CurrencyCodeLocal: content.CurrencyCode,
+		ReportingPerson: goAmlReportingPerson{
+			FirstName: content.ReportingEntity.ReportingPersonFirstName,
+			LastName:  content.ReportingEntity.ReportingPersonLastName,
+			Email:     content.ReportingEntity.ReportingPersonEmail,
+		},
+		Reason:     content.Narrative,
+		Action:     content.ActionTaken,
+		Indicators: content.Indicators,
+	}
+
+	switch content.ReportCode {
+	case models.SarReportCodeStr:
+		for _, transaction := range content.Transactions {
+			from := adaptGoAmlParty(subjects[transaction.FromSubjectId])
+			to := adaptGoAmlParty(subjects[transaction.ToSubjectId])
+			report.Transactions = append(report.Transactions, goAmlTransaction{
+				TransactionNumber:      transaction.TransactionNumber,
+				InternalRefNumber:      transaction.DecisionId,
+				TransactionDescription: transaction.Description,
+				DateTransaction:        transaction.Date.UTC().Format(goAmlDateFormat),
+				TransmodeCode:          transaction.TransmodeCode,
+				AmountLocal:            fmt.Sprintf("%.2f", transaction.Amount),
+				From: goAmlFrom{
+					FundsCode: transaction.FromFundsCode,
+					Person:    from.Person,
+					Entity:    from.Entity,
+					Account:   from.Account,
+					Country:   from.Country,
+				},
+				To: goAmlTo{
+					FundsCode: transaction.ToFundsCode,
+					Person:    to.Person,
+					Entity:    to.Entity,
+					Account:   to.Account,
+					Country:   to.Country,
+				},
+			})
+		}
+	case models.SarReportCodeSar:
+		activity := goAmlActivity{}
+		for _, subject := range content.Subjects {
+			party := adaptGoAmlParty(subject)
+			activity.ReportParties = append(activity.ReportParties, goAmlReportParty{
+				Person:  party.Person,
+				Entity:  party.Entity,
+				Account: party.Account,
+			})
+		}
+		report.Activity = &activity
+	}
+
+	body, err := xml.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), body...), nil
+}
+
+// goAmlParty is a subject of the report, converted to the elements of either side of a transaction or of a report party
+type goAmlParty struct {
+	Person  *goAmlPerson
+	Entity  *goAmlEntity
+	Account *goAmlAccount
+	Country string
+}
+
+func adaptGoAmlParty(subject models.SarSubject) goAmlParty {
+	var addresses *goAmlAddresses
+	if subject.Address != "" {
+		addresses = &goAmlAddresses{Addresses: []goAmlAddress{{
+			Address:     subject.Address,
+			City:        subject.City,
+			CountryCode: subject.CountryCode,
+		}}}
+	}
+
+	party := goAmlParty{Country: subject.CountryCode}
+	switch subject.Type {
+	case models.SarSubjectPerson:
+		person := goAmlPerson{
+			FirstName:    subject.FirstName,
+			LastName:     subject.LastName,
+			Nationality1: subject.Nationality,
+			Addresses:    addresses,
+		}
+		if subject.BirthDate != nil {
+			person.Birthdate = subject.BirthDate.UTC().Format(goAmlDateFormat)
+		}
+		party.Person = &person
+	case models.SarSubjectEntity:
+		party.Entity = &goAmlEntity{
+			Name:                subject.Name,
+			IncorporationNumber: subject.IncorporationNumber,
+			Addresses:           addresses,
+		}
+	case models.SarSubjectAccount:
+		party.Account = &goAmlAccount{
+			InstitutionName: subject.Institution,
+			Account:         subject.AccountNumber,
+		}
+	}
+	return party
+}
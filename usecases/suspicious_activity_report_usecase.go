@@ -18,6 +18,7 @@ import (
 type SuspiciousActivityReportCaseUsecase interface {
 	GetCase(ctx context.Context, id string) (models.Case, error)
 	PerformCaseActionSideEffects(ctx context.Context, tx repositories.Transaction, c models.Case) error
+	ReadCasePivotObjects(ctx context.Context, caseId string) ([]models.PivotObject, error)
 
 	getAvailableInboxIds(ctx context.Context, exec repositories.Executor, organizationId string) ([]string, error)
 }
@@ -33,6 +34,8 @@ type SuspiciousActivityReportRepository interface {
 		req models.SuspiciousActivityReportRequest) (models.SuspiciousActivityReport, error)
 	UploadSuspiciousActivityReport(ctx context.Context, tx repositories.Transaction,
 		sar models.SuspiciousActivityReport, req models.SuspiciousActivityReportRequest) (models.SuspiciousActivityReport, error)
+	UpdateSuspiciousActivityReportContent(ctx context.Context, exec repositories.Executor, caseId, reportId string,
+		content models.SarContent) (models.SuspiciousActivityReport, error)
 	DeleteSuspiciousActivityReport(ctx context.Context, exec repositories.Executor,
 		req models.SuspiciousActivityReportRequest) error
 
@@ -46,10 +49,12 @@ type SuspiciousActivityReportUsecase struct {
 
 	enforceCaseSecurity security.EnforceSecurityCase
 
-	caseUsecase          SuspiciousActivityReportCaseUsecase
-	repository           SuspiciousActivityReportRepository
-	blobRepository       repositories.BlobRepository
-	caseManagerBucketUrl string
+	caseUsecase            SuspiciousActivityReportCaseUsecase
+	repository             SuspiciousActivityReportRepository
+	organizationRepository repositories.OrganizationRepository
+	blobRepository         repositories.BlobRepository
+	fileScanner            repositories.FileScanner
+	caseManagerBucketUrl   string
 }
 
 func (uc SuspiciousActivityReportUsecase) ListReports(
@@ -87,6 +92,11 @@ func (uc SuspiciousActivityReportUsecase) CreateReport(
 	}
 
 	if req.File != nil {
+		scanStatus, err := scanUploadedFile(ctx, uc.fileScanner, *req.File)
+		if err != nil {
+			return models.SuspiciousActivityReport{}, err
+		}
+
 		blobKey := fmt.Sprintf("%s/%s/sar/%s", orgId, req.CaseId, uuid.NewString())
 
 		if err := uc.writeToBlobStorage(ctx, *req.File, blobKey); err != nil {
@@ -95,6 +105,7 @@ func (uc SuspiciousActivityReportUsecase) CreateReport(
 
 		req.Bucket = &uc.caseManagerBucketUrl
 		req.BlobKey = &blobKey
+		req.ScanStatus = &scanStatus
 	}
 
 	return executor_factory.TransactionReturnValue(ctx, uc.transactionFactory, func(
@@ -169,6 +180,11 @@ func (uc SuspiciousActivityReportUsecase) UpdateReport(
 	}
 
 	if req.File != nil {
+		scanStatus, err := scanUploadedFile(ctx, uc.fileScanner, *req.File)
+		if err != nil {
+			return models.SuspiciousActivityReport{}, err
+		}
+
 		blobKey := fmt.Sprintf("%s/%s/sar/%s", orgId, req.CaseId, uuid.NewString())
 
 		if err := uc.writeToBlobStorage(ctx, *req.File, blobKey); err != nil {
@@ -177,6 +193,7 @@ func (uc SuspiciousActivityReportUsecase) UpdateReport(
 
 		req.Bucket = &uc.caseManagerBucketUrl
 		req.BlobKey = &blobKey
+		req.ScanStatus = &scanStatus
 	}
 
 	var userId *string
@@ -238,6 +255,9 @@ func (uc SuspiciousActivityReportUsecase) GenerateReportUrl(
 		return "", errors.Wrap(models.NotFoundError,
 			"this suspicious activity report does not have an attached file")
 	}
+	if err := errIfQuarantined(sar.ScanStatus); err != nil {
+		return "", err
+	}
 
 	return uc.blobRepository.GenerateSignedUrl(ctx, *sar.Bucket, *sar.BlobKey)
 }
@@ -110,6 +110,10 @@ func (w *TaskQueueWorker) addMissingQueues(ctx context.Context, queues map[strin
 
 			w.riverClient.PeriodicJobs().Add(scheduled_execution.NewIndexCleanupPeriodicJob(orgId))
 			w.riverClient.PeriodicJobs().Add(scheduled_execution.NewTestRunSummaryPeriodicJob(orgId))
+			w.riverClient.PeriodicJobs().Add(scheduled_execution.NewIdempotencyKeyCleanupPeriodicJob(orgId))
+			w.riverClient.PeriodicJobs().Add(scheduled_execution.NewChangeFeedPeriodicJob(orgId))
+			w.riverClient.PeriodicJobs().Add(scheduled_execution.NewCaseSlaPeriodicJob(orgId))
+			w.riverClient.PeriodicJobs().Add(scheduled_execution.NewNotificationPeriodicJob(orgId))
 		}
 	}
 
@@ -150,6 +154,7 @@ func (w *TaskQueueWorker) removeQueuesFromMissingOrgs(ctx context.Context,
 
 func QueuesFromOrgs(ctx context.Context, orgsRepo repositories.OrganizationRepository,
 	execGetter repositories.ExecutorGetter, offloadingConfig infra.OffloadingConfig,
+	retentionConfig infra.RetentionConfig,
 ) (queues map[string]river.QueueConfig, periodics []*river.PeriodicJob, err error) {
 	exec_fac := executor_factory.NewDbExecutorFactory(orgsRepo, execGetter)
 	orgs, err := orgsRepo.AllOrganizations(ctx, exec_fac.NewExecutor())
@@ -159,17 +164,24 @@ func QueuesFromOrgs(ctx context.Context, orgsRepo repositories.OrganizationRepos
 	}
 
 	queues = make(map[string]river.QueueConfig, len(orgs))
-	periodics = make([]*river.PeriodicJob, 0, len(orgs)*2)
+	periodics = make([]*river.PeriodicJob, 0, len(orgs)*3)
 
 	for _, org := range orgs {
 		periodics = append(periodics, []*river.PeriodicJob{
 			scheduled_execution.NewIndexCleanupPeriodicJob(org.Id),
 			scheduled_execution.NewTestRunSummaryPeriodicJob(org.Id),
+			scheduled_execution.NewIdempotencyKeyCleanupPeriodicJob(org.Id),
+			scheduled_execution.NewChangeFeedPeriodicJob(org.Id),
+			scheduled_execution.NewCaseSlaPeriodicJob(org.Id),
+			scheduled_execution.NewNotificationPeriodicJob(org.Id),
 		}...)
 
 		if offloadingConfig.Enabled {
 			periodics = append(periodics, scheduled_execution.NewOffloadingPeriodicJob(org.Id, offloadingConfig.JobInterval))
 		}
+		if retentionConfig.Enabled {
+			periodics = append(periodics, scheduled_execution.NewRetentionPeriodicJob(org.Id, retentionConfig.JobInterval))
+		}
 
 		queues[org.Id] = river.QueueConfig{
 			MaxWorkers: numberWorkersPerQueue,
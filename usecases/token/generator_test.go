@@ -49,6 +49,7 @@ func TestGenerator_GenerateToken_APIKey(t *testing.T) {
 			OrganizationId: "organization_id",
 			Role:           models.ADMIN,
 			ActorIdentity: models.Identity{
+				ApiKeyId:   "api_key_id",
 				ApiKeyName: "Api key abc*** of organization",
 			},
 		}).
@@ -114,6 +115,7 @@ func TestGenerator_GenerateToken_APIKey(t *testing.T) {
 			OrganizationId: "organization_id",
 			Role:           models.ADMIN,
 			ActorIdentity: models.Identity{
+				ApiKeyId:   "api_key_id",
 				ApiKeyName: "Api key abc*** of organization",
 			},
 		}).
@@ -0,0 +1,114 @@
+package usecases
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/repositories"
+	"github.com/checkmarble/marble-backend/utils"
+)
+
+var supportedFileTypes = []string{
+	"text/",
+	"application/vnd.ms-excel",
+	"application/vnd.openxmlformats-officedocument.",
+	"application/msword",
+	"application/zip",
+	"application/x-zip-compressed",
+	"application/pdf",
+	"image/",
+}
+
+// Legacy office documents (.doc, .xls) are OLE compound files, which are not recognized by http.DetectContentType
+const oleContentType = "application/x-ole-storage"
+
+var oleSignature = []byte{0xD0, 0xCF, 0x11, 0xE0, 0xA1, 0xB1, 0x1A, 0xE1}
+
+func isSupportedFileType(contentType string) bool {
+	for _, supportedFileType := range supportedFileTypes {
+		if strings.HasPrefix(contentType, supportedFileType) {
+			return true
+		}
+	}
+	return false
+}
+
+// sniffContentType detects the type of a file from its first bytes. Office Open XML documents (.docx, .xlsx) are
+// detected as zip archives.
+func sniffContentType(head []byte) string {
+	if bytes.HasPrefix(head, oleSignature) {
+		return oleContentType
+	}
+	return http.DetectContentType(head)
+}
+
+// validateFileType checks both the content type declared by the client and the type detected from the content of the
+// file, so that an executable cannot be uploaded under a supported content type.
+func validateFileType(file multipart.FileHeader) error {
+	declaredType := file.Header.Get("Content-Type")
+	if !isSupportedFileType(declaredType) {
+		return errors.Wrap(models.BadParameterError,
+			fmt.Sprintf("file type not supported: %s", declaredType))
+	}
+
+	f, err := file.Open()
+	if err != nil {
+		return errors.Wrap(models.BadParameterError, err.Error())
+	}
+	defer f.Close()
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(f, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return errors.Wrap(models.BadParameterError, err.Error())
+	}
+
+	detectedType := sniffContentType(head[:n])
+	if detectedType != oleContentType && !isSupportedFileType(detectedType) {
+		return errors.Wrap(models.BadParameterError,
+			fmt.Sprintf("content of file %s is not supported: detected %s", file.Filename, detectedType))
+	}
+
+	return nil
+}
+
+// scanUploadedFile scans an uploaded file for malware before it is written to blob storage. An infected file is not
+// rejected: it is stored with a quarantined status, so that it can be investigated but not downloaded.
+func scanUploadedFile(ctx context.Context, scanner repositories.FileScanner,
+	fileHeader multipart.FileHeader,
+) (models.FileScanStatus, error) {
+	file, err := fileHeader.Open()
+	if err != nil {
+		return "", errors.Wrap(models.BadParameterError, err.Error())
+	}
+	defer file.Close()
+
+	result, err := scanner.Scan(ctx, file)
+	if err != nil {
+		return "", errors.Wrap(err, "could not scan uploaded file")
+	}
+
+	if result.Status.IsQuarantined() {
+		utils.LoggerFromContext(ctx).WarnContext(ctx, "malware found in uploaded file, the file is quarantined",
+			"file_name", fileHeader.Filename,
+			"signature", result.Signature)
+	}
+
+	return result.Status, nil
+}
+
+func errIfQuarantined(status models.FileScanStatus) error {
+	if status.IsQuarantined() {
+		return errors.Wrap(models.UnprocessableEntityError,
+			"this file is quarantined because malware was found in it, it cannot be downloaded")
+	}
+	return nil
+}
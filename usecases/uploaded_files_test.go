@@ -0,0 +1,90 @@
+package usecases
+
+import (
+	"bytes"
+	"context"
+	"mime/multipart"
+	"net/textproto"
+	"testing"
+
+	"github.com/checkmarble/marble-backend/mocks"
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestFileHeader(t *testing.T, fileName, contentType string, content []byte) multipart.FileHeader {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", `form-data; name="file"; filename="`+fileName+`"`)
+	header.Set("Content-Type", contentType)
+	part, err := writer.CreatePart(header)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := part.Write(content); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	form, err := multipart.NewReader(&body, writer.Boundary()).ReadForm(1 << 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return *form.File["file"][0]
+}
+
+func TestValidateFileType(t *testing.T) {
+	tts := []struct {
+		name        string
+		fileName    string
+		contentType string
+		content     []byte
+		valid       bool
+	}{
+		{"pdf", "report.pdf", "application/pdf", []byte("%PDF-1.7\n..."), true},
+		{"csv", "export.csv", "text/csv", []byte("id,amount\n1,100\n"), true},
+		{"png", "screenshot.png", "image/png", []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR"), true},
+		{"docx", "letter.docx", "application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+			[]byte("PK\x03\x04\x14\x00\x06\x00"), true},
+		{"xls", "sheet.xls", "application/vnd.ms-excel", append(oleSignature, 0x00, 0x00), true},
+		{"empty", "empty.txt", "text/plain", []byte{}, true},
+		{"unsupported declared type", "run.exe", "application/x-msdownload", []byte("MZ\x90\x00"), false},
+		{"executable sent as pdf", "report.pdf", "application/pdf", []byte("MZ\x90\x00\x03\x00\x00\x00\x04\x00"), false},
+		{"elf sent as image", "photo.jpg", "image/jpeg", []byte("\x7fELF\x02\x01\x01\x00\x00\x00\x00\x00"), false},
+	}
+
+	for _, tt := range tts {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateFileType(newTestFileHeader(t, tt.fileName, tt.contentType, tt.content))
+			if tt.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, models.BadParameterError)
+			}
+		})
+	}
+}
+
+func TestScanUploadedFile(t *testing.T) {
+	file := newTestFileHeader(t, "report.pdf", "application/pdf", []byte("%PDF-1.7\n..."))
+
+	scanner := new(mocks.FileScanner)
+	scanner.On("Scan", mock.Anything, mock.Anything).Return(models.FileScanResult{
+		Status:    models.FileScanQuarantined,
+		Signature: "Eicar-Test-Signature",
+	}, nil)
+
+	status, err := scanUploadedFile(context.TODO(), scanner, file)
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.FileScanQuarantined, status)
+	assert.ErrorIs(t, errIfQuarantined(status), models.UnprocessableEntityError)
+	assert.NoError(t, errIfQuarantined(models.FileScanClean))
+	assert.NoError(t, errIfQuarantined(models.FileScanNotScanned))
+	scanner.AssertExpectations(t)
+}
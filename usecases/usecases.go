@@ -1,6 +1,8 @@
 package usecases
 
 import (
+	"time"
+
 	"github.com/checkmarble/marble-backend/infra"
 	"github.com/checkmarble/marble-backend/models"
 	"github.com/checkmarble/marble-backend/models/ast"
@@ -23,8 +25,13 @@ type Usecases struct {
 	batchIngestionMaxSize       int
 	ingestionBucketUrl          string
 	caseManagerBucketUrl        string
+	marbleAppUrl                string
 	offloadingBucketUrl         string
+	decisionExportBucketUrl     string
 	offloadingConfig            infra.OffloadingConfig
+	retentionConfig             infra.RetentionConfig
+	idempotencyKeyTtl           time.Duration
+	decisionRequestDeadline     time.Duration
 	failedWebhooksRetryPageSize int
 	hasConvoyServerSetup        bool
 	hasMetabaseSetup            bool
@@ -53,18 +60,48 @@ func WithOffloadingBucketUrl(bucket string) Option {
 	}
 }
 
+func WithDecisionExportBucketUrl(bucket string) Option {
+	return func(o *options) {
+		o.decisionExportBucketUrl = bucket
+	}
+}
+
 func WithOffloading(cfg infra.OffloadingConfig) Option {
 	return func(o *options) {
 		o.offloadingConfig = cfg
 	}
 }
 
+func WithRetention(cfg infra.RetentionConfig) Option {
+	return func(o *options) {
+		o.retentionConfig = cfg
+	}
+}
+
+func WithIdempotencyKeyTtl(ttl time.Duration) Option {
+	return func(o *options) {
+		o.idempotencyKeyTtl = ttl
+	}
+}
+
+func WithDecisionRequestDeadline(deadline time.Duration) Option {
+	return func(o *options) {
+		o.decisionRequestDeadline = deadline
+	}
+}
+
 func WithCaseManagerBucketUrl(bucket string) Option {
 	return func(o *options) {
 		o.caseManagerBucketUrl = bucket
 	}
 }
 
+func WithMarbleAppUrl(url string) Option {
+	return func(o *options) {
+		o.marbleAppUrl = url
+	}
+}
+
 func WithFailedWebhooksRetryPageSize(size int) Option {
 	return func(o *options) {
 		o.failedWebhooksRetryPageSize = size
@@ -118,8 +155,13 @@ type options struct {
 	batchIngestionMaxSize       int
 	ingestionBucketUrl          string
 	caseManagerBucketUrl        string
+	marbleAppUrl                string
 	offloadingBucketUrl         string
+	decisionExportBucketUrl     string
 	offloadingConfig            infra.OffloadingConfig
+	retentionConfig             infra.RetentionConfig
+	idempotencyKeyTtl           time.Duration
+	decisionRequestDeadline     time.Duration
 	failedWebhooksRetryPageSize int
 	license                     models.LicenseValidation
 	hasConvoyServerSetup        bool
@@ -138,8 +180,13 @@ func newUsecasesWithOptions(repositories repositories.Repositories, o *options)
 		batchIngestionMaxSize:       o.batchIngestionMaxSize,
 		ingestionBucketUrl:          o.ingestionBucketUrl,
 		caseManagerBucketUrl:        o.caseManagerBucketUrl,
+		marbleAppUrl:                o.marbleAppUrl,
 		offloadingBucketUrl:         o.offloadingBucketUrl,
+		decisionExportBucketUrl:     o.decisionExportBucketUrl,
 		offloadingConfig:            o.offloadingConfig,
+		retentionConfig:             o.retentionConfig,
+		idempotencyKeyTtl:           o.idempotencyKeyTtl,
+		decisionRequestDeadline:     o.decisionRequestDeadline,
 		failedWebhooksRetryPageSize: o.failedWebhooksRetryPageSize,
 		license:                     o.license,
 		hasConvoyServerSetup:        o.hasConvoyServerSetup,
@@ -263,6 +310,10 @@ func (usecases *Usecases) AstEvaluationEnvironmentFactory(params ast_eval.Evalua
 			usecases.Repositories.OrganizationRepository,
 			params.OrganizationId))
 
+	if params.Decision != nil {
+		environment.AddEvaluator(ast.FUNC_DECISION, evaluate.DecisionAccess{Decision: *params.Decision})
+	}
+
 	return environment
 }
 
@@ -5,6 +5,7 @@ import (
 	"github.com/checkmarble/marble-backend/usecases/decision_phantom"
 	"github.com/checkmarble/marble-backend/usecases/decision_workflows"
 	"github.com/checkmarble/marble-backend/usecases/evaluate_scenario"
+	"github.com/checkmarble/marble-backend/usecases/executor_factory"
 	"github.com/checkmarble/marble-backend/usecases/feature_access"
 	"github.com/checkmarble/marble-backend/usecases/inboxes"
 	"github.com/checkmarble/marble-backend/usecases/indexes"
@@ -88,6 +89,34 @@ func (usecases *UsecasesWithCreds) NewEnforceCaseSecurity() security.EnforceSecu
 	}
 }
 
+func (usecases *UsecasesWithCreds) NewEnforceErasureSecurity() security.EnforceSecurityErasure {
+	return &security.EnforceSecurityErasureImpl{
+		EnforceSecurity: usecases.NewEnforceSecurity(),
+		Credentials:     usecases.Credentials,
+	}
+}
+
+func (usecases *UsecasesWithCreds) NewEnforceDecisionLabelSecurity() security.EnforceSecurityDecisionLabel {
+	return &security.EnforceSecurityDecisionLabelImpl{
+		EnforceSecurity: usecases.NewEnforceSecurity(),
+		Credentials:     usecases.Credentials,
+	}
+}
+
+func (usecases *UsecasesWithCreds) NewEnforceDecisionExportSecurity() security.EnforceSecurityDecisionExport {
+	return &security.EnforceSecurityDecisionExportImpl{
+		EnforceSecurity: usecases.NewEnforceSecurity(),
+		Credentials:     usecases.Credentials,
+	}
+}
+
+func (usecases *UsecasesWithCreds) NewEnforceChangeFeedSecurity() security.EnforceSecurityChangeFeed {
+	return &security.EnforceSecurityChangeFeedImpl{
+		EnforceSecurity: usecases.NewEnforceSecurity(),
+		Credentials:     usecases.Credentials,
+	}
+}
+
 func (usecases *UsecasesWithCreds) NewEnforceTagSecurity() security.EnforceSecurityTags {
 	return &security.EnforceSecurityImpl{
 		Credentials: usecases.Credentials,
@@ -109,22 +138,106 @@ func (usecases *UsecasesWithCreds) NewEnforceAnnotationSecurity() security.Enfor
 
 func (usecases *UsecasesWithCreds) NewDecisionUsecase() DecisionUsecase {
 	return DecisionUsecase{
-		enforceSecurity:           usecases.NewEnforceDecisionSecurity(),
-		enforceSecurityScenario:   usecases.NewEnforceScenarioSecurity(),
-		executorFactory:           usecases.NewExecutorFactory(),
-		transactionFactory:        usecases.NewTransactionFactory(),
-		dataModelRepository:       usecases.Repositories.MarbleDbRepository,
-		repository:                &usecases.Repositories.MarbleDbRepository,
-		sanctionCheckRepository:   &usecases.Repositories.MarbleDbRepository,
-		decisionWorkflows:         usecases.NewDecisionWorkflows(),
-		webhookEventsSender:       usecases.NewWebhookEventsUsecase(),
-		phantomUseCase:            usecases.NewPhantomDecisionUseCase(),
-		scenarioTestRunRepository: &usecases.Repositories.MarbleDbRepository,
-		scenarioEvaluator:         usecases.NewScenarioEvaluator(),
-		featureAccessReader:       usecases.NewFeatureAccessReader(),
-		openSanctionsRepository:   usecases.Repositories.OpenSanctionsRepository,
-		taskQueueRepository:       usecases.Repositories.TaskQueueRepository,
-		offloadedReader:           usecases.NewOffloadedReader(),
+		enforceSecurity:                usecases.NewEnforceDecisionSecurity(),
+		enforceSecurityScenario:        usecases.NewEnforceScenarioSecurity(),
+		executorFactory:                usecases.NewExecutorFactory(),
+		transactionFactory:             usecases.NewTransactionFactory(),
+		dataModelRepository:            usecases.Repositories.MarbleDbRepository,
+		repository:                     &usecases.Repositories.MarbleDbRepository,
+		sanctionCheckRepository:        &usecases.Repositories.MarbleDbRepository,
+		decisionWorkflows:              usecases.NewDecisionWorkflows(),
+		webhookEventsSender:            usecases.NewWebhookEventsUsecase(),
+		phantomUseCase:                 usecases.NewPhantomDecisionUseCase(),
+		scenarioTestRunRepository:      &usecases.Repositories.MarbleDbRepository,
+		scenarioEvaluator:              usecases.NewScenarioEvaluator(),
+		featureAccessReader:            usecases.NewFeatureAccessReader(),
+		openSanctionsRepository:        usecases.Repositories.OpenSanctionsRepository,
+		taskQueueRepository:            usecases.Repositories.TaskQueueRepository,
+		offloadedReader:                usecases.NewOffloadedReader(),
+		ingestedDataReadRepository:     usecases.Repositories.IngestedDataReadRepository,
+		idempotencyRepository:          &usecases.Repositories.MarbleDbRepository,
+		idempotencyKeyTtl:              usecases.idempotencyKeyTtl,
+		decisionRequestRepository:      &usecases.Repositories.MarbleDbRepository,
+		decisionRequestDeadline:        usecases.decisionRequestDeadline,
+		decisionReevaluationRepository: &usecases.Repositories.MarbleDbRepository,
+		credentials:                    usecases.Credentials,
+	}
+}
+
+func (usecases *UsecasesWithCreds) NewDecisionLabelUsecase() DecisionLabelUsecase {
+	return DecisionLabelUsecase{
+		enforceSecurity: usecases.NewEnforceDecisionLabelSecurity(),
+		executorFactory: usecases.NewExecutorFactory(),
+		repository:      &usecases.Repositories.MarbleDbRepository,
+	}
+}
+
+func (usecases *UsecasesWithCreds) NewDecisionExportUsecase() DecisionExportUsecase {
+	decisionUsecase := usecases.NewDecisionUsecase()
+	return DecisionExportUsecase{
+		enforceSecurity:     usecases.NewEnforceDecisionExportSecurity(),
+		executorFactory:     usecases.NewExecutorFactory(),
+		transactionFactory:  usecases.NewTransactionFactory(),
+		repository:          &usecases.Repositories.MarbleDbRepository,
+		taskQueueRepository: usecases.Repositories.TaskQueueRepository,
+		blobRepository:      usecases.Repositories.BlobRepository,
+		filtersValidator:    &decisionUsecase,
+		bucketUrl:           usecases.decisionExportBucketUrl,
+		credentials:         usecases.Credentials,
+	}
+}
+
+func (usecases *UsecasesWithCreds) NewCaseBulkActionUsecase() CaseBulkActionUsecase {
+	return CaseBulkActionUsecase{
+		executorFactory:     usecases.NewExecutorFactory(),
+		transactionFactory:  usecases.NewTransactionFactory(),
+		caseLister:          usecases.NewCaseUseCase(),
+		repository:          &usecases.Repositories.MarbleDbRepository,
+		taskQueueRepository: usecases.Repositories.TaskQueueRepository,
+		credentials:         usecases.Credentials,
+	}
+}
+
+func (usecases *UsecasesWithCreds) NewCaseQaReviewUsecase() CaseQaReviewUsecase {
+	return CaseQaReviewUsecase{
+		executorFactory:    usecases.NewExecutorFactory(),
+		transactionFactory: usecases.NewTransactionFactory(),
+		enforceSecurity: security.EnforceSecurityInboxes{
+			EnforceSecurity: usecases.NewEnforceSecurity(),
+			Credentials:     usecases.Credentials,
+		},
+		repository:  &usecases.Repositories.MarbleDbRepository,
+		credentials: usecases.Credentials,
+	}
+}
+
+func (usecases *UsecasesWithCreds) NewCaseExportUsecase() CaseExportUsecase {
+	return CaseExportUsecase{
+		executorFactory:     usecases.NewExecutorFactory(),
+		transactionFactory:  usecases.NewTransactionFactory(),
+		caseReader:          usecases.NewCaseUseCase(),
+		repository:          &usecases.Repositories.MarbleDbRepository,
+		taskQueueRepository: usecases.Repositories.TaskQueueRepository,
+		blobRepository:      usecases.Repositories.BlobRepository,
+		bucketUrl:           usecases.caseManagerBucketUrl,
+		credentials:         usecases.Credentials,
+	}
+}
+
+func (usecases *UsecasesWithCreds) NewNotificationUsecase() NotificationUsecase {
+	return NotificationUsecase{
+		executorFactory:    usecases.NewExecutorFactory(),
+		transactionFactory: usecases.NewTransactionFactory(),
+		repository:         &usecases.Repositories.MarbleDbRepository,
+		credentials:        usecases.Credentials,
+	}
+}
+
+func (usecases *UsecasesWithCreds) NewChangeFeedUsecase() ChangeFeedUsecase {
+	return ChangeFeedUsecase{
+		enforceSecurity: usecases.NewEnforceChangeFeedSecurity(),
+		executorFactory: usecases.NewExecutorFactory(),
+		repository:      &usecases.Repositories.MarbleDbRepository,
 	}
 }
 
@@ -180,6 +293,7 @@ func (usecases *UsecasesWithCreds) NewSanctionCheckUsecase() SanctionCheckUsecas
 		taskQueueRepository:           usecases.Repositories.TaskQueueRepository,
 		repository:                    &usecases.Repositories.MarbleDbRepository,
 		blobRepository:                usecases.Repositories.BlobRepository,
+		fileScanner:                   usecases.Repositories.FileScanner,
 		blobBucketUrl:                 usecases.caseManagerBucketUrl,
 		executorFactory:               usecases.NewExecutorFactory(),
 		transactionFactory:            usecases.NewTransactionFactory(),
@@ -187,22 +301,25 @@ func (usecases *UsecasesWithCreds) NewSanctionCheckUsecase() SanctionCheckUsecas
 }
 
 func (usecases *UsecasesWithCreds) NewDecisionWorkflows() decision_workflows.DecisionsWorkflows {
+	scenarioEvaluator := usecases.NewScenarioEvaluator()
 	return decision_workflows.NewDecisionWorkflows(
 		usecases.NewCaseUseCase(),
 		&usecases.Repositories.MarbleDbRepository,
 		usecases.NewWebhookEventsUsecase(),
-		usecases.NewScenarioEvaluator(),
+		scenarioEvaluator,
+		scenarioEvaluator,
 	)
 }
 
 func (usecases *UsecasesWithCreds) NewScenarioUsecase() ScenarioUsecase {
 	return ScenarioUsecase{
-		transactionFactory:  usecases.NewTransactionFactory(),
-		scenarioFetcher:     usecases.NewScenarioFetcher(),
-		validateScenarioAst: usecases.NewValidateScenarioAst(),
-		executorFactory:     usecases.NewExecutorFactory(),
-		enforceSecurity:     usecases.NewEnforceScenarioSecurity(),
-		repository:          &usecases.Repositories.MarbleDbRepository,
+		transactionFactory:    usecases.NewTransactionFactory(),
+		scenarioFetcher:       usecases.NewScenarioFetcher(),
+		validateScenarioAst:   usecases.NewValidateScenarioAst(),
+		executorFactory:       usecases.NewExecutorFactory(),
+		enforceSecurity:       usecases.NewEnforceScenarioSecurity(),
+		repository:            &usecases.Repositories.MarbleDbRepository,
+		caseRoutingRepository: &usecases.Repositories.MarbleDbRepository,
 	}
 }
 
@@ -297,6 +414,7 @@ func (usecases *UsecasesWithCreds) NewDataModelUseCase() DataModelUseCase {
 }
 
 func (usecases *UsecasesWithCreds) NewIngestionUseCase() IngestionUseCase {
+	decisionUsecase := usecases.NewDecisionUsecase()
 	return IngestionUseCase{
 		enforceSecurity:       usecases.NewEnforceIngestionSecurity(),
 		transactionFactory:    usecases.NewTransactionFactory(),
@@ -305,6 +423,8 @@ func (usecases *UsecasesWithCreds) NewIngestionUseCase() IngestionUseCase {
 		blobRepository:        usecases.Repositories.BlobRepository,
 		dataModelRepository:   usecases.Repositories.MarbleDbRepository,
 		uploadLogRepository:   usecases.Repositories.UploadLogRepository,
+		decisionUsecase:       &decisionUsecase,
+		taskQueueRepository:   usecases.Repositories.TaskQueueRepository,
 		ingestionBucketUrl:    usecases.ingestionBucketUrl,
 		batchIngestionMaxSize: usecases.Usecases.batchIngestionMaxSize,
 	}
@@ -355,30 +475,34 @@ func (usecases *UsecasesWithCreds) NewInboxReader() inboxes.InboxReader {
 
 func (usecases *UsecasesWithCreds) NewCaseUseCase() *CaseUseCase {
 	return &CaseUseCase{
-		enforceSecurity:         usecases.NewEnforceCaseSecurity(),
-		transactionFactory:      usecases.NewTransactionFactory(),
-		executorFactory:         usecases.NewExecutorFactory(),
-		repository:              &usecases.Repositories.MarbleDbRepository,
-		decisionRepository:      &usecases.Repositories.MarbleDbRepository,
-		inboxReader:             usecases.NewInboxReader(),
-		caseManagerBucketUrl:    usecases.caseManagerBucketUrl,
-		blobRepository:          usecases.Repositories.BlobRepository,
-		userRepository:          usecases.Repositories.UserRepository,
-		webhookEventsUsecase:    usecases.NewWebhookEventsUsecase(),
-		sanctionCheckRepository: &usecases.Repositories.MarbleDbRepository,
-		ingestedDataReader:      usecases.NewIngestedDataReaderUsecase(),
+		enforceSecurity:          usecases.NewEnforceCaseSecurity(),
+		transactionFactory:       usecases.NewTransactionFactory(),
+		executorFactory:          usecases.NewExecutorFactory(),
+		repository:               &usecases.Repositories.MarbleDbRepository,
+		decisionRepository:       &usecases.Repositories.MarbleDbRepository,
+		inboxReader:              usecases.NewInboxReader(),
+		caseManagerBucketUrl:     usecases.caseManagerBucketUrl,
+		blobRepository:           usecases.Repositories.BlobRepository,
+		fileScanner:              usecases.Repositories.FileScanner,
+		userRepository:           usecases.Repositories.UserRepository,
+		webhookEventsUsecase:     usecases.NewWebhookEventsUsecase(),
+		sanctionCheckRepository:  &usecases.Repositories.MarbleDbRepository,
+		ingestedDataReader:       usecases.NewIngestedDataReaderUsecase(),
+		caseAssignmentRepository: &usecases.Repositories.MarbleDbRepository,
 	}
 }
 
 func (usecases *UsecasesWithCreds) NewSuspiciousActivityReportUsecase() *SuspiciousActivityReportUsecase {
 	return &SuspiciousActivityReportUsecase{
-		executorFactory:      usecases.NewExecutorFactory(),
-		transactionFactory:   usecases.NewTransactionFactory(),
-		enforceCaseSecurity:  usecases.NewEnforceCaseSecurity(),
-		caseUsecase:          usecases.NewCaseUseCase(),
-		repository:           &usecases.Repositories.MarbleDbRepository,
-		blobRepository:       usecases.NewCaseUseCase().blobRepository,
-		caseManagerBucketUrl: usecases.caseManagerBucketUrl,
+		executorFactory:        usecases.NewExecutorFactory(),
+		transactionFactory:     usecases.NewTransactionFactory(),
+		enforceCaseSecurity:    usecases.NewEnforceCaseSecurity(),
+		caseUsecase:            usecases.NewCaseUseCase(),
+		repository:             &usecases.Repositories.MarbleDbRepository,
+		organizationRepository: usecases.Repositories.OrganizationRepository,
+		blobRepository:         usecases.NewCaseUseCase().blobRepository,
+		fileScanner:            usecases.Repositories.FileScanner,
+		caseManagerBucketUrl:   usecases.caseManagerBucketUrl,
 	}
 }
 
@@ -389,13 +513,16 @@ func (usecases *UsecasesWithCreds) NewInboxUsecase() InboxUsecase {
 	}
 	executorFactory := usecases.NewExecutorFactory()
 	return InboxUsecase{
-		enforceSecurity:    sec,
-		inboxRepository:    &usecases.Repositories.MarbleDbRepository,
-		userRepository:     usecases.Repositories.UserRepository,
-		credentials:        usecases.Credentials,
-		transactionFactory: usecases.NewTransactionFactory(),
-		executorFactory:    executorFactory,
-		inboxReader:        usecases.NewInboxReader(),
+		enforceSecurity:        sec,
+		inboxRepository:        &usecases.Repositories.MarbleDbRepository,
+		userRepository:         usecases.Repositories.UserRepository,
+		credentials:            usecases.Credentials,
+		slaPolicyRepository:    &usecases.Repositories.MarbleDbRepository,
+		caseWorkflowRepository: &usecases.Repositories.MarbleDbRepository,
+		qaRuleRepository:       &usecases.Repositories.MarbleDbRepository,
+		transactionFactory:     usecases.NewTransactionFactory(),
+		executorFactory:        executorFactory,
+		inboxReader:            usecases.NewInboxReader(),
 		inboxUsers: inboxes.InboxUsers{
 			EnforceSecurity:     sec,
 			InboxUserRepository: &usecases.Repositories.MarbleDbRepository,
@@ -416,6 +543,30 @@ func (usecases *UsecasesWithCreds) NewTagUseCase() TagUseCase {
 	}
 }
 
+func (usecases *UsecasesWithCreds) NewRetentionPolicyUsecase() RetentionPolicyUsecase {
+	return RetentionPolicyUsecase{
+		enforceSecurity:    usecases.NewEnforceOrganizationSecurity(),
+		executorFactory:    usecases.NewExecutorFactory(),
+		repository:         &usecases.Repositories.MarbleDbRepository,
+		clientDbRepository: &usecases.Repositories.ClientDbRepository,
+		config:             usecases.retentionConfig,
+	}
+}
+
+func (usecases *UsecasesWithCreds) NewErasureUsecase() ErasureUsecase {
+	return ErasureUsecase{
+		enforceSecurity:     usecases.NewEnforceErasureSecurity(),
+		executorFactory:     usecases.NewExecutorFactory(),
+		transactionFactory:  usecases.NewTransactionFactory(),
+		repository:          &usecases.Repositories.MarbleDbRepository,
+		clientDbRepository:  &usecases.Repositories.ClientDbRepository,
+		taskQueueRepository: usecases.Repositories.TaskQueueRepository,
+		blobRepository:      usecases.Repositories.BlobRepository,
+		offloadingBucketUrl: usecases.offloadingBucketUrl,
+		credentials:         usecases.Credentials,
+	}
+}
+
 func (usecases *UsecasesWithCreds) NewApiKeyUseCase() ApiKeyUseCase {
 	return ApiKeyUseCase{
 		executorFactory: usecases.NewExecutorFactory(),
@@ -437,6 +588,16 @@ func (usecases *UsecasesWithCreds) NewAnalyticsUseCase() AnalyticsUseCase {
 	}
 }
 
+func (usecases *UsecasesWithCreds) NewCaseMetricsUsecase() CaseMetricsUsecase {
+	inboxReader := usecases.NewInboxReader()
+	return CaseMetricsUsecase{
+		executorFactory: usecases.NewExecutorFactory(),
+		enforceSecurity: usecases.NewEnforceSecurity(),
+		inboxReader:     &inboxReader,
+		repository:      &usecases.Repositories.MarbleDbRepository,
+	}
+}
+
 func (usecases *UsecasesWithCreds) NewTransferCheckUsecase() TransferCheckUsecase {
 	return TransferCheckUsecase{
 		dataModelRepository:               usecases.Repositories.MarbleDbRepository,
@@ -579,6 +740,117 @@ func (usecases UsecasesWithCreds) NewIndexCleanupWorker() *scheduled_execution.I
 	return &w
 }
 
+func (usecases UsecasesWithCreds) NewIdempotencyKeyCleanupWorker() *scheduled_execution.IdempotencyKeyCleanupWorker {
+	w := scheduled_execution.NewIdempotencyKeyCleanupWorker(
+		usecases.NewExecutorFactory(),
+		&usecases.Repositories.MarbleDbRepository,
+	)
+	return &w
+}
+
+func (usecases UsecasesWithCreds) NewDecisionExportWorker() *scheduled_execution.DecisionExportWorker {
+	w := scheduled_execution.NewDecisionExportWorker(
+		usecases.NewExecutorFactory(),
+		&usecases.Repositories.MarbleDbRepository,
+		usecases.Repositories.BlobRepository,
+		usecases.decisionExportBucketUrl,
+	)
+	return &w
+}
+
+func (usecases UsecasesWithCreds) NewCaseExportWorker() *scheduled_execution.CaseExportWorker {
+	w := scheduled_execution.NewCaseExportWorker(
+		usecases.NewExecutorFactory(),
+		&usecases.Repositories.MarbleDbRepository,
+		usecases.Repositories.BlobRepository,
+		usecases.caseManagerBucketUrl,
+	)
+	return &w
+}
+
+// NewCaseBulkActionWorker performs every bulk action with the case usecase of the user who requested it
+func (usecases UsecasesWithCreds) NewCaseBulkActionWorker() *scheduled_execution.CaseBulkActionWorker {
+	w := scheduled_execution.NewCaseBulkActionWorker(
+		usecases.NewExecutorFactory(),
+		usecases.NewTransactionFactory(),
+		&usecases.Repositories.MarbleDbRepository,
+		usecases.Repositories.UserRepository,
+		func(creds models.Credentials,
+			transactionFactory executor_factory.TransactionFactory,
+		) scheduled_execution.CaseBulkActionCaseUsecase {
+			userUsecases := UsecasesWithCreds{Usecases: usecases.Usecases, Credentials: creds}
+			caseUsecase := userUsecases.NewCaseUseCase()
+			caseUsecase.transactionFactory = transactionFactory
+			return caseUsecase
+		},
+	)
+	return &w
+}
+
+func (usecases UsecasesWithCreds) NewDecisionRequestWorker() *scheduled_execution.DecisionRequestWorker {
+	decisionUsecase := usecases.NewDecisionUsecase()
+	w := scheduled_execution.NewDecisionRequestWorker(
+		usecases.NewExecutorFactory(),
+		usecases.NewTransactionFactory(),
+		&usecases.Repositories.MarbleDbRepository,
+		&decisionUsecase,
+		usecases.NewWebhookEventsUsecase(),
+	)
+	return &w
+}
+
+func (usecases UsecasesWithCreds) NewCaseSlaWorker() *scheduled_execution.CaseSlaWorker {
+	w := scheduled_execution.NewCaseSlaWorker(
+		usecases.NewExecutorFactory(),
+		usecases.NewTransactionFactory(),
+		&usecases.Repositories.MarbleDbRepository,
+		usecases.NewCaseUseCase(),
+		usecases.NewWebhookEventsUsecase(),
+	)
+	return &w
+}
+
+func (usecases UsecasesWithCreds) NewNotificationWorker() *scheduled_execution.NotificationWorker {
+	w := scheduled_execution.NewNotificationWorker(
+		usecases.NewExecutorFactory(),
+		usecases.NewTransactionFactory(),
+		&usecases.Repositories.MarbleDbRepository,
+		usecases.Repositories.UserRepository,
+		usecases.Repositories.TaskQueueRepository,
+		usecases.Repositories.EmailSender.Enabled(),
+	)
+	return &w
+}
+
+func (usecases UsecasesWithCreds) NewNotificationEmailWorker() *scheduled_execution.NotificationEmailWorker {
+	w := scheduled_execution.NewNotificationEmailWorker(
+		usecases.NewExecutorFactory(),
+		usecases.Repositories.UserRepository,
+		usecases.Repositories.EmailSender,
+		usecases.marbleAppUrl,
+	)
+	return &w
+}
+
+func (usecases UsecasesWithCreds) NewDecisionReevaluationBatchWorker() *scheduled_execution.DecisionReevaluationBatchWorker {
+	decisionUsecase := usecases.NewDecisionUsecase()
+	w := scheduled_execution.NewDecisionReevaluationBatchWorker(
+		usecases.NewExecutorFactory(),
+		&usecases.Repositories.MarbleDbRepository,
+		&decisionUsecase,
+	)
+	return &w
+}
+
+func (usecases UsecasesWithCreds) NewChangeFeedWorker() *scheduled_execution.ChangeFeedWorker {
+	w := scheduled_execution.NewChangeFeedWorker(
+		usecases.NewExecutorFactory(),
+		&usecases.Repositories.MarbleDbRepository,
+		usecases.Repositories.ChangeFeedSinkRepository,
+	)
+	return &w
+}
+
 func (usecases UsecasesWithCreds) NewTestRunSummaryWorker() *scheduled_execution.TestRunSummaryWorker {
 	w := scheduled_execution.NewTestRunSummaryWorker(
 		usecases.NewExecutorFactory(),
@@ -598,6 +870,17 @@ func (usecases UsecasesWithCreds) NewMatchEnrichmentWorker() *scheduled_executio
 	return &w
 }
 
+func (usecases UsecasesWithCreds) NewIngestedObjectsDecisionWorker() *scheduled_execution.IngestedObjectsDecisionWorker {
+	decisionUsecase := usecases.NewDecisionUsecase()
+	w := scheduled_execution.NewIngestedObjectsDecisionWorker(&decisionUsecase)
+	return &w
+}
+
+func (usecases UsecasesWithCreds) NewBlobDeletionWorker() *scheduled_execution.BlobDeletionWorker {
+	w := scheduled_execution.NewBlobDeletionWorker(usecases.Repositories.BlobRepository)
+	return &w
+}
+
 func (usecases UsecasesWithCreds) NewOffloadingWorker() *scheduled_execution.OffloadingWorker {
 	return scheduled_execution.NewOffloadingWorker(
 		usecases.NewExecutorFactory(),
@@ -609,6 +892,18 @@ func (usecases UsecasesWithCreds) NewOffloadingWorker() *scheduled_execution.Off
 	)
 }
 
+func (usecases UsecasesWithCreds) NewRetentionWorker() *scheduled_execution.RetentionWorker {
+	return scheduled_execution.NewRetentionWorker(
+		usecases.NewExecutorFactory(),
+		usecases.NewTransactionFactory(),
+		&usecases.Repositories.MarbleDbRepository,
+		&usecases.Repositories.ClientDbRepository,
+		usecases.Repositories.BlobRepository,
+		usecases.offloadingBucketUrl,
+		usecases.retentionConfig,
+	)
+}
+
 func (usecases UsecasesWithCreds) NewIngestedDataReaderUsecase() IngestedDataReaderUsecase {
 	return NewIngestedDataReaderUsecase(
 		usecases.Repositories.IngestedDataReadRepository,
@@ -652,6 +947,7 @@ func (usecases *UsecasesWithCreds) NewEntityAnnotationUsecase() EntityAnnotation
 		ingestedDataReadRepository: usecases.Repositories.IngestedDataReadRepository,
 		tagRepository:              &usecases.Repositories.MarbleDbRepository,
 		blobRepository:             usecases.Repositories.BlobRepository,
+		fileScanner:                usecases.Repositories.FileScanner,
 		bucketUrl:                  usecases.caseManagerBucketUrl,
 		executorFactory:            usecases.NewExecutorFactory(),
 		transactionFactory:         usecases.NewTransactionFactory(),